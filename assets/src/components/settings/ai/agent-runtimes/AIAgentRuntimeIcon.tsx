@@ -40,4 +40,6 @@ export const runtimeToIcon = {
   [AgentRuntimeType.Custom]: AiSparkleFilledIcon,
   [AgentRuntimeType.Codex]: OpenAILogoIcon,
   [AgentRuntimeType.Pi]: PiLogoIcon,
+  [AgentRuntimeType.Aider]: AiSparkleFilledIcon,
+  [AgentRuntimeType.Goose]: AiSparkleFilledIcon,
 } as const satisfies Record<AgentRuntimeType, ComponentType<IconProps>>
@@ -28,6 +28,7 @@ export function StackTypeIcon({
         />
       )
     case StackType.Terraform:
+    case StackType.Cdktf:
       return (
         <TerraformLogoIcon
           size={size}
@@ -6,11 +6,15 @@ export const STACK_TYPE_LABELS: Record<StackType, string> = {
   [StackType.Terraform]: 'Terraform',
   [StackType.Terragrunt]: 'Terragrunt',
   [StackType.Pulumi]: 'Pulumi',
+  [StackType.Opentofu]: 'OpenTofu',
+  [StackType.Cdktf]: 'CDKTF',
 }
 
 const TERRAFORM_FAMILY_STACK_TYPES = new Set<StackType>([
   StackType.Terraform,
   StackType.Terragrunt,
+  StackType.Opentofu,
+  StackType.Cdktf,
 ])
 
 export function stackTypeLabel(type: Nullable<StackType>): string {
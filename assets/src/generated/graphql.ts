@@ -762,10 +762,12 @@ export type AgentRuntimeEdge = {
 };
 
 export enum AgentRuntimeType {
+  Aider = 'AIDER',
   Claude = 'CLAUDE',
   Codex = 'CODEX',
   Custom = 'CUSTOM',
   Gemini = 'GEMINI',
+  Goose = 'GOOSE',
   Opencode = 'OPENCODE',
   Pi = 'PI'
 }
@@ -948,6 +950,21 @@ export type AiApprovalConfiguration = {
   ignoreCancel?: Maybe<Scalars['Boolean']['output']>;
 };
 
+export type AiBlockedTopic = {
+  __typename?: 'AiBlockedTopic';
+  /** the name of the topic, reported when a prompt is rejected */
+  name: Scalars['String']['output'];
+  /** case-insensitive regexes matching prompts about this topic */
+  patterns?: Maybe<Array<Maybe<Scalars['String']['output']>>>;
+};
+
+export type AiBlockedTopicAttributes = {
+  /** the name of the topic, reported when a prompt is rejected */
+  name: Scalars['String']['input'];
+  /** case-insensitive regexes matching prompts about this topic */
+  patterns?: InputMaybe<Array<InputMaybe<Scalars['String']['input']>>>;
+};
+
 /** configuration for AI based service promotion */
 export type AiCriteriaAttributes = {
   /** whether AI based service promotion is enabled for this promotion */
@@ -969,6 +986,42 @@ export type AiDelta = {
   tool?: Maybe<ToolDelta>;
 };
 
+export type AiGuardrailsAttributes = {
+  /** topics that cause a prompt to be rejected */
+  blockedTopics?: InputMaybe<Array<InputMaybe<AiBlockedTopicAttributes>>>;
+  /** whether to redact credentials like cloud keys, private keys and kubernetes tokens */
+  detectSecrets?: InputMaybe<Scalars['Boolean']['input']>;
+  /** whether guardrails are enforced on AI proxy requests */
+  enabled?: InputMaybe<Scalars['Boolean']['input']>;
+  /** the maximum number of characters allowed in a prompt */
+  maxPromptSize?: InputMaybe<Scalars['Int']['input']>;
+  /** custom regexes for PII that should be redacted */
+  piiPatterns?: InputMaybe<Array<InputMaybe<AiPiiPatternAttributes>>>;
+  /** a dictionary of terms that are always redacted */
+  piiTerms?: InputMaybe<Array<InputMaybe<Scalars['String']['input']>>>;
+  /** whether to replace PII in prompts with reversible placeholders */
+  redactPii?: InputMaybe<Scalars['Boolean']['input']>;
+};
+
+/** Prompt guardrails enforced by the AI proxy before and after provider calls */
+export type AiGuardrailsSettings = {
+  __typename?: 'AiGuardrailsSettings';
+  /** topics that cause a prompt to be rejected */
+  blockedTopics?: Maybe<Array<Maybe<AiBlockedTopic>>>;
+  /** whether to redact credentials like cloud keys, private keys and kubernetes tokens */
+  detectSecrets?: Maybe<Scalars['Boolean']['output']>;
+  /** whether guardrails are enforced on AI proxy requests */
+  enabled?: Maybe<Scalars['Boolean']['output']>;
+  /** the maximum number of characters allowed in a prompt */
+  maxPromptSize?: Maybe<Scalars['Int']['output']>;
+  /** custom regexes for PII that should be redacted */
+  piiPatterns?: Maybe<Array<Maybe<AiPiiPattern>>>;
+  /** a dictionary of terms that are always redacted */
+  piiTerms?: Maybe<Array<Maybe<Scalars['String']['output']>>>;
+  /** whether to replace PII in prompts with reversible placeholders */
+  redactPii?: Maybe<Scalars['Boolean']['output']>;
+};
+
 /** A representation of a LLM-derived insight */
 export type AiInsight = {
   __typename?: 'AiInsight';
@@ -1007,6 +1060,21 @@ export type AiInsightEvidence = {
   updatedAt?: Maybe<Scalars['DateTime']['output']>;
 };
 
+export type AiPiiPattern = {
+  __typename?: 'AiPiiPattern';
+  /** the name of the placeholder used for redacted matches */
+  name: Scalars['String']['output'];
+  /** the regex matching this kind of PII */
+  pattern: Scalars['String']['output'];
+};
+
+export type AiPiiPatternAttributes = {
+  /** the name of the placeholder used for redacted matches */
+  name: Scalars['String']['input'];
+  /** the regex matching this kind of PII */
+  pattern: Scalars['String']['input'];
+};
+
 /** A saved item for future ai-based investigation */
 export type AiPin = {
   __typename?: 'AiPin';
@@ -1093,6 +1161,8 @@ export type AiSettings = {
   /** ai provider to use with embeddings (for vector indexing) */
   embeddingProvider?: Maybe<AiProvider>;
   enabled?: Maybe<Scalars['Boolean']['output']>;
+  /** prompt guardrails enforced by the AI proxy */
+  guardrails?: Maybe<AiGuardrailsSettings>;
   /** whether to enable log analysis in AI insights (turn off to save on log query costs) */
   logAnalysis?: Maybe<Scalars['Boolean']['output']>;
   ollama?: Maybe<OllamaSettings>;
@@ -1121,6 +1191,8 @@ export type AiSettingsAttributes = {
   embeddingProvider?: InputMaybe<AiProvider>;
   enabled?: InputMaybe<Scalars['Boolean']['input']>;
   graph?: InputMaybe<GraphStoreAttributes>;
+  /** prompt guardrails enforced by the AI proxy */
+  guardrails?: InputMaybe<AiGuardrailsAttributes>;
   /** whether to enable log analysis in AI insights (turn off to save on log query costs) */
   logAnalysis?: InputMaybe<Scalars['Boolean']['input']>;
   ollama?: InputMaybe<OllamaAttributes>;
@@ -3546,6 +3618,8 @@ export type ComponentContent = {
   __typename?: 'ComponentContent';
   /** the inferred desired state of this component */
   desired?: Maybe<Scalars['String']['output']>;
+  /** a json encoded structured diff between the live and desired states of this component */
+  diff?: Maybe<Scalars['String']['output']>;
   id: Scalars['ID']['output'];
   insertedAt?: Maybe<Scalars['DateTime']['output']>;
   live?: Maybe<Scalars['String']['output']>;
@@ -3556,6 +3630,8 @@ export type ComponentContent = {
 export type ComponentContentAttributes = {
   /** the desired state of a service component as determined from the configured manifests */
   desired?: InputMaybe<Scalars['String']['input']>;
+  /** a json encoded structured diff between the live and desired states of a service component */
+  diff?: InputMaybe<Scalars['String']['input']>;
   live?: InputMaybe<Scalars['String']['input']>;
 };
 
@@ -3812,6 +3888,22 @@ export type CostAttributes = {
   storageCost?: InputMaybe<Scalars['Float']['input']>;
 };
 
+export type CostEstimationAttributes = {
+  /** the monthly cost increase above which a run requires manual approval */
+  approvalThreshold?: InputMaybe<Scalars['Float']['input']>;
+  /** whether to price planned changes after the plan stage */
+  enabled: Scalars['Boolean']['input'];
+};
+
+/** Configuration for estimating the cost of planned changes in a stack run */
+export type CostEstimationConfiguration = {
+  __typename?: 'CostEstimationConfiguration';
+  /** the monthly cost increase above which a run requires manual approval */
+  approvalThreshold?: Maybe<Scalars['Float']['output']>;
+  /** whether to price planned changes after the plan stage */
+  enabled: Scalars['Boolean']['output'];
+};
+
 export type CostIngestAttributes = {
   cluster?: InputMaybe<CostAttributes>;
   namespaces?: InputMaybe<Array<InputMaybe<CostAttributes>>>;
@@ -8152,6 +8244,8 @@ export type PolicyEngineAttributes = {
 };
 
 export enum PolicyEngineType {
+  Checkov = 'CHECKOV',
+  Conftest = 'CONFTEST',
   Trivy = 'TRIVY'
 }
 
@@ -9138,7 +9232,10 @@ export type RendererAttributes = {
 
 export enum RendererType {
   Auto = 'AUTO',
+  Cue = 'CUE',
   Helm = 'HELM',
+  Jsonnet = 'JSONNET',
+  Kcl = 'KCL',
   Kustomize = 'KUSTOMIZE',
   Raw = 'RAW'
 }
@@ -14687,6 +14784,8 @@ export type StackConfiguration = {
   aiApproval?: Maybe<AiApprovalConfiguration>;
   /** the ansible configuration for this stack */
   ansible?: Maybe<AnsibleConfiguration>;
+  /** the cost estimation configuration for this stack */
+  costEstimation?: Maybe<CostEstimationConfiguration>;
   /** the hooks to customize execution for this stack */
   hooks?: Maybe<Array<Maybe<StackHook>>>;
   /** optional custom image you might want to use */
@@ -14708,6 +14807,8 @@ export type StackConfigurationAttributes = {
   aiApproval?: InputMaybe<AiApprovalAttributes>;
   /** the ansible configuration for this stack */
   ansible?: InputMaybe<AnsibleConfigurationAttributes>;
+  /** the cost estimation configuration for this stack */
+  costEstimation?: InputMaybe<CostEstimationAttributes>;
   /** the hooks to customize execution for this stack */
   hooks?: InputMaybe<Array<InputMaybe<StackHookAttributes>>>;
   /** optional custom image you might want to use */
@@ -15130,7 +15231,9 @@ export enum StackStatus {
 
 export enum StackType {
   Ansible = 'ANSIBLE',
+  Cdktf = 'CDKTF',
   Custom = 'CUSTOM',
+  Opentofu = 'OPENTOFU',
   Pulumi = 'PULUMI',
   Terraform = 'TERRAFORM',
   Terragrunt = 'TERRAGRUNT'
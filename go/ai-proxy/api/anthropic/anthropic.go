@@ -0,0 +1,509 @@
+package anthropic
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	ollamaapi "github.com/ollama/ollama/api"
+	"github.com/ollama/ollama/openai"
+	"github.com/samber/lo"
+)
+
+type Endpoint string
+
+const (
+	EndpointMessages = "/v1/messages"
+
+	HeaderAPIKey  = "x-api-key"
+	HeaderVersion = "anthropic-version"
+
+	// APIVersion is the Messages API version sent with every request.
+	APIVersion = "2023-06-01"
+
+	// DefaultMaxTokens is used when the caller does not set max_tokens, as it is
+	// required by the Messages API.
+	DefaultMaxTokens = 4096
+)
+
+const (
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+	RoleSystem    = "system"
+	RoleTool      = "tool"
+
+	ContentTypeText       = "text"
+	ContentTypeImage      = "image"
+	ContentTypeToolUse    = "tool_use"
+	ContentTypeToolResult = "tool_result"
+
+	DeltaTypeText      = "text_delta"
+	DeltaTypeInputJSON = "input_json_delta"
+
+	StopReasonEndTurn      = "end_turn"
+	StopReasonMaxTokens    = "max_tokens"
+	StopReasonStopSequence = "stop_sequence"
+	StopReasonToolUse      = "tool_use"
+)
+
+type EventType string
+
+const (
+	EventMessageStart      EventType = "message_start"
+	EventMessageDelta      EventType = "message_delta"
+	EventMessageStop       EventType = "message_stop"
+	EventContentBlockStart EventType = "content_block_start"
+	EventContentBlockDelta EventType = "content_block_delta"
+	EventContentBlockStop  EventType = "content_block_stop"
+	EventPing              EventType = "ping"
+	EventError             EventType = "error"
+)
+
+type MessagesRequest struct {
+	Model         string      `json:"model"`
+	System        string      `json:"system,omitempty"`
+	Messages      []Message   `json:"messages"`
+	MaxTokens     int         `json:"max_tokens"`
+	Stream        bool        `json:"stream,omitempty"`
+	Temperature   *float64    `json:"temperature,omitempty"`
+	TopP          *float64    `json:"top_p,omitempty"`
+	StopSequences []string    `json:"stop_sequences,omitempty"`
+	Tools         []Tool      `json:"tools,omitempty"`
+	ToolChoice    *ToolChoice `json:"tool_choice,omitempty"`
+}
+
+type Message struct {
+	Role    string         `json:"role"`
+	Content []ContentBlock `json:"content"`
+}
+
+type ContentBlock struct {
+	Type      string          `json:"type"`
+	Text      string          `json:"text,omitempty"`
+	Source    *ImageSource    `json:"source,omitempty"`
+	ID        string          `json:"id,omitempty"`
+	Name      string          `json:"name,omitempty"`
+	Input     json.RawMessage `json:"input,omitempty"`
+	ToolUseID string          `json:"tool_use_id,omitempty"`
+	Content   string          `json:"content,omitempty"`
+}
+
+type ImageSource struct {
+	Type      string `json:"type"`
+	MediaType string `json:"media_type"`
+	Data      string `json:"data"`
+}
+
+type Tool struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	InputSchema any    `json:"input_schema"`
+}
+
+type ToolChoice struct {
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
+}
+
+type MessagesResponse struct {
+	ID         string         `json:"id"`
+	Type       string         `json:"type"`
+	Role       string         `json:"role"`
+	Model      string         `json:"model"`
+	Content    []ContentBlock `json:"content"`
+	StopReason string         `json:"stop_reason,omitempty"`
+	Usage      Usage          `json:"usage"`
+}
+
+type Usage struct {
+	InputTokens              int `json:"input_tokens"`
+	OutputTokens             int `json:"output_tokens"`
+	CacheCreationInputTokens int `json:"cache_creation_input_tokens,omitempty"`
+	CacheReadInputTokens     int `json:"cache_read_input_tokens,omitempty"`
+}
+
+// PromptTokens returns the total number of input tokens, including the cached ones
+// that the Messages API reports separately.
+func (in Usage) PromptTokens() int {
+	return in.InputTokens + in.CacheCreationInputTokens + in.CacheReadInputTokens
+}
+
+type StreamEvent struct {
+	Type         EventType         `json:"type"`
+	Index        int               `json:"index"`
+	Message      *MessagesResponse `json:"message,omitempty"`
+	ContentBlock *ContentBlock     `json:"content_block,omitempty"`
+	Delta        *StreamDelta      `json:"delta,omitempty"`
+	Usage        *Usage            `json:"usage,omitempty"`
+	Error        *Error            `json:"error,omitempty"`
+}
+
+type StreamDelta struct {
+	Type        string `json:"type,omitempty"`
+	Text        string `json:"text,omitempty"`
+	PartialJSON string `json:"partial_json,omitempty"`
+	StopReason  string `json:"stop_reason,omitempty"`
+}
+
+type Error struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+type ErrorResponse struct {
+	Type  string `json:"type"`
+	Error Error  `json:"error"`
+}
+
+// FromChatCompletionRequest maps OpenAI chat completion request to the Messages API request.
+// System messages are lifted to the top-level system prompt, tool results are sent back as
+// user tool_result blocks and consecutive messages with the same role are merged, as required
+// by the Messages API.
+func FromChatCompletionRequest(in openai.ChatCompletionRequest) (MessagesRequest, error) {
+	result := MessagesRequest{
+		Model:       in.Model,
+		MaxTokens:   lo.FromPtrOr(in.MaxTokens, DefaultMaxTokens),
+		Stream:      in.Stream,
+		Temperature: in.Temperature,
+		TopP:        in.TopP,
+		Tools:       toTools(in.Tools),
+	}
+
+	switch stop := in.Stop.(type) {
+	case string:
+		result.StopSequences = []string{stop}
+	case []any:
+		for _, s := range stop {
+			if str, ok := s.(string); ok {
+				result.StopSequences = append(result.StopSequences, str)
+			}
+		}
+	}
+
+	var system []string
+	for _, message := range in.Messages {
+		switch message.Role {
+		case RoleSystem, "developer":
+			system = append(system, contentText(message.Content))
+		case RoleTool:
+			result.Messages = appendMessage(result.Messages, RoleUser, ContentBlock{
+				Type:      ContentTypeToolResult,
+				ToolUseID: message.ToolCallID,
+				Content:   contentText(message.Content),
+			})
+		case RoleAssistant:
+			blocks := toContentBlocks(message.Content)
+			for _, call := range message.ToolCalls {
+				input := json.RawMessage(call.Function.Arguments)
+				if len(strings.TrimSpace(call.Function.Arguments)) == 0 {
+					input = json.RawMessage("{}")
+				}
+				if !json.Valid(input) {
+					return result, fmt.Errorf("invalid arguments for tool call %s", call.ID)
+				}
+
+				blocks = append(blocks, ContentBlock{
+					Type:  ContentTypeToolUse,
+					ID:    call.ID,
+					Name:  call.Function.Name,
+					Input: input,
+				})
+			}
+			result.Messages = appendMessage(result.Messages, RoleAssistant, blocks...)
+		default:
+			result.Messages = appendMessage(result.Messages, RoleUser, toContentBlocks(message.Content)...)
+		}
+	}
+
+	result.System = strings.Join(system, "\n\n")
+	return result, nil
+}
+
+// ToChatCompletion maps the Messages API response to the OpenAI chat completion response.
+func ToChatCompletion(in MessagesResponse) openai.ChatCompletion {
+	message := openai.Message{Role: RoleAssistant}
+
+	var text strings.Builder
+	for _, block := range in.Content {
+		switch block.Type {
+		case ContentTypeText:
+			text.WriteString(block.Text)
+		case ContentTypeToolUse:
+			message.ToolCalls = append(message.ToolCalls, ToToolCall(len(message.ToolCalls), block.ID, block.Name, string(block.Input)))
+		}
+	}
+	message.Content = text.String()
+
+	return openai.ChatCompletion{
+		Id:      in.ID,
+		Object:  "chat.completion",
+		Created: time.Now().Unix(),
+		Model:   in.Model,
+		Choices: []openai.Choice{
+			{
+				Index:        0,
+				Message:      message,
+				FinishReason: ToFinishReason(in.StopReason),
+			},
+		},
+		Usage: ToUsage(in.Usage),
+	}
+}
+
+// ToToolCall creates the OpenAI tool call from the Messages API tool_use block attributes.
+func ToToolCall(index int, id, name, arguments string) openai.ToolCall {
+	return openai.ToolCall{
+		ID:    id,
+		Index: index,
+		Type:  "function",
+		Function: struct {
+			Name      string `json:"name"`
+			Arguments string `json:"arguments"`
+		}{
+			Name:      name,
+			Arguments: arguments,
+		},
+	}
+}
+
+func ToUsage(in Usage) openai.Usage {
+	return openai.Usage{
+		PromptTokens:     in.PromptTokens(),
+		CompletionTokens: in.OutputTokens,
+		TotalTokens:      in.PromptTokens() + in.OutputTokens,
+	}
+}
+
+// ToFinishReason maps the Messages API stop reason to the OpenAI finish reason.
+func ToFinishReason(stopReason string) *string {
+	switch stopReason {
+	case "":
+		return nil
+	case StopReasonMaxTokens:
+		return lo.ToPtr("length")
+	case StopReasonToolUse:
+		return lo.ToPtr("tool_calls")
+	default:
+		return lo.ToPtr("stop")
+	}
+}
+
+// ToOpenAIErrorResponse maps the Messages API error response to the OpenAI error response.
+func ToOpenAIErrorResponse(in ErrorResponse) openai.ErrorResponse {
+	return openai.ErrorResponse{
+		Error: openai.Error{
+			Message: in.Error.Message,
+			Type:    in.Error.Type,
+		},
+	}
+}
+
+// FromOllamaChatRequest maps Ollama chat request to the Messages API request.
+// Streaming is not supported by the Ollama translation and is always disabled.
+func FromOllamaChatRequest(in ollamaapi.ChatRequest) MessagesRequest {
+	result := MessagesRequest{
+		Model:     in.Model,
+		MaxTokens: DefaultMaxTokens,
+		Tools:     toTools(in.Tools),
+	}
+
+	var system []string
+	for _, message := range in.Messages {
+		switch message.Role {
+		case RoleSystem:
+			system = append(system, message.Content)
+		case RoleTool:
+			result.Messages = appendMessage(result.Messages, RoleUser, ContentBlock{
+				Type:      ContentTypeToolResult,
+				ToolUseID: message.ToolCallID,
+				Content:   message.Content,
+			})
+		case RoleAssistant:
+			var blocks []ContentBlock
+			if len(message.Content) > 0 {
+				blocks = append(blocks, ContentBlock{Type: ContentTypeText, Text: message.Content})
+			}
+			for _, call := range message.ToolCalls {
+				blocks = append(blocks, ContentBlock{
+					Type:  ContentTypeToolUse,
+					ID:    call.ID,
+					Name:  call.Function.Name,
+					Input: json.RawMessage(call.Function.Arguments.String()),
+				})
+			}
+			result.Messages = appendMessage(result.Messages, RoleAssistant, blocks...)
+		default:
+			result.Messages = appendMessage(result.Messages, RoleUser, ContentBlock{Type: ContentTypeText, Text: message.Content})
+		}
+	}
+	result.System = strings.Join(system, "\n\n")
+
+	if in.Options != nil {
+		if temperature, ok := in.Options["temperature"].(float64); ok {
+			result.Temperature = &temperature
+		}
+		if topP, ok := in.Options["top_p"].(float64); ok {
+			result.TopP = &topP
+		}
+		if numPredict, ok := in.Options["num_predict"].(float64); ok && numPredict > 0 {
+			result.MaxTokens = int(numPredict)
+		}
+	}
+
+	return result
+}
+
+// ToOllamaChatResponse maps the Messages API response to the Ollama chat response.
+func ToOllamaChatResponse(in MessagesResponse) ollamaapi.ChatResponse {
+	message := ollamaapi.Message{Role: RoleAssistant}
+
+	var text strings.Builder
+	for _, block := range in.Content {
+		switch block.Type {
+		case ContentTypeText:
+			text.WriteString(block.Text)
+		case ContentTypeToolUse:
+			arguments := ollamaapi.NewToolCallFunctionArguments()
+			_ = arguments.UnmarshalJSON(block.Input)
+			message.ToolCalls = append(message.ToolCalls, ollamaapi.ToolCall{
+				ID: block.ID,
+				Function: ollamaapi.ToolCallFunction{
+					Index:     len(message.ToolCalls),
+					Name:      block.Name,
+					Arguments: arguments,
+				},
+			})
+		}
+	}
+	message.Content = text.String()
+
+	return ollamaapi.ChatResponse{
+		Model:      in.Model,
+		CreatedAt:  time.Now(),
+		Message:    message,
+		Done:       true,
+		DoneReason: lo.FromPtr(ToFinishReason(in.StopReason)),
+		Metrics: ollamaapi.Metrics{
+			PromptEvalCount: in.Usage.PromptTokens(),
+			EvalCount:       in.Usage.OutputTokens,
+		},
+	}
+}
+
+func FromErrorResponse(statusCode int) func(response ErrorResponse) ollamaapi.StatusError {
+	return func(in ErrorResponse) ollamaapi.StatusError {
+		return ollamaapi.StatusError{
+			StatusCode:   statusCode,
+			Status:       in.Error.Type,
+			ErrorMessage: in.Error.Message,
+		}
+	}
+}
+
+func toTools(tools ollamaapi.Tools) []Tool {
+	if len(tools) == 0 {
+		return nil
+	}
+
+	result := make([]Tool, 0, len(tools))
+	for _, tool := range tools {
+		schema := tool.Function.Parameters
+		if len(schema.Type) == 0 {
+			schema.Type = "object"
+		}
+		if schema.Properties == nil {
+			schema.Properties = ollamaapi.NewToolPropertiesMap()
+		}
+
+		result = append(result, Tool{
+			Name:        tool.Function.Name,
+			Description: tool.Function.Description,
+			InputSchema: schema,
+		})
+	}
+
+	return result
+}
+
+// appendMessage adds content blocks to the conversation merging them into the last
+// message if it has the same role, since the Messages API requires alternating roles.
+func appendMessage(messages []Message, role string, blocks ...ContentBlock) []Message {
+	if len(blocks) == 0 {
+		return messages
+	}
+
+	if len(messages) > 0 && messages[len(messages)-1].Role == role {
+		messages[len(messages)-1].Content = append(messages[len(messages)-1].Content, blocks...)
+		return messages
+	}
+
+	return append(messages, Message{Role: role, Content: blocks})
+}
+
+// toContentBlocks maps OpenAI message content that can be either a plain string or
+// a list of typed content parts.
+func toContentBlocks(content any) []ContentBlock {
+	switch c := content.(type) {
+	case string:
+		if len(c) == 0 {
+			return nil
+		}
+		return []ContentBlock{{Type: ContentTypeText, Text: c}}
+	case []any:
+		result := make([]ContentBlock, 0, len(c))
+		for _, part := range c {
+			p, ok := part.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			switch p["type"] {
+			case "text":
+				if text, ok := p["text"].(string); ok && len(text) > 0 {
+					result = append(result, ContentBlock{Type: ContentTypeText, Text: text})
+				}
+			case "image_url":
+				if block, ok := toImageBlock(p["image_url"]); ok {
+					result = append(result, block)
+				}
+			}
+		}
+		return result
+	}
+
+	return nil
+}
+
+// toImageBlock maps base64 encoded data URL images. Remote URLs are not supported
+// by the Messages API base64 source and are skipped.
+func toImageBlock(imageURL any) (ContentBlock, bool) {
+	u, ok := imageURL.(map[string]any)
+	if !ok {
+		return ContentBlock{}, false
+	}
+
+	raw, _ := u["url"].(string)
+	header, data, found := strings.Cut(strings.TrimPrefix(raw, "data:"), ",")
+	if !found || !strings.HasPrefix(raw, "data:") {
+		return ContentBlock{}, false
+	}
+
+	return ContentBlock{
+		Type: ContentTypeImage,
+		Source: &ImageSource{
+			Type:      "base64",
+			MediaType: strings.TrimSuffix(header, ";base64"),
+			Data:      data,
+		},
+	}, true
+}
+
+func contentText(content any) string {
+	var result strings.Builder
+	for _, block := range toContentBlocks(content) {
+		result.WriteString(block.Text)
+	}
+
+	return result.String()
+}
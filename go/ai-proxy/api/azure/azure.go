@@ -0,0 +1,109 @@
+package azure
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	// EndpointChatCompletions, EndpointEmbeddings and EndpointResponses are Azure OpenAI
+	// deployment-scoped endpoints. The deployment placeholder is replaced with the requested model.
+	EndpointChatCompletions = "/openai/deployments/${DEPLOYMENT}/chat/completions"
+	EndpointEmbeddings      = "/openai/deployments/${DEPLOYMENT}/embeddings"
+	EndpointResponses       = "/openai/responses"
+
+	HeaderAPIKey    = "api-key"
+	QueryAPIVersion = "api-version"
+
+	// EnvAPIVersion overrides the default API version. The api-version query parameter
+	// of the provider host takes precedence over it.
+	EnvAPIVersion     = "AZURE_API_VERSION"
+	DefaultAPIVersion = "2024-10-21"
+
+	// ResponsesAPIVersion is the minimal API version that serves the Responses API.
+	ResponsesAPIVersion = "2025-04-01-preview"
+
+	deploymentPlaceholder = "DEPLOYMENT"
+	previewSuffix         = "-preview"
+)
+
+// APIVersion returns the API version read from the provider host query, environment or the default one.
+func APIVersion(host *url.URL) string {
+	if version := host.Query().Get(QueryAPIVersion); len(version) > 0 {
+		return version
+	}
+
+	if version := os.Getenv(EnvAPIVersion); len(version) > 0 {
+		return version
+	}
+
+	return DefaultAPIVersion
+}
+
+// MinAPIVersion returns version, or minimum if version is older. Versions are compared by
+// their date and a GA version is newer than the preview of the same date. Versions without
+// a date, e.g. "preview" or "latest" of the v1 API, are returned as is.
+func MinAPIVersion(version, minimum string) string {
+	date, err := apiVersionDate(version)
+	if err != nil {
+		return version
+	}
+
+	minimumDate, err := apiVersionDate(minimum)
+	if err != nil {
+		return version
+	}
+
+	preview := strings.HasSuffix(version, previewSuffix) && !strings.HasSuffix(minimum, previewSuffix)
+	if date.Before(minimumDate) || (date.Equal(minimumDate) && preview) {
+		return minimum
+	}
+
+	return version
+}
+
+func apiVersionDate(version string) (time.Time, error) {
+	return time.Parse(time.DateOnly, strings.TrimSuffix(version, previewSuffix))
+}
+
+// DeploymentPath expands the deployment-scoped endpoint with the deployment name.
+func DeploymentPath(endpoint, deployment string) string {
+	return os.Expand(endpoint, func(s string) string {
+		if s == deploymentPlaceholder {
+			return url.PathEscape(deployment)
+		}
+
+		return s
+	})
+}
+
+// IsDeploymentPath returns true if the path is the deployment-scoped endpoint, regardless of the deployment.
+func IsDeploymentPath(endpoint, path string) bool {
+	prefix, suffix, _ := strings.Cut(endpoint, "${"+deploymentPlaceholder+"}")
+	return strings.HasPrefix(path, prefix) && strings.HasSuffix(path, suffix) && len(path) > len(prefix)+len(suffix)
+}
+
+// PeekModel reads the model from the JSON request body and restores the body,
+// so it can be read again. Azure uses the model name as the deployment name.
+func PeekModel(body io.ReadCloser) (string, io.ReadCloser, error) {
+	if body == nil {
+		return "", body, nil
+	}
+
+	data, err := io.ReadAll(body)
+	_ = body.Close()
+	if err != nil {
+		return "", io.NopCloser(bytes.NewReader(data)), err
+	}
+
+	var payload struct {
+		Model string `json:"model"`
+	}
+	_ = json.Unmarshal(data, &payload)
+	return payload.Model, io.NopCloser(bytes.NewReader(data)), nil
+}
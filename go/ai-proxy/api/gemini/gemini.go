@@ -0,0 +1,45 @@
+package gemini
+
+import (
+	"encoding/json"
+
+	ollamaapi "github.com/ollama/ollama/api"
+)
+
+const (
+	// DefaultHost is the Gemini API (Google AI Studio) host used when provider host is not set.
+	DefaultHost = "https://generativelanguage.googleapis.com"
+
+	// EndpointChatCompletions and EndpointEmbeddings are OpenAI compatible Gemini API endpoints
+	// authenticated with the API key passed as a bearer token.
+	EndpointChatCompletions = "/v1beta/openai/chat/completions"
+	EndpointEmbeddings      = "/v1beta/openai/embeddings"
+)
+
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Status  string `json:"status,omitempty"`
+}
+
+type ErrorResponse struct {
+	Error Error `json:"error"`
+}
+
+// FromErrorResponse maps the Gemini API error to the Ollama error. Gemini returns
+// either a single error object or a list of errors depending on the endpoint.
+func FromErrorResponse(statusCode int) func(response json.RawMessage) ollamaapi.StatusError {
+	return func(in json.RawMessage) ollamaapi.StatusError {
+		var errors []ErrorResponse
+		if err := json.Unmarshal(in, &errors); err != nil || len(errors) == 0 {
+			errors = make([]ErrorResponse, 1)
+			_ = json.Unmarshal(in, &errors[0])
+		}
+
+		return ollamaapi.StatusError{
+			StatusCode:   statusCode,
+			Status:       errors[0].Error.Status,
+			ErrorMessage: errors[0].Error.Message,
+		}
+	}
+}
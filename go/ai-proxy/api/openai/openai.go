@@ -79,6 +79,10 @@ func FromChatCompletionResponse(in openai.ChatCompletion) ollamaapi.ChatResponse
 		CreatedAt: time.Unix(in.Created, 0),
 		Message:   toFlatMessage(in.Choices),
 		Done:      true,
+		Metrics: ollamaapi.Metrics{
+			PromptEvalCount: in.Usage.PromptTokens,
+			EvalCount:       in.Usage.CompletionTokens,
+		},
 	}
 }
 
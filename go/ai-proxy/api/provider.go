@@ -3,6 +3,9 @@ package api
 import (
 	"fmt"
 
+	"github.com/pluralsh/console/go/ai-proxy/api/anthropic"
+	"github.com/pluralsh/console/go/ai-proxy/api/azure"
+	"github.com/pluralsh/console/go/ai-proxy/api/gemini"
 	"github.com/pluralsh/console/go/ai-proxy/api/ollama"
 	"github.com/pluralsh/console/go/ai-proxy/api/openai"
 	"github.com/pluralsh/console/go/ai-proxy/api/vertex"
@@ -26,6 +29,10 @@ func ToProvider(s string) (Provider, error) {
 		return ProviderVertex, nil
 	case ProviderBedrock.String():
 		return ProviderBedrock, nil
+	case ProviderAzure.String():
+		return ProviderAzure, nil
+	case ProviderGemini.String():
+		return ProviderGemini, nil
 	}
 
 	return "", fmt.Errorf("invalid provider: %s", s)
@@ -37,6 +44,8 @@ const (
 	ProviderOllama    Provider = "ollama"
 	ProviderVertex    Provider = "vertex"
 	ProviderBedrock   Provider = "bedrock"
+	ProviderAzure     Provider = "azure"
+	ProviderGemini    Provider = "gemini"
 )
 
 type OllamaAPI string
@@ -50,6 +59,15 @@ var (
 	ollamaToVertex ProviderAPIMapping = map[string]string{
 		ollama.EndpointChat: vertex.EndpointChat,
 	}
+	ollamaToAnthropic ProviderAPIMapping = map[string]string{
+		ollama.EndpointChat: anthropic.EndpointMessages,
+	}
+	ollamaToAzure ProviderAPIMapping = map[string]string{
+		ollama.EndpointChat: azure.EndpointChatCompletions,
+	}
+	ollamaToGemini ProviderAPIMapping = map[string]string{
+		ollama.EndpointChat: gemini.EndpointChatCompletions,
+	}
 )
 
 func ToProviderAPIPath(target Provider, path string) string {
@@ -69,6 +87,27 @@ func ToProviderAPIPath(target Provider, path string) string {
 			panic(fmt.Sprintf("path %s not registered for provider %s", path, target))
 		}
 
+		return targetPath
+	case ProviderAnthropic:
+		targetPath, exists := ollamaToAnthropic[path]
+		if !exists {
+			panic(fmt.Sprintf("path %s not registered for provider %s", path, target))
+		}
+
+		return targetPath
+	case ProviderAzure:
+		targetPath, exists := ollamaToAzure[path]
+		if !exists {
+			panic(fmt.Sprintf("path %s not registered for provider %s", path, target))
+		}
+
+		return targetPath
+	case ProviderGemini:
+		targetPath, exists := ollamaToGemini[path]
+		if !exists {
+			panic(fmt.Sprintf("path %s not registered for provider %s", path, target))
+		}
+
 		return targetPath
 	}
 
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// RateLimitConfig defines per-caller limits enforced before requests are proxied upstream.
+type RateLimitConfig struct {
+	// IdentityHeader is a request header that identifies the caller. If not set or missing
+	// in the request, the bearer token is used, and the client IP address as a last resort.
+	IdentityHeader string `json:"identityHeader,omitempty"`
+
+	// Default limits apply to every caller that does not have its own entry in Callers.
+	Default RateLimits `json:"default"`
+
+	// Callers overrides default limits for specific caller identities.
+	Callers map[string]RateLimits `json:"callers,omitempty"`
+}
+
+// RateLimits is a set of limits for a single caller. Zero value means no limit.
+type RateLimits struct {
+	RequestsPerMinute int64 `json:"requestsPerMinute,omitempty"`
+	TokensPerDay      int64 `json:"tokensPerDay,omitempty"`
+}
+
+// LoadRateLimitConfig reads and validates rate limit config from the JSON file.
+func LoadRateLimitConfig(path string) (*RateLimitConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read rate limit config: %w", err)
+	}
+
+	config := &RateLimitConfig{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("could not parse rate limit config: %w", err)
+	}
+
+	return config, config.Validate()
+}
+
+func (in *RateLimitConfig) Validate() error {
+	if err := in.Default.validate(); err != nil {
+		return fmt.Errorf("default limits: %w", err)
+	}
+
+	for caller, limits := range in.Callers {
+		if err := limits.validate(); err != nil {
+			return fmt.Errorf("caller %s limits: %w", caller, err)
+		}
+	}
+
+	return nil
+}
+
+// ForCaller returns limits that apply to the caller.
+func (in *RateLimitConfig) ForCaller(caller string) RateLimits {
+	if limits, ok := in.Callers[caller]; ok {
+		return limits
+	}
+
+	return in.Default
+}
+
+func (in RateLimits) validate() error {
+	if in.RequestsPerMinute < 0 || in.TokensPerDay < 0 {
+		return fmt.Errorf("limits cannot be negative")
+	}
+
+	return nil
+}
@@ -0,0 +1,180 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+const (
+	// RouteWildcard marks a route model entry as a prefix. A single RouteWildcard
+	// matches every model and can be used as a default route.
+	RouteWildcard = "*"
+)
+
+// RoutingConfig maps models to one or more providers that can serve them.
+// It allows a single proxy to serve multiple providers at once.
+type RoutingConfig struct {
+	// Providers is a set of named upstream providers referenced by routes.
+	Providers map[string]ProviderConfig `json:"providers"`
+
+	// Routes are matched against the model of the incoming request. Exact model
+	// names take precedence over prefixes and the longest matching prefix wins.
+	Routes []Route `json:"routes"`
+}
+
+type ProviderConfig struct {
+	Provider Provider `json:"provider"`
+	Host     string   `json:"host,omitempty"`
+
+	// Tokens used to authenticate with the provider. TokensEnv can be used instead
+	// to read comma separated tokens from the environment.
+	Tokens    []string `json:"tokens,omitempty"`
+	TokensEnv string   `json:"tokensEnv,omitempty"`
+
+	// ServiceAccount used to authenticate with Vertex. ServiceAccountEnv can be used
+	// instead to read it from the environment.
+	ServiceAccount    string `json:"serviceAccount,omitempty"`
+	ServiceAccountEnv string `json:"serviceAccountEnv,omitempty"`
+
+	AWSRegion string `json:"awsRegion,omitempty"`
+}
+
+// GetTokens returns provider tokens including the ones read from the TokensEnv variable.
+func (in ProviderConfig) GetTokens() []string {
+	tokens := in.Tokens
+	if len(in.TokensEnv) > 0 {
+		for _, token := range strings.Split(os.Getenv(in.TokensEnv), ",") {
+			if token = strings.TrimSpace(token); len(token) > 0 {
+				tokens = append(tokens, token)
+			}
+		}
+	}
+
+	return tokens
+}
+
+// GetServiceAccount returns provider service account, ServiceAccountEnv takes precedence if set.
+func (in ProviderConfig) GetServiceAccount() string {
+	if len(in.ServiceAccountEnv) > 0 {
+		if sa := os.Getenv(in.ServiceAccountEnv); len(sa) > 0 {
+			return sa
+		}
+	}
+
+	return in.ServiceAccount
+}
+
+type Route struct {
+	// Models is a list of model names. Entries ending with RouteWildcard are treated as prefixes.
+	Models []string `json:"models"`
+
+	// Targets is an ordered list of providers. The first one is the primary, and the rest
+	// are used as a fallback when the previous one fails with 429 or 5xx.
+	Targets []RouteTarget `json:"targets"`
+}
+
+type RouteTarget struct {
+	// Provider is a name of the provider from RoutingConfig.Providers.
+	Provider string `json:"provider"`
+
+	// Model optionally overrides the requested model name, i.e. when the fallback
+	// provider uses a different name for the same model.
+	Model string `json:"model,omitempty"`
+}
+
+// LoadRoutingConfig reads and validates routing config from the JSON file.
+func LoadRoutingConfig(path string) (*RoutingConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read routing config: %w", err)
+	}
+
+	config := &RoutingConfig{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("could not parse routing config: %w", err)
+	}
+
+	return config, config.Validate()
+}
+
+func (in *RoutingConfig) Validate() error {
+	if len(in.Routes) == 0 {
+		return fmt.Errorf("routing config must have at least one route")
+	}
+
+	for name, p := range in.Providers {
+		if _, err := ToProvider(p.Provider.String()); err != nil {
+			return fmt.Errorf("provider %s: %w", name, err)
+		}
+
+		if p.Provider != ProviderBedrock && p.Provider != ProviderGemini && len(p.Host) == 0 {
+			return fmt.Errorf("provider %s: host is required", name)
+		}
+	}
+
+	for i, route := range in.Routes {
+		if len(route.Models) == 0 {
+			return fmt.Errorf("route %d must have at least one model", i)
+		}
+
+		if len(route.Targets) == 0 {
+			return fmt.Errorf("route %d must have at least one target", i)
+		}
+
+		for _, target := range route.Targets {
+			if _, ok := in.Providers[target.Provider]; !ok {
+				return fmt.Errorf("route %d references unknown provider %s", i, target.Provider)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Match returns route targets for the provided model or nil if no route matches.
+func (in *RoutingConfig) Match(model string) []RouteTarget {
+	var result []RouteTarget
+	longestPrefix := -1
+	for _, route := range in.Routes {
+		for _, m := range route.Models {
+			prefix, isPrefix := strings.CutSuffix(m, RouteWildcard)
+			if !isPrefix {
+				if m == model {
+					return route.Targets
+				}
+
+				continue
+			}
+
+			if strings.HasPrefix(model, prefix) && len(prefix) > longestPrefix {
+				result = route.Targets
+				longestPrefix = len(prefix)
+			}
+		}
+	}
+
+	return result
+}
+
+// ExactModels returns the route models that are not prefixes.
+func (in *RoutingConfig) ExactModels() []string {
+	result := make([]string, 0)
+	for _, route := range in.Routes {
+		for _, m := range route.Models {
+			if !strings.HasSuffix(m, RouteWildcard) {
+				result = append(result, m)
+			}
+		}
+	}
+
+	return result
+}
+
+// ShouldFallback returns true if a request that finished with the provided status
+// code should be retried with the next route target.
+func ShouldFallback(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
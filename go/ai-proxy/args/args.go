@@ -10,6 +10,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"github.com/pluralsh/console/go/ai-proxy/api"
+	"github.com/pluralsh/console/go/ai-proxy/api/gemini"
 	"github.com/pluralsh/console/go/ai-proxy/internal/helpers"
 	"github.com/pluralsh/console/go/ai-proxy/internal/log"
 )
@@ -22,6 +23,9 @@ const (
 	envBedrockMantleAWSRegion = "BEDROCK_MANTLE_AWS_REGION"
 	envBedrockMantlePrefixes  = "BEDROCK_MANTLE_MODEL_PREFIXES"
 	envMantleSigV4            = "MANTLE_SIGV4"
+	envRoutingConfig          = "ROUTING_CONFIG"
+	envRateLimitConfig        = "RATE_LIMIT_CONFIG"
+	envMetricsModels          = "METRICS_MODELS"
 
 	defaultPort                   = 8000
 	defaultProvider               = api.ProviderOllama
@@ -30,7 +34,7 @@ const (
 )
 
 var (
-	argProvider               = pflag.String("provider", defaultProvider.String(), "Provider name. Must be one of: ollama, openai, vertex. Defaults to 'ollama' type API.")
+	argProvider               = pflag.String("provider", defaultProvider.String(), "Provider name. Must be one of: ollama, openai, anthropic, vertex, bedrock, azure, gemini. Defaults to 'ollama' type API.")
 	argProviderHost           = pflag.String("provider-host", "", "Provider host address to access the API i.e. https://api.openai.com")
 	argProviderTokens         = pflag.StringSlice("provider-tokens", helpers.GetPluralEnvSlice(envProviderToken, []string{}), "Provider tokens used to connect to the API if needed. Can be overridden via PLRL_PROVIDER_TOKEN env var.")
 	argProviderServiceAccount = pflag.String("provider-service-account", helpers.GetPluralEnv(envProviderServiceAccount, ""), "Provider service account file used to connect to the API if needed. Can be overridden via PLRL_PROVIDER_SERVICE_ACCOUNT env var.")
@@ -39,6 +43,9 @@ var (
 	argBedrockMantleAWSRegion = pflag.String("bedrock-mantle-aws-region", helpers.GetPluralEnv(envBedrockMantleAWSRegion, defaultBedrockMantleAWSRegion), "AWS region for Amazon Bedrock Mantle. Defaults to us-east-1.")
 	argBedrockMantlePrefixes  = pflag.StringSlice("bedrock-mantle-model-prefixes", helpers.GetPluralEnvSlice(envBedrockMantlePrefixes, []string{"gpt-5.6"}), "OpenAI model prefixes routed to Amazon Bedrock Mantle.")
 	argMantleSigV4            = pflag.Bool("mantle-sigv4", helpers.GetPluralEnvBool(envMantleSigV4, false), "Use AWS SigV4 authentication from the default credentials chain for Amazon Bedrock Mantle. Can be overridden via PLRL_MANTLE_SIGV4 env var.")
+	argRoutingConfig          = pflag.String("routing-config", helpers.GetPluralEnv(envRoutingConfig, ""), "Path to the JSON routing config file that maps models to multiple providers. When set, provider flags are ignored. Can be overridden via PLRL_ROUTING_CONFIG env var.")
+	argRateLimitConfig        = pflag.String("rate-limit-config", helpers.GetPluralEnv(envRateLimitConfig, ""), "Path to the JSON config file with per-caller request and token limits. Can be overridden via PLRL_RATE_LIMIT_CONFIG env var.")
+	argMetricsModels          = pflag.StringSlice("metrics-models", helpers.GetPluralEnvSlice(envMetricsModels, []string{}), "Additional model names recorded in metric labels. Other models not known to the proxy are recorded as 'unknown'. Can be overridden via PLRL_METRICS_MODELS env var.")
 	argPort                   = pflag.Int("port", defaultPort, "The port to listen on. Defaults to port 8000.")
 	argAddress                = pflag.IP("address", net.ParseIP(defaultAddress), "The IP address to serve on. Defaults to 0.0.0.0 (all interfaces).")
 )
@@ -78,6 +85,10 @@ func ProviderHost() string {
 		return ""
 	}
 
+	if Provider() == api.ProviderGemini && len(*argProviderHost) == 0 {
+		return gemini.DefaultHost
+	}
+
 	if len(*argProviderHost) == 0 {
 		panic(fmt.Errorf("provider host is required"))
 	}
@@ -100,7 +111,7 @@ func ProviderAwsRegion() string {
 }
 
 func ProviderTokens() []string {
-	if argProviderTokens != nil && len(*argProviderTokens) > 0 && Provider() == api.ProviderOpenAI {
+	if argProviderTokens != nil && len(*argProviderTokens) > 0 && lo.Contains([]api.Provider{api.ProviderOpenAI, api.ProviderAnthropic, api.ProviderAzure, api.ProviderGemini}, Provider()) {
 		return *argProviderTokens
 	}
 
@@ -135,6 +146,30 @@ func MantleSigV4() bool {
 	return argMantleSigV4 != nil && *argMantleSigV4
 }
 
+func RoutingConfig() string {
+	if argRoutingConfig == nil {
+		return ""
+	}
+
+	return *argRoutingConfig
+}
+
+func RateLimitConfig() string {
+	if argRateLimitConfig == nil {
+		return ""
+	}
+
+	return *argRateLimitConfig
+}
+
+func MetricsModels() []string {
+	if argMetricsModels == nil {
+		return nil
+	}
+
+	return *argMetricsModels
+}
+
 func Address() string {
 	if argAddress == nil {
 		klog.ErrorS(
@@ -153,5 +188,6 @@ func Address() string {
 }
 
 func OpenAICompatible() bool {
-	return Provider() == api.ProviderOpenAI || Provider() == api.ProviderBedrock || Provider() == api.ProviderOllama
+	return Provider() == api.ProviderOpenAI || Provider() == api.ProviderBedrock || Provider() == api.ProviderOllama || Provider() == api.ProviderAnthropic ||
+		Provider() == api.ProviderAzure || Provider() == api.ProviderGemini
 }
@@ -1,33 +1,230 @@
 package helpers
 
 import (
-	"sync/atomic"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// EndpointTokenHealth serves the health of provider tokens.
+	EndpointTokenHealth = "/health/tokens"
+
+	// DefaultRateLimitCooldown is used to bench rate limited tokens when upstream
+	// does not return the Retry-After header.
+	DefaultRateLimitCooldown = time.Minute
+
+	// DefaultUnauthorizedCooldown is used to bench tokens rejected with 401 or 403,
+	// i.e. revoked keys or keys without access to the model.
+	DefaultUnauthorizedCooldown = 10 * time.Minute
 )
 
 type TokenRotator interface {
 	GetNextToken() string
 }
 
+// TokenHealth describes the current state of a single token.
+// Token value is masked, so it can be safely exposed.
+type TokenHealth struct {
+	Token        string     `json:"token"`
+	Healthy      bool       `json:"healthy"`
+	BenchedUntil *time.Time `json:"benchedUntil,omitempty"`
+	LastStatus   int        `json:"lastStatus,omitempty"`
+	Failures     int        `json:"failures"`
+}
+
+type tokenState struct {
+	benchedUntil time.Time
+	lastStatus   int
+	failures     int
+}
+
+// RoundRobinTokenRotator cycles through healthy tokens. Tokens that upstream rejected
+// with 401, 403 or 429 are benched for a cooldown and skipped until it expires.
 type RoundRobinTokenRotator struct {
 	Tokens []string
-	index  atomic.Uint32
+
+	RateLimitCooldown    time.Duration
+	UnauthorizedCooldown time.Duration
+
+	mu     sync.Mutex
+	index  int
+	states map[string]*tokenState
+	now    func() time.Time
 }
 
 func NewRoundRobinTokenRotator(tokens []string) *RoundRobinTokenRotator {
 	tokenCpy := make([]string, len(tokens))
 	copy(tokenCpy, tokens)
 
+	states := make(map[string]*tokenState, len(tokens))
+	for _, token := range tokenCpy {
+		states[token] = &tokenState{}
+	}
+
 	return &RoundRobinTokenRotator{
-		Tokens: tokenCpy,
+		Tokens:               tokenCpy,
+		RateLimitCooldown:    DefaultRateLimitCooldown,
+		UnauthorizedCooldown: DefaultUnauthorizedCooldown,
+		states:               states,
+		now:                  time.Now,
 	}
 }
 
+// GetNextToken returns the next healthy token. If all tokens are benched,
+// the one that will be available first is returned.
 func (rr *RoundRobinTokenRotator) GetNextToken() string {
 	if len(rr.Tokens) == 0 {
 		return ""
 	}
 
-	currentIndex := rr.index.Load()
-	rr.index.Add(1)
-	return rr.Tokens[currentIndex%uint32(len(rr.Tokens))]
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+
+	now := rr.now()
+	fallback := -1
+	for i := range rr.Tokens {
+		idx := (rr.index + i) % len(rr.Tokens)
+		state := rr.states[rr.Tokens[idx]]
+		if !state.benchedUntil.After(now) {
+			rr.index = idx + 1
+			return rr.Tokens[idx]
+		}
+
+		if fallback < 0 || state.benchedUntil.Before(rr.states[rr.Tokens[fallback]].benchedUntil) {
+			fallback = idx
+		}
+	}
+
+	rr.index = fallback + 1
+	return rr.Tokens[fallback]
+}
+
+// Owns returns true if the token is managed by this rotator.
+func (rr *RoundRobinTokenRotator) Owns(token string) bool {
+	_, ok := rr.states[token]
+	return ok
+}
+
+// HasHealthy returns true if at least one token other than the excluded one is not benched.
+func (rr *RoundRobinTokenRotator) HasHealthy(exclude string) bool {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+
+	now := rr.now()
+	for _, token := range rr.Tokens {
+		if token != exclude && !rr.states[token].benchedUntil.After(now) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Report updates token health based on the upstream response status code.
+// Tokens are benched on 401, 403 and 429. The Retry-After value, if provided,
+// takes precedence over the default rate limit cooldown.
+func (rr *RoundRobinTokenRotator) Report(token string, statusCode int, retryAfter time.Duration) {
+	state, ok := rr.states[token]
+	if !ok {
+		return
+	}
+
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+
+	state.lastStatus = statusCode
+	if !IsTokenFailure(statusCode) {
+		state.failures = 0
+		state.benchedUntil = time.Time{}
+		return
+	}
+
+	cooldown := rr.UnauthorizedCooldown
+	if statusCode == http.StatusTooManyRequests {
+		cooldown = rr.RateLimitCooldown
+		if retryAfter > 0 {
+			cooldown = retryAfter
+		}
+	}
+
+	state.failures++
+	state.benchedUntil = rr.now().Add(cooldown)
+	klog.InfoS("benching provider token", "token", maskToken(token), "status", statusCode, "cooldown", cooldown, "failures", state.failures)
+}
+
+// Health returns the current health of all tokens.
+func (rr *RoundRobinTokenRotator) Health() []TokenHealth {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+
+	now := rr.now()
+	result := make([]TokenHealth, 0, len(rr.Tokens))
+	for _, token := range rr.Tokens {
+		state := rr.states[token]
+		health := TokenHealth{
+			Token:      maskToken(token),
+			Healthy:    !state.benchedUntil.After(now),
+			LastStatus: state.lastStatus,
+			Failures:   state.failures,
+		}
+		if !health.Healthy {
+			benchedUntil := state.benchedUntil
+			health.BenchedUntil = &benchedUntil
+		}
+		result = append(result, health)
+	}
+
+	return result
+}
+
+// IsTokenFailure returns true for status codes that indicate a problem with the token itself.
+func IsTokenFailure(statusCode int) bool {
+	return statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden || statusCode == http.StatusTooManyRequests
+}
+
+// ParseRetryAfter parses the Retry-After header value that can be either a number
+// of seconds or an HTTP date.
+func ParseRetryAfter(value string, now time.Time) time.Duration {
+	if len(value) == 0 {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+
+	return 0
+}
+
+func maskToken(token string) string {
+	const visible = 4
+	if len(token) <= visible*2 {
+		return "****"
+	}
+
+	return "****" + token[len(token)-visible:]
+}
+
+// TokenHealthHandler serves the health of all provider tokens grouped by the provider name.
+func TokenHealthHandler(rotators map[string]*RoundRobinTokenRotator) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		result := make(map[string][]TokenHealth, len(rotators))
+		for name, rotator := range rotators {
+			result[name] = rotator.Health()
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(result); err != nil {
+			klog.ErrorS(err, "failed to encode token health")
+		}
+	}
 }
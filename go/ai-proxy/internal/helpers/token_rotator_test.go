@@ -1,7 +1,12 @@
 package helpers
 
 import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestRoundRobinTokenRotator(t *testing.T) {
@@ -23,3 +28,101 @@ func TestRoundRobinTokenRotator(t *testing.T) {
 		t.Errorf("Fourth token = %s, want token1", got)
 	}
 }
+
+func TestRoundRobinTokenRotatorSkipsBenchedTokens(t *testing.T) {
+	now := time.Now()
+	rotator := NewRoundRobinTokenRotator([]string{"token1", "token2", "token3"})
+	rotator.now = func() time.Time { return now }
+
+	rotator.Report("token2", http.StatusTooManyRequests, 30*time.Second)
+	for i, want := range []string{"token1", "token3", "token1"} {
+		if got := rotator.GetNextToken(); got != want {
+			t.Errorf("token %d = %s, want %s", i, got, want)
+		}
+	}
+
+	now = now.Add(31 * time.Second)
+	if got := rotator.GetNextToken(); got != "token2" {
+		t.Errorf("token after cooldown = %s, want token2", got)
+	}
+}
+
+func TestRoundRobinTokenRotatorAllBenched(t *testing.T) {
+	now := time.Now()
+	rotator := NewRoundRobinTokenRotator([]string{"token1", "token2"})
+	rotator.now = func() time.Time { return now }
+
+	rotator.Report("token1", http.StatusUnauthorized, 0)
+	rotator.Report("token2", http.StatusTooManyRequests, 0)
+	if got := rotator.GetNextToken(); got != "token2" {
+		t.Errorf("token = %s, want token2 which is available first", got)
+	}
+
+	health := rotator.Health()
+	if health[0].Healthy || health[0].LastStatus != http.StatusUnauthorized || health[0].Failures != 1 {
+		t.Errorf("unexpected token1 health: %+v", health[0])
+	}
+
+	rotator.Report("token1", http.StatusOK, 0)
+	if health := rotator.Health(); !health[0].Healthy || health[0].Failures != 0 {
+		t.Errorf("expected token1 to recover, got %+v", health[0])
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := map[string]time.Duration{
+		"":                              0,
+		"120":                           2 * time.Minute,
+		"invalid":                       0,
+		"Wed, 01 Jan 2025 12:00:30 GMT": 30 * time.Second,
+		"Wed, 01 Jan 2025 11:00:00 GMT": 0,
+	}
+
+	for value, want := range tests {
+		if got := ParseRetryAfter(value, now); got != want {
+			t.Errorf("ParseRetryAfter(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
+
+func TestTokenRetryTransport(t *testing.T) {
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "payload" {
+			t.Errorf("unexpected body %q", body)
+		}
+
+		token := r.Header.Get("Authorization")
+		seen = append(seen, token)
+		if token == "Bearer token1" {
+			w.Header().Set("Retry-After", "10")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	rotator := NewRoundRobinTokenRotator([]string{"token1", "token2"})
+	client := &http.Client{Transport: NewBearerTokenRetryTransport(rotator, nil)}
+
+	request, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader("payload"))
+	request.Header.Set("Authorization", "Bearer "+rotator.GetNextToken())
+	response, err := client.Do(request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want 200", response.StatusCode)
+	}
+	if len(seen) != 2 || seen[1] != "Bearer token2" {
+		t.Errorf("unexpected upstream tokens: %v", seen)
+	}
+	if rotator.HasHealthy("token2") {
+		t.Errorf("expected token1 to be benched")
+	}
+}
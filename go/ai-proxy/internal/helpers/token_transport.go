@@ -0,0 +1,88 @@
+package helpers
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/pluralsh/console/go/ai-proxy/internal/log"
+)
+
+// TokenRetryTransport reports upstream responses to the token rotator and transparently
+// retries requests rejected with 401, 403 or 429 using the next healthy token.
+// Requests authenticated with tokens that are not managed by the rotator are passed through.
+type TokenRetryTransport struct {
+	rotator *RoundRobinTokenRotator
+	next    http.RoundTripper
+
+	// header is the name of the header that carries the token.
+	header string
+	// prefix is the header value prefix, i.e. "Bearer ".
+	prefix string
+}
+
+func NewTokenRetryTransport(rotator *RoundRobinTokenRotator, header, prefix string, next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &TokenRetryTransport{
+		rotator: rotator,
+		next:    next,
+		header:  header,
+		prefix:  prefix,
+	}
+}
+
+// NewBearerTokenRetryTransport creates TokenRetryTransport for tokens sent with the Authorization header.
+func NewBearerTokenRetryTransport(rotator *RoundRobinTokenRotator, next http.RoundTripper) http.RoundTripper {
+	return NewTokenRetryTransport(rotator, "Authorization", "Bearer ", next)
+}
+
+func (in *TokenRetryTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	token, ok := strings.CutPrefix(request.Header.Get(in.header), in.prefix)
+	if !ok || !in.rotator.Owns(token) {
+		return in.next.RoundTrip(request)
+	}
+
+	var body []byte
+	if request.Body != nil && request.Body != http.NoBody {
+		var err error
+		if body, err = io.ReadAll(request.Body); err != nil {
+			return nil, err
+		}
+		_ = request.Body.Close()
+	}
+
+	// Each token gets at most one attempt.
+	for attempt := 0; ; attempt++ {
+		current := request
+		if attempt > 0 {
+			current = request.Clone(request.Context())
+			current.Header.Set(in.header, in.prefix+token)
+		}
+		if body != nil {
+			current.Body = io.NopCloser(bytes.NewReader(body))
+			current.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(body)), nil }
+		}
+
+		response, err := in.next.RoundTrip(current)
+		if err != nil {
+			return nil, err
+		}
+
+		in.rotator.Report(token, response.StatusCode, ParseRetryAfter(response.Header.Get("Retry-After"), time.Now()))
+		if !IsTokenFailure(response.StatusCode) || attempt >= len(in.rotator.Tokens)-1 || !in.rotator.HasHealthy(token) {
+			return response, nil
+		}
+
+		klog.V(log.LogLevelDebug).InfoS("retrying request with the next provider token", "status", response.StatusCode, "url", current.URL.String())
+		_, _ = io.Copy(io.Discard, response.Body)
+		_ = response.Body.Close()
+		token = in.rotator.GetNextToken()
+	}
+}
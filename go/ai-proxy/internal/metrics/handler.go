@@ -0,0 +1,287 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/pluralsh/console/go/ai-proxy/api/openai"
+)
+
+const (
+	unknownModel = "unknown"
+
+	// maxCapturedBodySize limits the size of non-streaming response bodies kept in memory
+	// to read the usage from.
+	maxCapturedBodySize = 16 << 20
+
+	contentTypeEventStream = "text/event-stream"
+	sseEventSeparator      = "\n\n"
+	sseDataPrefix          = "data:"
+)
+
+// Instrument wraps the proxy handler and records request count, latency, upstream status
+// codes and token usage read from both streaming and non-streaming responses. Requests are
+// labeled with the matched route and models that were not registered are recorded as unknown.
+//
+// Streaming chat completion requests are modified to always include usage. The usage chunk
+// is stripped from the response if the caller did not request it.
+func Instrument(provider string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		model, stripUsage := inspectRequest(r)
+
+		writer := &usageResponseWriter{ResponseWriter: w, stripUsage: stripUsage}
+		next(writer, r)
+		writer.finish()
+
+		label := modelLabel(model)
+		Record().Request(provider, label, endpointLabel(r), writer.statusCode(), time.Since(start))
+		Record().Usage(provider, label, writer.usage)
+		if listener, ok := r.Context().Value(usageListenerKey{}).(UsageListener); ok {
+			listener(model, writer.usage)
+		}
+	}
+}
+
+// UsageListener is notified about the token usage of every instrumented request.
+type UsageListener func(model string, usage Usage)
+
+type usageListenerKey struct{}
+
+// WithUsageListener returns a copy of the context that makes Instrument notify the listener
+// once the response is served.
+func WithUsageListener(ctx context.Context, listener UsageListener) context.Context {
+	return context.WithValue(ctx, usageListenerKey{}, listener)
+}
+
+// inspectRequest reads the model from JSON request body and enables stream usage reporting
+// for chat completions. It returns the model and whether the usage chunk should be stripped
+// from the response.
+func inspectRequest(r *http.Request) (model string, stripUsage bool) {
+	model = unknownModel
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if r.Body == nil || strings.HasPrefix(mediaType, "multipart/") {
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	_ = r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+
+	var payload map[string]json.RawMessage
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return
+	}
+
+	if m := jsonString(payload["model"]); len(m) > 0 {
+		model = m
+	}
+
+	var stream bool
+	_ = json.Unmarshal(payload["stream"], &stream)
+	if !stream || r.URL.Path != openai.EndpointChat {
+		return
+	}
+
+	streamOptions := map[string]any{}
+	_ = json.Unmarshal(payload["stream_options"], &streamOptions)
+	if includeUsage, _ := streamOptions["include_usage"].(bool); includeUsage {
+		return
+	}
+
+	streamOptions["include_usage"] = true
+	payload["stream_options"], _ = json.Marshal(streamOptions)
+	rewritten, err := json.Marshal(payload)
+	if err != nil {
+		klog.ErrorS(err, "failed to enable stream usage reporting")
+		return
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(rewritten))
+	r.ContentLength = int64(len(rewritten))
+	r.Header.Del("Content-Length")
+	return model, true
+}
+
+func jsonString(raw json.RawMessage) string {
+	var s string
+	_ = json.Unmarshal(raw, &s)
+	return s
+}
+
+// usagePayload covers usage reported by OpenAI chat completions, responses and embeddings APIs
+// as well as Ollama chat responses.
+type usagePayload struct {
+	Choices  json.RawMessage `json:"choices"`
+	Usage    *usage          `json:"usage"`
+	Response *struct {
+		Usage *usage `json:"usage"`
+	} `json:"response"`
+	PromptEvalCount int `json:"prompt_eval_count"`
+	EvalCount       int `json:"eval_count"`
+}
+
+type usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	InputTokens      int `json:"input_tokens"`
+	OutputTokens     int `json:"output_tokens"`
+}
+
+func (in *usagePayload) toUsage() (Usage, bool) {
+	u := in.Usage
+	if in.Response != nil && in.Response.Usage != nil {
+		u = in.Response.Usage
+	}
+
+	if u != nil {
+		return Usage{
+			PromptTokens:     u.PromptTokens + u.InputTokens,
+			CompletionTokens: u.CompletionTokens + u.OutputTokens,
+		}, true
+	}
+
+	if in.PromptEvalCount > 0 || in.EvalCount > 0 {
+		return Usage{PromptTokens: in.PromptEvalCount, CompletionTokens: in.EvalCount}, true
+	}
+
+	return Usage{}, false
+}
+
+// isUsageChunk returns true for the chat completion chunk that only carries the usage.
+func (in *usagePayload) isUsageChunk() bool {
+	return in.Usage != nil && string(bytes.TrimSpace(in.Choices)) == "[]"
+}
+
+type usageResponseWriter struct {
+	http.ResponseWriter
+
+	stripUsage  bool
+	status      int
+	wroteHeader bool
+	streaming   bool
+	pending     []byte
+	body        bytes.Buffer
+	usage       Usage
+}
+
+func (in *usageResponseWriter) WriteHeader(statusCode int) {
+	if !in.wroteHeader {
+		in.wroteHeader = true
+		in.status = statusCode
+		in.streaming = strings.HasPrefix(in.Header().Get("Content-Type"), contentTypeEventStream)
+	}
+
+	in.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (in *usageResponseWriter) Write(b []byte) (int, error) {
+	if !in.wroteHeader {
+		in.WriteHeader(http.StatusOK)
+	}
+
+	if !in.streaming {
+		if in.body.Len()+len(b) <= maxCapturedBodySize {
+			in.body.Write(b)
+		}
+		return in.ResponseWriter.Write(b)
+	}
+
+	in.pending = append(in.pending, b...)
+	for {
+		idx := bytes.Index(in.pending, []byte(sseEventSeparator))
+		if idx < 0 {
+			break
+		}
+
+		event := in.pending[:idx+len(sseEventSeparator)]
+		in.pending = in.pending[idx+len(sseEventSeparator):]
+		if !in.processEvent(event) {
+			continue
+		}
+
+		if _, err := in.ResponseWriter.Write(event); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(b), nil
+}
+
+// processEvent reads the usage from the server-sent event and returns false
+// if the event should not be forwarded to the caller.
+func (in *usageResponseWriter) processEvent(event []byte) bool {
+	for _, line := range strings.Split(string(event), "\n") {
+		data, ok := strings.CutPrefix(line, sseDataPrefix)
+		if !ok {
+			continue
+		}
+
+		var payload usagePayload
+		if err := json.Unmarshal([]byte(strings.TrimSpace(data)), &payload); err != nil {
+			continue
+		}
+
+		if u, ok := payload.toUsage(); ok {
+			in.usage = u
+		}
+
+		if in.stripUsage && payload.isUsageChunk() {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (in *usageResponseWriter) Flush() {
+	if flusher, ok := in.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (in *usageResponseWriter) Unwrap() http.ResponseWriter {
+	return in.ResponseWriter
+}
+
+// finish forwards any incomplete stream event and reads the usage from non-streaming response.
+func (in *usageResponseWriter) finish() {
+	if len(in.pending) > 0 {
+		if in.processEvent(in.pending) {
+			_, _ = in.ResponseWriter.Write(in.pending)
+		}
+		in.pending = nil
+	}
+
+	if in.streaming || in.body.Len() == 0 {
+		return
+	}
+
+	var payload usagePayload
+	if err := json.Unmarshal(in.body.Bytes(), &payload); err != nil {
+		return
+	}
+
+	if u, ok := payload.toUsage(); ok {
+		in.usage = u
+	}
+}
+
+func (in *usageResponseWriter) statusCode() int {
+	if !in.wroteHeader {
+		return http.StatusOK
+	}
+
+	return in.status
+}
@@ -0,0 +1,136 @@
+package metrics
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// serve routes the request through a router, so the handler sees the matched route.
+func serve(handler http.HandlerFunc, path string, w http.ResponseWriter, request *http.Request) {
+	router := mux.NewRouter()
+	router.HandleFunc(path, handler)
+	router.ServeHTTP(w, request)
+}
+
+func TestInstrumentReadsNonStreamingUsage(t *testing.T) {
+	handler := Instrument("test-non-streaming", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"1","choices":[],"usage":{"prompt_tokens":11,"completion_tokens":7,"total_tokens":18}}`))
+	})
+
+	request := httptest.NewRequest(http.MethodPost, "/openai/v1/chat/completions", bytes.NewBufferString(`{"model":"gpt-5","messages":[]}`))
+	serve(handler, "/openai/v1/chat/completions", httptest.NewRecorder(), request)
+
+	r := recorder.(*prometheusRecorder)
+	if got := testutil.ToFloat64(r.tokensCounter.WithLabelValues("test-non-streaming", "gpt-5", TokenTypePrompt.String())); got != 11 {
+		t.Errorf("prompt tokens: got %v, want 11", got)
+	}
+	if got := testutil.ToFloat64(r.tokensCounter.WithLabelValues("test-non-streaming", "gpt-5", TokenTypeCompletion.String())); got != 7 {
+		t.Errorf("completion tokens: got %v, want 7", got)
+	}
+	if got := testutil.ToFloat64(r.requestsCounter.WithLabelValues("test-non-streaming", "gpt-5", "/openai/v1/chat/completions", "200")); got != 1 {
+		t.Errorf("requests: got %v, want 1", got)
+	}
+}
+
+func TestInstrumentReadsStreamingUsage(t *testing.T) {
+	var upstreamRequest map[string]any
+	handler := Instrument("test-streaming", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &upstreamRequest)
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"content\":\"Hi\"}}],\"usage\":null}\n\n")
+		// usage chunk split across writes
+		_, _ = fmt.Fprint(w, "data: {\"choices\":[],\"usage\":{\"prompt_tokens\":3,")
+		_, _ = fmt.Fprint(w, "\"completion_tokens\":2}}\n\n")
+		_, _ = fmt.Fprint(w, "data: [DONE]\n\n")
+	})
+
+	request := httptest.NewRequest(http.MethodPost, "/openai/v1/chat/completions", bytes.NewBufferString(`{"model":"gpt-5","stream":true,"messages":[]}`))
+	response := httptest.NewRecorder()
+	handler(response, request)
+
+	streamOptions, _ := upstreamRequest["stream_options"].(map[string]any)
+	if includeUsage, _ := streamOptions["include_usage"].(bool); !includeUsage {
+		t.Errorf("expected include_usage to be enabled upstream, got %v", upstreamRequest["stream_options"])
+	}
+	if strings.Contains(response.Body.String(), "prompt_tokens") {
+		t.Errorf("expected usage chunk to be stripped, got %q", response.Body.String())
+	}
+	if !strings.HasSuffix(response.Body.String(), "data: [DONE]\n\n") {
+		t.Errorf("expected stream to be forwarded, got %q", response.Body.String())
+	}
+
+	r := recorder.(*prometheusRecorder)
+	if got := testutil.ToFloat64(r.tokensCounter.WithLabelValues("test-streaming", "gpt-5", TokenTypePrompt.String())); got != 3 {
+		t.Errorf("prompt tokens: got %v, want 3", got)
+	}
+	if got := testutil.ToFloat64(r.tokensCounter.WithLabelValues("test-streaming", "gpt-5", TokenTypeCompletion.String())); got != 2 {
+		t.Errorf("completion tokens: got %v, want 2", got)
+	}
+}
+
+func TestInstrumentRecordsUpstreamErrors(t *testing.T) {
+	for _, code := range []int{http.StatusTooManyRequests, http.StatusBadGateway} {
+		handler := Instrument("test-errors", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(code)
+		})
+
+		request := httptest.NewRequest(http.MethodPost, "/openai/v1/embeddings", bytes.NewBufferString(`{"model":"text-embedding-3-small"}`))
+		serve(handler, "/openai/v1/embeddings", httptest.NewRecorder(), request)
+	}
+
+	r := recorder.(*prometheusRecorder)
+	if got := testutil.ToFloat64(r.upstreamErrorsCounter.WithLabelValues("test-errors", "text-embedding-3-small", "429")); got != 0 {
+		t.Errorf("client errors: got %v, want 0", got)
+	}
+	if got := testutil.ToFloat64(r.upstreamErrorsCounter.WithLabelValues("test-errors", "text-embedding-3-small", "502")); got != 1 {
+		t.Errorf("upstream errors: got %v, want 1", got)
+	}
+}
+
+func TestInstrumentBoundsLabelCardinality(t *testing.T) {
+	handler := Instrument("test-labels", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"usage":{"prompt_tokens":5,"completion_tokens":1}}`))
+	})
+
+	for _, model := range []string{"random-1", "random-2"} {
+		request := httptest.NewRequest(http.MethodPost, "/openai/v1/chat/completions", bytes.NewBufferString(`{"model":"`+model+`"}`))
+		serve(handler, "/openai/v1/chat/completions", httptest.NewRecorder(), request)
+	}
+	// not routed, so the path is not known to be a valid endpoint
+	handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/openai/v1/chat/completions/random", bytes.NewBufferString(`{"model":"gpt-5"}`)))
+
+	r := recorder.(*prometheusRecorder)
+	if got := testutil.ToFloat64(r.requestsCounter.WithLabelValues("test-labels", unknownModel, "/openai/v1/chat/completions", "200")); got != 2 {
+		t.Errorf("requests with unknown model: got %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(r.tokensCounter.WithLabelValues("test-labels", unknownModel, TokenTypePrompt.String())); got != 10 {
+		t.Errorf("prompt tokens of unknown model: got %v, want 10", got)
+	}
+	if got := testutil.ToFloat64(r.requestsCounter.WithLabelValues("test-labels", "gpt-5", unknownEndpoint, "200")); got != 1 {
+		t.Errorf("requests with unknown endpoint: got %v, want 1", got)
+	}
+}
+
+func TestRegisterModels(t *testing.T) {
+	if got := modelLabel("custom-model"); got != unknownModel {
+		t.Errorf("model label before registration: got %q, want %q", got, unknownModel)
+	}
+
+	RegisterModels("custom-model")
+	if got := modelLabel("custom-model"); got != "custom-model" {
+		t.Errorf("model label after registration: got %q, want %q", got, "custom-model")
+	}
+}
@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	recorder = (&prometheusRecorder{}).init()
+)
+
+type prometheusRecorder struct {
+	requestsCounter       *prometheus.CounterVec
+	upstreamErrorsCounter *prometheus.CounterVec
+	tokensCounter         *prometheus.CounterVec
+	requestDuration       *prometheus.HistogramVec
+	rateLimitedCounter    *prometheus.CounterVec
+}
+
+func (in *prometheusRecorder) Request(provider, model, endpoint string, code int, duration time.Duration) {
+	statusCode := strconv.Itoa(code)
+	in.requestsCounter.WithLabelValues(provider, model, endpoint, statusCode).Inc()
+	in.requestDuration.WithLabelValues(provider, model, endpoint).Observe(duration.Seconds())
+
+	// Client errors are caused by the caller, only server errors and failures
+	// to reach the upstream are counted as upstream errors.
+	if code >= http.StatusInternalServerError {
+		in.upstreamErrorsCounter.WithLabelValues(provider, model, statusCode).Inc()
+	}
+}
+
+func (in *prometheusRecorder) Usage(provider, model string, usage Usage) {
+	if usage.PromptTokens > 0 {
+		in.tokensCounter.WithLabelValues(provider, model, TokenTypePrompt.String()).Add(float64(usage.PromptTokens))
+	}
+
+	if usage.CompletionTokens > 0 {
+		in.tokensCounter.WithLabelValues(provider, model, TokenTypeCompletion.String()).Add(float64(usage.CompletionTokens))
+	}
+}
+
+func (in *prometheusRecorder) RateLimited(limit string) {
+	in.rateLimitedCounter.WithLabelValues(limit).Inc()
+}
+
+func (in *prometheusRecorder) init() Recorder {
+	in.requestsCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: RequestsMetricName,
+		Help: RequestsMetricDescription,
+	}, []string{MetricLabelProvider, MetricLabelModel, MetricLabelEndpoint, MetricLabelCode})
+	in.upstreamErrorsCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: UpstreamErrorsMetricName,
+		Help: UpstreamErrorsMetricDescription,
+	}, []string{MetricLabelProvider, MetricLabelModel, MetricLabelCode})
+	in.tokensCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: TokensMetricName,
+		Help: TokensMetricDescription,
+	}, []string{MetricLabelProvider, MetricLabelModel, MetricLabelTokenType})
+	in.requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    RequestDurationMetricName,
+		Help:    RequestDurationMetricDescription,
+		Buckets: []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300},
+	}, []string{MetricLabelProvider, MetricLabelModel, MetricLabelEndpoint})
+	in.rateLimitedCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: RateLimitedMetricName,
+		Help: RateLimitedMetricDescription,
+	}, []string{MetricLabelLimit})
+
+	return in
+}
+
+func Record() Recorder {
+	return recorder
+}
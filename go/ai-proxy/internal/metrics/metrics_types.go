@@ -0,0 +1,59 @@
+package metrics
+
+import (
+	"time"
+)
+
+const (
+	// Endpoint serves metrics in the Prometheus format.
+	Endpoint = "/metrics"
+
+	RequestsMetricName        = "ai_proxy_requests_total"
+	RequestsMetricDescription = "The total number of requests proxied to the upstream provider"
+
+	UpstreamErrorsMetricName        = "ai_proxy_upstream_errors_total"
+	UpstreamErrorsMetricDescription = "The total number of requests that failed with a server error status code"
+
+	TokensMetricName        = "ai_proxy_tokens_total"
+	TokensMetricDescription = "The total number of tokens reported by the upstream provider"
+
+	RequestDurationMetricName        = "ai_proxy_request_duration_seconds"
+	RequestDurationMetricDescription = "The time it takes to serve a proxied request, including streaming"
+
+	RateLimitedMetricName        = "ai_proxy_rate_limited_requests_total"
+	RateLimitedMetricDescription = "The total number of requests rejected by the client rate limits"
+
+	MetricLabelProvider  = "provider"
+	MetricLabelModel     = "model"
+	MetricLabelEndpoint  = "endpoint"
+	MetricLabelCode      = "code"
+	MetricLabelTokenType = "type"
+	MetricLabelLimit     = "limit"
+)
+
+type TokenType string
+
+func (in TokenType) String() string {
+	return string(in)
+}
+
+const (
+	TokenTypePrompt     TokenType = "prompt"
+	TokenTypeCompletion TokenType = "completion"
+)
+
+// Usage is a token usage reported by the upstream provider.
+type Usage struct {
+	PromptTokens     int
+	CompletionTokens int
+}
+
+func (in Usage) Total() int {
+	return in.PromptTokens + in.CompletionTokens
+}
+
+type Recorder interface {
+	Request(provider, model, endpoint string, code int, duration time.Duration)
+	Usage(provider, model string, usage Usage)
+	RateLimited(limit string)
+}
@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	unknownEndpoint = "unknown"
+)
+
+// defaultModels are recorded in metric labels by name out of the box. The model is read
+// from the request body, so any model that was not registered is recorded as unknownModel
+// to keep the number of series bounded.
+var defaultModels = []string{
+	"gpt-4o", "gpt-4o-mini", "gpt-4.1", "gpt-4.1-mini", "gpt-4.1-nano",
+	"gpt-5", "gpt-5-mini", "gpt-5-nano", "gpt-5.1", "gpt-5.2", "gpt-5.4",
+	"o3", "o3-mini", "o4-mini",
+	"text-embedding-3-small", "text-embedding-3-large", "text-embedding-ada-002",
+	"whisper-1", "gpt-4o-transcribe", "gpt-4o-mini-transcribe",
+	"claude-opus-4-1", "claude-opus-4-6", "claude-sonnet-4-5", "claude-sonnet-4-6", "claude-haiku-4-5",
+	"gemini-2.5-pro", "gemini-2.5-flash", "gemini-2.5-flash-lite",
+}
+
+var (
+	modelsMutex sync.RWMutex
+	models      = make(map[string]struct{})
+)
+
+func init() {
+	RegisterModels(defaultModels...)
+}
+
+// RegisterModels adds models that are recorded in metric labels by name.
+func RegisterModels(names ...string) {
+	modelsMutex.Lock()
+	defer modelsMutex.Unlock()
+
+	for _, name := range names {
+		if len(name) > 0 {
+			models[name] = struct{}{}
+		}
+	}
+}
+
+// modelLabel returns the model if it is registered and unknownModel otherwise.
+func modelLabel(model string) string {
+	modelsMutex.RLock()
+	defer modelsMutex.RUnlock()
+
+	if _, ok := models[model]; ok {
+		return model
+	}
+
+	return unknownModel
+}
+
+// endpointLabel returns the path template of the matched route, as the raw request path
+// is controlled by the client.
+func endpointLabel(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if template, err := route.GetPathTemplate(); err == nil {
+			return template
+		}
+	}
+
+	return unknownEndpoint
+}
@@ -0,0 +1,88 @@
+package ratelimit
+
+import (
+	"encoding/json"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ollama/ollama/openai"
+	"k8s.io/klog/v2"
+
+	"github.com/pluralsh/console/go/ai-proxy/api/ollama"
+	apioai "github.com/pluralsh/console/go/ai-proxy/api/openai"
+	"github.com/pluralsh/console/go/ai-proxy/internal/log"
+	"github.com/pluralsh/console/go/ai-proxy/internal/metrics"
+)
+
+const (
+	errorTypeRateLimit = "rate_limit_exceeded"
+	errorTypeQuota     = "insufficient_quota"
+)
+
+// Enforce wraps the proxy handler and rejects callers that exceeded their limits
+// with 429 status code before the request reaches the upstream provider.
+// Token usage is read from the metrics.Instrument handler, so it must be wrapped by Enforce.
+func Enforce(limiter *Limiter, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		caller := limiter.identify(r)
+		decision := limiter.Allow(caller)
+		if !decision.Allowed {
+			klog.V(log.LogLevelDebug).InfoS("rejecting rate limited request", "limit", decision.Limit, "retryAfter", decision.RetryAfter, "path", r.URL.Path)
+			metrics.Record().RateLimited(decision.Limit.String())
+			writeRateLimitError(w, r, decision)
+			return
+		}
+
+		ctx := metrics.WithUsageListener(r.Context(), func(_ string, usage metrics.Usage) {
+			limiter.Consume(caller, int64(usage.Total()))
+		})
+		next(w, r.WithContext(ctx))
+	}
+}
+
+// identify returns the caller identity read from the configured header, bearer token
+// or the client IP address.
+func (in *Limiter) identify(r *http.Request) string {
+	if len(in.config.IdentityHeader) > 0 {
+		if identity := r.Header.Get(in.config.IdentityHeader); len(identity) > 0 {
+			return identity
+		}
+	}
+
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok && len(token) > 0 {
+		return token
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+func writeRateLimitError(w http.ResponseWriter, r *http.Request, decision Decision) {
+	errorType := errorTypeRateLimit
+	message := "Rate limit reached for requests per minute. Please try again later."
+	if decision.Limit == LimitTokensPerDay {
+		errorType = errorTypeQuota
+		message = "Daily token quota exceeded. Please try again later."
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(decision.RetryAfter.Seconds()))))
+	w.WriteHeader(http.StatusTooManyRequests)
+
+	response := openai.ErrorResponse{Error: openai.Error{Message: message, Type: errorType, Code: &errorType}}
+	var payload any = response
+	if r.URL.Path == ollama.EndpointChat {
+		payload = apioai.FromErrorResponse(http.StatusTooManyRequests)(response)
+	}
+
+	if err := json.NewEncoder(w).Encode(payload); err != nil {
+		klog.Errorf("Error encoding response: %v", err)
+	}
+}
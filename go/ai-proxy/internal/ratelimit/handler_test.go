@@ -0,0 +1,94 @@
+package ratelimit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ollama/ollama/openai"
+
+	"github.com/pluralsh/console/go/ai-proxy/api"
+	"github.com/pluralsh/console/go/ai-proxy/internal/metrics"
+)
+
+func TestEnforceRequestsPerMinute(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 30, 0, time.UTC)
+	limiter := NewLimiter(&api.RateLimitConfig{
+		IdentityHeader: "X-Caller",
+		Default:        api.RateLimits{RequestsPerMinute: 1},
+		Callers:        map[string]api.RateLimits{"batch": {RequestsPerMinute: 2}},
+	})
+	limiter.now = func() time.Time { return now }
+
+	calls := 0
+	handler := Enforce(limiter, func(w http.ResponseWriter, r *http.Request) { calls++ })
+	send := func(caller string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, "/openai/v1/chat/completions", strings.NewReader(`{}`))
+		request.Header.Set("X-Caller", caller)
+		response := httptest.NewRecorder()
+		handler(response, request)
+		return response
+	}
+
+	for i, want := range []int{http.StatusOK, http.StatusTooManyRequests} {
+		if got := send("agent").Code; got != want {
+			t.Errorf("agent request %d: got %d, want %d", i, got, want)
+		}
+	}
+	for i, want := range []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests} {
+		if got := send("batch").Code; got != want {
+			t.Errorf("batch request %d: got %d, want %d", i, got, want)
+		}
+	}
+	if calls != 3 {
+		t.Errorf("upstream calls: got %d, want 3", calls)
+	}
+
+	response := send("agent")
+	if got := response.Header().Get("Retry-After"); got != "30" {
+		t.Errorf("Retry-After: got %q, want 30", got)
+	}
+
+	var errorResponse openai.ErrorResponse
+	if err := json.Unmarshal(response.Body.Bytes(), &errorResponse); err != nil {
+		t.Fatalf("failed to parse error response: %v", err)
+	}
+	if errorResponse.Error.Type != errorTypeRateLimit {
+		t.Errorf("error type: got %q, want %q", errorResponse.Error.Type, errorTypeRateLimit)
+	}
+
+	now = now.Add(time.Minute)
+	if got := send("agent").Code; got != http.StatusOK {
+		t.Errorf("request after window reset: got %d, want 200", got)
+	}
+}
+
+func TestEnforceTokensPerDay(t *testing.T) {
+	limiter := NewLimiter(&api.RateLimitConfig{Default: api.RateLimits{TokensPerDay: 100}})
+	handler := Enforce(limiter, metrics.Instrument("test-ratelimit", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"choices":[],"usage":{"prompt_tokens":60,"completion_tokens":40}}`))
+	}))
+	send := func() *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, "/api/chat", strings.NewReader(`{"model":"llama"}`))
+		request.Header.Set("Authorization", "Bearer caller-token")
+		response := httptest.NewRecorder()
+		handler(response, request)
+		return response
+	}
+
+	if got := send().Code; got != http.StatusOK {
+		t.Fatalf("first request: got %d, want 200", got)
+	}
+
+	response := send()
+	if response.Code != http.StatusTooManyRequests {
+		t.Fatalf("second request: got %d, want 429", response.Code)
+	}
+	if !strings.Contains(response.Body.String(), `"error":"Daily token quota exceeded`) {
+		t.Errorf("expected ollama error body, got %q", response.Body.String())
+	}
+}
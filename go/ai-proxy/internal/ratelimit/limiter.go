@@ -0,0 +1,137 @@
+package ratelimit
+
+import (
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	"github.com/pluralsh/console/go/ai-proxy/api"
+)
+
+type Limit string
+
+func (in Limit) String() string {
+	return string(in)
+}
+
+const (
+	LimitRequestsPerMinute Limit = "requests_per_minute"
+	LimitTokensPerDay      Limit = "tokens_per_day"
+)
+
+// Decision is the result of the admission check.
+type Decision struct {
+	Allowed bool
+
+	// Limit that rejected the request.
+	Limit Limit
+
+	// RetryAfter is the time left until the exceeded limit window resets.
+	RetryAfter time.Duration
+}
+
+// sweepInterval is how often callers without any usage left in their windows are evicted.
+const sweepInterval = time.Minute
+
+// callerKey identifies a caller in the limiter state. Callers are often identified by
+// their bearer token, so only its hash is kept in memory.
+type callerKey [sha256.Size]byte
+
+type callerState struct {
+	minute   time.Time
+	requests int64
+	day      time.Time
+	tokens   int64
+}
+
+// Limiter enforces per-caller limits using fixed windows. Request limits are checked
+// and consumed on admission. Token usage is only known once the response is served,
+// so callers are rejected after they have exhausted their daily token quota.
+type Limiter struct {
+	config *api.RateLimitConfig
+
+	mu      sync.Mutex
+	callers map[callerKey]*callerState
+	swept   time.Time
+	now     func() time.Time
+}
+
+func NewLimiter(config *api.RateLimitConfig) *Limiter {
+	return &Limiter{
+		config:  config,
+		callers: make(map[callerKey]*callerState),
+		now:     time.Now,
+	}
+}
+
+// Allow checks the caller limits and counts the request if it is admitted.
+func (in *Limiter) Allow(caller string) Decision {
+	limits := in.config.ForCaller(caller)
+
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	now := in.now().UTC()
+	in.sweep(now)
+	state := in.state(caller, now)
+	if limits.TokensPerDay > 0 && state.tokens >= limits.TokensPerDay {
+		return Decision{Limit: LimitTokensPerDay, RetryAfter: state.day.Add(24 * time.Hour).Sub(now)}
+	}
+
+	if limits.RequestsPerMinute > 0 && state.requests >= limits.RequestsPerMinute {
+		return Decision{Limit: LimitRequestsPerMinute, RetryAfter: state.minute.Add(time.Minute).Sub(now)}
+	}
+
+	state.requests++
+	return Decision{Allowed: true}
+}
+
+// Consume counts tokens used by the caller towards the daily quota.
+func (in *Limiter) Consume(caller string, tokens int64) {
+	if tokens <= 0 {
+		return
+	}
+
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	in.state(caller, in.now().UTC()).tokens += tokens
+}
+
+// state returns the caller state with windows reset if they already expired.
+func (in *Limiter) state(caller string, now time.Time) *callerState {
+	key := callerKey(sha256.Sum256([]byte(caller)))
+	state, ok := in.callers[key]
+	if !ok {
+		state = &callerState{}
+		in.callers[key] = state
+	}
+
+	if minute := now.Truncate(time.Minute); !state.minute.Equal(minute) {
+		state.minute = minute
+		state.requests = 0
+	}
+
+	if day := now.Truncate(24 * time.Hour); !state.day.Equal(day) {
+		state.day = day
+		state.tokens = 0
+	}
+
+	return state
+}
+
+// sweep evicts idle callers, whose state would be reset to zero on their next request
+// anyway. Without it every caller ever seen, e.g. every token sent, would be kept.
+func (in *Limiter) sweep(now time.Time) {
+	if now.Sub(in.swept) < sweepInterval {
+		return
+	}
+	in.swept = now
+
+	minute, day := now.Truncate(time.Minute), now.Truncate(24*time.Hour)
+	for key, state := range in.callers {
+		if state.day.Before(day) || (state.minute.Before(minute) && state.tokens == 0) {
+			delete(in.callers, key)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pluralsh/console/go/ai-proxy/api"
+)
+
+func TestLimiterEvictsIdleCallers(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 30, 0, time.UTC)
+	limiter := NewLimiter(&api.RateLimitConfig{Default: api.RateLimits{RequestsPerMinute: 1, TokensPerDay: 100}})
+	limiter.now = func() time.Time { return now }
+
+	limiter.Allow("sk-idle")
+	limiter.Allow("sk-active")
+	limiter.Consume("sk-active", 100)
+	if got := len(limiter.callers); got != 2 {
+		t.Fatalf("callers: got %d, want 2", got)
+	}
+
+	now = now.Add(time.Minute)
+	limiter.Allow("sk-other")
+	if got := len(limiter.callers); got != 2 {
+		t.Errorf("callers after the minute window: got %d, want 2", got)
+	}
+	if decision := limiter.Allow("sk-active"); decision.Allowed || decision.Limit != LimitTokensPerDay {
+		t.Errorf("active caller lost its token usage: %+v", decision)
+	}
+
+	now = now.Add(24 * time.Hour)
+	limiter.Allow("sk-other")
+	if got := len(limiter.callers); got != 1 {
+		t.Errorf("callers after the day window: got %d, want 1", got)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"k8s.io/klog/v2"
 
 	"github.com/pluralsh/console/go/ai-proxy/api"
@@ -14,58 +15,125 @@ import (
 	"github.com/pluralsh/console/go/ai-proxy/environment"
 	"github.com/pluralsh/console/go/ai-proxy/internal/helpers"
 	"github.com/pluralsh/console/go/ai-proxy/internal/log"
+	"github.com/pluralsh/console/go/ai-proxy/internal/metrics"
+	"github.com/pluralsh/console/go/ai-proxy/internal/ratelimit"
 	"github.com/pluralsh/console/go/ai-proxy/proxy"
 )
 
 func main() {
+	router := mux.NewRouter()
+	router.Handle(metrics.Endpoint, promhttp.Handler())
+	metrics.RegisterModels(args.MetricsModels()...)
+	admit := admission()
+	if len(args.RoutingConfig()) > 0 {
+		registerRoutingProxy(router, admit)
+	} else {
+		registerProviderProxy(router, admit)
+	}
+
+	klog.V(log.LogLevelMinimal).InfoS("Listening and serving HTTP", "address", args.Address())
+	if err := http.ListenAndServe(args.Address(), router); err != nil {
+		klog.ErrorS(err, "Could not run the router")
+		os.Exit(1)
+	}
+}
+
+func mantleConfig() api.MantleConfig {
+	return api.MantleConfig{
+		APIKey:        args.BedrockMantleKey(),
+		AWSRegion:     args.BedrockMantleAWSRegion(),
+		ModelPrefixes: args.BedrockMantleModelPrefixes(),
+		SigV4:         args.MantleSigV4(),
+	}
+}
+
+// admission returns a handler wrapper that enforces client rate limits if they are configured.
+func admission() func(http.HandlerFunc) http.HandlerFunc {
+	if len(args.RateLimitConfig()) == 0 {
+		return func(next http.HandlerFunc) http.HandlerFunc { return next }
+	}
+
+	config, err := api.LoadRateLimitConfig(args.RateLimitConfig())
+	if err != nil {
+		klog.ErrorS(err, "Could not load rate limit config")
+		os.Exit(1)
+	}
+
+	limiter := ratelimit.NewLimiter(config)
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return ratelimit.Enforce(limiter, next)
+	}
+}
+
+// registerRoutingProxy serves multiple providers at once based on the routing config.
+func registerRoutingProxy(router *mux.Router, admit func(http.HandlerFunc) http.HandlerFunc) {
+	klog.V(log.LogLevelMinimal).InfoS("Starting AI Proxy", "routingConfig", args.RoutingConfig(), "version", environment.Version, "commit", environment.Commit)
+
+	config, err := api.LoadRoutingConfig(args.RoutingConfig())
+	if err != nil {
+		klog.ErrorS(err, "Could not load routing config")
+		os.Exit(1)
+	}
+
+	metrics.RegisterModels(config.ExactModels()...)
+
+	rp, err := proxy.NewRouterProxy(config, mantleConfig())
+	if err != nil {
+		klog.ErrorS(err, "Could not create routing proxy")
+		os.Exit(1)
+	}
+
+	router.HandleFunc(ollama.EndpointChat, admit(rp.Proxy()))
+	router.HandleFunc(openai.EndpointChat, admit(rp.Proxy()))
+	router.HandleFunc(openai.EndpointResponses, admit(rp.Proxy()))
+	router.HandleFunc(openai.EndpointEmbeddings, admit(rp.Proxy()))
+	router.HandleFunc(helpers.EndpointTokenHealth, helpers.TokenHealthHandler(rp.TokenRotators()))
+}
+
+// registerProviderProxy serves a single provider configured via provider flags.
+func registerProviderProxy(router *mux.Router, admit func(http.HandlerFunc) http.HandlerFunc) {
 	klog.V(log.LogLevelMinimal).InfoS("Starting AI Proxy", "provider", args.Provider(), "version", environment.Version, "commit", environment.Commit)
 
-	router := mux.NewRouter()
 	tokenRotator := helpers.NewRoundRobinTokenRotator(args.ProviderTokens())
+	router.HandleFunc(helpers.EndpointTokenHealth, helpers.TokenHealthHandler(map[string]*helpers.RoundRobinTokenRotator{
+		args.Provider().String(): tokenRotator,
+	}))
 
 	p, err := proxy.NewOllamaTranslationProxy(args.Provider(), args.ProviderHost(), args.ProviderServiceAccount(), tokenRotator)
 	if err == nil {
-		router.HandleFunc(ollama.EndpointChat, p.Proxy())
+		router.HandleFunc(ollama.EndpointChat, admit(metrics.Instrument(args.Provider().String(), p.Proxy())))
 	} else if args.Provider() != api.ProviderBedrock {
 		klog.ErrorS(err, "Could not create proxy")
 		os.Exit(1)
 	}
 
-	if args.OpenAICompatible() {
-		mantleConfig := api.MantleConfig{
-			APIKey:        args.BedrockMantleKey(),
-			AWSRegion:     args.BedrockMantleAWSRegion(),
-			ModelPrefixes: args.BedrockMantleModelPrefixes(),
-			SigV4:         args.MantleSigV4(),
-		}
-		op, err := proxy.NewOpenAIProxy(args.Provider(), args.ProviderHost(), args.ProviderAwsRegion(), tokenRotator, mantleConfig)
-		if err != nil {
-			klog.ErrorS(err, "Could not create proxy")
-			os.Exit(1)
-		}
-		ep, err := proxy.NewOpenAIEmbeddingsProxy(args.Provider(), args.ProviderHost(), args.ProviderAwsRegion(), tokenRotator, mantleConfig)
-		if err != nil {
-			klog.ErrorS(err, "Could not create embedding proxy")
-			os.Exit(1)
-		}
-		router.HandleFunc(openai.EndpointChat, op.Proxy())
-		router.HandleFunc(openai.EndpointResponses, op.Proxy())
-		router.HandleFunc(openai.EndpointEmbeddings, ep.Proxy())
-
-		if args.Provider() == api.ProviderOpenAI {
-			aud, err := proxy.NewOpenAIAudioProxy(args.Provider(), args.ProviderHost(), args.ProviderAwsRegion(), tokenRotator)
-			if err != nil {
-				klog.ErrorS(err, "Could not create OpenAI audio proxy")
-				os.Exit(1)
-			}
-			router.HandleFunc(openai.EndpointAudioTranscriptions, aud.Proxy())
-			router.HandleFunc(openai.EndpointAudioTranslations, aud.Proxy())
-		}
+	if !args.OpenAICompatible() {
+		return
 	}
 
-	klog.V(log.LogLevelMinimal).InfoS("Listening and serving HTTP", "address", args.Address())
-	if err := http.ListenAndServe(args.Address(), router); err != nil {
-		klog.ErrorS(err, "Could not run the router")
+	op, err := proxy.NewOpenAIProxy(args.Provider(), args.ProviderHost(), args.ProviderAwsRegion(), tokenRotator, mantleConfig())
+	if err != nil {
+		klog.ErrorS(err, "Could not create proxy")
 		os.Exit(1)
 	}
+	router.HandleFunc(openai.EndpointChat, admit(metrics.Instrument(args.Provider().String(), op.Proxy())))
+	router.HandleFunc(openai.EndpointResponses, admit(metrics.Instrument(args.Provider().String(), op.Proxy())))
+
+	ep, err := proxy.NewOpenAIEmbeddingsProxy(args.Provider(), args.ProviderHost(), args.ProviderAwsRegion(), tokenRotator, mantleConfig())
+	if err == nil {
+		router.HandleFunc(openai.EndpointEmbeddings, admit(metrics.Instrument(args.Provider().String(), ep.Proxy())))
+	} else if args.Provider() != api.ProviderAnthropic {
+		klog.ErrorS(err, "Could not create embedding proxy")
+		os.Exit(1)
+	}
+
+	if args.Provider() == api.ProviderOpenAI {
+		aud, err := proxy.NewOpenAIAudioProxy(args.Provider(), args.ProviderHost(), args.ProviderAwsRegion(), tokenRotator)
+		if err != nil {
+			klog.ErrorS(err, "Could not create OpenAI audio proxy")
+			os.Exit(1)
+		}
+		router.HandleFunc(openai.EndpointAudioTranscriptions, admit(metrics.Instrument(args.Provider().String(), aud.Proxy())))
+		router.HandleFunc(openai.EndpointAudioTranslations, admit(metrics.Instrument(args.Provider().String(), aud.Proxy())))
+	}
 }
@@ -0,0 +1,301 @@
+package anthropic
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ollama/ollama/openai"
+	"k8s.io/klog/v2"
+
+	"github.com/pluralsh/console/go/ai-proxy/api"
+	"github.com/pluralsh/console/go/ai-proxy/api/anthropic"
+	apioai "github.com/pluralsh/console/go/ai-proxy/api/openai"
+	"github.com/pluralsh/console/go/ai-proxy/internal/helpers"
+	"github.com/pluralsh/console/go/ai-proxy/internal/log"
+)
+
+const (
+	ChatCompletionChunkObject = "chat.completion.chunk"
+
+	sseDataPrefix = "data:"
+)
+
+type AnthropicProxy struct {
+	targetURL    url.URL
+	client       *http.Client
+	tokenRotator helpers.TokenRotator
+}
+
+func NewAnthropicProxy(host string, tokenRotator *helpers.RoundRobinTokenRotator) (api.OpenAIProxy, error) {
+	if len(tokenRotator.Tokens) == 0 {
+		return nil, fmt.Errorf("at least one token is required")
+	}
+
+	parsedURL, err := helpers.ParseProviderBaseURL(host)
+	if err != nil {
+		return nil, err
+	}
+
+	targetURL := *parsedURL
+	targetURL.Path = strings.TrimRight(targetURL.Path, "/") + anthropic.EndpointMessages
+
+	return &AnthropicProxy{
+		targetURL:    targetURL,
+		client:       &http.Client{Transport: helpers.NewTokenRetryTransport(tokenRotator, anthropic.HeaderAPIKey, "", http.DefaultTransport)},
+		tokenRotator: tokenRotator,
+	}, nil
+}
+
+func (a *AnthropicProxy) Proxy() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == apioai.EndpointResponses {
+			http.Error(w, "responses endpoint is only supported for openai provider", http.StatusBadRequest)
+			return
+		}
+
+		var openAIReq openai.ChatCompletionRequest
+		if err := json.NewDecoder(r.Body).Decode(&openAIReq); err != nil {
+			http.Error(w, "failed to parse openai request", http.StatusBadRequest)
+			return
+		}
+
+		input, err := anthropic.FromChatCompletionRequest(openAIReq)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to convert anthropic request: %s", err), http.StatusBadRequest)
+			return
+		}
+
+		response, err := a.send(r.Context(), input)
+		if err != nil {
+			klog.ErrorS(err, "call to anthropic failed")
+			http.Error(w, "failed to send request to anthropic", http.StatusBadGateway)
+			return
+		}
+		defer func() {
+			if err := response.Body.Close(); err != nil {
+				klog.Errorf("error closing response body: %v", err)
+			}
+		}()
+
+		if response.StatusCode != http.StatusOK {
+			a.handleError(w, response)
+			return
+		}
+
+		if openAIReq.Stream {
+			a.handleStreamingAnthropic(w, response, &openAIReq)
+		} else {
+			a.handleNonStreamingAnthropic(w, response)
+		}
+	}
+}
+
+func (a *AnthropicProxy) send(ctx context.Context, input anthropic.MessagesRequest) (*http.Response, error) {
+	body, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, a.targetURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(anthropic.HeaderAPIKey, a.tokenRotator.GetNextToken())
+	request.Header.Set(anthropic.HeaderVersion, anthropic.APIVersion)
+
+	klog.V(log.LogLevelDebug).InfoS("proxying request", "to", a.targetURL.String(), "model", input.Model, "stream", input.Stream)
+	return a.client.Do(request)
+}
+
+func (a *AnthropicProxy) handleError(w http.ResponseWriter, response *http.Response) {
+	var anthropicErr anthropic.ErrorResponse
+	body, _ := io.ReadAll(response.Body)
+	if err := json.Unmarshal(body, &anthropicErr); err != nil || len(anthropicErr.Error.Message) == 0 {
+		anthropicErr.Error = anthropic.Error{Type: http.StatusText(response.StatusCode), Message: string(body)}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+	if err := json.NewEncoder(w).Encode(anthropic.ToOpenAIErrorResponse(anthropicErr)); err != nil {
+		klog.Errorf("Error encoding response: %v", err)
+	}
+}
+
+func (a *AnthropicProxy) handleNonStreamingAnthropic(w http.ResponseWriter, response *http.Response) {
+	var output anthropic.MessagesResponse
+	if err := json.NewDecoder(response.Body).Decode(&output); err != nil {
+		klog.ErrorS(err, "failed to decode anthropic response")
+		http.Error(w, "failed to decode anthropic response", http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(anthropic.ToChatCompletion(output)); err != nil {
+		klog.Errorf("Error encoding response: %v", err)
+		return
+	}
+}
+
+func (a *AnthropicProxy) handleStreamingAnthropic(
+	w http.ResponseWriter,
+	response *http.Response,
+	req *openai.ChatCompletionRequest,
+) {
+	// Set up streaming headers (SSE)
+	w.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		klog.Error("Streaming not supported by server")
+		return
+	}
+
+	translator := newStreamTranslator(req)
+	scanner := bufio.NewScanner(response.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, sseDataPrefix) {
+			continue
+		}
+
+		var event anthropic.StreamEvent
+		if err := json.Unmarshal([]byte(strings.TrimSpace(strings.TrimPrefix(line, sseDataPrefix))), &event); err != nil {
+			klog.ErrorS(err, "failed to parse anthropic stream event")
+			continue
+		}
+
+		if event.Type == anthropic.EventError && event.Error != nil {
+			klog.ErrorS(fmt.Errorf("%s", event.Error.Message), "anthropic stream failed", "type", event.Error.Type)
+			payload, _ := json.Marshal(anthropic.ToOpenAIErrorResponse(anthropic.ErrorResponse{Error: *event.Error}))
+			_, _ = fmt.Fprintf(w, "data: %s\n\n", payload)
+			flusher.Flush()
+			break
+		}
+
+		for _, chunk := range translator.translate(event) {
+			payload, _ := json.Marshal(chunk)
+			_, _ = fmt.Fprintf(w, "data: %s\n\n", payload)
+		}
+		flusher.Flush()
+	}
+
+	if err := scanner.Err(); err != nil {
+		klog.ErrorS(err, "failed to read anthropic stream")
+	}
+
+	// Send OpenAI's `[DONE]` event to signal end of stream
+	_, _ = fmt.Fprintf(w, "data: [DONE]\n\n")
+	flusher.Flush()
+}
+
+// streamTranslator keeps the state required to map Messages API stream events
+// to OpenAI chat completion chunks.
+type streamTranslator struct {
+	id           string
+	model        string
+	created      int64
+	includeUsage bool
+	usage        anthropic.Usage
+	// toolIndexes maps content block indexes to OpenAI tool call indexes.
+	toolIndexes map[int]int
+}
+
+func newStreamTranslator(req *openai.ChatCompletionRequest) *streamTranslator {
+	return &streamTranslator{
+		model:        req.Model,
+		created:      time.Now().Unix(),
+		includeUsage: req.StreamOptions != nil && req.StreamOptions.IncludeUsage,
+		toolIndexes:  make(map[int]int),
+	}
+}
+
+func (in *streamTranslator) translate(event anthropic.StreamEvent) []openai.ChatCompletionChunk {
+	switch event.Type {
+	case anthropic.EventMessageStart:
+		if event.Message != nil {
+			in.id = event.Message.ID
+			in.usage = event.Message.Usage
+			if len(event.Message.Model) > 0 {
+				in.model = event.Message.Model
+			}
+		}
+		return []openai.ChatCompletionChunk{in.chunk(openai.Message{Role: anthropic.RoleAssistant}, nil)}
+	case anthropic.EventContentBlockStart:
+		if event.ContentBlock == nil || event.ContentBlock.Type != anthropic.ContentTypeToolUse {
+			return nil
+		}
+
+		index := len(in.toolIndexes)
+		in.toolIndexes[event.Index] = index
+		return []openai.ChatCompletionChunk{in.chunk(openai.Message{
+			Role:      anthropic.RoleAssistant,
+			ToolCalls: []openai.ToolCall{anthropic.ToToolCall(index, event.ContentBlock.ID, event.ContentBlock.Name, "")},
+		}, nil)}
+	case anthropic.EventContentBlockDelta:
+		if event.Delta == nil {
+			return nil
+		}
+
+		switch event.Delta.Type {
+		case anthropic.DeltaTypeText:
+			return []openai.ChatCompletionChunk{in.chunk(openai.Message{Role: anthropic.RoleAssistant, Content: event.Delta.Text}, nil)}
+		case anthropic.DeltaTypeInputJSON:
+			index, ok := in.toolIndexes[event.Index]
+			if !ok {
+				return nil
+			}
+
+			toolCall := anthropic.ToToolCall(index, "", "", event.Delta.PartialJSON)
+			return []openai.ChatCompletionChunk{in.chunk(openai.Message{Role: anthropic.RoleAssistant, ToolCalls: []openai.ToolCall{toolCall}}, nil)}
+		}
+	case anthropic.EventMessageDelta:
+		if event.Usage != nil {
+			in.usage.OutputTokens = event.Usage.OutputTokens
+		}
+		if event.Delta == nil || len(event.Delta.StopReason) == 0 {
+			return nil
+		}
+
+		return []openai.ChatCompletionChunk{in.chunk(openai.Message{}, anthropic.ToFinishReason(event.Delta.StopReason))}
+	case anthropic.EventMessageStop:
+		if !in.includeUsage {
+			return nil
+		}
+
+		usage := anthropic.ToUsage(in.usage)
+		chunk := in.chunk(openai.Message{}, nil)
+		chunk.Choices = []openai.ChunkChoice{}
+		chunk.Usage = &usage
+		return []openai.ChatCompletionChunk{chunk}
+	}
+
+	return nil
+}
+
+func (in *streamTranslator) chunk(delta openai.Message, finishReason *string) openai.ChatCompletionChunk {
+	return openai.ChatCompletionChunk{
+		Id:      in.id,
+		Object:  ChatCompletionChunkObject,
+		Created: in.created,
+		Model:   in.model,
+		Choices: []openai.ChunkChoice{
+			{
+				Index:        0,
+				Delta:        delta,
+				FinishReason: finishReason,
+			},
+		},
+	}
+}
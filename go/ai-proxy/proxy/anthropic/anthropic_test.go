@@ -0,0 +1,223 @@
+package anthropic
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ollama/ollama/openai"
+
+	"github.com/pluralsh/console/go/ai-proxy/api/anthropic"
+	"github.com/pluralsh/console/go/ai-proxy/internal/helpers"
+)
+
+func newTestProxy(t *testing.T, handler http.HandlerFunc) http.HandlerFunc {
+	t.Helper()
+
+	upstream := httptest.NewServer(handler)
+	t.Cleanup(upstream.Close)
+
+	proxy, err := NewAnthropicProxy(upstream.URL, helpers.NewRoundRobinTokenRotator([]string{"anthropic-key"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return proxy.Proxy()
+}
+
+func TestAnthropicProxyTranslatesChatCompletion(t *testing.T) {
+	var gotRequest anthropic.MessagesRequest
+	var gotHeaders http.Header
+	proxy := newTestProxy(t, func(w http.ResponseWriter, r *http.Request) {
+		gotHeaders = r.Header
+		if r.URL.Path != anthropic.EndpointMessages {
+			t.Errorf("path: got %q, want %q", r.URL.Path, anthropic.EndpointMessages)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&gotRequest); err != nil {
+			t.Fatal(err)
+		}
+
+		_, _ = w.Write([]byte(`{
+			"id": "msg_1",
+			"type": "message",
+			"role": "assistant",
+			"model": "claude-sonnet-4-5",
+			"content": [
+				{"type": "text", "text": "Checking the weather."},
+				{"type": "tool_use", "id": "toolu_1", "name": "weather", "input": {"city": "Paris"}}
+			],
+			"stop_reason": "tool_use",
+			"usage": {"input_tokens": 10, "output_tokens": 5, "cache_read_input_tokens": 2}
+		}`))
+	})
+
+	request := httptest.NewRequest(http.MethodPost, "/openai/v1/chat/completions", bytes.NewBufferString(`{
+		"model": "claude-sonnet-4-5",
+		"messages": [
+			{"role": "system", "content": "You are helpful."},
+			{"role": "user", "content": "What is the weather?"},
+			{"role": "assistant", "content": "", "tool_calls": [{"id": "toolu_0", "type": "function", "function": {"name": "weather", "arguments": "{\"city\":\"Berlin\"}"}}]},
+			{"role": "tool", "tool_call_id": "toolu_0", "content": "sunny"},
+			{"role": "user", "content": "And in Paris?"}
+		],
+		"tools": [{"type": "function", "function": {"name": "weather", "description": "Get weather", "parameters": {"type": "object", "properties": {"city": {"type": "string"}}, "required": ["city"]}}}]
+	}`))
+	response := httptest.NewRecorder()
+	proxy.ServeHTTP(response, request)
+
+	if got, want := gotHeaders.Get(anthropic.HeaderAPIKey), "anthropic-key"; got != want {
+		t.Errorf("%s: got %q, want %q", anthropic.HeaderAPIKey, got, want)
+	}
+	if got, want := gotHeaders.Get(anthropic.HeaderVersion), anthropic.APIVersion; got != want {
+		t.Errorf("%s: got %q, want %q", anthropic.HeaderVersion, got, want)
+	}
+	if got, want := gotRequest.System, "You are helpful."; got != want {
+		t.Errorf("system: got %q, want %q", got, want)
+	}
+	if got, want := gotRequest.MaxTokens, anthropic.DefaultMaxTokens; got != want {
+		t.Errorf("max_tokens: got %d, want %d", got, want)
+	}
+	if got, want := len(gotRequest.Tools), 1; got != want {
+		t.Fatalf("tools: got %d, want %d", got, want)
+	}
+
+	// user, assistant tool_use, user (tool_result merged with the follow-up question)
+	if got, want := len(gotRequest.Messages), 3; got != want {
+		t.Fatalf("messages: got %d, want %d", got, want)
+	}
+	if got, want := gotRequest.Messages[1].Content[0].Type, anthropic.ContentTypeToolUse; got != want {
+		t.Errorf("assistant content type: got %q, want %q", got, want)
+	}
+	toolResult := gotRequest.Messages[2]
+	if got, want := len(toolResult.Content), 2; got != want {
+		t.Fatalf("merged user content: got %d, want %d", got, want)
+	}
+	if got, want := toolResult.Content[0].ToolUseID, "toolu_0"; got != want {
+		t.Errorf("tool_use_id: got %q, want %q", got, want)
+	}
+
+	var completion openai.ChatCompletion
+	if err := json.Unmarshal(response.Body.Bytes(), &completion); err != nil {
+		t.Fatal(err)
+	}
+	choice := completion.Choices[0]
+	if got, want := choice.Message.Content, "Checking the weather."; got != want {
+		t.Errorf("content: got %q, want %q", got, want)
+	}
+	if got, want := *choice.FinishReason, "tool_calls"; got != want {
+		t.Errorf("finish_reason: got %q, want %q", got, want)
+	}
+	if got, want := choice.Message.ToolCalls[0].Function.Arguments, `{"city": "Paris"}`; got != want {
+		t.Errorf("arguments: got %q, want %q", got, want)
+	}
+	if got, want := completion.Usage.PromptTokens, 12; got != want {
+		t.Errorf("prompt_tokens: got %d, want %d", got, want)
+	}
+}
+
+func TestAnthropicProxyTranslatesStream(t *testing.T) {
+	events := []string{
+		`{"type":"message_start","message":{"id":"msg_1","type":"message","role":"assistant","model":"claude-sonnet-4-5","content":[],"usage":{"input_tokens":7,"output_tokens":1}}}`,
+		`{"type":"content_block_start","index":0,"content_block":{"type":"text","text":""}}`,
+		`{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Hel"}}`,
+		`{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"lo"}}`,
+		`{"type":"content_block_stop","index":0}`,
+		`{"type":"content_block_start","index":1,"content_block":{"type":"tool_use","id":"toolu_1","name":"weather","input":{}}}`,
+		`{"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":"{\"city\":"}}`,
+		`{"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":"\"Paris\"}"}}`,
+		`{"type":"content_block_stop","index":1}`,
+		`{"type":"message_delta","delta":{"stop_reason":"tool_use"},"usage":{"output_tokens":12}}`,
+		`{"type":"message_stop"}`,
+	}
+
+	proxy := newTestProxy(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, event := range events {
+			var e anthropic.StreamEvent
+			_ = json.Unmarshal([]byte(event), &e)
+			_, _ = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, event)
+		}
+	})
+
+	request := httptest.NewRequest(http.MethodPost, "/openai/v1/chat/completions", bytes.NewBufferString(`{
+		"model": "claude-sonnet-4-5",
+		"stream": true,
+		"stream_options": {"include_usage": true},
+		"messages": [{"role": "user", "content": "Hi"}]
+	}`))
+	response := httptest.NewRecorder()
+	proxy.ServeHTTP(response, request)
+
+	var content, arguments strings.Builder
+	var finishReason string
+	var usage *openai.Usage
+	lines := strings.Split(strings.TrimSpace(response.Body.String()), "\n\n")
+	if got, want := lines[len(lines)-1], "data: [DONE]"; got != want {
+		t.Fatalf("last event: got %q, want %q", got, want)
+	}
+
+	for _, line := range lines[:len(lines)-1] {
+		var chunk openai.ChatCompletionChunk
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &chunk); err != nil {
+			t.Fatal(err)
+		}
+		if chunk.Id != "msg_1" {
+			t.Errorf("id: got %q, want %q", chunk.Id, "msg_1")
+		}
+		if chunk.Usage != nil {
+			usage = chunk.Usage
+		}
+
+		for _, choice := range chunk.Choices {
+			if text, ok := choice.Delta.Content.(string); ok {
+				content.WriteString(text)
+			}
+			for _, call := range choice.Delta.ToolCalls {
+				arguments.WriteString(call.Function.Arguments)
+			}
+			if choice.FinishReason != nil {
+				finishReason = *choice.FinishReason
+			}
+		}
+	}
+
+	if got, want := content.String(), "Hello"; got != want {
+		t.Errorf("content: got %q, want %q", got, want)
+	}
+	if got, want := arguments.String(), `{"city":"Paris"}`; got != want {
+		t.Errorf("arguments: got %q, want %q", got, want)
+	}
+	if got, want := finishReason, "tool_calls"; got != want {
+		t.Errorf("finish_reason: got %q, want %q", got, want)
+	}
+	if usage == nil || usage.PromptTokens != 7 || usage.CompletionTokens != 12 {
+		t.Errorf("usage: got %+v, want prompt 7 and completion 12", usage)
+	}
+}
+
+func TestAnthropicProxyTranslatesErrors(t *testing.T) {
+	proxy := newTestProxy(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"type":"error","error":{"type":"rate_limit_error","message":"slow down"}}`))
+	})
+
+	request := httptest.NewRequest(http.MethodPost, "/openai/v1/chat/completions", bytes.NewBufferString(`{"model":"claude-sonnet-4-5","messages":[{"role":"user","content":"Hi"}]}`))
+	response := httptest.NewRecorder()
+	proxy.ServeHTTP(response, request)
+
+	if got, want := response.Code, http.StatusTooManyRequests; got != want {
+		t.Errorf("status: got %d, want %d", got, want)
+	}
+
+	var errorResponse openai.ErrorResponse
+	if err := json.Unmarshal(response.Body.Bytes(), &errorResponse); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := errorResponse.Error.Type, "rate_limit_error"; got != want {
+		t.Errorf("error type: got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,94 @@
+package azure
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"github.com/pluralsh/console/go/ai-proxy/api"
+	"github.com/pluralsh/console/go/ai-proxy/api/azure"
+	"github.com/pluralsh/console/go/ai-proxy/api/openai"
+	"github.com/pluralsh/console/go/ai-proxy/internal/helpers"
+	"github.com/pluralsh/console/go/ai-proxy/internal/log"
+)
+
+// AzureProxy serves OpenAI-compatible endpoints with Azure OpenAI deployments.
+// The requested model is used as the deployment name.
+type AzureProxy struct {
+	proxy *httputil.ReverseProxy
+}
+
+func (in *AzureProxy) Proxy() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		in.proxy.ServeHTTP(w, r)
+	}
+}
+
+func NewAzureProxy(host string, tokenRotator *helpers.RoundRobinTokenRotator) (api.OpenAIProxy, error) {
+	return newAzureProxy(host, tokenRotator)
+}
+
+func NewAzureEmbeddingsProxy(host string, tokenRotator *helpers.RoundRobinTokenRotator) (api.OpenAIProxy, error) {
+	return newAzureProxy(host, tokenRotator)
+}
+
+func newAzureProxy(host string, tokenRotator *helpers.RoundRobinTokenRotator) (api.OpenAIProxy, error) {
+	if len(tokenRotator.Tokens) == 0 {
+		return nil, fmt.Errorf("at least one token is required")
+	}
+
+	parsedURL, err := helpers.ParseProviderBaseURL(host)
+	if err != nil {
+		return nil, err
+	}
+
+	apiVersion := azure.APIVersion(parsedURL)
+	basePath := strings.TrimRight(parsedURL.Path, "/")
+	reverse := &httputil.ReverseProxy{
+		Transport: helpers.NewTokenRetryTransport(tokenRotator, azure.HeaderAPIKey, "", http.DefaultTransport),
+		Rewrite: func(r *httputil.ProxyRequest) {
+			r.Out.Header.Del("Authorization")
+			r.Out.Header.Set(azure.HeaderAPIKey, tokenRotator.GetNextToken())
+			r.SetXForwarded()
+
+			model, body, err := azure.PeekModel(r.Out.Body)
+			r.Out.Body = body
+			if err != nil {
+				klog.ErrorS(err, "failed to read request body")
+			}
+
+			version := apiVersion
+			var targetPath string
+			switch r.In.URL.Path {
+			case openai.EndpointEmbeddings:
+				targetPath = azure.DeploymentPath(azure.EndpointEmbeddings, model)
+			case openai.EndpointResponses:
+				targetPath = azure.EndpointResponses
+				// Responses API is not available in GA versions older than the preview one.
+				version = azure.MinAPIVersion(version, azure.ResponsesAPIVersion)
+			default:
+				targetPath = azure.DeploymentPath(azure.EndpointChatCompletions, model)
+			}
+
+			query := r.Out.URL.Query()
+			query.Set(azure.QueryAPIVersion, version)
+			r.Out.URL.Scheme = parsedURL.Scheme
+			r.Out.URL.Host = parsedURL.Host
+			r.Out.Host = parsedURL.Host
+			r.Out.URL.Path = basePath + targetPath
+			r.Out.URL.RawPath = ""
+			r.Out.URL.RawQuery = query.Encode()
+
+			klog.V(log.LogLevelDebug).InfoS(
+				"proxying request",
+				"from", fmt.Sprintf("%s %s", r.In.Method, r.In.URL.Path),
+				"to", r.Out.URL.String(),
+			)
+		},
+	}
+
+	return &AzureProxy{proxy: reverse}, nil
+}
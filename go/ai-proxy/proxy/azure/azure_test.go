@@ -0,0 +1,153 @@
+package azure
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ollamaapi "github.com/ollama/ollama/api"
+
+	"github.com/pluralsh/console/go/ai-proxy/api/azure"
+	"github.com/pluralsh/console/go/ai-proxy/api/ollama"
+	"github.com/pluralsh/console/go/ai-proxy/api/openai"
+	"github.com/pluralsh/console/go/ai-proxy/internal/helpers"
+	"github.com/pluralsh/console/go/ai-proxy/proxy/provider"
+)
+
+func TestAzureProxyUsesDeploymentScopedURLs(t *testing.T) {
+	tests := []struct {
+		endpoint    string
+		wantPath    string
+		wantVersion string
+	}{
+		{endpoint: openai.EndpointChat, wantPath: "/openai/deployments/gpt-4o/chat/completions", wantVersion: "2024-06-01"},
+		{endpoint: openai.EndpointEmbeddings, wantPath: "/openai/deployments/gpt-4o/embeddings", wantVersion: "2024-06-01"},
+		{endpoint: openai.EndpointResponses, wantPath: azure.EndpointResponses, wantVersion: azure.ResponsesAPIVersion},
+	}
+
+	for _, test := range tests {
+		t.Run(test.endpoint, func(t *testing.T) {
+			upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != test.wantPath {
+					t.Errorf("path: got %q, want %q", r.URL.Path, test.wantPath)
+				}
+				if got := r.URL.Query().Get(azure.QueryAPIVersion); got != test.wantVersion {
+					t.Errorf("api-version: got %q, want %q", got, test.wantVersion)
+				}
+				if got := r.Header.Get(azure.HeaderAPIKey); got != "azure-key" {
+					t.Errorf("api-key: got %q, want azure-key", got)
+				}
+				if got := r.Header.Get("Authorization"); len(got) > 0 {
+					t.Errorf("unexpected Authorization header %q", got)
+				}
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			defer upstream.Close()
+
+			proxy, err := NewAzureProxy(upstream.URL+"?api-version=2024-06-01", helpers.NewRoundRobinTokenRotator([]string{"azure-key"}))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			request := httptest.NewRequest(http.MethodPost, test.endpoint, bytes.NewBufferString(`{"model":"gpt-4o","input":"hi"}`))
+			request.Header.Set("Authorization", "Bearer client-token")
+			response := httptest.NewRecorder()
+			proxy.Proxy()(response, request)
+			if response.Code != http.StatusOK {
+				t.Errorf("status: got %d, want 200", response.Code)
+			}
+		})
+	}
+}
+
+func TestAzureTranslationProxy(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/openai/deployments/gpt-4o/chat/completions" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		if got := r.URL.Query().Get(azure.QueryAPIVersion); got != azure.DefaultAPIVersion {
+			t.Errorf("api-version: got %q, want %q", got, azure.DefaultAPIVersion)
+		}
+		_, _ = w.Write([]byte(`{"id":"1","model":"gpt-4o","choices":[{"index":0,"message":{"role":"assistant","content":"Hello"},"finish_reason":"stop"}]}`))
+	}))
+	defer upstream.Close()
+
+	proxy, err := provider.NewAzureProxy(upstream.URL, helpers.NewRoundRobinTokenRotator([]string{"azure-key"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request := httptest.NewRequest(http.MethodPost, ollama.EndpointChat, bytes.NewBufferString(`{"model":"gpt-4o","messages":[{"role":"user","content":"Hi"}]}`))
+	response := httptest.NewRecorder()
+	proxy.Proxy()(response, request)
+
+	var chat ollamaapi.ChatResponse
+	if err := json.Unmarshal(response.Body.Bytes(), &chat); err != nil {
+		t.Fatalf("failed to parse response %q: %v", response.Body.String(), err)
+	}
+	if chat.Message.Content != "Hello" {
+		t.Errorf("content: got %q, want Hello", chat.Message.Content)
+	}
+}
+
+func TestAzureProxyResponsesAPIVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{version: "2024-10-21", want: azure.ResponsesAPIVersion},
+		{version: "2025-03-01-preview", want: azure.ResponsesAPIVersion},
+		{version: "2025-04-01", want: "2025-04-01"},
+		{version: "2025-08-01", want: "2025-08-01"},
+		{version: "2025-08-01-preview", want: "2025-08-01-preview"},
+		{version: "preview", want: "preview"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.version, func(t *testing.T) {
+			upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.URL.Query().Get(azure.QueryAPIVersion); got != test.want {
+					t.Errorf("api-version: got %q, want %q", got, test.want)
+				}
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			defer upstream.Close()
+
+			proxy, err := NewAzureProxy(upstream.URL+"?api-version="+test.version, helpers.NewRoundRobinTokenRotator([]string{"azure-key"}))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			request := httptest.NewRequest(http.MethodPost, openai.EndpointResponses, bytes.NewBufferString(`{"model":"gpt-4o","input":"hi"}`))
+			response := httptest.NewRecorder()
+			proxy.Proxy()(response, request)
+			if response.Code != http.StatusOK {
+				t.Errorf("status: got %d, want 200", response.Code)
+			}
+		})
+	}
+}
+
+func TestAzureTranslationProxyKeepsHostPath(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/gateway/openai/deployments/gpt-4o/chat/completions" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"id":"1","model":"gpt-4o","choices":[{"index":0,"message":{"role":"assistant","content":"Hello"},"finish_reason":"stop"}]}`))
+	}))
+	defer upstream.Close()
+
+	proxy, err := provider.NewAzureProxy(upstream.URL+"/gateway", helpers.NewRoundRobinTokenRotator([]string{"azure-key"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request := httptest.NewRequest(http.MethodPost, ollama.EndpointChat, bytes.NewBufferString(`{"model":"gpt-4o","messages":[{"role":"user","content":"Hi"}]}`))
+	response := httptest.NewRecorder()
+	proxy.Proxy()(response, request)
+	if response.Code != http.StatusOK {
+		t.Errorf("status: got %d, want 200", response.Code)
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/google/uuid"
 	ollamaapi "github.com/ollama/ollama/api"
 	"github.com/ollama/ollama/openai"
+	"github.com/samber/lo"
 	"k8s.io/klog/v2"
 
 	"github.com/pluralsh/console/go/ai-proxy/api"
@@ -153,6 +154,23 @@ func (b *BedrockProxy) handleStreamingBedrock(
 				klog.Infof("unknown content block delta type: %v", v.Value.Delta)
 			}
 
+			payload, _ := json.Marshal(chunkResp)
+			_, _ = fmt.Fprintf(w, "data: %s\n\n", payload)
+			flusher.Flush()
+		case *types.ConverseStreamOutputMemberMetadata:
+			if req.StreamOptions == nil || !req.StreamOptions.IncludeUsage || v.Value.Usage == nil {
+				continue
+			}
+
+			chunkResp := openai.ChatCompletionChunk{
+				Id:      uuid.NewString(),
+				Object:  ChatCompletionChunkObject,
+				Created: time.Now().Unix(),
+				Model:   req.Model,
+				Choices: []openai.ChunkChoice{},
+				Usage:   toUsage(v.Value.Usage),
+			}
+
 			payload, _ := json.Marshal(chunkResp)
 			_, _ = fmt.Fprintf(w, "data: %s\n\n", payload)
 			flusher.Flush()
@@ -239,9 +257,22 @@ func convertBedrockToOpenAI(output *bedrockruntime.ConverseOutput, model string,
 				},
 			},
 		},
+		Usage: lo.FromPtr(toUsage(output.Usage)),
 	}, nil
 }
 
+func toUsage(usage *types.TokenUsage) *openai.Usage {
+	if usage == nil {
+		return nil
+	}
+
+	return &openai.Usage{
+		PromptTokens:     int(aws.ToInt32(usage.InputTokens)),
+		CompletionTokens: int(aws.ToInt32(usage.OutputTokens)),
+		TotalTokens:      int(aws.ToInt32(usage.TotalTokens)),
+	}
+}
+
 func convertOpenAIToBedrockInput(openAIReq *openai.ChatCompletionRequest) (*bedrockruntime.ConverseInput, error) {
 	bedrockReq := &bedrockruntime.ConverseInput{
 		ModelId: aws.String(openAIReq.Model),
@@ -0,0 +1,78 @@
+package gemini
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"github.com/pluralsh/console/go/ai-proxy/api"
+	"github.com/pluralsh/console/go/ai-proxy/api/gemini"
+	"github.com/pluralsh/console/go/ai-proxy/api/openai"
+	"github.com/pluralsh/console/go/ai-proxy/internal/helpers"
+	"github.com/pluralsh/console/go/ai-proxy/internal/log"
+)
+
+// GeminiProxy serves OpenAI-compatible endpoints with the Gemini API authenticated
+// with API keys, as opposed to Vertex that requires a service account.
+type GeminiProxy struct {
+	proxy *httputil.ReverseProxy
+}
+
+func (in *GeminiProxy) Proxy() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == openai.EndpointResponses {
+			http.Error(w, "responses endpoint is only supported for openai provider", http.StatusBadRequest)
+			return
+		}
+
+		in.proxy.ServeHTTP(w, r)
+	}
+}
+
+func NewGeminiProxy(host string, tokenRotator *helpers.RoundRobinTokenRotator) (api.OpenAIProxy, error) {
+	return newGeminiProxy(host, gemini.EndpointChatCompletions, tokenRotator)
+}
+
+func NewGeminiEmbeddingsProxy(host string, tokenRotator *helpers.RoundRobinTokenRotator) (api.OpenAIProxy, error) {
+	return newGeminiProxy(host, gemini.EndpointEmbeddings, tokenRotator)
+}
+
+func newGeminiProxy(host, targetPath string, tokenRotator *helpers.RoundRobinTokenRotator) (api.OpenAIProxy, error) {
+	if len(tokenRotator.Tokens) == 0 {
+		return nil, fmt.Errorf("at least one token is required")
+	}
+
+	if len(host) == 0 {
+		host = gemini.DefaultHost
+	}
+
+	parsedURL, err := helpers.ParseProviderBaseURL(host)
+	if err != nil {
+		return nil, err
+	}
+
+	upstreamPath := strings.TrimRight(parsedURL.Path, "/") + targetPath
+	reverse := &httputil.ReverseProxy{
+		Transport: helpers.NewBearerTokenRetryTransport(tokenRotator, http.DefaultTransport),
+		Rewrite: func(r *httputil.ProxyRequest) {
+			r.Out.Header.Set("Authorization", "Bearer "+tokenRotator.GetNextToken())
+			r.SetXForwarded()
+
+			r.Out.URL.Scheme = parsedURL.Scheme
+			r.Out.URL.Host = parsedURL.Host
+			r.Out.Host = parsedURL.Host
+			r.Out.URL.Path = upstreamPath
+
+			klog.V(log.LogLevelDebug).InfoS(
+				"proxying request",
+				"from", fmt.Sprintf("%s %s", r.In.Method, r.In.URL.Path),
+				"to", r.Out.URL.String(),
+			)
+		},
+	}
+
+	return &GeminiProxy{proxy: reverse}, nil
+}
@@ -0,0 +1,79 @@
+package gemini
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ollamaapi "github.com/ollama/ollama/api"
+
+	"github.com/pluralsh/console/go/ai-proxy/api/gemini"
+	"github.com/pluralsh/console/go/ai-proxy/api/ollama"
+	"github.com/pluralsh/console/go/ai-proxy/api/openai"
+	"github.com/pluralsh/console/go/ai-proxy/internal/helpers"
+	"github.com/pluralsh/console/go/ai-proxy/proxy/provider"
+)
+
+func TestGeminiProxy(t *testing.T) {
+	var gotPath, gotAuthorization string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotAuthorization = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer upstream.Close()
+
+	proxy, err := NewGeminiProxy(upstream.URL, helpers.NewRoundRobinTokenRotator([]string{"gemini-key"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request := httptest.NewRequest(http.MethodPost, openai.EndpointChat, bytes.NewBufferString(`{"model":"gemini-2.5-flash"}`))
+	proxy.Proxy()(httptest.NewRecorder(), request)
+	if gotPath != gemini.EndpointChatCompletions {
+		t.Errorf("path: got %q, want %q", gotPath, gemini.EndpointChatCompletions)
+	}
+	if gotAuthorization != "Bearer gemini-key" {
+		t.Errorf("authorization: got %q, want Bearer gemini-key", gotAuthorization)
+	}
+
+	response := httptest.NewRecorder()
+	proxy.Proxy()(response, httptest.NewRequest(http.MethodPost, openai.EndpointResponses, bytes.NewBufferString(`{}`)))
+	if response.Code != http.StatusBadRequest {
+		t.Errorf("responses status: got %d, want 400", response.Code)
+	}
+}
+
+func TestGeminiTranslationProxyKeepsHostPath(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/gateway"+gemini.EndpointChatCompletions {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+
+		var request map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request["messages"] == nil {
+			t.Errorf("expected an openai chat completion request, got %v: %v", request, err)
+		}
+		_, _ = w.Write([]byte(`{"id":"1","model":"gemini-2.5-flash","choices":[{"index":0,"message":{"role":"assistant","content":"Hello"},"finish_reason":"stop"}]}`))
+	}))
+	defer upstream.Close()
+
+	proxy, err := provider.NewGeminiProxy(upstream.URL+"/gateway", helpers.NewRoundRobinTokenRotator([]string{"gemini-key"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request := httptest.NewRequest(http.MethodPost, ollama.EndpointChat, bytes.NewBufferString(`{"model":"gemini-2.5-flash","messages":[{"role":"user","content":"Hi"}]}`))
+	response := httptest.NewRecorder()
+	proxy.Proxy()(response, request)
+
+	var chat ollamaapi.ChatResponse
+	if err := json.Unmarshal(response.Body.Bytes(), &chat); err != nil {
+		t.Fatalf("failed to parse response %q: %v", response.Body.String(), err)
+	}
+	if chat.Message.Content != "Hello" {
+		t.Errorf("content: got %q, want Hello", chat.Message.Content)
+	}
+}
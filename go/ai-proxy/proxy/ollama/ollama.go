@@ -14,6 +14,7 @@ import (
 	"github.com/pluralsh/console/go/ai-proxy/api"
 	apioai "github.com/pluralsh/console/go/ai-proxy/api/openai"
 	"github.com/pluralsh/console/go/polly/algorithms"
+	"github.com/samber/lo"
 	"k8s.io/klog/v2"
 )
 
@@ -135,7 +136,23 @@ func (o *OllamaProxy) handleStreamingOllama(
 		return
 	}
 
+	includeUsage := req.StreamOptions != nil && req.StreamOptions.IncludeUsage
 	respFunc := func(resp ollamaapi.ChatResponse) error {
+		if resp.Done && includeUsage {
+			defer func() {
+				payload, _ := json.Marshal(openai.ChatCompletionChunk{
+					Id:      uuid.NewString(),
+					Object:  ChatCompletionChunkObject,
+					Created: time.Now().Unix(),
+					Model:   req.Model,
+					Choices: []openai.ChunkChoice{},
+					Usage:   lo.ToPtr(toUsage(resp.Metrics)),
+				})
+				_, _ = fmt.Fprintf(w, "data: %s\n\n", payload)
+				flusher.Flush()
+			}()
+		}
+
 		chunk := openai.ChunkChoice{}
 		var finishReason *string
 
@@ -279,6 +296,7 @@ func (o *OllamaProxy) handleNonStreamingOllama(
 				}(),
 			},
 		},
+		Usage: toUsage(ollamaResponse.Metrics),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -314,3 +332,11 @@ func (o *OllamaProxy) convertOpenAIToOllamaChatRequest(req *openai.ChatCompletio
 		Tools:    req.Tools,
 	}, nil
 }
+
+func toUsage(metrics ollamaapi.Metrics) openai.Usage {
+	return openai.Usage{
+		PromptTokens:     metrics.PromptEvalCount,
+		CompletionTokens: metrics.EvalCount,
+		TotalTokens:      metrics.PromptEvalCount + metrics.EvalCount,
+	}
+}
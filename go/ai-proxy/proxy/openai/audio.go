@@ -2,6 +2,7 @@ package openai
 
 import (
 	"fmt"
+	"net/http"
 	"net/http/httputil"
 	"net/url"
 
@@ -31,6 +32,7 @@ func NewOpenAIAudioProxy(host string, tokenRotator *helpers.RoundRobinTokenRotat
 	}
 
 	reverse := &httputil.ReverseProxy{
+		Transport: helpers.NewBearerTokenRetryTransport(tokenRotator, http.DefaultTransport),
 		Rewrite: func(r *httputil.ProxyRequest) {
 			r.Out.Header.Set("Authorization", "Bearer "+tokenRotator.GetNextToken())
 
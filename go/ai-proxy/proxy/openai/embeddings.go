@@ -2,6 +2,7 @@ package openai
 
 import (
 	"fmt"
+	"net/http"
 	"net/http/httputil"
 	"net/url"
 
@@ -27,6 +28,7 @@ func NewOpenAIEmbeddingsProxy(host string, tokenRotator *helpers.RoundRobinToken
 	}
 
 	reverse := &httputil.ReverseProxy{
+		Transport: helpers.NewBearerTokenRetryTransport(tokenRotator, http.DefaultTransport),
 		Rewrite: func(r *httputil.ProxyRequest) {
 			r.Out.Header.Set("Authorization", "Bearer "+tokenRotator.GetNextToken())
 
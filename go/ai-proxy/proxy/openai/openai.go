@@ -84,9 +84,9 @@ func NewOpenAIProxy(host string, tokenRotator *helpers.RoundRobinTokenRotator, m
 		return nil, err
 	}
 
-	var transport http.RoundTripper
+	transport := helpers.NewBearerTokenRetryTransport(tokenRotator, http.DefaultTransport)
 	if mantleConfig.SigV4 {
-		transport, err = newMantleSigV4RoundTripper(context.Background(), mantleConfig)
+		transport, err = newMantleSigV4RoundTripperWithBase(context.Background(), mantleConfig, transport)
 		if err != nil {
 			return nil, err
 		}
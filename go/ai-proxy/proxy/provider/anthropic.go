@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+
+	"k8s.io/klog/v2"
+
+	"github.com/pluralsh/console/go/ai-proxy/api"
+	"github.com/pluralsh/console/go/ai-proxy/api/anthropic"
+	"github.com/pluralsh/console/go/ai-proxy/internal/helpers"
+)
+
+type AnthropicProxy struct {
+	*baseTranslationProxy
+
+	tokenRotator helpers.TokenRotator
+}
+
+func (in *AnthropicProxy) ModifyRequest(r *httputil.ProxyRequest) {
+	in.baseTranslationProxy.ModifyRequest(r)
+
+	r.Out.Header.Set(anthropic.HeaderAPIKey, in.tokenRotator.GetNextToken())
+	r.Out.Header.Set(anthropic.HeaderVersion, anthropic.APIVersion)
+	r.SetXForwarded()
+
+	err := in.modifyRequestBody(r)
+	if err != nil {
+		klog.ErrorS(err, "failed to map request body")
+		return
+	}
+}
+
+func (in *AnthropicProxy) ModifyResponse(r *http.Response) error {
+	if err := in.baseTranslationProxy.ModifyResponse(r); err != nil {
+		return err
+	}
+
+	err := in.modifyResponseBody(r)
+	if err != nil {
+		klog.ErrorS(err, "failed to map response body")
+		return err
+	}
+
+	return nil
+}
+
+func (in *AnthropicProxy) modifyRequestBody(r *httputil.ProxyRequest) error {
+	endpoint := r.Out.URL.Path
+	if endpoint == anthropic.EndpointMessages {
+		return replaceRequestBody(r, anthropic.FromOllamaChatRequest)
+	}
+
+	return nil
+}
+
+func (in *AnthropicProxy) modifyResponseBody(r *http.Response) error {
+	if r.StatusCode != http.StatusOK {
+		return replaceResponseBody(r, anthropic.FromErrorResponse(r.StatusCode))
+	}
+
+	endpoint := r.Request.URL.Path
+	if endpoint == anthropic.EndpointMessages {
+		return replaceResponseBody(r, anthropic.ToOllamaChatResponse)
+	}
+
+	return nil
+}
+
+func NewAnthropicProxy(target string, tokenRotator *helpers.RoundRobinTokenRotator) (api.TranslationProxy, error) {
+	if len(tokenRotator.Tokens) == 0 {
+		return nil, fmt.Errorf("must have at least one anthropic token")
+	}
+	proxy := &AnthropicProxy{tokenRotator: tokenRotator}
+	base, err := newBaseTranslationProxy(target, api.ProviderAnthropic, proxy.ModifyRequest, proxy.ModifyResponse, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	base.proxy.Transport = helpers.NewTokenRetryTransport(tokenRotator, anthropic.HeaderAPIKey, "", http.DefaultTransport)
+	proxy.baseTranslationProxy = base
+	return proxy, nil
+}
@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"github.com/pluralsh/console/go/ai-proxy/api"
+	"github.com/pluralsh/console/go/ai-proxy/api/azure"
+	"github.com/pluralsh/console/go/ai-proxy/api/openai"
+	"github.com/pluralsh/console/go/ai-proxy/internal/helpers"
+)
+
+type AzureProxy struct {
+	*baseTranslationProxy
+
+	tokenRotator helpers.TokenRotator
+	apiVersion   string
+}
+
+func (in *AzureProxy) ModifyRequest(r *httputil.ProxyRequest) {
+	in.baseTranslationProxy.ModifyRequest(r)
+
+	r.Out.Header.Set(azure.HeaderAPIKey, in.tokenRotator.GetNextToken())
+	r.SetXForwarded()
+
+	err := in.modifyRequestBody(r)
+	if err != nil {
+		klog.ErrorS(err, "failed to map request body")
+		return
+	}
+}
+
+func (in *AzureProxy) ModifyResponse(r *http.Response) error {
+	if err := in.baseTranslationProxy.ModifyResponse(r); err != nil {
+		return err
+	}
+
+	err := in.modifyResponseBody(r)
+	if err != nil {
+		klog.ErrorS(err, "failed to map response body")
+		return err
+	}
+
+	return nil
+}
+
+func (in *AzureProxy) modifyRequestBody(r *httputil.ProxyRequest) error {
+	// the path is prefixed with the path of the provider host, if it has one
+	basePath, ok := strings.CutSuffix(r.Out.URL.Path, azure.EndpointChatCompletions)
+	if !ok {
+		return nil
+	}
+
+	model, body, err := azure.PeekModel(r.Out.Body)
+	r.Out.Body = body
+	if err != nil {
+		return err
+	}
+
+	r.Out.URL.Path = basePath + azure.DeploymentPath(azure.EndpointChatCompletions, model)
+	r.Out.URL.RawPath = ""
+	query := r.Out.URL.Query()
+	query.Set(azure.QueryAPIVersion, in.apiVersion)
+	r.Out.URL.RawQuery = query.Encode()
+	return replaceRequestBody(r, openai.ToChatCompletionRequest)
+}
+
+func (in *AzureProxy) modifyResponseBody(r *http.Response) error {
+	if r.StatusCode != http.StatusOK {
+		return replaceResponseBody(r, openai.FromErrorResponse(r.StatusCode))
+	}
+
+	endpoint := r.Request.URL.Path
+	if azure.IsDeploymentPath(azure.EndpointChatCompletions, endpoint) {
+		return replaceResponseBody(r, openai.FromChatCompletionResponse)
+	}
+
+	return nil
+}
+
+func NewAzureProxy(target string, tokenRotator *helpers.RoundRobinTokenRotator) (api.TranslationProxy, error) {
+	if len(tokenRotator.Tokens) == 0 {
+		return nil, fmt.Errorf("must have at least one azure token")
+	}
+
+	targetURL, err := helpers.ParseProviderBaseURL(target)
+	if err != nil {
+		return nil, err
+	}
+
+	proxy := &AzureProxy{tokenRotator: tokenRotator, apiVersion: azure.APIVersion(targetURL)}
+	targetURL.RawQuery = ""
+	base, err := newBaseTranslationProxy(targetURL.String(), api.ProviderAzure, proxy.ModifyRequest, proxy.ModifyResponse, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	base.proxy.Transport = helpers.NewTokenRetryTransport(tokenRotator, azure.HeaderAPIKey, "", http.DefaultTransport)
+	proxy.baseTranslationProxy = base
+	return proxy, nil
+}
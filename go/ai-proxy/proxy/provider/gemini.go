@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"github.com/pluralsh/console/go/ai-proxy/api"
+	"github.com/pluralsh/console/go/ai-proxy/api/gemini"
+	"github.com/pluralsh/console/go/ai-proxy/api/openai"
+	"github.com/pluralsh/console/go/ai-proxy/internal/helpers"
+)
+
+type GeminiProxy struct {
+	*baseTranslationProxy
+
+	tokenRotator helpers.TokenRotator
+}
+
+func (in *GeminiProxy) ModifyRequest(r *httputil.ProxyRequest) {
+	in.baseTranslationProxy.ModifyRequest(r)
+
+	r.Out.Header.Set("Authorization", "Bearer "+in.tokenRotator.GetNextToken())
+	r.SetXForwarded()
+
+	err := in.modifyRequestBody(r)
+	if err != nil {
+		klog.ErrorS(err, "failed to map request body")
+		return
+	}
+}
+
+func (in *GeminiProxy) ModifyResponse(r *http.Response) error {
+	if err := in.baseTranslationProxy.ModifyResponse(r); err != nil {
+		return err
+	}
+
+	err := in.modifyResponseBody(r)
+	if err != nil {
+		klog.ErrorS(err, "failed to map response body")
+		return err
+	}
+
+	return nil
+}
+
+func (in *GeminiProxy) modifyRequestBody(r *httputil.ProxyRequest) error {
+	// the path is prefixed with the path of the provider host, if it has one
+	endpoint := r.Out.URL.Path
+	if strings.HasSuffix(endpoint, gemini.EndpointChatCompletions) {
+		return replaceRequestBody(r, openai.ToChatCompletionRequest)
+	}
+
+	return nil
+}
+
+func (in *GeminiProxy) modifyResponseBody(r *http.Response) error {
+	if r.StatusCode != http.StatusOK {
+		return replaceResponseBody(r, gemini.FromErrorResponse(r.StatusCode))
+	}
+
+	endpoint := r.Request.URL.Path
+	if strings.HasSuffix(endpoint, gemini.EndpointChatCompletions) {
+		return replaceResponseBody(r, openai.FromChatCompletionResponse)
+	}
+
+	return nil
+}
+
+func NewGeminiProxy(target string, tokenRotator *helpers.RoundRobinTokenRotator) (api.TranslationProxy, error) {
+	if len(tokenRotator.Tokens) == 0 {
+		return nil, fmt.Errorf("must have at least one gemini api key")
+	}
+
+	if len(target) == 0 {
+		target = gemini.DefaultHost
+	}
+
+	proxy := &GeminiProxy{tokenRotator: tokenRotator}
+	base, err := newBaseTranslationProxy(target, api.ProviderGemini, proxy.ModifyRequest, proxy.ModifyResponse, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	base.proxy.Transport = helpers.NewBearerTokenRetryTransport(tokenRotator, http.DefaultTransport)
+	proxy.baseTranslationProxy = base
+	return proxy, nil
+}
@@ -77,6 +77,7 @@ func NewOpenAIProxy(target string, tokenRotator *helpers.RoundRobinTokenRotator)
 		return nil, err
 	}
 
+	base.proxy.Transport = helpers.NewBearerTokenRetryTransport(tokenRotator, http.DefaultTransport)
 	proxy.baseTranslationProxy = base
 	return proxy, nil
 }
@@ -5,7 +5,10 @@ import (
 
 	"github.com/pluralsh/console/go/ai-proxy/api"
 	"github.com/pluralsh/console/go/ai-proxy/internal/helpers"
+	"github.com/pluralsh/console/go/ai-proxy/proxy/anthropic"
+	"github.com/pluralsh/console/go/ai-proxy/proxy/azure"
 	"github.com/pluralsh/console/go/ai-proxy/proxy/bedrock"
+	"github.com/pluralsh/console/go/ai-proxy/proxy/gemini"
 	"github.com/pluralsh/console/go/ai-proxy/proxy/ollama"
 	"github.com/pluralsh/console/go/ai-proxy/proxy/openai"
 	"github.com/pluralsh/console/go/ai-proxy/proxy/provider"
@@ -20,7 +23,11 @@ func NewOllamaTranslationProxy(p api.Provider, host, serviceAccount string, toke
 	case api.ProviderVertex:
 		return provider.NewVertexProxy(host, serviceAccount)
 	case api.ProviderAnthropic:
-		return nil, fmt.Errorf("unsupported provider: %s", p)
+		return provider.NewAnthropicProxy(host, tokenRotator)
+	case api.ProviderAzure:
+		return provider.NewAzureProxy(host, tokenRotator)
+	case api.ProviderGemini:
+		return provider.NewGeminiProxy(host, tokenRotator)
 	}
 
 	return nil, fmt.Errorf("invalid provider: %s", p)
@@ -34,6 +41,12 @@ func NewOpenAIProxy(p api.Provider, host, region string, tokenRotator *helpers.R
 		return bedrock.NewBedrockProxy(region)
 	case api.ProviderOllama:
 		return ollama.NewOllamaProxy(host)
+	case api.ProviderAnthropic:
+		return anthropic.NewAnthropicProxy(host, tokenRotator)
+	case api.ProviderAzure:
+		return azure.NewAzureProxy(host, tokenRotator)
+	case api.ProviderGemini:
+		return gemini.NewGeminiProxy(host, tokenRotator)
 	}
 	return nil, fmt.Errorf("invalid provider: %s", p)
 }
@@ -46,6 +59,12 @@ func NewOpenAIEmbeddingsProxy(p api.Provider, host, region string, tokenRotator
 		return bedrock.NewBedrockEmbeddingsProxy(region)
 	case api.ProviderOllama:
 		return ollama.NewOllamaEmbeddingsProxy(host)
+	case api.ProviderAzure:
+		return azure.NewAzureEmbeddingsProxy(host, tokenRotator)
+	case api.ProviderGemini:
+		return gemini.NewGeminiEmbeddingsProxy(host, tokenRotator)
+	case api.ProviderAnthropic:
+		return nil, fmt.Errorf("embeddings are not supported for provider %s", p)
 	}
 	return nil, fmt.Errorf("invalid provider: %s", p)
 }
@@ -0,0 +1,261 @@
+package proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+
+	"k8s.io/klog/v2"
+
+	"github.com/pluralsh/console/go/ai-proxy/api"
+	"github.com/pluralsh/console/go/ai-proxy/api/ollama"
+	"github.com/pluralsh/console/go/ai-proxy/api/openai"
+	"github.com/pluralsh/console/go/ai-proxy/internal/helpers"
+	"github.com/pluralsh/console/go/ai-proxy/internal/log"
+	"github.com/pluralsh/console/go/ai-proxy/internal/metrics"
+)
+
+// routeBackend holds all proxies that could be created for a single provider.
+// Proxies that are not supported by the provider are left empty.
+type routeBackend struct {
+	name       string
+	chat       api.TranslationProxy
+	openai     api.OpenAIProxy
+	embeddings api.OpenAIProxy
+}
+
+func (in *routeBackend) handler(path string) http.HandlerFunc {
+	switch {
+	case path == ollama.EndpointChat && in.chat != nil:
+		return metrics.Instrument(in.name, in.chat.Proxy())
+	case path == openai.EndpointEmbeddings && in.embeddings != nil:
+		return metrics.Instrument(in.name, in.embeddings.Proxy())
+	case (path == openai.EndpointChat || path == openai.EndpointResponses) && in.openai != nil:
+		return metrics.Instrument(in.name, in.openai.Proxy())
+	}
+
+	return nil
+}
+
+// RouterProxy routes requests to providers based on the requested model and falls back
+// to the next provider configured for the route when upstream fails with 429 or 5xx.
+// Requests are recorded in metrics under the provider name from the routing config.
+type RouterProxy struct {
+	config        *api.RoutingConfig
+	backends      map[string]*routeBackend
+	tokenRotators map[string]*helpers.RoundRobinTokenRotator
+}
+
+func NewRouterProxy(config *api.RoutingConfig, mantleConfig api.MantleConfig) (*RouterProxy, error) {
+	backends := make(map[string]*routeBackend, len(config.Providers))
+	tokenRotators := make(map[string]*helpers.RoundRobinTokenRotator, len(config.Providers))
+	for name, p := range config.Providers {
+		tokenRotator := helpers.NewRoundRobinTokenRotator(p.GetTokens())
+		tokenRotators[name] = tokenRotator
+		backend := &routeBackend{name: name}
+
+		chat, chatErr := NewOllamaTranslationProxy(p.Provider, p.Host, p.GetServiceAccount(), tokenRotator)
+		if chatErr == nil {
+			backend.chat = chat
+		}
+
+		op, openaiErr := NewOpenAIProxy(p.Provider, p.Host, p.AWSRegion, tokenRotator, mantleConfig)
+		if openaiErr == nil {
+			backend.openai = op
+		}
+
+		ep, embeddingsErr := NewOpenAIEmbeddingsProxy(p.Provider, p.Host, p.AWSRegion, tokenRotator, mantleConfig)
+		if embeddingsErr == nil {
+			backend.embeddings = ep
+		}
+
+		if backend.chat == nil && backend.openai == nil && backend.embeddings == nil {
+			return nil, fmt.Errorf("could not create proxy for provider %s: %w", name, errors.Join(chatErr, openaiErr, embeddingsErr))
+		}
+
+		klog.V(log.LogLevelMinimal).InfoS("Registered routed provider", "name", name, "provider", p.Provider,
+			"chat", backend.chat != nil, "openai", backend.openai != nil, "embeddings", backend.embeddings != nil)
+		backends[name] = backend
+	}
+
+	return &RouterProxy{
+		config:        config,
+		backends:      backends,
+		tokenRotators: tokenRotators,
+	}, nil
+}
+
+// TokenRotators returns token rotators of all routed providers keyed by the provider name.
+func (in *RouterProxy) TokenRotators() map[string]*helpers.RoundRobinTokenRotator {
+	return in.tokenRotators
+}
+
+func (in *RouterProxy) Proxy() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "failed to read request body", http.StatusBadRequest)
+			return
+		}
+
+		var payload struct {
+			Model string `json:"model"`
+		}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			http.Error(w, "failed to parse request", http.StatusBadRequest)
+			return
+		}
+
+		targets := in.config.Match(payload.Model)
+		if len(targets) == 0 {
+			http.Error(w, fmt.Sprintf("no route configured for model %s", payload.Model), http.StatusNotFound)
+			return
+		}
+
+		// failure is the last upstream response discarded in favor of the next target. It is
+		// returned if no other target serves the request, i.e. when they do not support the endpoint.
+		var failure *fallbackResponseWriter
+		for i, target := range targets {
+			handler := in.backends[target.Provider].handler(r.URL.Path)
+			if handler == nil {
+				klog.V(log.LogLevelDebug).InfoS("provider does not support endpoint", "provider", target.Provider, "path", r.URL.Path)
+				continue
+			}
+
+			targetBody, err := withModel(body, target.Model)
+			if err != nil {
+				klog.ErrorS(err, "failed to rewrite request model", "provider", target.Provider)
+				continue
+			}
+
+			request := r.Clone(r.Context())
+			request.Body = io.NopCloser(bytes.NewReader(targetBody))
+			request.ContentLength = int64(len(targetBody))
+			request.Header.Del("Content-Length")
+
+			writer := newFallbackResponseWriter(w, i < len(targets)-1)
+			handler(writer, request)
+			if !writer.failed() {
+				return
+			}
+
+			klog.V(log.LogLevelInfo).InfoS("provider request failed, trying next route target",
+				"provider", target.Provider, "model", payload.Model, "path", r.URL.Path, "status", writer.status)
+			if writer.committed {
+				return
+			}
+			if writer.discard {
+				failure = writer
+			}
+		}
+
+		if failure != nil {
+			failure.replay()
+			return
+		}
+
+		http.Error(w, fmt.Sprintf("no provider could serve the request for model %s", payload.Model), http.StatusBadGateway)
+	}
+}
+
+// withModel replaces the model in the request body if the override is not empty.
+func withModel(body []byte, model string) ([]byte, error) {
+	if len(model) == 0 {
+		return body, nil
+	}
+
+	var payload map[string]any
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+
+	payload["model"] = model
+	return json.Marshal(payload)
+}
+
+// fallbackResponseWriter defers writing to the underlying response writer until the status
+// code is known. When fallback is allowed, responses that should fall back to the next
+// provider are buffered, so they can be replayed if no other provider serves the request.
+// Other responses, including streaming ones, are passed through.
+type fallbackResponseWriter struct {
+	http.ResponseWriter
+
+	canFallback bool
+	header      http.Header
+	status      int
+	wroteHeader bool
+	discard     bool
+	committed   bool
+	body        bytes.Buffer
+}
+
+func newFallbackResponseWriter(w http.ResponseWriter, canFallback bool) *fallbackResponseWriter {
+	return &fallbackResponseWriter{
+		ResponseWriter: w,
+		canFallback:    canFallback,
+		header:         make(http.Header),
+	}
+}
+
+func (in *fallbackResponseWriter) Header() http.Header {
+	return in.header
+}
+
+func (in *fallbackResponseWriter) WriteHeader(statusCode int) {
+	if in.wroteHeader {
+		return
+	}
+
+	in.wroteHeader = true
+	in.status = statusCode
+	if in.canFallback && api.ShouldFallback(statusCode) {
+		in.discard = true
+		return
+	}
+
+	maps.Copy(in.ResponseWriter.Header(), in.header)
+	in.ResponseWriter.WriteHeader(statusCode)
+	in.committed = true
+}
+
+func (in *fallbackResponseWriter) Write(b []byte) (int, error) {
+	if !in.wroteHeader {
+		in.WriteHeader(http.StatusOK)
+	}
+
+	if in.discard {
+		return in.body.Write(b)
+	}
+
+	return in.ResponseWriter.Write(b)
+}
+
+func (in *fallbackResponseWriter) Flush() {
+	if !in.committed {
+		return
+	}
+
+	if flusher, ok := in.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (in *fallbackResponseWriter) Unwrap() http.ResponseWriter {
+	return in.ResponseWriter
+}
+
+// replay writes the buffered response to the underlying response writer.
+func (in *fallbackResponseWriter) replay() {
+	maps.Copy(in.ResponseWriter.Header(), in.header)
+	in.ResponseWriter.WriteHeader(in.status)
+	_, _ = in.ResponseWriter.Write(in.body.Bytes())
+}
+
+// failed returns true if the handler did not write any response or the response was discarded.
+func (in *fallbackResponseWriter) failed() bool {
+	return !in.wroteHeader || in.discard || (in.committed && api.ShouldFallback(in.status))
+}
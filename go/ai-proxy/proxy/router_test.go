@@ -0,0 +1,173 @@
+package proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pluralsh/console/go/ai-proxy/api"
+)
+
+func newUpstream(t *testing.T, status int, body string, gotModel *string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Model string `json:"model"`
+		}
+		b, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(b, &payload)
+		if gotModel != nil {
+			*gotModel = payload.Model
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestRoutingConfigMatch(t *testing.T) {
+	config := &api.RoutingConfig{
+		Routes: []api.Route{
+			{Models: []string{"*"}, Targets: []api.RouteTarget{{Provider: "default"}}},
+			{Models: []string{"gpt-*"}, Targets: []api.RouteTarget{{Provider: "openai"}}},
+			{Models: []string{"gpt-4o-*"}, Targets: []api.RouteTarget{{Provider: "azure"}}},
+			{Models: []string{"gpt-4o-mini"}, Targets: []api.RouteTarget{{Provider: "ollama"}}},
+		},
+	}
+
+	cases := map[string]string{
+		"llama3":            "default",
+		"gpt-5":             "openai",
+		"gpt-4o-2024-08-06": "azure",
+		"gpt-4o-mini":       "ollama",
+	}
+
+	for model, want := range cases {
+		if got := config.Match(model); len(got) == 0 || got[0].Provider != want {
+			t.Errorf("Match(%q): got %v, want %s", model, got, want)
+		}
+	}
+}
+
+func TestRouterProxyFallsBackOnRateLimit(t *testing.T) {
+	var secondaryModel string
+	primary := newUpstream(t, http.StatusTooManyRequests, `{"error":{"message":"rate limited","type":"rate_limit"}}`, nil)
+	secondary := newUpstream(t, http.StatusOK, `{"id":"secondary"}`, &secondaryModel)
+
+	config := &api.RoutingConfig{
+		Providers: map[string]api.ProviderConfig{
+			"primary":   {Provider: api.ProviderOpenAI, Host: primary.URL, Tokens: []string{"a"}},
+			"secondary": {Provider: api.ProviderOpenAI, Host: secondary.URL, Tokens: []string{"b"}},
+		},
+		Routes: []api.Route{
+			{
+				Models:  []string{"gpt-*"},
+				Targets: []api.RouteTarget{{Provider: "primary"}, {Provider: "secondary", Model: "gpt-fallback"}},
+			},
+		},
+	}
+	if err := config.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	proxy, err := NewRouterProxy(config, api.MantleConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request := httptest.NewRequest(http.MethodPost, "/openai/v1/chat/completions", bytes.NewBufferString(`{"model":"gpt-5","messages":[]}`))
+	response := httptest.NewRecorder()
+	proxy.Proxy().ServeHTTP(response, request)
+
+	if got, want := response.Code, http.StatusOK; got != want {
+		t.Errorf("status: got %d, want %d", got, want)
+	}
+	if got, want := response.Body.String(), `{"id":"secondary"}`; got != want {
+		t.Errorf("body: got %q, want %q", got, want)
+	}
+	if got, want := secondaryModel, "gpt-fallback"; got != want {
+		t.Errorf("model: got %q, want %q", got, want)
+	}
+}
+
+func TestRouterProxyReturnsLastError(t *testing.T) {
+	primary := newUpstream(t, http.StatusInternalServerError, `{}`, nil)
+	secondary := newUpstream(t, http.StatusServiceUnavailable, `{"error":{"message":"unavailable"}}`, nil)
+
+	config := &api.RoutingConfig{
+		Providers: map[string]api.ProviderConfig{
+			"primary":   {Provider: api.ProviderOpenAI, Host: primary.URL, Tokens: []string{"a"}},
+			"secondary": {Provider: api.ProviderOpenAI, Host: secondary.URL, Tokens: []string{"b"}},
+		},
+		Routes: []api.Route{
+			{Models: []string{"*"}, Targets: []api.RouteTarget{{Provider: "primary"}, {Provider: "secondary"}}},
+		},
+	}
+
+	proxy, err := NewRouterProxy(config, api.MantleConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request := httptest.NewRequest(http.MethodPost, "/openai/v1/chat/completions", bytes.NewBufferString(`{"model":"any"}`))
+	response := httptest.NewRecorder()
+	proxy.Proxy().ServeHTTP(response, request)
+
+	if got, want := response.Code, http.StatusServiceUnavailable; got != want {
+		t.Errorf("status: got %d, want %d", got, want)
+	}
+	if got, want := response.Body.String(), `{"error":{"message":"unavailable"}}`; got != want {
+		t.Errorf("body: got %q, want %q", got, want)
+	}
+
+	request = httptest.NewRequest(http.MethodPost, "/openai/v1/chat/completions", bytes.NewBufferString(`{"model":"any"}`))
+	config.Routes[0].Models = []string{"gpt-*"}
+	response = httptest.NewRecorder()
+	proxy.Proxy().ServeHTTP(response, request)
+
+	if got, want := response.Code, http.StatusNotFound; got != want {
+		t.Errorf("status: got %d, want %d", got, want)
+	}
+}
+
+func TestRouterProxyReturnsUpstreamErrorWhenFallbackHasNoHandler(t *testing.T) {
+	primary := newUpstream(t, http.StatusTooManyRequests, `{"error":{"message":"rate limited","type":"rate_limit"}}`, nil)
+
+	config := &api.RoutingConfig{
+		Providers: map[string]api.ProviderConfig{
+			"primary": {Provider: api.ProviderOpenAI, Host: primary.URL, Tokens: []string{"a"}},
+			// anthropic does not serve embeddings
+			"secondary": {Provider: api.ProviderAnthropic, Host: "http://localhost", Tokens: []string{"b"}},
+		},
+		Routes: []api.Route{
+			{Models: []string{"*"}, Targets: []api.RouteTarget{{Provider: "primary"}, {Provider: "secondary"}}},
+		},
+	}
+
+	proxy, err := NewRouterProxy(config, api.MantleConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request := httptest.NewRequest(http.MethodPost, "/openai/v1/embeddings", bytes.NewBufferString(`{"model":"text-embedding-3-small","input":"hi"}`))
+	response := httptest.NewRecorder()
+	proxy.Proxy().ServeHTTP(response, request)
+
+	if got, want := response.Code, http.StatusTooManyRequests; got != want {
+		t.Errorf("status: got %d, want %d", got, want)
+	}
+	if got, want := response.Body.String(), `{"error":{"message":"rate limited","type":"rate_limit"}}`; got != want {
+		t.Errorf("body: got %q, want %q", got, want)
+	}
+	if got, want := response.Header().Get("Content-Type"), "application/json"; got != want {
+		t.Errorf("content type: got %q, want %q", got, want)
+	}
+}
@@ -49,14 +49,16 @@ func SetupServer() (*httptest.Server, error) {
 			klog.ErrorS(err, "Could not create proxy")
 			os.Exit(1)
 		}
+		router.HandleFunc(openai.EndpointChat, op.Proxy())
+		router.HandleFunc(openai.EndpointResponses, op.Proxy())
+
 		ep, err := proxy.NewOpenAIEmbeddingsProxy(args.Provider(), args.ProviderHost(), args.ProviderAwsRegion(), tokenRotator, mantleConfig)
-		if err != nil {
+		if err == nil {
+			router.HandleFunc(openai.EndpointEmbeddings, ep.Proxy())
+		} else if args.Provider() != api.ProviderAnthropic {
 			klog.ErrorS(err, "Could not create embedding proxy")
 			os.Exit(1)
 		}
-		router.HandleFunc(openai.EndpointChat, op.Proxy())
-		router.HandleFunc(openai.EndpointResponses, op.Proxy())
-		router.HandleFunc(openai.EndpointEmbeddings, ep.Proxy())
 
 		if args.Provider() == api.ProviderOpenAI {
 			aud, err := proxy.NewOpenAIAudioProxy(args.Provider(), args.ProviderHost(), args.ProviderAwsRegion(), tokenRotator)
@@ -6753,14 +6753,15 @@ func (t *RunStepFragment) GetIndex() int64 {
 }
 
 type StackConfigurationFragment struct {
-	Image      *string                                "json:\"image,omitempty\" graphql:\"image\""
-	Version    *string                                "json:\"version,omitempty\" graphql:\"version\""
-	Tag        *string                                "json:\"tag,omitempty\" graphql:\"tag\""
-	Hooks      []*StackHookFragment                   "json:\"hooks,omitempty\" graphql:\"hooks\""
-	Terraform  *StackConfigurationFragment_Terraform  "json:\"terraform,omitempty\" graphql:\"terraform\""
-	Terragrunt *StackConfigurationFragment_Terragrunt "json:\"terragrunt,omitempty\" graphql:\"terragrunt\""
-	Pulumi     *StackConfigurationFragment_Pulumi     "json:\"pulumi,omitempty\" graphql:\"pulumi\""
-	Ansible    *StackConfigurationFragment_Ansible    "json:\"ansible,omitempty\" graphql:\"ansible\""
+	Image          *string                                    "json:\"image,omitempty\" graphql:\"image\""
+	Version        *string                                    "json:\"version,omitempty\" graphql:\"version\""
+	Tag            *string                                    "json:\"tag,omitempty\" graphql:\"tag\""
+	Hooks          []*StackHookFragment                       "json:\"hooks,omitempty\" graphql:\"hooks\""
+	Terraform      *StackConfigurationFragment_Terraform      "json:\"terraform,omitempty\" graphql:\"terraform\""
+	Terragrunt     *StackConfigurationFragment_Terragrunt     "json:\"terragrunt,omitempty\" graphql:\"terragrunt\""
+	Pulumi         *StackConfigurationFragment_Pulumi         "json:\"pulumi,omitempty\" graphql:\"pulumi\""
+	Ansible        *StackConfigurationFragment_Ansible        "json:\"ansible,omitempty\" graphql:\"ansible\""
+	CostEstimation *StackConfigurationFragment_CostEstimation "json:\"costEstimation,omitempty\" graphql:\"costEstimation\""
 }
 
 func (t *StackConfigurationFragment) GetImage() *string {
@@ -6811,6 +6812,12 @@ func (t *StackConfigurationFragment) GetAnsible() *StackConfigurationFragment_An
 	}
 	return t.Ansible
 }
+func (t *StackConfigurationFragment) GetCostEstimation() *StackConfigurationFragment_CostEstimation {
+	if t == nil {
+		t = &StackConfigurationFragment{}
+	}
+	return t.CostEstimation
+}
 
 type StackHookFragment struct {
 	Cmd        string    "json:\"cmd\" graphql:\"cmd\""
@@ -14278,6 +14285,24 @@ func (t *StackConfigurationFragment_Ansible) GetPrivateKeyFile() *string {
 	return t.PrivateKeyFile
 }
 
+type StackConfigurationFragment_CostEstimation struct {
+	Enabled           bool     "json:\"enabled\" graphql:\"enabled\""
+	ApprovalThreshold *float64 "json:\"approvalThreshold,omitempty\" graphql:\"approvalThreshold\""
+}
+
+func (t *StackConfigurationFragment_CostEstimation) GetEnabled() bool {
+	if t == nil {
+		t = &StackConfigurationFragment_CostEstimation{}
+	}
+	return t.Enabled
+}
+func (t *StackConfigurationFragment_CostEstimation) GetApprovalThreshold() *float64 {
+	if t == nil {
+		t = &StackConfigurationFragment_CostEstimation{}
+	}
+	return t.ApprovalThreshold
+}
+
 type CustomStackRunFragment_Stack struct {
 	ID *string "json:\"id,omitempty\" graphql:\"id\""
 }
@@ -66086,6 +66111,10 @@ fragment StackConfigurationFragment on StackConfiguration {
 		privateKeyFile
 		configFile
 	}
+	costEstimation {
+		enabled
+		approvalThreshold
+	}
 }
 fragment StackHookFragment on StackHook {
 	cmd
@@ -66383,6 +66412,10 @@ fragment StackConfigurationFragment on StackConfiguration {
 		privateKeyFile
 		configFile
 	}
+	costEstimation {
+		enabled
+		approvalThreshold
+	}
 }
 fragment StackHookFragment on StackHook {
 	cmd
@@ -66558,6 +66591,10 @@ fragment StackConfigurationFragment on StackConfiguration {
 		privateKeyFile
 		configFile
 	}
+	costEstimation {
+		enabled
+		approvalThreshold
+	}
 }
 fragment StackHookFragment on StackHook {
 	cmd
@@ -66760,6 +66797,10 @@ fragment StackConfigurationFragment on StackConfiguration {
 		privateKeyFile
 		configFile
 	}
+	costEstimation {
+		enabled
+		approvalThreshold
+	}
 }
 fragment StackHookFragment on StackHook {
 	cmd
@@ -67015,6 +67056,10 @@ fragment StackConfigurationFragment on StackConfiguration {
 		privateKeyFile
 		configFile
 	}
+	costEstimation {
+		enabled
+		approvalThreshold
+	}
 }
 fragment StackHookFragment on StackHook {
 	cmd
@@ -67358,6 +67403,10 @@ fragment StackConfigurationFragment on StackConfiguration {
 		privateKeyFile
 		configFile
 	}
+	costEstimation {
+		enabled
+		approvalThreshold
+	}
 }
 fragment StackHookFragment on StackHook {
 	cmd
@@ -67686,6 +67735,10 @@ fragment StackConfigurationFragment on StackConfiguration {
 		privateKeyFile
 		configFile
 	}
+	costEstimation {
+		enabled
+		approvalThreshold
+	}
 }
 fragment StackHookFragment on StackHook {
 	cmd
@@ -67922,6 +67975,10 @@ fragment StackConfigurationFragment on StackConfiguration {
 		privateKeyFile
 		configFile
 	}
+	costEstimation {
+		enabled
+		approvalThreshold
+	}
 }
 fragment StackHookFragment on StackHook {
 	cmd
@@ -68213,6 +68270,10 @@ fragment StackConfigurationFragment on StackConfiguration {
 		privateKeyFile
 		configFile
 	}
+	costEstimation {
+		enabled
+		approvalThreshold
+	}
 }
 fragment StackHookFragment on StackHook {
 	cmd
@@ -68921,6 +68982,10 @@ fragment StackConfigurationFragment on StackConfiguration {
 		privateKeyFile
 		configFile
 	}
+	costEstimation {
+		enabled
+		approvalThreshold
+	}
 }
 fragment StackHookFragment on StackHook {
 	cmd
@@ -69268,6 +69333,10 @@ fragment StackConfigurationFragment on StackConfiguration {
 		privateKeyFile
 		configFile
 	}
+	costEstimation {
+		enabled
+		approvalThreshold
+	}
 }
 fragment StackHookFragment on StackHook {
 	cmd
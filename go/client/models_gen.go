@@ -759,6 +759,20 @@ type AiApprovalConfiguration struct {
 }
 
 // configuration for AI based service promotion
+type AiBlockedTopic struct {
+	// the name of the topic, reported when a prompt is rejected
+	Name string `json:"name"`
+	// case-insensitive regexes matching prompts about this topic
+	Patterns []*string `json:"patterns,omitempty"`
+}
+
+type AiBlockedTopicAttributes struct {
+	// the name of the topic, reported when a prompt is rejected
+	Name string `json:"name"`
+	// case-insensitive regexes matching prompts about this topic
+	Patterns []*string `json:"patterns,omitempty"`
+}
+
 type AiCriteriaAttributes struct {
 	// whether AI based service promotion is enabled for this promotion
 	Enabled *bool `json:"enabled,omitempty"`
@@ -778,6 +792,41 @@ type AiDelta struct {
 	Tool    *ToolDelta `json:"tool,omitempty"`
 }
 
+type AiGuardrailsAttributes struct {
+	// whether guardrails are enforced on AI proxy requests
+	Enabled *bool `json:"enabled,omitempty"`
+	// whether to replace PII in prompts with reversible placeholders
+	RedactPii *bool `json:"redactPii,omitempty"`
+	// whether to redact credentials like cloud keys, private keys and kubernetes tokens
+	DetectSecrets *bool `json:"detectSecrets,omitempty"`
+	// the maximum number of characters allowed in a prompt
+	MaxPromptSize *int64 `json:"maxPromptSize,omitempty"`
+	// a dictionary of terms that are always redacted
+	PiiTerms []*string `json:"piiTerms,omitempty"`
+	// custom regexes for PII that should be redacted
+	PiiPatterns []*AiPiiPatternAttributes `json:"piiPatterns,omitempty"`
+	// topics that cause a prompt to be rejected
+	BlockedTopics []*AiBlockedTopicAttributes `json:"blockedTopics,omitempty"`
+}
+
+// Prompt guardrails enforced by the AI proxy before and after provider calls
+type AiGuardrailsSettings struct {
+	// whether guardrails are enforced on AI proxy requests
+	Enabled *bool `json:"enabled,omitempty"`
+	// whether to replace PII in prompts with reversible placeholders
+	RedactPii *bool `json:"redactPii,omitempty"`
+	// whether to redact credentials like cloud keys, private keys and kubernetes tokens
+	DetectSecrets *bool `json:"detectSecrets,omitempty"`
+	// the maximum number of characters allowed in a prompt
+	MaxPromptSize *int64 `json:"maxPromptSize,omitempty"`
+	// a dictionary of terms that are always redacted
+	PiiTerms []*string `json:"piiTerms,omitempty"`
+	// custom regexes for PII that should be redacted
+	PiiPatterns []*AiPiiPattern `json:"piiPatterns,omitempty"`
+	// topics that cause a prompt to be rejected
+	BlockedTopics []*AiBlockedTopic `json:"blockedTopics,omitempty"`
+}
+
 // A representation of a LLM-derived insight
 type AiInsight struct {
 	ID string `json:"id"`
@@ -814,6 +863,20 @@ type AiInsightEvidence struct {
 	UpdatedAt   *string              `json:"updatedAt,omitempty"`
 }
 
+type AiPiiPattern struct {
+	// the name of the placeholder used for redacted matches
+	Name string `json:"name"`
+	// the regex matching this kind of PII
+	Pattern string `json:"pattern"`
+}
+
+type AiPiiPatternAttributes struct {
+	// the name of the placeholder used for redacted matches
+	Name string `json:"name"`
+	// the regex matching this kind of PII
+	Pattern string `json:"pattern"`
+}
+
 // A saved item for future ai-based investigation
 type AiPin struct {
 	ID         string      `json:"id"`
@@ -893,6 +956,8 @@ type AiSettings struct {
 	Vertex           *VertexAiSettings    `json:"vertex,omitempty"`
 	// per-model token prices used to calculate AI usage costs
 	PriceSheets []*AiPriceSheet `json:"priceSheets,omitempty"`
+	// prompt guardrails enforced by the AI proxy
+	Guardrails *AiGuardrailsSettings `json:"guardrails,omitempty"`
 }
 
 type AiSettingsAttributes struct {
@@ -920,6 +985,8 @@ type AiSettingsAttributes struct {
 	Graph            *GraphStoreAttributes        `json:"graph,omitempty"`
 	// per-model token prices used to calculate AI usage costs
 	PriceSheets []*AiPriceSheetAttributes `json:"priceSheets,omitempty"`
+	// prompt guardrails enforced by the AI proxy
+	Guardrails *AiGuardrailsAttributes `json:"guardrails,omitempty"`
 }
 
 type AiUsageAttributes struct {
@@ -2916,7 +2983,9 @@ type ComponentContent struct {
 	ID   string  `json:"id"`
 	Live *string `json:"live,omitempty"`
 	// the inferred desired state of this component
-	Desired    *string `json:"desired,omitempty"`
+	Desired *string `json:"desired,omitempty"`
+	// a json encoded structured diff between the live and desired states of this component
+	Diff       *string `json:"diff,omitempty"`
 	InsertedAt *string `json:"insertedAt,omitempty"`
 	UpdatedAt  *string `json:"updatedAt,omitempty"`
 }
@@ -2926,6 +2995,8 @@ type ComponentContentAttributes struct {
 	// the desired state of a service component as determined from the configured manifests
 	Desired *string `json:"desired,omitempty"`
 	Live    *string `json:"live,omitempty"`
+	// a json encoded structured diff between the live and desired states of a service component
+	Diff *string `json:"diff,omitempty"`
 }
 
 // A tree view of the kubernetes object hierarchy beneath a component
@@ -3120,6 +3191,21 @@ type CostAttributes struct {
 	StorageCost      *float64 `json:"storageCost,omitempty"`
 }
 
+type CostEstimationAttributes struct {
+	// whether to price planned changes after the plan stage
+	Enabled bool `json:"enabled"`
+	// the monthly cost increase above which a run requires manual approval
+	ApprovalThreshold *float64 `json:"approvalThreshold,omitempty"`
+}
+
+// Configuration for estimating the cost of planned changes in a stack run
+type CostEstimationConfiguration struct {
+	// whether to price planned changes after the plan stage
+	Enabled bool `json:"enabled"`
+	// the monthly cost increase above which a run requires manual approval
+	ApprovalThreshold *float64 `json:"approvalThreshold,omitempty"`
+}
+
 type CostIngestAttributes struct {
 	Cluster         *CostAttributes                    `json:"cluster,omitempty"`
 	Namespaces      []*CostAttributes                  `json:"namespaces,omitempty"`
@@ -9039,6 +9125,8 @@ type StackConfiguration struct {
 	Ansible *AnsibleConfiguration `json:"ansible,omitempty"`
 	// the ai approval configuration for this stack
 	AiApproval *AiApprovalConfiguration `json:"aiApproval,omitempty"`
+	// the cost estimation configuration for this stack
+	CostEstimation *CostEstimationConfiguration `json:"costEstimation,omitempty"`
 }
 
 type StackConfigurationAttributes struct {
@@ -9060,6 +9148,8 @@ type StackConfigurationAttributes struct {
 	Ansible *AnsibleConfigurationAttributes `json:"ansible,omitempty"`
 	// the ai approval configuration for this stack
 	AiApproval *AiApprovalAttributes `json:"aiApproval,omitempty"`
+	// the cost estimation configuration for this stack
+	CostEstimation *CostEstimationAttributes `json:"costEstimation,omitempty"`
 }
 
 type StackCron struct {
@@ -12344,6 +12434,8 @@ const (
 	AgentRuntimeTypeCustom   AgentRuntimeType = "CUSTOM"
 	AgentRuntimeTypeCodex    AgentRuntimeType = "CODEX"
 	AgentRuntimeTypePi       AgentRuntimeType = "PI"
+	AgentRuntimeTypeAider    AgentRuntimeType = "AIDER"
+	AgentRuntimeTypeGoose    AgentRuntimeType = "GOOSE"
 )
 
 var AllAgentRuntimeType = []AgentRuntimeType{
@@ -12353,11 +12445,13 @@ var AllAgentRuntimeType = []AgentRuntimeType{
 	AgentRuntimeTypeCustom,
 	AgentRuntimeTypeCodex,
 	AgentRuntimeTypePi,
+	AgentRuntimeTypeAider,
+	AgentRuntimeTypeGoose,
 }
 
 func (e AgentRuntimeType) IsValid() bool {
 	switch e {
-	case AgentRuntimeTypeClaude, AgentRuntimeTypeOpencode, AgentRuntimeTypeGemini, AgentRuntimeTypeCustom, AgentRuntimeTypeCodex, AgentRuntimeTypePi:
+	case AgentRuntimeTypeClaude, AgentRuntimeTypeOpencode, AgentRuntimeTypeGemini, AgentRuntimeTypeCustom, AgentRuntimeTypeCodex, AgentRuntimeTypePi, AgentRuntimeTypeAider, AgentRuntimeTypeGoose:
 		return true
 	}
 	return false
@@ -16052,16 +16146,20 @@ func (e PolicyAggregate) MarshalJSON() ([]byte, error) {
 type PolicyEngineType string
 
 const (
-	PolicyEngineTypeTrivy PolicyEngineType = "TRIVY"
+	PolicyEngineTypeTrivy    PolicyEngineType = "TRIVY"
+	PolicyEngineTypeCheckov  PolicyEngineType = "CHECKOV"
+	PolicyEngineTypeConftest PolicyEngineType = "CONFTEST"
 )
 
 var AllPolicyEngineType = []PolicyEngineType{
 	PolicyEngineTypeTrivy,
+	PolicyEngineTypeCheckov,
+	PolicyEngineTypeConftest,
 }
 
 func (e PolicyEngineType) IsValid() bool {
 	switch e {
-	case PolicyEngineTypeTrivy:
+	case PolicyEngineTypeTrivy, PolicyEngineTypeCheckov, PolicyEngineTypeConftest:
 		return true
 	}
 	return false
@@ -16512,6 +16610,9 @@ const (
 	RendererTypeRaw       RendererType = "RAW"
 	RendererTypeHelm      RendererType = "HELM"
 	RendererTypeKustomize RendererType = "KUSTOMIZE"
+	RendererTypeJsonnet   RendererType = "JSONNET"
+	RendererTypeCue       RendererType = "CUE"
+	RendererTypeKcl       RendererType = "KCL"
 )
 
 var AllRendererType = []RendererType{
@@ -16519,11 +16620,14 @@ var AllRendererType = []RendererType{
 	RendererTypeRaw,
 	RendererTypeHelm,
 	RendererTypeKustomize,
+	RendererTypeJsonnet,
+	RendererTypeCue,
+	RendererTypeKcl,
 }
 
 func (e RendererType) IsValid() bool {
 	switch e {
-	case RendererTypeAuto, RendererTypeRaw, RendererTypeHelm, RendererTypeKustomize:
+	case RendererTypeAuto, RendererTypeRaw, RendererTypeHelm, RendererTypeKustomize, RendererTypeJsonnet, RendererTypeCue, RendererTypeKcl:
 		return true
 	}
 	return false
@@ -17453,6 +17557,8 @@ const (
 	StackTypeCustom     StackType = "CUSTOM"
 	StackTypeTerragrunt StackType = "TERRAGRUNT"
 	StackTypePulumi     StackType = "PULUMI"
+	StackTypeOpentofu   StackType = "OPENTOFU"
+	StackTypeCdktf      StackType = "CDKTF"
 )
 
 var AllStackType = []StackType{
@@ -17461,11 +17567,13 @@ var AllStackType = []StackType{
 	StackTypeCustom,
 	StackTypeTerragrunt,
 	StackTypePulumi,
+	StackTypeOpentofu,
+	StackTypeCdktf,
 }
 
 func (e StackType) IsValid() bool {
 	switch e {
-	case StackTypeTerraform, StackTypeAnsible, StackTypeCustom, StackTypeTerragrunt, StackTypePulumi:
+	case StackTypeTerraform, StackTypeAnsible, StackTypeCustom, StackTypeTerragrunt, StackTypePulumi, StackTypeOpentofu, StackTypeCdktf:
 		return true
 	}
 	return false
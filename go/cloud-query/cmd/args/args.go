@@ -38,6 +38,8 @@ const (
 	defaultDatabaseEnabled        = true
 	defaultServerAddress          = ":9192"
 	defaultServerEnableReflection = false
+	defaultToolQueryCacheTTL      = 30 * time.Second
+	defaultToolQueryCacheSize     = 1000
 )
 
 var (
@@ -52,6 +54,8 @@ var (
 	argServerTLSCertPath      = pflag.String("server-tls-cert", "", "path to the TLS certificate file for the gRPC server")
 	argServerTLSKeyPath       = pflag.String("server-tls-key", "", "path to the TLS key file for the gRPC server")
 	argServerEnableReflection = pflag.Bool("server-enable-reflection", defaultServerEnableReflection, "enable gRPC reflection for the server, useful for debugging and introspection")
+	argToolQueryCacheTTL      = pflag.Duration("toolquery-cache-ttl", defaultToolQueryCacheTTL, "TTL for cached ToolQuery results, identical concurrent queries are coalesced while caching is enabled, set to 0 to disable")
+	argToolQueryCacheSize     = pflag.Int("toolquery-cache-size", defaultToolQueryCacheSize, "maximum number of cached ToolQuery results")
 )
 
 func DatabaseEnabled() bool {
@@ -138,6 +142,22 @@ func DatabaseConnectionTTL() time.Duration {
 	return *argDatabaseConnectionTTL
 }
 
+func ToolQueryCacheTTL() time.Duration {
+	if *argToolQueryCacheTTL < 0 {
+		return 0
+	}
+
+	return *argToolQueryCacheTTL
+}
+
+func ToolQueryCacheSize() int {
+	if *argToolQueryCacheSize <= 0 {
+		return defaultToolQueryCacheSize
+	}
+
+	return *argToolQueryCacheSize
+}
+
 func LogLevel() klog.Level {
 	v := pflag.Lookup("v")
 	if v == nil {
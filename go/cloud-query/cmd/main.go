@@ -7,9 +7,11 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"k8s.io/klog/v2"
 
 	"github.com/pluralsh/console/go/cloud-query/cmd/args"
+	"github.com/pluralsh/console/go/cloud-query/internal/cache"
 	"github.com/pluralsh/console/go/cloud-query/internal/pool"
 	"github.com/pluralsh/console/go/cloud-query/internal/server"
 	"github.com/pluralsh/console/go/cloud-query/internal/service"
@@ -17,8 +19,9 @@ import (
 
 func startHealthzHandler() {
 	http.HandleFunc("/healthz", healthz)
+	http.Handle("/metrics", promhttp.Handler())
 	go func() {
-		klog.InfoS("starting /healthz endpoint", "address", ":8080")
+		klog.InfoS("starting /healthz and /metrics endpoints", "address", ":8080")
 		err := http.ListenAndServe(":8080", nil)
 		if err != nil {
 			klog.Fatalf("failed to start healthz server: %v", err)
@@ -29,7 +32,9 @@ func startHealthzHandler() {
 func main() {
 	startHealthzHandler()
 
-	services := []service.Service{service.NewToolQueryService()}
+	services := []service.Service{
+		service.NewToolQueryService(cache.New(args.ToolQueryCacheTTL(), args.ToolQueryCacheSize())),
+	}
 
 	if args.DatabaseEnabled() {
 		p, err := pool.NewConnectionPool(args.DatabaseConnectionTTL())
@@ -0,0 +1,188 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	cmap "github.com/orcaman/concurrent-map/v2"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// cleanupInterval is how often expired entries are removed from the cache.
+	cleanupInterval = 1 * time.Minute
+
+	// defaultCallTimeout bounds shared calls when the caller did not set a deadline.
+	defaultCallTimeout = 2 * time.Minute
+)
+
+// Cache is an in-process result cache with a fixed TTL. Concurrent calls with the
+// same key are coalesced into a single call. Errors are never cached.
+//
+// A nil *Cache is valid and disables both caching and coalescing.
+type Cache struct {
+	entries    cmap.ConcurrentMap[string, entry]
+	group      singleflight.Group
+	ttl        time.Duration
+	maxEntries int
+}
+
+type entry struct {
+	value   any
+	expires time.Time
+}
+
+func (e entry) alive() bool {
+	return time.Now().Before(e.expires)
+}
+
+// New creates a cache storing at most maxEntries results for ttl. A non-positive
+// ttl disables the cache and nil is returned.
+func New(ttl time.Duration, maxEntries int) *Cache {
+	if ttl <= 0 {
+		return nil
+	}
+
+	cache := &Cache{
+		entries:    cmap.New[entry](),
+		ttl:        ttl,
+		maxEntries: maxEntries,
+	}
+
+	go cache.cleanupRoutine()
+
+	return cache
+}
+
+// TTL returns how long results are cached for.
+func (c *Cache) TTL() time.Duration {
+	if c == nil {
+		return 0
+	}
+
+	return c.ttl
+}
+
+func (c *Cache) cleanupRoutine() {
+	ticker := time.NewTicker(cleanupInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		c.cleanup()
+	}
+}
+
+func (c *Cache) cleanup() {
+	for item := range c.entries.IterBuffered() {
+		if !item.Val.alive() {
+			c.entries.RemoveCb(item.Key, func(_ string, v entry, exists bool) bool {
+				return exists && !v.alive()
+			})
+		}
+	}
+
+	entriesGauge.Set(float64(c.entries.Count()))
+}
+
+func (c *Cache) get(key string) (any, bool) {
+	e, ok := c.entries.Get(key)
+	if !ok || !e.alive() {
+		return nil, false
+	}
+
+	return e.value, true
+}
+
+func (c *Cache) set(key string, value any) {
+	if c.maxEntries > 0 && !c.entries.Has(key) && c.entries.Count() >= c.maxEntries {
+		c.cleanup()
+		for c.entries.Count() >= c.maxEntries {
+			if !c.evictOldest() {
+				break
+			}
+		}
+	}
+
+	c.entries.Set(key, entry{value: value, expires: time.Now().Add(c.ttl)})
+	entriesGauge.Set(float64(c.entries.Count()))
+}
+
+// evictOldest removes the entry closest to expiring, which is the oldest one since
+// every entry lives for the same TTL. It reports whether an entry was removed.
+func (c *Cache) evictOldest() bool {
+	var oldest string
+	var expires time.Time
+	for item := range c.entries.IterBuffered() {
+		if expires.IsZero() || item.Val.expires.Before(expires) {
+			oldest, expires = item.Key, item.Val.expires
+		}
+	}
+
+	if expires.IsZero() {
+		return false
+	}
+
+	c.entries.RemoveCb(oldest, func(_ string, v entry, exists bool) bool {
+		return exists && v.expires.Equal(expires)
+	})
+	return true
+}
+
+// Do returns the cached result for key or calls fn to produce it. Concurrent calls
+// for the same key wait for a single fn call. The shared call is not canceled when
+// one of the waiting callers goes away, it is bound by the caller deadline instead.
+// An empty key bypasses the cache.
+func Do[T any](ctx context.Context, c *Cache, operation, key string, fn func(context.Context) (T, error)) (T, error) {
+	if c == nil || len(key) == 0 {
+		return fn(ctx)
+	}
+
+	if value, ok := c.get(key); ok {
+		requestsCounter.WithLabelValues(operation, ResultHit).Inc()
+		return value.(T), nil
+	}
+
+	executed := false
+	ch := c.group.DoChan(key, func() (any, error) {
+		executed = true
+
+		callCtx, cancel := detach(ctx)
+		defer cancel()
+
+		value, err := fn(callCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		c.set(key, value)
+		return value, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		var empty T
+		return empty, ctx.Err()
+	case res := <-ch:
+		if executed {
+			requestsCounter.WithLabelValues(operation, ResultMiss).Inc()
+		} else {
+			requestsCounter.WithLabelValues(operation, ResultCoalesced).Inc()
+		}
+
+		if res.Err != nil {
+			var empty T
+			return empty, res.Err
+		}
+
+		return res.Val.(T), nil
+	}
+}
+
+func detach(ctx context.Context) (context.Context, context.CancelFunc) {
+	detached := context.WithoutCancel(ctx)
+	if deadline, ok := ctx.Deadline(); ok {
+		return context.WithDeadline(detached, deadline)
+	}
+
+	return context.WithTimeout(detached, defaultCallTimeout)
+}
@@ -0,0 +1,137 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDoCachesResults(t *testing.T) {
+	c := New(time.Minute, 10)
+	calls := 0
+	fn := func(context.Context) (string, error) {
+		calls++
+		return "result", nil
+	}
+
+	for range 3 {
+		value, err := Do(context.Background(), c, "test", "key", fn)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if value != "result" {
+			t.Fatalf("unexpected value: %s", value)
+		}
+	}
+
+	if calls != 1 {
+		t.Fatalf("expected a single call, got %d", calls)
+	}
+}
+
+func TestDoDoesNotCacheErrors(t *testing.T) {
+	c := New(time.Minute, 10)
+	calls := 0
+	fn := func(context.Context) (string, error) {
+		calls++
+		return "", errors.New("boom")
+	}
+
+	for range 2 {
+		if _, err := Do(context.Background(), c, "test", "key", fn); err == nil {
+			t.Fatalf("expected error")
+		}
+	}
+
+	if calls != 2 {
+		t.Fatalf("expected errors to be retried, got %d calls", calls)
+	}
+}
+
+func TestDoCoalescesConcurrentCalls(t *testing.T) {
+	c := New(time.Minute, 10)
+	release := make(chan struct{})
+	var calls atomic.Int32
+
+	fn := func(context.Context) (int, error) {
+		calls.Add(1)
+		<-release
+		return 42, nil
+	}
+
+	var wg sync.WaitGroup
+	results := make(chan int, 5)
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := Do(context.Background(), c, "test", "key", fn)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			results <- value
+		}()
+	}
+
+	// give all callers a chance to join the in-flight call
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(results)
+
+	for value := range results {
+		if value != 42 {
+			t.Fatalf("unexpected value: %d", value)
+		}
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("expected a single call, got %d", calls.Load())
+	}
+}
+
+func TestDoWithoutCacheOrKey(t *testing.T) {
+	calls := 0
+	fn := func(context.Context) (string, error) {
+		calls++
+		return "result", nil
+	}
+
+	for _, c := range []*Cache{New(0, 10), New(time.Minute, 10)} {
+		for range 2 {
+			if _, err := Do(context.Background(), c, "test", "", fn); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+	}
+
+	if calls != 4 {
+		t.Fatalf("expected every call to bypass the cache, got %d calls", calls)
+	}
+}
+
+func TestSetEvictsOldestEntryWhenFull(t *testing.T) {
+	c := New(time.Minute, 2)
+	c.set("first", 1)
+	c.set("second", 2)
+	c.set("third", 3)
+
+	if _, ok := c.get("first"); ok {
+		t.Fatalf("expected the oldest entry to be evicted when the cache is full")
+	}
+	for _, key := range []string{"second", "third"} {
+		if _, ok := c.get(key); !ok {
+			t.Fatalf("expected %s entry to be cached", key)
+		}
+	}
+	if count := c.entries.Count(); count != 2 {
+		t.Fatalf("expected 2 entries, got %d", count)
+	}
+
+	c.set("second", 4)
+	if _, ok := c.get("third"); !ok {
+		t.Fatalf("expected overwriting an entry not to evict others")
+	}
+}
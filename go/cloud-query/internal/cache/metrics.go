@@ -0,0 +1,27 @@
+package cache
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	// ResultHit marks requests served from the cache.
+	ResultHit = "hit"
+	// ResultMiss marks requests that called the backing provider.
+	ResultMiss = "miss"
+	// ResultCoalesced marks requests that waited for an identical in-flight request.
+	ResultCoalesced = "coalesced"
+)
+
+var (
+	requestsCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "cloud_query_toolquery_cache_requests_total",
+		Help: "Total number of ToolQuery cache lookups by operation and result (hit, miss, coalesced).",
+	}, []string{"operation", "result"})
+
+	entriesGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "cloud_query_toolquery_cache_entries",
+		Help: "Current number of entries in the ToolQuery cache.",
+	})
+)
@@ -0,0 +1,98 @@
+package azure
+
+import (
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"github.com/pluralsh/console/go/cloud-query/internal/extractor"
+	"github.com/pluralsh/console/go/cloud-query/internal/log"
+)
+
+const (
+	TableVirtualNetwork        extractor.Table = "azure_virtual_network"
+	TableSubnet                extractor.Table = "azure_subnet"
+	TableNetworkInterface      extractor.Table = "azure_network_interface"
+	TableKubernetesCluster     extractor.Table = "azure_kubernetes_cluster"
+	TableKubernetesAgentPool   extractor.Table = "azure_kubernetes_service_cluster_agent_pool"
+	TableComputeVirtualMachine extractor.Table = "azure_compute_virtual_machine"
+	TableStorageAccount        extractor.Table = "azure_storage_account"
+)
+
+var (
+	// resources define the mapping of Azure resources to their respective representations.
+	resources = map[extractor.Table]extractor.ResourceFactory{
+		TableVirtualNetwork:        extractor.NewResource[VirtualNetwork],
+		TableSubnet:                extractor.NewResource[Subnet],
+		TableNetworkInterface:      extractor.NewResource[NetworkInterface],
+		TableKubernetesCluster:     extractor.NewResource[KubernetesCluster],
+		TableKubernetesAgentPool:   extractor.NewResource[KubernetesAgentPool],
+		TableComputeVirtualMachine: extractor.NewResource[ComputeVirtualMachine],
+		TableStorageAccount:        extractor.NewResource[StorageAccount],
+	}
+
+	// extractOrder defines the order in which resources should be extracted.
+	extractOrder = []extractor.Table{
+		TableVirtualNetwork,        // Links: None
+		TableSubnet,                // Links: VirtualNetwork
+		TableNetworkInterface,      // Links: Subnet
+		TableKubernetesCluster,     // Links: Subnet
+		TableKubernetesAgentPool,   // Links: KubernetesCluster, Subnet
+		TableComputeVirtualMachine, // Links: NetworkInterface
+		TableStorageAccount,        // Links: Subnet
+	}
+)
+
+func entries() []extractor.Entry {
+	result := make([]extractor.Entry, 0, len(resources))
+	for _, table := range extractOrder {
+		factory, ok := resources[table]
+		if !ok {
+			klog.V(log.LogLevelDefault).InfoS("could not find resource factory for table", "table", table)
+			continue
+		}
+
+		result = append(result, extractor.Entry{
+			Table:   table,
+			Factory: factory,
+		})
+	}
+
+	return result
+}
+
+// normalizeID returns the lookup key for the Azure resource ID.
+// Azure resource IDs are case-insensitive and references between resources
+// do not always use the same casing, i.e. "resourceGroups" and "resourcegroups".
+func normalizeID(id string) string {
+	return strings.ToLower(strings.TrimSuffix(id, "/"))
+}
+
+// parentID returns the ID of the parent resource for child resource IDs structured as:
+// "/subscriptions/<id>/resourceGroups/<group>/providers/<namespace>/<type>/<name>/<child-type>/<child-name>"
+func parentID(id, childType string) string {
+	idx := strings.LastIndex(normalizeID(id), "/"+strings.ToLower(childType)+"/")
+	if idx < 0 {
+		klog.V(log.LogLevelVerbose).ErrorS(nil, "invalid child resource ID format", "id", id, "type", childType)
+		return ""
+	}
+
+	return id[:idx]
+}
+
+// linkIDs resolves referenced Azure resource IDs to the extracted ones.
+func linkIDs(lookup map[string]string, ids ...string) []string {
+	links := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if link, ok := lookup[normalizeID(id)]; ok && len(id) > 0 {
+			links = append(links, link)
+		}
+	}
+
+	return links
+}
+
+// NewAzureExtractor creates a new Azure extractor with the given sink.
+func NewAzureExtractor(sink extractor.Sink) extractor.Extractor {
+	return extractor.NewDefaultExtractor(sink, entries())
+}
@@ -0,0 +1,26 @@
+package azure
+
+type ComputeVirtualMachine struct {
+	ResourceID        string `json:"id"`
+	Name              string `json:"name"`
+	NetworkInterfaces []struct {
+		ID string `json:"id"`
+	} `json:"network_interfaces"`
+}
+
+func (in ComputeVirtualMachine) ID() string {
+	return in.ResourceID
+}
+
+func (in ComputeVirtualMachine) ShortID() string {
+	return normalizeID(in.ResourceID)
+}
+
+func (in ComputeVirtualMachine) Links(lookup map[string]string) []string {
+	interfaceIDs := make([]string, 0, len(in.NetworkInterfaces))
+	for _, networkInterface := range in.NetworkInterfaces {
+		interfaceIDs = append(interfaceIDs, networkInterface.ID)
+	}
+
+	return linkIDs(lookup, interfaceIDs...)
+}
@@ -0,0 +1,19 @@
+package azure
+
+type KubernetesAgentPool struct {
+	ResourceID   string `json:"id"`
+	Name         string `json:"name"`
+	VnetSubnetID string `json:"vnet_subnet_id"`
+}
+
+func (in KubernetesAgentPool) ID() string {
+	return in.ResourceID
+}
+
+func (in KubernetesAgentPool) ShortID() string {
+	return normalizeID(in.ResourceID)
+}
+
+func (in KubernetesAgentPool) Links(lookup map[string]string) []string {
+	return linkIDs(lookup, parentID(in.ResourceID, "agentPools"), in.VnetSubnetID)
+}
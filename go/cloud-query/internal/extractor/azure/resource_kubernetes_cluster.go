@@ -0,0 +1,29 @@
+package azure
+
+type KubernetesCluster struct {
+	ResourceID        string                  `json:"id"`
+	Name              string                  `json:"name"`
+	AgentPoolProfiles []KubernetesPoolProfile `json:"agent_pool_profiles"`
+}
+
+type KubernetesPoolProfile struct {
+	Name         string `json:"name"`
+	VnetSubnetID string `json:"vnetSubnetID"`
+}
+
+func (in KubernetesCluster) ID() string {
+	return in.ResourceID
+}
+
+func (in KubernetesCluster) ShortID() string {
+	return normalizeID(in.ResourceID)
+}
+
+func (in KubernetesCluster) Links(lookup map[string]string) []string {
+	subnetIDs := make([]string, 0, len(in.AgentPoolProfiles))
+	for _, profile := range in.AgentPoolProfiles {
+		subnetIDs = append(subnetIDs, profile.VnetSubnetID)
+	}
+
+	return linkIDs(lookup, subnetIDs...)
+}
@@ -0,0 +1,32 @@
+package azure
+
+type NetworkInterface struct {
+	ResourceID       string                   `json:"id"`
+	Name             string                   `json:"name"`
+	IPConfigurations []NetworkIPConfiguration `json:"ip_configurations"`
+}
+
+type NetworkIPConfiguration struct {
+	Properties struct {
+		Subnet struct {
+			ID string `json:"id"`
+		} `json:"subnet"`
+	} `json:"properties"`
+}
+
+func (in NetworkInterface) ID() string {
+	return in.ResourceID
+}
+
+func (in NetworkInterface) ShortID() string {
+	return normalizeID(in.ResourceID)
+}
+
+func (in NetworkInterface) Links(lookup map[string]string) []string {
+	subnetIDs := make([]string, 0, len(in.IPConfigurations))
+	for _, config := range in.IPConfigurations {
+		subnetIDs = append(subnetIDs, config.Properties.Subnet.ID)
+	}
+
+	return linkIDs(lookup, subnetIDs...)
+}
@@ -0,0 +1,26 @@
+package azure
+
+type StorageAccount struct {
+	ResourceID          string `json:"id"`
+	Name                string `json:"name"`
+	VirtualNetworkRules []struct {
+		ID string `json:"id"`
+	} `json:"virtual_network_rules"`
+}
+
+func (in StorageAccount) ID() string {
+	return in.ResourceID
+}
+
+func (in StorageAccount) ShortID() string {
+	return normalizeID(in.ResourceID)
+}
+
+func (in StorageAccount) Links(lookup map[string]string) []string {
+	subnetIDs := make([]string, 0, len(in.VirtualNetworkRules))
+	for _, rule := range in.VirtualNetworkRules {
+		subnetIDs = append(subnetIDs, rule.ID)
+	}
+
+	return linkIDs(lookup, subnetIDs...)
+}
@@ -0,0 +1,18 @@
+package azure
+
+type Subnet struct {
+	ResourceID string `json:"id"`
+	Name       string `json:"name"`
+}
+
+func (in Subnet) ID() string {
+	return in.ResourceID
+}
+
+func (in Subnet) ShortID() string {
+	return normalizeID(in.ResourceID)
+}
+
+func (in Subnet) Links(lookup map[string]string) []string {
+	return linkIDs(lookup, parentID(in.ResourceID, "subnets"))
+}
@@ -0,0 +1,20 @@
+package azure
+
+import (
+	"github.com/pluralsh/console/go/cloud-query/internal/extractor"
+)
+
+type VirtualNetwork struct {
+	extractor.UnlinkedResource
+
+	ResourceID string `json:"id"`
+	Name       string `json:"name"`
+}
+
+func (in VirtualNetwork) ID() string {
+	return in.ResourceID
+}
+
+func (in VirtualNetwork) ShortID() string {
+	return normalizeID(in.ResourceID)
+}
@@ -0,0 +1,94 @@
+package gcp
+
+import (
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"github.com/pluralsh/console/go/cloud-query/internal/extractor"
+	"github.com/pluralsh/console/go/cloud-query/internal/log"
+)
+
+const (
+	TableComputeNetwork    extractor.Table = "gcp_compute_network"
+	TableComputeSubnetwork extractor.Table = "gcp_compute_subnetwork"
+	TableKubernetesCluster extractor.Table = "gcp_kubernetes_cluster"
+	TableKubernetesPool    extractor.Table = "gcp_kubernetes_node_pool"
+	TableComputeInstance   extractor.Table = "gcp_compute_instance"
+	TableStorageBucket     extractor.Table = "gcp_storage_bucket"
+)
+
+var (
+	// resources define the mapping of GCP resources to their respective representations.
+	resources = map[extractor.Table]extractor.ResourceFactory{
+		TableComputeNetwork:    extractor.NewResource[ComputeNetwork],
+		TableComputeSubnetwork: extractor.NewResource[ComputeSubnetwork],
+		TableKubernetesCluster: extractor.NewResource[KubernetesCluster],
+		TableKubernetesPool:    extractor.NewResource[KubernetesNodePool],
+		TableComputeInstance:   extractor.NewResource[ComputeInstance],
+		TableStorageBucket:     extractor.NewResource[StorageBucket],
+	}
+
+	// extractOrder defines the order in which resources should be extracted.
+	extractOrder = []extractor.Table{
+		TableComputeNetwork,    // Links: None
+		TableComputeSubnetwork, // Links: ComputeNetwork
+		TableKubernetesCluster, // Links: ComputeNetwork, ComputeSubnetwork
+		TableKubernetesPool,    // Links: KubernetesCluster
+		TableComputeInstance,   // Links: ComputeNetwork, ComputeSubnetwork
+		TableStorageBucket,     // Links: None
+	}
+)
+
+func entries() []extractor.Entry {
+	result := make([]extractor.Entry, 0, len(resources))
+	for _, table := range extractOrder {
+		factory, ok := resources[table]
+		if !ok {
+			klog.V(log.LogLevelDefault).InfoS("could not find resource factory for table", "table", table)
+			continue
+		}
+
+		result = append(result, extractor.Entry{
+			Table:   table,
+			Factory: factory,
+		})
+	}
+
+	return result
+}
+
+// selfLinkToShortID returns the relative resource name for the GCP self link.
+// References between GCP resources use either full self links i.e.
+// "https://www.googleapis.com/compute/v1/projects/<project>/global/networks/<name>"
+// or relative names i.e. "projects/<project>/global/networks/<name>".
+func selfLinkToShortID(selfLink string) string {
+	idx := strings.Index(selfLink, "projects/")
+	if idx < 0 {
+		klog.V(log.LogLevelVerbose).ErrorS(nil, "invalid self link format", "selfLink", selfLink)
+		return ""
+	}
+
+	return strings.TrimSuffix(selfLink[idx:], "/")
+}
+
+// linkSelfLinks resolves referenced GCP resources to the extracted ones.
+func linkSelfLinks(lookup map[string]string, selfLinks ...string) []string {
+	links := make([]string, 0, len(selfLinks))
+	for _, selfLink := range selfLinks {
+		if len(selfLink) == 0 {
+			continue
+		}
+
+		if link, ok := lookup[selfLinkToShortID(selfLink)]; ok {
+			links = append(links, link)
+		}
+	}
+
+	return links
+}
+
+// NewGCPExtractor creates a new GCP extractor with the given sink.
+func NewGCPExtractor(sink extractor.Sink) extractor.Extractor {
+	return extractor.NewDefaultExtractor(sink, entries())
+}
@@ -0,0 +1,27 @@
+package gcp
+
+type ComputeInstance struct {
+	SelfLink          string `json:"self_link"`
+	Name              string `json:"name"`
+	NetworkInterfaces []struct {
+		Network    string `json:"network"`
+		Subnetwork string `json:"subnetwork"`
+	} `json:"network_interfaces"`
+}
+
+func (in ComputeInstance) ID() string {
+	return in.SelfLink
+}
+
+func (in ComputeInstance) ShortID() string {
+	return selfLinkToShortID(in.SelfLink)
+}
+
+func (in ComputeInstance) Links(lookup map[string]string) []string {
+	selfLinks := make([]string, 0, len(in.NetworkInterfaces)*2)
+	for _, networkInterface := range in.NetworkInterfaces {
+		selfLinks = append(selfLinks, networkInterface.Subnetwork, networkInterface.Network)
+	}
+
+	return linkSelfLinks(lookup, selfLinks...)
+}
@@ -0,0 +1,20 @@
+package gcp
+
+import (
+	"github.com/pluralsh/console/go/cloud-query/internal/extractor"
+)
+
+type ComputeNetwork struct {
+	extractor.UnlinkedResource
+
+	SelfLink string `json:"self_link"`
+	Name     string `json:"name"`
+}
+
+func (in ComputeNetwork) ID() string {
+	return in.SelfLink
+}
+
+func (in ComputeNetwork) ShortID() string {
+	return selfLinkToShortID(in.SelfLink)
+}
@@ -0,0 +1,19 @@
+package gcp
+
+type ComputeSubnetwork struct {
+	SelfLink string `json:"self_link"`
+	Name     string `json:"name"`
+	Network  string `json:"network"`
+}
+
+func (in ComputeSubnetwork) ID() string {
+	return in.SelfLink
+}
+
+func (in ComputeSubnetwork) ShortID() string {
+	return selfLinkToShortID(in.SelfLink)
+}
+
+func (in ComputeSubnetwork) Links(lookup map[string]string) []string {
+	return linkSelfLinks(lookup, in.Network)
+}
@@ -0,0 +1,22 @@
+package gcp
+
+type KubernetesCluster struct {
+	SelfLink      string `json:"self_link"`
+	Name          string `json:"name"`
+	NetworkConfig struct {
+		Network    string `json:"network"`
+		Subnetwork string `json:"subnetwork"`
+	} `json:"network_config"`
+}
+
+func (in KubernetesCluster) ID() string {
+	return in.SelfLink
+}
+
+func (in KubernetesCluster) ShortID() string {
+	return selfLinkToShortID(in.SelfLink)
+}
+
+func (in KubernetesCluster) Links(lookup map[string]string) []string {
+	return linkSelfLinks(lookup, in.NetworkConfig.Subnetwork, in.NetworkConfig.Network)
+}
@@ -0,0 +1,29 @@
+package gcp
+
+import (
+	"strings"
+)
+
+type KubernetesNodePool struct {
+	SelfLink string `json:"self_link"`
+	Name     string `json:"name"`
+}
+
+func (in KubernetesNodePool) ID() string {
+	return in.SelfLink
+}
+
+func (in KubernetesNodePool) ShortID() string {
+	return selfLinkToShortID(in.SelfLink)
+}
+
+func (in KubernetesNodePool) Links(lookup map[string]string) []string {
+	// Node pool self links are nested under the cluster self link, i.e.
+	// "https://container.googleapis.com/v1/projects/<project>/locations/<location>/clusters/<cluster>/nodePools/<name>"
+	cluster, _, ok := strings.Cut(in.SelfLink, "/nodePools/")
+	if !ok {
+		return []string{}
+	}
+
+	return linkSelfLinks(lookup, cluster)
+}
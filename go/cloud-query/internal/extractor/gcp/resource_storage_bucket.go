@@ -0,0 +1,20 @@
+package gcp
+
+import (
+	"github.com/pluralsh/console/go/cloud-query/internal/extractor"
+)
+
+type StorageBucket struct {
+	extractor.UnlinkedResource
+
+	SelfLink string `json:"self_link"`
+	Name     string `json:"name"`
+}
+
+func (in StorageBucket) ID() string {
+	return in.SelfLink
+}
+
+func (in StorageBucket) ShortID() string {
+	return in.Name
+}
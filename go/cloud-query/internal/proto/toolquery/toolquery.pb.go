@@ -851,6 +851,254 @@ func (x *AzureConnection) GetClientSecret() string {
 	return ""
 }
 
+type ClickhouseConnection struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// URL of the ClickHouse HTTP interface, e.g. https://clickhouse.example.com:8443
+	Url      string  `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Username *string `protobuf:"bytes,2,opt,name=username,proto3,oneof" json:"username,omitempty"`
+	Password *string `protobuf:"bytes,3,opt,name=password,proto3,oneof" json:"password,omitempty"`
+	Database *string `protobuf:"bytes,4,opt,name=database,proto3,oneof" json:"database,omitempty"`
+	// Table with logs in the OpenTelemetry exporter schema, defaults to otel_logs.
+	LogsTable *string `protobuf:"bytes,5,opt,name=logs_table,json=logsTable,proto3,oneof" json:"logs_table,omitempty"`
+	// Table with spans in the OpenTelemetry exporter schema, defaults to otel_traces.
+	TracesTable   *string `protobuf:"bytes,6,opt,name=traces_table,json=tracesTable,proto3,oneof" json:"traces_table,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClickhouseConnection) Reset() {
+	*x = ClickhouseConnection{}
+	mi := &file_toolquery_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClickhouseConnection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClickhouseConnection) ProtoMessage() {}
+
+func (x *ClickhouseConnection) ProtoReflect() protoreflect.Message {
+	mi := &file_toolquery_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClickhouseConnection.ProtoReflect.Descriptor instead.
+func (*ClickhouseConnection) Descriptor() ([]byte, []int) {
+	return file_toolquery_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ClickhouseConnection) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ClickhouseConnection) GetUsername() string {
+	if x != nil && x.Username != nil {
+		return *x.Username
+	}
+	return ""
+}
+
+func (x *ClickhouseConnection) GetPassword() string {
+	if x != nil && x.Password != nil {
+		return *x.Password
+	}
+	return ""
+}
+
+func (x *ClickhouseConnection) GetDatabase() string {
+	if x != nil && x.Database != nil {
+		return *x.Database
+	}
+	return ""
+}
+
+func (x *ClickhouseConnection) GetLogsTable() string {
+	if x != nil && x.LogsTable != nil {
+		return *x.LogsTable
+	}
+	return ""
+}
+
+func (x *ClickhouseConnection) GetTracesTable() string {
+	if x != nil && x.TracesTable != nil {
+		return *x.TracesTable
+	}
+	return ""
+}
+
+type VictoriaLogsConnection struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Token         *string                `protobuf:"bytes,2,opt,name=token,proto3,oneof" json:"token,omitempty"`
+	Username      *string                `protobuf:"bytes,3,opt,name=username,proto3,oneof" json:"username,omitempty"`
+	Password      *string                `protobuf:"bytes,4,opt,name=password,proto3,oneof" json:"password,omitempty"`
+	AccountId     *string                `protobuf:"bytes,5,opt,name=account_id,json=accountId,proto3,oneof" json:"account_id,omitempty"`
+	ProjectId     *string                `protobuf:"bytes,6,opt,name=project_id,json=projectId,proto3,oneof" json:"project_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VictoriaLogsConnection) Reset() {
+	*x = VictoriaLogsConnection{}
+	mi := &file_toolquery_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VictoriaLogsConnection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VictoriaLogsConnection) ProtoMessage() {}
+
+func (x *VictoriaLogsConnection) ProtoReflect() protoreflect.Message {
+	mi := &file_toolquery_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VictoriaLogsConnection.ProtoReflect.Descriptor instead.
+func (*VictoriaLogsConnection) Descriptor() ([]byte, []int) {
+	return file_toolquery_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *VictoriaLogsConnection) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *VictoriaLogsConnection) GetToken() string {
+	if x != nil && x.Token != nil {
+		return *x.Token
+	}
+	return ""
+}
+
+func (x *VictoriaLogsConnection) GetUsername() string {
+	if x != nil && x.Username != nil {
+		return *x.Username
+	}
+	return ""
+}
+
+func (x *VictoriaLogsConnection) GetPassword() string {
+	if x != nil && x.Password != nil {
+		return *x.Password
+	}
+	return ""
+}
+
+func (x *VictoriaLogsConnection) GetAccountId() string {
+	if x != nil && x.AccountId != nil {
+		return *x.AccountId
+	}
+	return ""
+}
+
+func (x *VictoriaLogsConnection) GetProjectId() string {
+	if x != nil && x.ProjectId != nil {
+		return *x.ProjectId
+	}
+	return ""
+}
+
+type MimirConnection struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Base URL of Mimir, the /prometheus API prefix is added when missing.
+	Url           string  `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Token         *string `protobuf:"bytes,2,opt,name=token,proto3,oneof" json:"token,omitempty"`
+	TenantId      *string `protobuf:"bytes,3,opt,name=tenant_id,json=tenantId,proto3,oneof" json:"tenant_id,omitempty"`
+	Username      *string `protobuf:"bytes,4,opt,name=username,proto3,oneof" json:"username,omitempty"`
+	Password      *string `protobuf:"bytes,5,opt,name=password,proto3,oneof" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MimirConnection) Reset() {
+	*x = MimirConnection{}
+	mi := &file_toolquery_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MimirConnection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MimirConnection) ProtoMessage() {}
+
+func (x *MimirConnection) ProtoReflect() protoreflect.Message {
+	mi := &file_toolquery_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MimirConnection.ProtoReflect.Descriptor instead.
+func (*MimirConnection) Descriptor() ([]byte, []int) {
+	return file_toolquery_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *MimirConnection) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *MimirConnection) GetToken() string {
+	if x != nil && x.Token != nil {
+		return *x.Token
+	}
+	return ""
+}
+
+func (x *MimirConnection) GetTenantId() string {
+	if x != nil && x.TenantId != nil {
+		return *x.TenantId
+	}
+	return ""
+}
+
+func (x *MimirConnection) GetUsername() string {
+	if x != nil && x.Username != nil {
+		return *x.Username
+	}
+	return ""
+}
+
+func (x *MimirConnection) GetPassword() string {
+	if x != nil && x.Password != nil {
+		return *x.Password
+	}
+	return ""
+}
+
 type ToolConnection struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Types that are valid to be assigned to Connection:
@@ -866,6 +1114,9 @@ type ToolConnection struct {
 	//	*ToolConnection_Azure
 	//	*ToolConnection_Jaeger
 	//	*ToolConnection_Opensearch
+	//	*ToolConnection_Clickhouse
+	//	*ToolConnection_VictoriaLogs
+	//	*ToolConnection_Mimir
 	Connection    isToolConnection_Connection `protobuf_oneof:"connection"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -873,7 +1124,7 @@ type ToolConnection struct {
 
 func (x *ToolConnection) Reset() {
 	*x = ToolConnection{}
-	mi := &file_toolquery_proto_msgTypes[11]
+	mi := &file_toolquery_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -885,7 +1136,7 @@ func (x *ToolConnection) String() string {
 func (*ToolConnection) ProtoMessage() {}
 
 func (x *ToolConnection) ProtoReflect() protoreflect.Message {
-	mi := &file_toolquery_proto_msgTypes[11]
+	mi := &file_toolquery_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -898,7 +1149,7 @@ func (x *ToolConnection) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ToolConnection.ProtoReflect.Descriptor instead.
 func (*ToolConnection) Descriptor() ([]byte, []int) {
-	return file_toolquery_proto_rawDescGZIP(), []int{11}
+	return file_toolquery_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ToolConnection) GetConnection() isToolConnection_Connection {
@@ -1007,6 +1258,33 @@ func (x *ToolConnection) GetOpensearch() *OpensearchConnection {
 	return nil
 }
 
+func (x *ToolConnection) GetClickhouse() *ClickhouseConnection {
+	if x != nil {
+		if x, ok := x.Connection.(*ToolConnection_Clickhouse); ok {
+			return x.Clickhouse
+		}
+	}
+	return nil
+}
+
+func (x *ToolConnection) GetVictoriaLogs() *VictoriaLogsConnection {
+	if x != nil {
+		if x, ok := x.Connection.(*ToolConnection_VictoriaLogs); ok {
+			return x.VictoriaLogs
+		}
+	}
+	return nil
+}
+
+func (x *ToolConnection) GetMimir() *MimirConnection {
+	if x != nil {
+		if x, ok := x.Connection.(*ToolConnection_Mimir); ok {
+			return x.Mimir
+		}
+	}
+	return nil
+}
+
 type isToolConnection_Connection interface {
 	isToolConnection_Connection()
 }
@@ -1055,6 +1333,18 @@ type ToolConnection_Opensearch struct {
 	Opensearch *OpensearchConnection `protobuf:"bytes,11,opt,name=opensearch,proto3,oneof"`
 }
 
+type ToolConnection_Clickhouse struct {
+	Clickhouse *ClickhouseConnection `protobuf:"bytes,12,opt,name=clickhouse,proto3,oneof"`
+}
+
+type ToolConnection_VictoriaLogs struct {
+	VictoriaLogs *VictoriaLogsConnection `protobuf:"bytes,13,opt,name=victoria_logs,json=victoriaLogs,proto3,oneof"`
+}
+
+type ToolConnection_Mimir struct {
+	Mimir *MimirConnection `protobuf:"bytes,14,opt,name=mimir,proto3,oneof"`
+}
+
 func (*ToolConnection_Elastic) isToolConnection_Connection() {}
 
 func (*ToolConnection_Datadog) isToolConnection_Connection() {}
@@ -1077,6 +1367,12 @@ func (*ToolConnection_Jaeger) isToolConnection_Connection() {}
 
 func (*ToolConnection_Opensearch) isToolConnection_Connection() {}
 
+func (*ToolConnection_Clickhouse) isToolConnection_Connection() {}
+
+func (*ToolConnection_VictoriaLogs) isToolConnection_Connection() {}
+
+func (*ToolConnection_Mimir) isToolConnection_Connection() {}
+
 type TimeRange struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Start         *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
@@ -1087,7 +1383,7 @@ type TimeRange struct {
 
 func (x *TimeRange) Reset() {
 	*x = TimeRange{}
-	mi := &file_toolquery_proto_msgTypes[12]
+	mi := &file_toolquery_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1099,7 +1395,7 @@ func (x *TimeRange) String() string {
 func (*TimeRange) ProtoMessage() {}
 
 func (x *TimeRange) ProtoReflect() protoreflect.Message {
-	mi := &file_toolquery_proto_msgTypes[12]
+	mi := &file_toolquery_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1112,7 +1408,7 @@ func (x *TimeRange) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TimeRange.ProtoReflect.Descriptor instead.
 func (*TimeRange) Descriptor() ([]byte, []int) {
-	return file_toolquery_proto_rawDescGZIP(), []int{12}
+	return file_toolquery_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *TimeRange) GetStart() *timestamppb.Timestamp {
@@ -1142,7 +1438,7 @@ type MetricsQueryInput struct {
 
 func (x *MetricsQueryInput) Reset() {
 	*x = MetricsQueryInput{}
-	mi := &file_toolquery_proto_msgTypes[13]
+	mi := &file_toolquery_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1154,7 +1450,7 @@ func (x *MetricsQueryInput) String() string {
 func (*MetricsQueryInput) ProtoMessage() {}
 
 func (x *MetricsQueryInput) ProtoReflect() protoreflect.Message {
-	mi := &file_toolquery_proto_msgTypes[13]
+	mi := &file_toolquery_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1167,7 +1463,7 @@ func (x *MetricsQueryInput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MetricsQueryInput.ProtoReflect.Descriptor instead.
 func (*MetricsQueryInput) Descriptor() ([]byte, []int) {
-	return file_toolquery_proto_rawDescGZIP(), []int{13}
+	return file_toolquery_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *MetricsQueryInput) GetConnection() *ToolConnection {
@@ -1214,7 +1510,7 @@ type MetricsOptions struct {
 
 func (x *MetricsOptions) Reset() {
 	*x = MetricsOptions{}
-	mi := &file_toolquery_proto_msgTypes[14]
+	mi := &file_toolquery_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1226,7 +1522,7 @@ func (x *MetricsOptions) String() string {
 func (*MetricsOptions) ProtoMessage() {}
 
 func (x *MetricsOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_toolquery_proto_msgTypes[14]
+	mi := &file_toolquery_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1239,7 +1535,7 @@ func (x *MetricsOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MetricsOptions.ProtoReflect.Descriptor instead.
 func (*MetricsOptions) Descriptor() ([]byte, []int) {
-	return file_toolquery_proto_rawDescGZIP(), []int{14}
+	return file_toolquery_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *MetricsOptions) GetAzure() *AzureMetricsOptions {
@@ -1265,7 +1561,7 @@ type AzureMetricsOptions struct {
 
 func (x *AzureMetricsOptions) Reset() {
 	*x = AzureMetricsOptions{}
-	mi := &file_toolquery_proto_msgTypes[15]
+	mi := &file_toolquery_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1277,7 +1573,7 @@ func (x *AzureMetricsOptions) String() string {
 func (*AzureMetricsOptions) ProtoMessage() {}
 
 func (x *AzureMetricsOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_toolquery_proto_msgTypes[15]
+	mi := &file_toolquery_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1290,7 +1586,7 @@ func (x *AzureMetricsOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AzureMetricsOptions.ProtoReflect.Descriptor instead.
 func (*AzureMetricsOptions) Descriptor() ([]byte, []int) {
-	return file_toolquery_proto_rawDescGZIP(), []int{15}
+	return file_toolquery_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *AzureMetricsOptions) GetResourceId() string {
@@ -1359,7 +1655,7 @@ type LogsQueryFacet struct {
 
 func (x *LogsQueryFacet) Reset() {
 	*x = LogsQueryFacet{}
-	mi := &file_toolquery_proto_msgTypes[16]
+	mi := &file_toolquery_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1371,7 +1667,7 @@ func (x *LogsQueryFacet) String() string {
 func (*LogsQueryFacet) ProtoMessage() {}
 
 func (x *LogsQueryFacet) ProtoReflect() protoreflect.Message {
-	mi := &file_toolquery_proto_msgTypes[16]
+	mi := &file_toolquery_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1384,7 +1680,7 @@ func (x *LogsQueryFacet) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogsQueryFacet.ProtoReflect.Descriptor instead.
 func (*LogsQueryFacet) Descriptor() ([]byte, []int) {
-	return file_toolquery_proto_rawDescGZIP(), []int{16}
+	return file_toolquery_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *LogsQueryFacet) GetName() string {
@@ -1402,20 +1698,23 @@ func (x *LogsQueryFacet) GetValue() string {
 }
 
 type LogsQueryInput struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Connection    *ToolConnection        `protobuf:"bytes,1,opt,name=connection,proto3" json:"connection,omitempty"`
-	Query         string                 `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
-	Range         *TimeRange             `protobuf:"bytes,3,opt,name=range,proto3" json:"range,omitempty"`
-	Limit         *int32                 `protobuf:"varint,4,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
-	Facets        []*LogsQueryFacet      `protobuf:"bytes,5,rep,name=facets,proto3" json:"facets,omitempty"`
-	Options       *LogsOptions           `protobuf:"bytes,6,opt,name=options,proto3,oneof" json:"options,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Connection *ToolConnection        `protobuf:"bytes,1,opt,name=connection,proto3" json:"connection,omitempty"`
+	Query      string                 `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	Range      *TimeRange             `protobuf:"bytes,3,opt,name=range,proto3" json:"range,omitempty"`
+	Limit      *int32                 `protobuf:"varint,4,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	Facets     []*LogsQueryFacet      `protobuf:"bytes,5,rep,name=facets,proto3" json:"facets,omitempty"`
+	Options    *LogsOptions           `protobuf:"bytes,6,opt,name=options,proto3,oneof" json:"options,omitempty"`
+	// Opaque token returned as next_page_token by the previous call. When set, the limit
+	// is used as a page size and the query must not change between pages.
+	PageToken     *string `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3,oneof" json:"page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *LogsQueryInput) Reset() {
 	*x = LogsQueryInput{}
-	mi := &file_toolquery_proto_msgTypes[17]
+	mi := &file_toolquery_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1427,7 +1726,7 @@ func (x *LogsQueryInput) String() string {
 func (*LogsQueryInput) ProtoMessage() {}
 
 func (x *LogsQueryInput) ProtoReflect() protoreflect.Message {
-	mi := &file_toolquery_proto_msgTypes[17]
+	mi := &file_toolquery_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1440,7 +1739,7 @@ func (x *LogsQueryInput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogsQueryInput.ProtoReflect.Descriptor instead.
 func (*LogsQueryInput) Descriptor() ([]byte, []int) {
-	return file_toolquery_proto_rawDescGZIP(), []int{17}
+	return file_toolquery_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *LogsQueryInput) GetConnection() *ToolConnection {
@@ -1485,6 +1784,13 @@ func (x *LogsQueryInput) GetOptions() *LogsOptions {
 	return nil
 }
 
+func (x *LogsQueryInput) GetPageToken() string {
+	if x != nil && x.PageToken != nil {
+		return *x.PageToken
+	}
+	return ""
+}
+
 type LogsOptions struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Azure         *AzureLogsOptions      `protobuf:"bytes,1,opt,name=azure,proto3,oneof" json:"azure,omitempty"`
@@ -1494,7 +1800,7 @@ type LogsOptions struct {
 
 func (x *LogsOptions) Reset() {
 	*x = LogsOptions{}
-	mi := &file_toolquery_proto_msgTypes[18]
+	mi := &file_toolquery_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1506,7 +1812,7 @@ func (x *LogsOptions) String() string {
 func (*LogsOptions) ProtoMessage() {}
 
 func (x *LogsOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_toolquery_proto_msgTypes[18]
+	mi := &file_toolquery_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1519,7 +1825,7 @@ func (x *LogsOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogsOptions.ProtoReflect.Descriptor instead.
 func (*LogsOptions) Descriptor() ([]byte, []int) {
-	return file_toolquery_proto_rawDescGZIP(), []int{18}
+	return file_toolquery_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *LogsOptions) GetAzure() *AzureLogsOptions {
@@ -1538,7 +1844,7 @@ type AzureLogsOptions struct {
 
 func (x *AzureLogsOptions) Reset() {
 	*x = AzureLogsOptions{}
-	mi := &file_toolquery_proto_msgTypes[19]
+	mi := &file_toolquery_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1550,7 +1856,7 @@ func (x *AzureLogsOptions) String() string {
 func (*AzureLogsOptions) ProtoMessage() {}
 
 func (x *AzureLogsOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_toolquery_proto_msgTypes[19]
+	mi := &file_toolquery_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1563,7 +1869,7 @@ func (x *AzureLogsOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AzureLogsOptions.ProtoReflect.Descriptor instead.
 func (*AzureLogsOptions) Descriptor() ([]byte, []int) {
-	return file_toolquery_proto_rawDescGZIP(), []int{19}
+	return file_toolquery_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *AzureLogsOptions) GetResourceId() string {
@@ -1574,19 +1880,22 @@ func (x *AzureLogsOptions) GetResourceId() string {
 }
 
 type TracesQueryInput struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Connection    *ToolConnection        `protobuf:"bytes,1,opt,name=connection,proto3" json:"connection,omitempty"`
-	Query         string                 `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
-	Range         *TimeRange             `protobuf:"bytes,3,opt,name=range,proto3" json:"range,omitempty"`
-	Limit         *int32                 `protobuf:"varint,4,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
-	Options       *TracesOptions         `protobuf:"bytes,5,opt,name=options,proto3,oneof" json:"options,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Connection *ToolConnection        `protobuf:"bytes,1,opt,name=connection,proto3" json:"connection,omitempty"`
+	Query      string                 `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	Range      *TimeRange             `protobuf:"bytes,3,opt,name=range,proto3" json:"range,omitempty"`
+	Limit      *int32                 `protobuf:"varint,4,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	Options    *TracesOptions         `protobuf:"bytes,5,opt,name=options,proto3,oneof" json:"options,omitempty"`
+	// Opaque token returned as next_page_token by the previous call. When set, the limit
+	// is used as a page size and the query must not change between pages.
+	PageToken     *string `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3,oneof" json:"page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TracesQueryInput) Reset() {
 	*x = TracesQueryInput{}
-	mi := &file_toolquery_proto_msgTypes[20]
+	mi := &file_toolquery_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1598,7 +1907,7 @@ func (x *TracesQueryInput) String() string {
 func (*TracesQueryInput) ProtoMessage() {}
 
 func (x *TracesQueryInput) ProtoReflect() protoreflect.Message {
-	mi := &file_toolquery_proto_msgTypes[20]
+	mi := &file_toolquery_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1611,7 +1920,7 @@ func (x *TracesQueryInput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TracesQueryInput.ProtoReflect.Descriptor instead.
 func (*TracesQueryInput) Descriptor() ([]byte, []int) {
-	return file_toolquery_proto_rawDescGZIP(), []int{20}
+	return file_toolquery_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *TracesQueryInput) GetConnection() *ToolConnection {
@@ -1649,6 +1958,13 @@ func (x *TracesQueryInput) GetOptions() *TracesOptions {
 	return nil
 }
 
+func (x *TracesQueryInput) GetPageToken() string {
+	if x != nil && x.PageToken != nil {
+		return *x.PageToken
+	}
+	return ""
+}
+
 type TracesOptions struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Jaeger        *JaegerTracesOptions   `protobuf:"bytes,1,opt,name=jaeger,proto3,oneof" json:"jaeger,omitempty"`
@@ -1658,7 +1974,7 @@ type TracesOptions struct {
 
 func (x *TracesOptions) Reset() {
 	*x = TracesOptions{}
-	mi := &file_toolquery_proto_msgTypes[21]
+	mi := &file_toolquery_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1670,7 +1986,7 @@ func (x *TracesOptions) String() string {
 func (*TracesOptions) ProtoMessage() {}
 
 func (x *TracesOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_toolquery_proto_msgTypes[21]
+	mi := &file_toolquery_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1683,7 +1999,7 @@ func (x *TracesOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TracesOptions.ProtoReflect.Descriptor instead.
 func (*TracesOptions) Descriptor() ([]byte, []int) {
-	return file_toolquery_proto_rawDescGZIP(), []int{21}
+	return file_toolquery_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *TracesOptions) GetJaeger() *JaegerTracesOptions {
@@ -1703,7 +2019,7 @@ type JaegerTraceQueryAttribute struct {
 
 func (x *JaegerTraceQueryAttribute) Reset() {
 	*x = JaegerTraceQueryAttribute{}
-	mi := &file_toolquery_proto_msgTypes[22]
+	mi := &file_toolquery_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1715,7 +2031,7 @@ func (x *JaegerTraceQueryAttribute) String() string {
 func (*JaegerTraceQueryAttribute) ProtoMessage() {}
 
 func (x *JaegerTraceQueryAttribute) ProtoReflect() protoreflect.Message {
-	mi := &file_toolquery_proto_msgTypes[22]
+	mi := &file_toolquery_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1728,7 +2044,7 @@ func (x *JaegerTraceQueryAttribute) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JaegerTraceQueryAttribute.ProtoReflect.Descriptor instead.
 func (*JaegerTraceQueryAttribute) Descriptor() ([]byte, []int) {
-	return file_toolquery_proto_rawDescGZIP(), []int{22}
+	return file_toolquery_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *JaegerTraceQueryAttribute) GetName() string {
@@ -1757,7 +2073,7 @@ type JaegerTracesOptions struct {
 
 func (x *JaegerTracesOptions) Reset() {
 	*x = JaegerTracesOptions{}
-	mi := &file_toolquery_proto_msgTypes[23]
+	mi := &file_toolquery_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1769,7 +2085,7 @@ func (x *JaegerTracesOptions) String() string {
 func (*JaegerTracesOptions) ProtoMessage() {}
 
 func (x *JaegerTracesOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_toolquery_proto_msgTypes[23]
+	mi := &file_toolquery_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1782,7 +2098,7 @@ func (x *JaegerTracesOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JaegerTracesOptions.ProtoReflect.Descriptor instead.
 func (*JaegerTracesOptions) Descriptor() ([]byte, []int) {
-	return file_toolquery_proto_rawDescGZIP(), []int{23}
+	return file_toolquery_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *JaegerTracesOptions) GetOperationName() string {
@@ -1825,7 +2141,7 @@ type MetricPoint struct {
 
 func (x *MetricPoint) Reset() {
 	*x = MetricPoint{}
-	mi := &file_toolquery_proto_msgTypes[24]
+	mi := &file_toolquery_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1837,7 +2153,7 @@ func (x *MetricPoint) String() string {
 func (*MetricPoint) ProtoMessage() {}
 
 func (x *MetricPoint) ProtoReflect() protoreflect.Message {
-	mi := &file_toolquery_proto_msgTypes[24]
+	mi := &file_toolquery_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1850,7 +2166,7 @@ func (x *MetricPoint) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MetricPoint.ProtoReflect.Descriptor instead.
 func (*MetricPoint) Descriptor() ([]byte, []int) {
-	return file_toolquery_proto_rawDescGZIP(), []int{24}
+	return file_toolquery_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *MetricPoint) GetTimestamp() *timestamppb.Timestamp {
@@ -1890,7 +2206,7 @@ type MetricsQueryOutput struct {
 
 func (x *MetricsQueryOutput) Reset() {
 	*x = MetricsQueryOutput{}
-	mi := &file_toolquery_proto_msgTypes[25]
+	mi := &file_toolquery_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1902,7 +2218,7 @@ func (x *MetricsQueryOutput) String() string {
 func (*MetricsQueryOutput) ProtoMessage() {}
 
 func (x *MetricsQueryOutput) ProtoReflect() protoreflect.Message {
-	mi := &file_toolquery_proto_msgTypes[25]
+	mi := &file_toolquery_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1915,7 +2231,7 @@ func (x *MetricsQueryOutput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MetricsQueryOutput.ProtoReflect.Descriptor instead.
 func (*MetricsQueryOutput) Descriptor() ([]byte, []int) {
-	return file_toolquery_proto_rawDescGZIP(), []int{25}
+	return file_toolquery_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *MetricsQueryOutput) GetMetrics() []*MetricPoint {
@@ -1942,7 +2258,7 @@ type MetricsSearchInput struct {
 
 func (x *MetricsSearchInput) Reset() {
 	*x = MetricsSearchInput{}
-	mi := &file_toolquery_proto_msgTypes[26]
+	mi := &file_toolquery_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1954,7 +2270,7 @@ func (x *MetricsSearchInput) String() string {
 func (*MetricsSearchInput) ProtoMessage() {}
 
 func (x *MetricsSearchInput) ProtoReflect() protoreflect.Message {
-	mi := &file_toolquery_proto_msgTypes[26]
+	mi := &file_toolquery_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1967,7 +2283,7 @@ func (x *MetricsSearchInput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MetricsSearchInput.ProtoReflect.Descriptor instead.
 func (*MetricsSearchInput) Descriptor() ([]byte, []int) {
-	return file_toolquery_proto_rawDescGZIP(), []int{26}
+	return file_toolquery_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *MetricsSearchInput) GetConnection() *ToolConnection {
@@ -2007,7 +2323,7 @@ type MetricsSearchOptions struct {
 
 func (x *MetricsSearchOptions) Reset() {
 	*x = MetricsSearchOptions{}
-	mi := &file_toolquery_proto_msgTypes[27]
+	mi := &file_toolquery_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2019,7 +2335,7 @@ func (x *MetricsSearchOptions) String() string {
 func (*MetricsSearchOptions) ProtoMessage() {}
 
 func (x *MetricsSearchOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_toolquery_proto_msgTypes[27]
+	mi := &file_toolquery_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2032,7 +2348,7 @@ func (x *MetricsSearchOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MetricsSearchOptions.ProtoReflect.Descriptor instead.
 func (*MetricsSearchOptions) Descriptor() ([]byte, []int) {
-	return file_toolquery_proto_rawDescGZIP(), []int{27}
+	return file_toolquery_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *MetricsSearchOptions) GetAzure() *AzureMetricsSearchOptions {
@@ -2052,7 +2368,7 @@ type AzureMetricsSearchOptions struct {
 
 func (x *AzureMetricsSearchOptions) Reset() {
 	*x = AzureMetricsSearchOptions{}
-	mi := &file_toolquery_proto_msgTypes[28]
+	mi := &file_toolquery_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2064,7 +2380,7 @@ func (x *AzureMetricsSearchOptions) String() string {
 func (*AzureMetricsSearchOptions) ProtoMessage() {}
 
 func (x *AzureMetricsSearchOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_toolquery_proto_msgTypes[28]
+	mi := &file_toolquery_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2077,7 +2393,7 @@ func (x *AzureMetricsSearchOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AzureMetricsSearchOptions.ProtoReflect.Descriptor instead.
 func (*AzureMetricsSearchOptions) Descriptor() ([]byte, []int) {
-	return file_toolquery_proto_rawDescGZIP(), []int{28}
+	return file_toolquery_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *AzureMetricsSearchOptions) GetResourceId() string {
@@ -2104,7 +2420,7 @@ type MetricsSearchResult struct {
 
 func (x *MetricsSearchResult) Reset() {
 	*x = MetricsSearchResult{}
-	mi := &file_toolquery_proto_msgTypes[29]
+	mi := &file_toolquery_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2116,7 +2432,7 @@ func (x *MetricsSearchResult) String() string {
 func (*MetricsSearchResult) ProtoMessage() {}
 
 func (x *MetricsSearchResult) ProtoReflect() protoreflect.Message {
-	mi := &file_toolquery_proto_msgTypes[29]
+	mi := &file_toolquery_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2129,7 +2445,7 @@ func (x *MetricsSearchResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MetricsSearchResult.ProtoReflect.Descriptor instead.
 func (*MetricsSearchResult) Descriptor() ([]byte, []int) {
-	return file_toolquery_proto_rawDescGZIP(), []int{29}
+	return file_toolquery_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *MetricsSearchResult) GetName() string {
@@ -2148,7 +2464,7 @@ type MetricsSearchOutput struct {
 
 func (x *MetricsSearchOutput) Reset() {
 	*x = MetricsSearchOutput{}
-	mi := &file_toolquery_proto_msgTypes[30]
+	mi := &file_toolquery_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2160,7 +2476,7 @@ func (x *MetricsSearchOutput) String() string {
 func (*MetricsSearchOutput) ProtoMessage() {}
 
 func (x *MetricsSearchOutput) ProtoReflect() protoreflect.Message {
-	mi := &file_toolquery_proto_msgTypes[30]
+	mi := &file_toolquery_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2173,7 +2489,7 @@ func (x *MetricsSearchOutput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MetricsSearchOutput.ProtoReflect.Descriptor instead.
 func (*MetricsSearchOutput) Descriptor() ([]byte, []int) {
-	return file_toolquery_proto_rawDescGZIP(), []int{30}
+	return file_toolquery_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *MetricsSearchOutput) GetMetrics() []*MetricsSearchResult {
@@ -2197,7 +2513,7 @@ type MetricsLabelSearchInput struct {
 
 func (x *MetricsLabelSearchInput) Reset() {
 	*x = MetricsLabelSearchInput{}
-	mi := &file_toolquery_proto_msgTypes[31]
+	mi := &file_toolquery_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2209,7 +2525,7 @@ func (x *MetricsLabelSearchInput) String() string {
 func (*MetricsLabelSearchInput) ProtoMessage() {}
 
 func (x *MetricsLabelSearchInput) ProtoReflect() protoreflect.Message {
-	mi := &file_toolquery_proto_msgTypes[31]
+	mi := &file_toolquery_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2222,7 +2538,7 @@ func (x *MetricsLabelSearchInput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MetricsLabelSearchInput.ProtoReflect.Descriptor instead.
 func (*MetricsLabelSearchInput) Descriptor() ([]byte, []int) {
-	return file_toolquery_proto_rawDescGZIP(), []int{31}
+	return file_toolquery_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *MetricsLabelSearchInput) GetConnection() *ToolConnection {
@@ -2276,7 +2592,7 @@ type MetricsLabelSearchOptions struct {
 
 func (x *MetricsLabelSearchOptions) Reset() {
 	*x = MetricsLabelSearchOptions{}
-	mi := &file_toolquery_proto_msgTypes[32]
+	mi := &file_toolquery_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2288,7 +2604,7 @@ func (x *MetricsLabelSearchOptions) String() string {
 func (*MetricsLabelSearchOptions) ProtoMessage() {}
 
 func (x *MetricsLabelSearchOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_toolquery_proto_msgTypes[32]
+	mi := &file_toolquery_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2301,7 +2617,7 @@ func (x *MetricsLabelSearchOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MetricsLabelSearchOptions.ProtoReflect.Descriptor instead.
 func (*MetricsLabelSearchOptions) Descriptor() ([]byte, []int) {
-	return file_toolquery_proto_rawDescGZIP(), []int{32}
+	return file_toolquery_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *MetricsLabelSearchOptions) GetAzure() *AzureMetricsLabelSearchOptions {
@@ -2323,7 +2639,7 @@ type AzureMetricsLabelSearchOptions struct {
 
 func (x *AzureMetricsLabelSearchOptions) Reset() {
 	*x = AzureMetricsLabelSearchOptions{}
-	mi := &file_toolquery_proto_msgTypes[33]
+	mi := &file_toolquery_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2335,7 +2651,7 @@ func (x *AzureMetricsLabelSearchOptions) String() string {
 func (*AzureMetricsLabelSearchOptions) ProtoMessage() {}
 
 func (x *AzureMetricsLabelSearchOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_toolquery_proto_msgTypes[33]
+	mi := &file_toolquery_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2348,7 +2664,7 @@ func (x *AzureMetricsLabelSearchOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AzureMetricsLabelSearchOptions.ProtoReflect.Descriptor instead.
 func (*AzureMetricsLabelSearchOptions) Descriptor() ([]byte, []int) {
-	return file_toolquery_proto_rawDescGZIP(), []int{33}
+	return file_toolquery_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *AzureMetricsLabelSearchOptions) GetResourceId() string {
@@ -2389,7 +2705,7 @@ type MetricsLabelSearchResult struct {
 
 func (x *MetricsLabelSearchResult) Reset() {
 	*x = MetricsLabelSearchResult{}
-	mi := &file_toolquery_proto_msgTypes[34]
+	mi := &file_toolquery_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2401,7 +2717,7 @@ func (x *MetricsLabelSearchResult) String() string {
 func (*MetricsLabelSearchResult) ProtoMessage() {}
 
 func (x *MetricsLabelSearchResult) ProtoReflect() protoreflect.Message {
-	mi := &file_toolquery_proto_msgTypes[34]
+	mi := &file_toolquery_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2414,7 +2730,7 @@ func (x *MetricsLabelSearchResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MetricsLabelSearchResult.ProtoReflect.Descriptor instead.
 func (*MetricsLabelSearchResult) Descriptor() ([]byte, []int) {
-	return file_toolquery_proto_rawDescGZIP(), []int{34}
+	return file_toolquery_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *MetricsLabelSearchResult) GetName() string {
@@ -2433,7 +2749,7 @@ type MetricsLabelSearchOutput struct {
 
 func (x *MetricsLabelSearchOutput) Reset() {
 	*x = MetricsLabelSearchOutput{}
-	mi := &file_toolquery_proto_msgTypes[35]
+	mi := &file_toolquery_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2445,7 +2761,7 @@ func (x *MetricsLabelSearchOutput) String() string {
 func (*MetricsLabelSearchOutput) ProtoMessage() {}
 
 func (x *MetricsLabelSearchOutput) ProtoReflect() protoreflect.Message {
-	mi := &file_toolquery_proto_msgTypes[35]
+	mi := &file_toolquery_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2458,7 +2774,7 @@ func (x *MetricsLabelSearchOutput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MetricsLabelSearchOutput.ProtoReflect.Descriptor instead.
 func (*MetricsLabelSearchOutput) Descriptor() ([]byte, []int) {
-	return file_toolquery_proto_rawDescGZIP(), []int{35}
+	return file_toolquery_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *MetricsLabelSearchOutput) GetResults() []*MetricsLabelSearchResult {
@@ -2479,7 +2795,7 @@ type LogEntry struct {
 
 func (x *LogEntry) Reset() {
 	*x = LogEntry{}
-	mi := &file_toolquery_proto_msgTypes[36]
+	mi := &file_toolquery_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2491,7 +2807,7 @@ func (x *LogEntry) String() string {
 func (*LogEntry) ProtoMessage() {}
 
 func (x *LogEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_toolquery_proto_msgTypes[36]
+	mi := &file_toolquery_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2504,7 +2820,7 @@ func (x *LogEntry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogEntry.ProtoReflect.Descriptor instead.
 func (*LogEntry) Descriptor() ([]byte, []int) {
-	return file_toolquery_proto_rawDescGZIP(), []int{36}
+	return file_toolquery_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *LogEntry) GetTimestamp() *timestamppb.Timestamp {
@@ -2529,15 +2845,18 @@ func (x *LogEntry) GetLabels() map[string]string {
 }
 
 type LogsQueryOutput struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Logs          []*LogEntry            `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Logs  []*LogEntry            `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
+	// Token used to fetch the next page. Empty if there are no more entries
+	// or the provider does not support pagination.
+	NextPageToken *string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3,oneof" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *LogsQueryOutput) Reset() {
 	*x = LogsQueryOutput{}
-	mi := &file_toolquery_proto_msgTypes[37]
+	mi := &file_toolquery_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2549,7 +2868,7 @@ func (x *LogsQueryOutput) String() string {
 func (*LogsQueryOutput) ProtoMessage() {}
 
 func (x *LogsQueryOutput) ProtoReflect() protoreflect.Message {
-	mi := &file_toolquery_proto_msgTypes[37]
+	mi := &file_toolquery_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2562,7 +2881,7 @@ func (x *LogsQueryOutput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogsQueryOutput.ProtoReflect.Descriptor instead.
 func (*LogsQueryOutput) Descriptor() ([]byte, []int) {
-	return file_toolquery_proto_rawDescGZIP(), []int{37}
+	return file_toolquery_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *LogsQueryOutput) GetLogs() []*LogEntry {
@@ -2572,6 +2891,13 @@ func (x *LogsQueryOutput) GetLogs() []*LogEntry {
 	return nil
 }
 
+func (x *LogsQueryOutput) GetNextPageToken() string {
+	if x != nil && x.NextPageToken != nil {
+		return *x.NextPageToken
+	}
+	return ""
+}
+
 type TraceSpan struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TraceId       string                 `protobuf:"bytes,1,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
@@ -2588,7 +2914,7 @@ type TraceSpan struct {
 
 func (x *TraceSpan) Reset() {
 	*x = TraceSpan{}
-	mi := &file_toolquery_proto_msgTypes[38]
+	mi := &file_toolquery_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2600,7 +2926,7 @@ func (x *TraceSpan) String() string {
 func (*TraceSpan) ProtoMessage() {}
 
 func (x *TraceSpan) ProtoReflect() protoreflect.Message {
-	mi := &file_toolquery_proto_msgTypes[38]
+	mi := &file_toolquery_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2613,7 +2939,7 @@ func (x *TraceSpan) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TraceSpan.ProtoReflect.Descriptor instead.
 func (*TraceSpan) Descriptor() ([]byte, []int) {
-	return file_toolquery_proto_rawDescGZIP(), []int{38}
+	return file_toolquery_proto_rawDescGZIP(), []int{41}
 }
 
 func (x *TraceSpan) GetTraceId() string {
@@ -2673,15 +2999,18 @@ func (x *TraceSpan) GetTags() map[string]string {
 }
 
 type TracesQueryOutput struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Spans         []*TraceSpan           `protobuf:"bytes,1,rep,name=spans,proto3" json:"spans,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Spans []*TraceSpan           `protobuf:"bytes,1,rep,name=spans,proto3" json:"spans,omitempty"`
+	// Token used to fetch the next page. Empty if there are no more spans
+	// or the provider does not support pagination.
+	NextPageToken *string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3,oneof" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TracesQueryOutput) Reset() {
 	*x = TracesQueryOutput{}
-	mi := &file_toolquery_proto_msgTypes[39]
+	mi := &file_toolquery_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2693,7 +3022,7 @@ func (x *TracesQueryOutput) String() string {
 func (*TracesQueryOutput) ProtoMessage() {}
 
 func (x *TracesQueryOutput) ProtoReflect() protoreflect.Message {
-	mi := &file_toolquery_proto_msgTypes[39]
+	mi := &file_toolquery_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2706,7 +3035,7 @@ func (x *TracesQueryOutput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TracesQueryOutput.ProtoReflect.Descriptor instead.
 func (*TracesQueryOutput) Descriptor() ([]byte, []int) {
-	return file_toolquery_proto_rawDescGZIP(), []int{39}
+	return file_toolquery_proto_rawDescGZIP(), []int{42}
 }
 
 func (x *TracesQueryOutput) GetSpans() []*TraceSpan {
@@ -2716,6 +3045,13 @@ func (x *TracesQueryOutput) GetSpans() []*TraceSpan {
 	return nil
 }
 
+func (x *TracesQueryOutput) GetNextPageToken() string {
+	if x != nil && x.NextPageToken != nil {
+		return *x.NextPageToken
+	}
+	return ""
+}
+
 type InvokeLambdaInput struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Connection    *cloudquery.Connection `protobuf:"bytes,1,opt,name=connection,proto3" json:"connection,omitempty"`
@@ -2727,7 +3063,7 @@ type InvokeLambdaInput struct {
 
 func (x *InvokeLambdaInput) Reset() {
 	*x = InvokeLambdaInput{}
-	mi := &file_toolquery_proto_msgTypes[40]
+	mi := &file_toolquery_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2739,7 +3075,7 @@ func (x *InvokeLambdaInput) String() string {
 func (*InvokeLambdaInput) ProtoMessage() {}
 
 func (x *InvokeLambdaInput) ProtoReflect() protoreflect.Message {
-	mi := &file_toolquery_proto_msgTypes[40]
+	mi := &file_toolquery_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2752,7 +3088,7 @@ func (x *InvokeLambdaInput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InvokeLambdaInput.ProtoReflect.Descriptor instead.
 func (*InvokeLambdaInput) Descriptor() ([]byte, []int) {
-	return file_toolquery_proto_rawDescGZIP(), []int{40}
+	return file_toolquery_proto_rawDescGZIP(), []int{43}
 }
 
 func (x *InvokeLambdaInput) GetConnection() *cloudquery.Connection {
@@ -2786,7 +3122,7 @@ type InvokeLambdaOutput struct {
 
 func (x *InvokeLambdaOutput) Reset() {
 	*x = InvokeLambdaOutput{}
-	mi := &file_toolquery_proto_msgTypes[41]
+	mi := &file_toolquery_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2798,7 +3134,7 @@ func (x *InvokeLambdaOutput) String() string {
 func (*InvokeLambdaOutput) ProtoMessage() {}
 
 func (x *InvokeLambdaOutput) ProtoReflect() protoreflect.Message {
-	mi := &file_toolquery_proto_msgTypes[41]
+	mi := &file_toolquery_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2811,7 +3147,7 @@ func (x *InvokeLambdaOutput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InvokeLambdaOutput.ProtoReflect.Descriptor instead.
 func (*InvokeLambdaOutput) Descriptor() ([]byte, []int) {
-	return file_toolquery_proto_rawDescGZIP(), []int{41}
+	return file_toolquery_proto_rawDescGZIP(), []int{44}
 }
 
 func (x *InvokeLambdaOutput) GetResult() string {
@@ -2838,7 +3174,7 @@ type RunLuaInput struct {
 
 func (x *RunLuaInput) Reset() {
 	*x = RunLuaInput{}
-	mi := &file_toolquery_proto_msgTypes[42]
+	mi := &file_toolquery_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2850,7 +3186,7 @@ func (x *RunLuaInput) String() string {
 func (*RunLuaInput) ProtoMessage() {}
 
 func (x *RunLuaInput) ProtoReflect() protoreflect.Message {
-	mi := &file_toolquery_proto_msgTypes[42]
+	mi := &file_toolquery_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2863,7 +3199,7 @@ func (x *RunLuaInput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RunLuaInput.ProtoReflect.Descriptor instead.
 func (*RunLuaInput) Descriptor() ([]byte, []int) {
-	return file_toolquery_proto_rawDescGZIP(), []int{42}
+	return file_toolquery_proto_rawDescGZIP(), []int{45}
 }
 
 func (x *RunLuaInput) GetScript() string {
@@ -2883,7 +3219,7 @@ type RunLuaOutput struct {
 
 func (x *RunLuaOutput) Reset() {
 	*x = RunLuaOutput{}
-	mi := &file_toolquery_proto_msgTypes[43]
+	mi := &file_toolquery_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2895,7 +3231,7 @@ func (x *RunLuaOutput) String() string {
 func (*RunLuaOutput) ProtoMessage() {}
 
 func (x *RunLuaOutput) ProtoReflect() protoreflect.Message {
-	mi := &file_toolquery_proto_msgTypes[43]
+	mi := &file_toolquery_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2908,7 +3244,7 @@ func (x *RunLuaOutput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RunLuaOutput.ProtoReflect.Descriptor instead.
 func (*RunLuaOutput) Descriptor() ([]byte, []int) {
-	return file_toolquery_proto_rawDescGZIP(), []int{43}
+	return file_toolquery_proto_rawDescGZIP(), []int{46}
 }
 
 func (x *RunLuaOutput) GetResultJson() string {
@@ -3028,7 +3364,45 @@ const file_toolquery_proto_rawDesc = "" +
 	"\x0fsubscription_id\x18\x01 \x01(\tR\x0esubscriptionId\x12\x1b\n" +
 	"\ttenant_id\x18\x02 \x01(\tR\btenantId\x12\x1b\n" +
 	"\tclient_id\x18\x03 \x01(\tR\bclientId\x12#\n" +
-	"\rclient_secret\x18\x04 \x01(\tR\fclientSecret\"\xa2\x05\n" +
+	"\rclient_secret\x18\x04 \x01(\tR\fclientSecret\"\x9e\x02\n" +
+	"\x14ClickhouseConnection\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x1f\n" +
+	"\busername\x18\x02 \x01(\tH\x00R\busername\x88\x01\x01\x12\x1f\n" +
+	"\bpassword\x18\x03 \x01(\tH\x01R\bpassword\x88\x01\x01\x12\x1f\n" +
+	"\bdatabase\x18\x04 \x01(\tH\x02R\bdatabase\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"logs_table\x18\x05 \x01(\tH\x03R\tlogsTable\x88\x01\x01\x12&\n" +
+	"\ftraces_table\x18\x06 \x01(\tH\x04R\vtracesTable\x88\x01\x01B\v\n" +
+	"\t_usernameB\v\n" +
+	"\t_passwordB\v\n" +
+	"\t_databaseB\r\n" +
+	"\v_logs_tableB\x0f\n" +
+	"\r_traces_table\"\x91\x02\n" +
+	"\x16VictoriaLogsConnection\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x19\n" +
+	"\x05token\x18\x02 \x01(\tH\x00R\x05token\x88\x01\x01\x12\x1f\n" +
+	"\busername\x18\x03 \x01(\tH\x01R\busername\x88\x01\x01\x12\x1f\n" +
+	"\bpassword\x18\x04 \x01(\tH\x02R\bpassword\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"account_id\x18\x05 \x01(\tH\x03R\taccountId\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"project_id\x18\x06 \x01(\tH\x04R\tprojectId\x88\x01\x01B\b\n" +
+	"\x06_tokenB\v\n" +
+	"\t_usernameB\v\n" +
+	"\t_passwordB\r\n" +
+	"\v_account_idB\r\n" +
+	"\v_project_id\"\xd4\x01\n" +
+	"\x0fMimirConnection\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x19\n" +
+	"\x05token\x18\x02 \x01(\tH\x00R\x05token\x88\x01\x01\x12 \n" +
+	"\ttenant_id\x18\x03 \x01(\tH\x01R\btenantId\x88\x01\x01\x12\x1f\n" +
+	"\busername\x18\x04 \x01(\tH\x02R\busername\x88\x01\x01\x12\x1f\n" +
+	"\bpassword\x18\x05 \x01(\tH\x03R\bpassword\x88\x01\x01B\b\n" +
+	"\x06_tokenB\f\n" +
+	"\n" +
+	"_tenant_idB\v\n" +
+	"\t_usernameB\v\n" +
+	"\t_password\"\xe3\x06\n" +
 	"\x0eToolConnection\x128\n" +
 	"\aelastic\x18\x01 \x01(\v2\x1c.toolquery.ElasticConnectionH\x00R\aelastic\x128\n" +
 	"\adatadog\x18\x02 \x01(\v2\x1c.toolquery.DatadogConnectionH\x00R\adatadog\x12A\n" +
@@ -3047,7 +3421,12 @@ const file_toolquery_proto_rawDesc = "" +
 	" \x01(\v2\x1b.toolquery.JaegerConnectionH\x00R\x06jaeger\x12A\n" +
 	"\n" +
 	"opensearch\x18\v \x01(\v2\x1f.toolquery.OpensearchConnectionH\x00R\n" +
-	"opensearchB\f\n" +
+	"opensearch\x12A\n" +
+	"\n" +
+	"clickhouse\x18\f \x01(\v2\x1f.toolquery.ClickhouseConnectionH\x00R\n" +
+	"clickhouse\x12H\n" +
+	"\rvictoria_logs\x18\r \x01(\v2!.toolquery.VictoriaLogsConnectionH\x00R\fvictoriaLogs\x122\n" +
+	"\x05mimir\x18\x0e \x01(\v2\x1a.toolquery.MimirConnectionH\x00R\x05mimirB\f\n" +
 	"\n" +
 	"connection\"k\n" +
 	"\tTimeRange\x120\n" +
@@ -3086,7 +3465,7 @@ const file_toolquery_proto_rawDesc = "" +
 	"\x0f_prometheus_url\":\n" +
 	"\x0eLogsQueryFacet\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value\"\xa8\x02\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"\xdb\x02\n" +
 	"\x0eLogsQueryInput\x129\n" +
 	"\n" +
 	"connection\x18\x01 \x01(\v2\x19.toolquery.ToolConnectionR\n" +
@@ -3095,16 +3474,19 @@ const file_toolquery_proto_rawDesc = "" +
 	"\x05range\x18\x03 \x01(\v2\x14.toolquery.TimeRangeR\x05range\x12\x19\n" +
 	"\x05limit\x18\x04 \x01(\x05H\x00R\x05limit\x88\x01\x01\x121\n" +
 	"\x06facets\x18\x05 \x03(\v2\x19.toolquery.LogsQueryFacetR\x06facets\x125\n" +
-	"\aoptions\x18\x06 \x01(\v2\x16.toolquery.LogsOptionsH\x01R\aoptions\x88\x01\x01B\b\n" +
+	"\aoptions\x18\x06 \x01(\v2\x16.toolquery.LogsOptionsH\x01R\aoptions\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"page_token\x18\a \x01(\tH\x02R\tpageToken\x88\x01\x01B\b\n" +
 	"\x06_limitB\n" +
 	"\n" +
-	"\b_options\"O\n" +
+	"\b_optionsB\r\n" +
+	"\v_page_token\"O\n" +
 	"\vLogsOptions\x126\n" +
 	"\x05azure\x18\x01 \x01(\v2\x1b.toolquery.AzureLogsOptionsH\x00R\x05azure\x88\x01\x01B\b\n" +
 	"\x06_azure\"3\n" +
 	"\x10AzureLogsOptions\x12\x1f\n" +
 	"\vresource_id\x18\x01 \x01(\tR\n" +
-	"resourceId\"\xf9\x01\n" +
+	"resourceId\"\xac\x02\n" +
 	"\x10TracesQueryInput\x129\n" +
 	"\n" +
 	"connection\x18\x01 \x01(\v2\x19.toolquery.ToolConnectionR\n" +
@@ -3112,10 +3494,13 @@ const file_toolquery_proto_rawDesc = "" +
 	"\x05query\x18\x02 \x01(\tR\x05query\x12*\n" +
 	"\x05range\x18\x03 \x01(\v2\x14.toolquery.TimeRangeR\x05range\x12\x19\n" +
 	"\x05limit\x18\x04 \x01(\x05H\x00R\x05limit\x88\x01\x01\x127\n" +
-	"\aoptions\x18\x05 \x01(\v2\x18.toolquery.TracesOptionsH\x01R\aoptions\x88\x01\x01B\b\n" +
+	"\aoptions\x18\x05 \x01(\v2\x18.toolquery.TracesOptionsH\x01R\aoptions\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"page_token\x18\x06 \x01(\tH\x02R\tpageToken\x88\x01\x01B\b\n" +
 	"\x06_limitB\n" +
 	"\n" +
-	"\b_options\"W\n" +
+	"\b_optionsB\r\n" +
+	"\v_page_token\"W\n" +
 	"\rTracesOptions\x12;\n" +
 	"\x06jaeger\x18\x01 \x01(\v2\x1e.toolquery.JaegerTracesOptionsH\x00R\x06jaeger\x88\x01\x01B\t\n" +
 	"\a_jaeger\"E\n" +
@@ -3200,9 +3585,11 @@ const file_toolquery_proto_rawDesc = "" +
 	"\x06labels\x18\x03 \x03(\v2\x1f.toolquery.LogEntry.LabelsEntryR\x06labels\x1a9\n" +
 	"\vLabelsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\":\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"{\n" +
 	"\x0fLogsQueryOutput\x12'\n" +
-	"\x04logs\x18\x01 \x03(\v2\x13.toolquery.LogEntryR\x04logs\"\xd7\x02\n" +
+	"\x04logs\x18\x01 \x03(\v2\x13.toolquery.LogEntryR\x04logs\x12+\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tH\x00R\rnextPageToken\x88\x01\x01B\x12\n" +
+	"\x10_next_page_token\"\xd7\x02\n" +
 	"\tTraceSpan\x12\x19\n" +
 	"\btrace_id\x18\x01 \x01(\tR\atraceId\x12\x17\n" +
 	"\aspan_id\x18\x02 \x01(\tR\x06spanId\x12\x1b\n" +
@@ -3214,9 +3601,11 @@ const file_toolquery_proto_rawDesc = "" +
 	"\x04tags\x18\b \x03(\v2\x1e.toolquery.TraceSpan.TagsEntryR\x04tags\x1a7\n" +
 	"\tTagsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"?\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x80\x01\n" +
 	"\x11TracesQueryOutput\x12*\n" +
-	"\x05spans\x18\x01 \x03(\v2\x14.toolquery.TraceSpanR\x05spans\"\x8e\x01\n" +
+	"\x05spans\x18\x01 \x03(\v2\x14.toolquery.TraceSpanR\x05spans\x12+\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tH\x00R\rnextPageToken\x88\x01\x01B\x12\n" +
+	"\x10_next_page_token\"\x8e\x01\n" +
 	"\x11InvokeLambdaInput\x126\n" +
 	"\n" +
 	"connection\x18\x01 \x01(\v2\x16.cloudquery.ConnectionR\n" +
@@ -3232,13 +3621,16 @@ const file_toolquery_proto_rawDesc = "" +
 	"\x06script\x18\x01 \x01(\tR\x06script\"/\n" +
 	"\fRunLuaOutput\x12\x1f\n" +
 	"\vresult_json\x18\x01 \x01(\tR\n" +
-	"resultJson2\x9c\x04\n" +
+	"resultJson2\xb4\x05\n" +
 	"\tToolQuery\x12H\n" +
 	"\aMetrics\x12\x1c.toolquery.MetricsQueryInput\x1a\x1d.toolquery.MetricsQueryOutput\"\x00\x12P\n" +
 	"\rMetricsSearch\x12\x1d.toolquery.MetricsSearchInput\x1a\x1e.toolquery.MetricsSearchOutput\"\x00\x12_\n" +
 	"\x12MetricsLabelSearch\x12\".toolquery.MetricsLabelSearchInput\x1a#.toolquery.MetricsLabelSearchOutput\"\x00\x12?\n" +
 	"\x04Logs\x12\x19.toolquery.LogsQueryInput\x1a\x1a.toolquery.LogsQueryOutput\"\x00\x12E\n" +
-	"\x06Traces\x12\x1b.toolquery.TracesQueryInput\x1a\x1c.toolquery.TracesQueryOutput\"\x00\x12M\n" +
+	"\x06Traces\x12\x1b.toolquery.TracesQueryInput\x1a\x1c.toolquery.TracesQueryOutput\"\x00\x12G\n" +
+	"\n" +
+	"StreamLogs\x12\x19.toolquery.LogsQueryInput\x1a\x1a.toolquery.LogsQueryOutput\"\x000\x01\x12M\n" +
+	"\fStreamTraces\x12\x1b.toolquery.TracesQueryInput\x1a\x1c.toolquery.TracesQueryOutput\"\x000\x01\x12M\n" +
 	"\fInvokeLambda\x12\x1c.toolquery.InvokeLambdaInput\x1a\x1d.toolquery.InvokeLambdaOutput\"\x00\x12;\n" +
 	"\x06RunLua\x12\x16.toolquery.RunLuaInput\x1a\x17.toolquery.RunLuaOutput\"\x00BEZCgithub.com/pluralsh/console/go/cloud-query/internal/proto/toolqueryb\x06proto3"
 
@@ -3254,7 +3646,7 @@ func file_toolquery_proto_rawDescGZIP() []byte {
 	return file_toolquery_proto_rawDescData
 }
 
-var file_toolquery_proto_msgTypes = make([]protoimpl.MessageInfo, 47)
+var file_toolquery_proto_msgTypes = make([]protoimpl.MessageInfo, 50)
 var file_toolquery_proto_goTypes = []any{
 	(*ElasticConnection)(nil),              // 0: toolquery.ElasticConnection
 	(*OpensearchConnection)(nil),           // 1: toolquery.OpensearchConnection
@@ -3267,44 +3659,47 @@ var file_toolquery_proto_goTypes = []any{
 	(*DynatraceConnection)(nil),            // 8: toolquery.DynatraceConnection
 	(*CloudwatchConnection)(nil),           // 9: toolquery.CloudwatchConnection
 	(*AzureConnection)(nil),                // 10: toolquery.AzureConnection
-	(*ToolConnection)(nil),                 // 11: toolquery.ToolConnection
-	(*TimeRange)(nil),                      // 12: toolquery.TimeRange
-	(*MetricsQueryInput)(nil),              // 13: toolquery.MetricsQueryInput
-	(*MetricsOptions)(nil),                 // 14: toolquery.MetricsOptions
-	(*AzureMetricsOptions)(nil),            // 15: toolquery.AzureMetricsOptions
-	(*LogsQueryFacet)(nil),                 // 16: toolquery.LogsQueryFacet
-	(*LogsQueryInput)(nil),                 // 17: toolquery.LogsQueryInput
-	(*LogsOptions)(nil),                    // 18: toolquery.LogsOptions
-	(*AzureLogsOptions)(nil),               // 19: toolquery.AzureLogsOptions
-	(*TracesQueryInput)(nil),               // 20: toolquery.TracesQueryInput
-	(*TracesOptions)(nil),                  // 21: toolquery.TracesOptions
-	(*JaegerTraceQueryAttribute)(nil),      // 22: toolquery.JaegerTraceQueryAttribute
-	(*JaegerTracesOptions)(nil),            // 23: toolquery.JaegerTracesOptions
-	(*MetricPoint)(nil),                    // 24: toolquery.MetricPoint
-	(*MetricsQueryOutput)(nil),             // 25: toolquery.MetricsQueryOutput
-	(*MetricsSearchInput)(nil),             // 26: toolquery.MetricsSearchInput
-	(*MetricsSearchOptions)(nil),           // 27: toolquery.MetricsSearchOptions
-	(*AzureMetricsSearchOptions)(nil),      // 28: toolquery.AzureMetricsSearchOptions
-	(*MetricsSearchResult)(nil),            // 29: toolquery.MetricsSearchResult
-	(*MetricsSearchOutput)(nil),            // 30: toolquery.MetricsSearchOutput
-	(*MetricsLabelSearchInput)(nil),        // 31: toolquery.MetricsLabelSearchInput
-	(*MetricsLabelSearchOptions)(nil),      // 32: toolquery.MetricsLabelSearchOptions
-	(*AzureMetricsLabelSearchOptions)(nil), // 33: toolquery.AzureMetricsLabelSearchOptions
-	(*MetricsLabelSearchResult)(nil),       // 34: toolquery.MetricsLabelSearchResult
-	(*MetricsLabelSearchOutput)(nil),       // 35: toolquery.MetricsLabelSearchOutput
-	(*LogEntry)(nil),                       // 36: toolquery.LogEntry
-	(*LogsQueryOutput)(nil),                // 37: toolquery.LogsQueryOutput
-	(*TraceSpan)(nil),                      // 38: toolquery.TraceSpan
-	(*TracesQueryOutput)(nil),              // 39: toolquery.TracesQueryOutput
-	(*InvokeLambdaInput)(nil),              // 40: toolquery.InvokeLambdaInput
-	(*InvokeLambdaOutput)(nil),             // 41: toolquery.InvokeLambdaOutput
-	(*RunLuaInput)(nil),                    // 42: toolquery.RunLuaInput
-	(*RunLuaOutput)(nil),                   // 43: toolquery.RunLuaOutput
-	nil,                                    // 44: toolquery.MetricPoint.LabelsEntry
-	nil,                                    // 45: toolquery.LogEntry.LabelsEntry
-	nil,                                    // 46: toolquery.TraceSpan.TagsEntry
-	(*timestamppb.Timestamp)(nil),          // 47: google.protobuf.Timestamp
-	(*cloudquery.Connection)(nil),          // 48: cloudquery.Connection
+	(*ClickhouseConnection)(nil),           // 11: toolquery.ClickhouseConnection
+	(*VictoriaLogsConnection)(nil),         // 12: toolquery.VictoriaLogsConnection
+	(*MimirConnection)(nil),                // 13: toolquery.MimirConnection
+	(*ToolConnection)(nil),                 // 14: toolquery.ToolConnection
+	(*TimeRange)(nil),                      // 15: toolquery.TimeRange
+	(*MetricsQueryInput)(nil),              // 16: toolquery.MetricsQueryInput
+	(*MetricsOptions)(nil),                 // 17: toolquery.MetricsOptions
+	(*AzureMetricsOptions)(nil),            // 18: toolquery.AzureMetricsOptions
+	(*LogsQueryFacet)(nil),                 // 19: toolquery.LogsQueryFacet
+	(*LogsQueryInput)(nil),                 // 20: toolquery.LogsQueryInput
+	(*LogsOptions)(nil),                    // 21: toolquery.LogsOptions
+	(*AzureLogsOptions)(nil),               // 22: toolquery.AzureLogsOptions
+	(*TracesQueryInput)(nil),               // 23: toolquery.TracesQueryInput
+	(*TracesOptions)(nil),                  // 24: toolquery.TracesOptions
+	(*JaegerTraceQueryAttribute)(nil),      // 25: toolquery.JaegerTraceQueryAttribute
+	(*JaegerTracesOptions)(nil),            // 26: toolquery.JaegerTracesOptions
+	(*MetricPoint)(nil),                    // 27: toolquery.MetricPoint
+	(*MetricsQueryOutput)(nil),             // 28: toolquery.MetricsQueryOutput
+	(*MetricsSearchInput)(nil),             // 29: toolquery.MetricsSearchInput
+	(*MetricsSearchOptions)(nil),           // 30: toolquery.MetricsSearchOptions
+	(*AzureMetricsSearchOptions)(nil),      // 31: toolquery.AzureMetricsSearchOptions
+	(*MetricsSearchResult)(nil),            // 32: toolquery.MetricsSearchResult
+	(*MetricsSearchOutput)(nil),            // 33: toolquery.MetricsSearchOutput
+	(*MetricsLabelSearchInput)(nil),        // 34: toolquery.MetricsLabelSearchInput
+	(*MetricsLabelSearchOptions)(nil),      // 35: toolquery.MetricsLabelSearchOptions
+	(*AzureMetricsLabelSearchOptions)(nil), // 36: toolquery.AzureMetricsLabelSearchOptions
+	(*MetricsLabelSearchResult)(nil),       // 37: toolquery.MetricsLabelSearchResult
+	(*MetricsLabelSearchOutput)(nil),       // 38: toolquery.MetricsLabelSearchOutput
+	(*LogEntry)(nil),                       // 39: toolquery.LogEntry
+	(*LogsQueryOutput)(nil),                // 40: toolquery.LogsQueryOutput
+	(*TraceSpan)(nil),                      // 41: toolquery.TraceSpan
+	(*TracesQueryOutput)(nil),              // 42: toolquery.TracesQueryOutput
+	(*InvokeLambdaInput)(nil),              // 43: toolquery.InvokeLambdaInput
+	(*InvokeLambdaOutput)(nil),             // 44: toolquery.InvokeLambdaOutput
+	(*RunLuaInput)(nil),                    // 45: toolquery.RunLuaInput
+	(*RunLuaOutput)(nil),                   // 46: toolquery.RunLuaOutput
+	nil,                                    // 47: toolquery.MetricPoint.LabelsEntry
+	nil,                                    // 48: toolquery.LogEntry.LabelsEntry
+	nil,                                    // 49: toolquery.TraceSpan.TagsEntry
+	(*timestamppb.Timestamp)(nil),          // 50: google.protobuf.Timestamp
+	(*cloudquery.Connection)(nil),          // 51: cloudquery.Connection
 }
 var file_toolquery_proto_depIdxs = []int32{
 	0,  // 0: toolquery.ToolConnection.elastic:type_name -> toolquery.ElasticConnection
@@ -3318,60 +3713,67 @@ var file_toolquery_proto_depIdxs = []int32{
 	10, // 8: toolquery.ToolConnection.azure:type_name -> toolquery.AzureConnection
 	6,  // 9: toolquery.ToolConnection.jaeger:type_name -> toolquery.JaegerConnection
 	1,  // 10: toolquery.ToolConnection.opensearch:type_name -> toolquery.OpensearchConnection
-	47, // 11: toolquery.TimeRange.start:type_name -> google.protobuf.Timestamp
-	47, // 12: toolquery.TimeRange.end:type_name -> google.protobuf.Timestamp
-	11, // 13: toolquery.MetricsQueryInput.connection:type_name -> toolquery.ToolConnection
-	12, // 14: toolquery.MetricsQueryInput.range:type_name -> toolquery.TimeRange
-	14, // 15: toolquery.MetricsQueryInput.options:type_name -> toolquery.MetricsOptions
-	15, // 16: toolquery.MetricsOptions.azure:type_name -> toolquery.AzureMetricsOptions
-	11, // 17: toolquery.LogsQueryInput.connection:type_name -> toolquery.ToolConnection
-	12, // 18: toolquery.LogsQueryInput.range:type_name -> toolquery.TimeRange
-	16, // 19: toolquery.LogsQueryInput.facets:type_name -> toolquery.LogsQueryFacet
-	18, // 20: toolquery.LogsQueryInput.options:type_name -> toolquery.LogsOptions
-	19, // 21: toolquery.LogsOptions.azure:type_name -> toolquery.AzureLogsOptions
-	11, // 22: toolquery.TracesQueryInput.connection:type_name -> toolquery.ToolConnection
-	12, // 23: toolquery.TracesQueryInput.range:type_name -> toolquery.TimeRange
-	21, // 24: toolquery.TracesQueryInput.options:type_name -> toolquery.TracesOptions
-	23, // 25: toolquery.TracesOptions.jaeger:type_name -> toolquery.JaegerTracesOptions
-	22, // 26: toolquery.JaegerTracesOptions.attributes:type_name -> toolquery.JaegerTraceQueryAttribute
-	47, // 27: toolquery.MetricPoint.timestamp:type_name -> google.protobuf.Timestamp
-	44, // 28: toolquery.MetricPoint.labels:type_name -> toolquery.MetricPoint.LabelsEntry
-	24, // 29: toolquery.MetricsQueryOutput.metrics:type_name -> toolquery.MetricPoint
-	11, // 30: toolquery.MetricsSearchInput.connection:type_name -> toolquery.ToolConnection
-	27, // 31: toolquery.MetricsSearchInput.options:type_name -> toolquery.MetricsSearchOptions
-	28, // 32: toolquery.MetricsSearchOptions.azure:type_name -> toolquery.AzureMetricsSearchOptions
-	29, // 33: toolquery.MetricsSearchOutput.metrics:type_name -> toolquery.MetricsSearchResult
-	11, // 34: toolquery.MetricsLabelSearchInput.connection:type_name -> toolquery.ToolConnection
-	32, // 35: toolquery.MetricsLabelSearchInput.options:type_name -> toolquery.MetricsLabelSearchOptions
-	33, // 36: toolquery.MetricsLabelSearchOptions.azure:type_name -> toolquery.AzureMetricsLabelSearchOptions
-	34, // 37: toolquery.MetricsLabelSearchOutput.results:type_name -> toolquery.MetricsLabelSearchResult
-	47, // 38: toolquery.LogEntry.timestamp:type_name -> google.protobuf.Timestamp
-	45, // 39: toolquery.LogEntry.labels:type_name -> toolquery.LogEntry.LabelsEntry
-	36, // 40: toolquery.LogsQueryOutput.logs:type_name -> toolquery.LogEntry
-	47, // 41: toolquery.TraceSpan.start:type_name -> google.protobuf.Timestamp
-	47, // 42: toolquery.TraceSpan.end:type_name -> google.protobuf.Timestamp
-	46, // 43: toolquery.TraceSpan.tags:type_name -> toolquery.TraceSpan.TagsEntry
-	38, // 44: toolquery.TracesQueryOutput.spans:type_name -> toolquery.TraceSpan
-	48, // 45: toolquery.InvokeLambdaInput.connection:type_name -> cloudquery.Connection
-	13, // 46: toolquery.ToolQuery.Metrics:input_type -> toolquery.MetricsQueryInput
-	26, // 47: toolquery.ToolQuery.MetricsSearch:input_type -> toolquery.MetricsSearchInput
-	31, // 48: toolquery.ToolQuery.MetricsLabelSearch:input_type -> toolquery.MetricsLabelSearchInput
-	17, // 49: toolquery.ToolQuery.Logs:input_type -> toolquery.LogsQueryInput
-	20, // 50: toolquery.ToolQuery.Traces:input_type -> toolquery.TracesQueryInput
-	40, // 51: toolquery.ToolQuery.InvokeLambda:input_type -> toolquery.InvokeLambdaInput
-	42, // 52: toolquery.ToolQuery.RunLua:input_type -> toolquery.RunLuaInput
-	25, // 53: toolquery.ToolQuery.Metrics:output_type -> toolquery.MetricsQueryOutput
-	30, // 54: toolquery.ToolQuery.MetricsSearch:output_type -> toolquery.MetricsSearchOutput
-	35, // 55: toolquery.ToolQuery.MetricsLabelSearch:output_type -> toolquery.MetricsLabelSearchOutput
-	37, // 56: toolquery.ToolQuery.Logs:output_type -> toolquery.LogsQueryOutput
-	39, // 57: toolquery.ToolQuery.Traces:output_type -> toolquery.TracesQueryOutput
-	41, // 58: toolquery.ToolQuery.InvokeLambda:output_type -> toolquery.InvokeLambdaOutput
-	43, // 59: toolquery.ToolQuery.RunLua:output_type -> toolquery.RunLuaOutput
-	53, // [53:60] is the sub-list for method output_type
-	46, // [46:53] is the sub-list for method input_type
-	46, // [46:46] is the sub-list for extension type_name
-	46, // [46:46] is the sub-list for extension extendee
-	0,  // [0:46] is the sub-list for field type_name
+	11, // 11: toolquery.ToolConnection.clickhouse:type_name -> toolquery.ClickhouseConnection
+	12, // 12: toolquery.ToolConnection.victoria_logs:type_name -> toolquery.VictoriaLogsConnection
+	13, // 13: toolquery.ToolConnection.mimir:type_name -> toolquery.MimirConnection
+	50, // 14: toolquery.TimeRange.start:type_name -> google.protobuf.Timestamp
+	50, // 15: toolquery.TimeRange.end:type_name -> google.protobuf.Timestamp
+	14, // 16: toolquery.MetricsQueryInput.connection:type_name -> toolquery.ToolConnection
+	15, // 17: toolquery.MetricsQueryInput.range:type_name -> toolquery.TimeRange
+	17, // 18: toolquery.MetricsQueryInput.options:type_name -> toolquery.MetricsOptions
+	18, // 19: toolquery.MetricsOptions.azure:type_name -> toolquery.AzureMetricsOptions
+	14, // 20: toolquery.LogsQueryInput.connection:type_name -> toolquery.ToolConnection
+	15, // 21: toolquery.LogsQueryInput.range:type_name -> toolquery.TimeRange
+	19, // 22: toolquery.LogsQueryInput.facets:type_name -> toolquery.LogsQueryFacet
+	21, // 23: toolquery.LogsQueryInput.options:type_name -> toolquery.LogsOptions
+	22, // 24: toolquery.LogsOptions.azure:type_name -> toolquery.AzureLogsOptions
+	14, // 25: toolquery.TracesQueryInput.connection:type_name -> toolquery.ToolConnection
+	15, // 26: toolquery.TracesQueryInput.range:type_name -> toolquery.TimeRange
+	24, // 27: toolquery.TracesQueryInput.options:type_name -> toolquery.TracesOptions
+	26, // 28: toolquery.TracesOptions.jaeger:type_name -> toolquery.JaegerTracesOptions
+	25, // 29: toolquery.JaegerTracesOptions.attributes:type_name -> toolquery.JaegerTraceQueryAttribute
+	50, // 30: toolquery.MetricPoint.timestamp:type_name -> google.protobuf.Timestamp
+	47, // 31: toolquery.MetricPoint.labels:type_name -> toolquery.MetricPoint.LabelsEntry
+	27, // 32: toolquery.MetricsQueryOutput.metrics:type_name -> toolquery.MetricPoint
+	14, // 33: toolquery.MetricsSearchInput.connection:type_name -> toolquery.ToolConnection
+	30, // 34: toolquery.MetricsSearchInput.options:type_name -> toolquery.MetricsSearchOptions
+	31, // 35: toolquery.MetricsSearchOptions.azure:type_name -> toolquery.AzureMetricsSearchOptions
+	32, // 36: toolquery.MetricsSearchOutput.metrics:type_name -> toolquery.MetricsSearchResult
+	14, // 37: toolquery.MetricsLabelSearchInput.connection:type_name -> toolquery.ToolConnection
+	35, // 38: toolquery.MetricsLabelSearchInput.options:type_name -> toolquery.MetricsLabelSearchOptions
+	36, // 39: toolquery.MetricsLabelSearchOptions.azure:type_name -> toolquery.AzureMetricsLabelSearchOptions
+	37, // 40: toolquery.MetricsLabelSearchOutput.results:type_name -> toolquery.MetricsLabelSearchResult
+	50, // 41: toolquery.LogEntry.timestamp:type_name -> google.protobuf.Timestamp
+	48, // 42: toolquery.LogEntry.labels:type_name -> toolquery.LogEntry.LabelsEntry
+	39, // 43: toolquery.LogsQueryOutput.logs:type_name -> toolquery.LogEntry
+	50, // 44: toolquery.TraceSpan.start:type_name -> google.protobuf.Timestamp
+	50, // 45: toolquery.TraceSpan.end:type_name -> google.protobuf.Timestamp
+	49, // 46: toolquery.TraceSpan.tags:type_name -> toolquery.TraceSpan.TagsEntry
+	41, // 47: toolquery.TracesQueryOutput.spans:type_name -> toolquery.TraceSpan
+	51, // 48: toolquery.InvokeLambdaInput.connection:type_name -> cloudquery.Connection
+	16, // 49: toolquery.ToolQuery.Metrics:input_type -> toolquery.MetricsQueryInput
+	29, // 50: toolquery.ToolQuery.MetricsSearch:input_type -> toolquery.MetricsSearchInput
+	34, // 51: toolquery.ToolQuery.MetricsLabelSearch:input_type -> toolquery.MetricsLabelSearchInput
+	20, // 52: toolquery.ToolQuery.Logs:input_type -> toolquery.LogsQueryInput
+	23, // 53: toolquery.ToolQuery.Traces:input_type -> toolquery.TracesQueryInput
+	20, // 54: toolquery.ToolQuery.StreamLogs:input_type -> toolquery.LogsQueryInput
+	23, // 55: toolquery.ToolQuery.StreamTraces:input_type -> toolquery.TracesQueryInput
+	43, // 56: toolquery.ToolQuery.InvokeLambda:input_type -> toolquery.InvokeLambdaInput
+	45, // 57: toolquery.ToolQuery.RunLua:input_type -> toolquery.RunLuaInput
+	28, // 58: toolquery.ToolQuery.Metrics:output_type -> toolquery.MetricsQueryOutput
+	33, // 59: toolquery.ToolQuery.MetricsSearch:output_type -> toolquery.MetricsSearchOutput
+	38, // 60: toolquery.ToolQuery.MetricsLabelSearch:output_type -> toolquery.MetricsLabelSearchOutput
+	40, // 61: toolquery.ToolQuery.Logs:output_type -> toolquery.LogsQueryOutput
+	42, // 62: toolquery.ToolQuery.Traces:output_type -> toolquery.TracesQueryOutput
+	40, // 63: toolquery.ToolQuery.StreamLogs:output_type -> toolquery.LogsQueryOutput
+	42, // 64: toolquery.ToolQuery.StreamTraces:output_type -> toolquery.TracesQueryOutput
+	44, // 65: toolquery.ToolQuery.InvokeLambda:output_type -> toolquery.InvokeLambdaOutput
+	46, // 66: toolquery.ToolQuery.RunLua:output_type -> toolquery.RunLuaOutput
+	58, // [58:67] is the sub-list for method output_type
+	49, // [49:58] is the sub-list for method input_type
+	49, // [49:49] is the sub-list for extension type_name
+	49, // [49:49] is the sub-list for extension extendee
+	0,  // [0:49] is the sub-list for field type_name
 }
 
 func init() { file_toolquery_proto_init() }
@@ -3387,7 +3789,10 @@ func file_toolquery_proto_init() {
 	file_toolquery_proto_msgTypes[6].OneofWrappers = []any{}
 	file_toolquery_proto_msgTypes[7].OneofWrappers = []any{}
 	file_toolquery_proto_msgTypes[9].OneofWrappers = []any{}
-	file_toolquery_proto_msgTypes[11].OneofWrappers = []any{
+	file_toolquery_proto_msgTypes[11].OneofWrappers = []any{}
+	file_toolquery_proto_msgTypes[12].OneofWrappers = []any{}
+	file_toolquery_proto_msgTypes[13].OneofWrappers = []any{}
+	file_toolquery_proto_msgTypes[14].OneofWrappers = []any{
 		(*ToolConnection_Elastic)(nil),
 		(*ToolConnection_Datadog)(nil),
 		(*ToolConnection_Prometheus)(nil),
@@ -3399,28 +3804,33 @@ func file_toolquery_proto_init() {
 		(*ToolConnection_Azure)(nil),
 		(*ToolConnection_Jaeger)(nil),
 		(*ToolConnection_Opensearch)(nil),
+		(*ToolConnection_Clickhouse)(nil),
+		(*ToolConnection_VictoriaLogs)(nil),
+		(*ToolConnection_Mimir)(nil),
 	}
-	file_toolquery_proto_msgTypes[13].OneofWrappers = []any{}
-	file_toolquery_proto_msgTypes[14].OneofWrappers = []any{}
-	file_toolquery_proto_msgTypes[15].OneofWrappers = []any{}
+	file_toolquery_proto_msgTypes[16].OneofWrappers = []any{}
 	file_toolquery_proto_msgTypes[17].OneofWrappers = []any{}
 	file_toolquery_proto_msgTypes[18].OneofWrappers = []any{}
 	file_toolquery_proto_msgTypes[20].OneofWrappers = []any{}
 	file_toolquery_proto_msgTypes[21].OneofWrappers = []any{}
 	file_toolquery_proto_msgTypes[23].OneofWrappers = []any{}
+	file_toolquery_proto_msgTypes[24].OneofWrappers = []any{}
 	file_toolquery_proto_msgTypes[26].OneofWrappers = []any{}
-	file_toolquery_proto_msgTypes[27].OneofWrappers = []any{}
-	file_toolquery_proto_msgTypes[28].OneofWrappers = []any{}
+	file_toolquery_proto_msgTypes[29].OneofWrappers = []any{}
+	file_toolquery_proto_msgTypes[30].OneofWrappers = []any{}
 	file_toolquery_proto_msgTypes[31].OneofWrappers = []any{}
-	file_toolquery_proto_msgTypes[32].OneofWrappers = []any{}
-	file_toolquery_proto_msgTypes[33].OneofWrappers = []any{}
+	file_toolquery_proto_msgTypes[34].OneofWrappers = []any{}
+	file_toolquery_proto_msgTypes[35].OneofWrappers = []any{}
+	file_toolquery_proto_msgTypes[36].OneofWrappers = []any{}
+	file_toolquery_proto_msgTypes[40].OneofWrappers = []any{}
+	file_toolquery_proto_msgTypes[42].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_toolquery_proto_rawDesc), len(file_toolquery_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   47,
+			NumMessages:   50,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -24,6 +24,8 @@ const (
 	ToolQuery_MetricsLabelSearch_FullMethodName = "/toolquery.ToolQuery/MetricsLabelSearch"
 	ToolQuery_Logs_FullMethodName               = "/toolquery.ToolQuery/Logs"
 	ToolQuery_Traces_FullMethodName             = "/toolquery.ToolQuery/Traces"
+	ToolQuery_StreamLogs_FullMethodName         = "/toolquery.ToolQuery/StreamLogs"
+	ToolQuery_StreamTraces_FullMethodName       = "/toolquery.ToolQuery/StreamTraces"
 	ToolQuery_InvokeLambda_FullMethodName       = "/toolquery.ToolQuery/InvokeLambda"
 	ToolQuery_RunLua_FullMethodName             = "/toolquery.ToolQuery/RunLua"
 )
@@ -37,6 +39,10 @@ type ToolQueryClient interface {
 	MetricsLabelSearch(ctx context.Context, in *MetricsLabelSearchInput, opts ...grpc.CallOption) (*MetricsLabelSearchOutput, error)
 	Logs(ctx context.Context, in *LogsQueryInput, opts ...grpc.CallOption) (*LogsQueryOutput, error)
 	Traces(ctx context.Context, in *TracesQueryInput, opts ...grpc.CallOption) (*TracesQueryOutput, error)
+	// StreamLogs follows page tokens and streams all matching entries split into multiple messages.
+	StreamLogs(ctx context.Context, in *LogsQueryInput, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LogsQueryOutput], error)
+	// StreamTraces follows page tokens and streams all matching spans split into multiple messages.
+	StreamTraces(ctx context.Context, in *TracesQueryInput, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TracesQueryOutput], error)
 	InvokeLambda(ctx context.Context, in *InvokeLambdaInput, opts ...grpc.CallOption) (*InvokeLambdaOutput, error)
 	RunLua(ctx context.Context, in *RunLuaInput, opts ...grpc.CallOption) (*RunLuaOutput, error)
 }
@@ -99,6 +105,44 @@ func (c *toolQueryClient) Traces(ctx context.Context, in *TracesQueryInput, opts
 	return out, nil
 }
 
+func (c *toolQueryClient) StreamLogs(ctx context.Context, in *LogsQueryInput, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LogsQueryOutput], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ToolQuery_ServiceDesc.Streams[0], ToolQuery_StreamLogs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[LogsQueryInput, LogsQueryOutput]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ToolQuery_StreamLogsClient = grpc.ServerStreamingClient[LogsQueryOutput]
+
+func (c *toolQueryClient) StreamTraces(ctx context.Context, in *TracesQueryInput, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TracesQueryOutput], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ToolQuery_ServiceDesc.Streams[1], ToolQuery_StreamTraces_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[TracesQueryInput, TracesQueryOutput]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ToolQuery_StreamTracesClient = grpc.ServerStreamingClient[TracesQueryOutput]
+
 func (c *toolQueryClient) InvokeLambda(ctx context.Context, in *InvokeLambdaInput, opts ...grpc.CallOption) (*InvokeLambdaOutput, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(InvokeLambdaOutput)
@@ -128,6 +172,10 @@ type ToolQueryServer interface {
 	MetricsLabelSearch(context.Context, *MetricsLabelSearchInput) (*MetricsLabelSearchOutput, error)
 	Logs(context.Context, *LogsQueryInput) (*LogsQueryOutput, error)
 	Traces(context.Context, *TracesQueryInput) (*TracesQueryOutput, error)
+	// StreamLogs follows page tokens and streams all matching entries split into multiple messages.
+	StreamLogs(*LogsQueryInput, grpc.ServerStreamingServer[LogsQueryOutput]) error
+	// StreamTraces follows page tokens and streams all matching spans split into multiple messages.
+	StreamTraces(*TracesQueryInput, grpc.ServerStreamingServer[TracesQueryOutput]) error
 	InvokeLambda(context.Context, *InvokeLambdaInput) (*InvokeLambdaOutput, error)
 	RunLua(context.Context, *RunLuaInput) (*RunLuaOutput, error)
 	mustEmbedUnimplementedToolQueryServer()
@@ -155,6 +203,12 @@ func (UnimplementedToolQueryServer) Logs(context.Context, *LogsQueryInput) (*Log
 func (UnimplementedToolQueryServer) Traces(context.Context, *TracesQueryInput) (*TracesQueryOutput, error) {
 	return nil, status.Error(codes.Unimplemented, "method Traces not implemented")
 }
+func (UnimplementedToolQueryServer) StreamLogs(*LogsQueryInput, grpc.ServerStreamingServer[LogsQueryOutput]) error {
+	return status.Error(codes.Unimplemented, "method StreamLogs not implemented")
+}
+func (UnimplementedToolQueryServer) StreamTraces(*TracesQueryInput, grpc.ServerStreamingServer[TracesQueryOutput]) error {
+	return status.Error(codes.Unimplemented, "method StreamTraces not implemented")
+}
 func (UnimplementedToolQueryServer) InvokeLambda(context.Context, *InvokeLambdaInput) (*InvokeLambdaOutput, error) {
 	return nil, status.Error(codes.Unimplemented, "method InvokeLambda not implemented")
 }
@@ -272,6 +326,28 @@ func _ToolQuery_Traces_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ToolQuery_StreamLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(LogsQueryInput)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ToolQueryServer).StreamLogs(m, &grpc.GenericServerStream[LogsQueryInput, LogsQueryOutput]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ToolQuery_StreamLogsServer = grpc.ServerStreamingServer[LogsQueryOutput]
+
+func _ToolQuery_StreamTraces_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TracesQueryInput)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ToolQueryServer).StreamTraces(m, &grpc.GenericServerStream[TracesQueryInput, TracesQueryOutput]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ToolQuery_StreamTracesServer = grpc.ServerStreamingServer[TracesQueryOutput]
+
 func _ToolQuery_InvokeLambda_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(InvokeLambdaInput)
 	if err := dec(in); err != nil {
@@ -344,6 +420,17 @@ var ToolQuery_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _ToolQuery_RunLua_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamLogs",
+			Handler:       _ToolQuery_StreamLogs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamTraces",
+			Handler:       _ToolQuery_StreamTraces_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "toolquery.proto",
 }
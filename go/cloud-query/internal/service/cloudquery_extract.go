@@ -9,6 +9,8 @@ import (
 	"github.com/pluralsh/console/go/cloud-query/internal/connection"
 	"github.com/pluralsh/console/go/cloud-query/internal/extractor"
 	"github.com/pluralsh/console/go/cloud-query/internal/extractor/aws"
+	"github.com/pluralsh/console/go/cloud-query/internal/extractor/azure"
+	"github.com/pluralsh/console/go/cloud-query/internal/extractor/gcp"
 	"github.com/pluralsh/console/go/cloud-query/internal/proto/cloudquery"
 )
 
@@ -39,6 +41,10 @@ func (in *CloudQueryService) newExtractor(provider config.Provider, sink extract
 	switch provider {
 	case config.ProviderAWS:
 		return aws.NewAWSExtractor(sink), nil
+	case config.ProviderAzure:
+		return azure.NewAzureExtractor(sink), nil
+	case config.ProviderGCP:
+		return gcp.NewGCPExtractor(sink), nil
 	default:
 		return nil, status.Errorf(codes.Unimplemented, "extractor for provider '%s' is not supported", provider)
 	}
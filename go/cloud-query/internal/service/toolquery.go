@@ -11,6 +11,7 @@ import (
 	"google.golang.org/grpc/status"
 	"k8s.io/klog/v2"
 
+	"github.com/pluralsh/console/go/cloud-query/internal/cache"
 	"github.com/pluralsh/console/go/cloud-query/internal/log"
 	"github.com/pluralsh/console/go/cloud-query/internal/proto/toolquery"
 	"github.com/pluralsh/console/go/cloud-query/internal/tools"
@@ -21,6 +22,9 @@ import (
 // ToolQueryService implements the toolquery.ToolQueryServer interface.
 type ToolQueryService struct {
 	toolquery.UnimplementedToolQueryServer
+
+	// cache stores provider results, nil disables caching.
+	cache *cache.Cache
 }
 
 // Install registers the ToolQuery service with the gRPC server.
@@ -29,9 +33,10 @@ func (in *ToolQueryService) Install(server *grpc.Server) {
 	toolquery.RegisterToolQueryServer(server, in)
 }
 
-// NewToolQueryService creates a new instance of the ToolQuery server.
-func NewToolQueryService() Service {
-	return &ToolQueryService{}
+// NewToolQueryService creates a new instance of the ToolQuery server. Provider
+// results are cached in the provided cache, a nil cache disables caching.
+func NewToolQueryService(c *cache.Cache) Service {
+	return &ToolQueryService{cache: c}
 }
 
 func (in *ToolQueryService) Metrics(ctx context.Context, input *toolquery.MetricsQueryInput) (*toolquery.MetricsQueryOutput, error) {
@@ -48,7 +53,9 @@ func (in *ToolQueryService) Metrics(ctx context.Context, input *toolquery.Metric
 		return nil, in.mapError("metrics", err)
 	}
 
-	output, err := provider.Metrics(ctx, input)
+	output, err := cache.Do(ctx, in.cache, "metrics", in.cacheKey("metrics", input), func(ctx context.Context) (*toolquery.MetricsQueryOutput, error) {
+		return provider.Metrics(ctx, input)
+	})
 	if err != nil {
 		return nil, in.mapError("metrics", err)
 	}
@@ -70,7 +77,9 @@ func (in *ToolQueryService) MetricsSearch(ctx context.Context, input *toolquery.
 		return nil, in.mapError("metrics_search", err)
 	}
 
-	output, err := provider.MetricsSearch(ctx, input)
+	output, err := cache.Do(ctx, in.cache, "metrics_search", in.cacheKey("metrics_search", input), func(ctx context.Context) (*toolquery.MetricsSearchOutput, error) {
+		return provider.MetricsSearch(ctx, input)
+	})
 	if err != nil {
 		return nil, in.mapError("metrics_search", err)
 	}
@@ -95,7 +104,9 @@ func (in *ToolQueryService) MetricsLabelSearch(ctx context.Context, input *toolq
 		return nil, in.mapError("metrics_label_search", err)
 	}
 
-	output, err := provider.MetricsLabelSearch(ctx, input)
+	output, err := cache.Do(ctx, in.cache, "metrics_label_search", in.cacheKey("metrics_label_search", input), func(ctx context.Context) (*toolquery.MetricsLabelSearchOutput, error) {
+		return provider.MetricsLabelSearch(ctx, input)
+	})
 	if err != nil {
 		return nil, in.mapError("metrics_label_search", err)
 	}
@@ -116,7 +127,9 @@ func (in *ToolQueryService) Logs(ctx context.Context, input *toolquery.LogsQuery
 		return nil, in.mapError("logs", err)
 	}
 
-	output, err := provider.Logs(ctx, input)
+	output, err := cache.Do(ctx, in.cache, "logs", in.cacheKey("logs", input), func(ctx context.Context) (*toolquery.LogsQueryOutput, error) {
+		return provider.Logs(ctx, input)
+	})
 	if err != nil {
 		return nil, in.mapError("logs", err)
 	}
@@ -138,7 +151,9 @@ func (in *ToolQueryService) Traces(ctx context.Context, input *toolquery.TracesQ
 		return nil, in.mapError("traces", err)
 	}
 
-	output, err := provider.Traces(ctx, input)
+	output, err := cache.Do(ctx, in.cache, "traces", in.cacheKey("traces", input), func(ctx context.Context) (*toolquery.TracesQueryOutput, error) {
+		return provider.Traces(ctx, input)
+	})
 	if err != nil {
 		return nil, in.mapError("traces", err)
 	}
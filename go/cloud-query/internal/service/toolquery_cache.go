@@ -0,0 +1,70 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"k8s.io/klog/v2"
+
+	"github.com/pluralsh/console/go/cloud-query/internal/log"
+	"github.com/pluralsh/console/go/cloud-query/internal/proto/toolquery"
+)
+
+// cacheKey fingerprints the input of a ToolQuery operation. The query is trimmed and
+// the time range is truncated to the cache TTL, results for ranges that only differ
+// within a single TTL window are considered equivalent. The connection, including its
+// credentials, is part of the key, so results are never shared between connections.
+// An empty key is returned when caching is disabled or the input cannot be fingerprinted.
+func (in *ToolQueryService) cacheKey(operation string, input proto.Message) string {
+	if in.cache == nil {
+		return ""
+	}
+
+	normalized := proto.Clone(input)
+	resolution := in.cache.TTL()
+
+	switch n := normalized.(type) {
+	case *toolquery.MetricsQueryInput:
+		n.Query = strings.TrimSpace(n.Query)
+		n.Range = in.truncateRange(n.Range, resolution)
+	case *toolquery.MetricsSearchInput:
+		n.Query = strings.TrimSpace(n.Query)
+	case *toolquery.MetricsLabelSearchInput:
+		n.Metric = strings.TrimSpace(n.Metric)
+	case *toolquery.LogsQueryInput:
+		n.Query = strings.TrimSpace(n.Query)
+		n.Range = in.truncateRange(n.Range, resolution)
+	case *toolquery.TracesQueryInput:
+		n.Query = strings.TrimSpace(n.Query)
+		n.Range = in.truncateRange(n.Range, resolution)
+	}
+
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(normalized)
+	if err != nil {
+		klog.V(log.LogLevelDebug).InfoS("skipping cache, failed to fingerprint input", "operation", operation, "error", err)
+		return ""
+	}
+
+	sum := sha256.Sum256(append([]byte(operation+"/"), data...))
+	return hex.EncodeToString(sum[:])
+}
+
+func (in *ToolQueryService) truncateRange(timeRange *toolquery.TimeRange, resolution time.Duration) *toolquery.TimeRange {
+	if timeRange == nil || resolution <= 0 {
+		return timeRange
+	}
+
+	truncated := &toolquery.TimeRange{}
+	if timeRange.Start != nil {
+		truncated.Start = timestamppb.New(timeRange.GetStart().AsTime().Truncate(resolution))
+	}
+	if timeRange.End != nil {
+		truncated.End = timestamppb.New(timeRange.GetEnd().AsTime().Truncate(resolution))
+	}
+
+	return truncated
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+
+	"github.com/samber/lo"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/pluralsh/console/go/cloud-query/internal/proto/toolquery"
+	"github.com/pluralsh/console/go/cloud-query/internal/tools"
+)
+
+// streamPageSize is the page size used by streaming queries that do not set a limit.
+const streamPageSize = 500
+
+// StreamLogs runs a logs query page by page and sends each page as a separate message.
+// The limit of the input is used as a page size. Providers without pagination support
+// send a single message.
+func (in *ToolQueryService) StreamLogs(input *toolquery.LogsQueryInput, stream grpc.ServerStreamingServer[toolquery.LogsQueryOutput]) error {
+	if input == nil {
+		return status.Error(codes.InvalidArgument, "input is required")
+	}
+
+	if err := in.validateInput(input.GetConnection(), input.GetQuery(), input.GetRange()); err != nil {
+		return err
+	}
+
+	provider, err := tools.NewProvider(input.GetConnection())
+	if err != nil {
+		return in.mapError("stream_logs", err)
+	}
+
+	page := proto.CloneOf(input)
+	// dynatrace rejects the limit field for logs, it has to be set in the query itself
+	if page.GetLimit() <= 0 && input.GetConnection().GetDynatrace() == nil {
+		page.Limit = proto.Int32(streamPageSize)
+	}
+
+	return streamPages(stream.Context(), page.PageToken, stream.Send,
+		func(ctx context.Context, token *string) (*toolquery.LogsQueryOutput, error) {
+			page.PageToken = token
+			output, err := provider.Logs(ctx, page)
+			if err != nil {
+				return nil, in.mapError("stream_logs", err)
+			}
+			return output, nil
+		})
+}
+
+// StreamTraces runs a traces query page by page and sends each page as a separate message.
+// The limit of the input is used as a page size. Providers without pagination support
+// send a single message.
+func (in *ToolQueryService) StreamTraces(input *toolquery.TracesQueryInput, stream grpc.ServerStreamingServer[toolquery.TracesQueryOutput]) error {
+	if input == nil {
+		return status.Error(codes.InvalidArgument, "input is required")
+	}
+
+	if err := in.validateInput(input.GetConnection(), input.GetQuery(), input.GetRange()); err != nil {
+		return err
+	}
+
+	provider, err := tools.NewProvider(input.GetConnection())
+	if err != nil {
+		return in.mapError("stream_traces", err)
+	}
+
+	page := proto.CloneOf(input)
+	if page.GetLimit() <= 0 {
+		page.Limit = proto.Int32(streamPageSize)
+	}
+
+	return streamPages(stream.Context(), page.PageToken, stream.Send,
+		func(ctx context.Context, token *string) (*toolquery.TracesQueryOutput, error) {
+			page.PageToken = token
+			output, err := provider.Traces(ctx, page)
+			if err != nil {
+				return nil, in.mapError("stream_traces", err)
+			}
+			return output, nil
+		})
+}
+
+// pagedOutput is a page of query results.
+type pagedOutput interface {
+	GetNextPageToken() string
+}
+
+// streamPages fetches pages starting at token and sends them until a page has no
+// next page token, or the token stops advancing.
+func streamPages[O pagedOutput](ctx context.Context, token *string, send func(O) error, fetch func(context.Context, *string) (O, error)) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+
+		output, err := fetch(ctx, token)
+		if err != nil {
+			return err
+		}
+
+		if err := send(output); err != nil {
+			return err
+		}
+
+		next := output.GetNextPageToken()
+		if next == "" || next == lo.FromPtr(token) {
+			return nil
+		}
+
+		token = &next
+	}
+}
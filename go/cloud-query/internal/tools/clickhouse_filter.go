@@ -0,0 +1,349 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// clickhouseSchema describes what a ClickHouse filter may reference. Columns map to the
+// type their values are bound as, maps are Map(String, String) attribute columns and
+// text is the column searched by bare string literals.
+type clickhouseSchema struct {
+	columns map[string]string
+	maps    map[string]struct{}
+	text    string
+}
+
+var clickhouseLogsSchema = clickhouseSchema{
+	columns: map[string]string{
+		"Body":           "String",
+		"ServiceName":    "String",
+		"SeverityText":   "String",
+		"SeverityNumber": "Int64",
+		"TraceId":        "String",
+		"SpanId":         "String",
+	},
+	maps: map[string]struct{}{"LogAttributes": {}, "ResourceAttributes": {}},
+	text: "Body",
+}
+
+var clickhouseTracesSchema = clickhouseSchema{
+	columns: map[string]string{
+		"TraceId":       "String",
+		"SpanId":        "String",
+		"ParentSpanId":  "String",
+		"SpanName":      "String",
+		"SpanKind":      "String",
+		"ServiceName":   "String",
+		"StatusCode":    "String",
+		"StatusMessage": "String",
+		"Duration":      "Int64",
+	},
+	maps: map[string]struct{}{"SpanAttributes": {}, "ResourceAttributes": {}},
+	text: "SpanName",
+}
+
+var clickhouseOperators = map[string]struct{}{
+	"=": {}, "!=": {}, "<>": {}, "<": {}, "<=": {}, ">": {}, ">=": {},
+	"LIKE": {}, "ILIKE": {}, "NOT LIKE": {}, "NOT ILIKE": {},
+}
+
+type clickhouseTokenKind int
+
+const (
+	clickhouseIdent clickhouseTokenKind = iota
+	clickhouseString
+	clickhouseNumber
+	clickhousePunct
+)
+
+type clickhouseToken struct {
+	kind  clickhouseTokenKind
+	text  string
+	value string
+}
+
+// compileClickhouseFilter compiles a user query into a WHERE condition. Queries are not
+// SQL: they are restricted to comparisons of known columns and attributes with literals,
+// combined with AND, OR, NOT and parentheses, e.g.
+//
+//	SeverityText = 'ERROR' AND (Body ILIKE '%timeout%' OR LogAttributes['code'] = '500')
+//
+// A bare string literal searches the text column. Literals are bound as query
+// parameters and everything else is rejected, so queries cannot reach other tables.
+func compileClickhouseFilter(query string, schema clickhouseSchema, params map[string]string) (string, error) {
+	tokens, err := tokenizeClickhouseFilter(query)
+	if err != nil {
+		return "", err
+	}
+	if len(tokens) == 0 {
+		return "", fmt.Errorf("%w: empty query", ErrInvalidArgument)
+	}
+
+	p := &clickhouseFilterParser{tokens: tokens, schema: schema, params: params}
+	condition, err := p.or()
+	if err != nil {
+		return "", err
+	}
+	if p.pos < len(p.tokens) {
+		return "", p.unexpected()
+	}
+
+	return condition, nil
+}
+
+type clickhouseFilterParser struct {
+	tokens []clickhouseToken
+	pos    int
+	bound  int
+	schema clickhouseSchema
+	params map[string]string
+}
+
+func (p *clickhouseFilterParser) or() (string, error) {
+	return p.binary("OR", p.and)
+}
+
+func (p *clickhouseFilterParser) and() (string, error) {
+	return p.binary("AND", p.unary)
+}
+
+func (p *clickhouseFilterParser) binary(keyword string, operand func() (string, error)) (string, error) {
+	left, err := operand()
+	if err != nil {
+		return "", err
+	}
+
+	for p.keyword(keyword) {
+		p.pos++
+		right, err := operand()
+		if err != nil {
+			return "", err
+		}
+		left = fmt.Sprintf("(%s %s %s)", left, keyword, right)
+	}
+
+	return left, nil
+}
+
+func (p *clickhouseFilterParser) unary() (string, error) {
+	if p.keyword("NOT") {
+		p.pos++
+		operand, err := p.unary()
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("(NOT %s)", operand), nil
+	}
+
+	return p.primary()
+}
+
+func (p *clickhouseFilterParser) primary() (string, error) {
+	tok, ok := p.peek()
+	if !ok {
+		return "", fmt.Errorf("%w: unexpected end of query", ErrInvalidArgument)
+	}
+
+	switch {
+	case tok.kind == clickhousePunct && tok.text == "(":
+		p.pos++
+		inner, err := p.or()
+		if err != nil {
+			return "", err
+		}
+		if !p.punct(")") {
+			return "", p.unexpected()
+		}
+		p.pos++
+		return inner, nil
+	case tok.kind == clickhouseString:
+		p.pos++
+		return fmt.Sprintf("positionCaseInsensitive(%s, %s) > 0", p.schema.text, p.bind("String", tok.value)), nil
+	case tok.kind == clickhouseIdent:
+		return p.comparison()
+	}
+
+	return "", p.unexpected()
+}
+
+func (p *clickhouseFilterParser) comparison() (string, error) {
+	field, typ, err := p.field()
+	if err != nil {
+		return "", err
+	}
+
+	op, err := p.operator()
+	if err != nil {
+		return "", err
+	}
+
+	tok, ok := p.peek()
+	if !ok {
+		return "", fmt.Errorf("%w: unexpected end of query", ErrInvalidArgument)
+	}
+	switch {
+	case tok.kind == clickhouseString && typ == "String":
+	case tok.kind == clickhouseNumber && typ != "String" && !strings.HasSuffix(op, "LIKE"):
+	default:
+		return "", fmt.Errorf("%w: invalid value %s for %s", ErrInvalidArgument, tok.text, field)
+	}
+	p.pos++
+
+	return fmt.Sprintf("%s %s %s", field, op, p.bind(typ, tok.value)), nil
+}
+
+// field parses a column or an attribute lookup such as LogAttributes['code']
+func (p *clickhouseFilterParser) field() (string, string, error) {
+	name := p.tokens[p.pos].text
+	p.pos++
+
+	if typ, ok := p.schema.columns[name]; ok {
+		return name, typ, nil
+	}
+	if _, ok := p.schema.maps[name]; !ok {
+		return "", "", fmt.Errorf("%w: unknown field %q", ErrInvalidArgument, name)
+	}
+
+	if !p.punct("[") {
+		return "", "", p.unexpected()
+	}
+	p.pos++
+	key, ok := p.peek()
+	if !ok || key.kind != clickhouseString {
+		return "", "", p.unexpected()
+	}
+	p.pos++
+	if !p.punct("]") {
+		return "", "", p.unexpected()
+	}
+	p.pos++
+
+	return fmt.Sprintf("%s[%s]", name, p.bind("String", key.value)), "String", nil
+}
+
+func (p *clickhouseFilterParser) operator() (string, error) {
+	tok, ok := p.peek()
+	if !ok {
+		return "", fmt.Errorf("%w: unexpected end of query", ErrInvalidArgument)
+	}
+
+	op := strings.ToUpper(tok.text)
+	if op == "NOT" && p.pos+1 < len(p.tokens) {
+		op += " " + strings.ToUpper(p.tokens[p.pos+1].text)
+		p.pos++
+	}
+	if _, ok := clickhouseOperators[op]; !ok || tok.kind == clickhouseString || tok.kind == clickhouseNumber {
+		return "", p.unexpected()
+	}
+	p.pos++
+
+	return op, nil
+}
+
+func (p *clickhouseFilterParser) bind(typ, value string) string {
+	name := fmt.Sprintf("q_%d", p.bound)
+	p.bound++
+	p.params[name] = value
+
+	return fmt.Sprintf("{%s:%s}", name, typ)
+}
+
+func (p *clickhouseFilterParser) peek() (clickhouseToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return clickhouseToken{}, false
+	}
+
+	return p.tokens[p.pos], true
+}
+
+func (p *clickhouseFilterParser) keyword(keyword string) bool {
+	tok, ok := p.peek()
+	return ok && tok.kind == clickhouseIdent && strings.EqualFold(tok.text, keyword)
+}
+
+func (p *clickhouseFilterParser) punct(text string) bool {
+	tok, ok := p.peek()
+	return ok && tok.kind == clickhousePunct && tok.text == text
+}
+
+func (p *clickhouseFilterParser) unexpected() error {
+	tok, ok := p.peek()
+	if !ok {
+		return fmt.Errorf("%w: unexpected end of query", ErrInvalidArgument)
+	}
+
+	return fmt.Errorf("%w: unexpected %q in query", ErrInvalidArgument, tok.text)
+}
+
+func tokenizeClickhouseFilter(query string) ([]clickhouseToken, error) {
+	var tokens []clickhouseToken
+	for pos := 0; pos < len(query); {
+		c := query[pos]
+		start := pos
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			pos++
+		case c == '\'':
+			value, end, err := clickhouseStringLiteral(query, pos)
+			if err != nil {
+				return nil, err
+			}
+			pos = end
+			tokens = append(tokens, clickhouseToken{kind: clickhouseString, text: query[start:pos], value: value})
+		case isASCIIDigit(c) || (c == '-' && pos+1 < len(query) && isASCIIDigit(query[pos+1])):
+			pos++
+			for pos < len(query) && isASCIIDigit(query[pos]) {
+				pos++
+			}
+			tokens = append(tokens, clickhouseToken{kind: clickhouseNumber, text: query[start:pos], value: query[start:pos]})
+		case c == '_' || (c < unicode.MaxASCII && unicode.IsLetter(rune(c))):
+			for pos < len(query) && (query[pos] == '_' || isASCIIDigit(query[pos]) || (query[pos] < unicode.MaxASCII && unicode.IsLetter(rune(query[pos])))) {
+				pos++
+			}
+			tokens = append(tokens, clickhouseToken{kind: clickhouseIdent, text: query[start:pos]})
+		default:
+			text := string(c)
+			if pos+1 < len(query) {
+				if two := query[pos : pos+2]; two == "!=" || two == "<>" || two == "<=" || two == ">=" {
+					text = two
+				}
+			}
+			if !strings.Contains("()[]=<>", text[:1]) && text != "!=" {
+				return nil, fmt.Errorf("%w: unexpected %q in query", ErrInvalidArgument, text)
+			}
+			pos += len(text)
+			tokens = append(tokens, clickhouseToken{kind: clickhousePunct, text: text})
+		}
+	}
+
+	return tokens, nil
+}
+
+// clickhouseStringLiteral reads a single-quoted literal, quotes are escaped with a
+// backslash or doubled
+func clickhouseStringLiteral(query string, start int) (string, int, error) {
+	var b strings.Builder
+	for pos := start + 1; pos < len(query); pos++ {
+		switch c := query[pos]; {
+		case c == '\\' && pos+1 < len(query):
+			pos++
+			b.WriteByte(query[pos])
+		case c == '\'' && pos+1 < len(query) && query[pos+1] == '\'':
+			pos++
+			b.WriteByte('\'')
+		case c == '\'':
+			return b.String(), pos + 1, nil
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return "", 0, fmt.Errorf("%w: unterminated string in query", ErrInvalidArgument)
+}
+
+func isASCIIDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
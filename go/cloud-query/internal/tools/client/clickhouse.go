@@ -0,0 +1,82 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"resty.dev/v3"
+)
+
+type ClickhouseClient struct {
+	*resty.Client
+
+	baseUrl  string
+	database string
+}
+
+// Query runs a read-only SQL query over the HTTP interface. Query parameters are
+// bound server-side to the {name:Type} placeholders used in the query. Rows are
+// returned in the JSONEachRow format.
+func (in *ClickhouseClient) Query(ctx context.Context, query string, params map[string]string) ([]json.RawMessage, error) {
+	response, err := in.R().
+		SetContext(ctx).
+		SetQueryString(in.queryParams(params).Encode()).
+		SetBody(query + " FORMAT JSONEachRow").
+		Post(in.baseUrl)
+	if err != nil {
+		return nil, err
+	}
+	if response.IsError() {
+		return nil, fmt.Errorf("clickhouse query failed: status=%d body=%s", response.StatusCode(), response.String())
+	}
+
+	rows := make([]json.RawMessage, 0)
+	scanner := bufio.NewScanner(strings.NewReader(response.String()))
+	scanner.Buffer(make([]byte, bufio.MaxScanTokenSize), 10*1024*1024) // 10MB
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		rows = append(rows, json.RawMessage(line))
+	}
+
+	return rows, scanner.Err()
+}
+
+func (in *ClickhouseClient) queryParams(params map[string]string) url.Values {
+	values := url.Values{
+		// only allow read queries, the query filter is user provided
+		"readonly": {"1"},
+	}
+
+	if len(in.database) > 0 {
+		values.Set("database", in.database)
+	}
+
+	for name, value := range params {
+		values.Set("param_"+name, value)
+	}
+
+	return values
+}
+
+func NewClickhouseClient(baseUrl, username, password, database string) *ClickhouseClient {
+	client := resty.New()
+
+	if len(username) > 0 {
+		client.SetBasicAuth(username, password)
+	}
+
+	return &ClickhouseClient{
+		Client:   client,
+		baseUrl:  strings.TrimSuffix(baseUrl, "/") + "/",
+		database: database,
+	}
+}
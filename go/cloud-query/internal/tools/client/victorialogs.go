@@ -0,0 +1,110 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"resty.dev/v3"
+)
+
+type VictoriaLogsClient struct {
+	*resty.Client
+
+	baseUrl string
+}
+
+// Logs runs a LogsQL query and returns the matching entries. Every entry is a flat
+// map of fields including the _time and _msg fields.
+func (in *VictoriaLogsClient) Logs(ctx context.Context, query, start, end, limit string, filters map[string]string) ([]map[string]string, error) {
+	params, err := in.logsParams(query, start, end, limit, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := in.R().
+		SetContext(ctx).
+		SetFormDataFromValues(params).
+		Post(in.logsEndpoint())
+	if err != nil {
+		return nil, err
+	}
+	if response.IsError() {
+		return nil, fmt.Errorf("victorialogs query failed: status=%d body=%s", response.StatusCode(), response.String())
+	}
+
+	return in.parseLogs(response.String())
+}
+
+func (in *VictoriaLogsClient) logsEndpoint() string {
+	return strings.TrimSuffix(in.baseUrl, "/") + "/select/logsql/query"
+}
+
+func (in *VictoriaLogsClient) logsParams(query, start, end, limit string, filters map[string]string) (url.Values, error) {
+	params := url.Values{
+		"query": {query},
+		"start": {start},
+		"end":   {end},
+	}
+
+	if len(limit) > 0 {
+		params.Add("limit", limit)
+	}
+
+	if len(filters) > 0 {
+		extra, err := json.Marshal(filters)
+		if err != nil {
+			return nil, err
+		}
+		params.Add("extra_filters", string(extra))
+	}
+
+	return params, nil
+}
+
+// parseLogs parses the JSON lines response of the query endpoint.
+func (in *VictoriaLogsClient) parseLogs(body string) ([]map[string]string, error) {
+	logs := make([]map[string]string, 0)
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	scanner.Buffer(make([]byte, bufio.MaxScanTokenSize), 10*1024*1024) // 10MB
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		entry := map[string]string{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			return nil, fmt.Errorf("invalid victorialogs response line: %w", err)
+		}
+
+		logs = append(logs, entry)
+	}
+
+	return logs, scanner.Err()
+}
+
+func NewVictoriaLogsClient(baseUrl, token, username, password, accountID, projectID string) *VictoriaLogsClient {
+	client := resty.New()
+
+	if len(accountID) > 0 {
+		client.SetHeader("AccountID", accountID)
+	}
+
+	if len(projectID) > 0 {
+		client.SetHeader("ProjectID", projectID)
+	}
+
+	if len(token) > 0 {
+		client.SetAuthToken(token)
+		client.SetAuthScheme("Bearer")
+	} else if len(username) > 0 && len(password) > 0 {
+		client.SetBasicAuth(username, password)
+	}
+
+	return &VictoriaLogsClient{Client: client, baseUrl: baseUrl}
+}
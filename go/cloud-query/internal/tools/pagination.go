@@ -0,0 +1,238 @@
+package tools
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pluralsh/console/go/cloud-query/internal/proto/toolquery"
+)
+
+// logsPaginator is implemented by logs providers that understand page tokens.
+// Providers without it reject any request that carries a page token.
+type logsPaginator interface {
+	paginatesLogs()
+}
+
+// tracesPaginator is the traces counterpart of logsPaginator.
+type tracesPaginator interface {
+	paginatesTraces()
+}
+
+// timeCursor is a keyset cursor for providers that return entries newest-first
+// and honor the range end and limit of a query. The next page is everything
+// at or before Before, minus the first Skip entries sharing that exact
+// timestamp which were already returned.
+type timeCursor struct {
+	Before time.Time `json:"before"`
+	Skip   int       `json:"skip,omitempty"`
+}
+
+func encodePageToken(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodePageToken(token string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return fmt.Errorf("%w: malformed page token", ErrInvalidArgument)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%w: malformed page token", ErrInvalidArgument)
+	}
+
+	return nil
+}
+
+// pageLogsInput narrows the input to the page referenced by its page token.
+// The returned input is a copy and the original is left untouched. A nil
+// cursor means the first page is requested.
+func pageLogsInput(input *toolquery.LogsQueryInput) (*toolquery.LogsQueryInput, *timeCursor, error) {
+	cursor, err := pageCursor(input.GetPageToken(), input.GetLimit())
+	if cursor == nil || err != nil {
+		return input, nil, err
+	}
+
+	paged := proto.CloneOf(input)
+	paged.Range = cursor.timeRange(input.GetRange())
+	paged.Limit = proto.Int32(input.GetLimit() + int32(cursor.Skip))
+	return paged, cursor, nil
+}
+
+// nextLogsPage trims the logs fetched for a paged input down to the requested
+// page and computes the token of the page that follows it, if there may be one.
+func nextLogsPage(input *toolquery.LogsQueryInput, cursor *timeCursor, output *toolquery.LogsQueryOutput) (*toolquery.LogsQueryOutput, error) {
+	if input.GetLimit() <= 0 {
+		return output, nil
+	}
+
+	logs, token, err := nextPage(output.GetLogs(), int(input.GetLimit()), cursor, func(log *toolquery.LogEntry) time.Time {
+		return log.GetTimestamp().AsTime()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	output.Logs = logs
+	output.NextPageToken = token
+	return output, nil
+}
+
+// pageTracesInput is the traces counterpart of pageLogsInput. Depending on the
+// provider the limit counts spans or whole traces.
+func pageTracesInput(input *toolquery.TracesQueryInput) (*toolquery.TracesQueryInput, *timeCursor, error) {
+	cursor, err := pageCursor(input.GetPageToken(), input.GetLimit())
+	if cursor == nil || err != nil {
+		return input, nil, err
+	}
+
+	paged := proto.CloneOf(input)
+	paged.Range = cursor.timeRange(input.GetRange())
+	paged.Limit = proto.Int32(input.GetLimit() + int32(cursor.Skip))
+	return paged, cursor, nil
+}
+
+// nextSpansPage pages through spans by their start time, for providers whose
+// limit counts spans.
+func nextSpansPage(input *toolquery.TracesQueryInput, cursor *timeCursor, output *toolquery.TracesQueryOutput) (*toolquery.TracesQueryOutput, error) {
+	if input.GetLimit() <= 0 {
+		return output, nil
+	}
+
+	spans, token, err := nextPage(output.GetSpans(), int(input.GetLimit()), cursor, func(span *toolquery.TraceSpan) time.Time {
+		return span.GetStart().AsTime()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	output.Spans = spans
+	output.NextPageToken = token
+	return output, nil
+}
+
+// nextTracesPage pages through whole traces by the start time of their earliest
+// span, for providers whose limit counts traces. Spans of a trace are never split
+// across pages.
+func nextTracesPage(input *toolquery.TracesQueryInput, cursor *timeCursor, output *toolquery.TracesQueryOutput) (*toolquery.TracesQueryOutput, error) {
+	if input.GetLimit() <= 0 {
+		return output, nil
+	}
+
+	var traces [][]*toolquery.TraceSpan
+	index := map[string]int{}
+	for _, span := range output.GetSpans() {
+		i, ok := index[span.GetTraceId()]
+		if !ok {
+			i = len(traces)
+			index[span.GetTraceId()] = i
+			traces = append(traces, nil)
+		}
+		traces[i] = append(traces[i], span)
+	}
+
+	traces, token, err := nextPage(traces, int(input.GetLimit()), cursor, func(spans []*toolquery.TraceSpan) time.Time {
+		start := spans[0].GetStart().AsTime()
+		for _, span := range spans[1:] {
+			if span.GetStart().AsTime().Before(start) {
+				start = span.GetStart().AsTime()
+			}
+		}
+		return start
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	output.Spans = slices.Concat(traces...)
+	output.NextPageToken = token
+	return output, nil
+}
+
+// pageCursor decodes a page token, a nil cursor means the first page is requested
+func pageCursor(token string, limit int32) (*timeCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	if limit <= 0 {
+		return nil, fmt.Errorf("%w: page token requires a limit", ErrInvalidArgument)
+	}
+
+	cursor := &timeCursor{}
+	if err := decodePageToken(token, cursor); err != nil {
+		return nil, err
+	}
+
+	return cursor, nil
+}
+
+// timeRange narrows timeRange so it ends at the cursor
+func (in *timeCursor) timeRange(timeRange *toolquery.TimeRange) *toolquery.TimeRange {
+	return &toolquery.TimeRange{
+		Start: timeRange.GetStart(),
+		End:   timestamppb.New(in.Before),
+	}
+}
+
+// nextPage sorts items newest-first, trims them down to the page following cursor
+// and computes the token of the page after it. Items newer than the cursor are
+// dropped as well, for backends that cannot end a range at the exact cursor time.
+func nextPage[T any](items []T, limit int, cursor *timeCursor, at func(T) time.Time) ([]T, *string, error) {
+	skip := 0
+	if cursor != nil {
+		skip = cursor.Skip
+	}
+	full := len(items) >= limit+skip
+
+	slices.SortStableFunc(items, func(a, b T) int {
+		return at(b).Compare(at(a))
+	})
+
+	if cursor != nil {
+		dropped := 0
+		for dropped < len(items) && at(items[dropped]).After(cursor.Before) {
+			dropped++
+		}
+		for skipped := 0; dropped < len(items) && skipped < skip && at(items[dropped]).Equal(cursor.Before); skipped++ {
+			dropped++
+		}
+		items = items[dropped:]
+	}
+
+	if len(items) > limit {
+		items = items[:limit]
+	}
+
+	if !full || len(items) == 0 {
+		return items, nil, nil
+	}
+
+	next := timeCursor{Before: at(items[len(items)-1])}
+	for _, item := range items {
+		if at(item).Equal(next.Before) {
+			next.Skip++
+		}
+	}
+	if cursor != nil && cursor.Before.Equal(next.Before) {
+		next.Skip += cursor.Skip
+	}
+
+	token, err := encodePageToken(next)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return items, &token, nil
+}
@@ -0,0 +1,277 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pluralsh/console/go/cloud-query/internal/proto/toolquery"
+)
+
+// fakeLogsBackend mimics a backend that returns the newest entries up to the
+// inclusive end of the range, capped at the limit.
+func fakeLogsBackend(entries []*toolquery.LogEntry, input *toolquery.LogsQueryInput) *toolquery.LogsQueryOutput {
+	end := input.GetRange().GetEnd().AsTime()
+	logs := make([]*toolquery.LogEntry, 0)
+	for _, entry := range entries {
+		if !entry.GetTimestamp().AsTime().After(end) {
+			logs = append(logs, entry)
+		}
+	}
+
+	slices.SortStableFunc(logs, func(a, b *toolquery.LogEntry) int {
+		return b.GetTimestamp().AsTime().Compare(a.GetTimestamp().AsTime())
+	})
+	if limit := int(input.GetLimit()); len(logs) > limit {
+		logs = logs[:limit]
+	}
+
+	return &toolquery.LogsQueryOutput{Logs: logs}
+}
+
+func TestLogsPaginationReturnsEveryEntryOnce(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	offsets := []int{0, 1, 1, 1, 1, 2, 3, 3, 4, 5, 5, 5}
+	entries := make([]*toolquery.LogEntry, 0, len(offsets))
+	for i, offset := range offsets {
+		entries = append(entries, &toolquery.LogEntry{
+			Timestamp: timestamppb.New(base.Add(time.Duration(offset) * time.Second)),
+			Message:   fmt.Sprintf("log-%d", i),
+		})
+	}
+
+	input := &toolquery.LogsQueryInput{
+		Query: "*",
+		Range: &toolquery.TimeRange{
+			Start: timestamppb.New(base),
+			End:   timestamppb.New(base.Add(time.Minute)),
+		},
+		Limit: proto.Int32(3),
+	}
+
+	seen := map[string]int{}
+	for pages := 0; ; pages++ {
+		if pages > len(entries) {
+			t.Fatalf("pagination did not terminate")
+		}
+
+		paged, cursor, err := pageLogsInput(input)
+		if err != nil {
+			t.Fatalf("failed to page input: %v", err)
+		}
+
+		output, err := nextLogsPage(input, cursor, fakeLogsBackend(entries, paged))
+		if err != nil {
+			t.Fatalf("failed to compute next page: %v", err)
+		}
+		if len(output.GetLogs()) > 3 {
+			t.Fatalf("page exceeds limit: %d", len(output.GetLogs()))
+		}
+
+		for _, log := range output.GetLogs() {
+			seen[log.GetMessage()]++
+		}
+
+		if output.GetNextPageToken() == "" {
+			break
+		}
+		input.PageToken = output.NextPageToken
+	}
+
+	if len(seen) != len(entries) {
+		t.Fatalf("expected %d entries, got %d", len(entries), len(seen))
+	}
+	for message, count := range seen {
+		if count != 1 {
+			t.Fatalf("entry %s returned %d times", message, count)
+		}
+	}
+}
+
+func TestLogsPaginationRejectsMalformedToken(t *testing.T) {
+	_, _, err := pageLogsInput(&toolquery.LogsQueryInput{
+		Limit:     proto.Int32(10),
+		PageToken: proto.String("not a token"),
+	})
+	if !errors.Is(err, ErrInvalidArgument) {
+		t.Fatalf("expected invalid argument error, got %v", err)
+	}
+}
+
+func TestLogsPaginationRequiresLimit(t *testing.T) {
+	token, err := encodePageToken(timeCursor{Before: time.Now()})
+	if err != nil {
+		t.Fatalf("failed to encode token: %v", err)
+	}
+
+	_, _, err = pageLogsInput(&toolquery.LogsQueryInput{PageToken: &token})
+	if !errors.Is(err, ErrInvalidArgument) {
+		t.Fatalf("expected invalid argument error, got %v", err)
+	}
+}
+
+func TestSpansPaginationReturnsEverySpanOnce(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	offsets := []int{0, 1, 1, 1, 2, 3, 3, 4, 5, 5}
+	spans := make([]*toolquery.TraceSpan, 0, len(offsets))
+	for i, offset := range offsets {
+		spans = append(spans, &toolquery.TraceSpan{
+			TraceId: fmt.Sprintf("trace-%d", i),
+			SpanId:  fmt.Sprintf("span-%d", i),
+			Start:   timestamppb.New(base.Add(time.Duration(offset) * time.Second)),
+		})
+	}
+
+	input := &toolquery.TracesQueryInput{
+		Query: "*",
+		Range: &toolquery.TimeRange{Start: timestamppb.New(base), End: timestamppb.New(base.Add(time.Minute))},
+		Limit: proto.Int32(3),
+	}
+
+	seen := paginateTraces(t, input, func(paged *toolquery.TracesQueryInput) []*toolquery.TraceSpan {
+		end := paged.GetRange().GetEnd().AsTime()
+		result := make([]*toolquery.TraceSpan, 0)
+		for _, span := range spans {
+			if !span.GetStart().AsTime().After(end) {
+				result = append(result, span)
+			}
+		}
+		slices.SortStableFunc(result, func(a, b *toolquery.TraceSpan) int {
+			return b.GetStart().AsTime().Compare(a.GetStart().AsTime())
+		})
+		return result[:min(len(result), int(paged.GetLimit()))]
+	}, nextSpansPage)
+
+	if len(seen) != len(spans) {
+		t.Fatalf("expected %d spans, got %d", len(spans), len(seen))
+	}
+}
+
+func TestTracesPaginationKeepsTracesTogether(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	traceStarts := []int{0, 1, 1, 2, 3, 3, 3, 4}
+	var spans []*toolquery.TraceSpan
+	for i, offset := range traceStarts {
+		for j := range 3 {
+			spans = append(spans, &toolquery.TraceSpan{
+				TraceId: fmt.Sprintf("trace-%d", i),
+				SpanId:  fmt.Sprintf("span-%d-%d", i, j),
+				Start:   timestamppb.New(base.Add(time.Duration(offset)*time.Second + time.Duration(j)*time.Millisecond)),
+			})
+		}
+	}
+
+	input := &toolquery.TracesQueryInput{
+		Query: "*",
+		Range: &toolquery.TimeRange{Start: timestamppb.New(base), End: timestamppb.New(base.Add(time.Minute))},
+		Limit: proto.Int32(2),
+	}
+
+	// the backend only knows the start of whole traces and ends the range at whole seconds
+	pages := 0
+	seen := paginateTraces(t, input, func(paged *toolquery.TracesQueryInput) []*toolquery.TraceSpan {
+		pages++
+		end := paged.GetRange().GetEnd().AsTime().Truncate(time.Second).Add(time.Second)
+		var traces []int
+		for i, offset := range traceStarts {
+			if base.Add(time.Duration(offset) * time.Second).Before(end) {
+				traces = append(traces, i)
+			}
+		}
+		slices.SortStableFunc(traces, func(a, b int) int { return traceStarts[b] - traceStarts[a] })
+		traces = traces[:min(len(traces), int(paged.GetLimit()))]
+
+		result := make([]*toolquery.TraceSpan, 0)
+		for _, span := range spans {
+			for _, i := range traces {
+				if span.GetTraceId() == fmt.Sprintf("trace-%d", i) {
+					result = append(result, span)
+				}
+			}
+		}
+		return result
+	}, func(input *toolquery.TracesQueryInput, cursor *timeCursor, output *toolquery.TracesQueryOutput) (*toolquery.TracesQueryOutput, error) {
+		output, err := nextTracesPage(input, cursor, output)
+		if err != nil {
+			return nil, err
+		}
+
+		traces := map[string]int{}
+		for _, span := range output.GetSpans() {
+			traces[span.GetTraceId()]++
+		}
+		for trace, count := range traces {
+			if count != 3 {
+				t.Fatalf("trace %s split across pages: %d spans", trace, count)
+			}
+		}
+		if len(traces) > 2 {
+			t.Fatalf("page exceeds limit: %d traces", len(traces))
+		}
+		return output, nil
+	})
+
+	if len(seen) != len(spans) {
+		t.Fatalf("expected %d spans, got %d", len(spans), len(seen))
+	}
+	if pages < len(traceStarts)/2 {
+		t.Fatalf("expected at least %d pages, got %d", len(traceStarts)/2, pages)
+	}
+}
+
+func paginateTraces(
+	t *testing.T,
+	input *toolquery.TracesQueryInput,
+	backend func(*toolquery.TracesQueryInput) []*toolquery.TraceSpan,
+	next func(*toolquery.TracesQueryInput, *timeCursor, *toolquery.TracesQueryOutput) (*toolquery.TracesQueryOutput, error),
+) map[string]int {
+	t.Helper()
+
+	seen := map[string]int{}
+	for pages := 0; ; pages++ {
+		if pages > 100 {
+			t.Fatalf("pagination did not terminate")
+		}
+
+		paged, cursor, err := pageTracesInput(input)
+		if err != nil {
+			t.Fatalf("failed to page input: %v", err)
+		}
+
+		output, err := next(input, cursor, &toolquery.TracesQueryOutput{Spans: backend(paged)})
+		if err != nil {
+			t.Fatalf("failed to compute next page: %v", err)
+		}
+
+		for _, span := range output.GetSpans() {
+			seen[span.GetSpanId()]++
+			if seen[span.GetSpanId()] > 1 {
+				t.Fatalf("span %s returned more than once", span.GetSpanId())
+			}
+		}
+
+		if output.GetNextPageToken() == "" {
+			return seen
+		}
+		input.PageToken = output.NextPageToken
+	}
+}
+
+func TestDynatracePagedTracesQuery(t *testing.T) {
+	provider := &DynatraceProvider{}
+	before := time.Date(2024, 1, 1, 0, 0, 1, 500, time.UTC)
+
+	if got := provider.pagedTracesQuery("fetch spans", 0, nil); got != "fetch spans" {
+		t.Fatalf("unexpected query without limit: %s", got)
+	}
+
+	want := "fetch spans\n| sort start_time desc\n| filter start_time <= toTimestamp(\"2024-01-01T00:00:01.0000005Z\")\n| limit 12"
+	if got := provider.pagedTracesQuery("fetch spans", 12, &timeCursor{Before: before, Skip: 2}); got != want {
+		t.Fatalf("unexpected paged query: got %q want %q", got, want)
+	}
+}
@@ -3,6 +3,7 @@ package tools
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/pluralsh/console/go/cloud-query/internal/proto/toolquery"
 )
@@ -30,6 +31,8 @@ func newMetricsProvider(conn *toolquery.ToolConnection) (MetricsProvider, error)
 		return NewCloudwatchProvider(provider.Cloudwatch), nil
 	case *toolquery.ToolConnection_Azure:
 		return NewAzureProvider(provider.Azure)
+	case *toolquery.ToolConnection_Mimir:
+		return NewMimirProvider(provider.Mimir), nil
 	default:
 		return nil, nil
 	}
@@ -57,6 +60,10 @@ func newLogsProvider(conn *toolquery.ToolConnection) (LogsProvider, error) {
 		return NewCloudwatchProvider(provider.Cloudwatch), nil
 	case *toolquery.ToolConnection_Azure:
 		return NewAzureProvider(provider.Azure)
+	case *toolquery.ToolConnection_Clickhouse:
+		return NewClickhouseProvider(provider.Clickhouse), nil
+	case *toolquery.ToolConnection_VictoriaLogs:
+		return NewVictoriaLogsProvider(provider.VictoriaLogs), nil
 	default:
 		return nil, nil
 	}
@@ -76,6 +83,8 @@ func newTracesProvider(conn *toolquery.ToolConnection) TracesProvider {
 		return NewDatadogProvider(provider.Datadog)
 	case *toolquery.ToolConnection_Dynatrace:
 		return NewDynatraceProvider(provider.Dynatrace)
+	case *toolquery.ToolConnection_Clickhouse:
+		return NewClickhouseProvider(provider.Clickhouse)
 	default:
 		return nil
 	}
@@ -122,6 +131,10 @@ func (p *toolProvider) Logs(ctx context.Context, input *toolquery.LogsQueryInput
 		return nil, ErrUnsupportedOperation
 	}
 
+	if _, ok := p.logs.(logsPaginator); !ok && input.GetPageToken() != "" {
+		return nil, fmt.Errorf("%w: provider does not support log pagination", ErrInvalidArgument)
+	}
+
 	return p.logs.Logs(ctx, input)
 }
 
@@ -130,6 +143,10 @@ func (p *toolProvider) Traces(ctx context.Context, input *toolquery.TracesQueryI
 		return nil, ErrUnsupportedOperation
 	}
 
+	if _, ok := p.traces.(tracesPaginator); !ok && input.GetPageToken() != "" {
+		return nil, fmt.Errorf("%w: provider does not support trace pagination", ErrInvalidArgument)
+	}
+
 	return p.traces.Traces(ctx, input)
 }
 
@@ -0,0 +1,323 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pluralsh/console/go/cloud-query/internal/proto/toolquery"
+	"github.com/pluralsh/console/go/cloud-query/internal/tools/client"
+)
+
+const (
+	clickhouseDefaultLogsTable   = "otel_logs"
+	clickhouseDefaultTracesTable = "otel_traces"
+	clickhouseDefaultLimit       = 100
+)
+
+// clickhouseLogColumns are top level columns of the OpenTelemetry logs schema
+// that can be used as facets directly, other facets match log and resource attributes.
+var clickhouseLogColumns = map[string]struct{}{
+	"ServiceName":  {},
+	"SeverityText": {},
+	"TraceId":      {},
+	"SpanId":       {},
+}
+
+type clickhouseLogRow struct {
+	Timestamp          json.Number       `json:"TimestampNano"`
+	Body               string            `json:"Body"`
+	SeverityText       string            `json:"SeverityText"`
+	ServiceName        string            `json:"ServiceName"`
+	TraceId            string            `json:"TraceId"`
+	SpanId             string            `json:"SpanId"`
+	ResourceAttributes map[string]string `json:"ResourceAttributes"`
+	LogAttributes      map[string]string `json:"LogAttributes"`
+}
+
+type clickhouseSpanRow struct {
+	Timestamp          json.Number       `json:"TimestampNano"`
+	Duration           json.Number       `json:"Duration"`
+	TraceId            string            `json:"TraceId"`
+	SpanId             string            `json:"SpanId"`
+	ParentSpanId       string            `json:"ParentSpanId"`
+	SpanName           string            `json:"SpanName"`
+	SpanKind           string            `json:"SpanKind"`
+	ServiceName        string            `json:"ServiceName"`
+	StatusCode         string            `json:"StatusCode"`
+	ResourceAttributes map[string]string `json:"ResourceAttributes"`
+	SpanAttributes     map[string]string `json:"SpanAttributes"`
+}
+
+// ClickhouseProvider queries logs and traces stored in ClickHouse using the
+// OpenTelemetry collector exporter schema, as used by e.g. HyperDX and SigNoz.
+// The query is a ClickHouse boolean expression used as a WHERE clause filter.
+type ClickhouseProvider struct {
+	conn *toolquery.ClickhouseConnection
+}
+
+func NewClickhouseProvider(conn *toolquery.ClickhouseConnection) *ClickhouseProvider {
+	return &ClickhouseProvider{conn: conn}
+}
+
+func (in *ClickhouseProvider) Logs(ctx context.Context, input *toolquery.LogsQueryInput) (*toolquery.LogsQueryOutput, error) {
+	if err := in.validate(); err != nil {
+		return nil, err
+	}
+	if input == nil || input.Query == "" {
+		return nil, ErrInvalidArgument
+	}
+
+	paged, cursor, err := pageLogsInput(input)
+	if err != nil {
+		return nil, err
+	}
+
+	params := in.rangeParams(paged.GetRange(), paged.GetLimit())
+	params["table"] = in.table(in.conn.GetLogsTable(), clickhouseDefaultLogsTable)
+
+	where, err := in.where(paged.Query, clickhouseLogsSchema, in.facetFilters(paged.GetFacets(), params), params)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`SELECT toUnixTimestamp64Nano(Timestamp) AS TimestampNano, Body, SeverityText, ServiceName, TraceId, SpanId, ResourceAttributes, LogAttributes
+FROM {table:Identifier}
+WHERE %s
+ORDER BY Timestamp DESC
+LIMIT {limit:UInt32}`, where)
+
+	clickhouse := in.client()
+	defer clickhouse.Close()
+
+	rows, err := clickhouse.Query(ctx, query, params)
+	if err != nil {
+		return nil, err
+	}
+
+	logs := make([]*toolquery.LogEntry, 0, len(rows))
+	for _, raw := range rows {
+		var row clickhouseLogRow
+		if err := json.Unmarshal(raw, &row); err != nil {
+			return nil, err
+		}
+
+		entry, err := row.toLogEntry()
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, entry)
+	}
+
+	return nextLogsPage(input, cursor, &toolquery.LogsQueryOutput{Logs: logs})
+}
+
+func (in *ClickhouseProvider) paginatesLogs() {}
+
+func (in *ClickhouseProvider) Traces(ctx context.Context, input *toolquery.TracesQueryInput) (*toolquery.TracesQueryOutput, error) {
+	if err := in.validate(); err != nil {
+		return nil, err
+	}
+	if input == nil || input.Query == "" {
+		return nil, ErrInvalidArgument
+	}
+
+	paged, cursor, err := pageTracesInput(input)
+	if err != nil {
+		return nil, err
+	}
+
+	params := in.rangeParams(paged.GetRange(), paged.GetLimit())
+	params["table"] = in.table(in.conn.GetTracesTable(), clickhouseDefaultTracesTable)
+
+	where, err := in.where(paged.Query, clickhouseTracesSchema, nil, params)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`SELECT toUnixTimestamp64Nano(Timestamp) AS TimestampNano, Duration, TraceId, SpanId, ParentSpanId, SpanName, SpanKind, ServiceName, StatusCode, ResourceAttributes, SpanAttributes
+FROM {table:Identifier}
+WHERE %s
+ORDER BY Timestamp DESC
+LIMIT {limit:UInt32}`, where)
+
+	clickhouse := in.client()
+	defer clickhouse.Close()
+
+	rows, err := clickhouse.Query(ctx, query, params)
+	if err != nil {
+		return nil, err
+	}
+
+	spans := make([]*toolquery.TraceSpan, 0, len(rows))
+	for _, raw := range rows {
+		var row clickhouseSpanRow
+		if err := json.Unmarshal(raw, &row); err != nil {
+			return nil, err
+		}
+
+		span, err := row.toTraceSpan()
+		if err != nil {
+			return nil, err
+		}
+		spans = append(spans, span)
+	}
+
+	return nextSpansPage(input, cursor, &toolquery.TracesQueryOutput{Spans: spans})
+}
+
+func (in *ClickhouseProvider) paginatesTraces() {}
+
+func (in *ClickhouseProvider) validate() error {
+	if in.conn == nil {
+		return ErrInvalidArgument
+	}
+
+	if len(in.conn.GetUrl()) == 0 {
+		return fmt.Errorf("%w: missing url", ErrInvalidArgument)
+	}
+
+	return nil
+}
+
+func (in *ClickhouseProvider) client() *client.ClickhouseClient {
+	return client.NewClickhouseClient(in.conn.GetUrl(), in.conn.GetUsername(), in.conn.GetPassword(), in.conn.GetDatabase())
+}
+
+func (in *ClickhouseProvider) table(table, fallback string) string {
+	if len(table) == 0 {
+		return fallback
+	}
+
+	return table
+}
+
+func (in *ClickhouseProvider) rangeParams(timeRange *toolquery.TimeRange, limit int32) map[string]string {
+	if limit <= 0 {
+		limit = clickhouseDefaultLimit
+	}
+
+	return map[string]string{
+		"start": strconv.FormatInt(timeRange.GetStart().AsTime().UnixNano(), 10),
+		"end":   strconv.FormatInt(timeRange.GetEnd().AsTime().UnixNano(), 10),
+		"limit": strconv.Itoa(int(limit)),
+	}
+}
+
+// where combines the time range, the facet filters and the user query. A query of
+// "*" matches everything, any other query is compiled by compileClickhouseFilter.
+func (in *ClickhouseProvider) where(query string, schema clickhouseSchema, filters []string, params map[string]string) (string, error) {
+	conditions := []string{
+		"Timestamp >= fromUnixTimestamp64Nano({start:Int64})",
+		"Timestamp <= fromUnixTimestamp64Nano({end:Int64})",
+	}
+	conditions = append(conditions, filters...)
+
+	if query = strings.TrimSpace(query); query != "*" {
+		condition, err := compileClickhouseFilter(query, schema, params)
+		if err != nil {
+			return "", err
+		}
+		conditions = append(conditions, condition)
+	}
+
+	return strings.Join(conditions, " AND "), nil
+}
+
+// facetFilters turns facets into conditions, facet values are bound as query parameters.
+func (in *ClickhouseProvider) facetFilters(facets []*toolquery.LogsQueryFacet, params map[string]string) []string {
+	filters := make([]string, 0, len(facets))
+	for i, facet := range facets {
+		if facet.GetName() == "" {
+			continue
+		}
+
+		name, value := fmt.Sprintf("facet_name_%d", i), fmt.Sprintf("facet_value_%d", i)
+		params[name], params[value] = facet.GetName(), facet.GetValue()
+
+		if _, ok := clickhouseLogColumns[facet.GetName()]; ok {
+			filters = append(filters, fmt.Sprintf("{%s:Identifier} = {%s:String}", name, value))
+			continue
+		}
+
+		filters = append(filters, fmt.Sprintf(
+			"(LogAttributes[{%[1]s:String}] = {%[2]s:String} OR ResourceAttributes[{%[1]s:String}] = {%[2]s:String})",
+			name, value,
+		))
+	}
+
+	return filters
+}
+
+func (in *clickhouseLogRow) toLogEntry() (*toolquery.LogEntry, error) {
+	ts, err := in.Timestamp.Int64()
+	if err != nil {
+		return nil, fmt.Errorf("invalid clickhouse log timestamp %q: %w", in.Timestamp, err)
+	}
+
+	labels := make(map[string]string, len(in.ResourceAttributes)+len(in.LogAttributes)+4)
+	for key, value := range in.ResourceAttributes {
+		labels[key] = value
+	}
+	for key, value := range in.LogAttributes {
+		labels[key] = value
+	}
+	for key, value := range map[string]string{
+		"service":  in.ServiceName,
+		"severity": in.SeverityText,
+		"trace_id": in.TraceId,
+		"span_id":  in.SpanId,
+	} {
+		if len(value) > 0 {
+			labels[key] = value
+		}
+	}
+
+	return &toolquery.LogEntry{
+		Timestamp: timestamppb.New(time.Unix(0, ts)),
+		Message:   in.Body,
+		Labels:    labels,
+	}, nil
+}
+
+func (in *clickhouseSpanRow) toTraceSpan() (*toolquery.TraceSpan, error) {
+	start, err := in.Timestamp.Int64()
+	if err != nil {
+		return nil, fmt.Errorf("invalid clickhouse span timestamp %q: %w", in.Timestamp, err)
+	}
+
+	duration, err := in.Duration.Int64()
+	if err != nil {
+		return nil, fmt.Errorf("invalid clickhouse span duration %q: %w", in.Duration, err)
+	}
+
+	tags := make(map[string]string, len(in.ResourceAttributes)+len(in.SpanAttributes)+2)
+	for key, value := range in.ResourceAttributes {
+		tags[key] = value
+	}
+	for key, value := range in.SpanAttributes {
+		tags[key] = value
+	}
+	if len(in.SpanKind) > 0 {
+		tags["span.kind"] = in.SpanKind
+	}
+	if len(in.StatusCode) > 0 {
+		tags["status.code"] = in.StatusCode
+	}
+
+	return &toolquery.TraceSpan{
+		TraceId:  in.TraceId,
+		SpanId:   in.SpanId,
+		ParentId: in.ParentSpanId,
+		Name:     in.SpanName,
+		Service:  in.ServiceName,
+		Start:    timestamppb.New(time.Unix(0, start)),
+		End:      timestamppb.New(time.Unix(0, start+duration)),
+		Tags:     tags,
+	}, nil
+}
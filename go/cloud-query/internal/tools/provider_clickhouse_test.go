@@ -0,0 +1,273 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pluralsh/console/go/cloud-query/internal/proto/toolquery"
+)
+
+func TestClickhouseProvider_LogsBindsParametersAndParsesRows(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Fatalf("unexpected method: %s", r.Method)
+		}
+
+		q := r.URL.Query()
+		if q.Get("readonly") != "1" {
+			t.Fatalf("expected readonly query, got %q", q.Get("readonly"))
+		}
+		if q.Get("database") != "observability" {
+			t.Fatalf("unexpected database: %q", q.Get("database"))
+		}
+		if q.Get("param_table") != "otel_logs" {
+			t.Fatalf("unexpected table: %q", q.Get("param_table"))
+		}
+		if q.Get("param_limit") != "2" {
+			t.Fatalf("unexpected limit: %q", q.Get("param_limit"))
+		}
+		if q.Get("param_end") != "1704070800000000000" {
+			t.Fatalf("unexpected end: %q", q.Get("param_end"))
+		}
+		if q.Get("param_facet_name_0") != "ServiceName" || q.Get("param_facet_value_0") != "api" {
+			t.Fatalf("unexpected column facet: %v", q)
+		}
+		if q.Get("param_facet_name_1") != "k8s.namespace.name" || q.Get("param_facet_value_1") != "prod" {
+			t.Fatalf("unexpected attribute facet: %v", q)
+		}
+		if q.Get("param_q_0") != "ERROR" {
+			t.Fatalf("unexpected query value: %q", q.Get("param_q_0"))
+		}
+
+		user, password, ok := r.BasicAuth()
+		if !ok || user != "reader" || password != "secret" {
+			t.Fatalf("unexpected basic auth: %s:%s", user, password)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		query := string(body)
+		for _, expected := range []string{
+			"FROM {table:Identifier}",
+			"{facet_name_0:Identifier} = {facet_value_0:String}",
+			"LogAttributes[{facet_name_1:String}] = {facet_value_1:String}",
+			"SeverityText = {q_0:String}",
+			"ORDER BY Timestamp DESC",
+			"FORMAT JSONEachRow",
+		} {
+			if !strings.Contains(query, expected) {
+				t.Fatalf("expected query to contain %q, got %s", expected, query)
+			}
+		}
+
+		_, _ = w.Write([]byte(`{"TimestampNano":"1704067300000000000","Body":"boom","SeverityText":"ERROR","ServiceName":"api","TraceId":"abc","SpanId":"","ResourceAttributes":{"k8s.namespace.name":"prod"},"LogAttributes":{"code":"500"}}
+{"TimestampNano":"1704067200000000000","Body":"bang","SeverityText":"ERROR","ServiceName":"api","TraceId":"","SpanId":"","ResourceAttributes":{},"LogAttributes":{}}
+`))
+	}))
+	defer ts.Close()
+
+	provider := NewClickhouseProvider(&toolquery.ClickhouseConnection{
+		Url:      ts.URL,
+		Username: proto.String("reader"),
+		Password: proto.String("secret"),
+		Database: proto.String("observability"),
+	})
+
+	output, err := provider.Logs(context.Background(), &toolquery.LogsQueryInput{
+		Query: "SeverityText = 'ERROR'",
+		Range: &toolquery.TimeRange{Start: timestamppb.New(start), End: timestamppb.New(end)},
+		Limit: proto.Int32(2),
+		Facets: []*toolquery.LogsQueryFacet{
+			{Name: "ServiceName", Value: "api"},
+			{Name: "k8s.namespace.name", Value: "prod"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(output.GetLogs()) != 2 {
+		t.Fatalf("expected 2 logs, got %d", len(output.GetLogs()))
+	}
+
+	first := output.GetLogs()[0]
+	if first.GetMessage() != "boom" {
+		t.Fatalf("unexpected message: %s", first.GetMessage())
+	}
+	if !first.GetTimestamp().AsTime().Equal(time.Unix(0, 1704067300000000000)) {
+		t.Fatalf("unexpected timestamp: %s", first.GetTimestamp().AsTime())
+	}
+	if first.GetLabels()["service"] != "api" || first.GetLabels()["code"] != "500" || first.GetLabels()["k8s.namespace.name"] != "prod" {
+		t.Fatalf("unexpected labels: %v", first.GetLabels())
+	}
+	if _, ok := first.GetLabels()["span_id"]; ok {
+		t.Fatalf("expected empty span_id label to be dropped: %v", first.GetLabels())
+	}
+	if output.GetNextPageToken() == "" {
+		t.Fatalf("expected next page token for a full page")
+	}
+}
+
+func TestClickhouseProvider_TracesParsesSpans(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("param_table") != "traces" {
+			t.Fatalf("unexpected table: %q", r.URL.Query().Get("param_table"))
+		}
+		if r.URL.Query().Get("param_limit") != "100" {
+			t.Fatalf("expected default limit, got %q", r.URL.Query().Get("param_limit"))
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		if strings.Contains(string(body), "(*)") {
+			t.Fatalf("expected wildcard query to be dropped, got %s", body)
+		}
+
+		_, _ = w.Write([]byte(`{"TimestampNano":"1704067200000000000","Duration":"1500000","TraceId":"t1","SpanId":"s1","ParentSpanId":"p1","SpanName":"GET /","SpanKind":"Server","ServiceName":"web","StatusCode":"Error","ResourceAttributes":{"host":"a"},"SpanAttributes":{"http.status_code":"500"}}
+`))
+	}))
+	defer ts.Close()
+
+	provider := NewClickhouseProvider(&toolquery.ClickhouseConnection{
+		Url:         ts.URL,
+		TracesTable: proto.String("traces"),
+	})
+
+	start := time.Unix(0, 1704067200000000000)
+	output, err := provider.Traces(context.Background(), &toolquery.TracesQueryInput{
+		Query: "*",
+		Range: &toolquery.TimeRange{Start: timestamppb.New(start.Add(-time.Hour)), End: timestamppb.New(start.Add(time.Hour))},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(output.GetSpans()) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(output.GetSpans()))
+	}
+
+	span := output.GetSpans()[0]
+	if span.GetTraceId() != "t1" || span.GetSpanId() != "s1" || span.GetParentId() != "p1" {
+		t.Fatalf("unexpected ids: %v", span)
+	}
+	if span.GetService() != "web" || span.GetName() != "GET /" {
+		t.Fatalf("unexpected service or name: %v", span)
+	}
+	if got := span.GetEnd().AsTime().Sub(span.GetStart().AsTime()); got != 1500*time.Microsecond {
+		t.Fatalf("unexpected duration: %s", got)
+	}
+	if span.GetTags()["span.kind"] != "Server" || span.GetTags()["status.code"] != "Error" || span.GetTags()["host"] != "a" {
+		t.Fatalf("unexpected tags: %v", span.GetTags())
+	}
+}
+
+func TestCompileClickhouseFilter(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		schema    clickhouseSchema
+		condition string
+		params    map[string]string
+	}{
+		{
+			name:      "comparison",
+			query:     "SeverityText = 'ERROR'",
+			schema:    clickhouseLogsSchema,
+			condition: "SeverityText = {q_0:String}",
+			params:    map[string]string{"q_0": "ERROR"},
+		},
+		{
+			name:      "boolean operators",
+			query:     "SeverityText = 'ERROR' and (Body ILIKE '%time''out%' OR not LogAttributes['code'] = '500')",
+			schema:    clickhouseLogsSchema,
+			condition: "(SeverityText = {q_0:String} AND (Body ILIKE {q_1:String} OR (NOT LogAttributes[{q_2:String}] = {q_3:String})))",
+			params:    map[string]string{"q_0": "ERROR", "q_1": "%time'out%", "q_2": "code", "q_3": "500"},
+		},
+		{
+			name:      "free text",
+			query:     "'connection refused'",
+			schema:    clickhouseLogsSchema,
+			condition: "positionCaseInsensitive(Body, {q_0:String}) > 0",
+			params:    map[string]string{"q_0": "connection refused"},
+		},
+		{
+			name:      "numeric column",
+			query:     "Duration >= 1000000 AND SpanName NOT LIKE 'GET %'",
+			schema:    clickhouseTracesSchema,
+			condition: "(Duration >= {q_0:Int64} AND SpanName NOT LIKE {q_1:String})",
+			params:    map[string]string{"q_0": "1000000", "q_1": "GET %"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := map[string]string{}
+			condition, err := compileClickhouseFilter(tt.query, tt.schema, params)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if condition != tt.condition {
+				t.Fatalf("unexpected condition: got %s want %s", condition, tt.condition)
+			}
+			if len(params) != len(tt.params) {
+				t.Fatalf("unexpected params: got %v want %v", params, tt.params)
+			}
+			for key, value := range tt.params {
+				if params[key] != value {
+					t.Fatalf("unexpected params: got %v want %v", params, tt.params)
+				}
+			}
+		})
+	}
+}
+
+func TestCompileClickhouseFilter_RejectsSQL(t *testing.T) {
+	for _, query := range []string{
+		"SeverityText = 'ERROR') UNION SELECT name FROM system.tables --",
+		"SeverityText = 'ERROR' UNION SELECT 1",
+		"Body IN (SELECT name FROM system.tables)",
+		"system.tables",
+		"SeverityText = (SELECT password FROM system.users)",
+		"ServiceName = 'api'; DROP TABLE otel_logs",
+		"lower(Body) = 'boom'",
+		"Password = 'secret'",
+		"SpanAttributes['code'] = '500'",
+		"SeverityText = ServiceName",
+		"SeverityNumber = 'x'",
+		"Body = 'unterminated",
+		"(SeverityText = 'ERROR'",
+	} {
+		t.Run(query, func(t *testing.T) {
+			params := map[string]string{}
+			if condition, err := compileClickhouseFilter(query, clickhouseLogsSchema, params); !errors.Is(err, ErrInvalidArgument) {
+				t.Fatalf("expected invalid argument, got %q, %v", condition, err)
+			}
+		})
+	}
+}
+
+func TestClickhouseProvider_LogsRejectsInjectedQuery(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("unexpected request to clickhouse")
+	}))
+	defer ts.Close()
+
+	provider := NewClickhouseProvider(&toolquery.ClickhouseConnection{Url: ts.URL})
+	_, err := provider.Logs(context.Background(), &toolquery.LogsQueryInput{
+		Query: "1 = 1) UNION ALL SELECT name FROM system.tables WHERE (1 = 1",
+		Range: &toolquery.TimeRange{Start: timestamppb.Now(), End: timestamppb.Now()},
+	})
+	if !errors.Is(err, ErrInvalidArgument) {
+		t.Fatalf("expected invalid argument, got %v", err)
+	}
+}
@@ -160,9 +160,14 @@ func (in *DatadogProvider) Logs(ctx context.Context, input *toolquery.LogsQueryI
 	request := datadogV2.NewLogsListRequest()
 	request.SetFilter(*filter)
 
-	if input.GetLimit() > 0 {
+	if input.GetLimit() > 0 || input.GetPageToken() != "" {
 		page := datadogV2.NewLogsListRequestPage()
-		page.SetLimit(input.GetLimit())
+		if input.GetLimit() > 0 {
+			page.SetLimit(input.GetLimit())
+		}
+		if input.GetPageToken() != "" {
+			page.SetCursor(input.GetPageToken())
+		}
 		request.SetPage(*page)
 	}
 
@@ -172,9 +177,19 @@ func (in *DatadogProvider) Logs(ctx context.Context, input *toolquery.LogsQueryI
 		return nil, err
 	}
 
-	return in.toLogsQueryOutput(resp), nil
+	output := in.toLogsQueryOutput(resp)
+	meta := resp.GetMeta()
+	page := meta.GetPage()
+	if after := page.GetAfter(); after != "" {
+		output.NextPageToken = &after
+	}
+
+	return output, nil
 }
 
+// paginatesLogs marks logs as paginated, page tokens are datadog's own cursors.
+func (in *DatadogProvider) paginatesLogs() {}
+
 func (in *DatadogProvider) toLogsQueryOutput(resp datadogV2.LogsListResponse) *toolquery.LogsQueryOutput {
 	logs := make([]*toolquery.LogEntry, 0, len(resp.GetData()))
 
@@ -227,9 +242,14 @@ func (in *DatadogProvider) Traces(ctx context.Context, input *toolquery.TracesQu
 	attrs := datadogV2.NewSpansListRequestAttributes()
 	attrs.SetFilter(*filter)
 
-	if input.GetLimit() > 0 {
+	if input.GetLimit() > 0 || input.GetPageToken() != "" {
 		page := datadogV2.NewSpansListRequestPage()
-		page.SetLimit(input.GetLimit())
+		if input.GetLimit() > 0 {
+			page.SetLimit(input.GetLimit())
+		}
+		if input.GetPageToken() != "" {
+			page.SetCursor(input.GetPageToken())
+		}
 		attrs.SetPage(*page)
 	}
 
@@ -248,9 +268,19 @@ func (in *DatadogProvider) Traces(ctx context.Context, input *toolquery.TracesQu
 		return nil, err
 	}
 
-	return in.toTraceQueryOutput(resp), nil
+	output := in.toTraceQueryOutput(resp)
+	meta := resp.GetMeta()
+	page := meta.GetPage()
+	if after := page.GetAfter(); after != "" {
+		output.NextPageToken = &after
+	}
+
+	return output, nil
 }
 
+// paginatesTraces marks traces as paginated, page tokens are datadog's own cursors.
+func (in *DatadogProvider) paginatesTraces() {}
+
 func (in *DatadogProvider) toTraceQueryOutput(resp datadogV2.SpansListResponse) *toolquery.TracesQueryOutput {
 	spans := make([]*toolquery.TraceSpan, 0, len(resp.GetData()))
 
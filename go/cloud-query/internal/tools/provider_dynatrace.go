@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/pluralsh/console/go/cloud-query/internal/proto/toolquery"
 	"github.com/pluralsh/console/go/cloud-query/internal/tools/client"
@@ -108,17 +109,39 @@ func (in *DynatraceProvider) Traces(ctx context.Context, input *toolquery.Traces
 		return nil, err
 	}
 
+	paged, cursor, err := pageTracesInput(input)
+	if err != nil {
+		return nil, err
+	}
+
 	resp, err := in.client.Traces(
 		ctx,
-		input.GetQuery(),
+		in.pagedTracesQuery(paged.GetQuery(), paged.GetLimit(), cursor),
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	return resp.ToTracesQueryOutput(), nil
+	return nextSpansPage(input, cursor, resp.ToTracesQueryOutput())
 }
 
+// pagedTracesQuery appends the pipes selecting a page of spans to a query, newest first
+func (in *DynatraceProvider) pagedTracesQuery(query string, limit int32, cursor *timeCursor) string {
+	if limit <= 0 {
+		return query
+	}
+
+	query += "\n| sort start_time desc"
+	if cursor != nil {
+		query += fmt.Sprintf("\n| filter start_time <= toTimestamp(%q)", cursor.Before.UTC().Format(time.RFC3339Nano))
+	}
+
+	return query + fmt.Sprintf("\n| limit %d", limit)
+}
+
+// paginatesTraces marks traces as paginated, the limit is applied with a limit pipe.
+func (in *DynatraceProvider) paginatesTraces() {}
+
 func (in *DynatraceProvider) validateTracesInput(input *toolquery.TracesQueryInput) error {
 	if !strings.HasPrefix(input.GetQuery(), "fetch spans") {
 		return fmt.Errorf("invalid query: must start with 'fetch spans'")
@@ -128,9 +151,5 @@ func (in *DynatraceProvider) validateTracesInput(input *toolquery.TracesQueryInp
 		return fmt.Errorf("unsupported use of 'range', use 'interval' directly in the query. Example: 'fetch spans, from:-1h'")
 	}
 
-	if input.GetLimit() > 0 {
-		return fmt.Errorf("unsupported use of 'limit', use 'limit' pipe directly in the query. Example: 'fetch spans | limit 100'")
-	}
-
 	return nil
 }
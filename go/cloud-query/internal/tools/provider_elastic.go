@@ -11,6 +11,7 @@ import (
 	"github.com/elastic/go-elasticsearch/v9/typedapi/core/search"
 	"github.com/elastic/go-elasticsearch/v9/typedapi/esdsl"
 	"github.com/elastic/go-elasticsearch/v9/typedapi/types"
+	"github.com/elastic/go-elasticsearch/v9/typedapi/types/enums/sortorder"
 	"github.com/samber/lo"
 
 	"github.com/pluralsh/console/go/cloud-query/internal/proto/toolquery"
@@ -44,19 +45,31 @@ func (in *ElasticProvider) Logs(ctx context.Context, input *toolquery.LogsQueryI
 		return nil, ErrInvalidArgument
 	}
 
+	paged, cursor, err := pageLogsInput(input)
+	if err != nil {
+		return nil, err
+	}
+
 	resp, err := in.client.Search().
 		Index(in.conn.GetIndex()).
 		Header("Accept", "application/json").
 		Header("Content-Type", "application/json").
-		Request(in.toRequest(input)).
+		Request(in.toRequest(paged)).
 		Do(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return in.toLogsQueryOutput(resp)
+	output, err := in.toLogsQueryOutput(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return nextLogsPage(input, cursor, output)
 }
 
+func (in *ElasticProvider) paginatesLogs() {}
+
 func (in *ElasticProvider) toLogsQueryOutput(resp *search.Response) (*toolquery.LogsQueryOutput, error) {
 	logs := make([]*toolquery.LogEntry, 0)
 
@@ -121,6 +134,12 @@ func (in *ElasticProvider) toRequest(input *toolquery.LogsQueryInput) *search.Re
 		},
 	}
 
+	request.Sort = []types.SortCombinations{
+		types.SortOptions{SortOptions: map[string]types.FieldSort{
+			"@timestamp": {Order: &sortorder.Desc},
+		}},
+	}
+
 	if input.GetLimit() > 0 {
 		request.Size = lo.ToPtr(int(input.GetLimit()))
 	}
@@ -27,6 +27,11 @@ func (in *JaegerProvider) Traces(ctx context.Context, input *toolquery.TracesQue
 		return nil, fmt.Errorf("%w: query must be set to service_name", ErrInvalidArgument)
 	}
 
+	paged, cursor, err := pageTracesInput(input)
+	if err != nil {
+		return nil, err
+	}
+
 	jaegerClient := client.NewJaegerClient(
 		in.conn.GetUrl(),
 		in.conn.GetToken(),
@@ -35,14 +40,23 @@ func (in *JaegerProvider) Traces(ctx context.Context, input *toolquery.TracesQue
 	)
 	defer jaegerClient.Close()
 
-	resp, err := jaegerClient.Traces(ctx, in.toJaegerQuery(input))
+	query := in.toJaegerQuery(paged)
+	if cursor != nil {
+		// the cursor is nanosecond precise, seconds would drop traces at the page boundary
+		query.StartTimeMax = cursor.Before.UTC().Format(time.RFC3339Nano)
+	}
+
+	resp, err := jaegerClient.Traces(ctx, query)
 	if err != nil {
 		return nil, err
 	}
 
-	return resp.ToTracesQueryOutput(), nil
+	return nextTracesPage(input, cursor, resp.ToTracesQueryOutput())
 }
 
+// paginatesTraces marks traces as paginated, the limit counts traces rather than spans.
+func (in *JaegerProvider) paginatesTraces() {}
+
 func (in *JaegerProvider) toJaegerQuery(input *toolquery.TracesQueryInput) datasource.JaegerTraceQuery {
 	q := datasource.JaegerTraceQuery{
 		ServiceName: strings.TrimSpace(input.GetQuery()),
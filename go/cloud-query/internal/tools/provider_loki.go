@@ -29,22 +29,40 @@ func (in *LokiProvider) Logs(ctx context.Context, input *toolquery.LogsQueryInpu
 		return nil, ErrInvalidArgument
 	}
 
+	paged, cursor, err := pageLogsInput(input)
+	if err != nil {
+		return nil, err
+	}
+
 	client := client.NewLokiClient(in.conn.GetUrl(), in.conn.GetToken(), in.conn.GetUsername(), in.conn.GetPassword(), in.conn.GetTenantId())
 	defer client.Close()
 
+	end := paged.GetRange().GetEnd().AsTime().UnixNano()
+	if cursor != nil {
+		// loki treats the end of the range as exclusive, include the entries at the cursor
+		end++
+	}
+
 	resp, err := client.Logs(
 		ctx,
-		mergeLokiQueryWithFacets(input.Query, input.GetFacets()),
-		strconv.FormatInt(input.GetRange().GetStart().AsTime().UnixNano(), 10),
-		strconv.FormatInt(input.GetRange().GetEnd().AsTime().UnixNano(), 10),
-		strconv.Itoa(int(input.GetLimit())))
+		mergeLokiQueryWithFacets(paged.Query, paged.GetFacets()),
+		strconv.FormatInt(paged.GetRange().GetStart().AsTime().UnixNano(), 10),
+		strconv.FormatInt(end, 10),
+		strconv.Itoa(int(paged.GetLimit())))
 	if err != nil {
 		return nil, err
 	}
 
-	return in.toLogsQueryOutput(resp)
+	output, err := in.toLogsQueryOutput(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return nextLogsPage(input, cursor, output)
 }
 
+func (in *LokiProvider) paginatesLogs() {}
+
 func (in *LokiProvider) toLogsQueryOutput(resp *client.LokiLogsResponse) (*toolquery.LogsQueryOutput, error) {
 	logs := make([]*toolquery.LogEntry, 0)
 
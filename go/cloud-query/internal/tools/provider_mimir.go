@@ -0,0 +1,42 @@
+package tools
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/pluralsh/console/go/cloud-query/internal/proto/toolquery"
+)
+
+// mimirPrometheusPrefix is the path under which Mimir serves the Prometheus HTTP API.
+const mimirPrometheusPrefix = "/prometheus"
+
+// NewMimirProvider returns a metrics provider for Grafana Mimir. Mimir exposes
+// the Prometheus HTTP API, so all queries are delegated to the Prometheus provider.
+func NewMimirProvider(conn *toolquery.MimirConnection) MetricsProvider {
+	return NewPrometheusProvider(&toolquery.PrometheusConnection{
+		Url:      mimirPrometheusURL(conn.GetUrl()),
+		Token:    conn.Token,
+		TenantId: conn.TenantId,
+		Username: conn.Username,
+		Password: conn.Password,
+	})
+}
+
+func mimirPrometheusURL(rawURL string) string {
+	if len(rawURL) == 0 {
+		return rawURL
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	path := strings.TrimSuffix(parsed.Path, "/")
+	if !strings.HasSuffix(path, mimirPrometheusPrefix) {
+		path += mimirPrometheusPrefix
+	}
+
+	parsed.Path = path
+	return parsed.String()
+}
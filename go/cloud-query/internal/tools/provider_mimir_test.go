@@ -0,0 +1,17 @@
+package tools
+
+import "testing"
+
+func TestMimirPrometheusURL(t *testing.T) {
+	for input, expected := range map[string]string{
+		"https://mimir.example.com":             "https://mimir.example.com/prometheus",
+		"https://mimir.example.com/":            "https://mimir.example.com/prometheus",
+		"https://mimir.example.com/prometheus":  "https://mimir.example.com/prometheus",
+		"https://mimir.example.com/prometheus/": "https://mimir.example.com/prometheus",
+		"":                                      "",
+	} {
+		if got := mimirPrometheusURL(input); got != expected {
+			t.Fatalf("mimirPrometheusURL(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+}
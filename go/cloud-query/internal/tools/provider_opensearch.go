@@ -50,7 +50,12 @@ func (in *OpensearchProvider) Logs(ctx context.Context, input *toolquery.LogsQue
 		return nil, ErrInvalidArgument
 	}
 
-	body, err := json.Marshal((&ElasticProvider{}).toRequest(input))
+	paged, cursor, err := pageLogsInput(input)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal((&ElasticProvider{}).toRequest(paged))
 	if err != nil {
 		return nil, err
 	}
@@ -78,9 +83,16 @@ func (in *OpensearchProvider) Logs(ctx context.Context, input *toolquery.LogsQue
 		return nil, err
 	}
 
-	return result.toLogsQueryOutput()
+	output, err := result.toLogsQueryOutput()
+	if err != nil {
+		return nil, err
+	}
+
+	return nextLogsPage(input, cursor, output)
 }
 
+func (in *OpensearchProvider) paginatesLogs() {}
+
 func (in *OpensearchProvider) searchURL() string {
 	return fmt.Sprintf("%s/%s/_search", strings.TrimRight(in.conn.GetHost(), "/"), strings.TrimLeft(in.conn.GetIndex(), "/"))
 }
@@ -58,6 +58,11 @@ func (in *SplunkProvider) Logs(ctx context.Context, input *toolquery.LogsQueryIn
 		return nil, fmt.Errorf("%w: missing auth (token or username/password required)", ErrInvalidArgument)
 	}
 
+	paged, cursor, err := pageLogsInput(input)
+	if err != nil {
+		return nil, err
+	}
+
 	client := client.NewSplunkClient(
 		in.conn.GetUrl(),
 		in.conn.GetToken(),
@@ -66,19 +71,31 @@ func (in *SplunkProvider) Logs(ctx context.Context, input *toolquery.LogsQueryIn
 	)
 	defer client.Close()
 
-	body, err := client.ExportSearch(ctx, in.queryParams(input))
+	body, err := client.ExportSearch(ctx, in.queryParams(paged, cursor))
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := in.toLogsQueryOutput(body)
 	if err != nil {
 		return nil, err
 	}
 
-	return in.toLogsQueryOutput(body)
+	return nextLogsPage(input, cursor, output)
 }
 
-func (in *SplunkProvider) queryParams(input *toolquery.LogsQueryInput) url.Values {
+func (in *SplunkProvider) paginatesLogs() {}
+
+func (in *SplunkProvider) queryParams(input *toolquery.LogsQueryInput, cursor *timeCursor) url.Values {
+	latest := input.GetRange().GetEnd().AsTime()
+	if cursor != nil {
+		// latest_time is exclusive, bump it so that entries at the cursor are included
+		latest = latest.Add(time.Microsecond)
+	}
 	values := url.Values{
 		"search":        {splunkSearchWithFacets(input.Query, input.GetLimit(), input.GetFacets())},
 		"earliest_time": {in.toSplunkTime(input.GetRange().GetStart().AsTime())},
-		"latest_time":   {in.toSplunkTime(input.GetRange().GetEnd().AsTime())},
+		"latest_time":   {in.toSplunkTime(latest)},
 		"output_mode":   {"json"},
 	}
 
@@ -24,18 +24,28 @@ func (in *TempoProvider) Traces(ctx context.Context, input *toolquery.TracesQuer
 		return nil, ErrInvalidArgument
 	}
 
+	paged, cursor, err := pageTracesInput(input)
+	if err != nil {
+		return nil, err
+	}
+
 	client := client.NewTempoClient(in.conn.GetUrl(), in.conn.GetToken(), in.conn.GetUsername(), in.conn.GetPassword(), in.conn.GetTenantId())
 	defer client.Close()
 
 	limit := ""
-	if input.GetLimit() > 0 {
-		limit = strconv.Itoa(int(input.GetLimit()))
+	if paged.GetLimit() > 0 {
+		limit = strconv.Itoa(int(paged.GetLimit()))
+	}
+	end := paged.GetRange().GetEnd().AsTime().Unix()
+	if cursor != nil && cursor.Before.Nanosecond() > 0 {
+		// tempo only accepts seconds, round up so traces at the page boundary are included
+		end++
 	}
 	searchResp, err := client.Search(
 		ctx,
-		input.Query,
-		strconv.FormatInt(input.Range.GetStart().AsTime().Unix(), 10),
-		strconv.FormatInt(input.Range.GetEnd().AsTime().Unix(), 10),
+		paged.Query,
+		strconv.FormatInt(paged.GetRange().GetStart().AsTime().Unix(), 10),
+		strconv.FormatInt(end, 10),
 		limit,
 	)
 	if err != nil {
@@ -55,5 +65,8 @@ func (in *TempoProvider) Traces(ctx context.Context, input *toolquery.TracesQuer
 		spans = append(spans, traceResp.ToTraceSpans()...)
 	}
 
-	return &toolquery.TracesQueryOutput{Spans: spans}, nil
+	return nextTracesPage(input, cursor, &toolquery.TracesQueryOutput{Spans: spans})
 }
+
+// paginatesTraces marks traces as paginated, the limit counts traces rather than spans.
+func (in *TempoProvider) paginatesTraces() {}
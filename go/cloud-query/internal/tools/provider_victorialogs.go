@@ -0,0 +1,117 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pluralsh/console/go/cloud-query/internal/proto/toolquery"
+	"github.com/pluralsh/console/go/cloud-query/internal/tools/client"
+)
+
+const (
+	victoriaLogsTimeField    = "_time"
+	victoriaLogsMessageField = "_msg"
+)
+
+type VictoriaLogsProvider struct {
+	conn *toolquery.VictoriaLogsConnection
+}
+
+func NewVictoriaLogsProvider(conn *toolquery.VictoriaLogsConnection) LogsProvider {
+	return &VictoriaLogsProvider{conn: conn}
+}
+
+func (in *VictoriaLogsProvider) Logs(ctx context.Context, input *toolquery.LogsQueryInput) (*toolquery.LogsQueryOutput, error) {
+	if in.conn == nil {
+		return nil, ErrInvalidArgument
+	}
+	if len(in.conn.GetUrl()) == 0 {
+		return nil, fmt.Errorf("%w: missing url", ErrInvalidArgument)
+	}
+	if input == nil || input.Query == "" {
+		return nil, ErrInvalidArgument
+	}
+
+	paged, cursor, err := pageLogsInput(input)
+	if err != nil {
+		return nil, err
+	}
+
+	client := client.NewVictoriaLogsClient(
+		in.conn.GetUrl(),
+		in.conn.GetToken(),
+		in.conn.GetUsername(),
+		in.conn.GetPassword(),
+		in.conn.GetAccountId(),
+		in.conn.GetProjectId(),
+	)
+	defer client.Close()
+
+	limit := ""
+	if paged.GetLimit() > 0 {
+		limit = strconv.Itoa(int(paged.GetLimit()))
+	}
+
+	entries, err := client.Logs(
+		ctx,
+		paged.Query,
+		paged.GetRange().GetStart().AsTime().UTC().Format(time.RFC3339Nano),
+		paged.GetRange().GetEnd().AsTime().UTC().Format(time.RFC3339Nano),
+		limit,
+		in.toFilters(paged.GetFacets()))
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := in.toLogsQueryOutput(entries)
+	if err != nil {
+		return nil, err
+	}
+
+	return nextLogsPage(input, cursor, output)
+}
+
+func (in *VictoriaLogsProvider) paginatesLogs() {}
+
+func (in *VictoriaLogsProvider) toFilters(facets []*toolquery.LogsQueryFacet) map[string]string {
+	filters := map[string]string{}
+	for _, facet := range facets {
+		if facet.GetName() == "" {
+			continue
+		}
+		filters[facet.GetName()] = facet.GetValue()
+	}
+
+	return filters
+}
+
+func (in *VictoriaLogsProvider) toLogsQueryOutput(entries []map[string]string) (*toolquery.LogsQueryOutput, error) {
+	logs := make([]*toolquery.LogEntry, 0, len(entries))
+
+	for _, entry := range entries {
+		ts, err := time.Parse(time.RFC3339Nano, entry[victoriaLogsTimeField])
+		if err != nil {
+			return nil, fmt.Errorf("invalid victorialogs timestamp %q: %w", entry[victoriaLogsTimeField], err)
+		}
+
+		labels := make(map[string]string, len(entry))
+		for key, value := range entry {
+			if key == victoriaLogsTimeField || key == victoriaLogsMessageField || len(value) == 0 {
+				continue
+			}
+			labels[key] = value
+		}
+
+		logs = append(logs, &toolquery.LogEntry{
+			Timestamp: timestamppb.New(ts),
+			Message:   entry[victoriaLogsMessageField],
+			Labels:    labels,
+		})
+	}
+
+	return &toolquery.LogsQueryOutput{Logs: logs}, nil
+}
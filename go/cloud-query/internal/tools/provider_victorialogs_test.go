@@ -0,0 +1,80 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pluralsh/console/go/cloud-query/internal/proto/toolquery"
+)
+
+func TestVictoriaLogsProvider_LogsSendsTenantAndFacets(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/select/logsql/query" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Header.Get("AccountID") != "12" || r.Header.Get("ProjectID") != "34" {
+			t.Fatalf("unexpected tenant headers: %v", r.Header)
+		}
+		if r.Header.Get("Authorization") != "Bearer token" {
+			t.Fatalf("unexpected authorization: %s", r.Header.Get("Authorization"))
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("failed to parse form: %v", err)
+		}
+		if r.PostForm.Get("query") != "error" {
+			t.Fatalf("unexpected query: %s", r.PostForm.Get("query"))
+		}
+		if r.PostForm.Get("limit") != "10" {
+			t.Fatalf("unexpected limit: %s", r.PostForm.Get("limit"))
+		}
+		if r.PostForm.Get("extra_filters") != `{"namespace":"prod"}` {
+			t.Fatalf("unexpected extra_filters: %s", r.PostForm.Get("extra_filters"))
+		}
+
+		_, _ = w.Write([]byte(`{"_time":"2024-01-01T00:00:01Z","_msg":"older","_stream":"{app=\"api\"}","namespace":"prod"}
+{"_time":"2024-01-01T00:00:02.5Z","_msg":"newer","_stream":"{app=\"api\"}","namespace":"prod","empty":""}
+`))
+	}))
+	defer ts.Close()
+
+	provider := NewVictoriaLogsProvider(&toolquery.VictoriaLogsConnection{
+		Url:       ts.URL,
+		Token:     proto.String("token"),
+		AccountId: proto.String("12"),
+		ProjectId: proto.String("34"),
+	})
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	output, err := provider.Logs(context.Background(), &toolquery.LogsQueryInput{
+		Query:  "error",
+		Range:  &toolquery.TimeRange{Start: timestamppb.New(start), End: timestamppb.New(start.Add(time.Hour))},
+		Limit:  proto.Int32(10),
+		Facets: []*toolquery.LogsQueryFacet{{Name: "namespace", Value: "prod"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(output.GetLogs()) != 2 {
+		t.Fatalf("expected 2 logs, got %d", len(output.GetLogs()))
+	}
+	if output.GetLogs()[0].GetMessage() != "newer" {
+		t.Fatalf("expected newest log first, got %s", output.GetLogs()[0].GetMessage())
+	}
+	labels := output.GetLogs()[0].GetLabels()
+	if labels["namespace"] != "prod" || labels["_stream"] != `{app="api"}` {
+		t.Fatalf("unexpected labels: %v", labels)
+	}
+	if _, ok := labels["empty"]; ok {
+		t.Fatalf("expected empty label to be dropped: %v", labels)
+	}
+	if output.GetNextPageToken() != "" {
+		t.Fatalf("expected no next page token for a partial page")
+	}
+}
@@ -66,8 +66,8 @@ type InfrastructureStackSpec struct {
 	Name *string `json:"name,omitempty"`
 
 	// Type specifies the IaC tool to use for executing the stack.
-	// One of TERRAFORM, TERRAGRUNT, PULUMI, ANSIBLE, CUSTOM.
-	// +kubebuilder:validation:Enum=TERRAFORM;TERRAGRUNT;PULUMI;ANSIBLE;CUSTOM
+	// One of TERRAFORM, OPENTOFU, CDKTF, TERRAGRUNT, PULUMI, ANSIBLE, CUSTOM.
+	// +kubebuilder:validation:Enum=TERRAFORM;OPENTOFU;CDKTF;TERRAGRUNT;PULUMI;ANSIBLE;CUSTOM
 	// +kubebuilder:validation:Required
 	Type console.StackType `json:"type"`
 
@@ -498,8 +498,8 @@ type ObservableMetric struct {
 // PolicyEngine defines configuration for applying policy enforcement to a stack.
 type PolicyEngine struct {
 	// Type of the policy engine to use with this stack.
-	// At the moment only TRIVY is supported.
-	// +kubebuilder:validation:Enum=TRIVY
+	// One of TRIVY, CHECKOV, CONFTEST.
+	// +kubebuilder:validation:Enum=TRIVY;CHECKOV;CONFTEST
 	// +kubebuilder:validation:Required
 	Type console.PolicyEngineType `json:"type"`
 
@@ -384,7 +384,7 @@ type Renderer struct {
 
 	// Type is the type of renderer to use.
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:Enum=AUTO;RAW;HELM;KUSTOMIZE
+	// +kubebuilder:validation:Enum=AUTO;RAW;HELM;KUSTOMIZE;JSONNET;CUE;KCL
 	Type console.RendererType `json:"type"`
 
 	// Helm is the Helm configuration to use for this renderer, use if `path` points to a helm chart and you want to override the default helm configuration.
@@ -0,0 +1,56 @@
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAgentRun_EffectiveBudget(t *testing.T) {
+	t.Run("nil without any budget", func(t *testing.T) {
+		run := &AgentRun{}
+		if budget := run.EffectiveBudget(&AgentRuntime{}); budget != nil {
+			t.Fatalf("expected nil budget, got %+v", budget)
+		}
+	})
+
+	t.Run("uses runtime budget", func(t *testing.T) {
+		run := &AgentRun{}
+		runtime := &AgentRuntime{
+			Spec: AgentRuntimeSpec{
+				Budget: &AgentBudget{MaxCost: lo.ToPtr("10"), MaxTokens: lo.ToPtr(int64(1000))},
+			},
+		}
+
+		budget := run.EffectiveBudget(runtime)
+		if lo.FromPtr(budget.MaxCost) != "10" || lo.FromPtr(budget.MaxTokens) != 1000 || budget.MaxDuration != nil {
+			t.Fatalf("unexpected budget %+v", budget)
+		}
+	})
+
+	t.Run("run limits take precedence", func(t *testing.T) {
+		run := &AgentRun{
+			Spec: AgentRunSpec{
+				Budget: &AgentBudget{MaxCost: lo.ToPtr("2.5"), MaxDuration: &metav1.Duration{Duration: time.Hour}},
+			},
+		}
+		runtime := &AgentRuntime{
+			Spec: AgentRuntimeSpec{
+				Budget: &AgentBudget{MaxCost: lo.ToPtr("10"), MaxTokens: lo.ToPtr(int64(1000))},
+			},
+		}
+
+		budget := run.EffectiveBudget(runtime)
+		if lo.FromPtr(budget.MaxCost) != "2.5" {
+			t.Fatalf("expected run max cost, got %q", lo.FromPtr(budget.MaxCost))
+		}
+		if lo.FromPtr(budget.MaxTokens) != 1000 {
+			t.Fatalf("expected runtime max tokens, got %d", lo.FromPtr(budget.MaxTokens))
+		}
+		if budget.MaxDuration == nil || budget.MaxDuration.Duration != time.Hour {
+			t.Fatalf("expected run max duration, got %v", budget.MaxDuration)
+		}
+	})
+}
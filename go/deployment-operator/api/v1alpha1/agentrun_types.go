@@ -45,6 +45,11 @@ type AgentRunSpec struct {
 	// Deprecated: No longer used for image selection. Enable dind on the AgentRuntime instead.
 	// +kubebuilder:validation:Optional
 	LanguageVersion *string `json:"languageVersion,omitempty"`
+
+	// Budget limits the spend, tokens and wall-clock time of this agent run.
+	// Limits set here take precedence over the ones set on the AgentRuntime.
+	// +kubebuilder:validation:Optional
+	Budget *AgentBudget `json:"budget,omitempty"`
 }
 
 // AgentRunStatus defines the observed state of AgentRun
@@ -188,6 +193,40 @@ func (in *AgentRun) EnsureLabels(agentRuntimeName, agentRunID string) {
 	}
 }
 
+// EffectiveBudget merges the budget of the run with the budget of its runtime.
+// Limits set on the run take precedence over the runtime ones.
+func (in *AgentRun) EffectiveBudget(runtime *AgentRuntime) *AgentBudget {
+	var runtimeBudget *AgentBudget
+	if runtime != nil {
+		runtimeBudget = runtime.Spec.Budget
+	}
+
+	if in.Spec.Budget == nil && runtimeBudget == nil {
+		return nil
+	}
+
+	result := &AgentBudget{}
+	for _, budget := range []*AgentBudget{runtimeBudget, in.Spec.Budget} {
+		if budget == nil {
+			continue
+		}
+
+		if budget.MaxCost != nil {
+			result.MaxCost = budget.MaxCost
+		}
+
+		if budget.MaxTokens != nil {
+			result.MaxTokens = budget.MaxTokens
+		}
+
+		if budget.MaxDuration != nil {
+			result.MaxDuration = budget.MaxDuration
+		}
+	}
+
+	return result
+}
+
 func (in *AgentRun) GetAgentRunID() string {
 	if in.Status.HasID() {
 		return in.Status.GetID()
@@ -34,8 +34,8 @@ type AgentRuntimeSpec struct {
 	TargetNamespace string `json:"targetNamespace"`
 
 	// Type specifies the agent runtime to use for executing the stack.
-	// One of CLAUDE, OPENCODE, GEMINI, CODEX, PI, CUSTOM.
-	// +kubebuilder:validation:Enum=CLAUDE;OPENCODE;GEMINI;CODEX;PI;CUSTOM
+	// One of CLAUDE, OPENCODE, GEMINI, CODEX, PI, AIDER, GOOSE, CUSTOM.
+	// +kubebuilder:validation:Enum=CLAUDE;OPENCODE;GEMINI;CODEX;PI;AIDER;GOOSE;CUSTOM
 	// +kubebuilder:validation:Required
 	Type console.AgentRuntimeType `json:"type"`
 
@@ -60,7 +60,7 @@ type AgentRuntimeSpec struct {
 	//
 	// When only a bare model id is given, the harness may prefix it by runtime type:
 	//   - CLAUDE: anthropic/{model} (for example claude-sonnet-4-5 -> anthropic/claude-sonnet-4-5)
-	//   - CODEX, OPENCODE: openai/{model} (for example gpt-5.4 -> openai/gpt-5.4)
+	//   - CODEX, OPENCODE, AIDER, GOOSE: openai/{model} (for example gpt-5.4 -> openai/gpt-5.4)
 	//   - GEMINI: vertex/{model}
 	//   - CUSTOM: no automatic prefix; use provider/name explicitly
 	AiProxy *bool `json:"aiProxy,omitempty"`
@@ -117,6 +117,30 @@ type AgentRuntimeSpec struct {
 
 	// ExaConnection enables Exa web search and content retrieval tools on the Plural MCP server.
 	ExaConnection *ExaConnection `json:"exaConnection,omitempty"`
+
+	// Budget limits the spend, tokens and wall-clock time of every agent run on this runtime.
+	// Limits set on the AgentRun take precedence over the ones set here.
+	// +kubebuilder:validation:Optional
+	Budget *AgentBudget `json:"budget,omitempty"`
+}
+
+// AgentBudget limits the resources a single agent run can consume.
+// When any of the limits is exceeded, the agent is stopped and the run is marked as failed.
+type AgentBudget struct {
+	// MaxCost is the maximum total cost of a single agent run, i.e. "5.50".
+	// It is compared with the cost reported by the agent CLI, in the same currency.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// +kubebuilder:validation:Optional
+	MaxCost *string `json:"maxCost,omitempty"`
+
+	// MaxTokens is the maximum total number of tokens a single agent run can consume.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	MaxTokens *int64 `json:"maxTokens,omitempty"`
+
+	// MaxDuration is the maximum wall-clock time of a single agent run, i.e. "2h".
+	// +kubebuilder:validation:Optional
+	MaxDuration *metav1.Duration `json:"maxDuration,omitempty"`
 }
 
 type ExaConnection struct {
@@ -246,6 +270,14 @@ type AgentRuntimeConfig struct {
 	// Pi config for Pi coding-agent CLI runtime.
 	// +kubebuilder:validation:Optional
 	Pi *PiConfig `json:"pi,omitempty"`
+
+	// Aider config for Aider CLI runtime.
+	// +kubebuilder:validation:Optional
+	Aider *AiderConfig `json:"aider,omitempty"`
+
+	// Goose config for Goose CLI runtime.
+	// +kubebuilder:validation:Optional
+	Goose *GooseConfig `json:"goose,omitempty"`
 }
 
 func (in *AgentRuntimeConfig) ToAgentRuntimeConfigRaw(secretGetter func(corev1.SecretKeySelector) (*corev1.Secret, error), aiProxy bool) (*AgentRuntimeConfigRaw, error) {
@@ -277,12 +309,24 @@ func (in *AgentRuntimeConfig) ToAgentRuntimeConfigRaw(secretGetter func(corev1.S
 		return nil, err
 	}
 
+	aider, err := in.Aider.ToAiderConfigRaw(secretGetter)
+	if err != nil {
+		return nil, err
+	}
+
+	goose, err := in.Goose.ToGooseConfigRaw(secretGetter)
+	if err != nil {
+		return nil, err
+	}
+
 	return &AgentRuntimeConfigRaw{
 		Gemini:   gemini,
 		Claude:   claude,
 		OpenCode: openCode,
 		Codex:    codex,
 		Pi:       pi,
+		Aider:    aider,
+		Goose:    goose,
 	}, nil
 }
 
@@ -311,6 +355,14 @@ type AgentRuntimeConfigRaw struct {
 	// Pi is the raw configuration for the Pi runtime.
 	// +kubebuilder:validation:Optional
 	Pi *PiConfigRaw `json:"pi,omitempty"`
+
+	// Aider is the raw configuration for the Aider runtime.
+	// +kubebuilder:validation:Optional
+	Aider *AiderConfigRaw `json:"aider,omitempty"`
+
+	// Goose is the raw configuration for the Goose runtime.
+	// +kubebuilder:validation:Optional
+	Goose *GooseConfigRaw `json:"goose,omitempty"`
 }
 
 func (in *ExaConnection) ToExaConnectionRaw(secretGetter func(corev1.SecretKeySelector) (*corev1.Secret, error)) (*ExaConnectionRaw, error) {
@@ -427,6 +479,115 @@ type PiConfigRaw struct {
 	Timeout  *metav1.Duration `json:"timeout,omitempty"`
 }
 
+// AiderConfig configures the Aider CLI runtime.
+type AiderConfig struct {
+	// APIKeySecretRef references an API key. Optional with aiProxy enabled.
+	// +kubebuilder:validation:Optional
+	APIKeySecretRef *corev1.SecretKeySelector `json:"apiKeySecretRef,omitempty"`
+
+	// Model is the model id to use, i.e. gpt-5.4.
+	// +kubebuilder:validation:Optional
+	Model *string `json:"model,omitempty"`
+
+	// Endpoint overrides the OpenAI-compatible provider base URL.
+	// +kubebuilder:validation:Optional
+	Endpoint *string `json:"endpoint,omitempty"`
+
+	// Timeout bounds a single Aider invocation.
+	// +kubebuilder:validation:Optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+func (in *AiderConfig) ToAiderConfigRaw(secretGetter func(corev1.SecretKeySelector) (*corev1.Secret, error)) (*AiderConfigRaw, error) {
+	if in == nil {
+		return nil, nil
+	}
+	result := &AiderConfigRaw{
+		Model:    in.Model,
+		Endpoint: in.Endpoint,
+		Timeout:  in.Timeout,
+	}
+	if !secretKeySelectorSet(in.APIKeySecretRef) {
+		return result, nil
+	}
+	secret, err := secretGetter(*in.APIKeySecretRef)
+	if err != nil {
+		return nil, err
+	}
+	value, exists := secret.Data[in.APIKeySecretRef.Key]
+	if !exists {
+		return nil, fmt.Errorf("API key secret does not contain key %s", in.APIKeySecretRef.Key)
+	}
+	result.APIKey = string(value)
+	return result, nil
+}
+
+// AiderConfigRaw contains resolved credentials and configuration for Aider.
+type AiderConfigRaw struct {
+	APIKey   string           `json:"apiKey,omitempty"`
+	Model    *string          `json:"model,omitempty"`
+	Endpoint *string          `json:"endpoint,omitempty"`
+	Timeout  *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// GooseConfig configures the Goose CLI runtime.
+type GooseConfig struct {
+	// APIKeySecretRef references an API key. Optional with aiProxy enabled.
+	// +kubebuilder:validation:Optional
+	APIKeySecretRef *corev1.SecretKeySelector `json:"apiKeySecretRef,omitempty"`
+
+	// Provider is Goose's provider id. Defaults to openai.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=openai;anthropic
+	Provider *string `json:"provider,omitempty"`
+
+	// Model is the model id to use.
+	// +kubebuilder:validation:Optional
+	Model *string `json:"model,omitempty"`
+
+	// Endpoint overrides the provider base URL.
+	// +kubebuilder:validation:Optional
+	Endpoint *string `json:"endpoint,omitempty"`
+
+	// Timeout bounds a single Goose invocation.
+	// +kubebuilder:validation:Optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+func (in *GooseConfig) ToGooseConfigRaw(secretGetter func(corev1.SecretKeySelector) (*corev1.Secret, error)) (*GooseConfigRaw, error) {
+	if in == nil {
+		return nil, nil
+	}
+	result := &GooseConfigRaw{
+		Provider: in.Provider,
+		Model:    in.Model,
+		Endpoint: in.Endpoint,
+		Timeout:  in.Timeout,
+	}
+	if !secretKeySelectorSet(in.APIKeySecretRef) {
+		return result, nil
+	}
+	secret, err := secretGetter(*in.APIKeySecretRef)
+	if err != nil {
+		return nil, err
+	}
+	value, exists := secret.Data[in.APIKeySecretRef.Key]
+	if !exists {
+		return nil, fmt.Errorf("API key secret does not contain key %s", in.APIKeySecretRef.Key)
+	}
+	result.APIKey = string(value)
+	return result, nil
+}
+
+// GooseConfigRaw contains resolved credentials and configuration for Goose.
+type GooseConfigRaw struct {
+	APIKey   string           `json:"apiKey,omitempty"`
+	Provider *string          `json:"provider,omitempty"`
+	Model    *string          `json:"model,omitempty"`
+	Endpoint *string          `json:"endpoint,omitempty"`
+	Timeout  *metav1.Duration `json:"timeout,omitempty"`
+}
+
 type CodexConfig struct {
 	// ApiKeySecretRef references a Secret containing the Codex API key.
 	// Optional when aiProxy is enabled; authentication uses the Console deploy token instead.
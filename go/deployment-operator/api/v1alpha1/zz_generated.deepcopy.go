@@ -54,6 +54,36 @@ func (in *AWSProviderCredentials) DeepCopy() *AWSProviderCredentials {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentBudget) DeepCopyInto(out *AgentBudget) {
+	*out = *in
+	if in.MaxCost != nil {
+		in, out := &in.MaxCost, &out.MaxCost
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxTokens != nil {
+		in, out := &in.MaxTokens, &out.MaxTokens
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxDuration != nil {
+		in, out := &in.MaxDuration, &out.MaxDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentBudget.
+func (in *AgentBudget) DeepCopy() *AgentBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AgentConfiguration) DeepCopyInto(out *AgentConfiguration) {
 	*out = *in
@@ -307,6 +337,11 @@ func (in *AgentRunSpec) DeepCopyInto(out *AgentRunSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Budget != nil {
+		in, out := &in.Budget, &out.Budget
+		*out = new(AgentBudget)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentRunSpec.
@@ -430,6 +465,16 @@ func (in *AgentRuntimeConfig) DeepCopyInto(out *AgentRuntimeConfig) {
 		*out = new(PiConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Aider != nil {
+		in, out := &in.Aider, &out.Aider
+		*out = new(AiderConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Goose != nil {
+		in, out := &in.Goose, &out.Goose
+		*out = new(GooseConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentRuntimeConfig.
@@ -470,6 +515,16 @@ func (in *AgentRuntimeConfigRaw) DeepCopyInto(out *AgentRuntimeConfigRaw) {
 		*out = new(PiConfigRaw)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Aider != nil {
+		in, out := &in.Aider, &out.Aider
+		*out = new(AiderConfigRaw)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Goose != nil {
+		in, out := &in.Goose, &out.Goose
+		*out = new(GooseConfigRaw)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentRuntimeConfigRaw.
@@ -617,6 +672,11 @@ func (in *AgentRuntimeSpec) DeepCopyInto(out *AgentRuntimeSpec) {
 		*out = new(ExaConnection)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Budget != nil {
+		in, out := &in.Budget, &out.Budget
+		*out = new(AgentBudget)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentRuntimeSpec.
@@ -629,6 +689,71 @@ func (in *AgentRuntimeSpec) DeepCopy() *AgentRuntimeSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AiderConfig) DeepCopyInto(out *AiderConfig) {
+	*out = *in
+	if in.APIKeySecretRef != nil {
+		in, out := &in.APIKeySecretRef, &out.APIKeySecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Model != nil {
+		in, out := &in.Model, &out.Model
+		*out = new(string)
+		**out = **in
+	}
+	if in.Endpoint != nil {
+		in, out := &in.Endpoint, &out.Endpoint
+		*out = new(string)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AiderConfig.
+func (in *AiderConfig) DeepCopy() *AiderConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AiderConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AiderConfigRaw) DeepCopyInto(out *AiderConfigRaw) {
+	*out = *in
+	if in.Model != nil {
+		in, out := &in.Model, &out.Model
+		*out = new(string)
+		**out = **in
+	}
+	if in.Endpoint != nil {
+		in, out := &in.Endpoint, &out.Endpoint
+		*out = new(string)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AiderConfigRaw.
+func (in *AiderConfigRaw) DeepCopy() *AiderConfigRaw {
+	if in == nil {
+		return nil
+	}
+	out := new(AiderConfigRaw)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Binding) DeepCopyInto(out *Binding) {
 	*out = *in
@@ -1442,6 +1567,81 @@ func (in *GitSpec) DeepCopy() *GitSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GooseConfig) DeepCopyInto(out *GooseConfig) {
+	*out = *in
+	if in.APIKeySecretRef != nil {
+		in, out := &in.APIKeySecretRef, &out.APIKeySecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Provider != nil {
+		in, out := &in.Provider, &out.Provider
+		*out = new(string)
+		**out = **in
+	}
+	if in.Model != nil {
+		in, out := &in.Model, &out.Model
+		*out = new(string)
+		**out = **in
+	}
+	if in.Endpoint != nil {
+		in, out := &in.Endpoint, &out.Endpoint
+		*out = new(string)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GooseConfig.
+func (in *GooseConfig) DeepCopy() *GooseConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GooseConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GooseConfigRaw) DeepCopyInto(out *GooseConfigRaw) {
+	*out = *in
+	if in.Provider != nil {
+		in, out := &in.Provider, &out.Provider
+		*out = new(string)
+		**out = **in
+	}
+	if in.Model != nil {
+		in, out := &in.Model, &out.Model
+		*out = new(string)
+		**out = **in
+	}
+	if in.Endpoint != nil {
+		in, out := &in.Endpoint, &out.Endpoint
+		*out = new(string)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GooseConfigRaw.
+func (in *GooseConfigRaw) DeepCopy() *GooseConfigRaw {
+	if in == nil {
+		return nil
+	}
+	out := new(GooseConfigRaw)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HelmConfiguration) DeepCopyInto(out *HelmConfiguration) {
 	*out = *in
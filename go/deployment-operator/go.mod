@@ -39,6 +39,7 @@ require (
 	github.com/gobuffalo/flect v1.0.3
 	github.com/google/gnostic-models v0.7.0
 	github.com/google/go-github/v68 v68.0.0
+	github.com/google/go-jsonnet v0.22.0
 	github.com/grafana/pyroscope-go v1.2.7
 	github.com/hashicorp/terraform-json v0.27.2
 	github.com/hasura/go-graphql-client v0.16.0
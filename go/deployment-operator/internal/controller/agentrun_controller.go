@@ -68,11 +68,24 @@ const (
 	EnvPiProvider = "PLRL_PI_PROVIDER"
 	EnvPiEndpoint = "PLRL_PI_ENDPOINT"
 
+	EnvAiderModel    = "PLRL_AIDER_MODEL"
+	EnvAiderAPIKey   = "PLRL_AIDER_API_KEY"
+	EnvAiderEndpoint = "PLRL_AIDER_ENDPOINT"
+
+	EnvGooseModel    = "PLRL_GOOSE_MODEL"
+	EnvGooseAPIKey   = "PLRL_GOOSE_API_KEY"
+	EnvGooseProvider = "PLRL_GOOSE_PROVIDER"
+	EnvGooseEndpoint = "PLRL_GOOSE_ENDPOINT"
+
 	EnvDindEnabled    = "PLRL_DIND_ENABLED"
 	EnvBrowserEnabled = "PLRL_BROWSER_ENABLED"
 	EnvMemoryEnabled  = "PLRL_MEMORY_ENABLED"
 	EnvExecTimeout    = "PLRL_EXEC_TIMEOUT"
 
+	EnvBudgetMaxCost     = "PLRL_BUDGET_MAX_COST"
+	EnvBudgetMaxTokens   = "PLRL_BUDGET_MAX_TOKENS"
+	EnvBudgetMaxDuration = "PLRL_BUDGET_MAX_DURATION"
+
 	EnvGitProxy = "PLRL_GIT_PROXY"
 
 	EnvExaConnection   = "PLRL_EXA_CONNECTION"
@@ -86,6 +99,8 @@ var (
 		console.AgentRunStatusFailed,
 		console.AgentRunStatusCancelled,
 	}
+
+	budgetKeys = []string{EnvBudgetMaxCost, EnvBudgetMaxTokens, EnvBudgetMaxDuration}
 )
 
 // AgentRunReconciler is a controller for the AgentRun custom resource.
@@ -482,6 +497,9 @@ func (r *AgentRunReconciler) reconcilePodSecret(ctx context.Context, run *v1alph
 		}
 	}
 
+	budget := getBudgetData(run.EffectiveBudget(runtime))
+	data := lo.Assign(r.getSecretData(run, config, runtime.Spec.Type, signingKey, exaConnection), budget)
+
 	secret := &corev1.Secret{}
 	if err := r.Get(ctx, types.NamespacedName{Name: run.Name, Namespace: run.Namespace}, secret); err != nil {
 		if !errors.IsNotFound(err) {
@@ -490,7 +508,7 @@ func (r *AgentRunReconciler) reconcilePodSecret(ctx context.Context, run *v1alph
 
 		secret = &corev1.Secret{
 			ObjectMeta: metav1.ObjectMeta{Name: run.Name, Namespace: run.Namespace},
-			StringData: r.getSecretData(run, config, runtime.Spec.Type, signingKey, exaConnection),
+			StringData: data,
 		}
 
 		logger.V(2).Info("creating secret", "namespace", secret.Namespace, "name", secret.Name)
@@ -501,9 +519,13 @@ func (r *AgentRunReconciler) reconcilePodSecret(ctx context.Context, run *v1alph
 		return secret, nil
 	}
 
-	if !r.hasSecretData(secret.Data, run) {
+	if !r.hasSecretData(secret.Data, run) || !hasBudgetData(secret.Data, budget) {
 		logger.V(2).Info("updating secret", "namespace", secret.Namespace, "name", secret.Name)
-		secret.StringData = r.getSecretData(run, config, runtime.Spec.Type, signingKey, exaConnection)
+		// string data is merged into data, drop budget limits that were removed
+		for _, key := range budgetKeys {
+			delete(secret.Data, key)
+		}
+		secret.StringData = data
 		if err := r.Update(ctx, secret); err != nil {
 			logger.Error(err, "unable to update secret")
 			return nil, err
@@ -671,10 +693,73 @@ func (r *AgentRunReconciler) getSecretData(run *v1alpha1.AgentRun, config *v1alp
 			result[EnvPiEndpoint] = lo.FromPtr(config.Pi.Endpoint)
 		}
 	}
+	if runtimeType == console.AgentRuntimeTypeAider {
+		if config.Aider == nil {
+			return result
+		}
+		result[EnvAiderModel] = lo.FromPtr(config.Aider.Model)
+		result[EnvAiderAPIKey] = config.Aider.APIKey
+		if config.Aider.Timeout != nil {
+			result[EnvExecTimeout] = config.Aider.Timeout.Duration.String()
+		}
+		if config.Aider.Endpoint != nil {
+			result[EnvAiderEndpoint] = lo.FromPtr(config.Aider.Endpoint)
+		}
+	}
+	if runtimeType == console.AgentRuntimeTypeGoose {
+		if config.Goose == nil {
+			return result
+		}
+		result[EnvGooseModel] = lo.FromPtr(config.Goose.Model)
+		result[EnvGooseAPIKey] = config.Goose.APIKey
+		result[EnvGooseProvider] = lo.FromPtr(config.Goose.Provider)
+		if config.Goose.Timeout != nil {
+			result[EnvExecTimeout] = config.Goose.Timeout.Duration.String()
+		}
+		if config.Goose.Endpoint != nil {
+			result[EnvGooseEndpoint] = lo.FromPtr(config.Goose.Endpoint)
+		}
+	}
 
 	return result
 }
 
+// getBudgetData returns the budget limits of the agent run as harness environment variables.
+func getBudgetData(budget *v1alpha1.AgentBudget) map[string]string {
+	result := make(map[string]string)
+	if budget == nil {
+		return result
+	}
+
+	if budget.MaxCost != nil {
+		result[EnvBudgetMaxCost] = *budget.MaxCost
+	}
+
+	if budget.MaxTokens != nil {
+		result[EnvBudgetMaxTokens] = strconv.FormatInt(*budget.MaxTokens, 10)
+	}
+
+	if budget.MaxDuration != nil {
+		result[EnvBudgetMaxDuration] = budget.MaxDuration.Duration.String()
+	}
+
+	return result
+}
+
+// hasBudgetData checks that the secret holds exactly the given budget limits, so budget
+// changes of a running agent are propagated to its secret.
+func hasBudgetData(data map[string][]byte, budget map[string]string) bool {
+	for _, key := range budgetKeys {
+		value, ok := data[key]
+		expected, expectedOk := budget[key]
+		if ok != expectedOk || string(value) != expected {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (r *AgentRunReconciler) hasSecretData(data map[string][]byte, run *v1alpha1.AgentRun) bool {
 	token, hasToken := data[EnvDeployToken]
 	url, hasUrl := data[EnvConsoleURL]
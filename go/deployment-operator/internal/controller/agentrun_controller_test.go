@@ -758,6 +758,31 @@ var _ = Describe("AgentRun Controller", Ordered, func() {
 			Expect(reconciler.hasSecretData(wrongRunIDData, run)).Should(BeFalse())
 		})
 
+		It("should detect budget changes in secret data", func() {
+			budget := getBudgetData(&v1alpha1.AgentBudget{MaxCost: lo.ToPtr("10"), MaxTokens: lo.ToPtr(int64(1000))})
+			secretData := map[string][]byte{
+				EnvBudgetMaxCost:   []byte("10"),
+				EnvBudgetMaxTokens: []byte("1000"),
+			}
+			Expect(hasBudgetData(secretData, budget)).Should(BeTrue())
+
+			// Changed limit
+			Expect(hasBudgetData(secretData, getBudgetData(&v1alpha1.AgentBudget{MaxCost: lo.ToPtr("20"), MaxTokens: lo.ToPtr(int64(1000))}))).Should(BeFalse())
+
+			// Removed limit
+			Expect(hasBudgetData(secretData, getBudgetData(&v1alpha1.AgentBudget{MaxCost: lo.ToPtr("10")}))).Should(BeFalse())
+
+			// Added limit
+			Expect(hasBudgetData(secretData, getBudgetData(&v1alpha1.AgentBudget{
+				MaxCost:     lo.ToPtr("10"),
+				MaxTokens:   lo.ToPtr(int64(1000)),
+				MaxDuration: &metav1.Duration{Duration: time.Hour},
+			}))).Should(BeFalse())
+
+			// No budget
+			Expect(hasBudgetData(map[string][]byte{}, getBudgetData(nil))).Should(BeTrue())
+		})
+
 		It("should include Claude config in secret data", func() {
 			reconciler := &AgentRunReconciler{
 				ConsoleURL:  "https://console.test.com",
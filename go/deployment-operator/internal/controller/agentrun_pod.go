@@ -124,6 +124,8 @@ var (
 		console.AgentRuntimeTypeOpencode: "%s-opencode-1.17.3",
 		console.AgentRuntimeTypeCodex:    "%s-codex-0.104.0",
 		console.AgentRuntimeTypePi:       "%s-pi-0.84.1",
+		console.AgentRuntimeTypeAider:    "%s-aider-0.86.1",
+		console.AgentRuntimeTypeGoose:    "%s-goose-1.9.3",
 	}
 
 	defaultBrowserImages = map[v1alpha1.Browser]string{
@@ -320,6 +320,9 @@ func (r *AgentRuntimeReconciler) createAgentRun(ctx context.Context, agentRuntim
 			Mode:            run.Mode,
 			Language:        run.Language,
 			LanguageVersion: run.LanguageVersion,
+			// Runs are created from the console, which has no per-run budget, so the run
+			// keeps the runtime budget it was started with.
+			Budget: agentRuntime.Spec.Budget.DeepCopy(),
 		},
 	}
 	if run.Flow != nil {
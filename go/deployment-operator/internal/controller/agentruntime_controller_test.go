@@ -8,7 +8,12 @@ import (
 	. "github.com/onsi/gomega"
 	console "github.com/pluralsh/console/go/client"
 	"github.com/pluralsh/console/go/deployment-operator/pkg/test/mocks"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/mock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/pluralsh/console/go/deployment-operator/api/v1alpha1"
 )
 
 var _ = Describe("AgentRuntime Controller", func() {
@@ -46,4 +51,37 @@ var _ = Describe("AgentRuntime Controller", func() {
 			Expect(err).To(MatchError(ContainSubstring(gqlErr.Error())))
 		})
 	})
+
+	Describe("createAgentRun", func() {
+		It("copies the runtime budget into the agent run spec", func() {
+			ctx := context.Background()
+			agentRuntime := &v1alpha1.AgentRuntime{
+				ObjectMeta: metav1.ObjectMeta{Name: "budget-runtime"},
+				Spec: v1alpha1.AgentRuntimeSpec{
+					Type:            console.AgentRuntimeTypeClaude,
+					TargetNamespace: "default",
+					Budget: &v1alpha1.AgentBudget{
+						MaxCost:   lo.ToPtr("5.50"),
+						MaxTokens: lo.ToPtr(int64(100000)),
+					},
+				},
+			}
+
+			reconciler := &AgentRuntimeReconciler{Client: kClient}
+			Expect(reconciler.createAgentRun(ctx, agentRuntime, &console.AgentRunFragment{
+				ID:         "budget-run",
+				Prompt:     "fix it",
+				Repository: "https://github.com/test/repo",
+				Mode:       console.AgentRunModeAnalyze,
+			})).To(Succeed())
+
+			agentRun := &v1alpha1.AgentRun{}
+			Expect(kClient.Get(ctx, types.NamespacedName{Name: "budget-run", Namespace: "default"}, agentRun)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(kClient.Delete(ctx, agentRun)).To(Succeed())
+			})
+
+			Expect(agentRun.Spec.Budget).To(Equal(agentRuntime.Spec.Budget))
+		})
+	})
 })
@@ -31,6 +31,8 @@ var (
 		console.StackTypeAnsible:    "latest",
 		console.StackTypeTerragrunt: "1.8",
 		console.StackTypePulumi:     "3.251",
+		console.StackTypeOpentofu:   "1.10",
+		console.StackTypeCdktf:      "0.21",
 	}
 
 	stackRunDefaultJobVolume = corev1.Volume{
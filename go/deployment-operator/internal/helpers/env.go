@@ -82,6 +82,38 @@ func GetPluralEnvDuration(key string, fallback time.Duration) time.Duration {
 	return fallback
 }
 
+// GetPluralEnvInt64 retrieves an integer from an environment variable prefixed with EnvPrefix.
+// Returns the parsed integer or a fallback if parsing fails or the environment variable is not set.
+func GetPluralEnvInt64(key string, fallback int64) int64 {
+	if v := GetPluralEnv(key, ""); len(v) > 0 {
+		result, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			klog.Errorf("failed to parse %s as integer: %s", v, err)
+			return fallback
+		}
+
+		return result
+	}
+
+	return fallback
+}
+
+// GetPluralEnvFloat retrieves a float from an environment variable prefixed with EnvPrefix.
+// Returns the parsed float or a fallback if parsing fails or the environment variable is not set.
+func GetPluralEnvFloat(key string, fallback float64) float64 {
+	if v := GetPluralEnv(key, ""); len(v) > 0 {
+		result, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			klog.Errorf("failed to parse %s as float: %s", v, err)
+			return fallback
+		}
+
+		return result
+	}
+
+	return fallback
+}
+
 func ParseIntOrDie(value string) int {
 	result, err := strconv.ParseInt(value, 10, 32)
 	if err != nil {
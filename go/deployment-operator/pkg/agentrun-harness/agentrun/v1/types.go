@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/samber/lo"
@@ -8,6 +9,7 @@ import (
 	console "github.com/pluralsh/console/go/client"
 	"github.com/pluralsh/console/go/deployment-operator/internal/controller"
 	"github.com/pluralsh/console/go/deployment-operator/internal/helpers"
+	"github.com/pluralsh/console/go/deployment-operator/pkg/harness/errors"
 )
 
 const (
@@ -59,6 +61,17 @@ type AgentRun struct {
 	ApprovedAt      *string
 	Followup        bool
 	FollowupPrURL   string
+
+	// Budget limits the resources this agent run can consume.
+	Budget Budget
+}
+
+// Budget limits the spend, tokens and wall-clock time of an agent run.
+// Zero values mean no limit.
+type Budget struct {
+	MaxCost     float64
+	MaxTokens   int64
+	MaxDuration time.Duration
 }
 
 // AgentUser is the Console user who initiated the agent run. Git commits
@@ -91,6 +104,8 @@ type AgentRuntimeConfig struct {
 	Gemini   *GeminiConfig   `json:"gemini,omitempty"`
 	Codex    *CodexConfig    `json:"codex,omitempty"`
 	Pi       *PiConfig       `json:"pi,omitempty"`
+	Aider    *AiderConfig    `json:"aider,omitempty"`
+	Goose    *GooseConfig    `json:"goose,omitempty"`
 }
 
 type OpencodeConfig struct {
@@ -136,6 +151,21 @@ type PiConfig struct {
 	Timeout  time.Duration `json:"timeout"`
 }
 
+type AiderConfig struct {
+	APIKey   string        `json:"apiKey"`
+	Model    string        `json:"model,omitempty"`
+	Endpoint *string       `json:"endpoint,omitempty"`
+	Timeout  time.Duration `json:"timeout"`
+}
+
+type GooseConfig struct {
+	APIKey   string        `json:"apiKey"`
+	Provider string        `json:"provider,omitempty"`
+	Model    string        `json:"model,omitempty"`
+	Endpoint *string       `json:"endpoint,omitempty"`
+	Timeout  time.Duration `json:"timeout"`
+}
+
 // FromAgentRunFragment converts Console API fragment to harness type
 func (ar *AgentRun) FromAgentRunFragment(fragment *console.AgentRunFragment) *AgentRun {
 	run := &AgentRun{
@@ -205,9 +235,35 @@ func (ar *AgentRun) FromAgentRunFragment(fragment *console.AgentRunFragment) *Ag
 		run.FollowupPrURL = *fragment.FollowupPrURL
 	}
 
+	run.Budget = Budget{
+		MaxCost:     helpers.GetPluralEnvFloat(controller.EnvBudgetMaxCost, 0),
+		MaxTokens:   helpers.GetPluralEnvInt64(controller.EnvBudgetMaxTokens, 0),
+		MaxDuration: helpers.GetPluralEnvDuration(controller.EnvBudgetMaxDuration, 0),
+	}
+
 	return run
 }
 
+// IsEmpty returns true if the budget does not set any limit.
+func (in Budget) IsEmpty() bool {
+	return in.MaxCost <= 0 && in.MaxTokens <= 0 && in.MaxDuration <= 0
+}
+
+// Exceeded returns an error describing the first limit exceeded by the given usage
+// or nil if the usage is within the budget.
+func (in Budget) Exceeded(totalTokens int64, totalCost float64, elapsed time.Duration) error {
+	switch {
+	case in.MaxCost > 0 && totalCost > in.MaxCost:
+		return fmt.Errorf("%w: total cost %.4f exceeded the limit of %.4f", errors.ErrBudgetExceeded, totalCost, in.MaxCost)
+	case in.MaxTokens > 0 && totalTokens > in.MaxTokens:
+		return fmt.Errorf("%w: total tokens %d exceeded the limit of %d", errors.ErrBudgetExceeded, totalTokens, in.MaxTokens)
+	case in.MaxDuration > 0 && elapsed > in.MaxDuration:
+		return fmt.Errorf("%w: run time %s exceeded the limit of %s", errors.ErrBudgetExceeded, elapsed.Round(time.Second), in.MaxDuration)
+	}
+
+	return nil
+}
+
 func usageFromFragment(usage *console.AgentRunFragment_Usage) *console.AgentRunUsage {
 	if usage == nil {
 		return nil
@@ -293,6 +349,25 @@ func (ar *AgentRun) fromEnv(runtime *console.AgentRuntimeFragment) *AgentRuntime
 		if endpoint := helpers.GetPluralEnv(controller.EnvPiEndpoint, ""); endpoint != "" {
 			config.Pi.Endpoint = &endpoint
 		}
+	case console.AgentRuntimeTypeAider:
+		config.Aider = &AiderConfig{
+			APIKey:  helpers.GetPluralEnv(controller.EnvAiderAPIKey, ""),
+			Model:   helpers.GetPluralEnv(controller.EnvAiderModel, ""),
+			Timeout: helpers.GetPluralEnvDuration(controller.EnvExecTimeout, defaultTimeout),
+		}
+		if endpoint := helpers.GetPluralEnv(controller.EnvAiderEndpoint, ""); endpoint != "" {
+			config.Aider.Endpoint = &endpoint
+		}
+	case console.AgentRuntimeTypeGoose:
+		config.Goose = &GooseConfig{
+			APIKey:   helpers.GetPluralEnv(controller.EnvGooseAPIKey, ""),
+			Provider: helpers.GetPluralEnv(controller.EnvGooseProvider, ""),
+			Model:    helpers.GetPluralEnv(controller.EnvGooseModel, ""),
+			Timeout:  helpers.GetPluralEnvDuration(controller.EnvExecTimeout, defaultTimeout),
+		}
+		if endpoint := helpers.GetPluralEnv(controller.EnvGooseEndpoint, ""); endpoint != "" {
+			config.Goose.Endpoint = &endpoint
+		}
 	}
 
 	result.Config = config
@@ -1,9 +1,13 @@
 package v1
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	console "github.com/pluralsh/console/go/client"
+
+	internalerrors "github.com/pluralsh/console/go/deployment-operator/pkg/harness/errors"
 )
 
 func TestExaConnectionEnabled(t *testing.T) {
@@ -114,3 +118,32 @@ func TestFromAgentRunFragmentCopiesInitiatingUser(t *testing.T) {
 		t.Fatalf("expected initiating user email copied, got %q", run.User.Email)
 	}
 }
+
+func TestBudgetExceeded(t *testing.T) {
+	if !(Budget{}).IsEmpty() {
+		t.Fatal("expected zero budget to be empty")
+	}
+
+	if err := (Budget{}).Exceeded(1_000_000, 1000, 24*time.Hour); err != nil {
+		t.Fatalf("expected empty budget to never be exceeded, got %v", err)
+	}
+
+	budget := Budget{MaxCost: 5, MaxTokens: 1000, MaxDuration: time.Hour}
+	if budget.IsEmpty() {
+		t.Fatal("expected budget not to be empty")
+	}
+
+	if err := budget.Exceeded(1000, 5, time.Hour); err != nil {
+		t.Fatalf("expected usage at the limits to be within budget, got %v", err)
+	}
+
+	for name, err := range map[string]error{
+		"cost":     budget.Exceeded(10, 5.01, time.Minute),
+		"tokens":   budget.Exceeded(1001, 0, time.Minute),
+		"duration": budget.Exceeded(10, 0, 2*time.Hour),
+	} {
+		if !errors.Is(err, internalerrors.ErrBudgetExceeded) {
+			t.Fatalf("expected %s budget to be exceeded, got %v", name, err)
+		}
+	}
+}
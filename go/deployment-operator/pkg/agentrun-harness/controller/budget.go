@@ -0,0 +1,81 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	gqlclient "github.com/pluralsh/console/go/client"
+	"github.com/samber/lo"
+	"k8s.io/klog/v2"
+
+	internalerrors "github.com/pluralsh/console/go/deployment-operator/pkg/harness/errors"
+	"github.com/pluralsh/console/go/deployment-operator/pkg/log"
+)
+
+const budgetExceededSummary = "Agent run stopped after exceeding its budget"
+
+// waitForToolExit gives the interrupted agent CLI a chance to exit
+// and flush its final output before the run is completed.
+func (in *agentRunController) waitForToolExit(timeout time.Duration) {
+	select {
+	case err := <-in.errChan:
+		klog.V(log.LogLevelDebug).InfoS("agent CLI exited after interrupt", "error", err)
+	case <-in.runDone:
+	case <-time.After(timeout):
+		klog.V(log.LogLevelInfo).InfoS("agent CLI did not exit within the grace period", "timeout", timeout)
+	}
+}
+
+// messageContext returns the context agent messages are persisted with. The CLI interrupted
+// after exceeding the budget still flushes its final output, so those messages are persisted
+// without the cancellation, bounded by the stop grace period.
+func messageContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if !errors.Is(context.Cause(ctx), internalerrors.ErrBudgetExceeded) {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(context.WithoutCancel(ctx), stopGracePeriod)
+}
+
+// persistBudgetReport records why the run was stopped as a final agent message.
+// If the run is expected to persist an analysis and the agent did not do it
+// before being stopped, the report is also stored as the run analysis.
+func (in *agentRunController) persistBudgetReport(ctx context.Context, reason error) {
+	report := in.budgetReport(reason)
+	if _, err := in.consoleClient.CreateAgentMessage(ctx, in.agentRunID, gqlclient.AgentMessageAttributes{
+		Message: report,
+		Role:    gqlclient.AiRoleSystem,
+	}); err != nil {
+		klog.ErrorS(err, "could not persist budget report message")
+	}
+
+	if in.agentRun == nil || !analysisGateEnabled(in.agentRun.Mode) {
+		return
+	}
+
+	frag, err := in.consoleClient.GetAgentRun(ctx, in.agentRunID)
+	if err != nil {
+		klog.ErrorS(err, "could not get agent run to verify analysis")
+		return
+	}
+
+	if analysisPersisted(frag) {
+		return
+	}
+
+	if _, err := in.consoleClient.UpdateAgentRunAnalysis(ctx, in.agentRunID, gqlclient.AgentAnalysisAttributes{
+		Summary:  budgetExceededSummary,
+		Analysis: report,
+		Bullets:  []*string{lo.ToPtr(reason.Error())},
+	}); err != nil {
+		klog.ErrorS(err, "could not persist budget analysis")
+	}
+}
+
+func (in *agentRunController) budgetReport(reason error) string {
+	tokens, cost := in.usage.Totals()
+	return fmt.Sprintf("Agent run stopped: %s. It used %d tokens with a total cost of %.4f before it was stopped.",
+		reason.Error(), tokens, cost)
+}
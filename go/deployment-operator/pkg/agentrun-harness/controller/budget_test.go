@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	gqlclient "github.com/pluralsh/console/go/client"
+	agentrunv1 "github.com/pluralsh/console/go/deployment-operator/pkg/agentrun-harness/agentrun/v1"
+	"github.com/pluralsh/console/go/deployment-operator/pkg/agentrun-harness/usage"
+	internalerrors "github.com/pluralsh/console/go/deployment-operator/pkg/harness/errors"
+	"github.com/pluralsh/console/go/deployment-operator/pkg/test/mocks"
+)
+
+func TestPostStartPersistsBudgetReport(t *testing.T) {
+	t.Parallel()
+
+	budgetErr := fmt.Errorf("%w: total cost 5.5000 exceeded the limit of 5.0000", internalerrors.ErrBudgetExceeded)
+	recorder := usage.New(nil)
+	recorder.RecordUsage(usage.Record{InputTokens: 100, OutputTokens: 20, TotalCost: 5.5})
+
+	m := mocks.NewClientMock(t)
+	m.On("CreateAgentMessage", mock.Anything, "r1", mock.MatchedBy(func(attrs gqlclient.AgentMessageAttributes) bool {
+		return attrs.Role == gqlclient.AiRoleSystem &&
+			strings.Contains(attrs.Message, budgetErr.Error()) &&
+			strings.Contains(attrs.Message, "120 tokens")
+	})).Return(&gqlclient.CreateAgentMessage_CreateAgentMessage{}, nil).Once()
+	m.On("GetAgentRun", mock.Anything, "r1").Return(&gqlclient.AgentRunFragment{ID: "r1"}, nil).Once()
+	m.On("UpdateAgentRunAnalysis", mock.Anything, "r1", mock.MatchedBy(func(attrs gqlclient.AgentAnalysisAttributes) bool {
+		return attrs.Summary == budgetExceededSummary && strings.Contains(attrs.Analysis, budgetErr.Error())
+	})).Return(&gqlclient.AgentRunBaseFragment{}, nil).Once()
+	m.On("UpdateAgentRun", mock.Anything, "r1", mock.MatchedBy(func(attrs gqlclient.AgentRunStatusAttributes) bool {
+		return attrs.Status == gqlclient.AgentRunStatusFailed &&
+			attrs.Error != nil && *attrs.Error == budgetErr.Error()
+	})).Return(&gqlclient.AgentRunFragment{ID: "r1"}, nil).Once()
+
+	in := &agentRunController{
+		agentRun:      &agentrunv1.AgentRun{Mode: gqlclient.AgentRunModeAnalyze},
+		agentRunID:    "r1",
+		consoleClient: m,
+		usage:         recorder,
+	}
+
+	in.postStart(budgetErr)
+}
+
+func TestPersistBudgetReportKeepsExistingAnalysis(t *testing.T) {
+	t.Parallel()
+
+	m := mocks.NewClientMock(t)
+	m.On("CreateAgentMessage", mock.Anything, "r1", mock.Anything).
+		Return(&gqlclient.CreateAgentMessage_CreateAgentMessage{}, nil).Once()
+	m.On("GetAgentRun", mock.Anything, "r1").Return(&gqlclient.AgentRunFragment{
+		ID:       "r1",
+		Analysis: &gqlclient.AgentAnalysisFragment{Summary: "s", Analysis: "body"},
+	}, nil).Once()
+
+	in := &agentRunController{
+		agentRun:      &agentrunv1.AgentRun{Mode: gqlclient.AgentRunModeWrite},
+		agentRunID:    "r1",
+		consoleClient: m,
+		usage:         usage.New(nil),
+	}
+
+	in.persistBudgetReport(context.Background(), internalerrors.ErrBudgetExceeded)
+}
+
+func TestMessageContextOutlivesBudgetCancellation(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(internalerrors.ErrBudgetExceeded)
+
+	msgCtx, msgCancel := messageContext(ctx)
+	defer msgCancel()
+	if err := msgCtx.Err(); err != nil {
+		t.Fatalf("expected message context to stay active after the budget stop, got %v", err)
+	}
+	if _, ok := msgCtx.Deadline(); !ok {
+		t.Fatal("expected message context to be bounded by a deadline")
+	}
+
+	m := mocks.NewClientMock(t)
+	m.On("CreateAgentMessage", mock.MatchedBy(func(ctx context.Context) bool {
+		return ctx.Err() == nil
+	}), "r1", mock.Anything).Return(&gqlclient.CreateAgentMessage_CreateAgentMessage{}, nil).Once()
+
+	in := &agentRunController{agentRunID: "r1", consoleClient: m}
+	in.handleAgentMessage(msgCtx, &gqlclient.AgentMessageAttributes{Message: "final output", Role: gqlclient.AiRoleAssistant}, "")
+}
+
+func TestMessageContextKeepsOtherCancellations(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	msgCtx, msgCancel := messageContext(ctx)
+	defer msgCancel()
+	if msgCtx.Err() == nil {
+		t.Fatal("expected message context to be cancelled")
+	}
+}
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"path/filepath"
 	"strings"
@@ -13,12 +14,14 @@ import (
 
 	agentrunv1 "github.com/pluralsh/console/go/deployment-operator/pkg/agentrun-harness/agentrun/v1"
 	"github.com/pluralsh/console/go/deployment-operator/pkg/agentrun-harness/environment"
+	agentsignals "github.com/pluralsh/console/go/deployment-operator/pkg/agentrun-harness/signals"
 	"github.com/pluralsh/console/go/deployment-operator/pkg/agentrun-harness/tool"
 	toolv1 "github.com/pluralsh/console/go/deployment-operator/pkg/agentrun-harness/tool/v1"
 	"github.com/pluralsh/console/go/deployment-operator/pkg/agentrun-harness/usage"
 	"github.com/pluralsh/console/go/deployment-operator/pkg/common"
 	internalerrors "github.com/pluralsh/console/go/deployment-operator/pkg/harness/errors"
 	"github.com/pluralsh/console/go/deployment-operator/pkg/harness/exec"
+	"github.com/pluralsh/console/go/deployment-operator/pkg/harness/signals"
 	v1 "github.com/pluralsh/console/go/deployment-operator/pkg/harness/stackrun/v1"
 	"github.com/pluralsh/console/go/deployment-operator/pkg/log"
 	"github.com/pluralsh/console/go/deployment-operator/pkg/scm"
@@ -30,9 +33,12 @@ import (
 //   - All commands have finished their execution
 //   - It was running for too long and timed out
 //   - Remote cancellation signal was received and stopped the execution
+//   - It has exceeded its budget and was stopped
 func (in *agentRunController) Start(ctx context.Context) (retErr error) {
 	in.Lock()
 
+	ctx = signals.NewCancelableContext(ctx, agentsignals.NewBudgetSignal(in.agentRun.Budget, in.usage))
+
 	ready := false
 	defer func() {
 		// Only unlock if we haven't reached
@@ -55,13 +61,16 @@ func (in *agentRunController) Start(ctx context.Context) (retErr error) {
 
 	in.ensureToolCallMessageIDs()
 	in.tool.OnMessage(func(message *gqlclient.AgentMessageAttributes, callID string) {
-		in.handleAgentMessage(ctx, message, callID)
+		msgCtx, cancel := messageContext(ctx)
+		defer cancel()
+		in.handleAgentMessage(msgCtx, message, callID)
 	})
 
 	in.tool.Run(
 		ctx,
 		exec.WithHook(v1.LifecyclePreStart, in.preExecHook()),
 		exec.WithHook(v1.LifecyclePostStart, in.postExecHook()),
+		exec.WithGracePeriod(stopGracePeriod),
 	)
 
 	go func() {
@@ -77,9 +86,16 @@ func (in *agentRunController) Start(ctx context.Context) (retErr error) {
 	// Stop the execution if provided context is done.
 	case <-ctx.Done():
 		retErr = context.Cause(ctx)
+		if errors.Is(retErr, internalerrors.ErrBudgetExceeded) {
+			in.waitForToolExit(stopGracePeriod)
+		}
 	// In case of any error finish the execution and return error.
 	case err := <-in.errChan:
 		retErr = err
+		// The interrupted CLI can report its failure before the budget cancellation is noticed.
+		if cause := context.Cause(ctx); errors.Is(cause, internalerrors.ErrBudgetExceeded) {
+			retErr = cause
+		}
 	// If execution finished successfully, return without error.
 	case <-in.done:
 		retErr = nil
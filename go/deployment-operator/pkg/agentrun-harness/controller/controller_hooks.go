@@ -59,6 +59,9 @@ func (in *agentRunController) postStart(err error) {
 		status = gqlclient.AgentRunStatusCancelled
 		// Do not send an error if agent run was cancelled
 		err = nil
+	case errors.Is(err, internalerrors.ErrBudgetExceeded):
+		status = gqlclient.AgentRunStatusFailed
+		in.persistBudgetReport(context.Background(), err)
 	default:
 		status = gqlclient.AgentRunStatusFailed
 	}
@@ -6,4 +6,8 @@ const (
 	// promptPollInterval is how often the harness polls for queued user prompts
 	// during approval wait and babysit mode.
 	promptPollInterval = 5 * time.Second
+
+	// stopGracePeriod is how long the agent CLI is given to exit after it has
+	// been interrupted, i.e. when the run exceeds its budget.
+	stopGracePeriod = 30 * time.Second
 )
@@ -12,7 +12,8 @@ func ProxyProvider(runtimeType console.AgentRuntimeType) string {
 	switch runtimeType {
 	case console.AgentRuntimeTypeClaude:
 		return "anthropic"
-	case console.AgentRuntimeTypeCodex, console.AgentRuntimeTypeOpencode, console.AgentRuntimeTypePi:
+	case console.AgentRuntimeTypeCodex, console.AgentRuntimeTypeOpencode, console.AgentRuntimeTypePi,
+		console.AgentRuntimeTypeAider, console.AgentRuntimeTypeGoose:
 		return "openai"
 	case console.AgentRuntimeTypeGemini:
 		return "vertex"
@@ -14,6 +14,8 @@ func TestProxyProvider(t *testing.T) {
 		{console.AgentRuntimeTypeClaude, "anthropic"},
 		{console.AgentRuntimeTypeCodex, "openai"},
 		{console.AgentRuntimeTypeOpencode, "openai"},
+		{console.AgentRuntimeTypeAider, "openai"},
+		{console.AgentRuntimeTypeGoose, "openai"},
 		{console.AgentRuntimeTypeGemini, "vertex"},
 		{console.AgentRuntimeTypeCustom, ""},
 	}
@@ -0,0 +1,54 @@
+package signals
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	agentrunv1 "github.com/pluralsh/console/go/deployment-operator/pkg/agentrun-harness/agentrun/v1"
+	"github.com/pluralsh/console/go/deployment-operator/pkg/agentrun-harness/usage"
+	types "github.com/pluralsh/console/go/deployment-operator/pkg/harness/signals"
+	"github.com/pluralsh/console/go/deployment-operator/pkg/log"
+)
+
+// budgetCheckPeriod is mutable so tests can shorten waits.
+var budgetCheckPeriod = 5 * time.Second
+
+type budgetSignal struct {
+	budget agentrunv1.Budget
+	usage  *usage.Usage
+}
+
+func (in *budgetSignal) Listen(cancelFunc context.CancelCauseFunc) {
+	if in.budget.IsEmpty() {
+		return
+	}
+
+	klog.V(log.LogLevelDebug).InfoS("starting budget signal listener",
+		"maxCost", in.budget.MaxCost,
+		"maxTokens", in.budget.MaxTokens,
+		"maxDuration", in.budget.MaxDuration,
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	start := time.Now()
+
+	go wait.Until(func() {
+		tokens, cost := in.usage.Totals()
+		if err := in.budget.Exceeded(tokens, cost, time.Since(start)); err != nil {
+			klog.ErrorS(err, "agent run budget exceeded, stopping")
+			cancelFunc(err)
+			cancel()
+		}
+	}, budgetCheckPeriod, ctx.Done())
+}
+
+// NewBudgetSignal cancels the context once the agent run exceeds any of its budget limits.
+func NewBudgetSignal(budget agentrunv1.Budget, usage *usage.Usage) types.Signal {
+	return &budgetSignal{
+		budget: budget,
+		usage:  usage,
+	}
+}
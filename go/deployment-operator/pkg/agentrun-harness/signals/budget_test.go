@@ -0,0 +1,48 @@
+package signals
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	agentrunv1 "github.com/pluralsh/console/go/deployment-operator/pkg/agentrun-harness/agentrun/v1"
+	"github.com/pluralsh/console/go/deployment-operator/pkg/agentrun-harness/usage"
+	internalerrors "github.com/pluralsh/console/go/deployment-operator/pkg/harness/errors"
+	types "github.com/pluralsh/console/go/deployment-operator/pkg/harness/signals"
+)
+
+func TestBudgetSignal(t *testing.T) {
+	budgetCheckPeriod = 5 * time.Millisecond
+
+	t.Run("empty budget never cancels", func(t *testing.T) {
+		ctx := types.NewCancelableContext(context.Background(), NewBudgetSignal(agentrunv1.Budget{}, usage.New(nil)))
+
+		select {
+		case <-ctx.Done():
+			t.Fatal("expected context not to be cancelled")
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+
+	t.Run("cancels once the budget is exceeded", func(t *testing.T) {
+		recorder := usage.New(nil)
+		ctx := types.NewCancelableContext(context.Background(), NewBudgetSignal(agentrunv1.Budget{MaxTokens: 100}, recorder))
+
+		select {
+		case <-ctx.Done():
+			t.Fatal("expected context not to be cancelled while within budget")
+		case <-time.After(25 * time.Millisecond):
+		}
+
+		recorder.RecordUsage(usage.Record{InputTokens: 80, OutputTokens: 30})
+
+		select {
+		case <-ctx.Done():
+			require.ErrorIs(t, context.Cause(ctx), internalerrors.ErrBudgetExceeded)
+		case <-time.After(time.Second):
+			t.Fatal("expected context to be cancelled")
+		}
+	})
+}
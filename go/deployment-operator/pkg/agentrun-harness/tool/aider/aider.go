@@ -0,0 +1,317 @@
+package aider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	console "github.com/pluralsh/console/go/client"
+	"github.com/pluralsh/console/go/deployment-operator/internal/helpers"
+	proxymodel "github.com/pluralsh/console/go/deployment-operator/pkg/agentrun-harness/model"
+	"github.com/pluralsh/console/go/deployment-operator/pkg/agentrun-harness/tool/artifacts"
+	v1 "github.com/pluralsh/console/go/deployment-operator/pkg/agentrun-harness/tool/v1"
+	"github.com/pluralsh/console/go/deployment-operator/pkg/common"
+	"github.com/pluralsh/console/go/deployment-operator/pkg/harness/exec"
+	stackrunv1 "github.com/pluralsh/console/go/deployment-operator/pkg/harness/stackrun/v1"
+	"github.com/pluralsh/console/go/deployment-operator/pkg/log"
+)
+
+func New(config v1.Config) v1.Tool {
+	runtimeConfig := config.Run.Runtime.Config.Aider
+	result := &Aider{
+		DefaultTool: v1.DefaultTool{Config: config},
+		tools:       &mcpBridge{url: common.AgentMCPServerURL},
+		model:       defaultModel,
+	}
+	if runtimeConfig != nil {
+		if runtimeConfig.Model != "" {
+			result.model = runtimeConfig.Model
+		}
+		result.apiKey = runtimeConfig.APIKey
+		if runtimeConfig.Endpoint != nil {
+			result.endpoint = *runtimeConfig.Endpoint
+		}
+	}
+	if config.Run.IsProxyEnabled() {
+		// Aider resolves models through litellm, which strips the "openai/" prefix
+		// before calling an OpenAI-compatible endpoint. Prefixing it once more keeps
+		// the full provider/model format when the request reaches the Plural AI proxy.
+		result.model = fmt.Sprintf("%s/%s", openAIProvider, proxymodel.ProxyModel(console.AgentRuntimeTypeAider, result.model))
+	} else if result.endpoint != "" && !strings.HasPrefix(result.model, openAIProvider+"/") {
+		result.model = fmt.Sprintf("%s/%s", openAIProvider, result.model)
+	}
+	if err := result.ensure(); err != nil {
+		klog.Fatalf("failed to initialize aider tool: %v", err)
+	}
+	return result
+}
+
+func (in *Aider) ensure() error {
+	if in.Config.WorkDir == "" {
+		return fmt.Errorf("work directory is not set")
+	}
+	if in.Config.RepositoryDir == "" {
+		return fmt.Errorf("repository directory is not set")
+	}
+	if in.Config.Run == nil || in.Config.Run.Runtime == nil || in.Config.Run.Runtime.Config == nil || in.Config.Run.Runtime.Config.Aider == nil {
+		return fmt.Errorf("aider runtime configuration is not set")
+	}
+	return nil
+}
+
+func (in *Aider) Run(ctx context.Context, options ...exec.Option) {
+	go in.start(ctx, in.Config.Run.Prompt, options...)
+}
+
+func (in *Aider) Configure(consoleURL, consoleToken string) error {
+	in.consoleURL = consoleURL
+	in.consoleToken = consoleToken
+	if err := in.ConfigureSystemPrompt(console.AgentRuntimeTypeAider); err != nil {
+		return err
+	}
+	if err := in.ConfigureSkills(in.skillsPath()); err != nil {
+		return err
+	}
+	return in.excludeAiderFiles()
+}
+
+func (in *Aider) ConfigureBabysitRun() error {
+	if err := in.ConfigureSystemPromptForBabysitRun(console.AgentRuntimeTypeAider); err != nil {
+		return err
+	}
+	return in.ConfigureSkills(in.skillsPath())
+}
+
+func (in *Aider) OnMessage(f v1.MessageCallback) {
+	in.onMessage = f
+}
+
+func (in *Aider) BabysitRun(ctx context.Context, bCtx *v1.BabysitContext) bool {
+	if bCtx == nil {
+		return false
+	}
+	if err := in.run(ctx, bCtx.Prompt, true); err != nil {
+		in.Config.ErrorChan <- err
+	}
+	return false
+}
+
+// FollowUpRun restores the chat history of the previous invocations, so aider
+// continues the same conversation.
+func (in *Aider) FollowUpRun(ctx context.Context, prompt string) error {
+	return in.runWithOptions(ctx, prompt, true)
+}
+
+func (in *Aider) start(ctx context.Context, prompt string, options ...exec.Option) {
+	in.emit(&console.AgentMessageAttributes{Message: prompt, Role: console.AiRoleUser}, "")
+	if err := in.runWithOptions(ctx, prompt, false, options...); err != nil {
+		klog.ErrorS(err, "aider execution failed")
+		in.Config.ErrorChan <- err
+	}
+}
+
+func (in *Aider) run(ctx context.Context, prompt string, emitUser bool) error {
+	if emitUser {
+		in.emit(&console.AgentMessageAttributes{Message: prompt, Role: console.AiRoleUser}, "")
+	}
+	return in.runWithOptions(ctx, prompt, true)
+}
+
+// runWithOptions invokes aider and executes the Plural tool calls it requests,
+// feeding their results back until it stops requesting them. Lifecycle hooks
+// wrap the whole conversation instead of every aider invocation, so the
+// controller observes a single run.
+func (in *Aider) runWithOptions(ctx context.Context, prompt string, resume bool, options ...exec.Option) (err error) {
+	if err := in.configureTools(ctx); err != nil {
+		return err
+	}
+
+	preStart := exec.Hook(stackrunv1.LifecyclePreStart, options...)
+	postStart := exec.Hook(stackrunv1.LifecyclePostStart, options...)
+	options = append(slices.Clone(options), exec.WithoutHooks())
+
+	if err := preStart(); err != nil {
+		return err
+	}
+	defer func() {
+		if hookErr := postStart(); hookErr != nil {
+			err = errors.Join(err, hookErr)
+		}
+	}()
+
+	for round := 0; ; round++ {
+		if err := in.runOnce(ctx, prompt, resume, options...); err != nil {
+			return err
+		}
+
+		calls := parseToolCalls(in.reply.String())
+		if len(calls) == 0 {
+			return nil
+		}
+		if round >= maxToolRounds {
+			return fmt.Errorf("aider requested plural tools for more than %d rounds", maxToolRounds)
+		}
+
+		klog.V(log.LogLevelDebug).InfoS("executing aider tool calls", "round", round, "count", len(calls))
+		prompt = in.callTools(ctx, calls)
+		resume = true
+	}
+}
+
+func (in *Aider) runOnce(ctx context.Context, prompt string, resume bool, options ...exec.Option) error {
+	in.reply.Reset()
+	in.message.Reset()
+	in.executable = exec.NewExecutable(
+		"aider",
+		append(slices.Clone(options),
+			exec.WithArgs(in.args(prompt, resume)),
+			exec.WithEnv(in.env()),
+			exec.WithDir(in.Config.RepositoryDir),
+			exec.WithTimeout(in.Config.Run.Runtime.Config.Aider.Timeout),
+		)...,
+	)
+	err := in.executable.RunStream(ctx, in.handleStreamLine)
+	in.flushMessage(nil)
+	return err
+}
+
+// configureTools writes the Plural tool protocol prompt once the MCP server is reachable.
+func (in *Aider) configureTools(ctx context.Context) error {
+	if in.toolsReady {
+		return nil
+	}
+
+	tools, err := in.tools.ListTools(ctx)
+	if err != nil {
+		return fmt.Errorf("list plural tools: %w", err)
+	}
+	if err := helpers.File().Create(in.toolsPromptPath(), toolsPrompt(tools), 0644); err != nil {
+		return fmt.Errorf("write aider tools prompt: %w", err)
+	}
+
+	in.toolsReady = true
+	return nil
+}
+
+func (in *Aider) args(prompt string, resume bool) []string {
+	args := []string{
+		"--model", in.model,
+		"--yes-always",
+		"--no-pretty",
+		"--no-stream",
+		"--no-fancy-input",
+		"--no-auto-commits",
+		"--no-dirty-commits",
+		"--no-gitignore",
+		"--no-check-update",
+		"--no-show-release-notes",
+		"--no-analytics",
+		"--no-detect-urls",
+		"--chat-history-file", in.chatHistoryPath(),
+		"--input-history-file", in.inputHistoryPath(),
+		"--read", in.systemPromptPath(),
+		"--read", in.toolsPromptPath(),
+	}
+	for _, skill := range in.skillFiles() {
+		args = append(args, "--read", skill)
+	}
+	if resume {
+		args = append(args, "--restore-chat-history")
+	}
+	return append(args, "--message", prompt)
+}
+
+func (in *Aider) env() []string {
+	apiKey := in.apiKey
+	endpoint := in.endpoint
+	if in.Config.Run.IsProxyEnabled() {
+		apiKey = in.consoleToken
+		endpoint = fmt.Sprintf("%s/ext/ai/v1", in.consoleURL)
+		if in.Config.Run.IsStreamingProxyEnabled() {
+			endpoint = common.AgentOpenAIBaseURL
+		}
+	}
+
+	env := []string{fmt.Sprintf("%s=%s", openAIAPIKeyEnv, apiKey)}
+	if endpoint != "" {
+		env = append(env, fmt.Sprintf("%s=%s", openAIAPIBase, endpoint))
+	}
+	return env
+}
+
+// excludeAiderFiles keeps aider caches created in the repository root out of
+// commits without modifying the tracked .gitignore.
+func (in *Aider) excludeAiderFiles() error {
+	excludeFile := filepath.Join(in.Config.RepositoryDir, ".git", "info", "exclude")
+	content, err := os.ReadFile(excludeFile)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("read git exclude file: %w", err)
+	}
+	if strings.Contains(string(content), ".aider*") {
+		return nil
+	}
+	if len(content) > 0 && !strings.HasSuffix(string(content), "\n") {
+		content = append(content, '\n')
+	}
+	content = append(content, ".aider*\n"...)
+	if err := helpers.File().Create(excludeFile, string(content), 0644); err != nil {
+		return fmt.Errorf("write git exclude file: %w", err)
+	}
+	return nil
+}
+
+func (in *Aider) aiderHome() string {
+	return filepath.Join(in.Config.WorkDir, ".aider")
+}
+
+func (in *Aider) systemPromptPath() string {
+	return filepath.Join(in.aiderHome(), v1.SystemPromptFile)
+}
+
+func (in *Aider) toolsPromptPath() string {
+	return filepath.Join(in.aiderHome(), toolsPromptFile)
+}
+
+func (in *Aider) skillsPath() string {
+	return filepath.Join(in.aiderHome(), "skills")
+}
+
+func (in *Aider) sessionsPath() string {
+	return filepath.Join(in.aiderHome(), "sessions")
+}
+
+func (in *Aider) chatHistoryPath() string {
+	return filepath.Join(in.sessionsPath(), "chat.history.md")
+}
+
+func (in *Aider) inputHistoryPath() string {
+	return filepath.Join(in.sessionsPath(), "input.history")
+}
+
+// skillFiles returns the sideloaded skills, which aider reads as read-only context files.
+func (in *Aider) skillFiles() []string {
+	files, err := filepath.Glob(filepath.Join(in.skillsPath(), "*", "SKILL.md"))
+	if err != nil {
+		return nil
+	}
+	return files
+}
+
+func (in *Aider) UploadArtifacts(ctx context.Context) (*artifacts.UploadArtifacts, error) {
+	return in.BuildUploadArtifacts(ctx, artifacts.BuildArtifactsOptions{
+		Provider: "aider",
+		Source:   artifacts.SessionSource{Path: in.sessionsPath(), ArchivePath: "sessions"},
+	})
+}
+
+func (in *Aider) emit(message *console.AgentMessageAttributes, callID string) {
+	if message != nil && in.onMessage != nil {
+		in.onMessage(message, callID)
+	}
+}
@@ -0,0 +1,215 @@
+package aider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	console "github.com/pluralsh/console/go/client"
+	agentrunv1 "github.com/pluralsh/console/go/deployment-operator/pkg/agentrun-harness/agentrun/v1"
+	toolv1 "github.com/pluralsh/console/go/deployment-operator/pkg/agentrun-harness/tool/v1"
+	"github.com/pluralsh/console/go/deployment-operator/pkg/agentrun-harness/usage"
+	"github.com/pluralsh/console/go/deployment-operator/pkg/harness/exec"
+	stackrunv1 "github.com/pluralsh/console/go/deployment-operator/pkg/harness/stackrun/v1"
+)
+
+// mockAiderScript requests a Plural tool call on its first invocation and
+// answers without one afterwards, so a run spans two aider processes.
+const mockAiderScript = `#!/bin/sh
+if [ -f "$MOCK_AIDER_STATE" ]; then
+  echo "Done."
+  exit 0
+fi
+touch "$MOCK_AIDER_STATE"
+printf '%s\n' '` + "```plural-tool" + `' '{"name": "createBranch"}' '` + "```" + `'
+`
+
+// setupMockAider puts a mock aider executable first on the PATH.
+func setupMockAider(t *testing.T) {
+	t.Helper()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "aider"), []byte(mockAiderScript), 0755); err != nil {
+		t.Fatalf("failed to write mock aider: %v", err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("MOCK_AIDER_STATE", filepath.Join(dir, "state"))
+}
+
+type fakeTools struct {
+	calls []toolCall
+}
+
+func (in *fakeTools) ListTools(context.Context) ([]mcp.Tool, error) {
+	return []mcp.Tool{mcp.NewTool("createBranch", mcp.WithDescription("Creates a branch"))}, nil
+}
+
+func (in *fakeTools) CallTool(_ context.Context, name string, arguments map[string]any) (*mcp.CallToolResult, error) {
+	in.calls = append(in.calls, toolCall{Name: name, Arguments: arguments})
+	if name == "failing" {
+		return mcp.NewToolResultError("boom"), nil
+	}
+	return mcp.NewToolResultText("ok"), nil
+}
+
+func TestArgsRestoresChatHistoryOnResume(t *testing.T) {
+	tool := &Aider{
+		DefaultTool: toolv1.DefaultTool{Config: toolv1.Config{WorkDir: "/work"}},
+		model:       "openai/openai/gpt-5.4",
+	}
+	want := []string{
+		"--model", "openai/openai/gpt-5.4",
+		"--yes-always",
+		"--no-pretty",
+		"--no-stream",
+		"--no-fancy-input",
+		"--no-auto-commits",
+		"--no-dirty-commits",
+		"--no-gitignore",
+		"--no-check-update",
+		"--no-show-release-notes",
+		"--no-analytics",
+		"--no-detect-urls",
+		"--chat-history-file", "/work/.aider/sessions/chat.history.md",
+		"--input-history-file", "/work/.aider/sessions/input.history",
+		"--read", "/work/.aider/AGENTS.md",
+		"--read", "/work/.aider/PLURAL_TOOLS.md",
+		"--restore-chat-history",
+		"--message", "fix the test",
+	}
+	if got := tool.args("fix the test", true); !reflect.DeepEqual(got, want) {
+		t.Fatalf("args = %#v, want %#v", got, want)
+	}
+}
+
+func TestHandleStreamLineRecordsUsage(t *testing.T) {
+	var messages []*console.AgentMessageAttributes
+	tool := &Aider{DefaultTool: toolv1.DefaultTool{Config: toolv1.Config{Usage: usage.New(nil)}}}
+	tool.OnMessage(func(message *console.AgentMessageAttributes, _ string) {
+		messages = append(messages, message)
+	})
+
+	for _, line := range []string{
+		"Aider v0.86.1",
+		"Main model: openai/gpt-5.4 with diff edit format",
+		"I updated the handler.",
+		"Applied edit to main.go",
+		"Tokens: 2.5k sent, 1k cache hit, 250 received. Cost: $0.01 message, $0.05 session.",
+	} {
+		tool.handleStreamLine([]byte(line))
+	}
+
+	if len(messages) != 3 {
+		t.Fatalf("messages = %d, want 3", len(messages))
+	}
+	if messages[0].Message != "I updated the handler." {
+		t.Fatalf("message = %q", messages[0].Message)
+	}
+	if messages[1].Metadata == nil || *messages[1].Metadata.Tool.Input != "main.go" {
+		t.Fatalf("expected edit tool message, got %#v", messages[1])
+	}
+	if messages[2].Cost == nil || messages[2].Cost.Total != 0.01 {
+		t.Fatalf("expected usage message, got %#v", messages[2])
+	}
+
+	tokens, cost := tool.Config.Usage.Totals()
+	if tokens != 3750 || cost != 0.01 {
+		t.Fatalf("totals = %d, %v, want 3750, 0.01", tokens, cost)
+	}
+}
+
+func TestParseTokens(t *testing.T) {
+	for value, want := range map[string]int64{"": 0, "250": 250, "1.2k": 1200, "3M": 3_000_000, "1,024": 1024} {
+		if got := parseTokens(value); got != want {
+			t.Fatalf("parseTokens(%q) = %d, want %d", value, got, want)
+		}
+	}
+}
+
+func TestCallToolsReportsResults(t *testing.T) {
+	tools := &fakeTools{}
+	var states []console.AgentMessageToolState
+	tool := &Aider{tools: tools}
+	tool.OnMessage(func(message *console.AgentMessageAttributes, _ string) {
+		states = append(states, *message.Metadata.Tool.State)
+	})
+
+	calls := parseToolCalls("Creating a branch.\n```plural-tool\n{\"name\": \"createBranch\", \"arguments\": {\"branchName\": \"fix\"}}\n```\n" +
+		"```plural-tool\n{\"name\": \"failing\"}\n```\n```plural-tool\nnot json\n```")
+	if len(calls) != 3 {
+		t.Fatalf("calls = %d, want 3", len(calls))
+	}
+
+	prompt := tool.callTools(context.Background(), calls)
+	want := "Results of the requested Plural tool calls:\n\n### createBranch\nok\n\n### failing\nError: boom\n\n### Call 3\nError: the plural-tool block is not valid JSON with a \"name\" field.\n"
+	if prompt != want {
+		t.Fatalf("prompt = %q, want %q", prompt, want)
+	}
+	if !reflect.DeepEqual(tools.calls[0], toolCall{Name: "createBranch", Arguments: map[string]any{"branchName": "fix"}}) {
+		t.Fatalf("call = %#v", tools.calls[0])
+	}
+
+	wantStates := []console.AgentMessageToolState{
+		console.AgentMessageToolStateRunning,
+		console.AgentMessageToolStateCompleted,
+		console.AgentMessageToolStateRunning,
+		console.AgentMessageToolStateError,
+	}
+	if !reflect.DeepEqual(states, wantStates) {
+		t.Fatalf("states = %v, want %v", states, wantStates)
+	}
+}
+
+func TestNewWithProxyKeepsProviderPrefix(t *testing.T) {
+	tool := New(toolv1.Config{
+		WorkDir:       "/work",
+		RepositoryDir: "/work/repo",
+		Run: &agentrunv1.AgentRun{Runtime: &agentrunv1.AgentRuntime{
+			AiProxy: true,
+			Config:  &agentrunv1.AgentRuntimeConfig{Aider: &agentrunv1.AiderConfig{Model: "gpt-5.4"}},
+		}},
+	}).(*Aider)
+	if tool.model != "openai/openai/gpt-5.4" {
+		t.Fatalf("model = %q", tool.model)
+	}
+}
+
+func TestRunWithOptionsFiresHooksOncePerRun(t *testing.T) {
+	setupMockAider(t)
+
+	work := t.TempDir()
+	tools := &fakeTools{}
+	tool := &Aider{
+		DefaultTool: toolv1.DefaultTool{Config: toolv1.Config{
+			WorkDir:       work,
+			RepositoryDir: work,
+			Usage:         usage.New(nil),
+			Run: &agentrunv1.AgentRun{Runtime: &agentrunv1.AgentRuntime{
+				Config: &agentrunv1.AgentRuntimeConfig{Aider: &agentrunv1.AiderConfig{Timeout: time.Minute}},
+			}},
+		}},
+		tools: tools,
+		model: defaultModel,
+	}
+
+	var preStart, postStart int
+	options := []exec.Option{
+		exec.WithHook(stackrunv1.LifecyclePreStart, func() error { preStart++; return nil }),
+		exec.WithHook(stackrunv1.LifecyclePostStart, func() error { postStart++; return nil }),
+	}
+	if err := tool.runWithOptions(context.Background(), "create a branch", false, options...); err != nil {
+		t.Fatalf("runWithOptions() error = %v", err)
+	}
+
+	if len(tools.calls) != 1 {
+		t.Fatalf("tool calls = %d, want 1", len(tools.calls))
+	}
+	if preStart != 1 || postStart != 1 {
+		t.Fatalf("hooks fired pre=%d post=%d, want 1 each", preStart, postStart)
+	}
+}
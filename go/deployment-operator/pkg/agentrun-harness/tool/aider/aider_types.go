@@ -0,0 +1,70 @@
+package aider
+
+import (
+	"strings"
+
+	console "github.com/pluralsh/console/go/client"
+	toolv1 "github.com/pluralsh/console/go/deployment-operator/pkg/agentrun-harness/tool/v1"
+	"github.com/pluralsh/console/go/deployment-operator/pkg/harness/exec"
+)
+
+const (
+	defaultModel    = "gpt-5.4"
+	openAIProvider  = "openai"
+	openAIAPIKeyEnv = "OPENAI_API_KEY"
+	openAIAPIBase   = "OPENAI_API_BASE"
+
+	// toolsPromptFile is the read-only context file describing the Plural tool
+	// protocol. Aider has no native MCP support, so the harness bridges tool calls.
+	toolsPromptFile = "PLURAL_TOOLS.md"
+	// toolCallFence is the info string of fenced blocks aider uses to request a Plural tool call.
+	toolCallFence = "plural-tool"
+	// maxToolRounds bounds how many times aider is re-invoked with tool results during a single run.
+	maxToolRounds = 25
+)
+
+// Aider implements the Aider CLI integration.
+type Aider struct {
+	toolv1.DefaultTool
+
+	onMessage    toolv1.MessageCallback
+	executable   exec.Executable
+	tools        toolCaller
+	toolsReady   bool
+	calls        int
+	model        string
+	apiKey       string
+	endpoint     string
+	consoleURL   string
+	consoleToken string
+
+	// reply collects the whole output of the current aider invocation so
+	// Plural tool calls can be extracted from it once the process exits.
+	reply strings.Builder
+	// message collects the assistant output since the last reported token usage.
+	message strings.Builder
+}
+
+// toolCall is a Plural tool call requested by aider in a fenced plural-tool block.
+type toolCall struct {
+	Name      string         `json:"name"`
+	Arguments map[string]any `json:"arguments,omitempty"`
+}
+
+func toolMessage(name string, state console.AgentMessageToolState, input, output string) *console.AgentMessageAttributes {
+	tool := &console.AgentMessageToolAttributes{
+		Name:   new(name),
+		State:  new(state),
+		Output: new(output),
+	}
+	if input != "" {
+		tool.Input = new(input)
+	}
+	return &console.AgentMessageAttributes{
+		Role:    console.AiRoleAssistant,
+		Message: "Called tool",
+		Metadata: &console.AgentMessageMetadataAttributes{
+			Tool: tool,
+		},
+	}
+}
@@ -0,0 +1,188 @@
+package aider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"k8s.io/klog/v2"
+
+	console "github.com/pluralsh/console/go/client"
+	toolv1 "github.com/pluralsh/console/go/deployment-operator/pkg/agentrun-harness/tool/v1"
+	"github.com/pluralsh/console/go/deployment-operator/pkg/log"
+)
+
+var toolCallBlock = regexp.MustCompile("(?s)```" + toolCallFence + "[ \t]*\r?\n(.*?)\r?\n[ \t]*```")
+
+// toolCaller executes Plural MCP tools on behalf of aider.
+type toolCaller interface {
+	ListTools(ctx context.Context) ([]mcp.Tool, error)
+	CallTool(ctx context.Context, name string, arguments map[string]any) (*mcp.CallToolResult, error)
+}
+
+// mcpBridge calls the Plural MCP server over streamable HTTP.
+type mcpBridge struct {
+	url string
+}
+
+func (in *mcpBridge) ListTools(ctx context.Context) ([]mcp.Tool, error) {
+	var tools []mcp.Tool
+	err := in.withClient(ctx, func(c *client.Client) error {
+		result, err := c.ListTools(ctx, mcp.ListToolsRequest{})
+		if err != nil {
+			return err
+		}
+		tools = result.Tools
+		return nil
+	})
+	return tools, err
+}
+
+func (in *mcpBridge) CallTool(ctx context.Context, name string, arguments map[string]any) (*mcp.CallToolResult, error) {
+	var result *mcp.CallToolResult
+	err := in.withClient(ctx, func(c *client.Client) error {
+		request := mcp.CallToolRequest{}
+		request.Params.Name = name
+		request.Params.Arguments = arguments
+
+		var err error
+		result, err = c.CallTool(ctx, request)
+		return err
+	})
+	return result, err
+}
+
+func (in *mcpBridge) withClient(ctx context.Context, f func(c *client.Client) error) error {
+	c, err := client.NewStreamableHttpClient(in.url)
+	if err != nil {
+		return fmt.Errorf("create mcp client: %w", err)
+	}
+	defer func() { _ = c.Close() }()
+
+	if err := c.Start(ctx); err != nil {
+		return fmt.Errorf("start mcp client: %w", err)
+	}
+
+	request := mcp.InitializeRequest{}
+	request.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	request.Params.ClientInfo = mcp.Implementation{Name: "agent-harness-aider", Version: "1.0.0"}
+	if _, err := c.Initialize(ctx, request); err != nil {
+		return fmt.Errorf("initialize mcp client: %w", err)
+	}
+
+	return f(c)
+}
+
+// parseToolCalls extracts Plural tool calls from aider output. Malformed blocks
+// are returned as calls without a name so the agent can be told to fix them.
+func parseToolCalls(output string) []toolCall {
+	matches := toolCallBlock.FindAllStringSubmatch(output, -1)
+	calls := make([]toolCall, 0, len(matches))
+	for _, match := range matches {
+		call := toolCall{}
+		if err := json.Unmarshal([]byte(strings.TrimSpace(match[1])), &call); err != nil {
+			klog.V(log.LogLevelDebug).InfoS("ignoring malformed aider tool call", "block", match[1], "error", err)
+		}
+		calls = append(calls, call)
+	}
+	return calls
+}
+
+// stripToolCalls removes plural-tool blocks from assistant text, as the calls
+// are reported as separate tool messages.
+func stripToolCalls(text string) string {
+	return strings.TrimSpace(toolCallBlock.ReplaceAllString(text, ""))
+}
+
+// callTools executes the requested tool calls and returns a prompt with their
+// results that is sent back to aider.
+func (in *Aider) callTools(ctx context.Context, calls []toolCall) string {
+	sb := strings.Builder{}
+	sb.WriteString("Results of the requested Plural tool calls:\n")
+	for i, call := range calls {
+		in.calls++
+		callID := fmt.Sprintf("aider-tool-%d", in.calls)
+		input := ""
+		if call.Arguments != nil {
+			if data, err := json.Marshal(call.Arguments); err == nil {
+				input = string(data)
+			}
+		}
+
+		if call.Name == "" {
+			sb.WriteString(fmt.Sprintf("\n### Call %d\nError: the %s block is not valid JSON with a \"name\" field.\n", i+1, toolCallFence))
+			continue
+		}
+
+		in.emit(toolMessage(call.Name, console.AgentMessageToolStateRunning, input, toolv1.RunningToolOutput), callID)
+		output, err := in.callTool(ctx, call)
+		state := console.AgentMessageToolStateCompleted
+		if err != nil {
+			state = console.AgentMessageToolStateError
+			output = fmt.Sprintf("Error: %s", err)
+		}
+		in.emit(toolMessage(call.Name, state, input, output), callID)
+
+		sb.WriteString(fmt.Sprintf("\n### %s\n%s\n", call.Name, output))
+	}
+	return sb.String()
+}
+
+func (in *Aider) callTool(ctx context.Context, call toolCall) (string, error) {
+	result, err := in.tools.CallTool(ctx, call.Name, call.Arguments)
+	if err != nil {
+		return "", err
+	}
+
+	output := resultText(result)
+	if result.IsError {
+		return "", fmt.Errorf("%s", output)
+	}
+	return output, nil
+}
+
+func resultText(result *mcp.CallToolResult) string {
+	if result == nil {
+		return ""
+	}
+
+	parts := make([]string, 0, len(result.Content))
+	for _, content := range result.Content {
+		if text, ok := mcp.AsTextContent(content); ok {
+			parts = append(parts, text.Text)
+		}
+	}
+	if len(parts) == 0 && result.StructuredContent != nil {
+		if data, err := json.Marshal(result.StructuredContent); err == nil {
+			parts = append(parts, string(data))
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// toolsPrompt describes the tool call protocol and the available Plural tools.
+func toolsPrompt(tools []mcp.Tool) string {
+	sb := strings.Builder{}
+	sb.WriteString("# Plural tools\n\n")
+	sb.WriteString("The Plural tools referenced in your instructions are not available as native tools. ")
+	sb.WriteString("To call one, end your reply with one fenced block per call, using the `" + toolCallFence + "` info string:\n\n")
+	sb.WriteString("```" + toolCallFence + "\n{\"name\": \"<tool name>\", \"arguments\": {<tool arguments>}}\n```\n\n")
+	sb.WriteString("Calls are executed in order after your reply, and their results are sent back to you in the next message. ")
+	sb.WriteString("Do not assume a call succeeded until you see its result. ")
+	sb.WriteString("Stop emitting tool blocks once the task is complete.\n\n")
+	sb.WriteString("## Available tools\n")
+	for _, tool := range tools {
+		sb.WriteString(fmt.Sprintf("\n### %s\n", tool.Name))
+		if tool.Description != "" {
+			sb.WriteString(tool.Description + "\n")
+		}
+		if schema, err := json.Marshal(tool.InputSchema); err == nil {
+			sb.WriteString(fmt.Sprintf("\nInput schema: `%s`\n", schema))
+		}
+	}
+	return sb.String()
+}
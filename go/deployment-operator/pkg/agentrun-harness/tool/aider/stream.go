@@ -0,0 +1,130 @@
+package aider
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	console "github.com/pluralsh/console/go/client"
+	"github.com/pluralsh/console/go/deployment-operator/pkg/agentrun-harness/usage"
+	"github.com/pluralsh/console/go/deployment-operator/pkg/log"
+)
+
+var (
+	// tokensLine matches the usage report aider prints after every model response, e.g.
+	// "Tokens: 2.5k sent, 1.1k cache write, 3.2k cache hit, 250 received. Cost: $0.01 message, $0.05 session."
+	tokensLine = regexp.MustCompile(`^Tokens: (\S+) sent(?:, (\S+) cache write)?(?:, (\S+) cache hit)?, (\S+) received\.(?: Cost: \$([\d.]+) message, \$([\d.]+) session\.)?`)
+	// appliedEditLine matches the confirmation aider prints for every edited file.
+	appliedEditLine = regexp.MustCompile(`^Applied edit to (.+)$`)
+	// addedFileLine matches the notice printed for files added to the chat.
+	addedFileLine = regexp.MustCompile(`^Added .+ to the chat`)
+)
+
+// ignoredPrefixes are aider status lines that are not part of the model response.
+var ignoredPrefixes = []string{
+	"Aider v",
+	"Main model:",
+	"Weak model:",
+	"Editor model:",
+	"Git repo:",
+	"Repo-map:",
+	"Restored previous conversation history",
+	"Cost estimates may be inaccurate",
+	"https://aider.chat/",
+}
+
+// handleStreamLine maps aider plain text output to agent messages. Aider has no
+// structured output mode, so the response is reported once its usage line is printed.
+func (in *Aider) handleStreamLine(line []byte) {
+	text := strings.TrimRight(string(line), "\r")
+	in.reply.WriteString(text)
+	in.reply.WriteString("\n")
+
+	if match := tokensLine.FindStringSubmatch(text); match != nil {
+		in.flushMessage(in.recordUsage(match))
+		return
+	}
+
+	if match := appliedEditLine.FindStringSubmatch(text); match != nil {
+		in.flushMessage(nil)
+		in.emit(toolMessage("edit", console.AgentMessageToolStateCompleted, match[1], text), "")
+		return
+	}
+
+	if addedFileLine.MatchString(text) || hasIgnoredPrefix(text) {
+		klog.V(log.LogLevelDebug).InfoS("ignoring aider status line", "line", text)
+		return
+	}
+
+	in.message.WriteString(text)
+	in.message.WriteString("\n")
+}
+
+// flushMessage reports the assistant output collected so far.
+func (in *Aider) flushMessage(cost *console.AgentMessageCostAttributes) {
+	text := stripToolCalls(in.message.String())
+	in.message.Reset()
+	if text == "" && cost == nil {
+		return
+	}
+	if text == "" {
+		text = "__plrl_ignore__"
+	}
+	in.emit(&console.AgentMessageAttributes{Role: console.AiRoleAssistant, Message: text, Cost: cost}, "")
+}
+
+func (in *Aider) recordUsage(match []string) *console.AgentMessageCostAttributes {
+	sent := parseTokens(match[1])
+	cached := parseTokens(match[2]) + parseTokens(match[3])
+	received := parseTokens(match[4])
+	cost, _ := strconv.ParseFloat(match[5], 64)
+
+	in.Config.Usage.RecordUsage(usage.Record{
+		InputTokens:  sent,
+		OutputTokens: received,
+		TotalTokens:  sent + cached + received,
+		CachedTokens: cached,
+		TotalCost:    cost,
+	})
+	return &console.AgentMessageCostAttributes{
+		Total: cost,
+		Tokens: &console.AgentMessageTokensAttributes{
+			Input:  new(float64(sent)),
+			Output: new(float64(received)),
+		},
+	}
+}
+
+// parseTokens parses aider's human readable token counts, e.g. "250", "1.2k" or "3.4M".
+func parseTokens(value string) int64 {
+	if value == "" {
+		return 0
+	}
+
+	multiplier := 1.0
+	switch {
+	case strings.HasSuffix(value, "k"), strings.HasSuffix(value, "K"):
+		multiplier = 1_000
+		value = value[:len(value)-1]
+	case strings.HasSuffix(value, "M"), strings.HasSuffix(value, "m"):
+		multiplier = 1_000_000
+		value = value[:len(value)-1]
+	}
+
+	parsed, err := strconv.ParseFloat(strings.ReplaceAll(value, ",", ""), 64)
+	if err != nil {
+		return 0
+	}
+	return int64(parsed * multiplier)
+}
+
+func hasIgnoredPrefix(line string) bool {
+	for _, prefix := range ignoredPrefixes {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,390 @@
+package goose
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	console "github.com/pluralsh/console/go/client"
+	proxymodel "github.com/pluralsh/console/go/deployment-operator/pkg/agentrun-harness/model"
+	"github.com/pluralsh/console/go/deployment-operator/pkg/agentrun-harness/tool/artifacts"
+	v1 "github.com/pluralsh/console/go/deployment-operator/pkg/agentrun-harness/tool/v1"
+	"github.com/pluralsh/console/go/deployment-operator/pkg/agentrun-harness/usage"
+	"github.com/pluralsh/console/go/deployment-operator/pkg/common"
+	"github.com/pluralsh/console/go/deployment-operator/pkg/harness/exec"
+	"github.com/pluralsh/console/go/deployment-operator/pkg/log"
+)
+
+func New(config v1.Config) v1.Tool {
+	runtimeConfig := config.Run.Runtime.Config.Goose
+	result := &Goose{
+		DefaultTool: v1.DefaultTool{Config: config},
+		sessionName: fmt.Sprintf("agent-run-%s", config.Run.ID),
+		model:       defaultModel,
+		provider:    openAIProvider,
+		toolCalls:   make(map[string]toolRequest),
+	}
+	if runtimeConfig != nil {
+		if runtimeConfig.Model != "" {
+			result.model = runtimeConfig.Model
+		}
+		if runtimeConfig.Provider != "" {
+			result.provider = runtimeConfig.Provider
+		}
+		result.apiKey = runtimeConfig.APIKey
+		if runtimeConfig.Endpoint != nil {
+			result.endpoint = *runtimeConfig.Endpoint
+		}
+	}
+	if config.Run.IsProxyEnabled() {
+		// The Plural AI proxy exposes an OpenAI-compatible API for every upstream provider.
+		result.provider = openAIProvider
+		result.model = proxymodel.ProxyModel(console.AgentRuntimeTypeGoose, result.model)
+	}
+	if err := result.ensure(); err != nil {
+		klog.Fatalf("failed to initialize goose tool: %v", err)
+	}
+	return result
+}
+
+func (in *Goose) ensure() error {
+	if in.Config.WorkDir == "" {
+		return fmt.Errorf("work directory is not set")
+	}
+	if in.Config.RepositoryDir == "" {
+		return fmt.Errorf("repository directory is not set")
+	}
+	if in.Config.Run == nil || in.Config.Run.Runtime == nil || in.Config.Run.Runtime.Config == nil || in.Config.Run.Runtime.Config.Goose == nil {
+		return fmt.Errorf("goose runtime configuration is not set")
+	}
+	if in.provider != openAIProvider && in.provider != anthropicProvider {
+		return fmt.Errorf("unsupported goose provider %q", in.provider)
+	}
+	return nil
+}
+
+func (in *Goose) Run(ctx context.Context, options ...exec.Option) {
+	go in.start(ctx, in.Config.Run.Prompt, options...)
+}
+
+func (in *Goose) Configure(consoleURL, consoleToken string) error {
+	in.consoleURL = consoleURL
+	in.consoleToken = consoleToken
+	if err := in.ConfigureSystemPrompt(console.AgentRuntimeTypeGoose); err != nil {
+		return err
+	}
+	return in.ConfigureSkills(in.skillsPath())
+}
+
+func (in *Goose) ConfigureBabysitRun() error {
+	if err := in.ConfigureSystemPromptForBabysitRun(console.AgentRuntimeTypeGoose); err != nil {
+		return err
+	}
+	return in.ConfigureSkills(in.skillsPath())
+}
+
+func (in *Goose) OnMessage(f v1.MessageCallback) {
+	in.onMessage = f
+}
+
+func (in *Goose) BabysitRun(ctx context.Context, bCtx *v1.BabysitContext) bool {
+	if bCtx == nil {
+		return false
+	}
+	if err := in.run(ctx, bCtx.Prompt, true); err != nil {
+		in.Config.ErrorChan <- err
+	}
+	return false
+}
+
+// FollowUpRun resumes the named goose session created by the initial run.
+func (in *Goose) FollowUpRun(ctx context.Context, prompt string) error {
+	return in.run(ctx, prompt, false)
+}
+
+func (in *Goose) start(ctx context.Context, prompt string, options ...exec.Option) {
+	in.emit(&console.AgentMessageAttributes{Message: prompt, Role: console.AiRoleUser}, "")
+	if err := in.runWithOptions(ctx, prompt, options...); err != nil {
+		klog.ErrorS(err, "goose execution failed")
+		in.Config.ErrorChan <- err
+	}
+}
+
+func (in *Goose) run(ctx context.Context, prompt string, emitUser bool) error {
+	if emitUser {
+		in.emit(&console.AgentMessageAttributes{Message: prompt, Role: console.AiRoleUser}, "")
+	}
+	return in.runWithOptions(ctx, prompt)
+}
+
+func (in *Goose) runWithOptions(ctx context.Context, prompt string, options ...exec.Option) error {
+	systemPrompt, err := in.systemPrompt()
+	if err != nil {
+		return err
+	}
+
+	resume := in.started
+	in.started = true
+	in.executable = exec.NewExecutable(
+		"goose",
+		append(options,
+			exec.WithArgs(in.args(prompt, systemPrompt, resume)),
+			exec.WithEnv(in.env()),
+			exec.WithDir(in.Config.RepositoryDir),
+			exec.WithTimeout(in.Config.Run.Runtime.Config.Goose.Timeout),
+		)...,
+	)
+	err = in.executable.RunStream(ctx, in.handleStreamLine)
+	in.flushText()
+	return err
+}
+
+func (in *Goose) args(prompt, systemPrompt string, resume bool) []string {
+	args := []string{
+		"run",
+		"--output-format", "stream-json",
+		"--name", in.sessionName,
+	}
+	if resume {
+		args = append(args, "--resume")
+	}
+	return append(args,
+		"--system", systemPrompt,
+		"--with-builtin", "developer",
+		"--with-streamable-http-extension", common.AgentMCPServerURL,
+		"--with-extension", fmt.Sprintf("%s=%s %s", common.CodebaseMemoryCacheEnv, common.CodebaseMemoryCacheDir, common.CodebaseMemoryMCPCommand),
+		"--text", prompt,
+	)
+}
+
+func (in *Goose) env() []string {
+	env := []string{
+		fmt.Sprintf("GOOSE_PATH_ROOT=%s", in.gooseHome()),
+		fmt.Sprintf("GOOSE_PROVIDER=%s", in.provider),
+		fmt.Sprintf("GOOSE_MODEL=%s", in.model),
+		"GOOSE_MODE=auto",
+		"GOOSE_DISABLE_KEYRING=1",
+	}
+
+	if in.Config.Run.IsProxyEnabled() {
+		endpoint := fmt.Sprintf("%s/ext/ai/v1", in.consoleURL)
+		if in.Config.Run.IsStreamingProxyEnabled() {
+			endpoint = common.AgentOpenAIBaseURL
+		}
+		host, basePath := openAIHost(endpoint)
+		return append(env,
+			fmt.Sprintf("OPENAI_API_KEY=%s", in.consoleToken),
+			fmt.Sprintf("OPENAI_HOST=%s", host),
+			fmt.Sprintf("OPENAI_BASE_PATH=%s", basePath),
+		)
+	}
+
+	if in.provider == anthropicProvider {
+		env = append(env, fmt.Sprintf("ANTHROPIC_API_KEY=%s", in.apiKey))
+		if in.endpoint != "" {
+			env = append(env, fmt.Sprintf("ANTHROPIC_HOST=%s", in.endpoint))
+		}
+		return env
+	}
+
+	env = append(env, fmt.Sprintf("OPENAI_API_KEY=%s", in.apiKey))
+	if in.endpoint != "" {
+		host, basePath := openAIHost(in.endpoint)
+		env = append(env,
+			fmt.Sprintf("OPENAI_HOST=%s", host),
+			fmt.Sprintf("OPENAI_BASE_PATH=%s", basePath),
+		)
+	}
+	return env
+}
+
+// openAIHost splits an OpenAI-compatible base URL, e.g. https://example.com/ext/ai/v1,
+// into the host and chat completions path goose expects.
+func openAIHost(endpoint string) (host, basePath string) {
+	parsed, err := url.Parse(strings.TrimSuffix(endpoint, "/"))
+	if err != nil || parsed.Host == "" {
+		return endpoint, defaultOpenAIBasePath
+	}
+
+	basePath = strings.TrimPrefix(parsed.Path, "/")
+	if basePath == "" {
+		basePath = defaultOpenAIBasePath
+	} else if !strings.HasSuffix(basePath, "chat/completions") {
+		basePath += "/chat/completions"
+	}
+	return fmt.Sprintf("%s://%s", parsed.Scheme, parsed.Host), basePath
+}
+
+// systemPrompt returns the rendered system prompt extended with the location
+// of sideloaded skills, as goose does not discover them on its own.
+func (in *Goose) systemPrompt() (string, error) {
+	content, err := os.ReadFile(in.systemPromptPath())
+	if err != nil {
+		return "", fmt.Errorf("read goose system prompt: %w", err)
+	}
+
+	skills, _ := filepath.Glob(filepath.Join(in.skillsPath(), "*", "SKILL.md"))
+	if len(skills) == 0 {
+		return string(content), nil
+	}
+
+	sb := strings.Builder{}
+	sb.Write(content)
+	sb.WriteString("\n\n# Skills\n\nThe following skills are available. Read a skill file before starting a task that matches its description.\n\n")
+	for _, skill := range skills {
+		sb.WriteString(fmt.Sprintf("- %s\n", skill))
+	}
+	return sb.String(), nil
+}
+
+func (in *Goose) gooseHome() string {
+	return filepath.Join(in.Config.WorkDir, ".goose")
+}
+
+func (in *Goose) systemPromptPath() string {
+	return filepath.Join(in.gooseHome(), v1.SystemPromptFile)
+}
+
+func (in *Goose) skillsPath() string {
+	return filepath.Join(in.gooseHome(), "skills")
+}
+
+func (in *Goose) sessionsPath() string {
+	return filepath.Join(in.gooseHome(), "data", "sessions")
+}
+
+func (in *Goose) UploadArtifacts(ctx context.Context) (*artifacts.UploadArtifacts, error) {
+	return in.BuildUploadArtifacts(ctx, artifacts.BuildArtifactsOptions{
+		Provider:  "goose",
+		Source:    artifacts.SessionSource{Path: in.sessionsPath(), ArchivePath: "sessions"},
+		SessionID: in.sessionName,
+	})
+}
+
+func (in *Goose) handleStreamLine(line []byte) {
+	var event StreamEvent
+	if err := json.Unmarshal(line, &event); err != nil {
+		klog.V(log.LogLevelDebug).InfoS("ignoring non-json goose stream line", "line", string(line))
+		return
+	}
+
+	switch event.Type {
+	case "message":
+		in.handleMessage(event.Message)
+	case "error":
+		in.flushText()
+		if event.Error != "" {
+			in.emit(&console.AgentMessageAttributes{Role: console.AiRoleAssistant, Message: event.Error}, "")
+		}
+	case "complete":
+		in.flushText()
+		in.recordUsage(event.TotalTokens)
+	}
+}
+
+func (in *Goose) handleMessage(message *Message) {
+	if message == nil {
+		return
+	}
+
+	for _, content := range message.Content {
+		switch content.Type {
+		case "text":
+			if message.Role != "assistant" {
+				continue
+			}
+			// Streaming providers emit chunks of the same message, which are
+			// reported as a single message once it is complete.
+			if message.ID != in.pendingID {
+				in.flushText()
+				in.pendingID = message.ID
+			}
+			in.pending.WriteString(content.Text)
+		case "toolRequest":
+			in.flushText()
+			request := content.request()
+			in.toolCalls[content.ID] = request
+			in.emit(toolMessage(request.Name, console.AgentMessageToolStateRunning, rawString(request.Arguments), v1.RunningToolOutput), content.ID)
+		case "toolResponse":
+			request := in.toolCalls[content.ID]
+			delete(in.toolCalls, content.ID)
+			state, output := content.response()
+			in.emit(toolMessage(request.Name, state, rawString(request.Arguments), output), content.ID)
+		}
+	}
+}
+
+// flushText reports the buffered assistant text.
+func (in *Goose) flushText() {
+	text := strings.TrimSpace(in.pending.String())
+	in.pending.Reset()
+	in.pendingID = ""
+	if text == "" {
+		return
+	}
+	in.emit(&console.AgentMessageAttributes{Role: console.AiRoleAssistant, Message: text}, "")
+}
+
+// recordUsage records the tokens used since the last report. Goose reports the
+// cumulative token count of the session, which also spans resumed runs.
+func (in *Goose) recordUsage(total *int64) {
+	if total == nil {
+		return
+	}
+
+	delta := *total - in.totalTokens
+	if delta < 0 {
+		delta = *total
+	}
+	in.totalTokens = *total
+	in.Config.Usage.RecordUsage(usage.Record{TotalTokens: delta})
+}
+
+func (in *Goose) emit(message *console.AgentMessageAttributes, callID string) {
+	if message != nil && in.onMessage != nil {
+		in.onMessage(message, callID)
+	}
+}
+
+func (in MessageContent) request() toolRequest {
+	request := toolRequest{Name: "unknown"}
+	if in.ToolCall == nil || in.ToolCall.Status != "success" {
+		return request
+	}
+	_ = json.Unmarshal(in.ToolCall.Value, &request)
+	return request
+}
+
+func (in MessageContent) response() (console.AgentMessageToolState, string) {
+	if in.ToolResult == nil {
+		return console.AgentMessageToolStateCompleted, ""
+	}
+	if in.ToolResult.Status != "success" {
+		return console.AgentMessageToolStateError, in.ToolResult.Error
+	}
+
+	var contents []textContent
+	if err := json.Unmarshal(in.ToolResult.Value, &contents); err != nil {
+		return console.AgentMessageToolStateCompleted, rawString(in.ToolResult.Value)
+	}
+	parts := make([]string, 0, len(contents))
+	for _, content := range contents {
+		if content.Type == "text" {
+			parts = append(parts, content.Text)
+		}
+	}
+	return console.AgentMessageToolStateCompleted, strings.Join(parts, "\n")
+}
+
+func rawString(value json.RawMessage) string {
+	if len(value) == 0 || string(value) == "null" {
+		return ""
+	}
+	return string(value)
+}
@@ -0,0 +1,91 @@
+package goose
+
+import (
+	"reflect"
+	"testing"
+
+	console "github.com/pluralsh/console/go/client"
+	toolv1 "github.com/pluralsh/console/go/deployment-operator/pkg/agentrun-harness/tool/v1"
+	"github.com/pluralsh/console/go/deployment-operator/pkg/agentrun-harness/usage"
+	"github.com/pluralsh/console/go/deployment-operator/pkg/common"
+)
+
+func TestArgsResumesNamedSession(t *testing.T) {
+	tool := &Goose{sessionName: "agent-run-1"}
+	want := []string{
+		"run",
+		"--output-format", "stream-json",
+		"--name", "agent-run-1",
+		"--resume",
+		"--system", "be helpful",
+		"--with-builtin", "developer",
+		"--with-streamable-http-extension", common.AgentMCPServerURL,
+		"--with-extension", common.CodebaseMemoryCacheEnv + "=" + common.CodebaseMemoryCacheDir + " " + common.CodebaseMemoryMCPCommand,
+		"--text", "fix the test",
+	}
+	if got := tool.args("fix the test", "be helpful", true); !reflect.DeepEqual(got, want) {
+		t.Fatalf("args = %#v, want %#v", got, want)
+	}
+}
+
+func TestOpenAIHost(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		host     string
+		basePath string
+	}{
+		{"https://console.example.com/ext/ai/v1", "https://console.example.com", "ext/ai/v1/chat/completions"},
+		{"https://api.openai.com", "https://api.openai.com", defaultOpenAIBasePath},
+		{"http://127.0.0.1:8080/v1/", "http://127.0.0.1:8080", "v1/chat/completions"},
+	}
+	for _, tt := range tests {
+		host, basePath := openAIHost(tt.endpoint)
+		if host != tt.host || basePath != tt.basePath {
+			t.Fatalf("openAIHost(%q) = %q, %q, want %q, %q", tt.endpoint, host, basePath, tt.host, tt.basePath)
+		}
+	}
+}
+
+func TestHandleStreamLineMapsMessages(t *testing.T) {
+	var messages []*console.AgentMessageAttributes
+	var callIDs []string
+	tool := &Goose{
+		DefaultTool: toolv1.DefaultTool{Config: toolv1.Config{Usage: usage.New(nil)}},
+		toolCalls:   make(map[string]toolRequest),
+		totalTokens: 100,
+	}
+	tool.OnMessage(func(message *console.AgentMessageAttributes, callID string) {
+		messages = append(messages, message)
+		callIDs = append(callIDs, callID)
+	})
+
+	for _, line := range []string{
+		`starting session`,
+		`{"type":"message","message":{"id":"m1","role":"assistant","content":[{"type":"text","text":"Running "}]}}`,
+		`{"type":"message","message":{"id":"m1","role":"assistant","content":[{"type":"text","text":"tests."}]}}`,
+		`{"type":"message","message":{"id":"m2","role":"assistant","content":[{"type":"toolRequest","id":"call-1","toolCall":{"status":"success","value":{"name":"developer__shell","arguments":{"command":"go test ./..."}}}}]}}`,
+		`{"type":"message","message":{"id":"m3","role":"user","content":[{"type":"toolResponse","id":"call-1","toolResult":{"status":"success","value":[{"type":"text","text":"ok"}]}}]}}`,
+		`{"type":"message","message":{"id":"m4","role":"assistant","content":[{"type":"text","text":"Done."}]}}`,
+		`{"type":"complete","total_tokens":350}`,
+	} {
+		tool.handleStreamLine([]byte(line))
+	}
+
+	if len(messages) != 4 {
+		t.Fatalf("messages = %d, want 4", len(messages))
+	}
+	if messages[0].Message != "Running tests." || messages[3].Message != "Done." {
+		t.Fatalf("unexpected assistant messages %q, %q", messages[0].Message, messages[3].Message)
+	}
+	if *messages[1].Metadata.Tool.State != console.AgentMessageToolStateRunning || callIDs[1] != "call-1" {
+		t.Fatalf("expected running tool message, got %#v", messages[1])
+	}
+	end := messages[2].Metadata.Tool
+	if *end.State != console.AgentMessageToolStateCompleted || *end.Name != "developer__shell" || *end.Output != "ok" {
+		t.Fatalf("expected completed tool message, got %#v", end)
+	}
+
+	if tokens, _ := tool.Config.Usage.Totals(); tokens != 250 {
+		t.Fatalf("tokens = %d, want 250", tokens)
+	}
+}
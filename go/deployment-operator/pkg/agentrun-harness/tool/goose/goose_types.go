@@ -0,0 +1,100 @@
+package goose
+
+import (
+	"encoding/json"
+	"strings"
+
+	console "github.com/pluralsh/console/go/client"
+	toolv1 "github.com/pluralsh/console/go/deployment-operator/pkg/agentrun-harness/tool/v1"
+	"github.com/pluralsh/console/go/deployment-operator/pkg/harness/exec"
+)
+
+const (
+	defaultModel      = "gpt-5.4"
+	openAIProvider    = "openai"
+	anthropicProvider = "anthropic"
+	// defaultOpenAIBasePath is the chat completions path goose appends to OPENAI_HOST.
+	defaultOpenAIBasePath = "v1/chat/completions"
+)
+
+// Goose implements the Goose CLI integration.
+type Goose struct {
+	toolv1.DefaultTool
+
+	onMessage    toolv1.MessageCallback
+	executable   exec.Executable
+	sessionName  string
+	started      bool
+	model        string
+	provider     string
+	apiKey       string
+	endpoint     string
+	consoleURL   string
+	consoleToken string
+
+	// pendingID and pending collect streamed text chunks of the current assistant message.
+	pendingID string
+	pending   strings.Builder
+	// toolCalls keeps requested tool calls, so responses can be reported with their name and input.
+	toolCalls map[string]toolRequest
+	// totalTokens is the last cumulative session token count reported by goose.
+	totalTokens int64
+}
+
+// StreamEvent is an event emitted by `goose run --output-format stream-json`.
+type StreamEvent struct {
+	Type        string   `json:"type"`
+	Message     *Message `json:"message,omitempty"`
+	Error       string   `json:"error,omitempty"`
+	TotalTokens *int64   `json:"total_tokens,omitempty"`
+}
+
+type Message struct {
+	ID      string           `json:"id,omitempty"`
+	Role    string           `json:"role,omitempty"`
+	Content []MessageContent `json:"content,omitempty"`
+}
+
+type MessageContent struct {
+	Type       string      `json:"type"`
+	Text       string      `json:"text,omitempty"`
+	ID         string      `json:"id,omitempty"`
+	ToolCall   *ToolResult `json:"toolCall,omitempty"`
+	ToolResult *ToolResult `json:"toolResult,omitempty"`
+}
+
+// ToolResult is a serialized Rust result, either {"status":"success","value":...}
+// or {"status":"error","error":"..."}.
+type ToolResult struct {
+	Status string          `json:"status"`
+	Value  json.RawMessage `json:"value,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+type toolRequest struct {
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+}
+
+type textContent struct {
+	Type string `json:"type"`
+	Text string `json:"text,omitempty"`
+}
+
+func toolMessage(name string, state console.AgentMessageToolState, input, output string) *console.AgentMessageAttributes {
+	tool := &console.AgentMessageToolAttributes{
+		Name:   new(name),
+		State:  new(state),
+		Output: new(output),
+	}
+	if input != "" {
+		tool.Input = new(input)
+	}
+	return &console.AgentMessageAttributes{
+		Role:    console.AiRoleAssistant,
+		Message: "Called tool",
+		Metadata: &console.AgentMessageMetadataAttributes{
+			Tool: tool,
+		},
+	}
+}
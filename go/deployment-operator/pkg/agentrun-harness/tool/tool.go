@@ -4,9 +4,11 @@ import (
 	"fmt"
 
 	console "github.com/pluralsh/console/go/client"
+	"github.com/pluralsh/console/go/deployment-operator/pkg/agentrun-harness/tool/aider"
 	"github.com/pluralsh/console/go/deployment-operator/pkg/agentrun-harness/tool/claude"
 	"github.com/pluralsh/console/go/deployment-operator/pkg/agentrun-harness/tool/codex"
 	"github.com/pluralsh/console/go/deployment-operator/pkg/agentrun-harness/tool/gemini"
+	"github.com/pluralsh/console/go/deployment-operator/pkg/agentrun-harness/tool/goose"
 	"github.com/pluralsh/console/go/deployment-operator/pkg/agentrun-harness/tool/opencode"
 	"github.com/pluralsh/console/go/deployment-operator/pkg/agentrun-harness/tool/pi"
 	v1 "github.com/pluralsh/console/go/deployment-operator/pkg/agentrun-harness/tool/v1"
@@ -30,6 +32,10 @@ func New(runtimeType console.AgentRuntimeType, config v1.Config) (v1.Tool, error
 		return codex.New(config), nil
 	case console.AgentRuntimeTypePi:
 		return pi.New(config), nil
+	case console.AgentRuntimeTypeAider:
+		return aider.New(config), nil
+	case console.AgentRuntimeTypeGoose:
+		return goose.New(config), nil
 
 	default:
 		return nil, fmt.Errorf("unsupported agent run type: %s", runtimeType)
@@ -45,6 +45,10 @@ func (in DefaultTool) ConfigureSystemPrompt(runtime console.AgentRuntimeType) er
 		providerDir = ".codex"
 	case console.AgentRuntimeTypePi:
 		providerDir = ".pi/agent"
+	case console.AgentRuntimeTypeAider:
+		providerDir = ".aider"
+	case console.AgentRuntimeTypeGoose:
+		providerDir = ".goose"
 	}
 
 	outputFile := path.Join(in.Config.WorkDir, providerDir, SystemPromptFile)
@@ -83,6 +87,10 @@ func (in DefaultTool) ConfigureSystemPromptForBabysitRun(runtime console.AgentRu
 		providerDir = ".codex"
 	case console.AgentRuntimeTypePi:
 		providerDir = ".pi/agent"
+	case console.AgentRuntimeTypeAider:
+		providerDir = ".aider"
+	case console.AgentRuntimeTypeGoose:
+		providerDir = ".goose"
 	}
 
 	outputFile := path.Join(in.Config.WorkDir, providerDir, SystemPromptFile)
@@ -86,6 +86,17 @@ func (u *Usage) RecordUsage(record Record) {
 	u.mu.Unlock()
 }
 
+// Totals returns the total number of tokens and the total cost accumulated so far.
+func (u *Usage) Totals() (tokens int64, cost float64) {
+	if u == nil {
+		return 0, 0
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.totalTokens, u.totalCost
+}
+
 func (u *Usage) Attributes() *console.AiUsageAttributes {
 	if u == nil {
 		return nil
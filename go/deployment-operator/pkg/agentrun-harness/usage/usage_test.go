@@ -36,6 +36,21 @@ func TestRecordUsageAccumulates(t *testing.T) {
 	require.Equal(t, 0.25, *attrs.TotalCost)
 }
 
+func TestTotals(t *testing.T) {
+	var empty *Usage
+	tokens, cost := empty.Totals()
+	require.Zero(t, tokens)
+	require.Zero(t, cost)
+
+	u := New(nil)
+	u.RecordUsage(Record{InputTokens: 10, OutputTokens: 5, TotalCost: 0.25})
+	u.RecordUsage(Record{TotalTokens: 20, TotalCost: 0.5})
+
+	tokens, cost = u.Totals()
+	require.Equal(t, int64(35), tokens)
+	require.Equal(t, 0.75, cost)
+}
+
 func TestNewPreservesExistingUsage(t *testing.T) {
 	input := int64(10)
 	totalCost := 0.5
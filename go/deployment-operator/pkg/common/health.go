@@ -886,6 +886,14 @@ func GetHealthCheckFuncByGroupVersionKind(gvk schema.GroupVersionKind) func(obj
 }
 
 func GetOtherHealthStatus(obj *unstructured.Unstructured) (*HealthStatus, error) {
+	if isCrossplaneResource(obj) {
+		return getHealthLibraryStatus(obj, crossplaneHealthScript)
+	}
+
+	return getReadyConditionHealthStatus(obj)
+}
+
+func getReadyConditionHealthStatus(obj *unstructured.Unstructured) (*HealthStatus, error) {
 	defaultReadyStatus := &HealthStatus{
 		Status: HealthStatusHealthy,
 	}
@@ -0,0 +1,8 @@
+-- Composite resource definitions serve the composite resource API once Established, and
+-- the claim API once Offered when they define claim names.
+local established = conditionHealth(Obj, "Established")
+if established.status == "Healthy" and Obj.spec ~= nil and Obj.spec.claimNames ~= nil then
+  healthStatus = conditionHealth(Obj, "Offered")
+else
+  healthStatus = established
+end
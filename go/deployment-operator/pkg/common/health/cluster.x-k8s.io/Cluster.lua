@@ -0,0 +1,11 @@
+if Obj.spec ~= nil and Obj.spec.paused == true then
+  healthStatus = { status = "Suspended", message = "Cluster is paused" }
+elseif Obj.status ~= nil and Obj.status.phase == "Failed" then
+  healthStatus = { status = "Degraded", message = Obj.status.failureMessage }
+elseif Obj.status ~= nil and Obj.status.phase == "Provisioned" and getCondition(Obj, "Available") ~= nil then
+  healthStatus = conditionHealth(Obj, "Available")
+elseif Obj.status ~= nil and Obj.status.phase == "Provisioned" then
+  healthStatus = conditionHealth(Obj, "Ready")
+else
+  healthStatus = { status = "Progressing", message = "Waiting for the cluster to be provisioned" }
+end
@@ -0,0 +1,18 @@
+if Obj.spec ~= nil and Obj.spec.paused == true then
+  healthStatus = { status = "Suspended", message = "MachineDeployment is paused" }
+elseif Obj.status == nil then
+  healthStatus = { status = "Progressing", message = "Waiting for the status to be reported" }
+elseif Obj.status.phase == "Failed" then
+  healthStatus = { status = "Degraded", message = "MachineDeployment has failed" }
+elseif not isGenerationObserved(Obj) then
+  healthStatus = { status = "Progressing", message = "Waiting for the latest generation to be observed" }
+else
+  local replicas = Obj.status.replicas or 0
+  local ready = Obj.status.readyReplicas or 0
+  local updated = Obj.status.updatedReplicas or 0
+  if Obj.status.phase == "Running" and ready == replicas and updated == replicas then
+    healthStatus = { status = "Healthy" }
+  else
+    healthStatus = { status = "Progressing", message = "Waiting for " .. tostring(replicas - ready) .. " machines to be ready" }
+  end
+end
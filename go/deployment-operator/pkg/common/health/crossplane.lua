@@ -0,0 +1,11 @@
+-- Crossplane managed resources, composite resources and claims report whether the desired
+-- state was synced with the Synced condition and whether it is available with Ready.
+local synced = getCondition(Obj, "Synced")
+local ready = getCondition(Obj, "Ready")
+if synced ~= nil and synced.status == "False" then
+  healthStatus = { status = "Degraded", message = synced.message }
+elseif ready ~= nil and ready.status == "False" and (ready.reason == "Creating" or ready.reason == "Deleting") then
+  healthStatus = { status = "Progressing", message = ready.message }
+else
+  healthStatus = conditionHealth(Obj, "Ready")
+end
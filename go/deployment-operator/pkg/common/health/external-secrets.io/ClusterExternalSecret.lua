@@ -0,0 +1 @@
+healthStatus = conditionHealth(Obj, "Ready")
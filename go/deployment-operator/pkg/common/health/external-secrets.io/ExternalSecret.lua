@@ -0,0 +1,5 @@
+if not isGenerationObserved(Obj) then
+  healthStatus = { status = "Progressing", message = "Waiting for the latest generation to be observed" }
+else
+  healthStatus = conditionHealth(Obj, "Ready")
+end
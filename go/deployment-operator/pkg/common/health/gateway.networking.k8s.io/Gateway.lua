@@ -0,0 +1,6 @@
+local accepted = getCondition(Obj, "Accepted")
+if accepted ~= nil and accepted.status == "False" then
+  healthStatus = { status = "Degraded", message = accepted.message }
+else
+  healthStatus = conditionHealth(Obj, "Programmed")
+end
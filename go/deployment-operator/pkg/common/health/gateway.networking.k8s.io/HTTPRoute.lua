@@ -0,0 +1 @@
+healthStatus = routeHealth(Obj)
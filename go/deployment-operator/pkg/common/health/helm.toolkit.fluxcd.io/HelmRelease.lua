@@ -0,0 +1 @@
+healthStatus = fluxHealth(Obj)
@@ -0,0 +1,6 @@
+local paused = getCondition(Obj, "Paused")
+if paused ~= nil and paused.status == "True" then
+  healthStatus = { status = "Suspended", message = paused.message }
+else
+  healthStatus = conditionHealth(Obj, "Ready")
+end
@@ -0,0 +1,107 @@
+-- Helpers shared by all scripts of the built-in health library.
+
+function getCondition(obj, conditionType)
+  if obj.status == nil or obj.status.conditions == nil then
+    return nil
+  end
+
+  for _, condition in ipairs(obj.status.conditions) do
+    if condition.type == conditionType then
+      return condition
+    end
+  end
+
+  return nil
+end
+
+function isGenerationObserved(obj)
+  if obj.status == nil or obj.status.observedGeneration == nil or obj.metadata.generation == nil then
+    return true
+  end
+
+  return obj.status.observedGeneration >= obj.metadata.generation
+end
+
+-- conditionHealth maps a condition to a health status: True is healthy, False is degraded
+-- and a missing or unknown condition means the resource is still progressing.
+function conditionHealth(obj, conditionType)
+  local condition = getCondition(obj, conditionType)
+  if condition == nil then
+    return { status = "Progressing", message = "Waiting for the " .. conditionType .. " condition" }
+  end
+
+  if condition.status == "True" then
+    return { status = "Healthy", message = condition.message }
+  end
+
+  if condition.status == "False" then
+    return { status = "Degraded", message = condition.message }
+  end
+
+  return { status = "Progressing", message = condition.message }
+end
+
+-- fluxHealth assesses Flux objects, which report progress with the Reconciling condition
+-- and the result with the Ready condition.
+function fluxHealth(obj)
+  if obj.spec ~= nil and obj.spec.suspend == true then
+    return { status = "Suspended", message = "Reconciliation is suspended" }
+  end
+
+  if not isGenerationObserved(obj) then
+    return { status = "Progressing", message = "Waiting for the latest generation to be reconciled" }
+  end
+
+  local reconciling = getCondition(obj, "Reconciling")
+  if reconciling ~= nil and reconciling.status == "True" then
+    return { status = "Progressing", message = reconciling.message }
+  end
+
+  local ready = getCondition(obj, "Ready")
+  if ready ~= nil and ready.status == "False" and (ready.reason == "Progressing" or ready.reason == "DependencyNotReady") then
+    return { status = "Progressing", message = ready.message }
+  end
+
+  return conditionHealth(obj, "Ready")
+end
+
+-- routeHealth assesses Gateway API routes, which report conditions for every parent gateway.
+function routeHealth(obj)
+  if obj.status == nil or obj.status.parents == nil or #obj.status.parents == 0 then
+    return { status = "Progressing", message = "Waiting for the route to be attached to a gateway" }
+  end
+
+  for _, parent in ipairs(obj.status.parents) do
+    if parent.conditions ~= nil then
+      for _, condition in ipairs(parent.conditions) do
+        if (condition.type == "Accepted" or condition.type == "ResolvedRefs") and condition.status == "False" then
+          return { status = "Degraded", message = condition.message }
+        end
+      end
+    end
+
+    local accepted = getCondition({ status = parent }, "Accepted")
+    if accepted == nil or accepted.status ~= "True" then
+      return { status = "Progressing", message = "Waiting for the route to be accepted" }
+    end
+  end
+
+  return { status = "Healthy" }
+end
+
+-- crossplanePackageHealth assesses Crossplane packages (providers, functions and
+-- configurations), which report the installation with the Installed condition and the
+-- package runtime with the Healthy condition.
+function crossplanePackageHealth(obj)
+  local installed = getCondition(obj, "Installed")
+  if installed ~= nil and installed.status == "False" and installed.reason == "UnpackingPackage" then
+    return { status = "Progressing", message = installed.message }
+  end
+
+  local health = conditionHealth(obj, "Installed")
+  if health.status ~= "Healthy" then
+    return health
+  end
+
+  return conditionHealth(obj, "Healthy")
+end
@@ -0,0 +1 @@
+healthStatus = crossplanePackageHealth(Obj)
@@ -0,0 +1,155 @@
+package common
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// HealthLibraryVersion is the version of the built-in health library.
+// It has to be bumped whenever any of the bundled health scripts changes.
+const HealthLibraryVersion = "1.1.0"
+
+const (
+	healthLibraryDir     = "health"
+	healthLibraryPrelude = "lib.lua"
+	crossplaneHealth     = "crossplane.lua"
+)
+
+// healthLibrary contains Lua health scripts for popular CRD ecosystems. Scripts are stored
+// as health/<group>/<kind>.lua and are prefixed with the shared helpers from health/lib.lua.
+// They can be overridden per GVK with the CustomHealth resource.
+//
+//go:embed health
+var healthLibrary embed.FS
+
+var (
+	healthLibraryScripts    = make(map[schema.GroupKind]string)
+	crossplaneHealthScript  string
+	crossplaneProviderGroup = []string{".crossplane.io", ".upbound.io"}
+
+	// crossplaneCoreGroups are the API groups of Crossplane itself. They hold packages and
+	// composition machinery rather than managed resources, which report other conditions
+	// (e.g. Installed, Established) or none at all.
+	crossplaneCoreGroups = map[string]struct{}{
+		"apiextensions.crossplane.io": {},
+		"pkg.crossplane.io":           {},
+		"protection.crossplane.io":    {},
+		"ops.crossplane.io":           {},
+		"secrets.crossplane.io":       {},
+	}
+
+	// crossplaneConfigKinds are provider kinds configuring managed resources, they have no conditions.
+	crossplaneConfigKinds = map[string]struct{}{
+		"ProviderConfig":             {},
+		"ClusterProviderConfig":      {},
+		"ProviderConfigUsage":        {},
+		"ClusterProviderConfigUsage": {},
+		"StoreConfig":                {},
+	}
+)
+
+func init() {
+	prelude := mustReadHealthLibraryFile(healthLibraryPrelude)
+	crossplaneHealthScript = prelude + mustReadHealthLibraryFile(crossplaneHealth)
+
+	err := fs.WalkDir(healthLibrary, healthLibraryDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		group := path.Base(path.Dir(p))
+		if d.IsDir() || path.Ext(p) != ".lua" || group == healthLibraryDir {
+			return nil
+		}
+
+		kind := strings.TrimSuffix(path.Base(p), ".lua")
+		healthLibraryScripts[schema.GroupKind{Group: group, Kind: kind}] = prelude + mustReadHealthLibraryFile(path.Join(group, path.Base(p)))
+		return nil
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+func mustReadHealthLibraryFile(name string) string {
+	data, err := healthLibrary.ReadFile(path.Join(healthLibraryDir, name))
+	if err != nil {
+		panic(err)
+	}
+
+	return string(data) + "\n"
+}
+
+// getHealthLibraryFunc returns a health check function from the built-in health library
+// or nil if there is no script for the given GVK.
+func getHealthLibraryFunc(gvk schema.GroupVersionKind) func(obj *unstructured.Unstructured) (*HealthStatus, error) {
+	script, ok := healthLibraryScripts[gvk.GroupKind()]
+	if !ok {
+		return nil
+	}
+
+	return func(obj *unstructured.Unstructured) (*HealthStatus, error) {
+		return getHealthLibraryStatus(obj, script)
+	}
+}
+
+func getHealthLibraryStatus(obj *unstructured.Unstructured, script string) (*HealthStatus, error) {
+	health, err := GetLuaHealthConvert(obj, script)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run built-in health script v%s: %w", HealthLibraryVersion, err)
+	}
+
+	if health == nil {
+		return getReadyConditionHealthStatus(obj)
+	}
+
+	return health, nil
+}
+
+// isCrossplaneResource checks if the object is a Crossplane managed resource, composite resource
+// or claim. Managed resources are served from provider groups, while composites and claims live in
+// groups defined by their XRDs and are recognized by the composition references Crossplane sets on them.
+func isCrossplaneResource(obj *unstructured.Unstructured) bool {
+	gvk := obj.GroupVersionKind()
+	if _, ok := crossplaneCoreGroups[gvk.Group]; ok {
+		return false
+	}
+	if _, ok := crossplaneConfigKinds[gvk.Kind]; ok {
+		return false
+	}
+
+	group := gvk.Group
+	for _, suffix := range crossplaneProviderGroup {
+		if strings.HasSuffix(group, suffix) {
+			return true
+		}
+	}
+
+	spec, ok := obj.Object["spec"].(map[string]any)
+	if !ok {
+		return false
+	}
+	if hasCompositionRef(spec) {
+		return true
+	}
+
+	// Crossplane v2 composites nest their machinery under spec.crossplane.
+	crossplane, ok := spec["crossplane"].(map[string]any)
+	return ok && hasCompositionRef(crossplane)
+}
+
+func hasCompositionRef(fields map[string]any) bool {
+	for _, field := range []string{"compositionRef", "compositionSelector", "compositionRevisionRef"} {
+		if _, ok := fields[field]; ok {
+			return true
+		}
+	}
+
+	return false
+}
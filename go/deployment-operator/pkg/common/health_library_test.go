@@ -0,0 +1,183 @@
+package common_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/pluralsh/console/go/deployment-operator/pkg/common"
+)
+
+var _ = Describe("Health Library Test", func() {
+	DescribeTable("should get status from the built-in health library",
+		func(object map[string]interface{}, expected common.HealthStatus) {
+			common.ClearLuaScripts()
+			DeferCleanup(common.ClearLuaScripts)
+
+			status, err := common.GetResourceHealth(&unstructured.Unstructured{Object: object})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status).To(Not(BeNil()))
+			Expect(*status).To(Equal(expected))
+		},
+		Entry("failed Flux HelmRelease", map[string]interface{}{
+			"apiVersion": "helm.toolkit.fluxcd.io/v2",
+			"kind":       "HelmRelease",
+			"metadata":   map[string]interface{}{"name": "test", "generation": int64(2)},
+			"status": map[string]interface{}{
+				"observedGeneration": int64(2),
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Ready", "status": "False", "reason": "InstallFailed", "message": "install failed"},
+				},
+			},
+		}, common.HealthStatus{Status: common.HealthStatusDegraded, Message: "install failed"}),
+		Entry("suspended Flux HelmRelease", map[string]interface{}{
+			"apiVersion": "helm.toolkit.fluxcd.io/v2",
+			"kind":       "HelmRelease",
+			"metadata":   map[string]interface{}{"name": "test"},
+			"spec":       map[string]interface{}{"suspend": true},
+		}, common.HealthStatus{Status: common.HealthStatusSuspended, Message: "Reconciliation is suspended"}),
+		Entry("accepted Gateway API HTTPRoute", map[string]interface{}{
+			"apiVersion": "gateway.networking.k8s.io/v1",
+			"kind":       "HTTPRoute",
+			"metadata":   map[string]interface{}{"name": "test"},
+			"status": map[string]interface{}{
+				"parents": []interface{}{
+					map[string]interface{}{
+						"conditions": []interface{}{
+							map[string]interface{}{"type": "Accepted", "status": "True"},
+							map[string]interface{}{"type": "ResolvedRefs", "status": "True"},
+						},
+					},
+				},
+			},
+		}, common.HealthStatus{Status: common.HealthStatusHealthy}),
+		Entry("Gateway API HTTPRoute with unresolved refs", map[string]interface{}{
+			"apiVersion": "gateway.networking.k8s.io/v1",
+			"kind":       "HTTPRoute",
+			"metadata":   map[string]interface{}{"name": "test"},
+			"status": map[string]interface{}{
+				"parents": []interface{}{
+					map[string]interface{}{
+						"conditions": []interface{}{
+							map[string]interface{}{"type": "ResolvedRefs", "status": "False", "message": "service not found"},
+						},
+					},
+				},
+			},
+		}, common.HealthStatus{Status: common.HealthStatusDegraded, Message: "service not found"}),
+		Entry("Crossplane composite resource that failed to sync", map[string]interface{}{
+			"apiVersion": "example.org/v1",
+			"kind":       "XDatabase",
+			"metadata":   map[string]interface{}{"name": "test"},
+			"spec":       map[string]interface{}{"compositionRef": map[string]interface{}{"name": "test"}},
+			"status": map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Synced", "status": "False", "message": "cannot compose resources"},
+				},
+			},
+		}, common.HealthStatus{Status: common.HealthStatusDegraded, Message: "cannot compose resources"}),
+		Entry("healthy Crossplane Provider", map[string]interface{}{
+			"apiVersion": "pkg.crossplane.io/v1",
+			"kind":       "Provider",
+			"metadata":   map[string]interface{}{"name": "provider-aws"},
+			"status": map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Installed", "status": "True", "reason": "ActivePackageRevision"},
+					map[string]interface{}{"type": "Healthy", "status": "True", "reason": "HealthyPackageRevision"},
+				},
+			},
+		}, common.HealthStatus{Status: common.HealthStatusHealthy}),
+		Entry("unpacking Crossplane Provider", map[string]interface{}{
+			"apiVersion": "pkg.crossplane.io/v1",
+			"kind":       "Provider",
+			"metadata":   map[string]interface{}{"name": "provider-aws"},
+			"status": map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Installed", "status": "False", "reason": "UnpackingPackage", "message": "unpacking package"},
+				},
+			},
+		}, common.HealthStatus{Status: common.HealthStatusProgressing, Message: "unpacking package"}),
+		Entry("unhealthy Crossplane Function", map[string]interface{}{
+			"apiVersion": "pkg.crossplane.io/v1",
+			"kind":       "Function",
+			"metadata":   map[string]interface{}{"name": "function-patch-and-transform"},
+			"status": map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Installed", "status": "True", "reason": "ActivePackageRevision"},
+					map[string]interface{}{"type": "Healthy", "status": "False", "reason": "UnhealthyPackageRevision", "message": "pod crashlooping"},
+				},
+			},
+		}, common.HealthStatus{Status: common.HealthStatusDegraded, Message: "pod crashlooping"}),
+		Entry("established and offered Crossplane CompositeResourceDefinition", map[string]interface{}{
+			"apiVersion": "apiextensions.crossplane.io/v1",
+			"kind":       "CompositeResourceDefinition",
+			"metadata":   map[string]interface{}{"name": "xdatabases.example.org"},
+			"spec":       map[string]interface{}{"claimNames": map[string]interface{}{"kind": "Database"}},
+			"status": map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Established", "status": "True"},
+					map[string]interface{}{"type": "Offered", "status": "True"},
+				},
+			},
+		}, common.HealthStatus{Status: common.HealthStatusHealthy}),
+		Entry("Crossplane CompositeResourceDefinition waiting for its claim API", map[string]interface{}{
+			"apiVersion": "apiextensions.crossplane.io/v1",
+			"kind":       "CompositeResourceDefinition",
+			"metadata":   map[string]interface{}{"name": "xdatabases.example.org"},
+			"spec":       map[string]interface{}{"claimNames": map[string]interface{}{"kind": "Database"}},
+			"status": map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Established", "status": "True"},
+				},
+			},
+		}, common.HealthStatus{Status: common.HealthStatusProgressing, Message: "Waiting for the Offered condition"}),
+		Entry("Crossplane Composition", map[string]interface{}{
+			"apiVersion": "apiextensions.crossplane.io/v1",
+			"kind":       "Composition",
+			"metadata":   map[string]interface{}{"name": "xdatabases"},
+			"spec":       map[string]interface{}{"compositeTypeRef": map[string]interface{}{"apiVersion": "example.org/v1", "kind": "XDatabase"}},
+		}, common.HealthStatus{Status: common.HealthStatusHealthy}),
+		Entry("Crossplane ProviderConfig", map[string]interface{}{
+			"apiVersion": "aws.upbound.io/v1beta1",
+			"kind":       "ProviderConfig",
+			"metadata":   map[string]interface{}{"name": "default"},
+			"spec":       map[string]interface{}{"credentials": map[string]interface{}{"source": "IRSA"}},
+		}, common.HealthStatus{Status: common.HealthStatusHealthy}),
+		Entry("Crossplane managed resource without conditions", map[string]interface{}{
+			"apiVersion": "s3.aws.upbound.io/v1beta1",
+			"kind":       "Bucket",
+			"metadata":   map[string]interface{}{"name": "test"},
+			"spec":       map[string]interface{}{"forProvider": map[string]interface{}{"region": "us-east-1"}},
+		}, common.HealthStatus{Status: common.HealthStatusProgressing, Message: "Waiting for the Ready condition"}),
+		Entry("Crossplane v2 composite resource", map[string]interface{}{
+			"apiVersion": "example.org/v1",
+			"kind":       "XBucket",
+			"metadata":   map[string]interface{}{"name": "test"},
+			"spec": map[string]interface{}{
+				"crossplane": map[string]interface{}{"compositionRef": map[string]interface{}{"name": "test"}},
+			},
+		}, common.HealthStatus{Status: common.HealthStatusProgressing, Message: "Waiting for the Ready condition"}),
+		Entry("custom resource with a resourceRef that is not managed by Crossplane", map[string]interface{}{
+			"apiVersion": "example.com/v1",
+			"kind":       "Backup",
+			"metadata":   map[string]interface{}{"name": "test"},
+			"spec":       map[string]interface{}{"resourceRef": map[string]interface{}{"name": "test"}},
+		}, common.HealthStatus{Status: common.HealthStatusHealthy}),
+		Entry("scaling Cluster API MachineDeployment", map[string]interface{}{
+			"apiVersion": "cluster.x-k8s.io/v1beta1",
+			"kind":       "MachineDeployment",
+			"metadata":   map[string]interface{}{"name": "test"},
+			"status":     map[string]interface{}{"phase": "ScalingUp", "replicas": int64(3), "readyReplicas": int64(1)},
+		}, common.HealthStatus{Status: common.HealthStatusProgressing, Message: "Waiting for 2 machines to be ready"}),
+		Entry("paused KEDA ScaledObject", map[string]interface{}{
+			"apiVersion": "keda.sh/v1alpha1",
+			"kind":       "ScaledObject",
+			"metadata":   map[string]interface{}{"name": "test"},
+			"status": map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Paused", "status": "True", "message": "scaling is paused"},
+				},
+			},
+		}, common.HealthStatus{Status: common.HealthStatusSuspended, Message: "scaling is paused"}),
+	)
+})
@@ -162,6 +162,10 @@ func GetHealthCheckFunc(gvk schema.GroupVersionKind) func(obj *unstructured.Unst
 		return healthFunc
 	}
 
+	if healthFunc := getHealthLibraryFunc(gvk); healthFunc != nil {
+		return healthFunc
+	}
+
 	// for the default Lua script, we want to return the Lua health convert function even if the GVK is not explicitly set
 	if IsLuaScriptValueForGVK(schema.GroupVersionKind{}) {
 		return getDefaultLuaHealthConvert
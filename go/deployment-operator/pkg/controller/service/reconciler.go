@@ -180,11 +180,14 @@ func (s *ServiceReconciler) GetPublisher() (string, websocket.Publisher) {
 	}
 }
 
-func (s *ServiceReconciler) ignoreUpdateFields(ctx context.Context, objs []unstructured.Unstructured, svc *console.ServiceDeploymentForAgent) ([]unstructured.Unstructured, error) {
+// ignoreUpdateFields removes ignored fields from the manifests and backfills configured fields from live objects.
+// It also returns the ignored JSON pointers for each manifest, so they can be skipped when diffing dry runs.
+func (s *ServiceReconciler) ignoreUpdateFields(ctx context.Context, objs []unstructured.Unstructured, svc *console.ServiceDeploymentForAgent) ([]unstructured.Unstructured, map[smcommon.Key][]string, error) {
 	normalizerMap := make(map[normalizerKey][]string)
+	ignored := make(map[smcommon.Key][]string)
 
 	if svc == nil {
-		return objs, nil
+		return objs, ignored, nil
 	}
 	if svc.SyncConfig != nil {
 		for _, dn := range svc.SyncConfig.DiffNormalizers {
@@ -221,20 +224,21 @@ func (s *ServiceReconciler) ignoreUpdateFields(ctx context.Context, objs []unstr
 		if len(backFillPaths) > 0 {
 			newObj, err := BackFillJSONPaths(ctx, s.k8sClient, obj, backFillPaths)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			objs[i] = newObj
 		}
 		if len(ignorePaths) > 0 {
 			newObj, err := IgnoreJSONPaths(objs[i], ignorePaths)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			objs[i] = newObj
+			ignored[smcommon.NewKeyFromUnstructured(newObj)] = ignorePaths
 		}
 	}
 
-	return objs, nil
+	return objs, ignored, nil
 }
 
 func getJsonPaths(obj unstructured.Unstructured, annotation string) []string {
@@ -529,7 +533,7 @@ func (s *ServiceReconciler) Reconcile(ctx context.Context, id string) (result re
 		return
 	}
 
-	manifests, err = s.ignoreUpdateFields(ctx, manifests, svc)
+	manifests, ignoredPaths, err := s.ignoreUpdateFields(ctx, manifests, svc)
 	if err != nil {
 		return
 	}
@@ -551,6 +555,7 @@ func (s *ServiceReconciler) Reconcile(ctx context.Context, id string) (result re
 		*svc,
 		manifests,
 		applier.WithWaveDryRun(dryRun),
+		applier.WithWaveIgnoredPaths(ignoredPaths),
 		applier.WithWaveOnApply(func(obj unstructured.Unstructured) {
 			if s.supervisor == nil {
 				return
@@ -15,6 +15,7 @@ import (
 	"github.com/pluralsh/console/go/polly/algorithms"
 	"k8s.io/klog/v2"
 
+	"github.com/pluralsh/console/go/deployment-operator/pkg/harness/cost"
 	"github.com/pluralsh/console/go/deployment-operator/pkg/harness/environment"
 	"github.com/pluralsh/console/go/deployment-operator/pkg/harness/exec"
 	"github.com/pluralsh/console/go/deployment-operator/pkg/harness/security"
@@ -222,6 +223,7 @@ func (in *stackRunController) prepare() error {
 		ExecDir:      in.execWorkDir(),
 		Variables:    variables,
 		Scanner:      security.NewScanner(in.stackRun.PolicyEngine),
+		Estimator:    in.estimator(),
 		Run:          in.stackRun,
 		Env:          in.stackRun.Env(),
 		ConsoleURL:   in.stackRun.ConsoleURL,
@@ -231,6 +233,21 @@ func (in *stackRunController) prepare() error {
 	return nil
 }
 
+// estimator returns a cost estimator if cost estimation is enabled for the stack run.
+func (in *stackRunController) estimator() *cost.Estimator {
+	if !in.stackRun.CostEstimation {
+		return nil
+	}
+
+	estimator, err := cost.NewEstimator(in.dir)
+	if err != nil {
+		klog.ErrorS(err, "could not initialize cost estimator")
+		return nil
+	}
+
+	return estimator
+}
+
 func (in *stackRunController) init() (Controller, error) {
 	if len(in.stackRunID) == 0 {
 		return nil, fmt.Errorf("could not initialize controller: stack run id is empty")
@@ -11,6 +11,7 @@ import (
 	"k8s.io/klog/v2"
 
 	clienterrors "github.com/pluralsh/console/go/deployment-operator/internal/errors"
+	"github.com/pluralsh/console/go/deployment-operator/pkg/harness/cost"
 	"github.com/pluralsh/console/go/deployment-operator/pkg/harness/environment"
 	harnesserrors "github.com/pluralsh/console/go/deployment-operator/pkg/harness/errors"
 	securityv1 "github.com/pluralsh/console/go/deployment-operator/pkg/harness/security/v1"
@@ -150,7 +151,7 @@ func (in *stackRunController) preExecHook(ctx context.Context, step *gqlclient.R
 }
 
 func (in *stackRunController) requiresApproval() bool {
-	return in.stackRun.Approval && !runApproved && in.stackRun.ApprovedAt == nil
+	return (in.stackRun.Approval || in.costApproval) && !runApproved && in.stackRun.ApprovedAt == nil
 }
 
 func (in *stackRunController) waitForApproval(ctx context.Context) error {
@@ -236,11 +237,22 @@ func (in *stackRunController) afterPlan() error {
 		klog.ErrorS(err, "could not run security scan")
 	}
 
-	if err = in.consoleClient.UpdateStackRun(in.stackRunID, gqlclient.StackRunAttributes{
+	// Run cost estimation if enabled
+	estimate, err := in.tool.Cost()
+	if err != nil {
+		klog.ErrorS(err, "could not estimate cost")
+	}
+
+	attrs := gqlclient.StackRunAttributes{
 		State:      state,
 		Violations: violations,
 		Status:     gqlclient.StackStatusRunning,
-	}); err != nil {
+	}
+	if estimate != nil {
+		attrs.InfracostResources = estimate.Attributes()
+	}
+
+	if err = in.consoleClient.UpdateStackRun(in.stackRunID, attrs); err != nil {
 		if clienterrors.IsUnauthenticated(err) {
 			return harnesserrors.WrapUnauthenticated("could not update stack run after plan", err)
 		}
@@ -252,6 +264,10 @@ func (in *stackRunController) afterPlan() error {
 		return fmt.Errorf("security scanner error: max severity violation exceeded")
 	}
 
+	if estimate != nil {
+		in.checkCostApproval(estimate)
+	}
+
 	klog.V(log.LogLevelInfo).InfoS("checking approve empty status", "approveEmpty", lo.FromPtr(in.stackRun.ApproveEmpty))
 	if !lo.FromPtr(in.stackRun.ApproveEmpty) {
 		return nil
@@ -269,3 +285,24 @@ func (in *stackRunController) afterPlan() error {
 
 	return nil
 }
+
+// checkCostApproval requires an approval before apply if the estimated monthly cost
+// delta exceeds the approval threshold configured for the stack.
+func (in *stackRunController) checkCostApproval(estimate *cost.Estimate) {
+	delta := estimate.MonthlyCostDelta()
+	klog.V(log.LogLevelInfo).InfoS("estimated monthly cost",
+		"before", estimate.MonthlyCostBefore,
+		"after", estimate.MonthlyCostAfter,
+		"delta", delta,
+		"currency", estimate.Currency,
+		"unpriced", len(estimate.Unpriced),
+	)
+
+	threshold := in.stackRun.CostApprovalThreshold
+	if threshold == nil || delta <= *threshold {
+		return
+	}
+
+	klog.V(log.LogLevelInfo).InfoS("monthly cost delta exceeds approval threshold, approval required", "delta", delta, "threshold", *threshold)
+	in.costApproval = true
+}
@@ -43,6 +43,10 @@ type stackRunController struct {
 	// List of supported tools is based on the gqlclient.StackType.
 	tool toolv1.Tool
 
+	// costApproval is set after the plan stage when the estimated monthly cost delta
+	// exceeds the configured approval threshold.
+	costApproval bool
+
 	// stackRunID
 	stackRunID string
 
@@ -0,0 +1,152 @@
+package cost
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+//go:embed catalog/catalog.json
+var defaultCatalog []byte
+
+// DefaultCatalog returns the pricing catalog cached in the harness binary.
+// It contains on-demand list prices from the reference regions of each provider
+// (AWS us-east-1, GCP us-central1 and Azure eastus).
+func DefaultCatalog() (*Catalog, error) {
+	return parseCatalog(defaultCatalog)
+}
+
+// LoadCatalog reads a pricing catalog from a local file.
+func LoadCatalog(path string) (*Catalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading pricing catalog: %w", err)
+	}
+
+	return parseCatalog(data)
+}
+
+func parseCatalog(data []byte) (*Catalog, error) {
+	catalog := new(Catalog)
+	if err := json.Unmarshal(data, catalog); err != nil {
+		return nil, fmt.Errorf("failed unmarshaling pricing catalog: %w", err)
+	}
+
+	if len(catalog.Currency) == 0 {
+		catalog.Currency = "USD"
+	}
+
+	return catalog, nil
+}
+
+// monthlyCost prices the resource values with the given pricing.
+// It returns an error describing the first component that could not be priced.
+func (in ResourcePricing) monthlyCost(values any) (float64, error) {
+	total := 0.0
+	for _, component := range in.Components {
+		cost, err := component.monthlyCost(values)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", component.Name, err)
+		}
+
+		total += cost
+	}
+
+	return total, nil
+}
+
+func (in Component) monthlyCost(values any) (float64, error) {
+	price, err := in.price(values)
+	if err != nil {
+		return 0, err
+	}
+
+	quantity, err := in.quantity(values)
+	if err != nil {
+		return 0, err
+	}
+
+	if in.Unit == UnitHour {
+		return price * quantity * hoursPerMonth, nil
+	}
+
+	return price * quantity, nil
+}
+
+func (in Component) price(values any) (float64, error) {
+	if len(in.Attribute) == 0 {
+		return in.Price, nil
+	}
+
+	value, ok := lookup(values, in.Attribute)
+	if !ok || value == nil {
+		if len(in.DefaultValue) == 0 {
+			return 0, fmt.Errorf("%s is not known until apply", in.Attribute)
+		}
+
+		value = in.DefaultValue
+	}
+
+	key := fmt.Sprint(value)
+	price, ok := in.Prices[key]
+	if !ok {
+		return 0, fmt.Errorf("no price for %s %q", in.Attribute, key)
+	}
+
+	return price, nil
+}
+
+func (in Component) quantity(values any) (float64, error) {
+	if len(in.Quantity) == 0 {
+		return 1, nil
+	}
+
+	value, ok := lookup(values, in.Quantity)
+	if !ok || value == nil {
+		if in.DefaultQuantity != nil {
+			return *in.DefaultQuantity, nil
+		}
+
+		return 1, nil
+	}
+
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case json.Number:
+		return v.Float64()
+	case string:
+		return strconv.ParseFloat(v, 64)
+	default:
+		return 0, fmt.Errorf("%s is not a number", in.Quantity)
+	}
+}
+
+// lookup returns the value at the dot-separated path, where numeric segments index lists,
+// i.e. root_block_device.0.volume_size.
+func lookup(values any, path string) (any, bool) {
+	current := values
+	for _, segment := range strings.Split(path, ".") {
+		switch v := current.(type) {
+		case map[string]any:
+			next, ok := v[segment]
+			if !ok {
+				return nil, false
+			}
+			current = next
+		case []any:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(v) {
+				return nil, false
+			}
+			current = v[index]
+		default:
+			return nil, false
+		}
+	}
+
+	return current, true
+}
@@ -0,0 +1,160 @@
+package cost
+
+import (
+	"encoding/json"
+	"path/filepath"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/samber/lo"
+	"k8s.io/klog/v2"
+
+	console "github.com/pluralsh/console/go/client"
+
+	"github.com/pluralsh/console/go/deployment-operator/internal/helpers"
+	"github.com/pluralsh/console/go/deployment-operator/pkg/log"
+)
+
+// Estimate prices all managed resource changes in the plan.
+// Resource types missing from the catalog are skipped.
+func (in *Estimator) Estimate(plan *tfjson.Plan) *Estimate {
+	result := &Estimate{
+		Currency:       in.catalog.Currency,
+		CatalogVersion: in.catalog.Version,
+	}
+
+	if plan == nil {
+		return result
+	}
+
+	for _, change := range plan.ResourceChanges {
+		if change == nil || change.Change == nil || change.Mode != tfjson.ManagedResourceMode {
+			continue
+		}
+
+		if change.Change.Actions.NoOp() || change.Change.Actions.Read() {
+			continue
+		}
+
+		pricing, ok := in.catalog.Resources[change.Type]
+		if !ok {
+			continue
+		}
+
+		estimate, err := in.estimateResource(change, pricing)
+		if err != nil {
+			result.Unpriced = append(result.Unpriced, UnpricedResource{
+				Address: change.Address,
+				Type:    change.Type,
+				Reason:  err.Error(),
+			})
+			continue
+		}
+
+		result.MonthlyCostBefore += estimate.MonthlyCostBefore
+		result.MonthlyCostAfter += estimate.MonthlyCostAfter
+		result.Resources = append(result.Resources, *estimate)
+	}
+
+	return result
+}
+
+func (in *Estimator) estimateResource(change *tfjson.ResourceChange, pricing ResourcePricing) (*ResourceEstimate, error) {
+	result := &ResourceEstimate{
+		Address:  change.Address,
+		Type:     change.Type,
+		Provider: pricing.Provider,
+		Actions:  change.Change.Actions,
+	}
+
+	if change.Change.Before != nil {
+		cost, err := pricing.monthlyCost(change.Change.Before)
+		if err != nil {
+			return nil, err
+		}
+
+		result.MonthlyCostBefore = cost
+	}
+
+	if change.Change.After != nil {
+		cost, err := pricing.monthlyCost(change.Change.After)
+		if err != nil {
+			return nil, err
+		}
+
+		result.MonthlyCostAfter = cost
+	}
+
+	return result, nil
+}
+
+// MonthlyCostDelta returns the change of the monthly cost after the plan is applied.
+func (in *Estimate) MonthlyCostDelta() float64 {
+	return in.MonthlyCostAfter - in.MonthlyCostBefore
+}
+
+// Attributes converts the estimate to cost rows that can be attached to the stack run.
+// Every priced resource has a past_breakdown, breakdown and diff row, or a single
+// free row if it does not cost anything both before and after the change.
+func (in *Estimate) Attributes() []*console.StackInfracostResourceAttributes {
+	result := make([]*console.StackInfracostResourceAttributes, 0, len(in.Resources)*3)
+	for _, resource := range in.Resources {
+		if resource.MonthlyCostBefore == 0 && resource.MonthlyCostAfter == 0 {
+			result = append(result, resource.attributes("free", 0))
+			continue
+		}
+
+		if resource.MonthlyCostBefore > 0 {
+			result = append(result, resource.attributes("past_breakdown", resource.MonthlyCostBefore))
+		}
+
+		if resource.MonthlyCostAfter > 0 {
+			result = append(result, resource.attributes("breakdown", resource.MonthlyCostAfter))
+		}
+
+		result = append(result, resource.attributes("diff", resource.MonthlyCostAfter-resource.MonthlyCostBefore))
+	}
+
+	return result
+}
+
+func (in *ResourceEstimate) attributes(scope string, monthlyCost float64) *console.StackInfracostResourceAttributes {
+	raw, err := json.Marshal(map[string]any{
+		"provider": in.Provider,
+		"actions":  in.Actions,
+	})
+	if err != nil {
+		klog.V(log.LogLevelDebug).ErrorS(err, "failed marshaling cost resource", "address", in.Address)
+	}
+
+	return &console.StackInfracostResourceAttributes{
+		ResourceScope: scope,
+		Name:          in.Address,
+		ResourceType:  lo.ToPtr(in.Type),
+		HourlyCost:    lo.ToPtr(monthlyCost / hoursPerMonth),
+		MonthlyCost:   lo.ToPtr(monthlyCost),
+		RawResource:   lo.EmptyableToPtr(string(raw)),
+	}
+}
+
+// NewEstimator creates an estimator for the stack in the given directory.
+// It uses the catalog from CatalogFile if it exists in the directory and
+// falls back to the catalog cached in the harness binary otherwise.
+func NewEstimator(dir string) (*Estimator, error) {
+	override := filepath.Join(dir, CatalogFile)
+	if helpers.Exists(override) {
+		catalog, err := LoadCatalog(override)
+		if err == nil {
+			klog.V(log.LogLevelInfo).InfoS("using custom pricing catalog", "path", override, "version", catalog.Version)
+			return &Estimator{catalog: catalog}, nil
+		}
+
+		klog.ErrorS(err, "failed loading custom pricing catalog, using the default one", "path", override)
+	}
+
+	catalog, err := DefaultCatalog()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Estimator{catalog: catalog}, nil
+}
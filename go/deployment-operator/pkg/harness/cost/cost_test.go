@@ -0,0 +1,165 @@
+package cost
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	console "github.com/pluralsh/console/go/client"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func resourceChange(address, resourceType string, actions tfjson.Actions, before, after map[string]any) *tfjson.ResourceChange {
+	change := &tfjson.ResourceChange{
+		Address: address,
+		Type:    resourceType,
+		Mode:    tfjson.ManagedResourceMode,
+		Change:  &tfjson.Change{Actions: actions},
+	}
+
+	if before != nil {
+		change.Change.Before = before
+	}
+
+	if after != nil {
+		change.Change.After = after
+	}
+
+	return change
+}
+
+func TestDefaultCatalog(t *testing.T) {
+	catalog, err := DefaultCatalog()
+	require.NoError(t, err)
+	assert.Equal(t, "USD", catalog.Currency)
+	assert.NotEmpty(t, catalog.Version)
+
+	for resourceType, pricing := range catalog.Resources {
+		assert.Contains(t, []string{"aws", "google", "azurerm"}, pricing.Provider, resourceType)
+		assert.NotEmpty(t, pricing.Components, resourceType)
+		for _, component := range pricing.Components {
+			assert.Contains(t, []Unit{UnitHour, UnitMonth}, component.Unit, resourceType)
+		}
+	}
+}
+
+func TestEstimate(t *testing.T) {
+	catalog, err := DefaultCatalog()
+	require.NoError(t, err)
+	estimator := &Estimator{catalog: catalog}
+
+	plan := &tfjson.Plan{
+		ResourceChanges: []*tfjson.ResourceChange{
+			resourceChange("aws_instance.web", "aws_instance", tfjson.Actions{tfjson.ActionUpdate},
+				map[string]any{"instance_type": "t3.micro"},
+				map[string]any{"instance_type": "t3.large", "root_block_device": []any{map[string]any{"volume_size": float64(20)}}},
+			),
+			resourceChange("google_container_node_pool.pool", "google_container_node_pool", tfjson.Actions{tfjson.ActionCreate},
+				nil,
+				map[string]any{"node_count": float64(3), "node_config": []any{map[string]any{"machine_type": "e2-standard-4"}}},
+			),
+			resourceChange("azurerm_public_ip.ip", "azurerm_public_ip", tfjson.Actions{tfjson.ActionDelete},
+				map[string]any{"name": "ip"},
+				nil,
+			),
+			resourceChange("aws_instance.unknown", "aws_instance", tfjson.Actions{tfjson.ActionCreate},
+				nil,
+				map[string]any{},
+			),
+			resourceChange("aws_s3_bucket.bucket", "aws_s3_bucket", tfjson.Actions{tfjson.ActionCreate},
+				nil,
+				map[string]any{"bucket": "test"},
+			),
+			resourceChange("aws_eks_cluster.noop", "aws_eks_cluster", tfjson.Actions{tfjson.ActionNoop},
+				map[string]any{},
+				map[string]any{},
+			),
+		},
+	}
+
+	estimate := estimator.Estimate(plan)
+	require.Len(t, estimate.Resources, 3)
+	require.Len(t, estimate.Unpriced, 1)
+	assert.Equal(t, "aws_instance.unknown", estimate.Unpriced[0].Address)
+
+	web := estimate.Resources[0]
+	assert.InDelta(t, 0.0104*730+8*0.08, web.MonthlyCostBefore, 0.001)
+	assert.InDelta(t, 0.0832*730+20*0.08, web.MonthlyCostAfter, 0.001)
+
+	pool := estimate.Resources[1]
+	assert.Zero(t, pool.MonthlyCostBefore)
+	assert.InDelta(t, 3*0.134*730, pool.MonthlyCostAfter, 0.001)
+
+	ip := estimate.Resources[2]
+	assert.InDelta(t, 0.005*730, ip.MonthlyCostBefore, 0.001)
+	assert.Zero(t, ip.MonthlyCostAfter)
+
+	assert.InDelta(t, web.MonthlyCostBefore+ip.MonthlyCostBefore, estimate.MonthlyCostBefore, 0.001)
+	assert.InDelta(t, web.MonthlyCostAfter+pool.MonthlyCostAfter, estimate.MonthlyCostAfter, 0.001)
+	assert.InDelta(t, estimate.MonthlyCostAfter-estimate.MonthlyCostBefore, estimate.MonthlyCostDelta(), 0.001)
+}
+
+func TestAttributes(t *testing.T) {
+	estimate := &Estimate{
+		Resources: []ResourceEstimate{
+			{Address: "aws_instance.web", Type: "aws_instance", Provider: "aws", Actions: tfjson.Actions{tfjson.ActionUpdate}, MonthlyCostBefore: 10, MonthlyCostAfter: 25},
+			{Address: "azurerm_kubernetes_cluster.aks", Type: "azurerm_kubernetes_cluster", Provider: "azurerm", Actions: tfjson.Actions{tfjson.ActionCreate}},
+		},
+	}
+
+	attrs := estimate.Attributes()
+	require.Len(t, attrs, 4)
+
+	scopes := lo.Map(attrs, func(attr *console.StackInfracostResourceAttributes, _ int) string {
+		return attr.ResourceScope
+	})
+	assert.Equal(t, []string{"past_breakdown", "breakdown", "diff", "free"}, scopes)
+
+	diff := attrs[2]
+	assert.Equal(t, "aws_instance.web", diff.Name)
+	assert.Equal(t, "aws_instance", lo.FromPtr(diff.ResourceType))
+	assert.InDelta(t, 15, lo.FromPtr(diff.MonthlyCost), 0.001)
+	assert.InDelta(t, 15.0/730, lo.FromPtr(diff.HourlyCost), 0.0001)
+
+	raw := make(map[string]any)
+	require.NoError(t, json.Unmarshal([]byte(lo.FromPtr(diff.RawResource)), &raw))
+	assert.Equal(t, "aws", raw["provider"])
+	assert.Equal(t, []any{"update"}, raw["actions"])
+}
+
+func TestNewEstimator(t *testing.T) {
+	dir := t.TempDir()
+
+	estimator, err := NewEstimator(dir)
+	require.NoError(t, err)
+	assert.Contains(t, estimator.catalog.Resources, "aws_instance")
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".plural"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, CatalogFile), []byte(`{
+		"version": "custom",
+		"resources": {
+			"aws_instance": {"provider": "aws", "components": [{"name": "compute", "unit": "month", "price": 42}]}
+		}
+	}`), 0644))
+
+	estimator, err = NewEstimator(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "custom", estimator.catalog.Version)
+	assert.Equal(t, "USD", estimator.catalog.Currency)
+
+	estimate := estimator.Estimate(&tfjson.Plan{
+		ResourceChanges: []*tfjson.ResourceChange{
+			resourceChange("aws_instance.web", "aws_instance", tfjson.Actions{tfjson.ActionCreate}, nil, map[string]any{}),
+		},
+	})
+	assert.InDelta(t, 42, estimate.MonthlyCostDelta(), 0.001)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, CatalogFile), []byte(`{`), 0644))
+	estimator, err = NewEstimator(dir)
+	require.NoError(t, err)
+	assert.NotEqual(t, "custom", estimator.catalog.Version)
+}
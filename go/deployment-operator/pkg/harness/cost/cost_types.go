@@ -0,0 +1,117 @@
+package cost
+
+import (
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+const (
+	// CatalogFile is the path of an optional pricing catalog, relative to the root of the stack tarball.
+	// It takes precedence over the catalog cached in the harness binary.
+	CatalogFile = ".plural/pricing.json"
+
+	// hoursPerMonth is used to convert hourly prices to monthly ones.
+	hoursPerMonth = 730
+)
+
+// Unit is a billing unit of a price.
+type Unit string
+
+const (
+	UnitHour  Unit = "hour"
+	UnitMonth Unit = "month"
+)
+
+// Catalog is a pricing catalog with list prices of the most common billable resources.
+type Catalog struct {
+	// Version identifies the pricing snapshot, i.e. the date it was taken.
+	Version string `json:"version"`
+
+	// Currency of all prices in the catalog.
+	Currency string `json:"currency"`
+
+	// Resources maps Terraform resource types to their pricing.
+	Resources map[string]ResourcePricing `json:"resources"`
+}
+
+// ResourcePricing describes how to price a single Terraform resource type.
+// The monthly cost of a resource is the sum of all its components.
+type ResourcePricing struct {
+	// Provider is the cloud provider of the resource, i.e. aws, google or azurerm.
+	Provider string `json:"provider"`
+
+	Components []Component `json:"components"`
+}
+
+// Component is a single billable part of a resource, i.e. compute or storage.
+type Component struct {
+	Name string `json:"name"`
+
+	// Unit is the billing unit of the price.
+	Unit Unit `json:"unit"`
+
+	// Attribute is a path to the resource attribute that selects the price from Prices,
+	// i.e. instance_type or node_config.0.machine_type.
+	Attribute string `json:"attribute,omitempty"`
+
+	// DefaultValue is used when Attribute is not set on the resource.
+	DefaultValue string `json:"defaultValue,omitempty"`
+
+	// Prices maps attribute values to their unit price.
+	Prices map[string]float64 `json:"prices,omitempty"`
+
+	// Price is the unit price used when Attribute is not set.
+	Price float64 `json:"price,omitempty"`
+
+	// Quantity is a path to a numeric resource attribute that multiplies the price,
+	// i.e. the volume size in GB or the node count.
+	Quantity string `json:"quantity,omitempty"`
+
+	// DefaultQuantity is used when Quantity is not set on the resource.
+	// Default: 1
+	DefaultQuantity *float64 `json:"defaultQuantity,omitempty"`
+}
+
+// Estimator prices planned resource changes using a pricing catalog.
+type Estimator struct {
+	catalog *Catalog
+}
+
+// Estimate is the monthly cost estimate of a plan.
+type Estimate struct {
+	// Currency of all costs in the estimate.
+	Currency string
+
+	// CatalogVersion is the version of the catalog used to price the plan.
+	CatalogVersion string
+
+	// MonthlyCostBefore is the monthly cost of all priced resources before the changes are applied.
+	MonthlyCostBefore float64
+
+	// MonthlyCostAfter is the monthly cost of all priced resources after the changes are applied.
+	MonthlyCostAfter float64
+
+	// Resources are the priced resource changes.
+	Resources []ResourceEstimate
+
+	// Unpriced are the changed resources that are known to the catalog but could not be priced,
+	// i.e. because the price depends on a value known only after apply.
+	Unpriced []UnpricedResource
+}
+
+// ResourceEstimate is the monthly cost estimate of a single resource change.
+type ResourceEstimate struct {
+	Address  string
+	Type     string
+	Provider string
+	Actions  tfjson.Actions
+
+	MonthlyCostBefore float64
+	MonthlyCostAfter  float64
+}
+
+// UnpricedResource is a changed resource that could not be priced.
+type UnpricedResource struct {
+	Address string
+	Type    string
+	Reason  string
+}
@@ -98,6 +98,13 @@ func (in *executable) prepare(ctx context.Context, streaming bool) (*exec.Cmd, e
 
 	ctx = signals.NewCancelableContext(ctx, signals.NewTimeoutSignal(in.timeout))
 	cmd := exec.CommandContext(ctx, in.command, in.args...)
+	if in.gracePeriod > 0 {
+		cmd.Cancel = func() error {
+			return cmd.Process.Signal(os.Interrupt)
+		}
+		cmd.WaitDelay = in.gracePeriod
+	}
+
 	if !streaming {
 		w := in.writer()
 		// Configure additional writers so that we can simultaneously write output
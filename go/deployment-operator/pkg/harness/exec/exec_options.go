@@ -45,12 +45,43 @@ func WithHook(lifecycle v1.Lifecycle, fn v1.HookFunction) Option {
 	}
 }
 
+// WithoutHooks drops the lifecycle hooks registered by the preceding options.
+func WithoutHooks() Option {
+	return func(e *executable) {
+		clear(e.hookFunctions)
+	}
+}
+
+// Hook returns the lifecycle hook registered by the given options, or a no-op
+// when there is none. It lets callers that start several executables for a
+// single logical run fire the hooks once around all of them.
+func Hook(lifecycle v1.Lifecycle, options ...Option) v1.HookFunction {
+	e := &executable{hookFunctions: make(map[v1.Lifecycle]v1.HookFunction)}
+	for _, o := range options {
+		o(e)
+	}
+
+	if fn, exists := e.hookFunctions[lifecycle]; exists {
+		return fn
+	}
+
+	return func() error { return nil }
+}
+
 func WithTimeout(timeout time.Duration) Option {
 	return func(e *executable) {
 		e.timeout = timeout
 	}
 }
 
+// WithGracePeriod interrupts the process instead of killing it when the context
+// is cancelled and waits up to the given duration for it to exit.
+func WithGracePeriod(gracePeriod time.Duration) Option {
+	return func(e *executable) {
+		e.gracePeriod = gracePeriod
+	}
+}
+
 func WithOutputAnalyzer(heuristics []OutputAnalyzerHeuristic, opts ...AnalyzerOption) Option {
 	return func(e *executable) {
 		e.outputAnalyzer = NewOutputAnalyzer(heuristics, opts...)
@@ -52,3 +52,32 @@ func TestRunStreamDoesNotDropLinesUnderCallbackBackpressure(t *testing.T) {
 		require.Equal(t, fmt.Sprintf("line-%d", i), received[i])
 	}
 }
+
+func TestRunStreamInterruptsProcessWithinGracePeriod(t *testing.T) {
+	exe := NewExecutable("sh", WithArgs([]string{
+		"-c",
+		`trap 'echo interrupted; exit 0' INT; echo started; while true; do sleep 0.05; done`,
+	}), WithGracePeriod(5*time.Second))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		received []string
+	)
+
+	err := exe.RunStream(ctx, func(line []byte) {
+		mu.Lock()
+		received = append(received, string(line))
+		mu.Unlock()
+		if string(line) == "started" {
+			cancel()
+		}
+	})
+	require.Error(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, []string{"started", "interrupted"}, received)
+}
@@ -43,6 +43,11 @@ type executable struct {
 	// timeout
 	timeout time.Duration
 
+	// gracePeriod is the time the process is given to exit after it has been
+	// interrupted due to context cancellation before it is killed.
+	// Zero means that the process is killed immediately.
+	gracePeriod time.Duration
+
 	// logSink is a custom writer that can be used to forward
 	// executable output. It does not stop output from being forwarded
 	// to the [os.Stdout].
@@ -0,0 +1,110 @@
+package checkov
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	console "github.com/pluralsh/console/go/client"
+	"github.com/samber/lo"
+	"k8s.io/klog/v2"
+
+	"github.com/pluralsh/console/go/deployment-operator/pkg/harness/exec"
+	v1 "github.com/pluralsh/console/go/deployment-operator/pkg/harness/security/v1"
+	loglevel "github.com/pluralsh/console/go/deployment-operator/pkg/log"
+)
+
+// Scan implements [v1.Scanner.Scan] interface.
+func (in *Scanner) Scan(tool console.StackType, options ...v1.ScanOption) ([]*console.StackPolicyViolationAttributes, error) {
+	opts := &v1.ScanOptions{}
+	for _, option := range options {
+		option(opts)
+	}
+
+	return in.scan(tool, opts)
+}
+
+// scan performs the actual scan for a given tool.
+func (in *Scanner) scan(tool console.StackType, options *v1.ScanOptions) ([]*console.StackPolicyViolationAttributes, error) {
+	switch tool {
+	case console.StackTypeTerraform, console.StackTypeOpentofu, console.StackTypeCdktf, console.StackTypeTerragrunt:
+		return in.scanTerraform(options)
+	default:
+		klog.Fatalf("unsupported tool type: %s", tool)
+		return nil, nil
+	}
+}
+
+// scanTerraform performs a scan of the Terraform working directory.
+func (in *Scanner) scanTerraform(options *v1.ScanOptions) ([]*console.StackPolicyViolationAttributes, error) {
+	args := []string{
+		"--directory", ".",
+		"--framework", "terraform",
+		"--output", "json",
+		"--quiet",
+		"--compact",
+		// Violations are reported through the stack run instead of the exit code.
+		"--soft-fail",
+	}
+
+	checks := in.PolicyPaths
+	if in.CustomPolicies {
+		checks = append([]string{path.Join(options.Terraform.WorkDir, v1.CustomPoliciesDir)}, checks...)
+	}
+
+	for _, dir := range checks {
+		args = append(args, "--external-checks-dir", dir)
+	}
+
+	if len(in.PolicyNamespaces) > 0 {
+		// Checkov has no namespaces, so they are used to select the checks to run instead, i.e. CKV_AWS or CKV2_*.
+		args = append(args, "--check", strings.Join(in.PolicyNamespaces, ","))
+	}
+
+	if len(options.Terraform.VariablesFileName) > 0 {
+		args = append(args, "--var-file", options.Terraform.VariablesFileName)
+	}
+
+	output, err := exec.NewExecutable(
+		"checkov",
+		exec.WithArgs(args),
+		exec.WithDir(options.Terraform.Dir),
+	).RunWithOutput(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed executing checkov: %s: %w", string(output), err)
+	}
+
+	klog.V(loglevel.LogLevelTrace).InfoS("checkov output", "output", string(output))
+	return in.toAttributes(output)
+}
+
+// toAttributes converts the Checkov output to a list of attributes.
+// Checkov prints a single report when one framework is scanned and a list otherwise.
+func (in *Scanner) toAttributes(data []byte) ([]*console.StackPolicyViolationAttributes, error) {
+	data = v1.JSONOutput(data)
+
+	reports := make([]Report, 0)
+	if strings.HasPrefix(strings.TrimSpace(string(data)), "[") {
+		if err := json.Unmarshal(data, &reports); err != nil {
+			return nil, err
+		}
+	} else {
+		report := Report{}
+		if err := json.Unmarshal(data, &report); err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+
+	return Reports(reports).Attributes(), nil
+}
+
+// New creates a new Checkov scanner.
+func New(config *console.PolicyEngineFragment) v1.Scanner {
+	return &Scanner{
+		DefaultScanner: v1.DefaultScanner{},
+		CustomPolicies: lo.FromPtr(config.GetCustomPolicies()),
+	}
+}
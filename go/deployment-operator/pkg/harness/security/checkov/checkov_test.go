@@ -0,0 +1,61 @@
+package checkov
+
+import (
+	"testing"
+
+	console "github.com/pluralsh/console/go/client"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToAttributes(t *testing.T) {
+	output := []byte(`2026-01-01 00:00:00 WARNING loading external checks
+	{
+		"check_type": "terraform",
+		"results": {
+			"failed_checks": [
+				{
+					"check_id": "CKV_AWS_20",
+					"check_name": "S3 Bucket has an ACL defined which allows public READ access.",
+					"check_class": "checkov.terraform.checks.resource.aws.S3PublicACLRead",
+					"guideline": "https://docs.prismacloud.io/policy",
+					"severity": null,
+					"file_path": "/main.tf",
+					"file_line_range": [1, 3],
+					"resource": "aws_s3_bucket.data",
+					"code_block": [[1, "resource \"aws_s3_bucket\" \"data\" {\n"], [2, "  acl = \"public-read\"\n"], [3, "}\n"]]
+				}
+			]
+		}
+	}`)
+
+	violations, err := (&Scanner{}).toAttributes(output)
+	require.NoError(t, err)
+	require.Len(t, violations, 1)
+
+	violation := violations[0]
+	assert.Equal(t, "CKV_AWS_20", violation.PolicyID)
+	assert.Equal(t, console.VulnSeverityUnknown, violation.Severity)
+	assert.Equal(t, "https://docs.prismacloud.io/policy", lo.FromPtr(violation.PolicyURL))
+	require.Len(t, violation.Causes, 1)
+	assert.Equal(t, "aws_s3_bucket.data", violation.Causes[0].Resource)
+	assert.Equal(t, int64(1), violation.Causes[0].Start)
+	assert.Equal(t, int64(3), violation.Causes[0].End)
+	require.Len(t, violation.Causes[0].Lines, 3)
+	assert.Equal(t, `  acl = "public-read"`, violation.Causes[0].Lines[1].Content)
+	assert.True(t, lo.FromPtr(violation.Causes[0].Lines[0].First))
+	assert.True(t, lo.FromPtr(violation.Causes[0].Lines[2].Last))
+}
+
+func TestToAttributesMultipleFrameworks(t *testing.T) {
+	output := []byte(`[
+		{"check_type": "terraform", "results": {"failed_checks": [{"check_id": "CKV_AWS_1", "check_name": "a", "resource": "r1"}]}},
+		{"check_type": "secrets", "results": {"failed_checks": [{"check_id": "CKV_AWS_1", "check_name": "a", "resource": "r2"}]}}
+	]`)
+
+	violations, err := (&Scanner{}).toAttributes(output)
+	require.NoError(t, err)
+	require.Len(t, violations, 1)
+	require.Len(t, violations[0].Causes, 2)
+}
@@ -0,0 +1,142 @@
+package checkov
+
+import (
+	"encoding/json"
+	"strings"
+
+	console "github.com/pluralsh/console/go/client"
+	"github.com/samber/lo"
+
+	v1 "github.com/pluralsh/console/go/deployment-operator/pkg/harness/security/v1"
+)
+
+// Scanner is a scanner implementation for checkov.
+type Scanner struct {
+	v1.DefaultScanner `json:",inline"`
+
+	// CustomPolicies enables loading custom checks from the .plural/policies
+	// subdirectory of the stack tarball.
+	CustomPolicies bool
+}
+
+// Report is a checkov JSON report of a single framework.
+type Report struct {
+	CheckType string `json:"check_type"`
+	Results   struct {
+		FailedChecks []Check `json:"failed_checks"`
+	} `json:"results"`
+}
+
+// Reports is a list of checkov reports, one per scanned framework.
+type Reports []Report
+
+// Check is a single failed check.
+type Check struct {
+	CheckID       string     `json:"check_id"`
+	CheckName     string     `json:"check_name"`
+	CheckClass    string     `json:"check_class"`
+	Description   string     `json:"description"`
+	Guideline     string     `json:"guideline"`
+	Severity      string     `json:"severity"`
+	FilePath      string     `json:"file_path"`
+	FileLineRange []int64    `json:"file_line_range"`
+	Resource      string     `json:"resource"`
+	CodeBlock     []CodeLine `json:"code_block"`
+}
+
+// CodeLine is a single line of a code block, serialized by checkov as a [number, content] tuple.
+type CodeLine struct {
+	Number  int64
+	Content string
+}
+
+// UnmarshalJSON implements [json.Unmarshaler] for the [number, content] tuple.
+func (in *CodeLine) UnmarshalJSON(data []byte) error {
+	var tuple []json.RawMessage
+	if err := json.Unmarshal(data, &tuple); err != nil {
+		return err
+	}
+
+	if len(tuple) > 0 {
+		if err := json.Unmarshal(tuple[0], &in.Number); err != nil {
+			return err
+		}
+	}
+
+	if len(tuple) > 1 {
+		if err := json.Unmarshal(tuple[1], &in.Content); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// maxDescriptionLen is the maximum allowed length for a policy violation description,
+// matching the server-side DB column constraint.
+const maxDescriptionLen = 255
+
+// Attributes transforms checkov [Reports] into the format acceptable by the Console API.
+// Violations are grouped by policyId (the server enforces uniqueness per stack run);
+// multiple affected resources for the same policy are merged as separate causes.
+func (in Reports) Attributes() []*console.StackPolicyViolationAttributes {
+	grouped := make(map[string]*console.StackPolicyViolationAttributes)
+	order := make([]string, 0)
+
+	for _, report := range in {
+		for _, check := range report.Results.FailedChecks {
+			attr := in.fromCheck(check)
+			if existing, ok := grouped[check.CheckID]; ok {
+				existing.Causes = append(existing.Causes, attr.Causes...)
+			} else {
+				grouped[check.CheckID] = attr
+				order = append(order, check.CheckID)
+			}
+		}
+	}
+
+	return lo.Map(order, func(id string, _ int) *console.StackPolicyViolationAttributes {
+		return grouped[id]
+	})
+}
+
+func (in Reports) fromCheck(check Check) *console.StackPolicyViolationAttributes {
+	desc := lo.CoalesceOrEmpty(check.Description, check.CheckName)
+	if len([]rune(desc)) > maxDescriptionLen {
+		desc = string([]rune(desc)[:maxDescriptionLen])
+	}
+
+	return &console.StackPolicyViolationAttributes{
+		// Severities are only returned by checkov when connected to the Prisma Cloud platform.
+		Severity:     v1.ParseSeverity(check.Severity, console.VulnSeverityUnknown),
+		PolicyID:     check.CheckID,
+		PolicyURL:    lo.EmptyableToPtr(check.Guideline),
+		PolicyModule: lo.EmptyableToPtr(check.CheckClass),
+		Title:        check.CheckName,
+		Description:  lo.ToPtr(desc),
+		Causes:       []*console.StackViolationCauseAttributes{in.toStackViolationCauseAttributes(check)},
+	}
+}
+
+func (in Reports) toStackViolationCauseAttributes(check Check) *console.StackViolationCauseAttributes {
+	cause := &console.StackViolationCauseAttributes{
+		Resource: check.Resource,
+		Filename: lo.EmptyableToPtr(check.FilePath),
+	}
+
+	if len(check.FileLineRange) == 2 {
+		cause.Start, cause.End = check.FileLineRange[0], check.FileLineRange[1]
+	}
+
+	cause.Lines = lo.Map(check.CodeBlock, func(line CodeLine, i int) *console.StackViolationCauseLineAttributes {
+		content := strings.TrimRight(line.Content, "\n")
+		return &console.StackViolationCauseLineAttributes{
+			Content: lo.Ternary(len(content) == 0, "..", content),
+			Line:    line.Number,
+			First:   lo.ToPtr(i == 0),
+			Last:    lo.ToPtr(i == len(check.CodeBlock)-1),
+		}
+	})
+
+	return cause
+}
@@ -0,0 +1,119 @@
+package conftest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+
+	console "github.com/pluralsh/console/go/client"
+	"github.com/samber/lo"
+	"k8s.io/klog/v2"
+
+	"github.com/pluralsh/console/go/deployment-operator/pkg/harness/exec"
+	v1 "github.com/pluralsh/console/go/deployment-operator/pkg/harness/security/v1"
+	loglevel "github.com/pluralsh/console/go/deployment-operator/pkg/log"
+)
+
+const (
+	// planJSONFileName is the name of the file the plan is rendered to before running conftest.
+	planJSONFileName = "plural.tfplan.json"
+)
+
+// Scan implements [v1.Scanner.Scan] interface.
+func (in *Scanner) Scan(tool console.StackType, options ...v1.ScanOption) ([]*console.StackPolicyViolationAttributes, error) {
+	opts := &v1.ScanOptions{}
+	for _, option := range options {
+		option(opts)
+	}
+
+	return in.scan(tool, opts)
+}
+
+// scan performs the actual scan for a given tool.
+func (in *Scanner) scan(tool console.StackType, options *v1.ScanOptions) ([]*console.StackPolicyViolationAttributes, error) {
+	switch tool {
+	case console.StackTypeTerraform, console.StackTypeCdktf:
+		return in.scanTerraform("terraform", options)
+	case console.StackTypeOpentofu:
+		return in.scanTerraform("tofu", options)
+	case console.StackTypeTerragrunt:
+		return in.scanTerraform("terragrunt", options)
+	default:
+		klog.Fatalf("unsupported tool type: %s", tool)
+		return nil, nil
+	}
+}
+
+// scanTerraform renders the plan file as JSON with the given binary and tests it against the policies.
+func (in *Scanner) scanTerraform(binary string, options *v1.ScanOptions) ([]*console.StackPolicyViolationAttributes, error) {
+	plan, err := exec.NewExecutable(
+		binary,
+		exec.WithArgs([]string{"show", "-json", options.Terraform.PlanFileName}),
+		exec.WithDir(options.Terraform.Dir),
+	).RunWithOutput(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed executing %s show: %s: %w", binary, string(plan), err)
+	}
+
+	if err := os.WriteFile(path.Join(options.Terraform.Dir, planJSONFileName), v1.JSONOutput(plan), 0644); err != nil {
+		return nil, fmt.Errorf("failed writing plan JSON: %w", err)
+	}
+
+	args := []string{
+		"test",
+		"--output", "json",
+		// Violations are reported through the stack run instead of the exit code.
+		"--no-fail",
+	}
+
+	policies := in.PolicyPaths
+	if in.CustomPolicies {
+		policies = append([]string{path.Join(options.Terraform.WorkDir, v1.CustomPoliciesDir)}, policies...)
+	}
+
+	for _, policy := range policies {
+		args = append(args, "--policy", policy)
+	}
+
+	if len(in.PolicyNamespaces) > 0 {
+		for _, namespace := range in.PolicyNamespaces {
+			args = append(args, "--namespace", namespace)
+		}
+	} else {
+		args = append(args, "--all-namespaces")
+	}
+
+	args = append(args, planJSONFileName)
+
+	output, err := exec.NewExecutable(
+		"conftest",
+		exec.WithArgs(args),
+		exec.WithDir(options.Terraform.Dir),
+	).RunWithOutput(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed executing conftest: %s: %w", string(output), err)
+	}
+
+	klog.V(loglevel.LogLevelTrace).InfoS("conftest output", "output", string(output))
+	return in.toAttributes(output)
+}
+
+// toAttributes converts the Conftest output to a list of attributes.
+func (in *Scanner) toAttributes(data []byte) ([]*console.StackPolicyViolationAttributes, error) {
+	report := Report{}
+	if err := json.Unmarshal(v1.JSONOutput(data), &report); err != nil {
+		return nil, err
+	}
+
+	return report.Attributes(), nil
+}
+
+// New creates a new Conftest scanner.
+func New(config *console.PolicyEngineFragment) v1.Scanner {
+	return &Scanner{
+		DefaultScanner: v1.DefaultScanner{},
+		CustomPolicies: lo.FromPtr(config.GetCustomPolicies()),
+	}
+}
@@ -0,0 +1,42 @@
+package conftest
+
+import (
+	"testing"
+
+	console "github.com/pluralsh/console/go/client"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToAttributes(t *testing.T) {
+	output := []byte(`[
+		{
+			"filename": "plural.tfplan.json",
+			"namespace": "main",
+			"successes": 3,
+			"failures": [
+				{"msg": "bucket aws_s3_bucket.a must be encrypted", "metadata": {"id": "S3_001", "severity": "CRITICAL", "resource": "aws_s3_bucket.a"}},
+				{"msg": "bucket aws_s3_bucket.b must be encrypted", "metadata": {"id": "S3_001", "severity": "CRITICAL", "resource": "aws_s3_bucket.b"}}
+			],
+			"warnings": [
+				{"msg": "resources should be tagged"}
+			]
+		}
+	]`)
+
+	violations, err := (&Scanner{}).toAttributes(output)
+	require.NoError(t, err)
+	require.Len(t, violations, 2)
+
+	assert.Equal(t, "S3_001", violations[0].PolicyID)
+	assert.Equal(t, console.VulnSeverityCritical, violations[0].Severity)
+	assert.Equal(t, "main", lo.FromPtr(violations[0].PolicyModule))
+	require.Len(t, violations[0].Causes, 2)
+	assert.Equal(t, "aws_s3_bucket.b", violations[0].Causes[1].Resource)
+
+	assert.Equal(t, console.VulnSeverityLow, violations[1].Severity)
+	assert.Equal(t, "resources should be tagged", violations[1].Title)
+	assert.Regexp(t, `^main\.[0-9a-f]{8}$`, violations[1].PolicyID)
+	assert.Empty(t, violations[1].Causes)
+}
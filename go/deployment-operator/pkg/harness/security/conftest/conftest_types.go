@@ -0,0 +1,119 @@
+package conftest
+
+import (
+	"fmt"
+	"hash/fnv"
+
+	console "github.com/pluralsh/console/go/client"
+	"github.com/samber/lo"
+
+	v1 "github.com/pluralsh/console/go/deployment-operator/pkg/harness/security/v1"
+)
+
+// Scanner is a scanner implementation for conftest.
+type Scanner struct {
+	v1.DefaultScanner `json:",inline"`
+
+	// CustomPolicies enables loading custom Rego policies from the .plural/policies
+	// subdirectory of the stack tarball.
+	CustomPolicies bool
+}
+
+// Report is the conftest JSON output, a list of results per tested file and namespace.
+type Report []CheckResult
+
+// CheckResult holds the outcome of all rules from a single namespace.
+type CheckResult struct {
+	Filename  string   `json:"filename"`
+	Namespace string   `json:"namespace"`
+	Warnings  []Result `json:"warnings"`
+	Failures  []Result `json:"failures"`
+}
+
+// Result is a single warn or deny rule result. Rules can return an object instead
+// of a plain message, in which case all other fields are exposed as metadata, i.e.:
+//
+//	deny contains {"msg": msg, "id": "S3_001", "severity": "HIGH", "resource": r.address} if { ... }
+type Result struct {
+	Message  string         `json:"msg"`
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// maxDescriptionLen is the maximum allowed length for a policy violation description,
+// matching the server-side DB column constraint.
+const maxDescriptionLen = 255
+
+// Attributes transforms a conftest [Report] into the format acceptable by the Console API.
+// Violations are grouped by policyId (the server enforces uniqueness per stack run);
+// multiple affected resources for the same policy are merged as separate causes.
+func (in Report) Attributes() []*console.StackPolicyViolationAttributes {
+	grouped := make(map[string]*console.StackPolicyViolationAttributes)
+	order := make([]string, 0)
+
+	add := func(result CheckResult, r Result, severity console.VulnSeverity) {
+		attr := in.fromResult(result, r, severity)
+		if existing, ok := grouped[attr.PolicyID]; ok {
+			existing.Causes = append(existing.Causes, attr.Causes...)
+			return
+		}
+
+		grouped[attr.PolicyID] = attr
+		order = append(order, attr.PolicyID)
+	}
+
+	for _, result := range in {
+		for _, failure := range result.Failures {
+			add(result, failure, console.VulnSeverityHigh)
+		}
+		for _, warning := range result.Warnings {
+			add(result, warning, console.VulnSeverityLow)
+		}
+	}
+
+	return lo.Map(order, func(id string, _ int) *console.StackPolicyViolationAttributes {
+		return grouped[id]
+	})
+}
+
+func (in Report) fromResult(result CheckResult, r Result, severity console.VulnSeverity) *console.StackPolicyViolationAttributes {
+	desc := r.metadata("description", r.Message)
+	if len([]rune(desc)) > maxDescriptionLen {
+		desc = string([]rune(desc)[:maxDescriptionLen])
+	}
+
+	attr := &console.StackPolicyViolationAttributes{
+		Severity:     v1.ParseSeverity(r.metadata("severity", ""), severity),
+		PolicyID:     r.metadata("id", r.policyID(result.Namespace)),
+		PolicyURL:    lo.EmptyableToPtr(r.metadata("url", "")),
+		PolicyModule: lo.ToPtr(result.Namespace),
+		Title:        r.metadata("title", r.Message),
+		Description:  lo.ToPtr(desc),
+		Resolution:   lo.EmptyableToPtr(r.metadata("resolution", "")),
+	}
+
+	if resource := r.metadata("resource", ""); len(resource) > 0 {
+		attr.Causes = []*console.StackViolationCauseAttributes{{
+			Resource: resource,
+			Filename: lo.EmptyableToPtr(result.Filename),
+		}}
+	}
+
+	return attr
+}
+
+// metadata returns a string metadata field returned by the rule or the fallback if it is not set.
+func (in Result) metadata(key, fallback string) string {
+	if value, ok := in.Metadata[key].(string); ok && len(value) > 0 {
+		return value
+	}
+
+	return fallback
+}
+
+// policyID derives a stable policy ID from the namespace and message
+// for rules that do not return an explicit id.
+func (in Result) policyID(namespace string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(in.Message))
+	return fmt.Sprintf("%s.%08x", namespace, h.Sum32())
+}
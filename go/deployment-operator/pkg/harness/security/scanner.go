@@ -4,6 +4,8 @@ import (
 	gqlclient "github.com/pluralsh/console/go/client"
 	"k8s.io/klog/v2"
 
+	"github.com/pluralsh/console/go/deployment-operator/pkg/harness/security/checkov"
+	"github.com/pluralsh/console/go/deployment-operator/pkg/harness/security/conftest"
 	"github.com/pluralsh/console/go/deployment-operator/pkg/harness/security/trivy"
 	v1 "github.com/pluralsh/console/go/deployment-operator/pkg/harness/security/v1"
 )
@@ -19,6 +21,10 @@ func NewScanner(config *gqlclient.PolicyEngineFragment) v1.Scanner {
 	switch config.Type {
 	case gqlclient.PolicyEngineTypeTrivy:
 		s = trivy.New(config)
+	case gqlclient.PolicyEngineTypeCheckov:
+		s = checkov.New(config)
+	case gqlclient.PolicyEngineTypeConftest:
+		s = conftest.New(config)
 	default:
 		klog.Fatalf("unsupported scanner type: %s", config.Type)
 	}
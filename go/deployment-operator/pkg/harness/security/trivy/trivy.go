@@ -15,12 +15,6 @@ import (
 	loglevel "github.com/pluralsh/console/go/deployment-operator/pkg/log"
 )
 
-const (
-	// customPoliciesDir is the subdirectory within the stack tarball root
-	// that contains custom Rego/OPA policy checks.
-	customPoliciesDir = ".plural/policies"
-)
-
 // Scan implements [v1.Scanner.Scan] interface.
 func (in *Scanner) Scan(tool console.StackType, options ...v1.ScanOption) ([]*console.StackPolicyViolationAttributes, error) {
 	opts := &v1.ScanOptions{}
@@ -34,7 +28,7 @@ func (in *Scanner) Scan(tool console.StackType, options ...v1.ScanOption) ([]*co
 // scan performs the actual scan for a given tool.
 func (in *Scanner) scan(tool console.StackType, options *v1.ScanOptions) ([]*console.StackPolicyViolationAttributes, error) {
 	switch tool {
-	case console.StackTypeTerraform, console.StackTypeTerragrunt:
+	case console.StackTypeTerraform, console.StackTypeOpentofu, console.StackTypeCdktf, console.StackTypeTerragrunt:
 		return in.scanTerraform(options)
 	default:
 		klog.Fatalf("unsupported tool type: %s", tool)
@@ -52,9 +46,9 @@ func (in *Scanner) scanTerraform(options *v1.ScanOptions) ([]*console.StackPolic
 	var checkNamespaces []string
 
 	if in.CustomPolicies {
-		configChecks = append(configChecks, path.Join(options.Terraform.WorkDir, customPoliciesDir))
+		configChecks = append(configChecks, path.Join(options.Terraform.WorkDir, v1.CustomPoliciesDir))
 		// "user" namespace must be explicitly registered so Trivy evaluates
-		// custom Rego policies loaded from customPoliciesDir. Without it,
+		// custom Rego policies loaded from v1.CustomPoliciesDir. Without it,
 		// Trivy silently ignores any policy whose package name starts with
 		// "user." (e.g. data.user.terraform.custom.*).
 		checkNamespaces = append(checkNamespaces, "user")
@@ -4,6 +4,10 @@ import (
 	console "github.com/pluralsh/console/go/client"
 )
 
+// CustomPoliciesDir is the subdirectory within the stack tarball root
+// that contains custom policy checks.
+const CustomPoliciesDir = ".plural/policies"
+
 // ScannerType defines the type of [Scanner] to be used.
 type ScannerType string
 
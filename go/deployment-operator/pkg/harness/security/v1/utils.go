@@ -1,6 +1,10 @@
 package v1
 
 import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
 	console "github.com/pluralsh/console/go/client"
 	"github.com/pluralsh/console/go/polly/algorithms"
 )
@@ -28,3 +32,63 @@ func MaxSeverity(violations []*console.StackPolicyViolationAttributes) int {
 func SeverityInt(severity console.VulnSeverity) int {
 	return severityToNumber[severity]
 }
+
+// JSONOutput returns the JSON report printed by a scanner, skipping any log lines
+// printed around it, since scanner output is captured together with stderr.
+// Log lines can contain brackets as well, i.e. [WARNING], so every candidate
+// offset is decoded until one of them holds a valid JSON value.
+func JSONOutput(data []byte) []byte {
+	for offset := 0; offset < len(data); {
+		i := bytes.IndexAny(data[offset:], "{[")
+		if i < 0 {
+			break
+		}
+
+		start := offset + i
+		if value, ok := jsonValue(data[start:]); ok {
+			return value
+		}
+
+		offset = start + 1
+	}
+
+	return data
+}
+
+// jsonValue decodes the JSON value at the beginning of data. It is only accepted
+// if nothing but whitespace follows it on its last line, so that log lines such
+// as "[1] scanning main.tf" are not mistaken for a report.
+func jsonValue(data []byte) ([]byte, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	var value json.RawMessage
+	if err := decoder.Decode(&value); err != nil {
+		return nil, false
+	}
+
+	end := int(decoder.InputOffset())
+	rest, _, _ := bytes.Cut(data[end:], []byte("\n"))
+	if len(bytes.TrimSpace(rest)) > 0 {
+		return nil, false
+	}
+
+	return data[:end], true
+}
+
+// ParseSeverity converts a severity name, i.e. HIGH or high, to [console.VulnSeverity].
+// Unrecognized values are mapped to the provided fallback.
+func ParseSeverity(severity string, fallback console.VulnSeverity) console.VulnSeverity {
+	switch strings.ToUpper(strings.TrimSpace(severity)) {
+	case "CRITICAL":
+		return console.VulnSeverityCritical
+	case "HIGH":
+		return console.VulnSeverityHigh
+	case "MEDIUM":
+		return console.VulnSeverityMedium
+	case "LOW":
+		return console.VulnSeverityLow
+	case "NONE", "INFO":
+		return console.VulnSeverityNone
+	default:
+		return fallback
+	}
+}
@@ -0,0 +1,49 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONOutput(t *testing.T) {
+	cases := map[string]struct {
+		input    string
+		expected string
+	}{
+		"plain report": {
+			input:    `{"results": []}`,
+			expected: `{"results": []}`,
+		},
+		"log lines before the report": {
+			input:    "2026-01-01 00:00:00 loading checks\n{\"results\": []}\n",
+			expected: `{"results": []}`,
+		},
+		"bracketed log level before the report": {
+			input:    "[WARNING] Failed to download policies\n[INFO] Using cached policies\n{\"results\": []}",
+			expected: `{"results": []}`,
+		},
+		"bracketed log level before an array report": {
+			input:    "[WARNING] Failed to download policies\n[{\"filename\": \"main.tf\"}]",
+			expected: `[{"filename": "main.tf"}]`,
+		},
+		"log line starting with a valid JSON value": {
+			input:    "[1] scanning main.tf\n[{\"filename\": \"main.tf\"}]",
+			expected: `[{"filename": "main.tf"}]`,
+		},
+		"log lines after the report": {
+			input:    "{\"results\": []}\n[INFO] done",
+			expected: `{"results": []}`,
+		},
+		"no report": {
+			input:    "[ERROR] scan failed",
+			expected: "[ERROR] scan failed",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, string(JSONOutput([]byte(tc.input))))
+		})
+	}
+}
@@ -46,6 +46,9 @@ type StackRun struct {
 	SSHKeyFile    *string
 	ConfigFile    *string
 
+	CostEstimation        bool
+	CostApprovalThreshold *float64
+
 	ConsoleURL   string
 	ConsoleToken string
 }
@@ -107,6 +110,10 @@ func (in *StackRun) FromStackRunBaseFragment(fragment *gqlclient.StackRunBaseFra
 		run.SSHKeyFile = ans.PrivateKeyFile
 		run.ConfigFile = ans.ConfigFile
 	}
+	if ce := fragment.Configuration.CostEstimation; ce != nil {
+		run.CostEstimation = ce.Enabled
+		run.CostApprovalThreshold = ce.ApprovalThreshold
+	}
 
 	return run
 }
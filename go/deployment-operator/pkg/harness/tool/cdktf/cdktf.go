@@ -0,0 +1,247 @@
+package cdktf
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	console "github.com/pluralsh/console/go/client"
+	"github.com/samber/lo"
+	"k8s.io/klog/v2"
+
+	"github.com/pluralsh/console/go/deployment-operator/internal/helpers"
+	"github.com/pluralsh/console/go/deployment-operator/pkg/harness/cost"
+	"github.com/pluralsh/console/go/deployment-operator/pkg/harness/exec"
+	"github.com/pluralsh/console/go/deployment-operator/pkg/harness/tool/terraform"
+	v1 "github.com/pluralsh/console/go/deployment-operator/pkg/harness/tool/v1"
+	"github.com/pluralsh/console/go/deployment-operator/pkg/log"
+)
+
+// Prepare implements [v1.Tool] interface.
+// It synthesizes the app to Terraform JSON configuration and selects the stack
+// that all further terraform commands, plan and state uploads operate on.
+func (in *CDKTF) Prepare() error {
+	if err := in.installDependencies(); err != nil {
+		return err
+	}
+
+	if err := in.getBindings(); err != nil {
+		return err
+	}
+
+	if output, err := in.command("synth", "--output", outputDir).RunWithOutput(context.Background()); err != nil {
+		return fmt.Errorf("failed executing cdktf synth: %s: %w", string(output), err)
+	}
+
+	manifest, err := in.manifest()
+	if err != nil {
+		return err
+	}
+
+	stack, err := manifest.stack(in.stackName)
+	if err != nil {
+		return err
+	}
+
+	config := in.config
+	config.ExecDir = path.Join(in.dir, outputDir, stack.WorkingDirectory)
+
+	in.stackDir = config.ExecDir
+	in.terraform = terraform.New(
+		config,
+		terraform.WithEnv(in.env),
+		terraform.WithStackType(console.StackTypeCdktf),
+	)
+
+	klog.V(log.LogLevelInfo).InfoS("synthesized cdktf stack", "stack", stack.Name, "dir", in.stackDir)
+	return nil
+}
+
+// Scan implements [v1.Tool] interface.
+func (in *CDKTF) Scan() ([]*console.StackPolicyViolationAttributes, error) {
+	return in.synthesized().Scan()
+}
+
+// Plan implements [v1.Tool] interface.
+func (in *CDKTF) Plan() (*console.StackStateAttributes, error) {
+	return in.synthesized().Plan()
+}
+
+// State implements [v1.Tool] interface.
+func (in *CDKTF) State() (*console.StackStateAttributes, error) {
+	return in.synthesized().State()
+}
+
+// Output implements [v1.Tool] interface.
+func (in *CDKTF) Output() ([]*console.StackOutputAttributes, error) {
+	return in.synthesized().Output()
+}
+
+// HasChanges implements [v1.Tool] interface.
+func (in *CDKTF) HasChanges() (bool, error) {
+	return in.synthesized().HasChanges()
+}
+
+// Cost implements [v1.Tool] interface.
+func (in *CDKTF) Cost() (*cost.Estimate, error) {
+	return in.synthesized().Cost()
+}
+
+// ConfigureStateBackend implements [v1.Tool] interface.
+// The override file is written to the synthesized stack directory, where it
+// replaces the local backend generated by cdktf.
+func (in *CDKTF) ConfigureStateBackend(actor, deployToken string, urls *console.StackRunBaseFragment_StateUrls) error {
+	return in.synthesized().ConfigureStateBackend(actor, deployToken, urls)
+}
+
+// Modifier implements [v1.Tool] interface.
+func (in *CDKTF) Modifier(stage console.StepStage) v1.Modifier {
+	if in.terraform == nil {
+		return v1.NewDefaultModifier()
+	}
+
+	return v1.NewMultiModifier(in.terraform.Modifier(stage), in.NewChdirArgsModifier())
+}
+
+// synthesized returns the terraform tool for the synthesized stack or the default
+// no-op tool if the app could not be synthesized.
+func (in *CDKTF) synthesized() v1.Tool {
+	if in.terraform == nil {
+		return &in.DefaultTool
+	}
+
+	return in.terraform
+}
+
+func (in *CDKTF) command(args ...string) exec.Executable {
+	return exec.NewExecutable(
+		"cdktf",
+		exec.WithArgs(args),
+		exec.WithDir(in.dir),
+		exec.WithEnv(in.env),
+	)
+}
+
+func (in *CDKTF) installDependencies() error {
+	// Node.js dependencies only, as it is the only language supported by the harness image.
+	if !helpers.Exists(path.Join(in.dir, "package.json")) {
+		return nil
+	}
+
+	output, err := exec.NewExecutable(
+		"npm",
+		exec.WithArgs([]string{"install", "--no-audit", "--no-fund"}),
+		exec.WithDir(in.dir),
+		exec.WithEnv(in.env),
+	).RunWithOutput(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed executing npm install: %s: %w", string(output), err)
+	}
+
+	return nil
+}
+
+// getBindings generates provider and module bindings if they are declared
+// in the project configuration but were not committed to the repository.
+func (in *CDKTF) getBindings() error {
+	data, err := os.ReadFile(path.Join(in.dir, configFileName))
+	if err != nil {
+		return fmt.Errorf("failed reading %s: %w", configFileName, err)
+	}
+
+	config := new(Config)
+	if err = json.Unmarshal(data, config); err != nil {
+		return fmt.Errorf("failed unmarshaling %s: %w", configFileName, err)
+	}
+
+	if len(config.TerraformProviders) == 0 && len(config.TerraformModules) == 0 {
+		return nil
+	}
+
+	if helpers.Exists(path.Join(in.dir, ".gen")) {
+		return nil
+	}
+
+	if output, err := in.command("get").RunWithOutput(context.Background()); err != nil {
+		return fmt.Errorf("failed executing cdktf get: %s: %w", string(output), err)
+	}
+
+	return nil
+}
+
+func (in *CDKTF) manifest() (*Manifest, error) {
+	data, err := os.ReadFile(path.Join(in.dir, outputDir, manifestFileName))
+	if err != nil {
+		return nil, fmt.Errorf("failed reading synth manifest: %w", err)
+	}
+
+	manifest := new(Manifest)
+	if err = json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("failed unmarshaling synth manifest: %w", err)
+	}
+
+	return manifest, nil
+}
+
+// stack returns the stack with the given name or the only stack of the app if the name is empty.
+func (in *Manifest) stack(name string) (*ManifestStack, error) {
+	names := lo.Keys(in.Stacks)
+	slices.Sort(names)
+
+	if len(name) > 0 {
+		stack, ok := in.Stacks[name]
+		if !ok {
+			return nil, fmt.Errorf("stack %q not found in the synthesized app, available stacks: %s", name, strings.Join(names, ", "))
+		}
+
+		return &stack, nil
+	}
+
+	switch len(names) {
+	case 0:
+		return nil, fmt.Errorf("synthesized app does not contain any stacks")
+	case 1:
+		stack := in.Stacks[names[0]]
+		return &stack, nil
+	default:
+		return nil, fmt.Errorf("synthesized app contains multiple stacks, set %s to one of: %s", stackEnvVar, strings.Join(names, ", "))
+	}
+}
+
+func envValue(env []string, key string) string {
+	prefix := key + "="
+	for _, entry := range env {
+		if strings.HasPrefix(entry, prefix) {
+			return strings.TrimPrefix(entry, prefix)
+		}
+	}
+
+	return ""
+}
+
+func (in *CDKTF) init() v1.Tool {
+	if len(in.dir) == 0 {
+		klog.Fatal("dir is required")
+	}
+
+	if len(envValue(in.env, checkpointEnvVar)) == 0 {
+		in.env = append(in.env, checkpointEnvVar+"=1")
+	}
+
+	in.stackName = envValue(in.env, stackEnvVar)
+	return in
+}
+
+// New creates a CDKTF structure that implements v1.Tool interface.
+func New(config v1.Config) v1.Tool {
+	return (&CDKTF{
+		DefaultTool: v1.DefaultTool{Scanner: config.Scanner, Estimator: config.Estimator},
+		config:      config,
+		dir:         config.ExecDir,
+		env:         slices.Clone(config.Env),
+	}).init()
+}
@@ -0,0 +1,112 @@
+package cdktf
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	console "github.com/pluralsh/console/go/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stackrunv1 "github.com/pluralsh/console/go/deployment-operator/pkg/harness/stackrun/v1"
+	toolv1 "github.com/pluralsh/console/go/deployment-operator/pkg/harness/tool/v1"
+)
+
+const mockManifestEnv = "MOCK_CDKTF_MANIFEST"
+const mockTerraformOutputEnv = "MOCK_TERRAFORM_OUTPUT"
+
+// setupMocks creates mock cdktf and terraform executables and adds them to the PATH.
+// The cdktf mock writes MOCK_CDKTF_MANIFEST as the synth manifest, the terraform
+// mock echoes MOCK_TERRAFORM_OUTPUT.
+func setupMocks(t *testing.T) {
+	tmpDir := t.TempDir()
+	scripts := map[string]string{
+		"cdktf": `#!/bin/sh
+if [ "$1" = "synth" ]; then
+  mkdir -p cdktf.out/stacks/dev cdktf.out/stacks/prod
+  echo "$` + mockManifestEnv + `" > cdktf.out/manifest.json
+fi
+`,
+		"terraform": `#!/bin/sh
+echo "$` + mockTerraformOutputEnv + `"
+`,
+	}
+
+	for name, content := range scripts {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	path := os.Getenv("PATH")
+	t.Setenv("PATH", tmpDir+string(os.PathListSeparator)+path)
+}
+
+func newProject(t *testing.T) string {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, configFileName), []byte(`{"language":"typescript","app":"npx ts-node main.ts"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return dir
+}
+
+const singleStackManifest = `{"version":"0.21.0","stacks":{"dev":{"name":"dev","workingDirectory":"stacks/dev","synthesizedStackPath":"stacks/dev/cdk.tf.json"}}}`
+
+const multiStackManifest = `{"version":"0.21.0","stacks":{
+  "dev":{"name":"dev","workingDirectory":"stacks/dev","synthesizedStackPath":"stacks/dev/cdk.tf.json"},
+  "prod":{"name":"prod","workingDirectory":"stacks/prod","synthesizedStackPath":"stacks/prod/cdk.tf.json"}
+}}`
+
+func TestPrepareSelectsSingleStack(t *testing.T) {
+	setupMocks(t)
+	t.Setenv(mockManifestEnv, singleStackManifest)
+	t.Setenv(mockTerraformOutputEnv, `{"format_version":"1.0","values":{"root_module":{"resources":[{"address":"aws_s3_bucket.example"}]}}}`)
+
+	dir := newProject(t)
+	tool := New(toolv1.Config{ExecDir: dir, Run: &stackrunv1.StackRun{}})
+	require.NoError(t, tool.Prepare())
+
+	stackDir := filepath.Join(dir, "cdktf.out", "stacks", "dev")
+	assert.Equal(t, stackDir, tool.(*CDKTF).stackDir)
+	assert.FileExists(t, filepath.Join(stackDir, "terraform.tfplan"))
+
+	state, err := tool.State()
+	require.NoError(t, err)
+	assert.Len(t, state.State, 1)
+
+	args := tool.Modifier(console.StepStagePlan).Args([]string{"plan"})
+	assert.Equal(t, []string{"-chdir=" + stackDir, "plan", "-out=terraform.tfplan"}, args)
+}
+
+func TestPrepareSelectsStackFromEnv(t *testing.T) {
+	setupMocks(t)
+	t.Setenv(mockManifestEnv, multiStackManifest)
+
+	dir := newProject(t)
+	tool := New(toolv1.Config{ExecDir: dir, Run: &stackrunv1.StackRun{}, Env: []string{"CDKTF_STACK=prod"}})
+	require.NoError(t, tool.Prepare())
+
+	assert.Equal(t, filepath.Join(dir, "cdktf.out", "stacks", "prod"), tool.(*CDKTF).stackDir)
+}
+
+func TestPrepareRequiresStackSelection(t *testing.T) {
+	setupMocks(t)
+	t.Setenv(mockManifestEnv, multiStackManifest)
+
+	tool := New(toolv1.Config{ExecDir: newProject(t), Run: &stackrunv1.StackRun{}})
+	assert.ErrorContains(t, tool.Prepare(), "set CDKTF_STACK to one of: dev, prod")
+
+	state, err := tool.State()
+	assert.NoError(t, err)
+	assert.Nil(t, state)
+}
+
+func TestChdirArgsModifier(t *testing.T) {
+	modifier := &ChdirArgsModifier{dir: "/stack"}
+
+	assert.Equal(t, []string{"-chdir=/stack", "init", "-upgrade"}, modifier.Args([]string{"init", "-upgrade"}))
+	assert.Equal(t, []string{"-chdir=/other", "plan"}, modifier.Args([]string{"-chdir=/other", "plan"}))
+	assert.Equal(t, []string{"npm", "test"}, modifier.Args([]string{"npm", "test"}))
+}
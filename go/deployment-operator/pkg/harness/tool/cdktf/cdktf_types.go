@@ -0,0 +1,69 @@
+package cdktf
+
+import (
+	v1 "github.com/pluralsh/console/go/deployment-operator/pkg/harness/tool/v1"
+)
+
+const (
+	// outputDir is the directory the app is synthesized to, relative to the working directory.
+	outputDir = "cdktf.out"
+
+	// manifestFileName is the synth manifest listing all synthesized stacks.
+	manifestFileName = "manifest.json"
+
+	// configFileName is the CDKTF project configuration file.
+	configFileName = "cdktf.json"
+
+	// stackEnvVar selects the synthesized stack to plan when the app defines more than one.
+	stackEnvVar = "CDKTF_STACK"
+
+	// checkpointEnvVar disables the CDKTF telemetry and version checks.
+	checkpointEnvVar = "CHECKPOINT_DISABLE"
+)
+
+// CDKTF implements tool.Tool interface.
+// The app is synthesized during Prepare and all other operations are delegated
+// to the terraform tool running in the selected synthesized stack directory.
+type CDKTF struct {
+	v1.DefaultTool
+
+	// config is the tool configuration used to create the terraform tool after synth.
+	config v1.Config
+
+	// dir is a working directory used by harness.
+	dir string
+
+	// env is the stack run environment passed to cdktf and terraform.
+	env []string
+
+	// stackName is the name of the synthesized stack to plan.
+	// Default: the only stack defined by the app
+	stackName string
+
+	// stackDir is the synthesized stack directory, available after Prepare.
+	stackDir string
+
+	// terraform is the terraform tool running in stackDir, available after Prepare.
+	terraform v1.Tool
+}
+
+// Config is the subset of the cdktf.json project configuration used by the harness.
+type Config struct {
+	Language           string `json:"language"`
+	App                string `json:"app"`
+	TerraformProviders []any  `json:"terraformProviders"`
+	TerraformModules   []any  `json:"terraformModules"`
+}
+
+// Manifest is the synth manifest written to cdktf.out/manifest.json.
+type Manifest struct {
+	Version string                   `json:"version"`
+	Stacks  map[string]ManifestStack `json:"stacks"`
+}
+
+// ManifestStack is a single synthesized stack.
+type ManifestStack struct {
+	Name                 string `json:"name"`
+	WorkingDirectory     string `json:"workingDirectory"`
+	SynthesizedStackPath string `json:"synthesizedStackPath"`
+}
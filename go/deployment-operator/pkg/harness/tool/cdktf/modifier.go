@@ -0,0 +1,31 @@
+package cdktf
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/samber/lo"
+
+	v1 "github.com/pluralsh/console/go/deployment-operator/pkg/harness/tool/v1"
+)
+
+// subcommands are the terraform subcommands that should run in the synthesized stack directory.
+var subcommands = []string{"init", "validate", "plan", "apply", "destroy", "output", "show", "state", "import", "refresh", "providers"}
+
+// Args implements [v1.ArgsModifier] type.
+// Terraform global options have to be placed before the subcommand.
+func (in *ChdirArgsModifier) Args(args []string) []string {
+	if len(args) == 0 || !lo.Contains(subcommands, args[0]) {
+		return args
+	}
+
+	if lo.ContainsBy(args, func(arg string) bool { return strings.HasPrefix(arg, "-chdir=") }) {
+		return args
+	}
+
+	return append([]string{fmt.Sprintf("-chdir=%s", in.dir)}, args...)
+}
+
+func (in *CDKTF) NewChdirArgsModifier() v1.Modifier {
+	return &ChdirArgsModifier{dir: in.stackDir}
+}
@@ -0,0 +1,13 @@
+package cdktf
+
+import (
+	v1 "github.com/pluralsh/console/go/deployment-operator/pkg/harness/tool/v1"
+)
+
+// ChdirArgsModifier implements [v1.ArgsModifier] interface.
+type ChdirArgsModifier struct {
+	v1.DefaultModifier
+
+	// dir is the synthesized stack directory.
+	dir string
+}
@@ -0,0 +1,29 @@
+package opentofu
+
+import (
+	console "github.com/pluralsh/console/go/client"
+
+	"github.com/pluralsh/console/go/deployment-operator/pkg/harness/tool/terraform"
+	v1 "github.com/pluralsh/console/go/deployment-operator/pkg/harness/tool/v1"
+)
+
+const (
+	// binary is the OpenTofu executable name.
+	binary = "tofu"
+)
+
+// New creates an OpenTofu tool that implements v1.Tool interface.
+//
+// OpenTofu is plan and state compatible with Terraform, so the terraform tool is reused
+// with the tofu binary. The stack run environment is passed to every tofu invocation,
+// as state and plan encryption is configured through the TF_ENCRYPTION env var or an
+// encryption block whose key providers usually read credentials from the environment.
+// Without it, encrypted plans and state could not be read by tofu show -json.
+func New(config v1.Config) v1.Tool {
+	return terraform.New(
+		config,
+		terraform.WithBinary(binary),
+		terraform.WithEnv(config.Env),
+		terraform.WithStackType(console.StackTypeOpentofu),
+	)
+}
@@ -0,0 +1,79 @@
+package opentofu
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	stackrunv1 "github.com/pluralsh/console/go/deployment-operator/pkg/harness/stackrun/v1"
+	toolv1 "github.com/pluralsh/console/go/deployment-operator/pkg/harness/tool/v1"
+)
+
+const mockTofuOutputEnv = "MOCK_TOFU_OUTPUT"
+
+// setupMockTofu creates a mock tofu executable that echoes MOCK_TOFU_OUTPUT,
+// but only when state encryption was configured, as the real binary would fail
+// to decrypt the state otherwise.
+func setupMockTofu(t *testing.T) {
+	tmpDir := t.TempDir()
+	script := filepath.Join(tmpDir, "tofu")
+	content := `#!/bin/sh
+if [ -z "$TF_ENCRYPTION" ]; then
+  echo "encrypted state found but no encryption configured" >&2
+  exit 1
+fi
+echo "$` + mockTofuOutputEnv + `"
+`
+
+	err := os.WriteFile(script, []byte(content), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := os.Getenv("PATH")
+	t.Setenv("PATH", tmpDir+string(os.PathListSeparator)+path)
+}
+
+func TestStatePassesEncryptionEnv(t *testing.T) {
+	setupMockTofu(t)
+	t.Setenv(mockTofuOutputEnv, `{"format_version":"1.0","values":{"outputs":{"bucket":{"value":"example","sensitive":false}},"root_module":{"resources":[{"address":"aws_s3_bucket.example"}]}}}`)
+
+	tool := New(toolv1.Config{
+		ExecDir: t.TempDir(),
+		Run:     &stackrunv1.StackRun{},
+		Env:     []string{`TF_ENCRYPTION=key_provider "pbkdf2" "main" { passphrase = "secret-passphrase" }`},
+	})
+
+	state, err := tool.State()
+	assert.NoError(t, err)
+	assert.Len(t, state.State, 1)
+	assert.Equal(t, "aws_s3_bucket.example", state.State[0].Identifier)
+
+	outputs, err := tool.Output()
+	assert.NoError(t, err)
+	assert.Len(t, outputs, 1)
+	assert.Equal(t, "bucket", outputs[0].Name)
+}
+
+func TestStateFailsWithoutEncryptionEnv(t *testing.T) {
+	setupMockTofu(t)
+
+	tool := New(toolv1.Config{ExecDir: t.TempDir(), Run: &stackrunv1.StackRun{}})
+
+	_, err := tool.State()
+	assert.ErrorContains(t, err, "failed executing tofu show -json")
+}
+
+func TestHasChanges(t *testing.T) {
+	setupMockTofu(t)
+	t.Setenv("TF_ENCRYPTION", "configured")
+	t.Setenv(mockTofuOutputEnv, `{"format_version":"1.2","resource_changes":[{"address":"aws_s3_bucket.example","change":{"actions":["create"]}}]}`)
+
+	tool := New(toolv1.Config{ExecDir: t.TempDir(), Run: &stackrunv1.StackRun{}})
+
+	hasChanges, err := tool.HasChanges()
+	assert.NoError(t, err)
+	assert.True(t, hasChanges)
+}
@@ -13,6 +13,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"github.com/pluralsh/console/go/deployment-operator/internal/helpers"
+	"github.com/pluralsh/console/go/deployment-operator/pkg/harness/cost"
 	"github.com/pluralsh/console/go/deployment-operator/pkg/harness/exec"
 	securityv1 "github.com/pluralsh/console/go/deployment-operator/pkg/harness/security/v1"
 	tfapi "github.com/pluralsh/console/go/deployment-operator/pkg/harness/tool/terraform/api"
@@ -125,13 +126,9 @@ func (in *Terraform) state() (*tfjson.State, error) {
 	}
 
 	state := new(tfjson.State)
-	output, err := exec.NewExecutable(
-		"terraform",
-		exec.WithArgs([]string{"show", "-json"}),
-		exec.WithDir(in.dir),
-	).RunWithOutput(context.Background())
+	output, err := in.command("show", "-json").RunWithOutput(context.Background())
 	if err != nil {
-		return state, fmt.Errorf("failed executing terraform show -json: %s: %w", string(output), err)
+		return state, fmt.Errorf("failed executing %s show -json: %s: %w", in.binaryName(), string(output), err)
 	}
 
 	err = state.UnmarshalJSON(output)
@@ -159,7 +156,7 @@ func (in *Terraform) Scan() ([]*console.StackPolicyViolationAttributes, error) {
 		return result, nil
 	}
 
-	result, err := in.Scanner.Scan(console.StackTypeTerraform, securityv1.WithTerraform(securityv1.TerraformScanOptions{
+	result, err := in.Scanner.Scan(in.scanType(), securityv1.WithTerraform(securityv1.TerraformScanOptions{
 		WorkDir:           in.workDir,
 		Dir:               in.dir,
 		PlanFileName:      in.planFileName,
@@ -175,13 +172,9 @@ func (in *Terraform) plan() (string, error) {
 		return *in.planTextCache, nil
 	}
 
-	output, err := exec.NewExecutable(
-		"terraform",
-		exec.WithArgs([]string{"show", in.planFileName}),
-		exec.WithDir(in.dir),
-	).RunWithOutput(context.Background())
+	output, err := in.command("show", in.planFileName).RunWithOutput(context.Background())
 	if err != nil {
-		return "", fmt.Errorf("failed executing terraform show: %s: %w", string(output), err)
+		return "", fmt.Errorf("failed executing %s show: %s: %w", in.binaryName(), string(output), err)
 	}
 
 	planText := string(output)
@@ -218,17 +211,13 @@ func (in *Terraform) planJSONRaw() (string, error) {
 		return *in.planJSONRawCache, nil
 	}
 
-	output, err := exec.NewExecutable(
-		"terraform",
-		exec.WithArgs([]string{"show", "-json", in.planFileName}),
-		exec.WithDir(in.dir),
-	).RunWithOutput(context.Background())
+	output, err := in.command("show", "-json", in.planFileName).RunWithOutput(context.Background())
 	if err != nil {
-		return "", fmt.Errorf("failed executing terraform show -json: %s: %w", string(output), err)
+		return "", fmt.Errorf("failed executing %s show -json: %s: %w", in.binaryName(), string(output), err)
 	}
 
 	if !json.Valid(output) {
-		return "", fmt.Errorf("terraform show -json returned invalid JSON")
+		return "", fmt.Errorf("%s show -json returned invalid JSON", in.binaryName())
 	}
 
 	raw := string(output)
@@ -254,6 +243,21 @@ func (in *Terraform) HasChanges() (bool, error) {
 	return hasChanges(plan), nil
 }
 
+// Cost implements [v1.Tool] interface.
+// It prices the resource changes from the plan file with the configured estimator.
+func (in *Terraform) Cost() (*cost.Estimate, error) {
+	if in.Estimator == nil {
+		return nil, nil
+	}
+
+	plan, err := in.planJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	return in.Estimator.Estimate(plan), nil
+}
+
 func hasChanges(plan *tfjson.Plan) bool {
 	// If there are deferred changes, we should consider this as having changes
 	if len(plan.DeferredChanges) > 0 {
@@ -307,6 +311,24 @@ func hasChanges(plan *tfjson.Plan) bool {
 	return false
 }
 
+// command creates an executable that runs the Terraform-compatible binary in the working directory.
+func (in *Terraform) command(args ...string) exec.Executable {
+	return exec.NewExecutable(
+		in.binaryName(),
+		exec.WithArgs(args),
+		exec.WithDir(in.dir),
+		exec.WithEnv(in.env),
+	)
+}
+
+func (in *Terraform) binaryName() string {
+	return lo.CoalesceOrEmpty(in.binary, defaultBinary)
+}
+
+func (in *Terraform) scanType() console.StackType {
+	return lo.CoalesceOrEmpty(in.stackType, console.StackTypeTerraform)
+}
+
 func (in *Terraform) init() v1.Tool {
 	if len(in.dir) == 0 {
 		klog.Fatal("dir is required")
@@ -324,13 +346,20 @@ func (in *Terraform) init() v1.Tool {
 }
 
 // New creates a Terraform structure that implements v1.Tool interface.
-func New(config v1.Config) v1.Tool {
-	return (&Terraform{
-		DefaultTool: v1.DefaultTool{Scanner: config.Scanner},
+// Options allow to reuse it for Terraform-compatible binaries, i.e. OpenTofu.
+func New(config v1.Config, options ...Option) v1.Tool {
+	tf := &Terraform{
+		DefaultTool: v1.DefaultTool{Scanner: config.Scanner, Estimator: config.Estimator},
 		workDir:     config.WorkDir,
 		dir:         config.ExecDir,
 		variables:   config.Variables,
 		parallelism: config.Run.Parallelism,
 		refresh:     config.Run.Refresh,
-	}).init()
+	}
+
+	for _, option := range options {
+		option(tf)
+	}
+
+	return tf.init()
 }
@@ -0,0 +1,29 @@
+package terraform
+
+import (
+	console "github.com/pluralsh/console/go/client"
+)
+
+// Option allows to customize the [Terraform] tool.
+type Option func(*Terraform)
+
+// WithBinary sets the Terraform-compatible executable used to read plans and state.
+func WithBinary(binary string) Option {
+	return func(t *Terraform) {
+		t.binary = binary
+	}
+}
+
+// WithEnv sets additional env vars passed to the executable when reading plans and state.
+func WithEnv(env []string) Option {
+	return func(t *Terraform) {
+		t.env = env
+	}
+}
+
+// WithStackType sets the stack type passed to the security scanner.
+func WithStackType(stackType console.StackType) Option {
+	return func(t *Terraform) {
+		t.stackType = stackType
+	}
+}
@@ -2,9 +2,16 @@ package terraform
 
 import (
 	tfjson "github.com/hashicorp/terraform-json"
+	console "github.com/pluralsh/console/go/client"
+
 	toolv1 "github.com/pluralsh/console/go/deployment-operator/pkg/harness/tool/v1"
 )
 
+const (
+	// defaultBinary is the executable used when no other binary was configured.
+	defaultBinary = "terraform"
+)
+
 // Terraform implements tool.Tool interface.
 type Terraform struct {
 	toolv1.DefaultTool
@@ -15,6 +22,18 @@ type Terraform struct {
 	// dir is a working directory used by harness.
 	dir string
 
+	// binary is the Terraform-compatible executable used to read plans and state.
+	// Default: terraform
+	binary string
+
+	// env is a list of additional env vars passed to the binary,
+	// i.e. OpenTofu state encryption configuration.
+	env []string
+
+	// stackType is the stack type passed to the security scanner.
+	// Default: TERRAFORM
+	stackType console.StackType
+
 	// planFileName is a terraform plan file name.
 	// Default: terraform.tfplan
 	planFileName string
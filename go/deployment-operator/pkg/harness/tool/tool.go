@@ -5,6 +5,8 @@ import (
 	"k8s.io/klog/v2"
 
 	"github.com/pluralsh/console/go/deployment-operator/pkg/harness/tool/ansible"
+	"github.com/pluralsh/console/go/deployment-operator/pkg/harness/tool/cdktf"
+	"github.com/pluralsh/console/go/deployment-operator/pkg/harness/tool/opentofu"
 	"github.com/pluralsh/console/go/deployment-operator/pkg/harness/tool/pulumi"
 	"github.com/pluralsh/console/go/deployment-operator/pkg/harness/tool/terraform"
 	"github.com/pluralsh/console/go/deployment-operator/pkg/harness/tool/terragrunt"
@@ -18,6 +20,10 @@ func New(stackType console.StackType, config v1.Config) v1.Tool {
 	switch stackType {
 	case console.StackTypeTerraform:
 		t = terraform.New(config)
+	case console.StackTypeOpentofu:
+		t = opentofu.New(config)
+	case console.StackTypeCdktf:
+		t = cdktf.New(config)
 	case console.StackTypeTerragrunt:
 		t = terragrunt.New(config)
 	case console.StackTypePulumi:
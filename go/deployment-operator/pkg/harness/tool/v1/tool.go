@@ -2,6 +2,8 @@ package v1
 
 import (
 	console "github.com/pluralsh/console/go/client"
+
+	"github.com/pluralsh/console/go/deployment-operator/pkg/harness/cost"
 )
 
 // Prepare implements [Tool] interface.
@@ -46,6 +48,11 @@ func (in *DefaultTool) HasChanges() (bool, error) {
 	return true, nil
 }
 
+// Cost implements [Tool] interface.
+func (in *DefaultTool) Cost() (*cost.Estimate, error) {
+	return nil, nil
+}
+
 func New() Tool {
 	return &DefaultTool{}
 }
@@ -5,6 +5,7 @@ import (
 
 	console "github.com/pluralsh/console/go/client"
 
+	"github.com/pluralsh/console/go/deployment-operator/pkg/harness/cost"
 	securityv1 "github.com/pluralsh/console/go/deployment-operator/pkg/harness/security/v1"
 
 	stackrunv1 "github.com/pluralsh/console/go/deployment-operator/pkg/harness/stackrun/v1"
@@ -44,12 +45,19 @@ type Tool interface {
 	// Returns true if changes are detected, false for no-op plans.
 	// This allows the harness to skip unnecessary apply steps and not wait for approvals to free up resources.
 	HasChanges() (bool, error)
+	// Cost estimates the monthly cost of the planned changes based on local files
+	// created by specific tool after PLAN stage. Returns nil if cost estimation is
+	// not enabled or not supported by the tool.
+	Cost() (*cost.Estimate, error)
 }
 
 // DefaultTool implements [Tool] interface.
 type DefaultTool struct {
 	// Scanner is a security scanner. See [securityv1.Scanner] for more information.
 	Scanner securityv1.Scanner
+
+	// Estimator is a cost estimator. It is nil when cost estimation is disabled.
+	Estimator *cost.Estimator
 }
 
 // Modifier can do many different runtime modifications
@@ -103,6 +111,9 @@ type Config struct {
 	// Scanner is a security scanner. See [securityv1.Scanner] for more information.
 	Scanner securityv1.Scanner
 
+	// Estimator is a cost estimator. It is nil when cost estimation is disabled.
+	Estimator *cost.Estimator
+
 	// Run is a stack run that is being processed.
 	Run *stackrunv1.StackRun
 
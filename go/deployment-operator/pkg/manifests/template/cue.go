@@ -0,0 +1,97 @@
+package template
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	console "github.com/pluralsh/console/go/client"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	CueBinary = "cue"
+
+	// CueBindingsField is the top level field service bindings are unified into,
+	// e.g. plural.configuration or plural.cluster.
+	CueBindingsField = "plural"
+	// CueObjectsField is the top level field manifests are read from. When it is
+	// not defined the whole package value, minus the bindings, is used instead.
+	CueObjectsField = "objects"
+
+	cueTimeout = 2 * time.Minute
+)
+
+type cueTemplate struct {
+	dir string
+}
+
+// NewCue creates a renderer that exports the CUE package in dir with the cue CLI, which
+// has to be available on the PATH. Service bindings are unified into the plural field.
+func NewCue(dir string) Template {
+	return &cueTemplate{dir}
+}
+
+func (c *cueTemplate) Render(svc *console.ServiceDeploymentForAgent, mapper meta.RESTMapper) ([]unstructured.Unstructured, error) {
+	binary, err := exec.LookPath(CueBinary)
+	if err != nil {
+		return nil, fmt.Errorf("%s binary not found, it is required by the cue renderer: %w", CueBinary, err)
+	}
+
+	bindingsFile, err := c.writeBindings(svc)
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(bindingsFile)
+
+	ctx, cancel := context.WithTimeout(context.Background(), cueTimeout)
+	defer cancel()
+
+	// the bindings file is placed under the plural field with the --path flag
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	cmd := exec.CommandContext(ctx, binary, "export", ".", bindingsFile, "--path", CueBindingsField+":", "--out", "json")
+	cmd.Dir = c.dir
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to run cue: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	var value map[string]any
+	if err := json.Unmarshal(stdout.Bytes(), &value); err != nil {
+		return nil, fmt.Errorf("failed to parse cue output: %w", err)
+	}
+
+	if objects, ok := value[CueObjectsField]; ok {
+		return renderValue(objects, mapper, "cue", svc.Namespace)
+	}
+
+	// bindings are inputs only, make sure they never end up in the manifests
+	delete(value, CueBindingsField)
+	return renderValue(value, mapper, "cue", svc.Namespace)
+}
+
+func (c *cueTemplate) writeBindings(svc *console.ServiceDeploymentForAgent) (string, error) {
+	data, err := json.Marshal(bindings(svc))
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal bindings: %w", err)
+	}
+
+	file, err := os.CreateTemp("", "cue-bindings-*.json")
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	if _, err := file.Write(data); err != nil {
+		return "", err
+	}
+
+	return file.Name(), nil
+}
@@ -0,0 +1,31 @@
+package template
+
+import (
+	"os/exec"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cue template", func() {
+
+	svc := rendererService()
+
+	Context("Render cue template", func() {
+		It("should successfully render the cue template", func() {
+			if _, err := exec.LookPath(CueBinary); err != nil {
+				Skip("cue binary is not installed")
+			}
+
+			dir := filepath.Join("..", "..", "..", "test", "cue")
+			resp, err := NewCue(dir).Render(svc, mapper)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(resp)).To(Equal(2))
+			Expect(resp[0].GetKind()).To(Equal("ConfigMap"))
+			Expect(resp[0].GetName()).To(Equal("nginx-cluster"))
+			Expect(resp[1].GetKind()).To(Equal("Deployment"))
+			Expect(resp[1].GetName()).To(Equal("nginx"))
+		})
+	})
+})
@@ -0,0 +1,165 @@
+package template
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	gojsonnet "github.com/google/go-jsonnet"
+	console "github.com/pluralsh/console/go/client"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	JsonnetMainFile  = "main.jsonnet"
+	jsonnetExtension = ".jsonnet"
+)
+
+// jsonnetLibraryPaths are the directories, relative to the renderer path, added to the
+// Jsonnet import path. They follow the jsonnet-bundler and tanka conventions.
+var jsonnetLibraryPaths = []string{"lib", "vendor"}
+
+type jsonnet struct {
+	dir string
+}
+
+// NewJsonnet creates a renderer that evaluates main.jsonnet in dir, or every top level
+// .jsonnet file when there is no main.jsonnet. Service bindings are available as
+// external variables, e.g. std.extVar('configuration') or std.extVar('cluster').
+func NewJsonnet(dir string) Template {
+	return &jsonnet{dir}
+}
+
+func (j *jsonnet) Render(svc *console.ServiceDeploymentForAgent, mapper meta.RESTMapper) ([]unstructured.Unstructured, error) {
+	files, err := j.entrypoints()
+	if err != nil {
+		return nil, err
+	}
+
+	vm, err := j.vm(svc)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]unstructured.Unstructured, 0)
+	for _, file := range files {
+		output, err := vm.EvaluateFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate %s: %w", filepath.Base(file), err)
+		}
+
+		var value any
+		if err := json.Unmarshal([]byte(output), &value); err != nil {
+			return nil, fmt.Errorf("failed to parse %s output: %w", filepath.Base(file), err)
+		}
+
+		items, err := renderValue(value, mapper, "jsonnet", svc.Namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s output: %w", filepath.Base(file), err)
+		}
+
+		res = append(res, items...)
+	}
+
+	return res, nil
+}
+
+func (j *jsonnet) vm(svc *console.ServiceDeploymentForAgent) (*gojsonnet.VM, error) {
+	vm := gojsonnet.MakeVM()
+
+	jpaths := []string{j.dir}
+	for _, path := range jsonnetLibraryPaths {
+		jpaths = append(jpaths, filepath.Join(j.dir, path))
+	}
+	vm.Importer(&sandboxedImporter{roots: jpaths, importer: &gojsonnet.FileImporter{JPaths: jpaths}})
+
+	for name, value := range bindings(svc) {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s binding: %w", name, err)
+		}
+		vm.ExtCode(name, string(data))
+	}
+
+	return vm, nil
+}
+
+// sandboxedImporter resolves imports with the stock file importer, but rejects files outside
+// of the service directory and library paths, so templates cannot read files of the operator pod.
+type sandboxedImporter struct {
+	roots    []string
+	importer *gojsonnet.FileImporter
+}
+
+func (in *sandboxedImporter) Import(importedFrom, importedPath string) (gojsonnet.Contents, string, error) {
+	contents, foundAt, err := in.importer.Import(importedFrom, importedPath)
+	if err != nil {
+		return contents, foundAt, err
+	}
+
+	if !in.allowed(foundAt) {
+		return gojsonnet.Contents{}, "", fmt.Errorf("import %q is outside of the service directory", importedPath)
+	}
+
+	return contents, foundAt, nil
+}
+
+func (in *sandboxedImporter) allowed(path string) bool {
+	resolved, err := resolvePath(path)
+	if err != nil {
+		return false
+	}
+
+	for _, root := range in.roots {
+		resolvedRoot, err := resolvePath(root)
+		if err != nil {
+			continue
+		}
+
+		if rel, err := filepath.Rel(resolvedRoot, resolved); err == nil && filepath.IsLocal(rel) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resolvePath returns the absolute path with symlinks evaluated, so links cannot escape a root.
+func resolvePath(path string) (string, error) {
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Abs(resolved)
+}
+
+func (j *jsonnet) entrypoints() ([]string, error) {
+	main := filepath.Join(j.dir, JsonnetMainFile)
+	if _, err := os.Stat(main); err == nil {
+		return []string{main}, nil
+	}
+
+	entries, err := os.ReadDir(j.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]string, 0)
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.EqualFold(filepath.Ext(entry.Name()), jsonnetExtension) {
+			files = append(files, filepath.Join(j.dir, entry.Name()))
+		}
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no %s files found in %s", jsonnetExtension, j.dir)
+	}
+
+	slices.Sort(files)
+	return files, nil
+}
@@ -0,0 +1,43 @@
+package template
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Jsonnet template", func() {
+
+	svc := rendererService()
+
+	Context("Render jsonnet template", func() {
+		It("should successfully render the jsonnet template", func() {
+			dir := filepath.Join("..", "..", "..", "test", "jsonnet")
+			resp, err := NewJsonnet(dir).Render(svc, mapper)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(resp)).To(Equal(2))
+			Expect(resp[0].GetKind()).To(Equal("ConfigMap"))
+			Expect(resp[0].GetName()).To(Equal("nginx-cluster"))
+			Expect(resp[1].GetKind()).To(Equal("Deployment"))
+			Expect(resp[1].GetName()).To(Equal("nginx"))
+			Expect(resp[1].GetLabels()).To(HaveKeyWithValue("app.kubernetes.io/name", "nginx"))
+		})
+
+		It("should reject imports outside of the service directory", func() {
+			root := GinkgoT().TempDir()
+			dir := filepath.Join(root, "services", "app")
+			Expect(os.MkdirAll(dir, 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(root, "x"), []byte("secret"), 0644)).To(Succeed())
+
+			for _, path := range []string{"/etc/passwd", "../../x"} {
+				main := "{ apiVersion: 'v1', kind: 'ConfigMap', metadata: { name: 'leak' }, data: { value: importstr '" + path + "' } }"
+				Expect(os.WriteFile(filepath.Join(dir, JsonnetMainFile), []byte(main), 0644)).To(Succeed())
+
+				_, err := NewJsonnet(dir).Render(svc, mapper)
+				Expect(err).To(MatchError(ContainSubstring("outside of the service directory")), path)
+			}
+		})
+	})
+})
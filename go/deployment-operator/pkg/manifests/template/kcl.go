@@ -0,0 +1,121 @@
+package template
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+
+	console "github.com/pluralsh/console/go/client"
+	"github.com/samber/lo"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const (
+	KclBinary = "kcl"
+
+	kclTimeout = 2 * time.Minute
+)
+
+type kcl struct {
+	dir string
+}
+
+// NewKcl creates a renderer that runs the KCL program in dir with the kcl CLI, which
+// has to be available on the PATH. Service bindings are passed as top level arguments
+// through a settings file and can be read with option("configuration"), option("cluster"), etc.
+func NewKcl(dir string) Template {
+	return &kcl{dir}
+}
+
+func (k *kcl) Render(svc *console.ServiceDeploymentForAgent, mapper meta.RESTMapper) ([]unstructured.Unstructured, error) {
+	binary, err := exec.LookPath(KclBinary)
+	if err != nil {
+		return nil, fmt.Errorf("%s binary not found, it is required by the kcl renderer: %w", KclBinary, err)
+	}
+
+	settings, err := k.settings(svc)
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(settings)
+
+	ctx, cancel := context.WithTimeout(context.Background(), kclTimeout)
+	defer cancel()
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	cmd := exec.CommandContext(ctx, binary, "run", "--format", "yaml", "-Y", settings)
+	cmd.Dir = k.dir
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to run kcl: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	values := make([]any, 0)
+	decoder := yaml.NewYAMLOrJSONDecoder(stdout, 4096)
+	for {
+		var value any
+		if err := decoder.Decode(&value); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("failed to parse kcl output: %w", err)
+		}
+		values = append(values, value)
+	}
+
+	return renderValue(values, mapper, "kcl", svc.Namespace)
+}
+
+// settings writes the service bindings to a temporary KCL settings file and returns its path.
+// Bindings can contain secrets, so they are not passed on the command line where other
+// processes could read them.
+func (k *kcl) settings(svc *console.ServiceDeploymentForAgent) (string, error) {
+	b := bindings(svc)
+	keys := lo.Keys(b)
+	slices.Sort(keys)
+
+	options := make([]kclOption, 0, len(keys))
+	for _, key := range keys {
+		options = append(options, kclOption{Key: key, Value: b[key]})
+	}
+
+	data, err := json.Marshal(kclSettings{Options: options})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal kcl bindings: %w", err)
+	}
+
+	// os.CreateTemp creates the file readable by the owner only.
+	file, err := os.CreateTemp("", "kcl-settings-*.yaml")
+	if err != nil {
+		return "", fmt.Errorf("failed to create kcl settings file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(data); err != nil {
+		_ = os.Remove(file.Name())
+		return "", fmt.Errorf("failed to write kcl settings file: %w", err)
+	}
+
+	return file.Name(), nil
+}
+
+// kclSettings is the KCL settings file format, JSON being a subset of the YAML it expects.
+type kclSettings struct {
+	Options []kclOption `json:"kcl_options"`
+}
+
+type kclOption struct {
+	Key   string `json:"key"`
+	Value any    `json:"value"`
+}
@@ -0,0 +1,69 @@
+package template
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+
+	"github.com/samber/lo"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// renderValue converts an evaluated Jsonnet, CUE or KCL value into manifests. The value
+// can be a single object, a list of objects or an arbitrarily nested tree of objects
+// and lists (tanka style). Any map with both apiVersion and kind set is considered a
+// manifest, every other map and list is traversed further and scalars are ignored.
+func renderValue(value any, mapper meta.RESTMapper, name, namespace string) ([]unstructured.Unstructured, error) {
+	objects := collectObjects(value, nil)
+
+	buffer := &bytes.Buffer{}
+	for _, object := range objects {
+		data, err := json.Marshal(object)
+		if err != nil {
+			return nil, err
+		}
+
+		buffer.WriteString("---\n")
+		buffer.Write(data)
+		buffer.WriteString("\n")
+	}
+
+	if buffer.Len() == 0 {
+		return []unstructured.Unstructured{}, nil
+	}
+
+	return streamManifests(buffer, mapper, name, namespace)
+}
+
+func collectObjects(value any, objects []map[string]any) []map[string]any {
+	switch v := value.(type) {
+	case map[string]any:
+		if isObject(v) {
+			return append(objects, v)
+		}
+
+		// sort keys to keep the output stable between renders
+		keys := lo.Keys(v)
+		slices.Sort(keys)
+		for _, key := range keys {
+			objects = collectObjects(v[key], objects)
+		}
+	case []any:
+		for _, item := range v {
+			objects = collectObjects(item, objects)
+		}
+	}
+
+	return objects
+}
+
+func isObject(value map[string]any) bool {
+	apiVersion, ok := value["apiVersion"].(string)
+	if !ok || len(apiVersion) == 0 {
+		return false
+	}
+
+	kind, ok := value["kind"].(string)
+	return ok && len(kind) > 0
+}
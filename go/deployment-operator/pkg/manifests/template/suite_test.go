@@ -36,6 +36,7 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
+	console "github.com/pluralsh/console/go/client"
 	cache "github.com/pluralsh/console/go/deployment-operator/pkg/cache/discovery"
 )
 
@@ -125,3 +126,21 @@ var _ = AfterSuite(func() {
 		Expect(os.Unsetenv("KUBECONFIG")).To(Succeed())
 	}
 })
+
+// rendererService is the service the test/{jsonnet,cue,kcl} programs are rendered with,
+// each of them produces an nginx deployment and a config map named after the cluster.
+func rendererService() *console.ServiceDeploymentForAgent {
+	return &console.ServiceDeploymentForAgent{
+		Namespace: "default",
+		Configuration: []*console.ServiceDeploymentForAgent_Configuration{
+			{
+				Name:  "name",
+				Value: "nginx",
+			},
+		},
+		Cluster: &console.ServiceDeploymentForAgent_Cluster{
+			ID:   "123",
+			Name: "test",
+		},
+	}
+}
@@ -19,6 +19,9 @@ const (
 	RendererHelm      Renderer = "helm"
 	RendererRaw       Renderer = "raw"
 	RendererKustomize Renderer = "kustomize"
+	RendererJsonnet   Renderer = "jsonnet"
+	RendererCue       Renderer = "cue"
+	RendererKcl       Renderer = "kcl"
 
 	ChartFileName = "Chart.yaml"
 )
@@ -57,6 +60,12 @@ func Render(dir string, svc *console.ServiceDeploymentForAgent, mapper meta.REST
 			manifests, err = NewHelm(rendererPath).Render(&svcCopy, mapper)
 		case console.RendererTypeKustomize:
 			manifests, err = NewKustomize(rendererPath).Render(svc, mapper)
+		case console.RendererTypeJsonnet:
+			manifests, err = NewJsonnet(rendererPath).Render(svc, mapper)
+		case console.RendererTypeCue:
+			manifests, err = NewCue(rendererPath).Render(svc, mapper)
+		case console.RendererTypeKcl:
+			manifests, err = NewKcl(rendererPath).Render(svc, mapper)
 		default:
 			return nil, fmt.Errorf("unknown renderer type: %s", renderer.Type)
 		}
@@ -0,0 +1,552 @@
+package scm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// giteaPageSize is the page size requested from list endpoints. Gitea caps it
+// at the instance's MAX_RESPONSE_ITEMS setting (50 by default).
+const giteaPageSize = 50
+
+// Gitea Actions reports jobs as commit statuses whose target URL points at the
+// job page, i.e. https://{host}/{owner}/{repo}/actions/runs/{run}/jobs/{job}.
+var giteaActionsJobPattern = regexp.MustCompile(`/actions/runs/(\d+)/jobs/(\d+)`)
+
+// giteaParsedURL holds the fields extracted from a Gitea or Forgejo PR URL.
+type giteaParsedURL struct {
+	baseURL string // instance root including any sub-path, e.g. https://example.com/gitea
+	owner   string
+	repo    string
+	index   int64
+}
+
+// apiURL returns the REST API URL for the given repository scoped path.
+func (p giteaParsedURL) apiURL(format string, args ...any) string {
+	return fmt.Sprintf("%s/api/v1/repos/%s/%s", p.baseURL, url.PathEscape(p.owner), url.PathEscape(p.repo)) + fmt.Sprintf(format, args...)
+}
+
+// parseGiteaPRURL extracts the instance URL, owner, repo and PR index from a
+// Gitea or Forgejo pull request URL. Instances served from a sub-path are supported:
+//
+//	https://gitea.com/owner/repo/pulls/42
+//	https://codeberg.org/owner/repo/pulls/42/files
+//	https://git.internal.example.com/gitea/owner/repo/pulls/42
+func parseGiteaPRURL(prURL string) (giteaParsedURL, error) {
+	u, err := url.Parse(prURL)
+	if err != nil || u.Host == "" {
+		return giteaParsedURL{}, fmt.Errorf("cannot parse Gitea PR URL: %s", prURL)
+	}
+	parts, err := urlPathParts(prURL)
+	if err != nil {
+		return giteaParsedURL{}, fmt.Errorf("cannot parse Gitea PR URL: %s", prURL)
+	}
+	for i := 2; i+1 < len(parts); i++ {
+		if parts[i] != "pulls" {
+			continue
+		}
+		index, err := strconv.ParseInt(parts[i+1], 10, 64)
+		if err != nil {
+			return giteaParsedURL{}, fmt.Errorf("invalid PR index in URL %s: %w", prURL, err)
+		}
+		base := url.URL{Scheme: u.Scheme, Host: u.Host, Path: strings.Join(parts[:i-2], "/")}
+		if base.Path != "" {
+			base.Path = "/" + base.Path
+		}
+		return giteaParsedURL{
+			baseURL: strings.TrimRight(base.String(), "/"),
+			owner:   parts[i-2],
+			repo:    strings.TrimSuffix(parts[i-1], ".git"),
+			index:   index,
+		}, nil
+	}
+	return giteaParsedURL{}, fmt.Errorf("cannot parse Gitea PR URL: %s", prURL)
+}
+
+// giteaClient implements the SCM Client interface for Gitea and Forgejo, which
+// share the same v1 REST API. The token is sent as an access token unless it is
+// in "username:password" format, in which case Basic auth is used.
+type giteaClient struct {
+	token      string
+	httpClient *http.Client // defaults to http.DefaultClient; tests override with httptest
+}
+
+func newGiteaClient(token string) *giteaClient {
+	return &giteaClient{token: token}
+}
+
+type giteaUser struct {
+	Login string `json:"login"`
+}
+
+type giteaPR struct {
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+	State  string `json:"state"` // open, closed
+	Merged bool   `json:"merged"`
+	Head   struct {
+		Ref string `json:"ref"`
+		Sha string `json:"sha"`
+	} `json:"head"`
+}
+
+type giteaComment struct {
+	ID        int64     `json:"id"`
+	User      giteaUser `json:"user"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type giteaReview struct {
+	ID            int64     `json:"id"`
+	User          giteaUser `json:"user"`
+	Body          string    `json:"body"`
+	State         string    `json:"state"`
+	CommentsCount int       `json:"comments_count"`
+	SubmittedAt   time.Time `json:"submitted_at"`
+}
+
+type giteaReviewComment struct {
+	ID               int64     `json:"id"`
+	User             giteaUser `json:"user"`
+	Body             string    `json:"body"`
+	Path             string    `json:"path"`
+	Position         int       `json:"position"`
+	OriginalPosition int       `json:"original_position"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+type giteaCommitStatus struct {
+	ID        int64  `json:"id"`
+	Status    string `json:"status"` // pending, success, error, failure, warning
+	Context   string `json:"context"`
+	TargetURL string `json:"target_url"`
+}
+
+type giteaActionsJob struct {
+	ID int64 `json:"id"`
+}
+
+type giteaActionsRun struct {
+	ID        int64 `json:"id"`
+	RunNumber int64 `json:"run_number"`
+}
+
+type giteaReaction struct {
+	Content string `json:"content"`
+}
+
+func (c *giteaClient) GetPRDetails(ctx context.Context, prURL string) (*PRDetails, error) {
+	parsed, err := parseGiteaPRURL(prURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var pr giteaPR
+	if err := c.do(ctx, http.MethodGet, parsed.apiURL("/pulls/%d", parsed.index), nil, &pr); err != nil {
+		return nil, fmt.Errorf("get PR: %w", err)
+	}
+
+	comments, err := c.allComments(ctx, parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	checks, err := c.ciChecks(ctx, parsed, pr.Head.Sha)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PRDetails{
+		Title:    pr.Title,
+		Body:     pr.Body,
+		HeadRef:  pr.Head.Ref,
+		State:    giteaState(pr),
+		Comments: comments,
+		CIChecks: checks,
+	}, nil
+}
+
+func (c *giteaClient) GetPRSummary(ctx context.Context, prURL string) (*PRDetails, error) {
+	parsed, err := parseGiteaPRURL(prURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var pr giteaPR
+	if err := c.do(ctx, http.MethodGet, parsed.apiURL("/pulls/%d", parsed.index), nil, &pr); err != nil {
+		return nil, fmt.Errorf("get PR: %w", err)
+	}
+
+	return &PRDetails{
+		Title:   pr.Title,
+		Body:    pr.Body,
+		HeadRef: pr.Head.Ref,
+		State:   giteaState(pr),
+	}, nil
+}
+
+func (c *giteaClient) allComments(ctx context.Context, parsed giteaParsedURL) ([]PRComment, error) {
+	var all []PRComment
+
+	comments, err := giteaList[giteaComment](ctx, c, parsed.apiURL("/issues/%d/comments", parsed.index))
+	if err != nil {
+		return nil, fmt.Errorf("list issue comments: %w", err)
+	}
+	for _, cm := range comments {
+		all = append(all, PRComment{
+			ID:        strconv.FormatInt(cm.ID, 10),
+			Type:      PRCommentTypeIssue,
+			Author:    cm.User.Login,
+			Body:      cm.Body,
+			CreatedAt: cm.CreatedAt,
+		})
+	}
+
+	// Inline comments are only exposed per review, so reviews are listed first
+	// and their bodies are kept as review summaries.
+	reviews, err := giteaList[giteaReview](ctx, c, parsed.apiURL("/pulls/%d/reviews", parsed.index))
+	if err != nil {
+		return nil, fmt.Errorf("list reviews: %w", err)
+	}
+	for _, r := range reviews {
+		if body := strings.TrimSpace(r.Body); body != "" {
+			all = append(all, PRComment{
+				ID:        strconv.FormatInt(r.ID, 10),
+				Type:      PRCommentTypeReviewSummary,
+				Author:    r.User.Login,
+				Body:      body,
+				CreatedAt: r.SubmittedAt,
+			})
+		}
+		if r.CommentsCount == 0 {
+			continue
+		}
+
+		var reviewComments []giteaReviewComment
+		if err := c.do(ctx, http.MethodGet, parsed.apiURL("/pulls/%d/reviews/%d/comments", parsed.index, r.ID), nil, &reviewComments); err != nil {
+			return nil, fmt.Errorf("list review comments for review %d: %w", r.ID, err)
+		}
+		for _, rc := range reviewComments {
+			line := rc.Position
+			if line == 0 {
+				// Position is zero for outdated comments; the original
+				// position still identifies the reviewed code.
+				line = rc.OriginalPosition
+			}
+			all = append(all, PRComment{
+				ID:        strconv.FormatInt(rc.ID, 10),
+				Type:      PRCommentTypeReview,
+				Author:    rc.User.Login,
+				Body:      rc.Body,
+				CreatedAt: rc.CreatedAt,
+				FilePath:  rc.Path,
+				Line:      line,
+			})
+		}
+	}
+
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].CreatedAt.Before(all[j].CreatedAt)
+	})
+
+	return all, nil
+}
+
+func (c *giteaClient) ciChecks(ctx context.Context, parsed giteaParsedURL, sha string) ([]CICheck, error) {
+	statuses, err := c.latestStatuses(ctx, parsed, sha)
+	if err != nil {
+		return nil, err
+	}
+
+	all := make([]CICheck, 0, len(statuses))
+	for _, s := range statuses {
+		status, conclusion := giteaStatusToCheck(s.Status)
+		all = append(all, CICheck{
+			Name:       s.Context,
+			Status:     status,
+			Conclusion: conclusion,
+			CheckRunID: s.ID,
+		})
+	}
+	return all, nil
+}
+
+// latestStatuses returns the most recent status for each context of a commit.
+// Gitea keeps the whole status history, newest first.
+func (c *giteaClient) latestStatuses(ctx context.Context, parsed giteaParsedURL, sha string) ([]giteaCommitStatus, error) {
+	statuses, err := giteaList[giteaCommitStatus](ctx, c, parsed.apiURL("/commits/%s/statuses", url.PathEscape(sha)))
+	if err != nil {
+		return nil, fmt.Errorf("list commit statuses: %w", err)
+	}
+
+	seen := make(map[string]struct{}, len(statuses))
+	latest := make([]giteaCommitStatus, 0, len(statuses))
+	for _, s := range statuses {
+		if _, ok := seen[s.Context]; ok {
+			continue
+		}
+		seen[s.Context] = struct{}{}
+		latest = append(latest, s)
+	}
+	return latest, nil
+}
+
+// GetCILogs fetches the log of a Gitea Actions job (checkRunID = commit status ID).
+// Statuses reported by external CI systems, and instances without the Actions jobs
+// API, fall back to returning the status target URL.
+func (c *giteaClient) GetCILogs(ctx context.Context, prURL string, checkRunID int64) (string, error) {
+	parsed, err := parseGiteaPRURL(prURL)
+	if err != nil {
+		return "", err
+	}
+
+	var pr giteaPR
+	if err := c.do(ctx, http.MethodGet, parsed.apiURL("/pulls/%d", parsed.index), nil, &pr); err != nil {
+		return "", fmt.Errorf("get PR: %w", err)
+	}
+
+	statuses, err := c.latestStatuses(ctx, parsed, pr.Head.Sha)
+	if err != nil {
+		return "", err
+	}
+
+	for _, s := range statuses {
+		if s.ID != checkRunID {
+			continue
+		}
+		if logs, err := c.actionsJobLogs(ctx, parsed, pr.Head.Sha, s.TargetURL); err == nil && logs != "" {
+			return logs, nil
+		}
+		if s.TargetURL != "" {
+			return fmt.Sprintf("Logs available at: %s", s.TargetURL), nil
+		}
+		break
+	}
+	return "", fmt.Errorf("no logs found for check run %d", checkRunID)
+}
+
+// actionsJobLogs resolves a Gitea Actions job page URL to the job ID and downloads its log.
+// The job page URL contains the run index within the repository and the job index
+// within the run, neither of which is an ID the API accepts.
+func (c *giteaClient) actionsJobLogs(ctx context.Context, parsed giteaParsedURL, sha, targetURL string) (string, error) {
+	m := giteaActionsJobPattern.FindStringSubmatch(targetURL)
+	if m == nil {
+		return "", fmt.Errorf("not a Gitea Actions job URL: %s", targetURL)
+	}
+	runIndex, err := strconv.ParseInt(m[1], 10, 64)
+	if err != nil {
+		return "", err
+	}
+	jobIndex, err := strconv.Atoi(m[2])
+	if err != nil {
+		return "", err
+	}
+
+	runID, err := c.actionsRunID(ctx, parsed, sha, runIndex)
+	if err != nil {
+		return "", err
+	}
+
+	var jobs struct {
+		Jobs []giteaActionsJob `json:"jobs"`
+	}
+	if err := c.do(ctx, http.MethodGet, parsed.apiURL("/actions/runs/%d/jobs", runID), nil, &jobs); err != nil {
+		return "", fmt.Errorf("list run jobs: %w", err)
+	}
+	if jobIndex < 0 || jobIndex >= len(jobs.Jobs) {
+		return "", fmt.Errorf("job %d not found in run %d", jobIndex, runIndex)
+	}
+
+	raw, err := c.raw(ctx, parsed.apiURL("/actions/jobs/%d/logs", jobs.Jobs[jobIndex].ID))
+	if err != nil {
+		return "", fmt.Errorf("get job logs: %w", err)
+	}
+	return raw, nil
+}
+
+// actionsRunID finds the ID of the run with the given index among the runs of a commit.
+func (c *giteaClient) actionsRunID(ctx context.Context, parsed giteaParsedURL, sha string, runIndex int64) (int64, error) {
+	for page := 1; ; page++ {
+		var runs struct {
+			WorkflowRuns []giteaActionsRun `json:"workflow_runs"`
+		}
+		u := parsed.apiURL("/actions/runs?head_sha=%s&page=%d&limit=%d", url.QueryEscape(sha), page, giteaPageSize)
+		if err := c.do(ctx, http.MethodGet, u, nil, &runs); err != nil {
+			return 0, fmt.Errorf("list runs: %w", err)
+		}
+		for _, run := range runs.WorkflowRuns {
+			if run.RunNumber == runIndex {
+				return run.ID, nil
+			}
+		}
+		if len(runs.WorkflowRuns) < giteaPageSize {
+			return 0, fmt.Errorf("run %d not found", runIndex)
+		}
+	}
+}
+
+// ReactToComment adds a reaction to an issue or inline review comment. Both are
+// stored as issue comments in Gitea and share the same reactions endpoint.
+// working  → adds "eyes".
+// complete → removes "eyes" if present, then adds "+1".
+func (c *giteaClient) ReactToComment(ctx context.Context, prURL string, reactableID string, state CommentReactState) error {
+	parsed, err := parseGiteaPRURL(prURL)
+	if err != nil {
+		return err
+	}
+
+	parts := strings.SplitN(reactableID, ":", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid reactableID %q: expected format type:numericID", reactableID)
+	}
+	id, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid comment ID %q: %w", parts[1], err)
+	}
+
+	switch PRCommentType(parts[0]) {
+	case PRCommentTypeIssue, PRCommentTypeReview:
+	case PRCommentTypeReviewSummary:
+		return fmt.Errorf("review summaries are not reactable through Gitea comment reactions")
+	default:
+		return fmt.Errorf("unknown comment type %q in reactableID", parts[0])
+	}
+
+	reactionsURL := parsed.apiURL("/issues/comments/%d/reactions", id)
+	if state == CommentReactStateComplete {
+		// Best-effort removal of the "eyes" reaction we previously added.
+		_ = c.do(ctx, http.MethodDelete, reactionsURL, giteaReaction{Content: "eyes"}, nil)
+	}
+
+	content := "eyes"
+	if state == CommentReactStateComplete {
+		content = "+1"
+	}
+	return c.do(ctx, http.MethodPost, reactionsURL, giteaReaction{Content: content}, nil)
+}
+
+// giteaList fetches all pages of a list endpoint. Gitea paginates with
+// ?page=N&limit=M and returns a short page once the end is reached.
+func giteaList[T any](ctx context.Context, c *giteaClient, u string) ([]T, error) {
+	sep := "?"
+	if strings.Contains(u, "?") {
+		sep = "&"
+	}
+
+	var all []T
+	for page := 1; ; page++ {
+		var batch []T
+		if err := c.do(ctx, http.MethodGet, fmt.Sprintf("%s%spage=%d&limit=%d", u, sep, page, giteaPageSize), nil, &batch); err != nil {
+			return nil, err
+		}
+		all = append(all, batch...)
+		if len(batch) < giteaPageSize {
+			return all, nil
+		}
+	}
+}
+
+// do performs an authenticated JSON request. A nil out skips decoding the response.
+func (c *giteaClient) do(ctx context.Context, method, u string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	resp, err := c.request(ctx, method, u, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// raw performs an authenticated GET and returns the response body capped at 512 KB.
+func (c *giteaClient) raw(ctx context.Context, u string) (string, error) {
+	resp, err := c.request(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 512*1024))
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
+
+func (c *giteaClient) request(ctx context.Context, method, u string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	if username, password, ok := strings.Cut(c.token, ":"); ok {
+		req.SetBasicAuth(username, password)
+	} else {
+		req.Header.Set("Authorization", "token "+c.token)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	httpClient := c.httpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, data)
+	}
+	return resp, nil
+}
+
+func giteaState(pr giteaPR) PRState {
+	switch {
+	case pr.Merged:
+		return PRStateMerged
+	case pr.State == "closed":
+		return PRStateClosed
+	default:
+		return PRStateOpen
+	}
+}
+
+// giteaStatusToCheck maps pending/success/error/failure/warning → (status, conclusion).
+func giteaStatusToCheck(state string) (status, conclusion string) {
+	switch state {
+	case "success":
+		return CICheckStatusCompleted, CICheckConclusionSuccess
+	case "failure", "error":
+		return CICheckStatusCompleted, CICheckConclusionFailure
+	case "warning":
+		return CICheckStatusCompleted, CICheckConclusionNeutral
+	default: // pending
+		return CICheckStatusInProgress, ""
+	}
+}
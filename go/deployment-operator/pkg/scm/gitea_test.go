@@ -0,0 +1,216 @@
+package scm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testGiteaServer(t *testing.T, handler http.HandlerFunc) (*giteaClient, string) {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return &giteaClient{token: "token", httpClient: server.Client()}, server.URL
+}
+
+func TestGiteaGetPRSummaryPollability(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		body     string
+		want     PRState
+		pollable bool
+	}{
+		{
+			name:     "open",
+			body:     `{"title":"Fix","body":"n","state":"open","merged":false,"head":{"ref":"feat/x","sha":"abc"}}`,
+			want:     PRStateOpen,
+			pollable: true,
+		},
+		{
+			name:     "closed",
+			body:     `{"title":"Fix","body":"n","state":"closed","merged":false,"head":{"ref":"feat/x","sha":"abc"}}`,
+			want:     PRStateClosed,
+			pollable: false,
+		},
+		{
+			name:     "merged",
+			body:     `{"title":"Fix","body":"n","state":"closed","merged":true,"head":{"ref":"feat/x","sha":"abc"}}`,
+			want:     PRStateMerged,
+			pollable: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client, serverURL := testGiteaServer(t, func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/api/v1/repos/acme/app/pulls/3", r.URL.Path)
+				require.Equal(t, "token token", r.Header.Get("Authorization"))
+				_, _ = fmt.Fprint(w, tt.body)
+			})
+
+			details, err := client.GetPRSummary(context.Background(), serverURL+"/acme/app/pulls/3")
+			require.NoError(t, err)
+			require.Equal(t, "Fix", details.Title)
+			require.Equal(t, "feat/x", details.HeadRef)
+			require.Equal(t, tt.want, details.State)
+			require.Equal(t, tt.pollable, details.Pollable())
+			require.Empty(t, details.Comments)
+			require.Empty(t, details.CIChecks)
+		})
+	}
+}
+
+func TestGiteaGetPRDetailsIncludesCommentsAndChecks(t *testing.T) {
+	t.Parallel()
+
+	client, serverURL := testGiteaServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/forgejo/api/v1/repos/acme/app/pulls/3":
+			_, _ = fmt.Fprint(w, `{"title":"Fix","body":"n","state":"open","merged":false,"head":{"ref":"feat/x","sha":"abc123"}}`)
+		case "/forgejo/api/v1/repos/acme/app/issues/3/comments":
+			_, _ = fmt.Fprint(w, `[{"id":11,"user":{"login":"human"},"body":"please fix","created_at":"2026-01-01T00:00:00Z"}]`)
+		case "/forgejo/api/v1/repos/acme/app/pulls/3/reviews":
+			_, _ = fmt.Fprint(w, `[
+				{"id":5,"user":{"login":"bot-reviewer"},"body":"summary review feedback","state":"COMMENT","comments_count":1,"submitted_at":"2026-01-01T00:02:00Z"},
+				{"id":6,"user":{"login":"approver"},"body":"","state":"APPROVED","comments_count":0,"submitted_at":"2026-01-01T00:03:00Z"}
+			]`)
+		case "/forgejo/api/v1/repos/acme/app/pulls/3/reviews/5/comments":
+			_, _ = fmt.Fprint(w, `[{"id":22,"user":{"login":"bot-reviewer"},"body":"inline review comment","path":"main.go","position":0,"original_position":14,"created_at":"2026-01-01T00:01:00Z"}]`)
+		case "/forgejo/api/v1/repos/acme/app/commits/abc123/statuses":
+			_, _ = fmt.Fprint(w, `[
+				{"id":99,"status":"failure","context":"ci / test (pull_request)","target_url":"https://example.com/acme/app/actions/runs/7/jobs/0"},
+				{"id":98,"status":"pending","context":"ci / test (pull_request)"},
+				{"id":97,"status":"success","context":"lint"}
+			]`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	details, err := client.GetPRDetails(context.Background(), serverURL+"/forgejo/acme/app/pulls/3")
+	require.NoError(t, err)
+	require.Equal(t, PRStateOpen, details.State)
+	require.Len(t, details.Comments, 3)
+
+	require.Equal(t, PRCommentTypeIssue, details.Comments[0].Type)
+	require.Equal(t, "please fix", details.Comments[0].Body)
+	require.Equal(t, PRCommentTypeReview, details.Comments[1].Type)
+	require.Equal(t, "review:22", details.Comments[1].ReactableID())
+	require.Equal(t, "main.go:14", details.Comments[1].Location())
+	require.Equal(t, PRCommentTypeReviewSummary, details.Comments[2].Type)
+	require.Equal(t, "summary review feedback", details.Comments[2].Body)
+
+	require.Equal(t, []CICheck{
+		{Name: "ci / test (pull_request)", Status: CICheckStatusCompleted, Conclusion: CICheckConclusionFailure, CheckRunID: 99},
+		{Name: "lint", Status: CICheckStatusCompleted, Conclusion: CICheckConclusionSuccess, CheckRunID: 97},
+	}, details.CIChecks)
+}
+
+func TestGiteaGetCILogs(t *testing.T) {
+	t.Parallel()
+
+	client, serverURL := testGiteaServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/repos/acme/app/pulls/3":
+			_, _ = fmt.Fprint(w, `{"state":"open","head":{"ref":"feat/x","sha":"abc123"}}`)
+		case "/api/v1/repos/acme/app/commits/abc123/statuses":
+			_, _ = fmt.Fprint(w, `[
+				{"id":99,"status":"failure","context":"ci / test (pull_request)","target_url":"https://example.com/acme/app/actions/runs/7/jobs/1"},
+				{"id":98,"status":"failure","context":"external","target_url":"https://ci.example.com/builds/1"}
+			]`)
+		case "/api/v1/repos/acme/app/actions/runs":
+			// the job page URL holds the run index 7, the jobs API needs the run ID
+			require.Equal(t, "abc123", r.URL.Query().Get("head_sha"))
+			_, _ = fmt.Fprint(w, `{"workflow_runs":[{"id":1208,"run_number":8},{"id":1207,"run_number":7}],"total_count":2}`)
+		case "/api/v1/repos/acme/app/actions/runs/1207/jobs":
+			_, _ = fmt.Fprint(w, `{"jobs":[{"id":40},{"id":41}]}`)
+		case "/api/v1/repos/acme/app/actions/jobs/41/logs":
+			_, _ = fmt.Fprint(w, "--- FAIL: TestSomething")
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	logs, err := client.GetCILogs(context.Background(), serverURL+"/acme/app/pulls/3", 99)
+	require.NoError(t, err)
+	require.Equal(t, "--- FAIL: TestSomething", logs)
+
+	logs, err = client.GetCILogs(context.Background(), serverURL+"/acme/app/pulls/3", 98)
+	require.NoError(t, err)
+	require.Equal(t, "Logs available at: https://ci.example.com/builds/1", logs)
+
+	_, err = client.GetCILogs(context.Background(), serverURL+"/acme/app/pulls/3", 1)
+	require.Error(t, err)
+}
+
+func TestGiteaReactToComment(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu    sync.Mutex
+		calls []string
+	)
+	client, serverURL := testGiteaServer(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/repos/acme/app/issues/comments/22/reactions", r.URL.Path)
+		var reaction giteaReaction
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&reaction))
+
+		mu.Lock()
+		calls = append(calls, r.Method+" "+reaction.Content)
+		mu.Unlock()
+		_, _ = fmt.Fprint(w, `{}`)
+	})
+
+	prURL := serverURL + "/acme/app/pulls/3"
+	require.NoError(t, client.ReactToComment(context.Background(), prURL, "review:22", CommentReactStateWorking))
+	require.NoError(t, client.ReactToComment(context.Background(), prURL, "review:22", CommentReactStateComplete))
+	require.Error(t, client.ReactToComment(context.Background(), prURL, "review_summary:5", CommentReactStateWorking))
+
+	require.Equal(t, []string{"POST eyes", "DELETE eyes", "POST +1"}, calls)
+}
+
+func TestParseGiteaPRURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		url     string
+		baseURL string
+		owner   string
+		repo    string
+		index   int64
+	}{
+		{"https://gitea.com/acme/app/pulls/42", "https://gitea.com", "acme", "app", 42},
+		{"https://codeberg.org/acme/app.git/pulls/42/files", "https://codeberg.org", "acme", "app", 42},
+		{"https://git.internal.example.com/gitea/acme/app/pulls/7", "https://git.internal.example.com/gitea", "acme", "app", 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			t.Parallel()
+			parsed, err := parseGiteaPRURL(tt.url)
+			require.NoError(t, err)
+			require.Equal(t, giteaParsedURL{baseURL: tt.baseURL, owner: tt.owner, repo: tt.repo, index: tt.index}, parsed)
+		})
+	}
+
+	for _, invalid := range []string{
+		"https://github.com/acme/app/pull/42",
+		"https://gitea.com/acme/app/pulls",
+		"https://gitea.com/pulls/42",
+	} {
+		_, err := parseGiteaPRURL(invalid)
+		require.ErrorContains(t, err, "cannot parse Gitea PR URL", invalid)
+	}
+}
@@ -198,10 +198,12 @@ func (d *dispatchClient) clientFor(prURL string) (Client, error) {
 		return newBitBucketClient(d.token, host), nil
 	case parseOK(func() error { _, _, _, err := parseCloudPRURL(prURL); return err }):
 		return newBitBucketClient(d.token, host), nil
+	case parseOK(func() error { _, err := parseGiteaPRURL(prURL); return err }):
+		return newGiteaClient(d.token), nil
 	case parseOK(func() error { _, _, _, err := parseGitHubPRURL(prURL); return err }):
 		return newGitHubClient(d.token, host), nil
 	default:
-		return nil, fmt.Errorf("unsupported SCM host %q: only GitHub, GitLab, Bitbucket and Azure DevOps are supported", host)
+		return nil, fmt.Errorf("unsupported SCM host %q: only GitHub, GitLab, Bitbucket, Azure DevOps and Gitea/Forgejo are supported", host)
 	}
 }
 
@@ -139,6 +139,8 @@ func TestClientForDispatchesSelfHostedURLs(t *testing.T) {
 		{"https://bitbucket.internal.example.com/projects/PROJ/repos/app/pull-requests/8", "*scm.bitBucketDCClient"},
 		{"https://dev.azure.com/org/project/_git/repo/pullrequest/11", "*scm.azureDevOpsClient"},
 		{"https://contoso.visualstudio.com/project/_git/repo/pullrequest/11", "*scm.azureDevOpsClient"},
+		{"https://gitea.com/acme/app/pulls/3", "*scm.giteaClient"},
+		{"https://codeberg.org/acme/app/pulls/3/files", "*scm.giteaClient"},
 	}
 
 	for _, tt := range tests {
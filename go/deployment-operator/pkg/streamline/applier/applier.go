@@ -2,6 +2,7 @@ package applier
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/pluralsh/console/go/client"
@@ -65,7 +66,10 @@ func (in *Applier) Apply(ctx context.Context,
 	var syncPhase *smcommon.SyncPhase
 	var phase *Phase
 	var hasOnFailPhase bool
+	var interrupted bool
 	serviceErrorList := make([]client.ServiceErrorAttributes, 0)
+	dryRunComponents := make([]client.ComponentAttributes, 0)
+	pruneLast := make([]unstructured.Unstructured, 0)
 	for {
 		if phase, hasOnFailPhase = phases.Next(syncPhase, failed); phase == nil {
 			break
@@ -95,9 +99,13 @@ func (in *Applier) Apply(ctx context.Context,
 		waveStatistics := WaveStatistics{}
 		for i, wave := range waves {
 			processor := NewWaveProcessor(in.client, in.discoveryCache, phase.Name(), wave, opts...)
-			_, serviceErrors := processor.Run(ctx)
+			components, serviceErrors := processor.Run(ctx)
 
 			serviceErrorList = append(serviceErrorList, serviceErrors...)
+			if lo.FromPtr(service.DryRun) {
+				dryRunComponents = append(dryRunComponents, components...)
+			}
+			pruneLast = append(pruneLast, processor.Deferred()...)
 
 			waveStatistics.Add(processor.Statistics())
 
@@ -127,6 +135,7 @@ func (in *Applier) Apply(ctx context.Context,
 		hasPendingResources, hasFailedResources, err := phase.ResourceHealth()
 		if err != nil {
 			klog.V(log.LogLevelDefault).ErrorS(err, "failed to get phase health", "phase", phase.Name())
+			interrupted = true
 			break
 		}
 
@@ -137,6 +146,7 @@ func (in *Applier) Apply(ctx context.Context,
 				Warning: lo.ToPtr(true),
 			})
 			klog.V(log.LogLevelTrace).InfoS("waiting for resources to be ready", "phase", phase.Name())
+			interrupted = true
 			break
 		}
 
@@ -149,13 +159,66 @@ func (in *Applier) Apply(ctx context.Context,
 			})
 			if !hasOnFailPhase {
 				klog.V(log.LogLevelTrace).InfoS("failed to apply phase", "phase", phase.Name())
+				interrupted = true
 				break
 			}
 		}
 	}
 
+	// Resources with the PruneLast sync option are deleted only once all phases succeeded,
+	// otherwise they are kept in the store and deletion is retried during the next sync.
+	succeeded := !interrupted && !lo.ContainsBy(serviceErrorList, func(e client.ServiceErrorAttributes) bool { return !lo.FromPtr(e.Warning) })
+	if len(pruneLast) > 0 && succeeded {
+		processor := NewWaveProcessor(in.client, in.discoveryCache, lo.FromPtr(syncPhase), NewWave(pruneLast, DeleteWave), append(opts, WithWavePruneLast(true))...)
+		components, serviceErrors := processor.Run(ctx)
+		statistics := processor.Statistics()
+
+		serviceErrorList = append(serviceErrorList, serviceErrors...)
+		if lo.FromPtr(service.DryRun) {
+			dryRunComponents = append(dryRunComponents, components...)
+		}
+
+		klog.V(log.LogLevelDefault).InfoS(
+			"prune last result",
+			"service", service.Name,
+			"id", service.ID,
+			"deleted", statistics.Deleted(),
+			"dryRun", lo.FromPtr(service.DryRun),
+		)
+	}
+
 	attrs, err := in.store.GetComponentAttributes(service.ID, false)
-	return attrs, serviceErrorList, err
+	return mergeDryRunComponents(attrs, dryRunComponents), serviceErrorList, err
+}
+
+// mergeDryRunComponents overrides components from the store with the dry run content and diff
+// calculated by the wave processors. Components that do not exist yet are appended.
+func mergeDryRunComponents(attrs, dryRunComponents []client.ComponentAttributes) []client.ComponentAttributes {
+	if len(dryRunComponents) == 0 {
+		return attrs
+	}
+
+	key := func(c client.ComponentAttributes) string {
+		return fmt.Sprintf("%s/%s/%s/%s", c.Group, c.Kind, c.Namespace, c.Name)
+	}
+
+	indexes := make(map[string]int, len(attrs))
+	for i, attr := range attrs {
+		indexes[key(attr)] = i
+	}
+
+	for _, component := range dryRunComponents {
+		i, ok := indexes[key(component)]
+		if !ok {
+			attrs = append(attrs, component)
+			continue
+		}
+
+		attrs[i].Synced = component.Synced
+		attrs[i].Content = component.Content
+	}
+
+	return attrs
 }
 
 func (in *Applier) Destroy(ctx context.Context, serviceID string) ([]client.ComponentAttributes, error) {
@@ -178,7 +241,8 @@ func (in *Applier) Destroy(ctx context.Context, serviceID string) ([]client.Comp
 			return nil, err
 		}
 
-		if live.GetAnnotations() != nil && live.GetAnnotations()[smcommon.LifecycleDeleteAnnotation] == smcommon.PreventDeletion {
+		preventDeletion := live.GetAnnotations() != nil && live.GetAnnotations()[smcommon.LifecycleDeleteAnnotation] == smcommon.PreventDeletion
+		if preventDeletion || smcommon.HasDeleteDisabledSyncOption(*live) {
 			if err := in.store.DeleteComponent(smcommon.NewStoreKeyFromUnstructured(lo.FromPtr(live))); err != nil {
 				klog.V(log.LogLevelDefault).ErrorS(err, "failed to delete component from store", "resource", live.GetUID())
 			}
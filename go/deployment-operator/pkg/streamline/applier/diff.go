@@ -0,0 +1,176 @@
+package applier
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/pluralsh/console/go/deployment-operator/internal/utils"
+	smcommon "github.com/pluralsh/console/go/deployment-operator/pkg/streamline/common"
+)
+
+type DiffOperation string
+
+const (
+	DiffOperationAdd     DiffOperation = "add"
+	DiffOperationRemove  DiffOperation = "remove"
+	DiffOperationReplace DiffOperation = "replace"
+)
+
+// FieldDiff is a single field level change between the live and the desired state.
+type FieldDiff struct {
+	// Path is a JSON pointer to the changed field, i.e. /spec/template/spec/containers/0/image.
+	Path      string        `json:"path"`
+	Operation DiffOperation `json:"op"`
+	Live      any           `json:"live,omitempty"`
+	Desired   any           `json:"desired,omitempty"`
+}
+
+// ResourceDiff is a three-way diff of a resource. Changes are computed between the live
+// object and the state the cluster would end up in after applying the rendered manifest,
+// while the last applied state tracked in the store is used to tell apart changes coming
+// from the manifest from changes made directly on the cluster.
+type ResourceDiff struct {
+	// ManifestChanged is set when the rendered manifest differs from the last applied one.
+	ManifestChanged bool `json:"manifestChanged"`
+
+	// Drifted is set when the live object has been modified since the last apply.
+	Drifted bool `json:"drifted"`
+
+	// Changes is a list of field level changes that applying the manifest would cause.
+	Changes []FieldDiff `json:"changes"`
+}
+
+// diffIgnoredFields are set or managed by the API server and would only add noise to the diff.
+var diffIgnoredFields = [][]string{
+	{"status"},
+	{"metadata", "managedFields"},
+	{"metadata", "resourceVersion"},
+	{"metadata", "generation"},
+	{"metadata", "uid"},
+	{"metadata", "creationTimestamp"},
+	{"metadata", "selfLink"},
+}
+
+// NewResourceDiff calculates the diff between the live object and the desired one, which is
+// either the rendered manifest or the result of a dry run apply. A nil live object means
+// the resource would be created, while a nil desired object means it would be deleted.
+// Changes under any of the ignored JSON pointers are skipped.
+func NewResourceDiff(live, desired *unstructured.Unstructured, lastApplied *smcommon.Component, manifest *unstructured.Unstructured, ignoredPaths []string) *ResourceDiff {
+	result := &ResourceDiff{Changes: make([]FieldDiff, 0)}
+
+	if lastApplied != nil {
+		if manifest != nil {
+			sha, err := utils.HashResource(*manifest)
+			result.ManifestChanged = err != nil || sha != lastApplied.ManifestSHA
+		}
+
+		if live != nil && len(lastApplied.ApplySHA) > 0 {
+			sha, err := utils.HashResource(*live)
+			result.Drifted = err == nil && sha != lastApplied.ApplySHA
+		}
+	} else {
+		result.ManifestChanged = manifest != nil
+	}
+
+	switch {
+	case live == nil && desired == nil:
+		return result
+	case live == nil:
+		result.Changes = append(result.Changes, FieldDiff{Path: "", Operation: DiffOperationAdd, Desired: pruneForDiff(desired)})
+		return result
+	case desired == nil:
+		result.Changes = append(result.Changes, FieldDiff{Path: "", Operation: DiffOperationRemove, Live: pruneForDiff(live)})
+		return result
+	}
+
+	changes := diffValues("", pruneForDiff(live), pruneForDiff(desired), nil)
+	result.Changes = lo.Filter(changes, func(change FieldDiff, _ int) bool {
+		return !isIgnoredPath(change.Path, ignoredPaths)
+	})
+
+	return result
+}
+
+// JSON returns the diff serialized to JSON, which is the format it is uploaded in.
+func (in *ResourceDiff) JSON() (string, error) {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal resource diff: %w", err)
+	}
+
+	return string(data), nil
+}
+
+func pruneForDiff(obj *unstructured.Unstructured) map[string]any {
+	result := obj.DeepCopy().Object
+	for _, field := range diffIgnoredFields {
+		unstructured.RemoveNestedField(result, field...)
+	}
+
+	return result
+}
+
+func diffValues(path string, live, desired any, changes []FieldDiff) []FieldDiff {
+	liveMap, liveIsMap := live.(map[string]any)
+	desiredMap, desiredIsMap := desired.(map[string]any)
+	if liveIsMap && desiredIsMap {
+		keys := lo.Union(lo.Keys(liveMap), lo.Keys(desiredMap))
+		slices.Sort(keys)
+		for _, key := range keys {
+			childPath := path + "/" + escapeJSONPointer(key)
+			liveValue, inLive := liveMap[key]
+			desiredValue, inDesired := desiredMap[key]
+			switch {
+			case !inLive:
+				changes = append(changes, FieldDiff{Path: childPath, Operation: DiffOperationAdd, Desired: desiredValue})
+			case !inDesired:
+				changes = append(changes, FieldDiff{Path: childPath, Operation: DiffOperationRemove, Live: liveValue})
+			default:
+				changes = diffValues(childPath, liveValue, desiredValue, changes)
+			}
+		}
+
+		return changes
+	}
+
+	liveList, liveIsList := live.([]any)
+	desiredList, desiredIsList := desired.([]any)
+	if liveIsList && desiredIsList {
+		for i := 0; i < max(len(liveList), len(desiredList)); i++ {
+			childPath := fmt.Sprintf("%s/%d", path, i)
+			switch {
+			case i >= len(liveList):
+				changes = append(changes, FieldDiff{Path: childPath, Operation: DiffOperationAdd, Desired: desiredList[i]})
+			case i >= len(desiredList):
+				changes = append(changes, FieldDiff{Path: childPath, Operation: DiffOperationRemove, Live: liveList[i]})
+			default:
+				changes = diffValues(childPath, liveList[i], desiredList[i], changes)
+			}
+		}
+
+		return changes
+	}
+
+	if !reflect.DeepEqual(live, desired) {
+		changes = append(changes, FieldDiff{Path: path, Operation: DiffOperationReplace, Live: live, Desired: desired})
+	}
+
+	return changes
+}
+
+func isIgnoredPath(path string, ignoredPaths []string) bool {
+	return lo.ContainsBy(ignoredPaths, func(ignored string) bool {
+		ignored = strings.TrimSuffix(ignored, "/")
+		return path == ignored || strings.HasPrefix(path, ignored+"/")
+	})
+}
+
+func escapeJSONPointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
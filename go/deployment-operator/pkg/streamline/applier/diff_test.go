@@ -0,0 +1,94 @@
+package applier
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/pluralsh/console/go/deployment-operator/internal/utils"
+	smcommon "github.com/pluralsh/console/go/deployment-operator/pkg/streamline/common"
+)
+
+func newDiffDeployment(image string, replicas int64, labels map[string]any) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]any{
+			"name":            "nginx",
+			"namespace":       "default",
+			"labels":          labels,
+			"resourceVersion": "1",
+		},
+		"spec": map[string]any{
+			"replicas": replicas,
+			"template": map[string]any{
+				"spec": map[string]any{
+					"containers": []any{
+						map[string]any{"name": "nginx", "image": image},
+					},
+				},
+			},
+		},
+		"status": map[string]any{"readyReplicas": replicas},
+	}}
+}
+
+func TestNewResourceDiff(t *testing.T) {
+	t.Run("should report field level changes", func(t *testing.T) {
+		live := newDiffDeployment("nginx:1.26", 1, map[string]any{"app": "nginx", "a/b": "c"})
+		desired := newDiffDeployment("nginx:1.27", 1, map[string]any{"app": "nginx", "tier": "web"})
+		desired.SetResourceVersion("2")
+
+		diff := NewResourceDiff(live, desired, nil, desired, nil)
+
+		assert.True(t, diff.ManifestChanged)
+		assert.False(t, diff.Drifted)
+		assert.Equal(t, []FieldDiff{
+			{Path: "/metadata/labels/a~1b", Operation: DiffOperationRemove, Live: "c"},
+			{Path: "/metadata/labels/tier", Operation: DiffOperationAdd, Desired: "web"},
+			{Path: "/spec/template/spec/containers/0/image", Operation: DiffOperationReplace, Live: "nginx:1.26", Desired: "nginx:1.27"},
+		}, diff.Changes)
+	})
+
+	t.Run("should skip ignored paths", func(t *testing.T) {
+		live := newDiffDeployment("nginx:1.27", 3, nil)
+		desired := newDiffDeployment("nginx:1.27", 1, nil)
+
+		diff := NewResourceDiff(live, desired, nil, desired, []string{"/spec/replicas"})
+
+		assert.Empty(t, diff.Changes)
+	})
+
+	t.Run("should report creation and deletion", func(t *testing.T) {
+		obj := newDiffDeployment("nginx:1.27", 1, nil)
+
+		created := NewResourceDiff(nil, obj, nil, obj, nil)
+		require.Len(t, created.Changes, 1)
+		assert.Equal(t, DiffOperationAdd, created.Changes[0].Operation)
+
+		deleted := NewResourceDiff(obj, nil, nil, nil, nil)
+		require.Len(t, deleted.Changes, 1)
+		assert.Equal(t, DiffOperationRemove, deleted.Changes[0].Operation)
+		assert.False(t, deleted.ManifestChanged)
+	})
+
+	t.Run("should compare with the last applied state", func(t *testing.T) {
+		manifest := newDiffDeployment("nginx:1.27", 1, nil)
+		live := newDiffDeployment("nginx:1.27", 2, nil)
+
+		manifestSHA, err := utils.HashResource(*manifest)
+		require.NoError(t, err)
+		applySHA, err := utils.HashResource(*manifest)
+		require.NoError(t, err)
+
+		diff := NewResourceDiff(live, manifest, &smcommon.Component{ManifestSHA: manifestSHA, ApplySHA: applySHA}, manifest, nil)
+
+		assert.False(t, diff.ManifestChanged)
+		assert.True(t, diff.Drifted)
+		assert.Equal(t, []FieldDiff{
+			{Path: "/spec/replicas", Operation: DiffOperationReplace, Live: int64(2), Desired: int64(1)},
+		}, diff.Changes)
+	})
+}
@@ -152,6 +152,20 @@ type WaveProcessor struct {
 	// onApplyCallback is a callback function called when a resource is applied
 	onApplyCallback func(resource unstructured.Unstructured)
 
+	// pruneLast determines if resources with the PruneLast sync option should be deleted right away.
+	// It is set for the final wave run after all sync phases, otherwise the deletion is deferred.
+	pruneLast bool
+
+	// deferredMu guards deferred.
+	deferredMu sync.Mutex
+
+	// deferred contains resources with the PruneLast sync option, which deletion was deferred.
+	deferred []unstructured.Unstructured
+
+	// ignoredPaths contains JSON pointers ignored during updates for each resource.
+	// Changes under these paths are excluded from the dry run diff.
+	ignoredPaths map[smcommon.Key][]string
+
 	// svcCache is the discoveryCache used to get the service deployment for an agent.
 	svcCache cache.Store[console.ServiceDeploymentForAgent]
 
@@ -322,6 +336,22 @@ func (in *WaveProcessor) onDelete(ctx context.Context, resource unstructured.Uns
 		return
 	}
 
+	if smcommon.HasPruneDisabledSyncOption(*live) {
+		if err := streamline.GetGlobalStore().DeleteComponent(smcommon.NewStoreKeyFromUnstructured(lo.FromPtr(live))); err != nil {
+			klog.V(log.LogLevelDefault).ErrorS(err, "failed to delete component", "resource", live.GetUID())
+		}
+
+		// skip pruning when disabled by sync options
+		in.waveStatistics.deleted++ // In statistics, count as deleted
+		return
+	}
+
+	if !in.pruneLast && smcommon.HasPruneLastSyncOption(*live) {
+		klog.V(log.LogLevelDebug).InfoS("deferring prune", "resource", live.GetName(), "kind", live.GetKind())
+		in.deferPrune(*live)
+		return
+	}
+
 	c, err := in.clientForResource(*live)
 	if err != nil {
 		in.errorsChan <- console.ServiceErrorAttributes{
@@ -346,7 +376,7 @@ func (in *WaveProcessor) onDelete(ctx context.Context, resource unstructured.Uns
 
 	if in.dryRun {
 		component := common.ToComponentAttributes(live)
-		component = in.withDryRun(ctx, component, lo.FromPtr(live), true)
+		component = in.withDryRun(ctx, component, nil, lo.FromPtr(live), true)
 		in.componentChan <- lo.FromPtr(component)
 
 		return
@@ -369,6 +399,11 @@ func (in *WaveProcessor) onApply(ctx context.Context, resource unstructured.Unst
 
 	c, err := in.clientForResource(resource)
 	if err != nil {
+		if meta.IsNoMatchError(err) && smcommon.HasSkipDryRunOnMissingResourceSyncOption(resource) {
+			in.skipMissingResource(resource, err)
+			return
+		}
+
 		in.errorsChan <- console.ServiceErrorAttributes{
 			Source:  in.phase.String(),
 			Message: fmt.Sprintf("failed to build client for resource %s/%s: %s", resource.GetNamespace(), resource.GetName(), err.Error()),
@@ -376,8 +411,23 @@ func (in *WaveProcessor) onApply(ctx context.Context, resource unstructured.Unst
 		return
 	}
 
+	if smcommon.HasCreateNamespaceSyncOption(resource) {
+		if err := in.ensureNamespace(ctx, resource.GetNamespace()); err != nil {
+			in.errorsChan <- console.ServiceErrorAttributes{
+				Source:  in.phase.String(),
+				Message: fmt.Sprintf("failed to create namespace %s for %s/%s: %s", resource.GetNamespace(), resource.GetKind(), resource.GetName(), err.Error()),
+			}
+			return
+		}
+	}
+
 	appliedResource, err := in.doApply(ctx, c, resource)
 	if err != nil {
+		if apierrors.IsNotFound(err) && smcommon.HasSkipDryRunOnMissingResourceSyncOption(resource) {
+			in.skipMissingResource(resource, err)
+			return
+		}
+
 		if err := streamline.GetGlobalStore().ExpireSHA(resource); err != nil {
 			klog.ErrorS(err, "failed to expire sha", "resource", resource.GetName(), "kind", resource.GetKind())
 		}
@@ -403,7 +453,7 @@ func (in *WaveProcessor) onApply(ctx context.Context, resource unstructured.Unst
 
 	if in.dryRun {
 		component := common.ToComponentAttributes(&resource)
-		component = in.withDryRun(ctx, component, lo.FromPtr(appliedResource), false)
+		component = in.withDryRun(ctx, component, &resource, lo.FromPtr(appliedResource), false)
 		in.componentChan <- lo.FromPtr(component)
 
 		return
@@ -432,14 +482,73 @@ func (in *WaveProcessor) doReplace(ctx context.Context, c dynamic.ResourceInterf
 	existing, err := c.Get(ctx, u.GetName(), metav1.GetOptions{})
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			return c.Create(ctx, &u, metav1.CreateOptions{DryRun: dryRunOptions})
+			return c.Create(ctx, &u, metav1.CreateOptions{DryRun: dryRunOptions, FieldValidation: fieldValidation(u)})
 		}
 
 		return nil, err
 	}
 
 	u.SetResourceVersion(existing.GetResourceVersion()) // Keep the resource version so the API server accepts the PUT.
-	return c.Update(ctx, &u, metav1.UpdateOptions{DryRun: dryRunOptions})
+	return c.Update(ctx, &u, metav1.UpdateOptions{DryRun: dryRunOptions, FieldValidation: fieldValidation(u)})
+}
+
+// doClientSideApply creates the resource if it is missing, otherwise it is updated with a JSON merge patch.
+// Unlike server-side apply it does not track field ownership, fields removed from the manifest are kept.
+func (in *WaveProcessor) doClientSideApply(ctx context.Context, c dynamic.ResourceInterface, u unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	dryRunOptions := lo.Ternary(in.dryRun, []string{metav1.DryRunAll}, []string{})
+
+	if _, err := c.Get(ctx, u.GetName(), metav1.GetOptions{}); err != nil {
+		if apierrors.IsNotFound(err) {
+			return c.Create(ctx, &u, metav1.CreateOptions{
+				FieldManager:    smcommon.ClientFieldManager,
+				DryRun:          dryRunOptions,
+				FieldValidation: fieldValidation(u),
+			})
+		}
+
+		return nil, err
+	}
+
+	data, err := u.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Patch(ctx, u.GetName(), types.MergePatchType, data, metav1.PatchOptions{
+		FieldManager:    smcommon.ClientFieldManager,
+		DryRun:          dryRunOptions,
+		FieldValidation: fieldValidation(u),
+	})
+}
+
+// doServerSideApply applies the resource with server-side apply. When validation is disabled
+// the apply patch is sent directly, as apply options do not support setting field validation.
+func (in *WaveProcessor) doServerSideApply(ctx context.Context, c dynamic.ResourceInterface, u unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	dryRunOptions := lo.Ternary(in.dryRun, []string{metav1.DryRunAll}, []string{})
+
+	if !smcommon.HasValidateDisabledSyncOption(u) {
+		return c.Apply(ctx, u.GetName(), &u, metav1.ApplyOptions{
+			FieldManager: smcommon.ClientFieldManager,
+			Force:        true,
+			DryRun:       dryRunOptions,
+		})
+	}
+
+	data, err := u.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Patch(ctx, u.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{
+		FieldManager:    smcommon.ClientFieldManager,
+		Force:           lo.ToPtr(true),
+		DryRun:          dryRunOptions,
+		FieldValidation: fieldValidation(u),
+	})
+}
+
+func fieldValidation(u unstructured.Unstructured) string {
+	return lo.Ternary(smcommon.HasValidateDisabledSyncOption(u), metav1.FieldValidationIgnore, "")
 }
 
 func (in *WaveProcessor) doApply(ctx context.Context, c dynamic.ResourceInterface, u unstructured.Unstructured) (*unstructured.Unstructured, error) {
@@ -455,11 +564,13 @@ func (in *WaveProcessor) doApply(ctx context.Context, c dynamic.ResourceInterfac
 		return in.forceRecreate(ctx, c, u)
 	}
 
-	appliedResource, err := c.Apply(ctx, u.GetName(), &u, metav1.ApplyOptions{
-		FieldManager: smcommon.ClientFieldManager,
-		Force:        true,
-		DryRun:       lo.Ternary(in.dryRun, []string{metav1.DryRunAll}, []string{}),
-	})
+	var appliedResource *unstructured.Unstructured
+	var err error
+	if smcommon.HasServerSideApplyDisabledSyncOption(u) {
+		appliedResource, err = in.doClientSideApply(ctx, c, u)
+	} else {
+		appliedResource, err = in.doServerSideApply(ctx, c, u)
+	}
 
 	// Return early if no error occurred,
 	// if the service is in dry run mode
@@ -499,6 +610,61 @@ func (in *WaveProcessor) forceRecreate(ctx context.Context, c dynamic.ResourceIn
 	return CreateWithBackoff(ctx, c, u), nil
 }
 
+// ensureNamespace creates the namespace if it does not exist yet.
+func (in *WaveProcessor) ensureNamespace(ctx context.Context, namespace string) error {
+	if len(namespace) == 0 {
+		return nil
+	}
+
+	c := in.client.Resource(schema.GroupVersionResource{Version: "v1", Resource: "namespaces"})
+	if _, err := c.Get(ctx, namespace, metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	ns := unstructured.Unstructured{}
+	ns.SetAPIVersion("v1")
+	ns.SetKind("Namespace")
+	ns.SetName(namespace)
+	_, err := c.Create(ctx, &ns, metav1.CreateOptions{DryRun: lo.Ternary(in.dryRun, []string{metav1.DryRunAll}, []string{})})
+	if apierrors.IsAlreadyExists(err) {
+		return nil
+	}
+
+	return err
+}
+
+// skipMissingResource reports a resource of a kind unknown to the API server as a warning instead of an error.
+// Usually its CRD is applied by the same service, the resource is retried during the next sync.
+func (in *WaveProcessor) skipMissingResource(resource unstructured.Unstructured, err error) {
+	klog.V(log.LogLevelDebug).InfoS("skipping resource of missing kind", "resource", resource.GetName(), "kind", resource.GetKind(), "error", err)
+
+	if err := streamline.GetGlobalStore().ExpireSHA(resource); err != nil {
+		klog.ErrorS(err, "failed to expire sha", "resource", resource.GetName(), "kind", resource.GetKind())
+	}
+
+	in.errorsChan <- console.ServiceErrorAttributes{
+		Source:  in.phase.String(),
+		Message: fmt.Sprintf("skipped %s %s/%s, its kind is not known to the api server yet: %s", resource.GetKind(), resource.GetNamespace(), resource.GetName(), err.Error()),
+		Warning: lo.ToPtr(true),
+	}
+}
+
+func (in *WaveProcessor) deferPrune(resource unstructured.Unstructured) {
+	in.deferredMu.Lock()
+	defer in.deferredMu.Unlock()
+
+	in.deferred = append(in.deferred, resource)
+}
+
+// Deferred returns resources with the PruneLast sync option, which deletion was deferred
+// until all sync phases are completed.
+func (in *WaveProcessor) Deferred() []unstructured.Unstructured {
+	in.deferredMu.Lock()
+	defer in.deferredMu.Unlock()
+
+	return in.deferred
+}
+
 func (in *WaveProcessor) isManaged(entry *smcommon.Component, resource unstructured.Unstructured) bool {
 	if entry == nil {
 		return false
@@ -513,10 +679,14 @@ func (in *WaveProcessor) isManaged(entry *smcommon.Component, resource unstructu
 	return len(entry.ServiceID) > 0 && len(serviceID) > 0 && entry.ServiceID != serviceID
 }
 
-func (in *WaveProcessor) withDryRun(ctx context.Context, component *console.ComponentAttributes, resource unstructured.Unstructured, delete bool) *console.ComponentAttributes {
+// withDryRun fills the component with the live and desired state of the resource and the diff between them.
+// The manifest is the rendered resource, it is nil when the resource is being deleted.
+func (in *WaveProcessor) withDryRun(ctx context.Context, component *console.ComponentAttributes, manifest *unstructured.Unstructured, resource unstructured.Unstructured, delete bool) *console.ComponentAttributes {
 	desiredJSON := utils.UnstructuredAsJSON(&resource)
+	desiredResource := &resource
 	if delete {
 		desiredJSON = "# n/a"
+		desiredResource = nil
 	}
 
 	liveJSON := "# n/a"
@@ -525,10 +695,27 @@ func (in *WaveProcessor) withDryRun(ctx context.Context, component *console.Comp
 		liveJSON = utils.UnstructuredAsJSON(liveResource)
 	}
 
-	component.Synced = liveJSON == desiredJSON
+	lastApplied, err := streamline.GetGlobalStore().GetComponent(resource)
+	if err != nil {
+		klog.V(log.LogLevelDebug).ErrorS(err, "failed to get component from store", "resource", resource.GetName(), "kind", resource.GetKind())
+	}
+
+	var ignoredPaths []string
+	if manifest != nil {
+		ignoredPaths = in.ignoredPaths[smcommon.NewKeyFromUnstructured(*manifest)]
+	}
+
+	diff := NewResourceDiff(liveResource, desiredResource, lastApplied, manifest, ignoredPaths)
+	diffJSON, err := diff.JSON()
+	if err != nil {
+		klog.V(log.LogLevelDebug).ErrorS(err, "failed to build dry run diff", "resource", resource.GetName(), "kind", resource.GetKind())
+	}
+
+	component.Synced = len(diff.Changes) == 0
 	component.Content = &console.ComponentContentAttributes{
 		Desired: &desiredJSON,
 		Live:    &liveJSON,
+		Diff:    lo.EmptyableToPtr(diffJSON),
 	}
 	component.State = common.ToStatus(&resource)
 	component.Version = resource.GroupVersionKind().Version
@@ -601,6 +788,18 @@ func WithWaveOnApply(onApply func(resource unstructured.Unstructured)) WaveProce
 	}
 }
 
+func WithWavePruneLast(pruneLast bool) WaveProcessorOption {
+	return func(w *WaveProcessor) {
+		w.pruneLast = pruneLast
+	}
+}
+
+func WithWaveIgnoredPaths(ignoredPaths map[smcommon.Key][]string) WaveProcessorOption {
+	return func(w *WaveProcessor) {
+		w.ignoredPaths = ignoredPaths
+	}
+}
+
 func WithWaveSvcCache(c cache.Store[console.ServiceDeploymentForAgent]) WaveProcessorOption {
 	return func(w *WaveProcessor) {
 		w.svcCache = c
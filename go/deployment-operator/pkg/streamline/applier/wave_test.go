@@ -5,6 +5,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -404,3 +405,64 @@ func TestDoApplyWithoutReplaceAndWithForceInDryRunDoesNotEscalate(t *testing.T)
 	assert.Nil(t, result)
 	assert.Equal(t, []string{"apply"}, fake.calls)
 }
+
+func TestDoApplyWithServerSideApplyDisabledPatchesExisting(t *testing.T) {
+	ctx := context.Background()
+	wp := &WaveProcessor{}
+	resource := makeResource("ServerSideApply=false")
+
+	var patchType types.PatchType
+	fake := &fakeResourceInterface{
+		getFn: func(context.Context, string, metav1.GetOptions, ...string) (*unstructured.Unstructured, error) {
+			existing := makeResource("")
+			return &existing, nil
+		},
+		patchFn: func(_ context.Context, _ string, pt types.PatchType, _ []byte, _ metav1.PatchOptions, _ ...string) (*unstructured.Unstructured, error) {
+			patchType = pt
+			return &resource, nil
+		},
+	}
+
+	result, err := wp.doApply(ctx, fake, resource)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, []string{"get", "patch"}, fake.calls)
+	assert.Equal(t, types.MergePatchType, patchType)
+}
+
+func TestDoApplyWithServerSideApplyDisabledCreatesWhenMissing(t *testing.T) {
+	ctx := context.Background()
+	wp := &WaveProcessor{}
+	resource := makeResource("ServerSideApply=false")
+
+	fake := &fakeResourceInterface{}
+
+	result, err := wp.doApply(ctx, fake, resource)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, []string{"get", "create"}, fake.calls)
+}
+
+func TestDoApplyWithValidateDisabledIgnoresFieldValidation(t *testing.T) {
+	ctx := context.Background()
+	wp := &WaveProcessor{}
+	resource := makeResource("Validate=false")
+
+	var options metav1.PatchOptions
+	var patchType types.PatchType
+	fake := &fakeResourceInterface{
+		patchFn: func(_ context.Context, _ string, pt types.PatchType, _ []byte, opts metav1.PatchOptions, _ ...string) (*unstructured.Unstructured, error) {
+			patchType = pt
+			options = opts
+			return &resource, nil
+		},
+	}
+
+	result, err := wp.doApply(ctx, fake, resource)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, []string{"patch"}, fake.calls)
+	assert.Equal(t, types.ApplyPatchType, patchType)
+	assert.Equal(t, metav1.FieldValidationIgnore, options.FieldValidation)
+	assert.True(t, lo.FromPtr(options.Force))
+}
@@ -23,6 +23,31 @@ const (
 	// With force=true, a failed replace escalates to delete and recreate.
 	SyncOptionReplace = "replace=true"
 
+	// SyncOptionPruneDisabled prevents a resource from being pruned once it is removed from the manifests.
+	SyncOptionPruneDisabled = "prune=false"
+
+	// SyncOptionPruneLast prunes a resource only after all other resources were synced and are healthy.
+	SyncOptionPruneLast = "prunelast=true"
+
+	// SyncOptionDeleteDisabled keeps a resource on the cluster when its service is deleted.
+	SyncOptionDeleteDisabled = "delete=false"
+
+	// SyncOptionServerSideApply uses server-side apply. It is the default and only exists for Argo CD compatibility.
+	SyncOptionServerSideApply = "serversideapply=true"
+
+	// SyncOptionServerSideApplyDisabled uses a create or merge patch instead of server-side apply.
+	SyncOptionServerSideApplyDisabled = "serversideapply=false"
+
+	// SyncOptionValidateDisabled instructs the API server to ignore unknown and duplicate fields.
+	SyncOptionValidateDisabled = "validate=false"
+
+	// SyncOptionCreateNamespace creates the namespace of a resource if it does not exist yet.
+	SyncOptionCreateNamespace = "createnamespace=true"
+
+	// SyncOptionSkipDryRunOnMissingResource reports resources of kinds unknown to the API server,
+	// usually custom resources whose CRD is applied by the same service, as warnings instead of errors.
+	SyncOptionSkipDryRunOnMissingResource = "skipdryrunonmissingresource=true"
+
 	// ResyncInProgressAnnotation contains an annotation for a resource that was deleted forcefully
 	// and will be recreated in the next reconciling.
 	ResyncInProgressAnnotation = "deployment.plural.sh/resync"
@@ -74,6 +99,34 @@ func HasReplaceSyncOption(u unstructured.Unstructured) bool {
 	return HasSyncOption(u, SyncOptionReplace)
 }
 
+func HasPruneDisabledSyncOption(u unstructured.Unstructured) bool {
+	return HasSyncOption(u, SyncOptionPruneDisabled)
+}
+
+func HasPruneLastSyncOption(u unstructured.Unstructured) bool {
+	return HasSyncOption(u, SyncOptionPruneLast)
+}
+
+func HasDeleteDisabledSyncOption(u unstructured.Unstructured) bool {
+	return HasSyncOption(u, SyncOptionDeleteDisabled)
+}
+
+func HasServerSideApplyDisabledSyncOption(u unstructured.Unstructured) bool {
+	return HasSyncOption(u, SyncOptionServerSideApplyDisabled)
+}
+
+func HasValidateDisabledSyncOption(u unstructured.Unstructured) bool {
+	return HasSyncOption(u, SyncOptionValidateDisabled)
+}
+
+func HasCreateNamespaceSyncOption(u unstructured.Unstructured) bool {
+	return HasSyncOption(u, SyncOptionCreateNamespace)
+}
+
+func HasSkipDryRunOnMissingResourceSyncOption(u unstructured.Unstructured) bool {
+	return HasSyncOption(u, SyncOptionSkipDryRunOnMissingResource)
+}
+
 func HasResyncInProgressAnnotation(u *unstructured.Unstructured) bool {
 	annotations := u.GetAnnotations()
 	if annotations == nil {
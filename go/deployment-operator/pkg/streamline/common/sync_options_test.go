@@ -332,3 +332,27 @@ func TestHasReplaceSyncOption(t *testing.T) {
 		})
 	}
 }
+
+func TestArgoSyncOptions(t *testing.T) {
+	obj := unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"annotations": map[string]interface{}{
+					ArgoSyncOptionsAnnotation: "Prune=false,PruneLast=true,Delete=false,ServerSideApply=false,Validate=false,CreateNamespace=true,SkipDryRunOnMissingResource=true",
+				},
+			},
+		},
+	}
+
+	assert.True(t, HasPruneDisabledSyncOption(obj))
+	assert.True(t, HasPruneLastSyncOption(obj))
+	assert.True(t, HasDeleteDisabledSyncOption(obj))
+	assert.True(t, HasServerSideApplyDisabledSyncOption(obj))
+	assert.True(t, HasValidateDisabledSyncOption(obj))
+	assert.True(t, HasCreateNamespaceSyncOption(obj))
+	assert.True(t, HasSkipDryRunOnMissingResourceSyncOption(obj))
+	assert.False(t, HasForceSyncOption(obj))
+	assert.False(t, HasReplaceSyncOption(obj))
+	assert.False(t, HasSyncOption(obj, SyncOptionServerSideApply))
+	assert.False(t, HasPruneDisabledSyncOption(unstructured.Unstructured{}))
+}
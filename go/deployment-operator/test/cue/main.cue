@@ -0,0 +1,31 @@
+package nginx
+
+plural: {
+	configuration: name: string
+	cluster: name:       string
+	...
+}
+
+_name: plural.configuration.name
+
+objects: {
+	deployment: {
+		apiVersion: "apps/v1"
+		kind:       "Deployment"
+		metadata: name: _name
+		spec: {
+			replicas: 1
+			selector: matchLabels: app: _name
+			template: {
+				metadata: labels: app: _name
+				spec: containers: [{name: _name, image: "nginx:1.27"}]
+			}
+		}
+	}
+	config: {
+		apiVersion: "v1"
+		kind:       "ConfigMap"
+		metadata: name: "\(_name)-cluster"
+		data: cluster: plural.cluster.name
+	}
+}
@@ -0,0 +1,6 @@
+{
+  labels(name):: {
+    'app.kubernetes.io/name': name,
+    'app.kubernetes.io/managed-by': 'plural',
+  },
+}
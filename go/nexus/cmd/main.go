@@ -11,9 +11,11 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/pluralsh/console/go/nexus/cmd/args"
+	"github.com/pluralsh/console/go/nexus/internal/cache"
 	"github.com/pluralsh/console/go/nexus/internal/config"
 	"github.com/pluralsh/console/go/nexus/internal/console"
 	"github.com/pluralsh/console/go/nexus/internal/log"
+	"github.com/pluralsh/console/go/nexus/internal/metering"
 	"github.com/pluralsh/console/go/nexus/internal/server"
 	"github.com/pluralsh/console/go/nexus/internal/version"
 )
@@ -70,8 +72,39 @@ func serve() error {
 		}
 	}()
 
+	// Start per-caller usage metering, flushed to Console on an interval and on shutdown
+	var meter *metering.UsageReporter
+	meterDone := make(chan struct{})
+	meterCtx, stopMeter := context.WithCancel(ctx)
+	defer stopMeter()
+	if cfg.Metering.Enabled {
+		meter = metering.NewUsageReporter(consoleClient, cfg.Metering.FlushInterval, metering.NewPriceTable(cfg.Metering.Prices))
+		go func() {
+			meter.Start(meterCtx)
+			close(meterDone)
+		}()
+		logger.Info("usage metering enabled", zap.Duration("flush_interval", cfg.Metering.FlushInterval))
+	} else {
+		close(meterDone)
+	}
+
+	// Response cache is opt-in, semantic lookups additionally require a similarity threshold
+	var responseCache *cache.Cache
+	if cfg.Cache.Enabled {
+		threshold := 0.0
+		if cfg.Cache.Semantic.Enabled {
+			threshold = cfg.Cache.Semantic.Threshold
+		}
+		responseCache = cache.New(cfg.Cache.TTL, cfg.Cache.MaxEntries, threshold)
+		logger.Info("response cache enabled",
+			zap.Duration("ttl", cfg.Cache.TTL),
+			zap.Int("max_entries", cfg.Cache.MaxEntries),
+			zap.Bool("semantic", cfg.Cache.Semantic.Enabled),
+		)
+	}
+
 	logger.Info("starting HTTP server", zap.String("address", cfg.Server.Address))
-	srv := server.New(&cfg.Server, consoleClient)
+	srv := server.New(&cfg.Server, consoleClient, meter, responseCache)
 	readyChan, err := srv.Start(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to start server: %w", err)
@@ -84,6 +117,7 @@ func serve() error {
 		zap.String("address", srv.Addr()),
 		zap.String("health_endpoint", fmt.Sprintf("http://%s/health", srv.Addr())),
 		zap.String("ready_endpoint", fmt.Sprintf("http://%s/ready", srv.Addr())),
+		zap.String("metrics_endpoint", fmt.Sprintf("http://%s/metrics", srv.Addr())),
 	)
 
 	// Setup signal handling for graceful shutdown
@@ -103,6 +137,10 @@ func serve() error {
 		return fmt.Errorf("server shutdown failed: %w", err)
 	}
 
+	// Flush remaining usage before the Console client is closed
+	stopMeter()
+	<-meterDone
+
 	logger.Info("server shutdown complete")
 	return nil
 }
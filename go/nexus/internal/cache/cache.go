@@ -0,0 +1,169 @@
+package cache
+
+import (
+	"container/list"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Entry is a cached HTTP response, replayed byte for byte so streamed
+// responses keep the SSE framing of the route that produced them
+type Entry struct {
+	Header http.Header
+	Body   []byte
+}
+
+// Vector is the embedding of a prompt used for semantic lookups. Only vectors
+// in the same namespace are compared with each other.
+type Vector struct {
+	Namespace string
+	Embedding []float64
+}
+
+type item struct {
+	key     string
+	entry   *Entry
+	vector  *Vector
+	expires time.Time
+}
+
+// Cache is an in-memory LRU cache of responses with a fixed TTL
+type Cache struct {
+	ttl        time.Duration
+	maxEntries int
+	// threshold is the minimum cosine similarity of a semantic hit, semantic lookups are disabled when zero
+	threshold float64
+	now       func() time.Time
+
+	mu    sync.Mutex
+	items map[string]*list.Element
+	lru   *list.List
+}
+
+// New creates a new response cache. Semantic lookups are enabled with a positive threshold.
+func New(ttl time.Duration, maxEntries int, threshold float64) *Cache {
+	return &Cache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		threshold:  threshold,
+		now:        time.Now,
+		items:      make(map[string]*list.Element),
+		lru:        list.New(),
+	}
+}
+
+// Semantic reports whether similar prompts can be served from the cache
+func (in *Cache) Semantic() bool {
+	return in != nil && in.threshold > 0
+}
+
+// Get returns the response cached under key
+func (in *Cache) Get(key string) (*Entry, bool) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	elem, ok := in.items[key]
+	if ok && in.expired(elem) {
+		in.remove(elem)
+		ok = false
+	}
+
+	recordLookup(lookupExact, ok)
+	if !ok {
+		return nil, false
+	}
+
+	in.lru.MoveToFront(elem)
+	return elem.Value.(*item).entry, true
+}
+
+// Nearest returns the response of the most similar prompt in the namespace of
+// vector, if its cosine similarity reaches the cache threshold
+func (in *Cache) Nearest(vector *Vector) (*Entry, bool) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	var best *list.Element
+	bestScore := in.threshold
+	for elem := in.lru.Front(); elem != nil; {
+		next := elem.Next()
+		it := elem.Value.(*item)
+		switch {
+		case in.expired(elem):
+			in.remove(elem)
+		case it.vector != nil && it.vector.Namespace == vector.Namespace:
+			if score := cosine(it.vector.Embedding, vector.Embedding); score >= bestScore {
+				best, bestScore = elem, score
+			}
+		}
+		elem = next
+	}
+
+	recordLookup(lookupSemantic, best != nil)
+	if best == nil {
+		return nil, false
+	}
+
+	in.lru.MoveToFront(best)
+	return best.Value.(*item).entry, true
+}
+
+// Set caches a response under key. The vector is optional and makes the
+// response available to semantic lookups.
+func (in *Cache) Set(key string, entry *Entry, vector *Vector) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	if elem, ok := in.items[key]; ok {
+		in.remove(elem)
+	}
+
+	in.items[key] = in.lru.PushFront(&item{
+		key:     key,
+		entry:   entry,
+		vector:  vector,
+		expires: in.now().Add(in.ttl),
+	})
+
+	for in.lru.Len() > in.maxEntries {
+		in.remove(in.lru.Back())
+	}
+	entriesGauge.Set(float64(in.lru.Len()))
+}
+
+// Len returns the number of cached responses, including expired ones not evicted yet
+func (in *Cache) Len() int {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	return in.lru.Len()
+}
+
+func (in *Cache) expired(elem *list.Element) bool {
+	return !in.now().Before(elem.Value.(*item).expires)
+}
+
+func (in *Cache) remove(elem *list.Element) {
+	in.lru.Remove(elem)
+	delete(in.items, elem.Value.(*item).key)
+	entriesGauge.Set(float64(in.lru.Len()))
+}
+
+func cosine(a, b []float64) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
@@ -0,0 +1,113 @@
+package cache
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (in *fakeClock) Now() time.Time {
+	return in.now
+}
+
+func newTestCache(maxEntries int, threshold float64) (*Cache, *fakeClock) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	cache := New(time.Minute, maxEntries, threshold)
+	cache.now = clock.Now
+	return cache, clock
+}
+
+func entry(body string) *Entry {
+	return &Entry{Body: []byte(body)}
+}
+
+func TestCache_Expires(t *testing.T) {
+	cache, clock := newTestCache(10, 0)
+	cache.Set("key", entry("cached"), nil)
+
+	clock.now = clock.now.Add(59 * time.Second)
+	got, ok := cache.Get("key")
+	require.True(t, ok)
+	assert.Equal(t, "cached", string(got.Body))
+
+	clock.now = clock.now.Add(time.Second)
+	_, ok = cache.Get("key")
+	assert.False(t, ok)
+	assert.Equal(t, 0, cache.Len())
+}
+
+func TestCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache, _ := newTestCache(2, 0)
+	cache.Set("a", entry("a"), nil)
+	cache.Set("b", entry("b"), nil)
+
+	_, ok := cache.Get("a")
+	require.True(t, ok)
+
+	cache.Set("c", entry("c"), nil)
+	assert.Equal(t, 2, cache.Len())
+
+	_, ok = cache.Get("b")
+	assert.False(t, ok)
+	for _, key := range []string{"a", "c"} {
+		_, ok = cache.Get(key)
+		assert.True(t, ok, key)
+	}
+}
+
+func TestCache_Nearest(t *testing.T) {
+	cache, clock := newTestCache(10, 0.9)
+	require.True(t, cache.Semantic())
+
+	cache.Set("close", entry("close"), &Vector{Namespace: "user", Embedding: []float64{1, 0.1}})
+	cache.Set("far", entry("far"), &Vector{Namespace: "user", Embedding: []float64{0, 1}})
+	cache.Set("other", entry("other"), &Vector{Namespace: "other", Embedding: []float64{1, 0}})
+	cache.Set("exact", entry("exact"), nil)
+
+	got, ok := cache.Nearest(&Vector{Namespace: "user", Embedding: []float64{1, 0}})
+	require.True(t, ok)
+	assert.Equal(t, "close", string(got.Body))
+
+	_, ok = cache.Nearest(&Vector{Namespace: "user", Embedding: []float64{1, 1}})
+	assert.False(t, ok, "similarity below the threshold")
+
+	_, ok = cache.Nearest(&Vector{Namespace: "missing", Embedding: []float64{1, 0}})
+	assert.False(t, ok, "namespaces are never shared")
+
+	clock.now = clock.now.Add(time.Minute)
+	_, ok = cache.Nearest(&Vector{Namespace: "user", Embedding: []float64{1, 0}})
+	assert.False(t, ok)
+	assert.Equal(t, 0, cache.Len())
+}
+
+func TestCache_Semantic(t *testing.T) {
+	assert.False(t, (*Cache)(nil).Semantic())
+	assert.False(t, New(time.Minute, 1, 0).Semantic())
+}
+
+func TestCosine(t *testing.T) {
+	cases := []struct {
+		a, b []float64
+		want float64
+	}{
+		{a: []float64{1, 2, 3}, b: []float64{2, 4, 6}, want: 1},
+		{a: []float64{1, 0}, b: []float64{0, 1}, want: 0},
+		{a: []float64{1, 0}, b: []float64{-1, 0}, want: -1},
+		{a: []float64{1, 0}, b: []float64{1, 0, 0}, want: 0},
+		{a: []float64{0, 0}, b: []float64{1, 0}, want: 0},
+		{a: nil, b: nil, want: 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("%v_%v", tc.a, tc.b), func(t *testing.T) {
+			assert.InDelta(t, tc.want, cosine(tc.a, tc.b), 1e-9)
+		})
+	}
+}
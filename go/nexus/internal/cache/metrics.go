@@ -0,0 +1,35 @@
+package cache
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	lookupExact    = "exact"
+	lookupSemantic = "semantic"
+)
+
+var (
+	lookupsCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "nexus",
+		Subsystem: "response_cache",
+		Name:      "lookups_total",
+		Help:      "Response cache lookups by type (exact or semantic) and result (hit or miss).",
+	}, []string{"type", "result"})
+
+	entriesGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "nexus",
+		Subsystem: "response_cache",
+		Name:      "entries",
+		Help:      "Number of responses held in the response cache.",
+	})
+)
+
+func recordLookup(lookup string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	lookupsCounter.WithLabelValues(lookup, result).Inc()
+}
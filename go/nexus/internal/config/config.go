@@ -10,6 +10,8 @@ type Config struct {
 	Server        ServerConfig        `json:"server"`
 	Console       ConsoleConfig       `json:"console"`
 	Observability ObservabilityConfig `json:"observability"`
+	Metering      MeteringConfig      `json:"metering"`
+	Cache         CacheConfig         `json:"cache"`
 }
 
 // ServerConfig contains HTTP server settings
@@ -54,6 +56,51 @@ type ObservabilityConfig struct {
 	LogLevel string `json:"logLevel"`
 }
 
+// MeteringConfig contains per-caller AI usage metering settings
+type MeteringConfig struct {
+	// Enabled toggles reporting of per-caller usage to Console
+	Enabled bool `json:"enabled"`
+
+	// FlushInterval is how often aggregated usage is sent to Console
+	FlushInterval time.Duration `json:"flushInterval"`
+
+	// Prices overrides or extends the built-in price table. Keys are exact model names
+	// and prices are in USD per one million tokens.
+	Prices map[string]ModelPrice `json:"prices"`
+}
+
+// ModelPrice is the price of a model in USD per one million tokens
+type ModelPrice struct {
+	Input       float64 `json:"input"`
+	CachedInput float64 `json:"cachedInput"`
+	Output      float64 `json:"output"`
+}
+
+// CacheConfig contains response cache settings
+type CacheConfig struct {
+	// Enabled toggles caching of chat, responses and embeddings responses
+	Enabled bool `json:"enabled"`
+
+	// TTL is how long a cached response is served
+	TTL time.Duration `json:"ttl"`
+
+	// MaxEntries is the maximum number of cached responses, least recently used ones are evicted first
+	MaxEntries int `json:"maxEntries"`
+
+	// Semantic contains the similarity based cache settings
+	Semantic SemanticCacheConfig `json:"semantic"`
+}
+
+// SemanticCacheConfig contains the similarity based cache settings
+type SemanticCacheConfig struct {
+	// Enabled toggles serving cached responses for similar prompts. Every cache miss
+	// is embedded with the default embedding provider configured in Console.
+	Enabled bool `json:"enabled"`
+
+	// Threshold is the minimum cosine similarity between two prompts to share a response
+	Threshold float64 `json:"threshold"`
+}
+
 // Defaults returns a Config with default values
 func Defaults() *Config {
 	return &Config{
@@ -72,15 +119,28 @@ func Defaults() *Config {
 				MaxBackoff:     30 * time.Second,
 			},
 		},
+		Metering: MeteringConfig{
+			Enabled:       true,
+			FlushInterval: 30 * time.Second,
+		},
+		Cache: CacheConfig{
+			TTL:        10 * time.Minute,
+			MaxEntries: 1000,
+			Semantic: SemanticCacheConfig{
+				Threshold: 0.95,
+			},
+		},
 	}
 }
 
 // String returns a string representation of the config (with sensitive data redacted)
 func (c *Config) String() string {
-	return fmt.Sprintf("Config{Server: %+v, Console: %s, Observability: %+v}",
+	return fmt.Sprintf("Config{Server: %+v, Console: %s, Observability: %+v, Metering: %+v, Cache: %+v}",
 		c.Server,
 		redactConsoleConfig(c.Console),
 		c.Observability,
+		c.Metering,
+		c.Cache,
 	)
 }
 
@@ -105,6 +105,27 @@ func loadFromEnv(cfg *Config) {
 	if v.IsSet("console.connectionRetry.maxBackoff") {
 		cfg.Console.ConnectionRetry.MaxBackoff = v.GetDuration("console.connectionRetry.maxBackoff")
 	}
+	if v.IsSet("metering.enabled") {
+		cfg.Metering.Enabled = v.GetBool("metering.enabled")
+	}
+	if v.IsSet("metering.flushInterval") {
+		cfg.Metering.FlushInterval = v.GetDuration("metering.flushInterval")
+	}
+	if v.IsSet("cache.enabled") {
+		cfg.Cache.Enabled = v.GetBool("cache.enabled")
+	}
+	if v.IsSet("cache.ttl") {
+		cfg.Cache.TTL = v.GetDuration("cache.ttl")
+	}
+	if v.IsSet("cache.maxEntries") {
+		cfg.Cache.MaxEntries = v.GetInt("cache.maxEntries")
+	}
+	if v.IsSet("cache.semantic.enabled") {
+		cfg.Cache.Semantic.Enabled = v.GetBool("cache.semantic.enabled")
+	}
+	if v.IsSet("cache.semantic.threshold") {
+		cfg.Cache.Semantic.Threshold = v.GetFloat64("cache.semantic.threshold")
+	}
 }
 
 // LoadFromFileOrDefaults loads config from file if it exists, otherwise uses defaults
@@ -46,6 +46,16 @@ func Validate(cfg *Config) error {
 		errors = append(errors, err...)
 	}
 
+	// Validate Metering config
+	if err := validateMetering(&cfg.Metering); err != nil {
+		errors = append(errors, err...)
+	}
+
+	// Validate Cache config
+	if err := validateCache(&cfg.Cache); err != nil {
+		errors = append(errors, err...)
+	}
+
 	if len(errors) > 0 {
 		return errors
 	}
@@ -152,3 +162,56 @@ func validateConsole(cfg *ConsoleConfig) ValidationErrors {
 
 	return errors
 }
+
+func validateMetering(cfg *MeteringConfig) ValidationErrors {
+	var errors ValidationErrors
+
+	if cfg.Enabled && cfg.FlushInterval <= 0 {
+		errors = append(errors, ValidationError{
+			Field:   "metering.flushInterval",
+			Message: "flushInterval must be positive",
+		})
+	}
+
+	for model, price := range cfg.Prices {
+		if price.Input < 0 || price.CachedInput < 0 || price.Output < 0 {
+			errors = append(errors, ValidationError{
+				Field:   fmt.Sprintf("metering.prices.%s", model),
+				Message: "prices cannot be negative",
+			})
+		}
+	}
+
+	return errors
+}
+
+func validateCache(cfg *CacheConfig) ValidationErrors {
+	var errors ValidationErrors
+
+	if !cfg.Enabled {
+		return errors
+	}
+
+	if cfg.TTL <= 0 {
+		errors = append(errors, ValidationError{
+			Field:   "cache.ttl",
+			Message: "ttl must be positive",
+		})
+	}
+
+	if cfg.MaxEntries <= 0 {
+		errors = append(errors, ValidationError{
+			Field:   "cache.maxEntries",
+			Message: "maxEntries must be positive",
+		})
+	}
+
+	if cfg.Semantic.Enabled && (cfg.Semantic.Threshold <= 0 || cfg.Semantic.Threshold > 1) {
+		errors = append(errors, ValidationError{
+			Field:   "cache.semantic.threshold",
+			Message: "threshold must be greater than 0 and at most 1",
+		})
+	}
+
+	return errors
+}
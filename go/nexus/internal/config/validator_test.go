@@ -210,6 +210,81 @@ func TestValidateConsole_MaxBackoffLessThanInitial(t *testing.T) {
 	}
 }
 
+func TestValidateMetering_InvalidConfig(t *testing.T) {
+	cfg := config.Defaults()
+	cfg.Console.GRPCEndpoint = grpcEndpoint
+	cfg.Metering.FlushInterval = 0
+	cfg.Metering.Prices = map[string]config.ModelPrice{"gpt-5": {Input: -1}}
+
+	err := config.Validate(cfg)
+	if err == nil {
+		t.Fatal("expected error for invalid metering config")
+	}
+
+	var verr config.ValidationErrors
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected ValidationErrors, got %T", err)
+	}
+
+	fields := make(map[string]bool)
+	for _, e := range verr {
+		fields[e.Field] = true
+	}
+	if !fields["metering.flushInterval"] || !fields["metering.prices.gpt-5"] {
+		t.Errorf("expected metering errors, got: %v", verr)
+	}
+}
+
+func TestValidateMetering_DisabledIgnoresFlushInterval(t *testing.T) {
+	cfg := config.Defaults()
+	cfg.Console.GRPCEndpoint = grpcEndpoint
+	cfg.Metering.Enabled = false
+	cfg.Metering.FlushInterval = 0
+
+	if err := config.Validate(cfg); err != nil {
+		t.Errorf("expected valid config, got error: %v", err)
+	}
+}
+
+func TestValidateCache_InvalidConfig(t *testing.T) {
+	cfg := config.Defaults()
+	cfg.Console.GRPCEndpoint = grpcEndpoint
+	cfg.Cache.Enabled = true
+	cfg.Cache.TTL = 0
+	cfg.Cache.MaxEntries = 0
+	cfg.Cache.Semantic.Enabled = true
+	cfg.Cache.Semantic.Threshold = 1.5
+
+	err := config.Validate(cfg)
+	if err == nil {
+		t.Fatal("expected error for invalid cache config")
+	}
+
+	var verr config.ValidationErrors
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected ValidationErrors, got %T", err)
+	}
+
+	fields := make(map[string]bool)
+	for _, e := range verr {
+		fields[e.Field] = true
+	}
+	if !fields["cache.ttl"] || !fields["cache.maxEntries"] || !fields["cache.semantic.threshold"] {
+		t.Errorf("expected cache errors, got: %v", verr)
+	}
+}
+
+func TestValidateCache_DisabledIgnoresSettings(t *testing.T) {
+	cfg := config.Defaults()
+	cfg.Console.GRPCEndpoint = grpcEndpoint
+	cfg.Cache.TTL = 0
+	cfg.Cache.Semantic.Threshold = 2
+
+	if err := config.Validate(cfg); err != nil {
+		t.Errorf("expected valid config, got error: %v", err)
+	}
+}
+
 func TestValidationErrors_Error(t *testing.T) {
 	errs := config.ValidationErrors{
 		config.ValidationError{Field: "field1", Message: "error1"},
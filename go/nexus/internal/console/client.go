@@ -2,6 +2,7 @@ package console
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -20,8 +21,11 @@ type Client interface {
 	// GetAiConfig retrieves the AI configuration from Console
 	GetAiConfig(ctx context.Context) (*pb.AiConfig, error)
 
-	// ProxyAuthentication authenticates a request token with Console
-	ProxyAuthentication(ctx context.Context, token string) (bool, error)
+	// ProxyAuthentication authenticates a request token with Console and returns the caller identity
+	ProxyAuthentication(ctx context.Context, token string) (*pb.ProxyAuthenticationResponse, error)
+
+	// MeterAiUsage reports aggregated per-caller AI usage to Console
+	MeterAiUsage(ctx context.Context, usage []*pb.AiUsage) error
 
 	// IsConnected checks if the connection is still alive
 	IsConnected() bool
@@ -136,7 +140,7 @@ func (c *client) getAiConfig(ctx context.Context) (*pb.AiConfig, error) {
 }
 
 // ProxyAuthentication authenticates a request token with Console
-func (c *client) ProxyAuthentication(ctx context.Context, token string) (bool, error) {
+func (c *client) ProxyAuthentication(ctx context.Context, token string) (*pb.ProxyAuthenticationResponse, error) {
 	// Apply request timeout
 	ctx, cancel := context.WithTimeout(ctx, c.config.RequestTimeout)
 	defer cancel()
@@ -148,12 +152,30 @@ func (c *client) ProxyAuthentication(ctx context.Context, token string) (bool, e
 	})
 	if err != nil {
 		c.logger.Debug("ProxyAuthentication failed", zap.Error(err))
-		return false, fmt.Errorf("ProxyAuthentication failed: %w", err)
+		return nil, fmt.Errorf("ProxyAuthentication failed: %w", err)
 	}
 
 	c.logger.Debug("ProxyAuthentication succeeded", zap.Bool("authenticated", resp.Authenticated))
 
-	return resp.Authenticated, nil
+	return resp, nil
+}
+
+// MeterAiUsage reports aggregated per-caller AI usage to Console
+func (c *client) MeterAiUsage(ctx context.Context, usage []*pb.AiUsage) error {
+	// Apply request timeout
+	ctx, cancel := context.WithTimeout(ctx, c.config.RequestTimeout)
+	defer cancel()
+
+	resp, err := c.grpcClient.MeterAiUsage(ctx, &pb.MeterAiUsageRequest{Usage: usage})
+	if err != nil {
+		return fmt.Errorf("MeterAiUsage failed: %w", err)
+	}
+
+	if !resp.GetSuccess() {
+		return errors.New("MeterAiUsage was not accepted by console")
+	}
+
+	return nil
 }
 
 // IsConnected checks if the connection is still alive
@@ -25,17 +25,22 @@ type mockPluralServer struct {
 	pb.UnimplementedPluralServerServer
 	authenticateFunc func(token string) bool
 	getConfigFunc    func() (*pb.AiConfig, error)
+	metered          []*pb.AiUsage
 	callCount        int
 }
 
 func (m *mockPluralServer) ProxyAuthentication(_ context.Context, req *pb.ProxyAuthenticationRequest) (*pb.ProxyAuthenticationResponse, error) {
 	m.callCount++
-	if m.authenticateFunc != nil {
-		return &pb.ProxyAuthenticationResponse{
-			Authenticated: m.authenticateFunc(req.Token),
-		}, nil
+	if m.authenticateFunc != nil && !m.authenticateFunc(req.Token) {
+		return &pb.ProxyAuthenticationResponse{Authenticated: false}, nil
 	}
-	return &pb.ProxyAuthenticationResponse{Authenticated: true}, nil
+	return &pb.ProxyAuthenticationResponse{Authenticated: true, UserId: strPtr("user-" + req.Token)}, nil
+}
+
+func (m *mockPluralServer) MeterAiUsage(_ context.Context, req *pb.MeterAiUsageRequest) (*pb.MeterAiUsageResponse, error) {
+	m.callCount++
+	m.metered = append(m.metered, req.Usage...)
+	return &pb.MeterAiUsageResponse{Success: true}, nil
 }
 
 func (m *mockPluralServer) GetAiConfig(_ context.Context, _ *pb.AiConfigRequest) (*pb.AiConfig, error) {
@@ -132,14 +137,17 @@ func (c *testClientWrapper) GetAiConfig(ctx context.Context) (*pb.AiConfig, erro
 	return c.grpcClient.GetAiConfig(ctx, &pb.AiConfigRequest{})
 }
 
-func (c *testClientWrapper) ProxyAuthentication(ctx context.Context, token string) (bool, error) {
+func (c *testClientWrapper) ProxyAuthentication(ctx context.Context, token string) (*pb.ProxyAuthenticationResponse, error) {
 	ctx, cancel := context.WithTimeout(ctx, c.config.RequestTimeout)
 	defer cancel()
-	resp, err := c.grpcClient.ProxyAuthentication(ctx, &pb.ProxyAuthenticationRequest{Token: token})
-	if err != nil {
-		return false, err
-	}
-	return resp.Authenticated, nil
+	return c.grpcClient.ProxyAuthentication(ctx, &pb.ProxyAuthenticationRequest{Token: token})
+}
+
+func (c *testClientWrapper) MeterAiUsage(ctx context.Context, usage []*pb.AiUsage) error {
+	ctx, cancel := context.WithTimeout(ctx, c.config.RequestTimeout)
+	defer cancel()
+	_, err := c.grpcClient.MeterAiUsage(ctx, &pb.MeterAiUsageRequest{Usage: usage})
+	return err
 }
 
 func (c *testClientWrapper) IsConnected() bool {
@@ -301,20 +309,23 @@ func TestClient_ProxyAuthentication_Success(t *testing.T) {
 	ctx := context.Background()
 
 	// Test valid token
-	authenticated, err := client.ProxyAuthentication(ctx, "valid-token")
+	resp, err := client.ProxyAuthentication(ctx, "valid-token")
 	if err != nil {
 		t.Fatalf("ProxyAuthentication failed: %v", err)
 	}
-	if !authenticated {
+	if !resp.GetAuthenticated() {
 		t.Error("expected valid token to be authenticated")
 	}
+	if resp.GetUserId() != "user-valid-token" {
+		t.Errorf("expected caller identity, got %q", resp.GetUserId())
+	}
 
 	// Test invalid token
-	authenticated, err = client.ProxyAuthentication(ctx, "invalid-token")
+	resp, err = client.ProxyAuthentication(ctx, "invalid-token")
 	if err != nil {
 		t.Fatalf("ProxyAuthentication failed: %v", err)
 	}
-	if authenticated {
+	if resp.GetAuthenticated() {
 		t.Error("expected invalid token to not be authenticated")
 	}
 }
@@ -332,6 +343,29 @@ func TestClient_ProxyAuthentication_Error(t *testing.T) {
 	}
 }
 
+func TestClient_MeterAiUsage(t *testing.T) {
+	mock := &mockPluralServer{}
+	client, _, cleanup := createTestClient(t, mock)
+	defer cleanup()
+
+	usage := []*pb.AiUsage{{
+		UserId:           strPtr("user-1"),
+		Provider:         "openai",
+		Model:            "gpt-5",
+		PromptTokens:     100,
+		CompletionTokens: 20,
+		Cost:             0.001,
+		Requests:         1,
+	}}
+	if err := client.MeterAiUsage(context.Background(), usage); err != nil {
+		t.Fatalf("MeterAiUsage failed: %v", err)
+	}
+
+	if len(mock.metered) != 1 || mock.metered[0].GetUserId() != "user-1" || mock.metered[0].PromptTokens != 100 {
+		t.Errorf("unexpected metered usage: %v", mock.metered)
+	}
+}
+
 // errorReturningMockServer returns errors for testing
 type errorReturningMockServer struct {
 	pb.UnimplementedPluralServerServer
@@ -91,10 +91,15 @@ func (r *RetryableClient) GetAiConfig(ctx context.Context) (*pb.AiConfig, error)
 }
 
 // ProxyAuthentication authenticates a token with retry logic
-func (r *RetryableClient) ProxyAuthentication(ctx context.Context, token string) (bool, error) {
+func (r *RetryableClient) ProxyAuthentication(ctx context.Context, token string) (*pb.ProxyAuthenticationResponse, error) {
 	return r.client.ProxyAuthentication(ctx, token)
 }
 
+// MeterAiUsage reports aggregated AI usage to Console
+func (r *RetryableClient) MeterAiUsage(ctx context.Context, usage []*pb.AiUsage) error {
+	return r.client.MeterAiUsage(ctx, usage)
+}
+
 // Close closes the underlying client connection
 func (r *RetryableClient) Close() error {
 	return r.client.Close()
@@ -0,0 +1,83 @@
+package guardrails
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/maximhq/bifrost/core/schemas"
+
+	pb "github.com/pluralsh/console/go/nexus/internal/proto"
+)
+
+// ConfigSource provides the AI configuration holding the guardrails policy
+type ConfigSource interface {
+	GetAiConfig(ctx context.Context) (*pb.AiConfig, error)
+}
+
+// Guard enforces the guardrails configured in Console on proxied requests
+type Guard struct {
+	source ConfigSource
+
+	mu     sync.Mutex
+	config *pb.GuardrailsConfig
+	policy *Policy
+	err    error
+}
+
+// New creates a new guard reading its policy from source
+func New(source ConfigSource) *Guard {
+	return &Guard{source: source}
+}
+
+// Apply checks the request against the policy and redacts its prompts in place.
+// A *Violation is returned when the request is rejected. The returned session
+// restores redacted values in the response and is nil when there is nothing to restore.
+func (in *Guard) Apply(ctx context.Context, req *schemas.BifrostRequest) (*Session, error) {
+	if in == nil || req == nil {
+		return nil, nil
+	}
+
+	policy, err := in.currentPolicy(ctx)
+	if err != nil || policy == nil || policy.Empty() {
+		return nil, err
+	}
+
+	var texts []string
+	visitRequest(req, func(text *string) { texts = append(texts, *text) })
+	if err := policy.Check(texts); err != nil {
+		return nil, err
+	}
+
+	session := newSession(policy)
+	visitRequest(req, func(text *string) { *text = session.Redact(*text) })
+	if !session.restores() {
+		return nil, nil
+	}
+
+	return session, nil
+}
+
+// currentPolicy returns the compiled policy, recompiling it whenever Console returns a new config.
+// Compile errors are cached as well, an invalid config fails every request until it changes.
+func (in *Guard) currentPolicy(ctx context.Context) (*Policy, error) {
+	aiConfig, err := in.source.GetAiConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AI config: %w", err)
+	}
+
+	config := aiConfig.GetGuardrails()
+	if !config.GetEnabled() {
+		return nil, nil
+	}
+
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	if in.config != config {
+		in.policy, in.err = Compile(config)
+		in.config = config
+	}
+
+	return in.policy, in.err
+}
@@ -0,0 +1,177 @@
+package guardrails
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	pb "github.com/pluralsh/console/go/nexus/internal/proto"
+)
+
+// Violation is returned when a prompt is rejected by the policy
+type Violation struct {
+	// Rule is the name of the rule that rejected the prompt
+	Rule    string
+	Message string
+}
+
+func (v *Violation) Error() string {
+	return v.Message
+}
+
+// rule redacts all matches of a pattern. Reversible rules replace matches with
+// placeholders that are restored in responses, others drop the match for good.
+type rule struct {
+	kind       string
+	pattern    *regexp.Regexp
+	reversible bool
+	// group is the submatch that is redacted, the whole match when zero
+	group int
+	// valid filters out false positives of the pattern when set
+	valid func(match string) bool
+}
+
+type topic struct {
+	name     string
+	patterns []*regexp.Regexp
+}
+
+// Policy is a compiled pb.GuardrailsConfig
+type Policy struct {
+	rules         []rule
+	topics        []topic
+	maxPromptSize int
+}
+
+// secretRules detect credentials that must never reach a provider. Secrets are
+// not restored in responses, so the model cannot echo them back either.
+var secretRules = []rule{
+	{kind: "PRIVATE_KEY", pattern: regexp.MustCompile(`-----BEGIN[A-Z ]*PRIVATE KEY-----[\s\S]*?-----END[A-Z ]*PRIVATE KEY-----`)},
+	{kind: "AWS_ACCESS_KEY", pattern: regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{kind: "AWS_SECRET_KEY", pattern: regexp.MustCompile(`(?i)(aws_secret_access_key["']?\s*[:=]\s*["']?)([A-Za-z0-9/+=]{40})`), group: 2},
+	{kind: "KUBE_TOKEN", pattern: regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`)},
+	{kind: "TOKEN", pattern: regexp.MustCompile(`(?i)(\btoken["']?[ \t]*[:=][ \t]*["']?)([A-Za-z0-9._~+/-]{20,}=*)`), group: 2},
+	{kind: "TOKEN", pattern: regexp.MustCompile(`(?i)(\bbearer[ \t]+)([A-Za-z0-9._~+/-]{20,}=*)`), group: 2},
+}
+
+// piiRules are the built-in PII detectors used when PII redaction is enabled
+var piiRules = []rule{
+	{kind: "EMAIL", pattern: regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`), reversible: true},
+	{kind: "SSN", pattern: regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`), reversible: true},
+	{kind: "CREDIT_CARD", pattern: regexp.MustCompile(`\b\d{4}[ -]?\d{4}[ -]?\d{4}[ -]?\d{1,7}\b`), reversible: true, valid: luhn},
+	{kind: "PHONE", pattern: regexp.MustCompile(`(?:\+\d{1,3}[ .-]?)?\(?\b\d{3}\)?[ .-]\d{3}[ .-]\d{4}\b`), reversible: true},
+}
+
+// Compile builds a policy from the guardrails configured in Console
+func Compile(config *pb.GuardrailsConfig) (*Policy, error) {
+	policy := &Policy{maxPromptSize: int(config.GetMaxPromptSize())}
+
+	if config.GetDetectSecrets() {
+		policy.rules = append(policy.rules, secretRules...)
+	}
+
+	if terms := termsPattern(config.GetPiiTerms()); terms != nil {
+		policy.rules = append(policy.rules, rule{kind: "TERM", pattern: terms, reversible: true})
+	}
+
+	for _, p := range config.GetPiiPatterns() {
+		pattern, err := regexp.Compile(p.GetPattern())
+		if err != nil {
+			return nil, fmt.Errorf("invalid pii pattern %s: %w", p.GetName(), err)
+		}
+		policy.rules = append(policy.rules, rule{kind: strings.ToUpper(p.GetName()), pattern: pattern, reversible: true})
+	}
+
+	if config.GetRedactPii() {
+		policy.rules = append(policy.rules, piiRules...)
+	}
+
+	for _, t := range config.GetBlockedTopics() {
+		compiled := topic{name: t.GetName()}
+		for _, p := range t.GetPatterns() {
+			pattern, err := regexp.Compile("(?i)" + p)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern for blocked topic %s: %w", t.GetName(), err)
+			}
+			compiled.patterns = append(compiled.patterns, pattern)
+		}
+		policy.topics = append(policy.topics, compiled)
+	}
+
+	return policy, nil
+}
+
+// Check rejects prompts that exceed the maximum size or touch a blocked topic.
+// It must run on the original prompt, before anything is redacted.
+func (in *Policy) Check(texts []string) error {
+	if in.maxPromptSize > 0 {
+		size := 0
+		for _, text := range texts {
+			size += utf8.RuneCountInString(text)
+		}
+		if size > in.maxPromptSize {
+			return &Violation{
+				Rule:    "max_prompt_size",
+				Message: fmt.Sprintf("prompt of %d characters exceeds the maximum size of %d characters", size, in.maxPromptSize),
+			}
+		}
+	}
+
+	for _, t := range in.topics {
+		for _, pattern := range t.patterns {
+			for _, text := range texts {
+				if pattern.MatchString(text) {
+					return &Violation{
+						Rule:    "blocked_topic",
+						Message: fmt.Sprintf("prompt rejected by guardrails: blocked topic %q", t.name),
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Empty reports whether the policy has nothing to enforce
+func (in *Policy) Empty() bool {
+	return len(in.rules) == 0 && len(in.topics) == 0 && in.maxPromptSize <= 0
+}
+
+func termsPattern(terms []string) *regexp.Regexp {
+	quoted := make([]string, 0, len(terms))
+	for _, term := range terms {
+		if term = strings.TrimSpace(term); term != "" {
+			quoted = append(quoted, regexp.QuoteMeta(term))
+		}
+	}
+	if len(quoted) == 0 {
+		return nil
+	}
+
+	// Prefer the longest term when terms overlap
+	sort.Slice(quoted, func(i, j int) bool { return len(quoted[i]) > len(quoted[j]) })
+	return regexp.MustCompile("(?i)(?:" + strings.Join(quoted, "|") + ")")
+}
+
+// luhn validates credit card numbers to avoid redacting arbitrary digit runs
+func luhn(number string) bool {
+	sum, digits := 0, 0
+	for i := len(number) - 1; i >= 0; i-- {
+		c := number[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if digits%2 == 1 {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		digits++
+	}
+	return digits >= 13 && digits <= 19 && sum%10 == 0
+}
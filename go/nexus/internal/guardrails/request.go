@@ -0,0 +1,95 @@
+package guardrails
+
+import (
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+// visitRequest calls visit for every prompt text of the request, so it can be
+// inspected or rewritten in place.
+func visitRequest(req *schemas.BifrostRequest, visit func(*string)) {
+	switch {
+	case req.TextCompletionRequest != nil:
+		if input := req.TextCompletionRequest.Input; input != nil {
+			visitOptional(input.PromptStr, visit)
+			visitSlice(input.PromptArray, visit)
+		}
+	case req.ChatRequest != nil:
+		for i := range req.ChatRequest.Input {
+			visitChatMessage(&req.ChatRequest.Input[i], visit)
+		}
+	case req.ResponsesRequest != nil:
+		visitResponsesRequest(req.ResponsesRequest, visit)
+	case req.CountTokensRequest != nil:
+		visitResponsesRequest(req.CountTokensRequest, visit)
+	case req.EmbeddingRequest != nil:
+		if input := req.EmbeddingRequest.Input; input != nil {
+			visitOptional(input.Text, visit)
+			visitSlice(input.Texts, visit)
+		}
+	}
+}
+
+func visitResponsesRequest(req *schemas.BifrostResponsesRequest, visit func(*string)) {
+	if req.Params != nil {
+		visitOptional(req.Params.Instructions, visit)
+	}
+	for i := range req.Input {
+		visitResponsesMessage(&req.Input[i], visit)
+	}
+}
+
+func visitChatMessage(message *schemas.ChatMessage, visit func(*string)) {
+	if message == nil {
+		return
+	}
+
+	if message.Content != nil {
+		visitOptional(message.Content.ContentStr, visit)
+		for i := range message.Content.ContentBlocks {
+			visitOptional(message.Content.ContentBlocks[i].Text, visit)
+		}
+	}
+
+	if message.ChatAssistantMessage != nil {
+		for i := range message.ToolCalls {
+			visit(&message.ToolCalls[i].Function.Arguments)
+		}
+	}
+}
+
+func visitResponsesMessage(message *schemas.ResponsesMessage, visit func(*string)) {
+	if message == nil {
+		return
+	}
+
+	if message.Content != nil {
+		visitOptional(message.Content.ContentStr, visit)
+		visitResponsesBlocks(message.Content.ContentBlocks, visit)
+	}
+
+	if tool := message.ResponsesToolMessage; tool != nil {
+		visitOptional(tool.Arguments, visit)
+		if tool.Output != nil {
+			visitOptional(tool.Output.ResponsesToolCallOutputStr, visit)
+			visitResponsesBlocks(tool.Output.ResponsesFunctionToolCallOutputBlocks, visit)
+		}
+	}
+}
+
+func visitResponsesBlocks(blocks []schemas.ResponsesMessageContentBlock, visit func(*string)) {
+	for i := range blocks {
+		visitOptional(blocks[i].Text, visit)
+	}
+}
+
+func visitOptional(text *string, visit func(*string)) {
+	if text != nil {
+		visit(text)
+	}
+}
+
+func visitSlice(texts []string, visit func(*string)) {
+	for i := range texts {
+		visit(&texts[i])
+	}
+}
@@ -0,0 +1,137 @@
+package guardrails
+
+import (
+	"fmt"
+
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+// RestoreTextResponse restores placeholders in a text completion response
+func (in *Session) RestoreTextResponse(resp *schemas.BifrostTextCompletionResponse) {
+	if !in.restores() || resp == nil {
+		return
+	}
+
+	for i := range resp.Choices {
+		if choice := resp.Choices[i].TextCompletionResponseChoice; choice != nil {
+			visitOptional(choice.Text, in.restore)
+		}
+	}
+}
+
+// RestoreChatResponse restores placeholders in a chat completion response
+func (in *Session) RestoreChatResponse(resp *schemas.BifrostChatResponse) {
+	if !in.restores() || resp == nil {
+		return
+	}
+
+	for i := range resp.Choices {
+		if choice := resp.Choices[i].ChatNonStreamResponseChoice; choice != nil {
+			visitChatMessage(choice.Message, in.restore)
+		}
+	}
+}
+
+// RestoreResponsesResponse restores placeholders in a responses API response
+func (in *Session) RestoreResponsesResponse(resp *schemas.BifrostResponsesResponse) {
+	if !in.restores() || resp == nil {
+		return
+	}
+
+	for i := range resp.Output {
+		visitResponsesMessage(&resp.Output[i], in.restore)
+	}
+}
+
+// RestoreStreamChunk restores placeholders in a streamed response chunk
+func (in *Session) RestoreStreamChunk(chunk *schemas.BifrostStreamChunk) {
+	if !in.restores() || chunk == nil {
+		return
+	}
+
+	switch {
+	case chunk.BifrostTextCompletionResponse != nil:
+		for _, choice := range chunk.BifrostTextCompletionResponse.Choices {
+			if choice.TextCompletionResponseChoice == nil {
+				continue
+			}
+			key := fmt.Sprintf("text:%d", choice.Index)
+			choice.Text = in.restoreDelta(key, choice.Text, choice.FinishReason != nil)
+		}
+	case chunk.BifrostChatResponse != nil:
+		for _, choice := range chunk.BifrostChatResponse.Choices {
+			if choice.ChatStreamResponseChoice == nil || choice.Delta == nil {
+				visitChatMessage(chatMessage(choice), in.restore)
+				continue
+			}
+
+			final := choice.FinishReason != nil
+			choice.Delta.Content = in.restoreDelta(fmt.Sprintf("chat:%d", choice.Index), choice.Delta.Content, final)
+			for i := range choice.Delta.ToolCalls {
+				call := &choice.Delta.ToolCalls[i]
+				key := fmt.Sprintf("tool:%d:%d", choice.Index, call.Index)
+				call.Function.Arguments = in.RestoreChunk(key, call.Function.Arguments, final)
+			}
+		}
+	case chunk.BifrostResponsesStreamResponse != nil:
+		in.restoreResponsesStream(chunk.BifrostResponsesStreamResponse)
+	}
+}
+
+func (in *Session) restoreResponsesStream(resp *schemas.BifrostResponsesStreamResponse) {
+	key := "responses"
+	if resp.ItemID != nil {
+		key += ":" + *resp.ItemID
+	}
+	if resp.ContentIndex != nil {
+		key += fmt.Sprintf(":%d", *resp.ContentIndex)
+	}
+
+	switch resp.Type {
+	case schemas.ResponsesStreamResponseTypeOutputTextDone, schemas.ResponsesStreamResponseTypeFunctionCallArgumentsDone:
+		// The done events carry the complete text, anything held back is superseded by it
+		delete(in.pending, key)
+	default:
+		if resp.Delta != nil {
+			resp.Delta = in.restoreDelta(key, resp.Delta, false)
+		}
+	}
+
+	visitOptional(resp.Text, in.restore)
+	visitOptional(resp.Arguments, in.restore)
+	visitResponsesMessage(resp.Item, in.restore)
+	if resp.Part != nil {
+		visitOptional(resp.Part.Text, in.restore)
+	}
+	in.RestoreResponsesResponse(resp.Response)
+}
+
+// restoreDelta restores an optional streamed delta, flushing held back output on the final chunk
+func (in *Session) restoreDelta(key string, delta *string, final bool) *string {
+	text := ""
+	if delta != nil {
+		text = *delta
+	}
+
+	restored := in.RestoreChunk(key, text, final)
+	if delta == nil && restored == "" {
+		return nil
+	}
+	return &restored
+}
+
+func (in *Session) restore(text *string) {
+	*text = in.Restore(*text)
+}
+
+// restores reports whether responses can contain placeholders of this session
+func (in *Session) restores() bool {
+	return in != nil && len(in.originals) > 0
+}
+
+func chatMessage(choice schemas.BifrostResponseChoice) *schemas.ChatMessage {
+	if choice.ChatNonStreamResponseChoice == nil {
+		return nil
+	}
+	return choice.Message
+}
@@ -0,0 +1,119 @@
+package guardrails
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// placeholderPattern matches the reversible placeholders produced by a session, e.g. [[EMAIL_1]]
+	placeholderPattern = regexp.MustCompile(`\[\[[A-Z0-9_]+_\d+\]\]`)
+	// partialPlaceholderPattern matches a placeholder cut off at the end of a stream chunk
+	partialPlaceholderPattern = regexp.MustCompile(`\[(?:\[[A-Z0-9_]*\]?)?$`)
+)
+
+// Session redacts a single request and restores the placeholders in its response
+type Session struct {
+	policy *Policy
+
+	// originals maps placeholders to the redacted values and placeholders the
+	// other way around, so a repeated value always gets the same placeholder
+	originals    map[string]string
+	placeholders map[string]string
+	counters     map[string]int
+
+	// pending holds back stream output that may end with a partial placeholder
+	pending map[string]string
+}
+
+func newSession(policy *Policy) *Session {
+	return &Session{
+		policy:       policy,
+		originals:    make(map[string]string),
+		placeholders: make(map[string]string),
+		counters:     make(map[string]int),
+		pending:      make(map[string]string),
+	}
+}
+
+// Redact replaces PII and secrets in text according to the session policy
+func (in *Session) Redact(text string) string {
+	for _, r := range in.policy.rules {
+		text = in.redact(r, text)
+	}
+	return text
+}
+
+func (in *Session) redact(r rule, text string) string {
+	matches := r.pattern.FindAllStringSubmatchIndex(text, -1)
+	if len(matches) == 0 {
+		return text
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, match := range matches {
+		start, end := match[2*r.group], match[2*r.group+1]
+		if start < 0 || (r.valid != nil && !r.valid(text[start:end])) {
+			continue
+		}
+
+		b.WriteString(text[last:start])
+		b.WriteString(in.placeholder(r, text[start:end]))
+		last = end
+	}
+	b.WriteString(text[last:])
+
+	return b.String()
+}
+
+func (in *Session) placeholder(r rule, value string) string {
+	if !r.reversible {
+		return fmt.Sprintf("[[REDACTED_%s]]", r.kind)
+	}
+
+	if placeholder, ok := in.placeholders[value]; ok {
+		return placeholder
+	}
+
+	in.counters[r.kind]++
+	placeholder := fmt.Sprintf("[[%s_%d]]", r.kind, in.counters[r.kind])
+	in.placeholders[value] = placeholder
+	in.originals[placeholder] = value
+	return placeholder
+}
+
+// Restore replaces the placeholders in text with the original values
+func (in *Session) Restore(text string) string {
+	if in == nil || len(in.originals) == 0 {
+		return text
+	}
+
+	return placeholderPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		if original, ok := in.originals[placeholder]; ok {
+			return original
+		}
+		return placeholder
+	})
+}
+
+// RestoreChunk restores the placeholders in a streamed delta. Placeholders can be
+// split across chunks, so a trailing partial placeholder is held back per stream
+// key until the next delta arrives or the stream is finished.
+func (in *Session) RestoreChunk(key, delta string, final bool) string {
+	if in == nil || len(in.originals) == 0 {
+		return delta
+	}
+
+	text := in.pending[key] + delta
+	delete(in.pending, key)
+	if !final {
+		if loc := partialPlaceholderPattern.FindStringIndex(text); loc != nil {
+			in.pending[key] = text[loc[0]:]
+			text = text[:loc[0]]
+		}
+	}
+
+	return in.Restore(text)
+}
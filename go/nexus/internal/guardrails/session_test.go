@@ -0,0 +1,87 @@
+package guardrails
+
+import (
+	"testing"
+
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/pluralsh/console/go/nexus/internal/proto"
+)
+
+func newPIISession(t *testing.T) *Session {
+	policy, err := Compile(&pb.GuardrailsConfig{Enabled: true, RedactPii: true})
+	require.NoError(t, err)
+	return newSession(policy)
+}
+
+func TestSession_RedactRestoreRoundTrip(t *testing.T) {
+	session := newPIISession(t)
+
+	redacted := session.Redact("mail jane@example.com and bob@example.com, then jane@example.com again")
+	assert.Equal(t, "mail [[EMAIL_1]] and [[EMAIL_2]], then [[EMAIL_1]] again", redacted)
+	assert.Equal(t, "mail jane@example.com and bob@example.com, then jane@example.com again", session.Restore(redacted))
+	assert.Equal(t, "unknown [[EMAIL_9]]", session.Restore("unknown [[EMAIL_9]]"))
+}
+
+func TestSession_RestoreChunkHoldsBackPartialPlaceholders(t *testing.T) {
+	session := newPIISession(t)
+	session.Redact("jane@example.com")
+
+	var out string
+	for _, delta := range []string{"Hello ", "[", "[EMA", "IL_1", "]", "] and [", "x"} {
+		out += session.RestoreChunk("chat:0", delta, false)
+	}
+	out += session.RestoreChunk("chat:0", " [[EM", true)
+
+	assert.Equal(t, "Hello jane@example.com and [x [[EM", out)
+}
+
+func TestSession_RestoreChatStream(t *testing.T) {
+	session := newPIISession(t)
+	session.Redact("jane@example.com")
+
+	chunk := func(content string, finish *string) *schemas.BifrostStreamChunk {
+		return &schemas.BifrostStreamChunk{BifrostChatResponse: &schemas.BifrostChatResponse{
+			Choices: []schemas.BifrostResponseChoice{{
+				FinishReason: finish,
+				ChatStreamResponseChoice: &schemas.ChatStreamResponseChoice{
+					Delta: &schemas.ChatStreamResponseChoiceDelta{Content: lo.ToPtr(content)},
+				},
+			}},
+		}}
+	}
+
+	var out string
+	for _, c := range []*schemas.BifrostStreamChunk{chunk("Hi [[EMA", nil), chunk("IL_1]]!", nil), chunk(" [", lo.ToPtr("stop"))} {
+		session.RestoreStreamChunk(c)
+		out += lo.FromPtr(c.BifrostChatResponse.Choices[0].Delta.Content)
+	}
+
+	assert.Equal(t, "Hi jane@example.com! [", out)
+}
+
+func TestSession_RestoreResponses(t *testing.T) {
+	session := newPIISession(t)
+	session.Redact("jane@example.com")
+
+	resp := &schemas.BifrostResponsesResponse{Output: []schemas.ResponsesMessage{{
+		Content: &schemas.ResponsesMessageContent{ContentBlocks: []schemas.ResponsesMessageContentBlock{
+			{Type: schemas.ResponsesOutputMessageContentTypeText, Text: lo.ToPtr("reply to [[EMAIL_1]]")},
+		}},
+	}}}
+	session.RestoreResponsesResponse(resp)
+
+	assert.Equal(t, "reply to jane@example.com", *resp.Output[0].Content.ContentBlocks[0].Text)
+}
+
+func TestSession_NilIsNoop(t *testing.T) {
+	var session *Session
+	resp := &schemas.BifrostChatResponse{}
+
+	assert.Equal(t, "[[EMAIL_1]]", session.Restore("[[EMAIL_1]]"))
+	assert.NotPanics(t, func() { session.RestoreChatResponse(resp) })
+	assert.NotPanics(t, func() { session.RestoreStreamChunk(&schemas.BifrostStreamChunk{}) })
+}
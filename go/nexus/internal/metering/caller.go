@@ -0,0 +1,23 @@
+package metering
+
+import "context"
+
+type callerKey struct{}
+
+// Caller identifies who a proxied request is attributed to. Exactly one of
+// UserID and ClusterID is set for requests authenticated by Console.
+type Caller struct {
+	UserID    string
+	ClusterID string
+}
+
+// WithCaller stores the authenticated caller in the context
+func WithCaller(ctx context.Context, caller Caller) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// CallerFromContext returns the authenticated caller stored in the context
+func CallerFromContext(ctx context.Context) (Caller, bool) {
+	caller, ok := ctx.Value(callerKey{}).(Caller)
+	return caller, ok
+}
@@ -0,0 +1,132 @@
+package metering
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pluralsh/console/go/nexus/internal/config"
+)
+
+// defaultPrices are list prices in USD per one million tokens, keyed by model name.
+// They are estimates only and can be overridden with the metering.prices config.
+var defaultPrices = map[string]config.ModelPrice{
+	// OpenAI
+	"gpt-5":                  {Input: 1.25, CachedInput: 0.125, Output: 10},
+	"gpt-5-mini":             {Input: 0.25, CachedInput: 0.025, Output: 2},
+	"gpt-5-nano":             {Input: 0.05, CachedInput: 0.005, Output: 0.4},
+	"gpt-4.1":                {Input: 2, CachedInput: 0.5, Output: 8},
+	"gpt-4.1-mini":           {Input: 0.4, CachedInput: 0.1, Output: 1.6},
+	"gpt-4.1-nano":           {Input: 0.1, CachedInput: 0.025, Output: 0.4},
+	"gpt-4o":                 {Input: 2.5, CachedInput: 1.25, Output: 10},
+	"gpt-4o-mini":            {Input: 0.15, CachedInput: 0.075, Output: 0.6},
+	"o3":                     {Input: 2, CachedInput: 0.5, Output: 8},
+	"o4-mini":                {Input: 1.1, CachedInput: 0.275, Output: 4.4},
+	"text-embedding-3-small": {Input: 0.02},
+	"text-embedding-3-large": {Input: 0.13},
+
+	// Anthropic
+	"claude-opus-4":     {Input: 15, CachedInput: 1.5, Output: 75},
+	"claude-opus-4-1":   {Input: 15, CachedInput: 1.5, Output: 75},
+	"claude-opus-4-5":   {Input: 5, CachedInput: 0.5, Output: 25},
+	"claude-opus-4-6":   {Input: 5, CachedInput: 0.5, Output: 25},
+	"claude-sonnet-4":   {Input: 3, CachedInput: 0.3, Output: 15},
+	"claude-sonnet-4-5": {Input: 3, CachedInput: 0.3, Output: 15},
+	"claude-sonnet-4-6": {Input: 3, CachedInput: 0.3, Output: 15},
+	"claude-haiku-4-5":  {Input: 1, CachedInput: 0.1, Output: 5},
+	"claude-3-7-sonnet": {Input: 3, CachedInput: 0.3, Output: 15},
+	"claude-3-5-haiku":  {Input: 0.8, CachedInput: 0.08, Output: 4},
+
+	// Google
+	"gemini-2.5-pro":        {Input: 1.25, CachedInput: 0.31, Output: 10},
+	"gemini-2.5-flash":      {Input: 0.3, CachedInput: 0.075, Output: 2.5},
+	"gemini-2.5-flash-lite": {Input: 0.1, CachedInput: 0.025, Output: 0.4},
+	"gemini-embedding-001":  {Input: 0.15},
+
+	// xAI
+	"grok-4": {Input: 3, CachedInput: 0.75, Output: 15},
+}
+
+// defaultAliases map other names of a model to its defaultPrices entry. Versions of a
+// model are priced separately, so they must never be matched by prefix.
+var defaultAliases = map[string]string{
+	"gpt-5-chat-latest":        "gpt-5",
+	"chatgpt-4o-latest":        "gpt-4o",
+	"claude-opus-4-0":          "claude-opus-4",
+	"claude-sonnet-4-0":        "claude-sonnet-4",
+	"claude-3-7-sonnet-latest": "claude-3-7-sonnet",
+	"claude-3-5-haiku-latest":  "claude-3-5-haiku",
+	"grok-4-latest":            "grok-4",
+}
+
+// modelSnapshot matches the snapshot and Bedrock version suffixes of a model name, e.g.
+// "-2025-08-07", "-20250929" or "-20251101-v1:0"
+var modelSnapshot = regexp.MustCompile(`-(\d{4}-\d{2}-\d{2}|\d{8})(-v\d+(:\d+)?)?$|-v\d+(:\d+)?$`)
+
+// PriceTable estimates the cost of model usage
+type PriceTable struct {
+	prices  map[string]config.ModelPrice
+	aliases map[string]string
+}
+
+// NewPriceTable creates a price table from the built-in prices and the given overrides
+func NewPriceTable(overrides map[string]config.ModelPrice) *PriceTable {
+	prices := make(map[string]config.ModelPrice, len(defaultPrices)+len(overrides))
+	for model, price := range defaultPrices {
+		prices[model] = price
+	}
+	for model, price := range overrides {
+		prices[strings.ToLower(model)] = price
+	}
+
+	return &PriceTable{prices: prices, aliases: defaultAliases}
+}
+
+// Lookup returns the price of a model. Names are matched exactly, or through an alias,
+// after provider prefixes such as "openai/" or Bedrock's "us.anthropic." and snapshot
+// dates are removed, so "claude-sonnet-4-5-20250929" is priced as "claude-sonnet-4-5".
+func (t *PriceTable) Lookup(model string) (config.ModelPrice, bool) {
+	model = strings.ToLower(model)
+	if i := strings.LastIndex(model, "/"); i >= 0 {
+		model = model[i+1:]
+	}
+	if price, ok := t.exact(model); ok {
+		return price, true
+	}
+
+	// Bedrock model IDs are prefixed with an optional region and the vendor
+	if _, name, ok := strings.Cut(model, "anthropic."); ok {
+		model = name
+	}
+
+	return t.exact(modelSnapshot.ReplaceAllString(model, ""))
+}
+
+func (t *PriceTable) exact(model string) (config.ModelPrice, bool) {
+	if price, ok := t.prices[model]; ok {
+		return price, true
+	}
+	if alias, ok := t.aliases[model]; ok {
+		price, ok := t.prices[alias]
+		return price, ok
+	}
+
+	return config.ModelPrice{}, false
+}
+
+// Cost returns the estimated cost in USD of the given usage, or 0 for unknown models
+func (t *PriceTable) Cost(usage Usage) float64 {
+	price, ok := t.Lookup(usage.Model)
+	if !ok {
+		return 0
+	}
+
+	cachedPrice := price.CachedInput
+	if cachedPrice == 0 {
+		cachedPrice = price.Input
+	}
+
+	uncached := max(usage.PromptTokens-usage.CachedTokens, 0)
+	return (float64(uncached)*price.Input +
+		float64(usage.CachedTokens)*cachedPrice +
+		float64(usage.CompletionTokens)*price.Output) / 1_000_000
+}
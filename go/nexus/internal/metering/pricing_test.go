@@ -0,0 +1,84 @@
+package metering
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pluralsh/console/go/nexus/internal/config"
+)
+
+func TestPriceTable_Lookup(t *testing.T) {
+	table := NewPriceTable(nil)
+
+	tests := []struct {
+		model string
+		want  config.ModelPrice
+	}{
+		{"gpt-5", defaultPrices["gpt-5"]},
+		{"gpt-5-mini-2025-08-07", defaultPrices["gpt-5-mini"]},
+		{"openai/gpt-4o-mini", defaultPrices["gpt-4o-mini"]},
+		{"gpt-5-chat-latest", defaultPrices["gpt-5"]},
+		{"claude-sonnet-4-5-20250929", defaultPrices["claude-sonnet-4-5"]},
+		{"claude-sonnet-4-20250514", defaultPrices["claude-sonnet-4"]},
+		{"claude-opus-4-0", defaultPrices["claude-opus-4"]},
+		{"claude-opus-4-6", defaultPrices["claude-opus-4-6"]},
+		{"us.anthropic.claude-opus-4-5-20251101-v1:0", defaultPrices["claude-opus-4-5"]},
+		{"anthropic.claude-3-5-haiku-20241022-v1:0", defaultPrices["claude-3-5-haiku"]},
+		{"Gemini-2.5-Flash-Lite", defaultPrices["gemini-2.5-flash-lite"]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.model, func(t *testing.T) {
+			price, ok := table.Lookup(tt.model)
+			assert.True(t, ok)
+			assert.Equal(t, tt.want, price)
+		})
+	}
+}
+
+func TestPriceTable_LookupDoesNotMatchPrefixes(t *testing.T) {
+	table := NewPriceTable(nil)
+
+	for _, model := range []string{"llama-3-70b", "gpt-5.4", "claude-opus-4-7", "gpt-4o-audio-preview", "o3-pro"} {
+		t.Run(model, func(t *testing.T) {
+			_, ok := table.Lookup(model)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestPriceTable_Overrides(t *testing.T) {
+	table := NewPriceTable(map[string]config.ModelPrice{
+		"gpt-5":       {Input: 1, Output: 2},
+		"my-finetune": {Input: 4, Output: 8},
+	})
+
+	price, ok := table.Lookup("gpt-5-2025-08-07")
+	assert.True(t, ok)
+	assert.Equal(t, config.ModelPrice{Input: 1, Output: 2}, price)
+
+	price, ok = table.Lookup("my-finetune")
+	assert.True(t, ok)
+	assert.Equal(t, config.ModelPrice{Input: 4, Output: 8}, price)
+
+	_, ok = table.Lookup("my-finetune-2")
+	assert.False(t, ok)
+}
+
+func TestPriceTable_Cost(t *testing.T) {
+	table := NewPriceTable(map[string]config.ModelPrice{
+		"cached":   {Input: 2, CachedInput: 0.5, Output: 10},
+		"uncached": {Input: 2, Output: 10},
+	})
+
+	usage := Usage{PromptTokens: 1_000_000, CachedTokens: 400_000, CompletionTokens: 100_000}
+
+	usage.Model = "cached"
+	assert.InDelta(t, 0.6*2+0.4*0.5+0.1*10, table.Cost(usage), 1e-9)
+
+	usage.Model = "uncached"
+	assert.InDelta(t, 1*2+0.1*10, table.Cost(usage), 1e-9)
+
+	usage.Model = "unknown"
+	assert.Zero(t, table.Cost(usage))
+}
@@ -0,0 +1,163 @@
+package metering
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+
+	"github.com/pluralsh/console/go/nexus/internal/log"
+	pb "github.com/pluralsh/console/go/nexus/internal/proto"
+)
+
+// Client sends aggregated AI usage to Console
+type Client interface {
+	MeterAiUsage(ctx context.Context, usage []*pb.AiUsage) error
+}
+
+// Usage is the token usage of a single proxied request
+type Usage struct {
+	Provider string
+	Model    string
+
+	// PromptTokens is the total number of input tokens, including cached ones
+	PromptTokens     int64
+	CompletionTokens int64
+	CachedTokens     int64
+}
+
+// IsZero reports whether the usage carries no tokens
+func (u Usage) IsZero() bool {
+	return u.PromptTokens == 0 && u.CompletionTokens == 0 && u.CachedTokens == 0
+}
+
+type usageKey struct {
+	caller   Caller
+	provider string
+	model    string
+}
+
+// UsageReporter aggregates per-caller usage and periodically flushes it to Console
+type UsageReporter struct {
+	client   Client
+	interval time.Duration
+	prices   *PriceTable
+	logger   *zap.Logger
+
+	mu    sync.Mutex
+	usage map[usageKey]*pb.AiUsage
+}
+
+// NewUsageReporter creates a new usage reporter
+func NewUsageReporter(client Client, interval time.Duration, prices *PriceTable) *UsageReporter {
+	return &UsageReporter{
+		client:   client,
+		interval: interval,
+		prices:   prices,
+		logger:   log.Logger().With(zap.String("component", "usage-reporter")),
+		usage:    make(map[usageKey]*pb.AiUsage),
+	}
+}
+
+// Record attributes the usage of a request to the caller stored in the context.
+// It is safe to call on a nil reporter, which discards the usage.
+func (r *UsageReporter) Record(ctx context.Context, usage Usage) {
+	if r == nil || usage.IsZero() {
+		return
+	}
+
+	caller, _ := CallerFromContext(ctx)
+	cost := r.prices.Cost(usage)
+
+	r.logger.Debug("recording usage",
+		zap.String("user", caller.UserID),
+		zap.String("cluster", caller.ClusterID),
+		zap.String("provider", usage.Provider),
+		zap.String("model", usage.Model),
+		zap.Int64("prompt_tokens", usage.PromptTokens),
+		zap.Int64("completion_tokens", usage.CompletionTokens),
+		zap.Int64("cached_tokens", usage.CachedTokens),
+		zap.Float64("cost", cost),
+	)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.add(usageKey{caller: caller, provider: usage.Provider, model: usage.Model}, &pb.AiUsage{
+		PromptTokens:     usage.PromptTokens,
+		CompletionTokens: usage.CompletionTokens,
+		CachedTokens:     usage.CachedTokens,
+		Cost:             cost,
+		Requests:         1,
+	})
+}
+
+// Start flushes aggregated usage every interval until the context is cancelled,
+// then performs a final flush.
+func (r *UsageReporter) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.flush(ctx)
+		case <-ctx.Done():
+			r.flush(context.Background())
+			return
+		}
+	}
+}
+
+func (r *UsageReporter) flush(ctx context.Context) {
+	r.mu.Lock()
+	pending := r.usage
+	r.usage = make(map[usageKey]*pb.AiUsage)
+	r.mu.Unlock()
+
+	if len(pending) == 0 {
+		return
+	}
+
+	usage := make([]*pb.AiUsage, 0, len(pending))
+	for key, u := range pending {
+		u.Provider = key.provider
+		u.Model = key.model
+		if key.caller.UserID != "" {
+			u.UserId = lo.ToPtr(key.caller.UserID)
+		}
+		if key.caller.ClusterID != "" {
+			u.ClusterId = lo.ToPtr(key.caller.ClusterID)
+		}
+		usage = append(usage, u)
+	}
+
+	if err := r.client.MeterAiUsage(ctx, usage); err != nil {
+		r.logger.Info("failed to meter AI usage, requeueing", zap.Int("records", len(usage)), zap.Error(err))
+		r.mu.Lock()
+		for key, u := range pending {
+			r.add(key, u)
+		}
+		r.mu.Unlock()
+		return
+	}
+
+	r.logger.Debug("flushed AI usage", zap.Int("records", len(usage)))
+}
+
+// add merges usage into the aggregate for the key. The caller must hold r.mu.
+func (r *UsageReporter) add(key usageKey, usage *pb.AiUsage) {
+	existing, ok := r.usage[key]
+	if !ok {
+		r.usage[key] = usage
+		return
+	}
+
+	existing.PromptTokens += usage.PromptTokens
+	existing.CompletionTokens += usage.CompletionTokens
+	existing.CachedTokens += usage.CachedTokens
+	existing.Cost += usage.Cost
+	existing.Requests += usage.Requests
+}
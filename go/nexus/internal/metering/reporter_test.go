@@ -0,0 +1,141 @@
+package metering
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pluralsh/console/go/nexus/internal/config"
+	"github.com/pluralsh/console/go/nexus/internal/log"
+	pb "github.com/pluralsh/console/go/nexus/internal/proto"
+)
+
+func init() {
+	// Initialize logger for tests
+	_ = log.Init("info")
+}
+
+type fakeClient struct {
+	mu        sync.Mutex
+	calls     [][]*pb.AiUsage
+	failFirst bool
+}
+
+func (f *fakeClient) MeterAiUsage(_ context.Context, usage []*pb.AiUsage) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls = append(f.calls, usage)
+	if f.failFirst {
+		f.failFirst = false
+		return errors.New("boom")
+	}
+	return nil
+}
+
+func (f *fakeClient) Calls() [][]*pb.AiUsage {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out := make([][]*pb.AiUsage, len(f.calls))
+	copy(out, f.calls)
+	return out
+}
+
+func newTestReporter(client Client) *UsageReporter {
+	return NewUsageReporter(client, time.Second, NewPriceTable(map[string]config.ModelPrice{
+		"test-model": {Input: 1, Output: 2},
+	}))
+}
+
+func TestFlushAggregatesPerCaller(t *testing.T) {
+	client := &fakeClient{}
+	reporter := newTestReporter(client)
+
+	user := WithCaller(context.Background(), Caller{UserID: "user-1"})
+	cluster := WithCaller(context.Background(), Caller{ClusterID: "cluster-1"})
+	usage := Usage{Provider: "openai", Model: "test-model", PromptTokens: 1_000_000, CompletionTokens: 500_000}
+
+	reporter.Record(user, usage)
+	reporter.Record(user, usage)
+	reporter.Record(cluster, usage)
+	reporter.Record(cluster, Usage{Provider: "openai", Model: "test-model"})
+	reporter.flush(context.Background())
+
+	calls := client.Calls()
+	require.Len(t, calls, 1)
+	require.Len(t, calls[0], 2)
+
+	byCaller := make(map[string]*pb.AiUsage)
+	for _, u := range calls[0] {
+		byCaller[u.GetUserId()+u.GetClusterId()] = u
+	}
+
+	require.Contains(t, byCaller, "user-1")
+	assert.Nil(t, byCaller["user-1"].ClusterId)
+	assert.Equal(t, "openai", byCaller["user-1"].Provider)
+	assert.Equal(t, "test-model", byCaller["user-1"].Model)
+	assert.Equal(t, int64(2_000_000), byCaller["user-1"].PromptTokens)
+	assert.Equal(t, int64(1_000_000), byCaller["user-1"].CompletionTokens)
+	assert.Equal(t, int64(2), byCaller["user-1"].Requests)
+	assert.InDelta(t, 4.0, byCaller["user-1"].Cost, 1e-9)
+
+	require.Contains(t, byCaller, "cluster-1")
+	assert.Nil(t, byCaller["cluster-1"].UserId)
+	assert.Equal(t, int64(1), byCaller["cluster-1"].Requests)
+}
+
+func TestFlushRequeuesOnFailure(t *testing.T) {
+	client := &fakeClient{failFirst: true}
+	reporter := newTestReporter(client)
+
+	ctx := WithCaller(context.Background(), Caller{UserID: "user-1"})
+	reporter.Record(ctx, Usage{Provider: "openai", Model: "test-model", PromptTokens: 10})
+	reporter.flush(context.Background())
+	reporter.Record(ctx, Usage{Provider: "openai", Model: "test-model", PromptTokens: 5})
+	reporter.flush(context.Background())
+
+	calls := client.Calls()
+	require.Len(t, calls, 2)
+	require.Len(t, calls[1], 1)
+	assert.Equal(t, int64(15), calls[1][0].PromptTokens)
+	assert.Equal(t, int64(2), calls[1][0].Requests)
+}
+
+func TestFlushSkipsEmptyUsage(t *testing.T) {
+	client := &fakeClient{}
+	reporter := newTestReporter(client)
+
+	reporter.flush(context.Background())
+	assert.Empty(t, client.Calls())
+}
+
+func TestRecordOnNilReporter(t *testing.T) {
+	var reporter *UsageReporter
+	reporter.Record(context.Background(), Usage{Model: "test-model", PromptTokens: 1})
+}
+
+func TestStartFlushesOnShutdown(t *testing.T) {
+	client := &fakeClient{}
+	reporter := NewUsageReporter(client, time.Hour, NewPriceTable(nil))
+	reporter.Record(context.Background(), Usage{Provider: "openai", Model: "gpt-5", PromptTokens: 1})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		reporter.Start(ctx)
+		close(done)
+	}()
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("reporter did not stop")
+	}
+	assert.Len(t, client.Calls(), 1)
+}
@@ -8,11 +8,13 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/pluralsh/console/go/nexus/internal/log"
+	"github.com/pluralsh/console/go/nexus/internal/metering"
+	pb "github.com/pluralsh/console/go/nexus/internal/proto"
 )
 
 // ConsoleAuthenticator is an interface for authenticating tokens with Console
 type ConsoleAuthenticator interface {
-	ProxyAuthentication(ctx context.Context, token string) (bool, error)
+	ProxyAuthentication(ctx context.Context, token string) (*pb.ProxyAuthenticationResponse, error)
 }
 
 // Auth creates an authentication middleware that validates tokens with Console
@@ -21,6 +23,7 @@ type ConsoleAuthenticator interface {
 // FR-3.3: Return 403 for invalid tokens
 // FR-3.4: Return 401 for missing tokens
 // FR-3.5: No caching - validate on every request
+// The authenticated caller identity is stored in the request context for usage metering.
 func Auth(authenticator ConsoleAuthenticator) func(http.Handler) http.Handler {
 	logger := log.Logger().With(zap.String("middleware", "auth"))
 
@@ -46,7 +49,7 @@ func Auth(authenticator ConsoleAuthenticator) func(http.Handler) http.Handler {
 				return
 			}
 
-			authenticated, err := authenticator.ProxyAuthentication(r.Context(), token)
+			resp, err := authenticator.ProxyAuthentication(r.Context(), token)
 			if err != nil {
 				logger.Error("authentication check failed",
 					zap.Error(err),
@@ -58,7 +61,7 @@ func Auth(authenticator ConsoleAuthenticator) func(http.Handler) http.Handler {
 				return
 			}
 
-			if !authenticated {
+			if !resp.GetAuthenticated() {
 				logger.Error("authentication failed - invalid token",
 					zap.String("path", r.URL.Path),
 					zap.String("method", r.Method),
@@ -73,7 +76,11 @@ func Auth(authenticator ConsoleAuthenticator) func(http.Handler) http.Handler {
 			)
 
 			// Continue to next handler with authenticated context
-			next.ServeHTTP(w, r.WithContext(r.Context()))
+			ctx := metering.WithCaller(r.Context(), metering.Caller{
+				UserID:    resp.GetUserId(),
+				ClusterID: resp.GetClusterId(),
+			})
+			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
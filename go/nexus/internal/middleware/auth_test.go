@@ -9,18 +9,30 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/pluralsh/console/go/nexus/internal/metering"
+	pb "github.com/pluralsh/console/go/nexus/internal/proto"
 )
 
 // mockAuthenticator is a mock implementation of ConsoleAuthenticator for testing
 type mockAuthenticator struct {
 	authenticated bool
+	userID        string
 	err           error
 	calledWith    string // Track what token was passed
 }
 
-func (m *mockAuthenticator) ProxyAuthentication(_ context.Context, token string) (bool, error) {
+func (m *mockAuthenticator) ProxyAuthentication(_ context.Context, token string) (*pb.ProxyAuthenticationResponse, error) {
 	m.calledWith = token
-	return m.authenticated, m.err
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	resp := &pb.ProxyAuthenticationResponse{Authenticated: m.authenticated}
+	if m.userID != "" {
+		resp.UserId = &m.userID
+	}
+	return resp, nil
 }
 
 // TestAuth_MissingToken tests FR-3.4: Return 401 for missing tokens
@@ -65,6 +77,29 @@ func TestAuth_BearerToken(t *testing.T) {
 	assert.Equal(t, "test-bearer-token", authenticator.calledWith)
 }
 
+// TestAuth_StoresCaller tests that the authenticated caller is available to downstream handlers
+func TestAuth_StoresCaller(t *testing.T) {
+	authenticator := &mockAuthenticator{authenticated: true, userID: "user-1"}
+	middleware := Auth(authenticator)
+
+	var caller metering.Caller
+	var found bool
+	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		caller, found = metering.CallerFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest("GET", "/v1/chat/completions", nil)
+	req.Header.Set("Authorization", "Bearer test-bearer-token")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, found, "caller should be stored in the request context")
+	assert.Equal(t, metering.Caller{UserID: "user-1"}, caller)
+}
+
 // TestAuth_InvalidToken tests FR-3.3: Return 403 for invalid tokens
 func TestAuth_InvalidToken(t *testing.T) {
 	authenticator := &mockAuthenticator{authenticated: false}
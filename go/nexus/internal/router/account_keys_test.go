@@ -23,8 +23,12 @@ func (m *mockConsoleClient) GetAiConfig(_ context.Context) (*pb.AiConfig, error)
 	return m.cfg, nil
 }
 
-func (m *mockConsoleClient) ProxyAuthentication(_ context.Context, _ string) (bool, error) {
-	return true, nil
+func (m *mockConsoleClient) ProxyAuthentication(_ context.Context, _ string) (*pb.ProxyAuthenticationResponse, error) {
+	return &pb.ProxyAuthenticationResponse{Authenticated: true}, nil
+}
+
+func (m *mockConsoleClient) MeterAiUsage(_ context.Context, _ []*pb.AiUsage) error {
+	return nil
 }
 
 func (m *mockConsoleClient) IsConnected() bool { return true }
@@ -4,7 +4,10 @@ import (
 	bifrostcore "github.com/maximhq/bifrost/core"
 	"github.com/maximhq/bifrost/core/providers/anthropic"
 	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/pluralsh/console/go/nexus/internal/cache"
+	"github.com/pluralsh/console/go/nexus/internal/guardrails"
 	"github.com/pluralsh/console/go/nexus/internal/log"
+	"github.com/pluralsh/console/go/nexus/internal/metering"
 	"go.uber.org/zap"
 )
 
@@ -27,11 +30,14 @@ func (in *AnthropicRouter) init() Router {
 	return in
 }
 
-func NewAnthropicRouter(client *bifrostcore.Bifrost, resolver *EmbeddingsResolver) Router {
+func NewAnthropicRouter(client *bifrostcore.Bifrost, resolver *EmbeddingsResolver, meter *metering.UsageReporter, guard *guardrails.Guard, responseCache *cache.Cache) Router {
 	return (&AnthropicRouter{
 		GenericRouter: &GenericRouter{
 			client:   client,
 			resolver: resolver,
+			meter:    meter,
+			guard:    guard,
+			cache:    responseCache,
 		},
 	}).init()
 }
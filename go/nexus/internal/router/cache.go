@@ -0,0 +1,291 @@
+package router
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/pluralsh/console/go/nexus/internal/cache"
+	"github.com/pluralsh/console/go/nexus/internal/metering"
+	"go.uber.org/zap"
+)
+
+// cacheStatusHeader reports whether a response was served from the cache
+const cacheStatusHeader = "X-Nexus-Cache"
+
+// cacheLookup serves and stores the cached response of a single request
+type cacheLookup struct {
+	router *GenericRouter
+	key    string
+
+	// namespace scopes semantic lookups, it is empty when semantic caching is disabled
+	namespace string
+	vector    *cache.Vector
+	recorder  *cacheRecorder
+}
+
+// newCacheLookup returns nil when caching is disabled or the request cannot be cached.
+// It must be created before guardrails redact the request, so requests that only
+// differ in redacted values never share a response.
+func (in *GenericRouter) newCacheLookup(r *http.Request, config RouteConfig, req *schemas.BifrostRequest, streaming bool) *cacheLookup {
+	if in.cache == nil || bypassCache(r) {
+		return nil
+	}
+
+	body, err := cacheBody(req)
+	if err != nil || body == nil {
+		if err != nil {
+			in.logger.Debug("failed to normalize request for the response cache", zap.Error(err))
+		}
+		return nil
+	}
+
+	lookup := &cacheLookup{router: in, key: cacheKey(config.Path, streaming, body)}
+	if model := semanticModel(req); model != "" && in.cache.Semantic() {
+		// Similar prompts of different callers or models never share a response
+		caller, _ := metering.CallerFromContext(r.Context())
+		lookup.namespace = cacheKey(config.Path, streaming, []byte(strings.Join([]string{caller.UserID, caller.ClusterID, model}, "\n")))
+	}
+
+	return lookup
+}
+
+// serve replays a cached response, trying an exact match first and a similar prompt second.
+// Requests redacted by guardrails skip the semantic lookup: their redacted prompts look
+// alike while the responses restored from them contain the values of the first caller.
+func (in *cacheLookup) serve(w http.ResponseWriter, r *http.Request, ctx *schemas.BifrostContext, req *schemas.BifrostRequest) bool {
+	if in == nil {
+		return false
+	}
+
+	if entry, ok := in.router.cache.Get(in.key); ok {
+		replayCachedResponse(w, entry, "hit")
+		return true
+	}
+
+	if in.namespace == "" || guardrailsSession(ctx) != nil {
+		return false
+	}
+
+	prompt := semanticPrompt(req)
+	if prompt == "" {
+		return false
+	}
+
+	embedding, err := in.router.embed(r.Context(), prompt)
+	if err != nil {
+		in.router.logger.Warn("failed to embed prompt for the semantic cache", zap.Error(err))
+		return false
+	}
+
+	in.vector = &cache.Vector{Namespace: in.namespace, Embedding: embedding}
+	if entry, ok := in.router.cache.Nearest(in.vector); ok {
+		replayCachedResponse(w, entry, "semantic-hit")
+		return true
+	}
+
+	return false
+}
+
+// record wraps w to capture the response written by the route
+func (in *cacheLookup) record(w http.ResponseWriter) http.ResponseWriter {
+	if in == nil {
+		return w
+	}
+
+	w.Header().Set(cacheStatusHeader, "miss")
+	in.recorder = &cacheRecorder{ResponseWriter: w}
+	return in.recorder
+}
+
+// store caches the recorded response if it was completed successfully
+func (in *cacheLookup) store(r *http.Request) {
+	if in == nil || in.recorder == nil || r.Context().Err() != nil {
+		return
+	}
+
+	if entry, ok := in.recorder.entry(); ok {
+		in.router.cache.Set(in.key, entry, in.vector)
+	}
+}
+
+// embed creates the embedding of a prompt with the default embedding provider
+func (in *GenericRouter) embed(ctx context.Context, prompt string) ([]float64, error) {
+	config, err := in.resolver.toEmbeddingConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bifrostCtx, cancel := schemas.NewBifrostContextWithCancel(ctx)
+	defer cancel()
+
+	resp, bifrostErr := in.client.EmbeddingRequest(bifrostCtx, &schemas.BifrostEmbeddingRequest{
+		Provider: config.DefaultProvider,
+		Model:    config.DefaultModel,
+		Input:    &schemas.EmbeddingInput{Text: &prompt},
+	})
+	if bifrostErr != nil {
+		if bifrostErr.Error != nil {
+			return nil, fmt.Errorf("embedding request failed: %s", bifrostErr.Error.Message)
+		}
+		return nil, errors.New("embedding request failed")
+	}
+
+	in.meter.Record(bifrostCtx, llmUsage(resp.ExtraFields, resp.Model, resp.Usage))
+	if len(resp.Data) == 0 || len(resp.Data[0].Embedding.EmbeddingArray) == 0 {
+		return nil, errors.New("embedding response is empty")
+	}
+
+	return resp.Data[0].Embedding.EmbeddingArray, nil
+}
+
+// cacheBody normalizes cacheable requests, returning nil for requests that are never cached
+func cacheBody(req *schemas.BifrostRequest) ([]byte, error) {
+	switch {
+	case req.ChatRequest != nil:
+		return json.Marshal(req.ChatRequest)
+	case req.ResponsesRequest != nil:
+		return json.Marshal(req.ResponsesRequest)
+	case req.EmbeddingRequest != nil:
+		return json.Marshal(req.EmbeddingRequest)
+	}
+
+	return nil, nil
+}
+
+func cacheKey(path string, streaming bool, body []byte) string {
+	hash := sha256.New()
+	_, _ = fmt.Fprintf(hash, "%s\n%t\n", path, streaming)
+	_, _ = hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// bypassCache honors the no-cache and no-store request directives
+func bypassCache(r *http.Request) bool {
+	directives := strings.ToLower(r.Header.Get("Cache-Control"))
+	return strings.Contains(directives, "no-cache") || strings.Contains(directives, "no-store")
+}
+
+// semanticModel returns the model of requests that support semantic lookups
+func semanticModel(req *schemas.BifrostRequest) string {
+	switch {
+	case req.ChatRequest != nil:
+		return fmt.Sprintf("%s/%s", req.ChatRequest.Provider, req.ChatRequest.Model)
+	case req.ResponsesRequest != nil:
+		return fmt.Sprintf("%s/%s", req.ResponsesRequest.Provider, req.ResponsesRequest.Model)
+	}
+
+	return ""
+}
+
+// semanticPrompt flattens the conversation of a chat or responses request
+func semanticPrompt(req *schemas.BifrostRequest) string {
+	var b strings.Builder
+	write := func(role string, text *string) {
+		if text != nil && *text != "" {
+			_, _ = fmt.Fprintf(&b, "%s: %s\n", role, *text)
+		}
+	}
+
+	switch {
+	case req.ChatRequest != nil:
+		for _, message := range req.ChatRequest.Input {
+			if message.Content == nil {
+				continue
+			}
+			write(string(message.Role), message.Content.ContentStr)
+			for _, block := range message.Content.ContentBlocks {
+				write(string(message.Role), block.Text)
+			}
+		}
+	case req.ResponsesRequest != nil:
+		if req.ResponsesRequest.Params != nil {
+			write("system", req.ResponsesRequest.Params.Instructions)
+		}
+		for _, message := range req.ResponsesRequest.Input {
+			if message.Content == nil {
+				continue
+			}
+			role := ""
+			if message.Role != nil {
+				role = string(*message.Role)
+			}
+			write(role, message.Content.ContentStr)
+			for _, block := range message.Content.ContentBlocks {
+				write(role, block.Text)
+			}
+		}
+	}
+
+	return b.String()
+}
+
+func replayCachedResponse(w http.ResponseWriter, entry *cache.Entry, status string) {
+	for key, values := range entry.Header {
+		w.Header()[key] = slices.Clone(values)
+	}
+	w.Header().Set(cacheStatusHeader, status)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(entry.Body)
+}
+
+// cacheRecorder captures the response of a route while writing it to the client
+type cacheRecorder struct {
+	http.ResponseWriter
+
+	status  int
+	body    bytes.Buffer
+	discard bool
+}
+
+func (in *cacheRecorder) WriteHeader(status int) {
+	if in.status == 0 {
+		in.status = status
+	}
+	in.ResponseWriter.WriteHeader(status)
+}
+
+func (in *cacheRecorder) Write(p []byte) (int, error) {
+	if in.status == 0 {
+		in.status = http.StatusOK
+	}
+
+	n, err := in.ResponseWriter.Write(p)
+	in.body.Write(p[:n])
+	if err != nil {
+		in.discard = true
+	}
+	return n, err
+}
+
+func (in *cacheRecorder) Flush() {
+	if flusher, ok := in.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (in *cacheRecorder) entry() (*cache.Entry, bool) {
+	if in.discard || in.status != http.StatusOK || in.body.Len() == 0 {
+		return nil, false
+	}
+
+	header := in.Header().Clone()
+	header.Del(cacheStatusHeader)
+	return &cache.Entry{Header: header, Body: bytes.Clone(in.body.Bytes())}, true
+}
+
+// discardCachedResponse prevents caching a response that reported an error after the
+// status was written, e.g. an error event in the middle of a stream
+func discardCachedResponse(w http.ResponseWriter) {
+	if recorder, ok := w.(*cacheRecorder); ok {
+		recorder.discard = true
+	}
+}
@@ -0,0 +1,143 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pluralsh/console/go/nexus/internal/cache"
+	"github.com/pluralsh/console/go/nexus/internal/guardrails"
+	pb "github.com/pluralsh/console/go/nexus/internal/proto"
+)
+
+const sseBody = "data: {\"choices\":[{\"delta\":{\"content\":\"hi\"}}]}\n\ndata: [DONE]\n\n"
+
+func cacheRequest(model, prompt string) *schemas.BifrostRequest {
+	return &schemas.BifrostRequest{ChatRequest: &schemas.BifrostChatRequest{
+		Provider: schemas.OpenAI,
+		Model:    model,
+		Input: []schemas.ChatMessage{{
+			Role:    schemas.ChatMessageRoleUser,
+			Content: &schemas.ChatMessageContent{ContentStr: lo.ToPtr(prompt)},
+		}},
+	}}
+}
+
+// serveCached runs a request through the cache the same way createHandler does
+func serveCached(t *testing.T, router *GenericRouter, r *http.Request, req *schemas.BifrostRequest, write func(http.ResponseWriter)) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	lookup := router.newCacheLookup(r, RouteConfig{Path: "/v1/chat/completions"}, req, true)
+
+	ctx, cancel := schemas.NewBifrostContextWithCancel(r.Context())
+	defer cancel()
+	require.Nil(t, router.applyGuardrails(ctx, req))
+
+	if lookup.serve(w, r, ctx, req) {
+		return w
+	}
+
+	write(lookup.record(w))
+	lookup.store(r)
+	return w
+}
+
+func TestResponseCache_ReplaysStream(t *testing.T) {
+	router := &GenericRouter{cache: cache.New(time.Minute, 10, 0)}
+	calls := 0
+	write := func(w http.ResponseWriter) {
+		calls++
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte(sseBody))
+		w.(http.Flusher).Flush()
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+	first := serveCached(t, router, r, cacheRequest("gpt-4o", "hello"), write)
+	assert.Equal(t, "miss", first.Header().Get(cacheStatusHeader))
+
+	second := serveCached(t, router, r, cacheRequest("gpt-4o", "hello"), write)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, http.StatusOK, second.Code)
+	assert.Equal(t, "hit", second.Header().Get(cacheStatusHeader))
+	assert.Equal(t, "text/event-stream", second.Header().Get("Content-Type"))
+	assert.Equal(t, sseBody, second.Body.String())
+
+	serveCached(t, router, r, cacheRequest("gpt-4o-mini", "hello"), write)
+	assert.Equal(t, 2, calls, "different models never share a response")
+}
+
+func TestResponseCache_SkipsFailedResponses(t *testing.T) {
+	router := &GenericRouter{cache: cache.New(time.Minute, 10, 0)}
+	r := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+
+	serveCached(t, router, r, cacheRequest("gpt-4o", "error"), func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	serveCached(t, router, r, cacheRequest("gpt-4o", "mid-stream error"), func(w http.ResponseWriter) {
+		_, _ = w.Write([]byte(sseBody))
+		discardCachedResponse(w)
+	})
+	assert.Equal(t, 0, router.cache.Len())
+}
+
+func TestResponseCache_SkipsSemanticLookupOfRedactedPrompts(t *testing.T) {
+	// the router has no embedding resolver, a semantic lookup would fail the test
+	router := &GenericRouter{
+		cache: cache.New(time.Minute, 10, 0.9),
+		guard: guardrails.New(&mockConsoleClient{cfg: &pb.AiConfig{
+			Guardrails: &pb.GuardrailsConfig{Enabled: true, RedactPii: true},
+		}}),
+	}
+	calls := 0
+	write := func(w http.ResponseWriter) {
+		calls++
+		_, _ = w.Write([]byte(sseBody))
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+	serveCached(t, router, r, cacheRequest("gpt-4o", "reply to jane@example.com"), write)
+	second := serveCached(t, router, r, cacheRequest("gpt-4o", "reply to john@example.com"), write)
+	assert.Equal(t, 2, calls, "redacted prompts never share a response")
+	assert.Equal(t, "miss", second.Header().Get(cacheStatusHeader))
+
+	third := serveCached(t, router, r, cacheRequest("gpt-4o", "reply to john@example.com"), write)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, "hit", third.Header().Get(cacheStatusHeader), "exact matches are keyed before redaction")
+}
+
+func TestResponseCache_Bypass(t *testing.T) {
+	router := &GenericRouter{cache: cache.New(time.Minute, 10, 0)}
+	r := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+	r.Header.Set("Cache-Control", "no-cache")
+
+	assert.Nil(t, router.newCacheLookup(r, RouteConfig{}, cacheRequest("gpt-4o", "hello"), false))
+	assert.Nil(t, (&GenericRouter{}).newCacheLookup(httptest.NewRequest(http.MethodPost, "/", nil), RouteConfig{}, cacheRequest("gpt-4o", "hello"), false))
+}
+
+func TestCacheKey(t *testing.T) {
+	body := []byte(`{"model":"gpt-4o"}`)
+	key := cacheKey("/v1/chat/completions", false, body)
+
+	assert.Equal(t, key, cacheKey("/v1/chat/completions", false, body))
+	assert.NotEqual(t, key, cacheKey("/v1/chat/completions", true, body))
+	assert.NotEqual(t, key, cacheKey("/v1/responses", false, body))
+	assert.NotEqual(t, key, cacheKey("/v1/chat/completions", false, []byte(`{"model":"gpt-4o-mini"}`)))
+}
+
+func TestSemanticPrompt(t *testing.T) {
+	req := cacheRequest("gpt-4o", "summarize the error")
+	req.ChatRequest.Input = append([]schemas.ChatMessage{{
+		Role:    schemas.ChatMessageRoleSystem,
+		Content: &schemas.ChatMessageContent{ContentStr: lo.ToPtr("you are an SRE")},
+	}}, req.ChatRequest.Input...)
+
+	assert.Equal(t, "system: you are an SRE\nuser: summarize the error\n", semanticPrompt(req))
+	assert.Equal(t, "openai/gpt-4o", semanticModel(req))
+	assert.Empty(t, semanticModel(&schemas.BifrostRequest{EmbeddingRequest: &schemas.BifrostEmbeddingRequest{}}))
+}
@@ -8,7 +8,10 @@ import (
 	bifrostcore "github.com/maximhq/bifrost/core"
 	"github.com/maximhq/bifrost/core/providers/gemini"
 	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/pluralsh/console/go/nexus/internal/cache"
+	"github.com/pluralsh/console/go/nexus/internal/guardrails"
 	"github.com/pluralsh/console/go/nexus/internal/log"
+	"github.com/pluralsh/console/go/nexus/internal/metering"
 	"go.uber.org/zap"
 )
 
@@ -47,11 +50,14 @@ func (in *GeminiRouter) init() Router {
 	return in
 }
 
-func NewGeminiRouter(client *bifrostcore.Bifrost, resolver *EmbeddingsResolver) Router {
+func NewGeminiRouter(client *bifrostcore.Bifrost, resolver *EmbeddingsResolver, meter *metering.UsageReporter, guard *guardrails.Guard, responseCache *cache.Cache) Router {
 	return (&GeminiRouter{
 		GenericRouter: &GenericRouter{
 			client:   client,
 			resolver: resolver,
+			meter:    meter,
+			guard:    guard,
+			cache:    responseCache,
 		},
 	}).init()
 }
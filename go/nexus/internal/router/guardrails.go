@@ -0,0 +1,46 @@
+package router
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/pluralsh/console/go/nexus/internal/guardrails"
+	"github.com/samber/lo"
+)
+
+const nexusGuardrailsSessionKey schemas.BifrostContextKey = "nexus-guardrails-session"
+
+// applyGuardrails redacts the request in place and keeps the session needed to restore the response.
+// Violations are converted to bad request errors, so every route reports them in its native format.
+func (in *GenericRouter) applyGuardrails(ctx *schemas.BifrostContext, req *schemas.BifrostRequest) *schemas.BifrostError {
+	session, err := in.guard.Apply(ctx, req)
+	if err == nil {
+		if session != nil {
+			ctx.SetValue(nexusGuardrailsSessionKey, session)
+		}
+		return nil
+	}
+
+	var violation *guardrails.Violation
+	if !errors.As(err, &violation) {
+		// Fail closed, prompts must not reach a provider when the policy cannot be enforced
+		return in.toBifrostError(err, "failed to apply guardrails")
+	}
+
+	return &schemas.BifrostError{
+		IsBifrostError: false,
+		StatusCode:     lo.ToPtr(http.StatusBadRequest),
+		Error: &schemas.ErrorField{
+			Type:    lo.ToPtr("invalid_request_error"),
+			Code:    lo.ToPtr("guardrail_violation"),
+			Message: violation.Message,
+		},
+	}
+}
+
+// guardrailsSession returns the session of the current request. Its methods are no-ops when nil.
+func guardrailsSession(ctx *schemas.BifrostContext) *guardrails.Session {
+	session, _ := ctx.Value(nexusGuardrailsSessionKey).(*guardrails.Session)
+	return session
+}
@@ -0,0 +1,55 @@
+package router
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pluralsh/console/go/nexus/internal/guardrails"
+	pb "github.com/pluralsh/console/go/nexus/internal/proto"
+)
+
+func guardrailsRequest(prompt string) *schemas.BifrostRequest {
+	return &schemas.BifrostRequest{ChatRequest: &schemas.BifrostChatRequest{Input: []schemas.ChatMessage{{
+		Role:    schemas.ChatMessageRoleUser,
+		Content: &schemas.ChatMessageContent{ContentStr: lo.ToPtr(prompt)},
+	}}}}
+}
+
+func TestApplyGuardrails(t *testing.T) {
+	router := &GenericRouter{guard: guardrails.New(&mockConsoleClient{cfg: &pb.AiConfig{
+		Guardrails: &pb.GuardrailsConfig{
+			Enabled:       true,
+			RedactPii:     true,
+			BlockedTopics: []*pb.BlockedTopic{{Name: "exfiltration", Patterns: []string{"dump the database"}}},
+		},
+	}})}
+
+	t.Run("violation", func(t *testing.T) {
+		ctx, _ := schemas.NewBifrostContextWithCancel(context.Background())
+		bifrostErr := router.applyGuardrails(ctx, guardrailsRequest("please Dump the database"))
+		require.NotNil(t, bifrostErr)
+		assert.Equal(t, http.StatusBadRequest, *bifrostErr.StatusCode)
+		assert.Equal(t, "guardrail_violation", *bifrostErr.Error.Code)
+		assert.Equal(t, "invalid_request_error", *bifrostErr.Error.Type)
+	})
+
+	t.Run("redaction", func(t *testing.T) {
+		ctx, _ := schemas.NewBifrostContextWithCancel(context.Background())
+		req := guardrailsRequest("reply to jane@example.com")
+		require.Nil(t, router.applyGuardrails(ctx, req))
+		assert.Equal(t, "reply to [[EMAIL_1]]", *req.ChatRequest.Input[0].Content.ContentStr)
+		assert.Equal(t, "jane@example.com", guardrailsSession(ctx).Restore("[[EMAIL_1]]"))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		ctx, _ := schemas.NewBifrostContextWithCancel(context.Background())
+		require.Nil(t, (&GenericRouter{}).applyGuardrails(ctx, guardrailsRequest("reply to jane@example.com")))
+		assert.Nil(t, guardrailsSession(ctx))
+	})
+}
@@ -9,8 +9,11 @@ import (
 	"github.com/go-chi/chi/v5"
 	bifrostcore "github.com/maximhq/bifrost/core"
 	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/pluralsh/console/go/nexus/internal/cache"
 	"github.com/pluralsh/console/go/nexus/internal/console"
+	"github.com/pluralsh/console/go/nexus/internal/guardrails"
 	"github.com/pluralsh/console/go/nexus/internal/log"
+	"github.com/pluralsh/console/go/nexus/internal/metering"
 	"github.com/pluralsh/console/go/nexus/internal/tokenexchange"
 	"go.uber.org/zap"
 )
@@ -18,13 +21,19 @@ import (
 // Handler manages AI requests using the Bifrost Core SDK
 type Handler struct {
 	consoleClient console.Client
+	meter         *metering.UsageReporter
+	guard         *guardrails.Guard
+	cache         *cache.Cache
 	bifrostClient *bifrostcore.Bifrost
 	logger        *zap.Logger
 	router        chi.Router
 }
 
-// NewHandler creates a new Bifrost handler using the Bifrost Core SDK
-func NewHandler(consoleClient console.Client) (*Handler, error) {
+// NewHandler creates a new Bifrost handler using the Bifrost Core SDK.
+// Token usage is reported to meter, which may be nil when metering is disabled.
+// Prompts are checked against the guardrails policy read from Console. Responses are
+// served from responseCache when set, which is nil when caching is disabled.
+func NewHandler(consoleClient console.Client, meter *metering.UsageReporter, responseCache *cache.Cache) (*Handler, error) {
 	logger := log.Logger().With(zap.String("component", "bifrost-handler"))
 	// Dedicated client for OAuth token exchange so hung identity servers do not tie up the default transport.
 	tokenHTTP := &http.Client{Timeout: 90 * time.Second}
@@ -42,6 +51,9 @@ func NewHandler(consoleClient console.Client) (*Handler, error) {
 
 	h := &Handler{
 		consoleClient: consoleClient,
+		meter:         meter,
+		guard:         guardrails.New(consoleClient),
+		cache:         responseCache,
 		bifrostClient: bifrostClient,
 		logger:        logger,
 		router:        chi.NewRouter(),
@@ -53,9 +65,9 @@ func NewHandler(consoleClient console.Client) (*Handler, error) {
 }
 
 func (h *Handler) registerRoutes(account NexusAccount) {
-	NewOpenAIRouter(h.bifrostClient, NewEmbeddingsResolver(account), h.consoleClient).RegisterRoutes(h.router)
-	NewAnthropicRouter(h.bifrostClient, NewEmbeddingsResolver(account)).RegisterRoutes(h.router)
-	NewGeminiRouter(h.bifrostClient, NewEmbeddingsResolver(account)).RegisterRoutes(h.router)
+	NewOpenAIRouter(h.bifrostClient, NewEmbeddingsResolver(account), h.consoleClient, h.meter, h.guard, h.cache).RegisterRoutes(h.router)
+	NewAnthropicRouter(h.bifrostClient, NewEmbeddingsResolver(account), h.meter, h.guard, h.cache).RegisterRoutes(h.router)
+	NewGeminiRouter(h.bifrostClient, NewEmbeddingsResolver(account), h.meter, h.guard, h.cache).RegisterRoutes(h.router)
 }
 
 // ServeHTTP implements the http.Handler interface
@@ -0,0 +1,78 @@
+package router
+
+import (
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/pluralsh/console/go/nexus/internal/metering"
+)
+
+// llmUsage converts the usage of chat, text completion and embedding responses.
+func llmUsage(extra schemas.BifrostResponseExtraFields, model string, usage *schemas.BifrostLLMUsage) metering.Usage {
+	if usage == nil {
+		return metering.Usage{}
+	}
+
+	result := metering.Usage{
+		Provider:         string(extra.Provider),
+		Model:            usageModel(extra, model),
+		PromptTokens:     int64(usage.PromptTokens),
+		CompletionTokens: int64(usage.CompletionTokens),
+	}
+	if usage.PromptTokensDetails != nil {
+		result.CachedTokens = int64(usage.PromptTokensDetails.CachedReadTokens)
+	}
+
+	return result
+}
+
+// responsesUsage converts the usage of responses API responses.
+func responsesUsage(extra schemas.BifrostResponseExtraFields, model string, usage *schemas.ResponsesResponseUsage) metering.Usage {
+	if usage == nil {
+		return metering.Usage{}
+	}
+
+	result := metering.Usage{
+		Provider:         string(extra.Provider),
+		Model:            usageModel(extra, model),
+		PromptTokens:     int64(usage.InputTokens),
+		CompletionTokens: int64(usage.OutputTokens),
+	}
+	if usage.InputTokensDetails != nil {
+		result.CachedTokens = int64(usage.InputTokensDetails.CachedReadTokens)
+	}
+
+	return result
+}
+
+// streamChunkUsage returns the usage carried by a stream chunk, if any. Providers
+// report cumulative usage, so the last non-empty value is the usage of the request.
+func streamChunkUsage(chunk *schemas.BifrostStreamChunk) metering.Usage {
+	switch {
+	case chunk.BifrostTextCompletionResponse != nil:
+		resp := chunk.BifrostTextCompletionResponse
+		return llmUsage(resp.ExtraFields, resp.Model, resp.Usage)
+	case chunk.BifrostChatResponse != nil:
+		resp := chunk.BifrostChatResponse
+		return llmUsage(resp.ExtraFields, resp.Model, resp.Usage)
+	case chunk.BifrostResponsesStreamResponse != nil && chunk.BifrostResponsesStreamResponse.Response != nil:
+		resp := chunk.BifrostResponsesStreamResponse.Response
+		extra := chunk.BifrostResponsesStreamResponse.ExtraFields
+		if extra.Provider == "" {
+			extra = resp.ExtraFields
+		}
+		return responsesUsage(extra, resp.Model, resp.Usage)
+	}
+
+	return metering.Usage{}
+}
+
+// usageModel prefers the model that actually served the request over the requested alias.
+func usageModel(extra schemas.BifrostResponseExtraFields, model string) string {
+	switch {
+	case extra.ResolvedModelUsed != "":
+		return extra.ResolvedModelUsed
+	case extra.OriginalModelRequested != "":
+		return extra.OriginalModelRequested
+	}
+
+	return model
+}
@@ -0,0 +1,63 @@
+package router
+
+import (
+	"testing"
+
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pluralsh/console/go/nexus/internal/metering"
+)
+
+func TestLLMUsage(t *testing.T) {
+	extra := schemas.BifrostResponseExtraFields{
+		Provider:               schemas.Anthropic,
+		OriginalModelRequested: "sonnet",
+		ResolvedModelUsed:      "claude-sonnet-4-5-20250929",
+	}
+	usage := &schemas.BifrostLLMUsage{
+		PromptTokens:        120,
+		CompletionTokens:    30,
+		PromptTokensDetails: &schemas.ChatPromptTokensDetails{CachedReadTokens: 100},
+	}
+
+	require.Equal(t, metering.Usage{
+		Provider:         "anthropic",
+		Model:            "claude-sonnet-4-5-20250929",
+		PromptTokens:     120,
+		CompletionTokens: 30,
+		CachedTokens:     100,
+	}, llmUsage(extra, "sonnet", usage))
+	require.True(t, llmUsage(extra, "sonnet", nil).IsZero())
+}
+
+func TestStreamChunkUsage(t *testing.T) {
+	extra := schemas.BifrostResponseExtraFields{Provider: schemas.OpenAI}
+
+	require.True(t, streamChunkUsage(&schemas.BifrostStreamChunk{
+		BifrostChatResponse: &schemas.BifrostChatResponse{Model: "gpt-5", ExtraFields: extra},
+	}).IsZero())
+
+	require.Equal(t, metering.Usage{Provider: "openai", Model: "gpt-5", PromptTokens: 10, CompletionTokens: 5}, streamChunkUsage(&schemas.BifrostStreamChunk{
+		BifrostChatResponse: &schemas.BifrostChatResponse{
+			Model:       "gpt-5",
+			ExtraFields: extra,
+			Usage:       &schemas.BifrostLLMUsage{PromptTokens: 10, CompletionTokens: 5},
+		},
+	}))
+
+	require.Equal(t, metering.Usage{Provider: "openai", Model: "gpt-5", PromptTokens: 40, CompletionTokens: 8, CachedTokens: 32}, streamChunkUsage(&schemas.BifrostStreamChunk{
+		BifrostResponsesStreamResponse: &schemas.BifrostResponsesStreamResponse{
+			Type:        schemas.ResponsesStreamResponseTypeCompleted,
+			ExtraFields: extra,
+			Response: &schemas.BifrostResponsesResponse{
+				Model: "gpt-5",
+				Usage: &schemas.ResponsesResponseUsage{
+					InputTokens:        40,
+					OutputTokens:       8,
+					InputTokensDetails: &schemas.ResponsesResponseInputTokens{CachedReadTokens: 32},
+				},
+			},
+		},
+	}))
+}
@@ -8,8 +8,11 @@ import (
 
 	bifrostcore "github.com/maximhq/bifrost/core"
 	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/pluralsh/console/go/nexus/internal/cache"
 	"github.com/pluralsh/console/go/nexus/internal/console"
+	"github.com/pluralsh/console/go/nexus/internal/guardrails"
 	"github.com/pluralsh/console/go/nexus/internal/log"
+	"github.com/pluralsh/console/go/nexus/internal/metering"
 	pb "github.com/pluralsh/console/go/nexus/internal/proto"
 	"go.uber.org/zap"
 )
@@ -132,11 +135,14 @@ func (in *OpenAIRouter) init() Router {
 	return in
 }
 
-func NewOpenAIRouter(bifrostClient *bifrostcore.Bifrost, resolver *EmbeddingsResolver, consoleClient console.Client) Router {
+func NewOpenAIRouter(bifrostClient *bifrostcore.Bifrost, resolver *EmbeddingsResolver, consoleClient console.Client, meter *metering.UsageReporter, guard *guardrails.Guard, responseCache *cache.Cache) Router {
 	return (&OpenAIRouter{
 		GenericRouter: &GenericRouter{
 			client:   bifrostClient,
 			resolver: resolver,
+			meter:    meter,
+			guard:    guard,
+			cache:    responseCache,
 		},
 		consoleClient: consoleClient,
 	}).init()
@@ -12,6 +12,9 @@ import (
 	"github.com/go-chi/chi/v5"
 	bifrostcore "github.com/maximhq/bifrost/core"
 	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/pluralsh/console/go/nexus/internal/cache"
+	"github.com/pluralsh/console/go/nexus/internal/guardrails"
+	"github.com/pluralsh/console/go/nexus/internal/metering"
 	"go.uber.org/zap"
 )
 
@@ -120,6 +123,15 @@ type GenericRouter struct {
 
 	// resolver is an instance of EmbeddingsResolver used for resolving embedding configurations within the router.
 	resolver *EmbeddingsResolver
+
+	// meter attributes token usage to the authenticated caller. Usage is discarded when nil.
+	meter *metering.UsageReporter
+
+	// guard enforces the guardrails policy before and after provider calls. Nothing is enforced when nil.
+	guard *guardrails.Guard
+
+	// cache serves repeated requests without calling the provider. Nothing is cached when nil.
+	cache *cache.Cache
 }
 
 func (in *GenericRouter) RegisterRoutes(r chi.Router) {
@@ -231,6 +243,22 @@ func (in *GenericRouter) createHandler(config RouteConfig) http.HandlerFunc {
 		if streamingReq, ok := req.(StreamingRequest); ok {
 			isStreaming = streamingReq.IsStreamingRequested()
 		}
+
+		lookup := in.newCacheLookup(r, config, bifrostReq, isStreaming)
+
+		if bifrostErr := in.applyGuardrails(bifrostCtx, bifrostReq); bifrostErr != nil {
+			defer cancel()
+			in.sendError(w, bifrostCtx, config.ErrorConverter, bifrostErr)
+			return
+		}
+
+		if lookup.serve(w, r, bifrostCtx, bifrostReq) {
+			cancel()
+			return
+		}
+		w = lookup.record(w)
+		defer lookup.store(r)
+
 		if isStreaming {
 			in.handleStreamingRequest(w, config, bifrostReq, bifrostCtx, cancel)
 		} else {
@@ -290,6 +318,7 @@ func (in *GenericRouter) sendStreamingInitError(w http.ResponseWriter, bifrostCt
 		return
 	}
 
+	discardCachedResponse(w)
 	if bifrostErr == nil {
 		bifrostErr = in.toBifrostError(nil, "internal server error")
 	}
@@ -341,6 +370,11 @@ func (in *GenericRouter) handleStreaming(w http.ResponseWriter, ctx *schemas.Bif
 	defer cancel()
 	defer releaseChatToResponsesStreamState(ctx)
 
+	var usage metering.Usage
+	defer func() { in.meter.Record(ctx, usage) }()
+
+	session := guardrailsSession(ctx)
+
 	flusher, ok := w.(http.Flusher)
 	if !ok {
 		in.sendError(w, ctx, config.ErrorConverter, in.toBifrostError(nil, "streaming not supported"))
@@ -352,6 +386,11 @@ func (in *GenericRouter) handleStreaming(w http.ResponseWriter, ctx *schemas.Bif
 			continue
 		}
 
+		if chunkUsage := streamChunkUsage(chunk); !chunkUsage.IsZero() {
+			usage = chunkUsage
+		}
+		session.RestoreStreamChunk(chunk)
+
 		var errorResponse any
 		fallbackError := map[string]any{
 			"error": map[string]any{
@@ -361,6 +400,7 @@ func (in *GenericRouter) handleStreaming(w http.ResponseWriter, ctx *schemas.Bif
 		}
 
 		if chunk.BifrostError != nil {
+			discardCachedResponse(w)
 			switch {
 			case config.StreamConfig != nil && config.StreamConfig.ErrorConverter != nil:
 				errorResponse = config.StreamConfig.ErrorConverter(ctx, chunk.BifrostError)
@@ -545,6 +585,7 @@ func (in *GenericRouter) safeGetRequestType(chunk *schemas.BifrostStreamChunk) s
 func (in *GenericRouter) handleNonStreamingRequest(w http.ResponseWriter, config RouteConfig, _ *http.Request, bifrostReq *schemas.BifrostRequest, ctx *schemas.BifrostContext) {
 	var response any
 	var err error
+	session := guardrailsSession(ctx)
 
 	switch {
 	case bifrostReq.ListModelsRequest != nil:
@@ -575,6 +616,8 @@ func (in *GenericRouter) handleNonStreamingRequest(w http.ResponseWriter, config
 			return
 		}
 
+		in.meter.Record(ctx, llmUsage(bifrostResponse.ExtraFields, bifrostResponse.Model, bifrostResponse.Usage))
+		session.RestoreTextResponse(bifrostResponse)
 		response, err = config.TextResponseConverter(ctx, bifrostResponse)
 	case bifrostReq.ChatRequest != nil:
 		bifrostResponse, bifrostErr := in.client.ChatCompletionRequest(ctx, bifrostReq.ChatRequest)
@@ -588,6 +631,9 @@ func (in *GenericRouter) handleNonStreamingRequest(w http.ResponseWriter, config
 			return
 		}
 
+		in.meter.Record(ctx, llmUsage(bifrostResponse.ExtraFields, bifrostResponse.Model, bifrostResponse.Usage))
+		session.RestoreChatResponse(bifrostResponse)
+
 		if responsesViaChat(ctx) {
 			responsesResponse := bifrostResponse.ToBifrostResponsesResponse()
 			if responsesResponse == nil {
@@ -610,6 +656,8 @@ func (in *GenericRouter) handleNonStreamingRequest(w http.ResponseWriter, config
 			return
 		}
 
+		in.meter.Record(ctx, responsesUsage(bifrostResponse.ExtraFields, bifrostResponse.Model, bifrostResponse.Usage))
+		session.RestoreResponsesResponse(bifrostResponse)
 		response, err = config.ResponsesResponseConverter(ctx, bifrostResponse)
 	case bifrostReq.CountTokensRequest != nil:
 		bifrostResponse, bifrostErr := in.client.CountTokensRequest(ctx, bifrostReq.CountTokensRequest)
@@ -636,6 +684,7 @@ func (in *GenericRouter) handleNonStreamingRequest(w http.ResponseWriter, config
 			return
 		}
 
+		in.meter.Record(ctx, llmUsage(bifrostResponse.ExtraFields, bifrostResponse.Model, bifrostResponse.Usage))
 		response, err = config.EmbeddingResponseConverter(ctx, bifrostResponse)
 	default:
 		in.sendError(w, ctx, config.ErrorConverter, in.toBifrostError(nil, "unsupported request type"))
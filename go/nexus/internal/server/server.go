@@ -9,11 +9,14 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.uber.org/zap"
 
+	"github.com/pluralsh/console/go/nexus/internal/cache"
 	"github.com/pluralsh/console/go/nexus/internal/config"
 	"github.com/pluralsh/console/go/nexus/internal/console"
 	"github.com/pluralsh/console/go/nexus/internal/log"
+	"github.com/pluralsh/console/go/nexus/internal/metering"
 	nexusmw "github.com/pluralsh/console/go/nexus/internal/middleware"
 	"github.com/pluralsh/console/go/nexus/internal/router"
 )
@@ -25,15 +28,21 @@ type Server struct {
 	httpServer     *http.Server
 	router         chi.Router
 	consoleClient  console.Client
+	meter          *metering.UsageReporter
+	responseCache  *cache.Cache
 	bifrostHandler *router.Handler
 }
 
-// New creates a new HTTP server instance with Chi router
-func New(cfg *config.ServerConfig, consoleClient console.Client) *Server {
+// New creates a new HTTP server instance with Chi router.
+// The meter receives per-caller usage and may be nil when metering is disabled.
+// The responseCache may be nil when response caching is disabled.
+func New(cfg *config.ServerConfig, consoleClient console.Client, meter *metering.UsageReporter, responseCache *cache.Cache) *Server {
 	return &Server{
 		config:        cfg,
 		logger:        log.Logger(),
 		consoleClient: consoleClient,
+		meter:         meter,
+		responseCache: responseCache,
 		router:        chi.NewRouter(),
 	}
 }
@@ -48,11 +57,12 @@ func (s *Server) SetupRoutes() {
 	r.Use(nexusmw.RequestLogger())
 	r.Use(nexusmw.Recovery())
 
-	// Health endpoints (no auth required) - with short timeout
+	// Health and metrics endpoints (no auth required) - with short timeout
 	r.Group(func(r chi.Router) {
 		r.Use(middleware.Timeout(30 * time.Second)) // Short timeout for health checks
 		r.Get("/health", HealthHandler())
 		r.Get("/ready", ReadyHandler(s.consoleClient))
+		r.Handle("/metrics", promhttp.Handler())
 	})
 
 	// AI Proxy routes (Bifrost) - Protected with authentication
@@ -68,7 +78,7 @@ func (s *Server) SetupRoutes() {
 // Start initializes and starts the HTTP server
 // Returns a ready channel that will be closed when the server is listening and ready to accept connections
 func (s *Server) Start(ctx context.Context) (<-chan struct{}, error) {
-	bifrostHandler, err := router.NewHandler(s.consoleClient)
+	bifrostHandler, err := router.NewHandler(s.consoleClient, s.meter, s.responseCache)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize Bifrost handler: %w", err)
 	}
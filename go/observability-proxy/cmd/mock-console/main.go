@@ -20,13 +20,25 @@ type server struct {
 }
 
 const (
-	envMockConsoleAddr    = "MOCK_CONSOLE_ADDR"
-	envMockPrometheusHost = "MOCK_PROMETHEUS_HOST"
-	envMockElasticHost    = "MOCK_ELASTIC_HOST"
+	envMockConsoleAddr        = "MOCK_CONSOLE_ADDR"
+	envMockPrometheusHost     = "MOCK_PROMETHEUS_HOST"
+	envMockPrometheusUsername = "MOCK_PROMETHEUS_USERNAME"
+	envMockPrometheusPassword = "MOCK_PROMETHEUS_PASSWORD"
+	envMockElasticHost        = "MOCK_ELASTIC_HOST"
+	envMockElasticUsername    = "MOCK_ELASTIC_USERNAME"
+	envMockElasticPassword    = "MOCK_ELASTIC_PASSWORD"
+	envMockElasticIndex       = "MOCK_ELASTIC_INDEX"
+	envMockClusterToken       = "MOCK_CLUSTER_TOKEN"
+	envMockClusterHandle      = "MOCK_CLUSTER_HANDLE"
+	envMockClusterID          = "MOCK_CLUSTER_ID"
 
 	defaultMockConsoleAddr    = ":50051"
 	defaultMockPrometheusHost = "http://mock-prometheus:19090/select/default/prometheus"
 	defaultMockElasticHost    = "http://mock-elastic:19200"
+	defaultMockElasticIndex   = "obs_proxy_smoketest"
+	defaultMockClusterToken   = "deploy-mock"
+	defaultMockClusterHandle  = "mock-cluster"
+	defaultMockClusterID      = "00000000-0000-0000-0000-000000000001"
 )
 
 func main() {
@@ -76,17 +88,40 @@ func (s *server) GetObservabilityConfig(context.Context, *pb.ObservabilityConfig
 	promHost := envOrDefault(envMockPrometheusHost, defaultMockPrometheusHost)
 
 	elasticHost := envOrDefault(envMockElasticHost, defaultMockElasticHost)
+	elasticIndex := envOrDefault(envMockElasticIndex, defaultMockElasticIndex)
 
 	if promHost == "" || elasticHost == "" {
 		return nil, status.Error(codes.FailedPrecondition, "mock hosts not configured")
 	}
 
 	return &pb.ObservabilityConfig{
-		PrometheusHost: &promHost,
-		ElasticHost:    &elasticHost,
+		PrometheusHost:     &promHost,
+		PrometheusUsername: optionalEnv(envMockPrometheusUsername),
+		PrometheusPassword: optionalEnv(envMockPrometheusPassword),
+		ElasticHost:        &elasticHost,
+		ElasticUsername:    optionalEnv(envMockElasticUsername),
+		ElasticPassword:    optionalEnv(envMockElasticPassword),
+		ElasticIndex:       &elasticIndex,
 	}, nil
 }
 
+func (s *server) VerifyCluster(_ context.Context, req *pb.VerifyClusterRequest) (*pb.VerifyClusterResponse, error) {
+	if req.GetToken() != envOrDefault(envMockClusterToken, defaultMockClusterToken) {
+		return nil, status.Error(codes.Unauthenticated, "invalid cluster access token")
+	}
+
+	handle := envOrDefault(envMockClusterHandle, defaultMockClusterHandle)
+	return &pb.VerifyClusterResponse{Id: envOrDefault(envMockClusterID, defaultMockClusterID), Name: handle, Handle: handle}, nil
+}
+
+func optionalEnv(key string) *string {
+	if value := os.Getenv(key); value != "" {
+		return &value
+	}
+
+	return nil
+}
+
 func envOrDefault(key, fallback string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -16,6 +16,7 @@ const (
 	defaultGRPCTimeout     = 10 * time.Second
 	defaultUpstreamTimeout = 30 * time.Second
 	defaultMeterInterval   = 30 * time.Second
+	defaultAuthTTL         = 60 * time.Second
 )
 
 const (
@@ -25,6 +26,7 @@ const (
 	envGRPCTimeout     = "OBS_PROXY_GRPC_TIMEOUT"
 	envUpstreamTimeout = "OBS_PROXY_UPSTREAM_TIMEOUT"
 	envMeterInterval   = "OBS_PROXY_METER_INTERVAL"
+	envAuthTTL         = "OBS_PROXY_AUTH_TTL"
 )
 
 var (
@@ -34,6 +36,7 @@ var (
 	argGRPCTimeout     = flag.Duration("grpc-timeout", envDurationOrDefault(envGRPCTimeout, defaultGRPCTimeout), "Console gRPC timeout")
 	argUpstreamTimeout = flag.Duration("upstream-timeout", envDurationOrDefault(envUpstreamTimeout, defaultUpstreamTimeout), "Upstream request timeout")
 	argMeterInterval   = flag.Duration("meter-interval", envDurationOrDefault(envMeterInterval, defaultMeterInterval), "Interval for metering request bytes to Console")
+	argAuthTTL         = flag.Duration("auth-ttl", envDurationOrDefault(envAuthTTL, defaultAuthTTL), "Cache TTL of verified cluster tokens")
 )
 
 func Init() {
@@ -85,6 +88,13 @@ func MeterInterval() time.Duration {
 	return *argMeterInterval
 }
 
+func AuthTTL() time.Duration {
+	if *argAuthTTL <= 0 {
+		return defaultAuthTTL
+	}
+	return *argAuthTTL
+}
+
 func envOrDefault(key, fallback string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -29,6 +29,10 @@ func (c *refreshTestClient) GetObservabilityConfig(context.Context) (*pb.Observa
 
 func (c *refreshTestClient) MeterMetrics(context.Context, int64) error { return nil }
 
+func (c *refreshTestClient) VerifyCluster(context.Context, string) (*pb.VerifyClusterResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
 func (c *refreshTestClient) Close() error { return nil }
 
 func (c *refreshTestClient) Calls() int {
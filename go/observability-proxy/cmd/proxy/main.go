@@ -33,11 +33,12 @@ func main() {
 func run() error {
 	klog.V(logging.LevelMinimal).Infof("starting observability-proxy listen=%s grpc_endpoint=%s", args.ListenAddr(), args.ConsoleGRPCEndpoint())
 	klog.V(logging.LevelDebug).Infof(
-		"runtime options configTTL=%s grpcTimeout=%s upstreamTimeout=%s meterInterval=%s",
+		"runtime options configTTL=%s grpcTimeout=%s upstreamTimeout=%s meterInterval=%s authTTL=%s",
 		args.ConfigTTL(),
 		args.GRPCTimeout(),
 		args.UpstreamTimeout(),
 		args.MeterInterval(),
+		args.AuthTTL(),
 	)
 
 	grpcClient, err := console.NewGRPCClient(args.ConsoleGRPCEndpoint(), args.GRPCTimeout())
@@ -47,10 +48,11 @@ func run() error {
 	defer closeGRPCClient(grpcClient)
 
 	provider := console.NewCachingProvider(grpcClient, args.ConfigTTL())
+	verifier := console.NewCachingVerifier(grpcClient, args.AuthTTL())
 	reporter := metering.NewUsageReporter(grpcClient, args.MeterInterval())
 	defer startUsageReporter(reporter)()
 
-	srv := newHTTPServer(provider, reporter.AddBytes)
+	srv := newHTTPServer(provider, verifier, reporter.AddBytes)
 	errCh := startHTTPServer(srv)
 	defer startConfigRefresher(provider, args.ConfigTTL())()
 
@@ -135,8 +137,8 @@ func startConfigRefresherWithRetry(provider *console.CachingProvider, refreshInt
 	}
 }
 
-func newHTTPServer(provider *console.CachingProvider, recordBytes func(int64)) *http.Server {
-	h := proxy.NewHandler(provider, args.UpstreamTimeout(), recordBytes)
+func newHTTPServer(provider *console.CachingProvider, verifier console.ClusterVerifier, recordBytes func(int64)) *http.Server {
+	h := proxy.NewHandler(provider, verifier, args.UpstreamTimeout(), recordBytes)
 
 	mux := http.NewServeMux()
 	mux.HandleFunc("/health", healthHandler())
@@ -3,6 +3,8 @@ module github.com/pluralsh/console/go/observability-proxy
 go 1.26.5
 
 require (
+	github.com/golang/snappy v1.0.0
+	go.opentelemetry.io/proto/otlp v1.9.0
 	golang.org/x/sync v0.22.0
 	google.golang.org/grpc v1.82.1
 	google.golang.org/protobuf v1.36.12-0.20260120151049-f2248ac996af
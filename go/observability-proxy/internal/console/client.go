@@ -15,6 +15,7 @@ import (
 type Client interface {
 	GetObservabilityConfig(ctx context.Context) (*pb.ObservabilityConfig, error)
 	MeterMetrics(ctx context.Context, bytes int64) error
+	VerifyCluster(ctx context.Context, token string) (*pb.VerifyClusterResponse, error)
 	Close() error
 }
 
@@ -77,3 +78,15 @@ func (c *grpcClient) MeterMetrics(ctx context.Context, bytes int64) error {
 
 	return nil
 }
+
+func (c *grpcClient) VerifyCluster(ctx context.Context, token string) (*pb.VerifyClusterResponse, error) {
+	reqCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	resp, err := c.client.VerifyCluster(reqCtx, &pb.VerifyClusterRequest{Token: token})
+	if err != nil {
+		return nil, fmt.Errorf("verify cluster: %w", err)
+	}
+
+	return resp, nil
+}
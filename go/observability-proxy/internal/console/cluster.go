@@ -0,0 +1,112 @@
+package console
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pluralsh/console/go/observability-proxy/internal/logging"
+	"golang.org/x/sync/singleflight"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/klog/v2"
+)
+
+// ErrUnauthenticated is returned for tokens that do not belong to any cluster.
+var ErrUnauthenticated = errors.New("invalid cluster token")
+
+// Cluster is the identity of an authenticated cluster. The ID is stamped onto its
+// telemetry: names and handles can be changed and reused by other clusters, so data
+// labeled with them could leak to a different cluster later on.
+type Cluster struct {
+	ID     string
+	Name   string
+	Handle string
+}
+
+// ClusterVerifier authenticates clusters by their deploy token.
+type ClusterVerifier interface {
+	VerifyCluster(ctx context.Context, token string) (Cluster, error)
+}
+
+type verifiedCluster struct {
+	cluster   Cluster
+	expiresAt time.Time
+}
+
+// CachingVerifier verifies cluster tokens with Console gRPC and caches successful
+// verifications for a TTL, so high-frequency ingest does not call Console per request.
+type CachingVerifier struct {
+	client Client
+	ttl    time.Duration
+
+	mu      sync.Mutex
+	entries map[[sha256.Size]byte]verifiedCluster
+	sfGroup singleflight.Group
+}
+
+func NewCachingVerifier(client Client, ttl time.Duration) *CachingVerifier {
+	return &CachingVerifier{
+		client:  client,
+		ttl:     ttl,
+		entries: make(map[[sha256.Size]byte]verifiedCluster),
+	}
+}
+
+func (v *CachingVerifier) VerifyCluster(ctx context.Context, token string) (Cluster, error) {
+	if token == "" {
+		return Cluster{}, ErrUnauthenticated
+	}
+
+	// tokens are only held in memory as hashes
+	key := sha256.Sum256([]byte(token))
+	v.mu.Lock()
+	entry, ok := v.entries[key]
+	v.mu.Unlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.cluster, nil
+	}
+
+	val, err, _ := v.sfGroup.Do(string(key[:]), func() (interface{}, error) {
+		return v.verify(ctx, token, key)
+	})
+	if err != nil {
+		return Cluster{}, err
+	}
+
+	return val.(Cluster), nil
+}
+
+func (v *CachingVerifier) verify(ctx context.Context, token string, key [sha256.Size]byte) (Cluster, error) {
+	resp, err := v.client.VerifyCluster(ctx, token)
+	if err != nil {
+		switch status.Code(err) {
+		case codes.Unauthenticated, codes.PermissionDenied, codes.NotFound:
+			return Cluster{}, ErrUnauthenticated
+		}
+		klog.ErrorS(err, "failed to verify cluster token")
+		return Cluster{}, err
+	}
+
+	if resp.GetId() == "" {
+		return Cluster{}, fmt.Errorf("verify cluster: empty cluster identity")
+	}
+
+	cluster := Cluster{ID: resp.GetId(), Name: resp.GetName(), Handle: resp.GetHandle()}
+	klog.V(logging.LevelDebug).InfoS("verified cluster token", "cluster", cluster.ID, "handle", cluster.Handle)
+
+	now := time.Now()
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for k, entry := range v.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(v.entries, k)
+		}
+	}
+	v.entries[key] = verifiedCluster{cluster: cluster, expiresAt: now.Add(v.ttl)}
+
+	return cluster, nil
+}
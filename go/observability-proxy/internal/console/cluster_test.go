@@ -0,0 +1,103 @@
+package console
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/pluralsh/console/go/observability-proxy/internal/proto"
+)
+
+type fakeVerifyClient struct {
+	fakeConfigClient
+
+	mu    sync.Mutex
+	calls int
+	resp  *pb.VerifyClusterResponse
+	err   error
+}
+
+func (f *fakeVerifyClient) VerifyCluster(context.Context, string) (*pb.VerifyClusterResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	return f.resp, f.err
+}
+
+func (f *fakeVerifyClient) Calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+func TestVerifyClusterCachesVerifiedTokens(t *testing.T) {
+	client := &fakeVerifyClient{resp: &pb.VerifyClusterResponse{Id: "id", Name: "name", Handle: "handle"}}
+	verifier := NewCachingVerifier(client, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		cluster, err := verifier.VerifyCluster(context.Background(), "deploy-token")
+		if err != nil {
+			t.Fatalf("verify cluster: %v", err)
+		}
+		if cluster.ID != "id" || cluster.Handle != "handle" {
+			t.Fatalf("unexpected cluster: got %+v", cluster)
+		}
+	}
+
+	if got := client.Calls(); got != 1 {
+		t.Fatalf("unexpected grpc call count: got %d want 1", got)
+	}
+}
+
+func TestVerifyClusterExpiresTokens(t *testing.T) {
+	client := &fakeVerifyClient{resp: &pb.VerifyClusterResponse{Id: "id"}}
+	verifier := NewCachingVerifier(client, 10*time.Millisecond)
+
+	cluster, err := verifier.VerifyCluster(context.Background(), "deploy-token")
+	if err != nil {
+		t.Fatalf("verify cluster: %v", err)
+	}
+	if cluster.ID != "id" {
+		t.Fatalf("unexpected cluster id: got %q want %q", cluster.ID, "id")
+	}
+
+	time.Sleep(15 * time.Millisecond)
+	if _, err := verifier.VerifyCluster(context.Background(), "deploy-token"); err != nil {
+		t.Fatalf("verify cluster: %v", err)
+	}
+	if got := client.Calls(); got != 2 {
+		t.Fatalf("unexpected grpc call count: got %d want 2", got)
+	}
+}
+
+func TestVerifyClusterErrors(t *testing.T) {
+	cases := []struct {
+		name   string
+		token  string
+		err    error
+		unauth bool
+	}{
+		{name: "empty token", token: "", unauth: true},
+		{name: "rejected", token: "t", err: fmt.Errorf("verify cluster: %w", status.Error(codes.Unauthenticated, "invalid")), unauth: true},
+		{name: "unavailable", token: "t", err: fmt.Errorf("verify cluster: %w", status.Error(codes.Unavailable, "down"))},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			verifier := NewCachingVerifier(&fakeVerifyClient{err: tc.err}, time.Minute)
+			_, err := verifier.VerifyCluster(context.Background(), tc.token)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if got := errors.Is(err, ErrUnauthenticated); got != tc.unauth {
+				t.Fatalf("unexpected unauthenticated error: got %v want %v (%v)", got, tc.unauth, err)
+			}
+		})
+	}
+}
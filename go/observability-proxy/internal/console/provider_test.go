@@ -42,6 +42,10 @@ func (f *fakeConfigClient) GetObservabilityConfig(ctx context.Context) (*pb.Obse
 
 func (f *fakeConfigClient) MeterMetrics(context.Context, int64) error { return nil }
 
+func (f *fakeConfigClient) VerifyCluster(context.Context, string) (*pb.VerifyClusterResponse, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
 func (f *fakeConfigClient) Close() error { return nil }
 
 func (f *fakeConfigClient) Calls() int {
@@ -120,6 +120,7 @@ type AiConfig struct {
 	Azure            *AzureOpenAiConfig     `protobuf:"bytes,6,opt,name=azure,proto3" json:"azure,omitempty"`
 	OpenaiCompatible *OpenAiConfig          `protobuf:"bytes,7,opt,name=openaiCompatible,proto3" json:"openaiCompatible,omitempty"`
 	Xai              *OpenAiConfig          `protobuf:"bytes,8,opt,name=xai,proto3" json:"xai,omitempty"`
+	Guardrails       *GuardrailsConfig      `protobuf:"bytes,9,opt,name=guardrails,proto3" json:"guardrails,omitempty"`
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
@@ -210,6 +211,210 @@ func (x *AiConfig) GetXai() *OpenAiConfig {
 	return nil
 }
 
+func (x *AiConfig) GetGuardrails() *GuardrailsConfig {
+	if x != nil {
+		return x.Guardrails
+	}
+	return nil
+}
+
+// Prompt guardrails enforced by the AI proxy before and after provider calls.
+type GuardrailsConfig struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	RedactPii     bool                   `protobuf:"varint,2,opt,name=redactPii,proto3" json:"redactPii,omitempty"`
+	DetectSecrets bool                   `protobuf:"varint,3,opt,name=detectSecrets,proto3" json:"detectSecrets,omitempty"`
+	MaxPromptSize *int64                 `protobuf:"varint,4,opt,name=maxPromptSize,proto3,oneof" json:"maxPromptSize,omitempty"`
+	PiiTerms      []string               `protobuf:"bytes,5,rep,name=piiTerms,proto3" json:"piiTerms,omitempty"`
+	PiiPatterns   []*PiiPattern          `protobuf:"bytes,6,rep,name=piiPatterns,proto3" json:"piiPatterns,omitempty"`
+	BlockedTopics []*BlockedTopic        `protobuf:"bytes,7,rep,name=blockedTopics,proto3" json:"blockedTopics,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GuardrailsConfig) Reset() {
+	*x = GuardrailsConfig{}
+	mi := &file_console_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GuardrailsConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuardrailsConfig) ProtoMessage() {}
+
+func (x *GuardrailsConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_console_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuardrailsConfig.ProtoReflect.Descriptor instead.
+func (*GuardrailsConfig) Descriptor() ([]byte, []int) {
+	return file_console_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GuardrailsConfig) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *GuardrailsConfig) GetRedactPii() bool {
+	if x != nil {
+		return x.RedactPii
+	}
+	return false
+}
+
+func (x *GuardrailsConfig) GetDetectSecrets() bool {
+	if x != nil {
+		return x.DetectSecrets
+	}
+	return false
+}
+
+func (x *GuardrailsConfig) GetMaxPromptSize() int64 {
+	if x != nil && x.MaxPromptSize != nil {
+		return *x.MaxPromptSize
+	}
+	return 0
+}
+
+func (x *GuardrailsConfig) GetPiiTerms() []string {
+	if x != nil {
+		return x.PiiTerms
+	}
+	return nil
+}
+
+func (x *GuardrailsConfig) GetPiiPatterns() []*PiiPattern {
+	if x != nil {
+		return x.PiiPatterns
+	}
+	return nil
+}
+
+func (x *GuardrailsConfig) GetBlockedTopics() []*BlockedTopic {
+	if x != nil {
+		return x.BlockedTopics
+	}
+	return nil
+}
+
+type PiiPattern struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Pattern       string                 `protobuf:"bytes,2,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PiiPattern) Reset() {
+	*x = PiiPattern{}
+	mi := &file_console_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PiiPattern) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PiiPattern) ProtoMessage() {}
+
+func (x *PiiPattern) ProtoReflect() protoreflect.Message {
+	mi := &file_console_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PiiPattern.ProtoReflect.Descriptor instead.
+func (*PiiPattern) Descriptor() ([]byte, []int) {
+	return file_console_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PiiPattern) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PiiPattern) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+type BlockedTopic struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Patterns      []string               `protobuf:"bytes,2,rep,name=patterns,proto3" json:"patterns,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockedTopic) Reset() {
+	*x = BlockedTopic{}
+	mi := &file_console_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockedTopic) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockedTopic) ProtoMessage() {}
+
+func (x *BlockedTopic) ProtoReflect() protoreflect.Message {
+	mi := &file_console_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockedTopic.ProtoReflect.Descriptor instead.
+func (*BlockedTopic) Descriptor() ([]byte, []int) {
+	return file_console_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BlockedTopic) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BlockedTopic) GetPatterns() []string {
+	if x != nil {
+		return x.Patterns
+	}
+	return nil
+}
+
 type OpenAiTokenExchange struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Enabled       *bool                  `protobuf:"varint,1,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
@@ -222,7 +427,7 @@ type OpenAiTokenExchange struct {
 
 func (x *OpenAiTokenExchange) Reset() {
 	*x = OpenAiTokenExchange{}
-	mi := &file_console_proto_msgTypes[2]
+	mi := &file_console_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -234,7 +439,7 @@ func (x *OpenAiTokenExchange) String() string {
 func (*OpenAiTokenExchange) ProtoMessage() {}
 
 func (x *OpenAiTokenExchange) ProtoReflect() protoreflect.Message {
-	mi := &file_console_proto_msgTypes[2]
+	mi := &file_console_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -247,7 +452,7 @@ func (x *OpenAiTokenExchange) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OpenAiTokenExchange.ProtoReflect.Descriptor instead.
 func (*OpenAiTokenExchange) Descriptor() ([]byte, []int) {
-	return file_console_proto_rawDescGZIP(), []int{2}
+	return file_console_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *OpenAiTokenExchange) GetEnabled() bool {
@@ -294,7 +499,7 @@ type OpenAiConfig struct {
 
 func (x *OpenAiConfig) Reset() {
 	*x = OpenAiConfig{}
-	mi := &file_console_proto_msgTypes[3]
+	mi := &file_console_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -306,7 +511,7 @@ func (x *OpenAiConfig) String() string {
 func (*OpenAiConfig) ProtoMessage() {}
 
 func (x *OpenAiConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_console_proto_msgTypes[3]
+	mi := &file_console_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -319,7 +524,7 @@ func (x *OpenAiConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OpenAiConfig.ProtoReflect.Descriptor instead.
 func (*OpenAiConfig) Descriptor() ([]byte, []int) {
-	return file_console_proto_rawDescGZIP(), []int{3}
+	return file_console_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *OpenAiConfig) GetApiKey() string {
@@ -392,7 +597,7 @@ type AnthropicConfig struct {
 
 func (x *AnthropicConfig) Reset() {
 	*x = AnthropicConfig{}
-	mi := &file_console_proto_msgTypes[4]
+	mi := &file_console_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -404,7 +609,7 @@ func (x *AnthropicConfig) String() string {
 func (*AnthropicConfig) ProtoMessage() {}
 
 func (x *AnthropicConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_console_proto_msgTypes[4]
+	mi := &file_console_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -417,7 +622,7 @@ func (x *AnthropicConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AnthropicConfig.ProtoReflect.Descriptor instead.
 func (*AnthropicConfig) Descriptor() ([]byte, []int) {
-	return file_console_proto_rawDescGZIP(), []int{4}
+	return file_console_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *AnthropicConfig) GetBaseUrl() string {
@@ -479,7 +684,7 @@ type VertexAiConfig struct {
 
 func (x *VertexAiConfig) Reset() {
 	*x = VertexAiConfig{}
-	mi := &file_console_proto_msgTypes[5]
+	mi := &file_console_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -491,7 +696,7 @@ func (x *VertexAiConfig) String() string {
 func (*VertexAiConfig) ProtoMessage() {}
 
 func (x *VertexAiConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_console_proto_msgTypes[5]
+	mi := &file_console_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -504,7 +709,7 @@ func (x *VertexAiConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VertexAiConfig.ProtoReflect.Descriptor instead.
 func (*VertexAiConfig) Descriptor() ([]byte, []int) {
-	return file_console_proto_rawDescGZIP(), []int{5}
+	return file_console_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *VertexAiConfig) GetApiKey() string {
@@ -587,7 +792,7 @@ type BedrockConfig struct {
 
 func (x *BedrockConfig) Reset() {
 	*x = BedrockConfig{}
-	mi := &file_console_proto_msgTypes[6]
+	mi := &file_console_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -599,7 +804,7 @@ func (x *BedrockConfig) String() string {
 func (*BedrockConfig) ProtoMessage() {}
 
 func (x *BedrockConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_console_proto_msgTypes[6]
+	mi := &file_console_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -612,7 +817,7 @@ func (x *BedrockConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BedrockConfig.ProtoReflect.Descriptor instead.
 func (*BedrockConfig) Descriptor() ([]byte, []int) {
-	return file_console_proto_rawDescGZIP(), []int{6}
+	return file_console_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *BedrockConfig) GetModelId() string {
@@ -694,7 +899,7 @@ type AzureOpenAiConfig struct {
 
 func (x *AzureOpenAiConfig) Reset() {
 	*x = AzureOpenAiConfig{}
-	mi := &file_console_proto_msgTypes[7]
+	mi := &file_console_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -706,7 +911,7 @@ func (x *AzureOpenAiConfig) String() string {
 func (*AzureOpenAiConfig) ProtoMessage() {}
 
 func (x *AzureOpenAiConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_console_proto_msgTypes[7]
+	mi := &file_console_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -719,7 +924,7 @@ func (x *AzureOpenAiConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AzureOpenAiConfig.ProtoReflect.Descriptor instead.
 func (*AzureOpenAiConfig) Descriptor() ([]byte, []int) {
-	return file_console_proto_rawDescGZIP(), []int{7}
+	return file_console_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *AzureOpenAiConfig) GetApiVersion() string {
@@ -787,7 +992,7 @@ type ProxyAuthenticationRequest struct {
 
 func (x *ProxyAuthenticationRequest) Reset() {
 	*x = ProxyAuthenticationRequest{}
-	mi := &file_console_proto_msgTypes[8]
+	mi := &file_console_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -799,7 +1004,7 @@ func (x *ProxyAuthenticationRequest) String() string {
 func (*ProxyAuthenticationRequest) ProtoMessage() {}
 
 func (x *ProxyAuthenticationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_console_proto_msgTypes[8]
+	mi := &file_console_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -812,7 +1017,7 @@ func (x *ProxyAuthenticationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProxyAuthenticationRequest.ProtoReflect.Descriptor instead.
 func (*ProxyAuthenticationRequest) Descriptor() ([]byte, []int) {
-	return file_console_proto_rawDescGZIP(), []int{8}
+	return file_console_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ProxyAuthenticationRequest) GetToken() string {
@@ -825,13 +1030,15 @@ func (x *ProxyAuthenticationRequest) GetToken() string {
 type ProxyAuthenticationResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Authenticated bool                   `protobuf:"varint,1,opt,name=authenticated,proto3" json:"authenticated,omitempty"`
+	UserId        *string                `protobuf:"bytes,2,opt,name=userId,proto3,oneof" json:"userId,omitempty"`
+	ClusterId     *string                `protobuf:"bytes,3,opt,name=clusterId,proto3,oneof" json:"clusterId,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ProxyAuthenticationResponse) Reset() {
 	*x = ProxyAuthenticationResponse{}
-	mi := &file_console_proto_msgTypes[9]
+	mi := &file_console_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -843,7 +1050,7 @@ func (x *ProxyAuthenticationResponse) String() string {
 func (*ProxyAuthenticationResponse) ProtoMessage() {}
 
 func (x *ProxyAuthenticationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_console_proto_msgTypes[9]
+	mi := &file_console_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -856,7 +1063,7 @@ func (x *ProxyAuthenticationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProxyAuthenticationResponse.ProtoReflect.Descriptor instead.
 func (*ProxyAuthenticationResponse) Descriptor() ([]byte, []int) {
-	return file_console_proto_rawDescGZIP(), []int{9}
+	return file_console_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ProxyAuthenticationResponse) GetAuthenticated() bool {
@@ -866,6 +1073,20 @@ func (x *ProxyAuthenticationResponse) GetAuthenticated() bool {
 	return false
 }
 
+func (x *ProxyAuthenticationResponse) GetUserId() string {
+	if x != nil && x.UserId != nil {
+		return *x.UserId
+	}
+	return ""
+}
+
+func (x *ProxyAuthenticationResponse) GetClusterId() string {
+	if x != nil && x.ClusterId != nil {
+		return *x.ClusterId
+	}
+	return ""
+}
+
 // Fetches the cluster associated with a cluster deploy token.
 type VerifyClusterRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -876,7 +1097,7 @@ type VerifyClusterRequest struct {
 
 func (x *VerifyClusterRequest) Reset() {
 	*x = VerifyClusterRequest{}
-	mi := &file_console_proto_msgTypes[10]
+	mi := &file_console_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -888,7 +1109,7 @@ func (x *VerifyClusterRequest) String() string {
 func (*VerifyClusterRequest) ProtoMessage() {}
 
 func (x *VerifyClusterRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_console_proto_msgTypes[10]
+	mi := &file_console_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -901,7 +1122,7 @@ func (x *VerifyClusterRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VerifyClusterRequest.ProtoReflect.Descriptor instead.
 func (*VerifyClusterRequest) Descriptor() ([]byte, []int) {
-	return file_console_proto_rawDescGZIP(), []int{10}
+	return file_console_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *VerifyClusterRequest) GetToken() string {
@@ -911,18 +1132,19 @@ func (x *VerifyClusterRequest) GetToken() string {
 	return ""
 }
 
-// The cluster identity required by KAS.
+// The cluster identity required by KAS and the observability proxy.
 type VerifyClusterResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Handle        string                 `protobuf:"bytes,3,opt,name=handle,proto3" json:"handle,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *VerifyClusterResponse) Reset() {
 	*x = VerifyClusterResponse{}
-	mi := &file_console_proto_msgTypes[11]
+	mi := &file_console_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -934,7 +1156,7 @@ func (x *VerifyClusterResponse) String() string {
 func (*VerifyClusterResponse) ProtoMessage() {}
 
 func (x *VerifyClusterResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_console_proto_msgTypes[11]
+	mi := &file_console_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -947,7 +1169,7 @@ func (x *VerifyClusterResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VerifyClusterResponse.ProtoReflect.Descriptor instead.
 func (*VerifyClusterResponse) Descriptor() ([]byte, []int) {
-	return file_console_proto_rawDescGZIP(), []int{11}
+	return file_console_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *VerifyClusterResponse) GetId() string {
@@ -964,6 +1186,13 @@ func (x *VerifyClusterResponse) GetName() string {
 	return ""
 }
 
+func (x *VerifyClusterResponse) GetHandle() string {
+	if x != nil {
+		return x.Handle
+	}
+	return ""
+}
+
 type ObservabilityConfig struct {
 	state              protoimpl.MessageState `protogen:"open.v1"`
 	PrometheusUsername *string                `protobuf:"bytes,1,opt,name=prometheusUsername,proto3,oneof" json:"prometheusUsername,omitempty"`
@@ -979,7 +1208,7 @@ type ObservabilityConfig struct {
 
 func (x *ObservabilityConfig) Reset() {
 	*x = ObservabilityConfig{}
-	mi := &file_console_proto_msgTypes[12]
+	mi := &file_console_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -991,7 +1220,7 @@ func (x *ObservabilityConfig) String() string {
 func (*ObservabilityConfig) ProtoMessage() {}
 
 func (x *ObservabilityConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_console_proto_msgTypes[12]
+	mi := &file_console_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1004,7 +1233,7 @@ func (x *ObservabilityConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ObservabilityConfig.ProtoReflect.Descriptor instead.
 func (*ObservabilityConfig) Descriptor() ([]byte, []int) {
-	return file_console_proto_rawDescGZIP(), []int{12}
+	return file_console_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *ObservabilityConfig) GetPrometheusUsername() string {
@@ -1065,7 +1294,7 @@ type MeterMetricsRequest struct {
 
 func (x *MeterMetricsRequest) Reset() {
 	*x = MeterMetricsRequest{}
-	mi := &file_console_proto_msgTypes[13]
+	mi := &file_console_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1077,7 +1306,7 @@ func (x *MeterMetricsRequest) String() string {
 func (*MeterMetricsRequest) ProtoMessage() {}
 
 func (x *MeterMetricsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_console_proto_msgTypes[13]
+	mi := &file_console_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1090,7 +1319,7 @@ func (x *MeterMetricsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MeterMetricsRequest.ProtoReflect.Descriptor instead.
 func (*MeterMetricsRequest) Descriptor() ([]byte, []int) {
-	return file_console_proto_rawDescGZIP(), []int{13}
+	return file_console_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *MeterMetricsRequest) GetBytes() int64 {
@@ -1109,7 +1338,7 @@ type MeterMetricsResponse struct {
 
 func (x *MeterMetricsResponse) Reset() {
 	*x = MeterMetricsResponse{}
-	mi := &file_console_proto_msgTypes[14]
+	mi := &file_console_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1121,7 +1350,7 @@ func (x *MeterMetricsResponse) String() string {
 func (*MeterMetricsResponse) ProtoMessage() {}
 
 func (x *MeterMetricsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_console_proto_msgTypes[14]
+	mi := &file_console_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1134,7 +1363,7 @@ func (x *MeterMetricsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MeterMetricsResponse.ProtoReflect.Descriptor instead.
 func (*MeterMetricsResponse) Descriptor() ([]byte, []int) {
-	return file_console_proto_rawDescGZIP(), []int{14}
+	return file_console_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *MeterMetricsResponse) GetSuccess() bool {
@@ -1144,6 +1373,203 @@ func (x *MeterMetricsResponse) GetSuccess() bool {
 	return false
 }
 
+// Token usage of a single caller against a single model, aggregated over a flush interval.
+type AiUsage struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	UserId           *string                `protobuf:"bytes,1,opt,name=userId,proto3,oneof" json:"userId,omitempty"`
+	ClusterId        *string                `protobuf:"bytes,2,opt,name=clusterId,proto3,oneof" json:"clusterId,omitempty"`
+	Provider         string                 `protobuf:"bytes,3,opt,name=provider,proto3" json:"provider,omitempty"`
+	Model            string                 `protobuf:"bytes,4,opt,name=model,proto3" json:"model,omitempty"`
+	PromptTokens     int64                  `protobuf:"varint,5,opt,name=promptTokens,proto3" json:"promptTokens,omitempty"`
+	CompletionTokens int64                  `protobuf:"varint,6,opt,name=completionTokens,proto3" json:"completionTokens,omitempty"`
+	CachedTokens     int64                  `protobuf:"varint,7,opt,name=cachedTokens,proto3" json:"cachedTokens,omitempty"`
+	Cost             float64                `protobuf:"fixed64,8,opt,name=cost,proto3" json:"cost,omitempty"`
+	Requests         int64                  `protobuf:"varint,9,opt,name=requests,proto3" json:"requests,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *AiUsage) Reset() {
+	*x = AiUsage{}
+	mi := &file_console_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AiUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AiUsage) ProtoMessage() {}
+
+func (x *AiUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_console_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AiUsage.ProtoReflect.Descriptor instead.
+func (*AiUsage) Descriptor() ([]byte, []int) {
+	return file_console_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AiUsage) GetUserId() string {
+	if x != nil && x.UserId != nil {
+		return *x.UserId
+	}
+	return ""
+}
+
+func (x *AiUsage) GetClusterId() string {
+	if x != nil && x.ClusterId != nil {
+		return *x.ClusterId
+	}
+	return ""
+}
+
+func (x *AiUsage) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *AiUsage) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *AiUsage) GetPromptTokens() int64 {
+	if x != nil {
+		return x.PromptTokens
+	}
+	return 0
+}
+
+func (x *AiUsage) GetCompletionTokens() int64 {
+	if x != nil {
+		return x.CompletionTokens
+	}
+	return 0
+}
+
+func (x *AiUsage) GetCachedTokens() int64 {
+	if x != nil {
+		return x.CachedTokens
+	}
+	return 0
+}
+
+func (x *AiUsage) GetCost() float64 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+func (x *AiUsage) GetRequests() int64 {
+	if x != nil {
+		return x.Requests
+	}
+	return 0
+}
+
+type MeterAiUsageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Usage         []*AiUsage             `protobuf:"bytes,1,rep,name=usage,proto3" json:"usage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MeterAiUsageRequest) Reset() {
+	*x = MeterAiUsageRequest{}
+	mi := &file_console_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MeterAiUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MeterAiUsageRequest) ProtoMessage() {}
+
+func (x *MeterAiUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_console_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MeterAiUsageRequest.ProtoReflect.Descriptor instead.
+func (*MeterAiUsageRequest) Descriptor() ([]byte, []int) {
+	return file_console_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *MeterAiUsageRequest) GetUsage() []*AiUsage {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+type MeterAiUsageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MeterAiUsageResponse) Reset() {
+	*x = MeterAiUsageResponse{}
+	mi := &file_console_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MeterAiUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MeterAiUsageResponse) ProtoMessage() {}
+
+func (x *MeterAiUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_console_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MeterAiUsageResponse.ProtoReflect.Descriptor instead.
+func (*MeterAiUsageResponse) Descriptor() ([]byte, []int) {
+	return file_console_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *MeterAiUsageResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
 type ObservabilityConfigRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -1152,7 +1578,7 @@ type ObservabilityConfigRequest struct {
 
 func (x *ObservabilityConfigRequest) Reset() {
 	*x = ObservabilityConfigRequest{}
-	mi := &file_console_proto_msgTypes[15]
+	mi := &file_console_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1164,7 +1590,7 @@ func (x *ObservabilityConfigRequest) String() string {
 func (*ObservabilityConfigRequest) ProtoMessage() {}
 
 func (x *ObservabilityConfigRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_console_proto_msgTypes[15]
+	mi := &file_console_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1177,7 +1603,7 @@ func (x *ObservabilityConfigRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ObservabilityConfigRequest.ProtoReflect.Descriptor instead.
 func (*ObservabilityConfigRequest) Descriptor() ([]byte, []int) {
-	return file_console_proto_rawDescGZIP(), []int{15}
+	return file_console_proto_rawDescGZIP(), []int{21}
 }
 
 var File_console_proto protoreflect.FileDescriptor
@@ -1185,7 +1611,7 @@ var File_console_proto protoreflect.FileDescriptor
 const file_console_proto_rawDesc = "" +
 	"\n" +
 	"\rconsole.proto\x12\x04plrl\"\x11\n" +
-	"\x0fAiConfigRequest\"\xfb\x02\n" +
+	"\x0fAiConfigRequest\"\xb3\x03\n" +
 	"\bAiConfig\x12\x18\n" +
 	"\aenabled\x18\x01 \x01(\bR\aenabled\x12*\n" +
 	"\x06openai\x18\x02 \x01(\v2\x12.plrl.OpenAiConfigR\x06openai\x123\n" +
@@ -1194,7 +1620,26 @@ const file_console_proto_rawDesc = "" +
 	"\abedrock\x18\x05 \x01(\v2\x13.plrl.BedrockConfigR\abedrock\x12-\n" +
 	"\x05azure\x18\x06 \x01(\v2\x17.plrl.AzureOpenAiConfigR\x05azure\x12>\n" +
 	"\x10openaiCompatible\x18\a \x01(\v2\x12.plrl.OpenAiConfigR\x10openaiCompatible\x12$\n" +
-	"\x03xai\x18\b \x01(\v2\x12.plrl.OpenAiConfigR\x03xai\"\xd6\x01\n" +
+	"\x03xai\x18\b \x01(\v2\x12.plrl.OpenAiConfigR\x03xai\x126\n" +
+	"\n" +
+	"guardrails\x18\t \x01(\v2\x16.plrl.GuardrailsConfigR\n" +
+	"guardrails\"\xb7\x02\n" +
+	"\x10GuardrailsConfig\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x1c\n" +
+	"\tredactPii\x18\x02 \x01(\bR\tredactPii\x12$\n" +
+	"\rdetectSecrets\x18\x03 \x01(\bR\rdetectSecrets\x12)\n" +
+	"\rmaxPromptSize\x18\x04 \x01(\x03H\x00R\rmaxPromptSize\x88\x01\x01\x12\x1a\n" +
+	"\bpiiTerms\x18\x05 \x03(\tR\bpiiTerms\x122\n" +
+	"\vpiiPatterns\x18\x06 \x03(\v2\x10.plrl.PiiPatternR\vpiiPatterns\x128\n" +
+	"\rblockedTopics\x18\a \x03(\v2\x12.plrl.BlockedTopicR\rblockedTopicsB\x10\n" +
+	"\x0e_maxPromptSize\":\n" +
+	"\n" +
+	"PiiPattern\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\apattern\x18\x02 \x01(\tR\apattern\">\n" +
+	"\fBlockedTopic\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bpatterns\x18\x02 \x03(\tR\bpatterns\"\xd6\x01\n" +
 	"\x13OpenAiTokenExchange\x12\x1d\n" +
 	"\aenabled\x18\x01 \x01(\bH\x00R\aenabled\x88\x01\x01\x12\x1f\n" +
 	"\btokenUrl\x18\x02 \x01(\tH\x01R\btokenUrl\x88\x01\x01\x12\x1f\n" +
@@ -1300,14 +1745,20 @@ const file_console_proto_rawDesc = "" +
 	"_toolModelB\x0e\n" +
 	"\f_accessToken\"2\n" +
 	"\x1aProxyAuthenticationRequest\x12\x14\n" +
-	"\x05token\x18\x01 \x01(\tR\x05token\"C\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\x9c\x01\n" +
 	"\x1bProxyAuthenticationResponse\x12$\n" +
-	"\rauthenticated\x18\x01 \x01(\bR\rauthenticated\",\n" +
+	"\rauthenticated\x18\x01 \x01(\bR\rauthenticated\x12\x1b\n" +
+	"\x06userId\x18\x02 \x01(\tH\x00R\x06userId\x88\x01\x01\x12!\n" +
+	"\tclusterId\x18\x03 \x01(\tH\x01R\tclusterId\x88\x01\x01B\t\n" +
+	"\a_userIdB\f\n" +
+	"\n" +
+	"_clusterId\",\n" +
 	"\x14VerifyClusterRequest\x12\x14\n" +
-	"\x05token\x18\x01 \x01(\tR\x05token\";\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"S\n" +
 	"\x15VerifyClusterResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\"\xe4\x03\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06handle\x18\x03 \x01(\tR\x06handle\"\xe4\x03\n" +
 	"\x13ObservabilityConfig\x123\n" +
 	"\x12prometheusUsername\x18\x01 \x01(\tH\x00R\x12prometheusUsername\x88\x01\x01\x123\n" +
 	"\x12prometheusPassword\x18\x02 \x01(\tH\x01R\x12prometheusPassword\x88\x01\x01\x12-\n" +
@@ -1326,15 +1777,33 @@ const file_console_proto_rawDesc = "" +
 	"\x13MeterMetricsRequest\x12\x14\n" +
 	"\x05bytes\x18\x01 \x01(\x03R\x05bytes\"0\n" +
 	"\x14MeterMetricsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xb8\x02\n" +
+	"\aAiUsage\x12\x1b\n" +
+	"\x06userId\x18\x01 \x01(\tH\x00R\x06userId\x88\x01\x01\x12!\n" +
+	"\tclusterId\x18\x02 \x01(\tH\x01R\tclusterId\x88\x01\x01\x12\x1a\n" +
+	"\bprovider\x18\x03 \x01(\tR\bprovider\x12\x14\n" +
+	"\x05model\x18\x04 \x01(\tR\x05model\x12\"\n" +
+	"\fpromptTokens\x18\x05 \x01(\x03R\fpromptTokens\x12*\n" +
+	"\x10completionTokens\x18\x06 \x01(\x03R\x10completionTokens\x12\"\n" +
+	"\fcachedTokens\x18\a \x01(\x03R\fcachedTokens\x12\x12\n" +
+	"\x04cost\x18\b \x01(\x01R\x04cost\x12\x1a\n" +
+	"\brequests\x18\t \x01(\x03R\brequestsB\t\n" +
+	"\a_userIdB\f\n" +
+	"\n" +
+	"_clusterId\":\n" +
+	"\x13MeterAiUsageRequest\x12#\n" +
+	"\x05usage\x18\x01 \x03(\v2\r.plrl.AiUsageR\x05usage\"0\n" +
+	"\x14MeterAiUsageResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x1c\n" +
 	"\x1aObservabilityConfigRequest*Q\n" +
 	"\fOpenAiMethod\x12\x1e\n" +
 	"\x1aOPEN_AI_METHOD_UNSPECIFIED\x10\x00\x12\b\n" +
 	"\x04CHAT\x10\x01\x12\r\n" +
 	"\tRESPONSES\x10\x02\x12\b\n" +
-	"\x04AUTO\x10\x032\x88\x03\n" +
+	"\x04AUTO\x10\x032\xcf\x03\n" +
 	"\fPluralServer\x12E\n" +
-	"\fMeterMetrics\x12\x19.plrl.MeterMetricsRequest\x1a\x1a.plrl.MeterMetricsResponse\x124\n" +
+	"\fMeterMetrics\x12\x19.plrl.MeterMetricsRequest\x1a\x1a.plrl.MeterMetricsResponse\x12E\n" +
+	"\fMeterAiUsage\x12\x19.plrl.MeterAiUsageRequest\x1a\x1a.plrl.MeterAiUsageResponse\x124\n" +
 	"\vGetAiConfig\x12\x15.plrl.AiConfigRequest\x1a\x0e.plrl.AiConfig\x12U\n" +
 	"\x16GetObservabilityConfig\x12 .plrl.ObservabilityConfigRequest\x1a\x19.plrl.ObservabilityConfig\x12Z\n" +
 	"\x13ProxyAuthentication\x12 .plrl.ProxyAuthenticationRequest\x1a!.plrl.ProxyAuthenticationResponse\x12H\n" +
@@ -1353,55 +1822,67 @@ func file_console_proto_rawDescGZIP() []byte {
 }
 
 var file_console_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_console_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_console_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
 var file_console_proto_goTypes = []any{
 	(OpenAiMethod)(0),                   // 0: plrl.OpenAiMethod
 	(*AiConfigRequest)(nil),             // 1: plrl.AiConfigRequest
 	(*AiConfig)(nil),                    // 2: plrl.AiConfig
-	(*OpenAiTokenExchange)(nil),         // 3: plrl.OpenAiTokenExchange
-	(*OpenAiConfig)(nil),                // 4: plrl.OpenAiConfig
-	(*AnthropicConfig)(nil),             // 5: plrl.AnthropicConfig
-	(*VertexAiConfig)(nil),              // 6: plrl.VertexAiConfig
-	(*BedrockConfig)(nil),               // 7: plrl.BedrockConfig
-	(*AzureOpenAiConfig)(nil),           // 8: plrl.AzureOpenAiConfig
-	(*ProxyAuthenticationRequest)(nil),  // 9: plrl.ProxyAuthenticationRequest
-	(*ProxyAuthenticationResponse)(nil), // 10: plrl.ProxyAuthenticationResponse
-	(*VerifyClusterRequest)(nil),        // 11: plrl.VerifyClusterRequest
-	(*VerifyClusterResponse)(nil),       // 12: plrl.VerifyClusterResponse
-	(*ObservabilityConfig)(nil),         // 13: plrl.ObservabilityConfig
-	(*MeterMetricsRequest)(nil),         // 14: plrl.MeterMetricsRequest
-	(*MeterMetricsResponse)(nil),        // 15: plrl.MeterMetricsResponse
-	(*ObservabilityConfigRequest)(nil),  // 16: plrl.ObservabilityConfigRequest
-	nil,                                 // 17: plrl.BedrockConfig.DeploymentsEntry
-	nil,                                 // 18: plrl.AzureOpenAiConfig.DeploymentsEntry
+	(*GuardrailsConfig)(nil),            // 3: plrl.GuardrailsConfig
+	(*PiiPattern)(nil),                  // 4: plrl.PiiPattern
+	(*BlockedTopic)(nil),                // 5: plrl.BlockedTopic
+	(*OpenAiTokenExchange)(nil),         // 6: plrl.OpenAiTokenExchange
+	(*OpenAiConfig)(nil),                // 7: plrl.OpenAiConfig
+	(*AnthropicConfig)(nil),             // 8: plrl.AnthropicConfig
+	(*VertexAiConfig)(nil),              // 9: plrl.VertexAiConfig
+	(*BedrockConfig)(nil),               // 10: plrl.BedrockConfig
+	(*AzureOpenAiConfig)(nil),           // 11: plrl.AzureOpenAiConfig
+	(*ProxyAuthenticationRequest)(nil),  // 12: plrl.ProxyAuthenticationRequest
+	(*ProxyAuthenticationResponse)(nil), // 13: plrl.ProxyAuthenticationResponse
+	(*VerifyClusterRequest)(nil),        // 14: plrl.VerifyClusterRequest
+	(*VerifyClusterResponse)(nil),       // 15: plrl.VerifyClusterResponse
+	(*ObservabilityConfig)(nil),         // 16: plrl.ObservabilityConfig
+	(*MeterMetricsRequest)(nil),         // 17: plrl.MeterMetricsRequest
+	(*MeterMetricsResponse)(nil),        // 18: plrl.MeterMetricsResponse
+	(*AiUsage)(nil),                     // 19: plrl.AiUsage
+	(*MeterAiUsageRequest)(nil),         // 20: plrl.MeterAiUsageRequest
+	(*MeterAiUsageResponse)(nil),        // 21: plrl.MeterAiUsageResponse
+	(*ObservabilityConfigRequest)(nil),  // 22: plrl.ObservabilityConfigRequest
+	nil,                                 // 23: plrl.BedrockConfig.DeploymentsEntry
+	nil,                                 // 24: plrl.AzureOpenAiConfig.DeploymentsEntry
 }
 var file_console_proto_depIdxs = []int32{
-	4,  // 0: plrl.AiConfig.openai:type_name -> plrl.OpenAiConfig
-	5,  // 1: plrl.AiConfig.anthropic:type_name -> plrl.AnthropicConfig
-	6,  // 2: plrl.AiConfig.vertexAi:type_name -> plrl.VertexAiConfig
-	7,  // 3: plrl.AiConfig.bedrock:type_name -> plrl.BedrockConfig
-	8,  // 4: plrl.AiConfig.azure:type_name -> plrl.AzureOpenAiConfig
-	4,  // 5: plrl.AiConfig.openaiCompatible:type_name -> plrl.OpenAiConfig
-	4,  // 6: plrl.AiConfig.xai:type_name -> plrl.OpenAiConfig
-	3,  // 7: plrl.OpenAiConfig.tokenExchange:type_name -> plrl.OpenAiTokenExchange
-	0,  // 8: plrl.OpenAiConfig.method:type_name -> plrl.OpenAiMethod
-	17, // 9: plrl.BedrockConfig.deployments:type_name -> plrl.BedrockConfig.DeploymentsEntry
-	18, // 10: plrl.AzureOpenAiConfig.deployments:type_name -> plrl.AzureOpenAiConfig.DeploymentsEntry
-	14, // 11: plrl.PluralServer.MeterMetrics:input_type -> plrl.MeterMetricsRequest
-	1,  // 12: plrl.PluralServer.GetAiConfig:input_type -> plrl.AiConfigRequest
-	16, // 13: plrl.PluralServer.GetObservabilityConfig:input_type -> plrl.ObservabilityConfigRequest
-	9,  // 14: plrl.PluralServer.ProxyAuthentication:input_type -> plrl.ProxyAuthenticationRequest
-	11, // 15: plrl.PluralServer.VerifyCluster:input_type -> plrl.VerifyClusterRequest
-	15, // 16: plrl.PluralServer.MeterMetrics:output_type -> plrl.MeterMetricsResponse
-	2,  // 17: plrl.PluralServer.GetAiConfig:output_type -> plrl.AiConfig
-	13, // 18: plrl.PluralServer.GetObservabilityConfig:output_type -> plrl.ObservabilityConfig
-	10, // 19: plrl.PluralServer.ProxyAuthentication:output_type -> plrl.ProxyAuthenticationResponse
-	12, // 20: plrl.PluralServer.VerifyCluster:output_type -> plrl.VerifyClusterResponse
-	16, // [16:21] is the sub-list for method output_type
-	11, // [11:16] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	7,  // 0: plrl.AiConfig.openai:type_name -> plrl.OpenAiConfig
+	8,  // 1: plrl.AiConfig.anthropic:type_name -> plrl.AnthropicConfig
+	9,  // 2: plrl.AiConfig.vertexAi:type_name -> plrl.VertexAiConfig
+	10, // 3: plrl.AiConfig.bedrock:type_name -> plrl.BedrockConfig
+	11, // 4: plrl.AiConfig.azure:type_name -> plrl.AzureOpenAiConfig
+	7,  // 5: plrl.AiConfig.openaiCompatible:type_name -> plrl.OpenAiConfig
+	7,  // 6: plrl.AiConfig.xai:type_name -> plrl.OpenAiConfig
+	3,  // 7: plrl.AiConfig.guardrails:type_name -> plrl.GuardrailsConfig
+	4,  // 8: plrl.GuardrailsConfig.piiPatterns:type_name -> plrl.PiiPattern
+	5,  // 9: plrl.GuardrailsConfig.blockedTopics:type_name -> plrl.BlockedTopic
+	6,  // 10: plrl.OpenAiConfig.tokenExchange:type_name -> plrl.OpenAiTokenExchange
+	0,  // 11: plrl.OpenAiConfig.method:type_name -> plrl.OpenAiMethod
+	23, // 12: plrl.BedrockConfig.deployments:type_name -> plrl.BedrockConfig.DeploymentsEntry
+	24, // 13: plrl.AzureOpenAiConfig.deployments:type_name -> plrl.AzureOpenAiConfig.DeploymentsEntry
+	19, // 14: plrl.MeterAiUsageRequest.usage:type_name -> plrl.AiUsage
+	17, // 15: plrl.PluralServer.MeterMetrics:input_type -> plrl.MeterMetricsRequest
+	20, // 16: plrl.PluralServer.MeterAiUsage:input_type -> plrl.MeterAiUsageRequest
+	1,  // 17: plrl.PluralServer.GetAiConfig:input_type -> plrl.AiConfigRequest
+	22, // 18: plrl.PluralServer.GetObservabilityConfig:input_type -> plrl.ObservabilityConfigRequest
+	12, // 19: plrl.PluralServer.ProxyAuthentication:input_type -> plrl.ProxyAuthenticationRequest
+	14, // 20: plrl.PluralServer.VerifyCluster:input_type -> plrl.VerifyClusterRequest
+	18, // 21: plrl.PluralServer.MeterMetrics:output_type -> plrl.MeterMetricsResponse
+	21, // 22: plrl.PluralServer.MeterAiUsage:output_type -> plrl.MeterAiUsageResponse
+	2,  // 23: plrl.PluralServer.GetAiConfig:output_type -> plrl.AiConfig
+	16, // 24: plrl.PluralServer.GetObservabilityConfig:output_type -> plrl.ObservabilityConfig
+	13, // 25: plrl.PluralServer.ProxyAuthentication:output_type -> plrl.ProxyAuthenticationResponse
+	15, // 26: plrl.PluralServer.VerifyCluster:output_type -> plrl.VerifyClusterResponse
+	21, // [21:27] is the sub-list for method output_type
+	15, // [15:21] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_console_proto_init() }
@@ -1410,19 +1891,22 @@ func file_console_proto_init() {
 		return
 	}
 	file_console_proto_msgTypes[2].OneofWrappers = []any{}
-	file_console_proto_msgTypes[3].OneofWrappers = []any{}
-	file_console_proto_msgTypes[4].OneofWrappers = []any{}
 	file_console_proto_msgTypes[5].OneofWrappers = []any{}
 	file_console_proto_msgTypes[6].OneofWrappers = []any{}
 	file_console_proto_msgTypes[7].OneofWrappers = []any{}
+	file_console_proto_msgTypes[8].OneofWrappers = []any{}
+	file_console_proto_msgTypes[9].OneofWrappers = []any{}
+	file_console_proto_msgTypes[10].OneofWrappers = []any{}
 	file_console_proto_msgTypes[12].OneofWrappers = []any{}
+	file_console_proto_msgTypes[15].OneofWrappers = []any{}
+	file_console_proto_msgTypes[18].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_console_proto_rawDesc), len(file_console_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   18,
+			NumMessages:   24,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
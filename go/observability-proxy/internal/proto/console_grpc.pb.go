@@ -20,6 +20,7 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	PluralServer_MeterMetrics_FullMethodName           = "/plrl.PluralServer/MeterMetrics"
+	PluralServer_MeterAiUsage_FullMethodName           = "/plrl.PluralServer/MeterAiUsage"
 	PluralServer_GetAiConfig_FullMethodName            = "/plrl.PluralServer/GetAiConfig"
 	PluralServer_GetObservabilityConfig_FullMethodName = "/plrl.PluralServer/GetObservabilityConfig"
 	PluralServer_ProxyAuthentication_FullMethodName    = "/plrl.PluralServer/ProxyAuthentication"
@@ -33,6 +34,7 @@ const (
 // The AI configuration service definition.
 type PluralServerClient interface {
 	MeterMetrics(ctx context.Context, in *MeterMetricsRequest, opts ...grpc.CallOption) (*MeterMetricsResponse, error)
+	MeterAiUsage(ctx context.Context, in *MeterAiUsageRequest, opts ...grpc.CallOption) (*MeterAiUsageResponse, error)
 	GetAiConfig(ctx context.Context, in *AiConfigRequest, opts ...grpc.CallOption) (*AiConfig, error)
 	GetObservabilityConfig(ctx context.Context, in *ObservabilityConfigRequest, opts ...grpc.CallOption) (*ObservabilityConfig, error)
 	ProxyAuthentication(ctx context.Context, in *ProxyAuthenticationRequest, opts ...grpc.CallOption) (*ProxyAuthenticationResponse, error)
@@ -57,6 +59,16 @@ func (c *pluralServerClient) MeterMetrics(ctx context.Context, in *MeterMetricsR
 	return out, nil
 }
 
+func (c *pluralServerClient) MeterAiUsage(ctx context.Context, in *MeterAiUsageRequest, opts ...grpc.CallOption) (*MeterAiUsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MeterAiUsageResponse)
+	err := c.cc.Invoke(ctx, PluralServer_MeterAiUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *pluralServerClient) GetAiConfig(ctx context.Context, in *AiConfigRequest, opts ...grpc.CallOption) (*AiConfig, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AiConfig)
@@ -104,6 +116,7 @@ func (c *pluralServerClient) VerifyCluster(ctx context.Context, in *VerifyCluste
 // The AI configuration service definition.
 type PluralServerServer interface {
 	MeterMetrics(context.Context, *MeterMetricsRequest) (*MeterMetricsResponse, error)
+	MeterAiUsage(context.Context, *MeterAiUsageRequest) (*MeterAiUsageResponse, error)
 	GetAiConfig(context.Context, *AiConfigRequest) (*AiConfig, error)
 	GetObservabilityConfig(context.Context, *ObservabilityConfigRequest) (*ObservabilityConfig, error)
 	ProxyAuthentication(context.Context, *ProxyAuthenticationRequest) (*ProxyAuthenticationResponse, error)
@@ -121,6 +134,9 @@ type UnimplementedPluralServerServer struct{}
 func (UnimplementedPluralServerServer) MeterMetrics(context.Context, *MeterMetricsRequest) (*MeterMetricsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method MeterMetrics not implemented")
 }
+func (UnimplementedPluralServerServer) MeterAiUsage(context.Context, *MeterAiUsageRequest) (*MeterAiUsageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MeterAiUsage not implemented")
+}
 func (UnimplementedPluralServerServer) GetAiConfig(context.Context, *AiConfigRequest) (*AiConfig, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetAiConfig not implemented")
 }
@@ -172,6 +188,24 @@ func _PluralServer_MeterMetrics_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PluralServer_MeterAiUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MeterAiUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluralServerServer).MeterAiUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PluralServer_MeterAiUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluralServerServer).MeterAiUsage(ctx, req.(*MeterAiUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PluralServer_GetAiConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AiConfigRequest)
 	if err := dec(in); err != nil {
@@ -255,6 +289,10 @@ var PluralServer_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "MeterMetrics",
 			Handler:    _PluralServer_MeterMetrics_Handler,
 		},
+		{
+			MethodName: "MeterAiUsage",
+			Handler:    _PluralServer_MeterAiUsage_Handler,
+		},
 		{
 			MethodName: "GetAiConfig",
 			Handler:    _PluralServer_GetAiConfig_Handler,
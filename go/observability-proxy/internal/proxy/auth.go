@@ -0,0 +1,73 @@
+package proxy
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/pluralsh/console/go/observability-proxy/internal/console"
+	"github.com/pluralsh/console/go/observability-proxy/internal/logging"
+	"k8s.io/klog/v2"
+)
+
+// ClusterLabel is the label and document field that identifies the cluster that
+// shipped a series or a document. Callers cannot set it themselves.
+const ClusterLabel = "cluster"
+
+type clusterContextKey struct{}
+
+// ClusterFromContext returns the authenticated cluster of a request.
+func ClusterFromContext(ctx context.Context) (console.Cluster, bool) {
+	cluster, ok := ctx.Value(clusterContextKey{}).(console.Cluster)
+	return cluster, ok
+}
+
+// authenticate rejects requests that do not carry a valid cluster deploy token and
+// stores the cluster identity in the request context.
+func (h *Handler) authenticate(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cluster, err := h.verifier.VerifyCluster(r.Context(), clusterToken(r))
+		if err != nil {
+			if errors.Is(err, console.ErrUnauthenticated) {
+				klog.V(logging.LevelVerbose).Infof("rejecting unauthenticated request method=%s path=%s", r.Method, r.URL.Path)
+				w.Header().Set("WWW-Authenticate", `Bearer realm="observability-proxy"`)
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			http.Error(w, "cluster verification unavailable", http.StatusServiceUnavailable)
+			return
+		}
+
+		klog.V(logging.LevelTrace).Infof("authenticated cluster=%s method=%s path=%s", cluster.ID, r.Method, r.URL.Path)
+		next(w, r.WithContext(context.WithValue(r.Context(), clusterContextKey{}, cluster)))
+	}
+}
+
+// clusterToken reads the deploy token from a bearer token, or from the password of
+// basic auth for clients that only support it (e.g. Elastic shippers).
+func clusterToken(r *http.Request) string {
+	if _, password, ok := r.BasicAuth(); ok {
+		return password
+	}
+
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+
+	return strings.TrimSpace(token)
+}
+
+// setUpstreamAuth replaces the cluster credentials with the upstream ones, so
+// deploy tokens never leave the proxy.
+func setUpstreamAuth(header http.Header, username, password string) {
+	header.Del("Authorization")
+	if username == "" && password == "" {
+		return
+	}
+
+	header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(username+":"+password)))
+}
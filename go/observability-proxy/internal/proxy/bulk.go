@@ -0,0 +1,174 @@
+package proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// bulkActionKeys are the action metadata that could address documents of other
+// clusters, they are rejected rather than silently dropped.
+var bulkActionKeys = map[string]struct{}{
+	"_id": {}, "routing": {}, "_routing": {},
+}
+
+// SetBulkField rewrites an Elasticsearch _bulk NDJSON body so every document carries
+// field=value, replacing any value sent by the client. Only index and create actions
+// are accepted, updates and deletes could modify documents of other clusters. Every
+// action is rewritten to a create into index, so clients can neither pick the index
+// nor overwrite existing documents by ID.
+func SetBulkField(body []byte, index, field, value string) ([]byte, error) {
+	encodedValue, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	action, err := bulkCreateAction(index)
+	if err != nil {
+		return nil, err
+	}
+
+	out := bytes.NewBuffer(make([]byte, 0, len(body)+len(body)/8))
+	expectDocument := false
+	for lineNumber, line := range bytes.Split(body, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		if !expectDocument {
+			name, metadata, err := bulkAction(line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNumber+1, err)
+			}
+			if name != "index" && name != "create" {
+				return nil, fmt.Errorf("line %d: unsupported bulk action %q", lineNumber+1, name)
+			}
+			for key := range metadata {
+				if _, ok := bulkActionKeys[key]; ok {
+					return nil, fmt.Errorf("line %d: bulk actions cannot set %q", lineNumber+1, key)
+				}
+			}
+
+			out.Write(action)
+			out.WriteByte('\n')
+			expectDocument = true
+			continue
+		}
+
+		var doc map[string]json.RawMessage
+		if err := json.Unmarshal(line, &doc); err != nil {
+			return nil, fmt.Errorf("line %d: invalid document: %w", lineNumber+1, err)
+		}
+		if doc == nil {
+			return nil, fmt.Errorf("line %d: document must be an object", lineNumber+1)
+		}
+		doc[field] = encodedValue
+
+		encoded, err := json.Marshal(doc)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber+1, err)
+		}
+		out.Write(encoded)
+		out.WriteByte('\n')
+		expectDocument = false
+	}
+
+	if expectDocument {
+		return nil, fmt.Errorf("bulk action is missing its document")
+	}
+
+	return out.Bytes(), nil
+}
+
+func bulkAction(line []byte) (string, map[string]json.RawMessage, error) {
+	var action map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(line, &action); err != nil {
+		return "", nil, fmt.Errorf("invalid bulk action: %w", err)
+	}
+	if len(action) != 1 {
+		return "", nil, fmt.Errorf("bulk action must have exactly one key")
+	}
+
+	for name, metadata := range action {
+		return name, metadata, nil
+	}
+
+	return "", nil, nil
+}
+
+// bulkCreateAction encodes a create action into index. Create is used rather than
+// index so documents can also be written to data streams.
+func bulkCreateAction(index string) ([]byte, error) {
+	return json.Marshal(map[string]map[string]string{"create": {"_index": index}})
+}
+
+// encodeBulk encodes documents as _bulk create actions into index
+func encodeBulk(index string, docs []map[string]any) ([]byte, error) {
+	action, err := bulkCreateAction(index)
+	if err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	for _, doc := range docs {
+		encoded, err := json.Marshal(doc)
+		if err != nil {
+			return nil, err
+		}
+		out.Write(action)
+		out.WriteByte('\n')
+		out.Write(encoded)
+		out.WriteByte('\n')
+	}
+
+	return out.Bytes(), nil
+}
+
+// bulkRejections summarizes the items of a _bulk response Elastic did not write. A bulk
+// request succeeds as a whole even when some or all of its items fail, their results
+// are only reported per item.
+type bulkRejections struct {
+	rejected  int
+	throttled int
+	reason    string
+}
+
+// parseBulkResponse counts the failed items of a _bulk response
+func parseBulkResponse(body []byte) (bulkRejections, error) {
+	var resp struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			Status int `json:"status"`
+			Error  struct {
+				Type   string `json:"type"`
+				Reason string `json:"reason"`
+			} `json:"error"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return bulkRejections{}, err
+	}
+
+	var result bulkRejections
+	if !resp.Errors {
+		return result, nil
+	}
+
+	for _, item := range resp.Items {
+		for _, action := range item {
+			if action.Status < 300 {
+				continue
+			}
+			result.rejected++
+			if action.Status == http.StatusTooManyRequests {
+				result.throttled++
+			}
+			if result.reason == "" {
+				result.reason = fmt.Sprintf("%s: %s", action.Error.Type, action.Error.Reason)
+			}
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,80 @@
+package proxy
+
+import "testing"
+
+func TestSetBulkField(t *testing.T) {
+	body := "{\"index\":{\"_index\":\"other-cluster-logs\",\"pipeline\":\"reroute\"}}\n" +
+		"{\"message\":\"a\",\"cluster\":\"spoofed\"}\n" +
+		"\n" +
+		"{\"create\":{}}\n" +
+		"{\"message\":\"b\",\"count\":12345678901234567890}"
+
+	got, err := SetBulkField([]byte(body), "logs-write", "cluster", "c1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := "{\"create\":{\"_index\":\"logs-write\"}}\n" +
+		"{\"cluster\":\"c1\",\"message\":\"a\"}\n" +
+		"{\"create\":{\"_index\":\"logs-write\"}}\n" +
+		"{\"cluster\":\"c1\",\"count\":12345678901234567890,\"message\":\"b\"}\n"
+	if string(got) != want {
+		t.Fatalf("unexpected body: got %q want %q", got, want)
+	}
+}
+
+func TestSetBulkFieldInvalid(t *testing.T) {
+	cases := map[string]string{
+		"delete":           "{\"delete\":{\"_id\":\"1\"}}\n",
+		"update":           "{\"update\":{\"_id\":\"1\"}}\n{\"doc\":{\"cluster\":\"other\"}}\n",
+		"missing document": "{\"index\":{}}\n",
+		"array document":   "{\"index\":{}}\n[1]\n",
+		"null document":    "{\"index\":{}}\nnull\n",
+		"invalid action":   "{\"index\":{},\"create\":{}}\n{}\n",
+		"existing id":      "{\"index\":{\"_index\":\"logs\",\"_id\":\"doc-of-other-cluster\"}}\n{\"message\":\"x\"}\n",
+		"create with id":   "{\"create\":{\"_id\":\"1\"}}\n{}\n",
+		"routing":          "{\"index\":{\"routing\":\"shard\"}}\n{}\n",
+	}
+
+	for name, body := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := SetBulkField([]byte(body), "logs-write", "cluster", "c1"); err == nil {
+				t.Fatalf("expected error")
+			}
+		})
+	}
+}
+
+func TestParseBulkResponse(t *testing.T) {
+	cases := []struct {
+		name string
+		body string
+		want bulkRejections
+	}{
+		{
+			name: "success",
+			body: `{"errors":false,"items":[{"create":{"status":201}}]}`,
+		},
+		{
+			name: "partial",
+			body: `{"errors":true,"items":[{"create":{"status":201}},{"create":{"status":400,"error":{"type":"mapper_parsing_exception","reason":"failed to parse field [status]"}}},{"create":{"status":429,"error":{"type":"es_rejected_execution_exception","reason":"queue full"}}}]}`,
+			want: bulkRejections{rejected: 2, throttled: 1, reason: "mapper_parsing_exception: failed to parse field [status]"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseBulkResponse([]byte(tc.body))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("unexpected rejections: got %+v want %+v", got, tc.want)
+			}
+		})
+	}
+
+	if _, err := parseBulkResponse([]byte("not json")); err == nil {
+		t.Fatalf("expected an error for an invalid response")
+	}
+}
@@ -1,9 +1,13 @@
 package proxy
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"errors"
+	"fmt"
 	"io"
+	"mime"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
@@ -15,14 +19,20 @@ import (
 	"k8s.io/klog/v2"
 )
 
-// Handler serves observability ingest and query proxy endpoints.
+// maxIngestBodySize bounds the request bodies read into memory to be rewritten.
+const maxIngestBodySize = 64 << 20
+
+// Handler serves observability ingest and query proxy endpoints. Every request is
+// authenticated as a cluster, whose identity is stamped onto ingested data and
+// enforced on queries.
 type Handler struct {
 	configProvider console.ConfigProvider
+	verifier       console.ClusterVerifier
 	transport      *http.Transport
 	recordBytes    func(int64)
 }
 
-func NewHandler(provider console.ConfigProvider, upstreamTimeout time.Duration, recordBytes func(int64)) *Handler {
+func NewHandler(provider console.ConfigProvider, verifier console.ClusterVerifier, upstreamTimeout time.Duration, recordBytes func(int64)) *Handler {
 	transport := http.DefaultTransport.(*http.Transport).Clone()
 	transport.ResponseHeaderTimeout = upstreamTimeout
 	if recordBytes == nil {
@@ -31,6 +41,7 @@ func NewHandler(provider console.ConfigProvider, upstreamTimeout time.Duration,
 
 	return &Handler{
 		configProvider: provider,
+		verifier:       verifier,
 		transport:      transport,
 		recordBytes:    recordBytes,
 	}
@@ -38,11 +49,15 @@ func NewHandler(provider console.ConfigProvider, upstreamTimeout time.Duration,
 
 func (h *Handler) Register(mux *http.ServeMux) {
 	klog.V(logging.LevelInfo).Infof("registering observability proxy routes")
-	mux.HandleFunc("/ext/v1/ingest/prometheus", h.prometheusIngest)
-	mux.HandleFunc("/ext/v1/ingest/elastic", h.elasticIngest)
-	mux.HandleFunc("/ext/v1/ingest/elastic/", h.elasticIngest)
-	mux.HandleFunc("/ext/v1/query/prometheus", h.prometheusQuery)
-	mux.HandleFunc("/ext/v1/query/prometheus/", h.prometheusQuery)
+	mux.HandleFunc("/ext/v1/ingest/prometheus", h.authenticate(h.prometheusIngest))
+	mux.HandleFunc("/ext/v1/ingest/elastic", h.authenticate(h.elasticIngest))
+	mux.HandleFunc("/ext/v1/ingest/elastic/", h.authenticate(h.elasticIngest))
+	mux.HandleFunc(otlpPrefix+"/v1/metrics", h.authenticate(h.otlpMetrics))
+	mux.HandleFunc(otlpPrefix+"/v1/logs", h.authenticate(h.otlpLogs))
+	mux.HandleFunc(otlpPrefix+"/v1/traces", h.authenticate(h.otlpTraces))
+	mux.HandleFunc(lokiPushPath, h.authenticate(h.lokiPush))
+	mux.HandleFunc("/ext/v1/query/prometheus", h.authenticate(h.prometheusQuery))
+	mux.HandleFunc("/ext/v1/query/prometheus/", h.authenticate(h.prometheusQuery))
 }
 
 func (h *Handler) prometheusIngest(w http.ResponseWriter, r *http.Request) {
@@ -65,7 +80,26 @@ func (h *Handler) prometheusIngest(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.forward(w, r, target)
+	if err := checkRemoteWriteFormat(r.Header.Get("Content-Type"), r.Header.Get("Content-Encoding")); err != nil {
+		http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	body, ok := readBody(w, r)
+	if !ok {
+		return
+	}
+
+	cluster, _ := ClusterFromContext(r.Context())
+	body, err = SetRemoteWriteLabel(body, ClusterLabel, cluster.ID)
+	if err != nil {
+		klog.V(logging.LevelVerbose).Infof("rejecting invalid remote-write request cluster=%s: %v", cluster.ID, err)
+		http.Error(w, "invalid remote-write request", http.StatusBadRequest)
+		return
+	}
+
+	replaceBody(r, body)
+	h.forward(w, r, target, cfg.PrometheusUsername, cfg.PrometheusPassword)
 }
 
 func (h *Handler) elasticIngest(w http.ResponseWriter, r *http.Request) {
@@ -100,7 +134,56 @@ func (h *Handler) elasticIngest(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.forward(w, r, target)
+	if mappedSuffix == "/_bulk" {
+		index, err := ElasticWriteIndex(cfg.ElasticIndex)
+		if err != nil {
+			klog.Errorf("invalid elastic index: %v", err)
+			http.Error(w, "elastic index unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		if !h.stampBulk(w, r, index) {
+			return
+		}
+	}
+
+	h.forward(w, r, target, cfg.ElasticUsername, cfg.ElasticPassword)
+}
+
+// stampBulk injects the cluster field into every document of a _bulk request and
+// writes them to index. Query parameters such as pipeline or routing are dropped.
+func (h *Handler) stampBulk(w http.ResponseWriter, r *http.Request, index string) bool {
+	encoding := r.Header.Get("Content-Encoding")
+	if encoding != "" && !strings.EqualFold(encoding, "gzip") {
+		http.Error(w, fmt.Sprintf("unsupported content encoding %q", encoding), http.StatusUnsupportedMediaType)
+		return false
+	}
+
+	body, ok := readBody(w, r)
+	if !ok {
+		return false
+	}
+
+	if encoding != "" {
+		decoded, err := gunzip(body)
+		if err != nil {
+			http.Error(w, "invalid gzip body", http.StatusBadRequest)
+			return false
+		}
+		body = decoded
+		r.Header.Del("Content-Encoding")
+	}
+
+	cluster, _ := ClusterFromContext(r.Context())
+	body, err := SetBulkField(body, index, ClusterLabel, cluster.ID)
+	if err != nil {
+		klog.V(logging.LevelVerbose).Infof("rejecting invalid bulk request cluster=%s: %v", cluster.ID, err)
+		http.Error(w, fmt.Sprintf("invalid bulk request: %v", err), http.StatusBadRequest)
+		return false
+	}
+
+	r.URL.RawQuery = ""
+	replaceBody(r, body)
+	return true
 }
 
 func (h *Handler) prometheusQuery(w http.ResponseWriter, r *http.Request) {
@@ -129,10 +212,63 @@ func (h *Handler) prometheusQuery(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.forward(w, r, target)
+	if !h.restrictQuery(w, r) {
+		return
+	}
+
+	h.forward(w, r, target, cfg.PrometheusUsername, cfg.PrometheusPassword)
 }
 
-func (h *Handler) forward(w http.ResponseWriter, r *http.Request, target *url.URL) {
+// restrictQuery rewrites the selectors of a query API request so it only reads the
+// series of the authenticated cluster
+func (h *Handler) restrictQuery(w http.ResponseWriter, r *http.Request) bool {
+	api, ok := lookupQueryAPI(strings.TrimPrefix(r.URL.Path, queryPrefix))
+	if !ok {
+		http.NotFound(w, r)
+		return false
+	}
+
+	cluster, _ := ClusterFromContext(r.Context())
+	query := r.URL.Query()
+	if err := api.Restrict(query, ClusterLabel, cluster.ID); err != nil {
+		http.Error(w, fmt.Sprintf("invalid query: %v", err), http.StatusBadRequest)
+		return false
+	}
+
+	var form url.Values
+	if r.Method == http.MethodPost {
+		body, ok := readBody(w, r)
+		if !ok {
+			return false
+		}
+
+		if len(body) > 0 {
+			mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			if mediaType != "application/x-www-form-urlencoded" {
+				http.Error(w, "unsupported content type", http.StatusUnsupportedMediaType)
+				return false
+			}
+
+			parsed, err := url.ParseQuery(string(body))
+			if err != nil {
+				http.Error(w, "invalid form body", http.StatusBadRequest)
+				return false
+			}
+			form = parsed
+			if err := api.Restrict(form, ClusterLabel, cluster.ID); err != nil {
+				http.Error(w, fmt.Sprintf("invalid query: %v", err), http.StatusBadRequest)
+				return false
+			}
+		}
+		replaceBody(r, []byte(form.Encode()))
+	}
+
+	api.RequireMatch(query, form, ClusterLabel, cluster.ID)
+	r.URL.RawQuery = query.Encode()
+	return true
+}
+
+func (h *Handler) forward(w http.ResponseWriter, r *http.Request, target *url.URL, username, password string) {
 	klog.V(logging.LevelDebug).Infof(
 		"forwarding request method=%s path=%s upstream=%s://%s%s",
 		r.Method,
@@ -151,6 +287,7 @@ func (h *Handler) forward(w http.ResponseWriter, r *http.Request, target *url.UR
 			preq.Out.URL.Path = target.Path
 			preq.Out.URL.RawPath = target.RawPath
 			preq.Out.Host = target.Host
+			setUpstreamAuth(preq.Out.Header, username, password)
 			preq.SetXForwarded()
 			klog.V(logging.LevelTrace).Infof("rewritten request method=%s out_url=%s", preq.Out.Method, preq.Out.URL.String())
 		},
@@ -174,6 +311,51 @@ func (h *Handler) forward(w http.ResponseWriter, r *http.Request, target *url.UR
 	proxy.ServeHTTP(w, r)
 }
 
+// readBody reads the request body into memory, replying with an error if it fails
+func readBody(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	if r.Body == nil {
+		return nil, true
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxIngestBodySize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+			return nil, false
+		}
+
+		http.Error(w, "failed to read request body", http.StatusBadRequest)
+		return nil, false
+	}
+
+	return body, true
+}
+
+func replaceBody(r *http.Request, body []byte) {
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.ContentLength = int64(len(body))
+	r.Header.Del("Content-Length")
+}
+
+func gunzip(body []byte) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	decoded, err := io.ReadAll(io.LimitReader(reader, maxIngestBodySize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(decoded) > maxIngestBodySize {
+		return nil, fmt.Errorf("decompressed body exceeds %d bytes", maxIngestBodySize)
+	}
+
+	return decoded, nil
+}
+
 type countingReadCloser struct {
 	readCloser io.ReadCloser
 	onRead     func(int64)
@@ -1,11 +1,13 @@
 package proxy
 
 import (
+	"bytes"
 	"context"
 	"io"
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"sync/atomic"
 	"testing"
@@ -14,6 +16,8 @@ import (
 	"github.com/pluralsh/console/go/observability-proxy/internal/console"
 )
 
+const testClusterToken = "deploy-test"
+
 type staticProvider struct {
 	cfg console.ObservabilityConfig
 }
@@ -24,14 +28,29 @@ func (s staticProvider) GetConfig(context.Context) (console.ObservabilityConfig,
 
 func (s staticProvider) Ready() bool { return true }
 
+type staticVerifier struct{}
+
+func (staticVerifier) VerifyCluster(_ context.Context, token string) (console.Cluster, error) {
+	if token != testClusterToken {
+		return console.Cluster{}, console.ErrUnauthenticated
+	}
+	return console.Cluster{ID: "test-id", Name: "test", Handle: "test-handle"}, nil
+}
+
+func newClusterRequest(method, target string, body io.Reader) *http.Request {
+	req := httptest.NewRequest(method, target, body)
+	req.Header.Set("Authorization", "Bearer "+testClusterToken)
+	return req
+}
+
 func TestElasticRouteValidation(t *testing.T) {
 	provider := staticProvider{cfg: console.ObservabilityConfig{PrometheusHost: "http://example.com/select/t/prometheus", ElasticHost: "http://example.com"}}
-	handler := NewHandler(provider, 5*time.Second, nil)
+	handler := NewHandler(provider, staticVerifier{}, 5*time.Second, nil)
 
 	mux := http.NewServeMux()
 	handler.Register(mux)
 
-	req := httptest.NewRequest(http.MethodDelete, "/ext/v1/ingest/elastic/_bulk", nil)
+	req := newClusterRequest(http.MethodDelete, "/ext/v1/ingest/elastic/_bulk", nil)
 	rec := httptest.NewRecorder()
 
 	mux.ServeHTTP(rec, req)
@@ -54,12 +73,12 @@ func TestPrometheusIngestForwardsExpectedUpstreamPath(t *testing.T) {
 			ElasticHost:    "http://example.com",
 		},
 	}
-	handler := NewHandler(provider, 5*time.Second, nil)
+	handler := NewHandler(provider, staticVerifier{}, 5*time.Second, nil)
 
 	mux := http.NewServeMux()
 	handler.Register(mux)
 
-	req := httptest.NewRequest(http.MethodPost, "/ext/v1/ingest/prometheus", strings.NewReader(""))
+	req := newClusterRequest(http.MethodPost, "/ext/v1/ingest/prometheus", bytes.NewReader(remoteWriteBody(t)))
 	rec := httptest.NewRecorder()
 	mux.ServeHTTP(rec, req)
 
@@ -87,12 +106,12 @@ func TestPrometheusQueryForwardsMappedPathAndQuery(t *testing.T) {
 			ElasticHost:    "http://example.com",
 		},
 	}
-	handler := NewHandler(provider, 5*time.Second, nil)
+	handler := NewHandler(provider, staticVerifier{}, 5*time.Second, nil)
 
 	mux := http.NewServeMux()
 	handler.Register(mux)
 
-	req := httptest.NewRequest(http.MethodGet, "/ext/v1/query/prometheus/api/v1/query?query=up", nil)
+	req := newClusterRequest(http.MethodGet, "/ext/v1/query/prometheus/api/v1/query?query=up", nil)
 	rec := httptest.NewRecorder()
 	mux.ServeHTTP(rec, req)
 
@@ -102,7 +121,7 @@ func TestPrometheusQueryForwardsMappedPathAndQuery(t *testing.T) {
 	if gotPath != "/select/t/prometheus/api/v1/query" {
 		t.Fatalf("unexpected upstream path: got %q", gotPath)
 	}
-	if gotQuery != "query=up" {
+	if want := (url.Values{"query": {`up{cluster="test-id"}`}}).Encode(); gotQuery != want {
 		t.Fatalf("unexpected upstream query: got %q", gotQuery)
 	}
 }
@@ -110,8 +129,10 @@ func TestPrometheusQueryForwardsMappedPathAndQuery(t *testing.T) {
 func TestPrometheusIngestCountsRequestBytes(t *testing.T) {
 	var counted atomic.Int64
 	var addCalls atomic.Int64
+	var received atomic.Int64
 	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		_, _ = io.ReadAll(r.Body)
+		body, _ := io.ReadAll(r.Body)
+		received.Add(int64(len(body)))
 		w.WriteHeader(http.StatusNoContent)
 	}))
 	defer upstream.Close()
@@ -122,7 +143,7 @@ func TestPrometheusIngestCountsRequestBytes(t *testing.T) {
 			ElasticHost:    "http://example.com",
 		},
 	}
-	handler := NewHandler(provider, 5*time.Second, func(n int64) {
+	handler := NewHandler(provider, staticVerifier{}, 5*time.Second, func(n int64) {
 		addCalls.Add(1)
 		counted.Add(n)
 	})
@@ -130,16 +151,15 @@ func TestPrometheusIngestCountsRequestBytes(t *testing.T) {
 	mux := http.NewServeMux()
 	handler.Register(mux)
 
-	body := "abc123"
-	req := httptest.NewRequest(http.MethodPost, "/ext/v1/ingest/prometheus", strings.NewReader(body))
+	req := newClusterRequest(http.MethodPost, "/ext/v1/ingest/prometheus", bytes.NewReader(remoteWriteBody(t)))
 	rec := httptest.NewRecorder()
 	mux.ServeHTTP(rec, req)
 
 	if rec.Code != http.StatusNoContent {
 		t.Fatalf("unexpected status: got %d want %d", rec.Code, http.StatusNoContent)
 	}
-	if counted.Load() != int64(len(body)) {
-		t.Fatalf("unexpected counted bytes: got %d want %d", counted.Load(), len(body))
+	if counted.Load() != received.Load() {
+		t.Fatalf("unexpected counted bytes: got %d want %d", counted.Load(), received.Load())
 	}
 	if addCalls.Load() != 1 {
 		t.Fatalf("unexpected meter call count: got %d want 1", addCalls.Load())
@@ -162,7 +182,7 @@ func TestPrometheusIngestDoesNotCountRequestBytesWhenUpstreamUnavailable(t *test
 			ElasticHost:    "http://example.com",
 		},
 	}
-	handler := NewHandler(provider, 5*time.Second, func(n int64) {
+	handler := NewHandler(provider, staticVerifier{}, 5*time.Second, func(n int64) {
 		addCalls.Add(1)
 		counted.Add(n)
 	})
@@ -170,8 +190,7 @@ func TestPrometheusIngestDoesNotCountRequestBytesWhenUpstreamUnavailable(t *test
 	mux := http.NewServeMux()
 	handler.Register(mux)
 
-	body := "abc123"
-	req := httptest.NewRequest(http.MethodPost, "/ext/v1/ingest/prometheus", strings.NewReader(body))
+	req := newClusterRequest(http.MethodPost, "/ext/v1/ingest/prometheus", bytes.NewReader(remoteWriteBody(t)))
 	rec := httptest.NewRecorder()
 	mux.ServeHTTP(rec, req)
 
@@ -193,12 +212,12 @@ func TestPrometheusQueryMethodValidation(t *testing.T) {
 			ElasticHost:    "http://example.com",
 		},
 	}
-	handler := NewHandler(provider, 5*time.Second, nil)
+	handler := NewHandler(provider, staticVerifier{}, 5*time.Second, nil)
 
 	mux := http.NewServeMux()
 	handler.Register(mux)
 
-	req := httptest.NewRequest(http.MethodDelete, "/ext/v1/query/prometheus/api/v1/query?query=up", nil)
+	req := newClusterRequest(http.MethodDelete, "/ext/v1/query/prometheus/api/v1/query?query=up", nil)
 	rec := httptest.NewRecorder()
 	mux.ServeHTTP(rec, req)
 
@@ -206,3 +225,156 @@ func TestPrometheusQueryMethodValidation(t *testing.T) {
 		t.Fatalf("unexpected status: got %d want %d", rec.Code, http.StatusMethodNotAllowed)
 	}
 }
+
+func TestIngestRequiresClusterToken(t *testing.T) {
+	provider := staticProvider{cfg: console.ObservabilityConfig{PrometheusHost: "http://example.com/select/t/prometheus", ElasticHost: "http://example.com"}}
+	handler := NewHandler(provider, staticVerifier{}, 5*time.Second, nil)
+
+	mux := http.NewServeMux()
+	handler.Register(mux)
+
+	for _, auth := range []string{"", "Bearer deploy-other", "Basic " + testClusterToken} {
+		req := httptest.NewRequest(http.MethodPost, "/ext/v1/ingest/prometheus", bytes.NewReader(remoteWriteBody(t)))
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusUnauthorized {
+			t.Fatalf("unexpected status for auth %q: got %d want %d", auth, rec.Code, http.StatusUnauthorized)
+		}
+	}
+}
+
+func TestPrometheusIngestStampsClusterLabel(t *testing.T) {
+	var gotAuth string
+	var gotBody []byte
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer upstream.Close()
+
+	provider := staticProvider{
+		cfg: console.ObservabilityConfig{
+			PrometheusHost:     upstream.URL + "/select/t/prometheus",
+			PrometheusUsername: "vm-user",
+			PrometheusPassword: "vm-pass",
+		},
+	}
+	handler := NewHandler(provider, staticVerifier{}, 5*time.Second, nil)
+
+	mux := http.NewServeMux()
+	handler.Register(mux)
+
+	// clusters that only support basic auth send the token as password
+	req := httptest.NewRequest(http.MethodPost, "/ext/v1/ingest/prometheus", bytes.NewReader(remoteWriteBody(t, "cluster", "spoofed", "job", "node")))
+	req.SetBasicAuth("ignored", testClusterToken)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("unexpected status: got %d want %d", rec.Code, http.StatusNoContent)
+	}
+
+	want := &http.Request{Header: http.Header{}}
+	want.SetBasicAuth("vm-user", "vm-pass")
+	if gotAuth != want.Header.Get("Authorization") {
+		t.Fatalf("unexpected upstream authorization: got %q", gotAuth)
+	}
+	if got := remoteWriteLabels(t, gotBody); got != `[__name__=up cluster=test-id job=node]` {
+		t.Fatalf("unexpected upstream labels: got %s", got)
+	}
+}
+
+func TestElasticBulkStampsClusterField(t *testing.T) {
+	var gotBody, gotQuery string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		gotQuery = r.URL.RawQuery
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer upstream.Close()
+
+	provider := staticProvider{cfg: console.ObservabilityConfig{ElasticHost: upstream.URL, ElasticIndex: "plrl-x-logs-*"}}
+	handler := NewHandler(provider, staticVerifier{}, 5*time.Second, nil)
+
+	mux := http.NewServeMux()
+	handler.Register(mux)
+
+	body := "{\"index\":{\"_index\":\"logs\"}}\n{\"message\":\"hello\",\"cluster\":\"spoofed\"}\n"
+	req := newClusterRequest(http.MethodPost, "/ext/v1/ingest/elastic/_bulk?pipeline=reroute", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status: got %d want %d", rec.Code, http.StatusOK)
+	}
+	if want := "{\"create\":{\"_index\":\"plrl-x-logs-write\"}}\n{\"cluster\":\"test-id\",\"message\":\"hello\"}\n"; gotBody != want {
+		t.Fatalf("unexpected upstream body: got %q want %q", gotBody, want)
+	}
+	if gotQuery != "" {
+		t.Fatalf("unexpected upstream query: got %q", gotQuery)
+	}
+
+	req = newClusterRequest(http.MethodPost, "/ext/v1/ingest/elastic/_bulk", strings.NewReader("{\"delete\":{\"_index\":\"logs\",\"_id\":\"1\"}}\n"))
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("unexpected status: got %d want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestPrometheusQueryRestrictsToCluster(t *testing.T) {
+	var gotQuery url.Values
+	var gotForm url.Values
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.Query()
+		_ = r.ParseForm()
+		gotForm = r.PostForm
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer upstream.Close()
+
+	provider := staticProvider{cfg: console.ObservabilityConfig{PrometheusHost: upstream.URL + "/select/t/prometheus"}}
+	handler := NewHandler(provider, staticVerifier{}, 5*time.Second, nil)
+
+	mux := http.NewServeMux()
+	handler.Register(mux)
+
+	req := newClusterRequest(http.MethodGet, "/ext/v1/query/prometheus/api/v1/labels", nil)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status: got %d want %d", rec.Code, http.StatusOK)
+	}
+	if got := gotQuery.Get("match[]"); got != `{cluster="test-id"}` {
+		t.Fatalf("unexpected match[]: got %q", got)
+	}
+
+	form := url.Values{"query": {`sum(rate(http_requests_total{cluster="other"}[5m]))`}}
+	req = newClusterRequest(http.MethodPost, "/ext/v1/query/prometheus/api/v1/query_range", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status: got %d want %d", rec.Code, http.StatusOK)
+	}
+	if got, want := gotForm.Get("query"), `sum(rate(http_requests_total{cluster="other",cluster="test-id"}[5m]))`; got != want {
+		t.Fatalf("unexpected query: got %q want %q", got, want)
+	}
+
+	req = newClusterRequest(http.MethodGet, "/ext/v1/query/prometheus/api/v1/status/tsdb", nil)
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("unexpected status: got %d want %d", rec.Code, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,360 @@
+package proxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/pluralsh/console/go/observability-proxy/internal/logging"
+	"google.golang.org/protobuf/encoding/protowire"
+	"k8s.io/klog/v2"
+)
+
+// lokiPushPath mirrors the Loki push API so clients only need the proxy as base URL.
+const lokiPushPath = "/ext/v1/ingest/loki/api/v1/push"
+
+// Field numbers of the Loki push protobuf messages.
+const (
+	pushRequestStreams      = 1
+	streamLabels            = 1
+	streamEntries           = 2
+	entryTimestamp          = 1
+	entryLine               = 2
+	entryStructuredMetadata = 3
+	labelPairName           = 1
+	labelPairValue          = 2
+	timestampSeconds        = 1
+	timestampNanos          = 2
+)
+
+type lokiEntry struct {
+	timestamp time.Time
+	line      string
+	metadata  map[string]string
+}
+
+type lokiStream struct {
+	labels  map[string]string
+	entries []lokiEntry
+}
+
+// lokiPush translates a Loki push request into Elastic documents.
+func (h *Handler) lokiPush(w http.ResponseWriter, r *http.Request) {
+	klog.V(logging.LevelVerbose).Infof("handling loki push request method=%s path=%s", r.Method, r.URL.Path)
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	target, index, username, password, ok := h.elasticBulkTarget(w, r)
+	if !ok {
+		return
+	}
+
+	body, ok := readEncodedBody(w, r)
+	if !ok {
+		return
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	var streams []lokiStream
+	var err error
+	switch mediaType {
+	case contentTypeProtobuf, "":
+		streams, err = decodeLokiProto(body)
+	case contentTypeJSON:
+		streams, err = decodeLokiJSON(body)
+	default:
+		http.Error(w, "unsupported content type", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	cluster, _ := ClusterFromContext(r.Context())
+	if err != nil {
+		klog.V(logging.LevelVerbose).Infof("rejecting invalid loki push request cluster=%s: %v", cluster.ID, err)
+		http.Error(w, fmt.Sprintf("invalid push request: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	docs := lokiDocuments(streams, cluster.ID)
+	rejections, ok := h.pushDocuments(w, r, target, index, docs, username, password)
+	if !ok {
+		return
+	}
+	if rejections.rejected > 0 {
+		// the push API has no partial success, clients must not retry the written entries
+		http.Error(w, fmt.Sprintf("upstream rejected %d of %d entries: %s", rejections.rejected, len(docs), rejections.reason), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func lokiDocuments(streams []lokiStream, cluster string) []map[string]any {
+	var docs []map[string]any
+	for _, stream := range streams {
+		for _, entry := range stream.entries {
+			doc := map[string]any{
+				"@timestamp": entry.timestamp.UTC().Format(time.RFC3339Nano),
+				"message":    entry.line,
+				signalField:  signalLogs,
+				ClusterLabel: cluster,
+			}
+			if len(stream.labels) > 0 {
+				doc["labels"] = stream.labels
+			}
+			if len(entry.metadata) > 0 {
+				doc["structured_metadata"] = entry.metadata
+			}
+			docs = append(docs, doc)
+		}
+	}
+
+	return docs
+}
+
+// decodeLokiProto decodes a snappy-compressed logproto.PushRequest
+func decodeLokiProto(body []byte) ([]lokiStream, error) {
+	size, err := snappy.DecodedLen(body)
+	if err != nil {
+		return nil, fmt.Errorf("decode snappy: %w", err)
+	}
+	if size > maxIngestBodySize {
+		return nil, fmt.Errorf("decoded push request exceeds %d bytes", maxIngestBodySize)
+	}
+
+	decoded, err := snappy.Decode(nil, body)
+	if err != nil {
+		return nil, fmt.Errorf("decode snappy: %w", err)
+	}
+
+	var streams []lokiStream
+	err = eachBytesField(decoded, pushRequestStreams, func(msg []byte) error {
+		var stream lokiStream
+		err := eachField(msg, func(num protowire.Number, typ protowire.Type, field []byte) error {
+			if typ != protowire.BytesType {
+				return nil
+			}
+			value, n := protowire.ConsumeBytes(field[protowire.SizeTag(num):])
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+
+			switch num {
+			case streamLabels:
+				labels, err := parseLokiLabels(string(value))
+				if err != nil {
+					return err
+				}
+				stream.labels = labels
+			case streamEntries:
+				entry, err := decodeLokiEntry(value)
+				if err != nil {
+					return err
+				}
+				stream.entries = append(stream.entries, entry)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		streams = append(streams, stream)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return streams, nil
+}
+
+func decodeLokiEntry(msg []byte) (lokiEntry, error) {
+	var entry lokiEntry
+	err := eachField(msg, func(num protowire.Number, typ protowire.Type, field []byte) error {
+		if typ != protowire.BytesType {
+			return nil
+		}
+		value, n := protowire.ConsumeBytes(field[protowire.SizeTag(num):])
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+
+		switch num {
+		case entryTimestamp:
+			timestamp, err := decodeTimestamp(value)
+			if err != nil {
+				return err
+			}
+			entry.timestamp = timestamp
+		case entryLine:
+			entry.line = string(value)
+		case entryStructuredMetadata:
+			name, value, err := decodeLabelPair(value)
+			if err != nil {
+				return err
+			}
+			if entry.metadata == nil {
+				entry.metadata = map[string]string{}
+			}
+			entry.metadata[name] = value
+		}
+		return nil
+	})
+
+	return entry, err
+}
+
+// decodeTimestamp decodes a google.protobuf.Timestamp
+func decodeTimestamp(msg []byte) (time.Time, error) {
+	var seconds, nanos int64
+	err := eachField(msg, func(num protowire.Number, typ protowire.Type, field []byte) error {
+		if typ != protowire.VarintType {
+			return nil
+		}
+		value, n := protowire.ConsumeVarint(field[protowire.SizeTag(num):])
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+
+		switch num {
+		case timestampSeconds:
+			seconds = int64(value)
+		case timestampNanos:
+			nanos = int64(int32(value))
+		}
+		return nil
+	})
+
+	return time.Unix(seconds, nanos), err
+}
+
+func decodeLabelPair(msg []byte) (name, value string, err error) {
+	err = eachField(msg, func(num protowire.Number, typ protowire.Type, field []byte) error {
+		if typ != protowire.BytesType {
+			return nil
+		}
+		text, n := protowire.ConsumeString(field[protowire.SizeTag(num):])
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+
+		switch num {
+		case labelPairName:
+			name = text
+		case labelPairValue:
+			value = text
+		}
+		return nil
+	})
+
+	return name, value, err
+}
+
+// eachBytesField calls fn with the contents of every length-delimited field num
+func eachBytesField(msg []byte, num protowire.Number, fn func(value []byte) error) error {
+	return eachField(msg, func(current protowire.Number, typ protowire.Type, field []byte) error {
+		if current != num || typ != protowire.BytesType {
+			return nil
+		}
+		value, n := protowire.ConsumeBytes(field[protowire.SizeTag(current):])
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+
+		return fn(value)
+	})
+}
+
+// decodeLokiJSON decodes the JSON push format, whose values are
+// [unix nanoseconds, line] pairs with optional structured metadata
+func decodeLokiJSON(body []byte) ([]lokiStream, error) {
+	var request struct {
+		Streams []struct {
+			Stream map[string]string   `json:"stream"`
+			Values [][]json.RawMessage `json:"values"`
+		} `json:"streams"`
+	}
+	if err := json.Unmarshal(body, &request); err != nil {
+		return nil, err
+	}
+
+	streams := make([]lokiStream, 0, len(request.Streams))
+	for _, s := range request.Streams {
+		stream := lokiStream{labels: s.Stream}
+		for _, value := range s.Values {
+			if len(value) < 2 || len(value) > 3 {
+				return nil, fmt.Errorf("entry must be [timestamp, line] or [timestamp, line, metadata]")
+			}
+
+			var entry lokiEntry
+			var timestamp string
+			if err := json.Unmarshal(value[0], &timestamp); err != nil {
+				return nil, fmt.Errorf("invalid timestamp: %w", err)
+			}
+			nanos, err := strconv.ParseInt(timestamp, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid timestamp %q", timestamp)
+			}
+			entry.timestamp = time.Unix(0, nanos)
+
+			if err := json.Unmarshal(value[1], &entry.line); err != nil {
+				return nil, fmt.Errorf("invalid line: %w", err)
+			}
+			if len(value) == 3 {
+				if err := json.Unmarshal(value[2], &entry.metadata); err != nil {
+					return nil, fmt.Errorf("invalid structured metadata: %w", err)
+				}
+			}
+			stream.entries = append(stream.entries, entry)
+		}
+		streams = append(streams, stream)
+	}
+
+	return streams, nil
+}
+
+// parseLokiLabels parses a stream selector such as {app="api", env="prod"}
+func parseLokiLabels(selector string) (map[string]string, error) {
+	rest := strings.TrimSpace(selector)
+	if !strings.HasPrefix(rest, "{") || !strings.HasSuffix(rest, "}") {
+		return nil, fmt.Errorf("invalid stream labels %q", selector)
+	}
+	rest = strings.TrimSpace(rest[1 : len(rest)-1])
+
+	labels := map[string]string{}
+	for rest != "" {
+		name, value, ok := strings.Cut(rest, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid stream labels %q", selector)
+		}
+
+		quoted, err := strconv.QuotedPrefix(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid stream labels %q", selector)
+		}
+		unquoted, err := strconv.Unquote(quoted)
+		if err != nil {
+			return nil, fmt.Errorf("invalid stream labels %q", selector)
+		}
+		labels[name] = unquoted
+
+		rest = strings.TrimSpace(strings.TrimSpace(value)[len(quoted):])
+		if rest != "" {
+			var found bool
+			if rest, found = strings.CutPrefix(rest, ","); !found {
+				return nil, fmt.Errorf("invalid stream labels %q", selector)
+			}
+			rest = strings.TrimSpace(rest)
+		}
+	}
+
+	return labels, nil
+}
@@ -0,0 +1,174 @@
+package proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/snappy"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// lokiPushBody encodes a snappy-compressed logproto.PushRequest with one entry
+func lokiPushBody(labels, line string, seconds int64) []byte {
+	timestamp := protowire.AppendTag(nil, timestampSeconds, protowire.VarintType)
+	timestamp = protowire.AppendVarint(timestamp, uint64(seconds))
+
+	metadata := protowire.AppendTag(nil, labelPairName, protowire.BytesType)
+	metadata = protowire.AppendString(metadata, "trace_id")
+	metadata = protowire.AppendTag(metadata, labelPairValue, protowire.BytesType)
+	metadata = protowire.AppendString(metadata, "abc")
+
+	entry := protowire.AppendTag(nil, entryTimestamp, protowire.BytesType)
+	entry = protowire.AppendBytes(entry, timestamp)
+	entry = protowire.AppendTag(entry, entryLine, protowire.BytesType)
+	entry = protowire.AppendString(entry, line)
+	entry = protowire.AppendTag(entry, entryStructuredMetadata, protowire.BytesType)
+	entry = protowire.AppendBytes(entry, metadata)
+
+	stream := protowire.AppendTag(nil, streamLabels, protowire.BytesType)
+	stream = protowire.AppendString(stream, labels)
+	stream = protowire.AppendTag(stream, streamEntries, protowire.BytesType)
+	stream = protowire.AppendBytes(stream, entry)
+
+	request := protowire.AppendTag(nil, pushRequestStreams, protowire.BytesType)
+	request = protowire.AppendBytes(request, stream)
+
+	return snappy.Encode(nil, request)
+}
+
+func TestParseLokiLabels(t *testing.T) {
+	cases := []struct {
+		selector string
+		want     map[string]string
+	}{
+		{selector: `{}`, want: map[string]string{}},
+		{selector: `{app="api"}`, want: map[string]string{"app": "api"}},
+		{selector: ` { app = "api", msg="a,b=\"c\"" } `, want: map[string]string{"app": "api", "msg": `a,b="c"`}},
+	}
+
+	for _, tc := range cases {
+		got, err := parseLokiLabels(tc.selector)
+		if err != nil {
+			t.Fatalf("expected no error for %q, got %v", tc.selector, err)
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("unexpected labels for %q: got %v want %v", tc.selector, got, tc.want)
+		}
+	}
+
+	for _, selector := range []string{`app="api"`, `{app}`, `{app="api"`, `{app="api" env="prod"}`, `{="x"}`} {
+		if _, err := parseLokiLabels(selector); err == nil {
+			t.Fatalf("expected error for %q", selector)
+		}
+	}
+}
+
+func TestLokiPush(t *testing.T) {
+	cases := []struct {
+		name        string
+		contentType string
+		body        []byte
+		metadata    map[string]any
+	}{
+		{
+			name:        "protobuf",
+			contentType: "application/x-protobuf",
+			body:        lokiPushBody(`{app="api", cluster="spoofed"}`, "hello", 1700000000),
+			metadata:    map[string]any{"trace_id": "abc"},
+		},
+		{
+			name:        "json",
+			contentType: "application/json",
+			body:        []byte(`{"streams":[{"stream":{"app":"api","cluster":"spoofed"},"values":[["1700000000000000000","hello",{"trace_id":"abc"}]]}]}`),
+			metadata:    map[string]any{"trace_id": "abc"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			upstream, body, path := captureUpstream(t, http.StatusOK)
+			mux := newOTLPMux(upstream.URL, nil)
+
+			req := newClusterRequest(http.MethodPost, "/ext/v1/ingest/loki/api/v1/push", bytes.NewReader(tc.body))
+			req.Header.Set("Content-Type", tc.contentType)
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusNoContent {
+				t.Fatalf("unexpected status: got %d want %d (%s)", rec.Code, http.StatusNoContent, rec.Body.String())
+			}
+			if *path != "/_bulk" {
+				t.Fatalf("unexpected upstream path: got %q", *path)
+			}
+
+			lines := strings.Split(strings.TrimSpace(string(*body)), "\n")
+			if len(lines) != 2 {
+				t.Fatalf("unexpected bulk body: %q", *body)
+			}
+			var doc map[string]any
+			if err := json.Unmarshal([]byte(lines[1]), &doc); err != nil {
+				t.Fatalf("invalid document: %v", err)
+			}
+			want := map[string]any{
+				"@timestamp":          "2023-11-14T22:13:20Z",
+				"cluster":             "test-id",
+				"signal":              "logs",
+				"message":             "hello",
+				"labels":              map[string]any{"app": "api", "cluster": "spoofed"},
+				"structured_metadata": tc.metadata,
+			}
+			if !reflect.DeepEqual(doc, want) {
+				t.Fatalf("unexpected document: got %v want %v", doc, want)
+			}
+		})
+	}
+}
+
+func TestLokiPushReportsRejectedEntries(t *testing.T) {
+	upstream := bulkUpstream(t, `{"errors":true,"items":[{"create":{"status":400,"error":{"type":"mapper_parsing_exception","reason":"bad field"}}}]}`)
+	mux := newOTLPMux(upstream.URL, nil)
+
+	body := `{"streams":[{"stream":{"app":"api"},"values":[["1700000000000000000","hello"],["1700000000000000001","world"]]}]}`
+	req := newClusterRequest(http.MethodPost, "/ext/v1/ingest/loki/api/v1/push", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "rejected 1 of 2 entries: mapper_parsing_exception: bad field") {
+		t.Fatalf("unexpected response: got %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestLokiPushRejectsInvalidRequests(t *testing.T) {
+	upstream, _, _ := captureUpstream(t, http.StatusOK)
+	mux := newOTLPMux(upstream.URL, nil)
+
+	cases := map[string]struct {
+		contentType string
+		body        []byte
+		want        int
+	}{
+		"not snappy":   {contentType: "application/x-protobuf", body: []byte("plain"), want: http.StatusBadRequest},
+		"bad labels":   {contentType: "application/x-protobuf", body: lokiPushBody(`app="api"`, "x", 1), want: http.StatusBadRequest},
+		"bad json":     {contentType: "application/json", body: []byte(`{"streams":[{"values":[["x","y"]]}]}`), want: http.StatusBadRequest},
+		"content type": {contentType: "text/plain", body: []byte("x"), want: http.StatusUnsupportedMediaType},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			req := newClusterRequest(http.MethodPost, "/ext/v1/ingest/loki/api/v1/push", bytes.NewReader(tc.body))
+			req.Header.Set("Content-Type", tc.contentType)
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			if rec.Code != tc.want {
+				t.Fatalf("unexpected status: got %d want %d", rec.Code, tc.want)
+			}
+		})
+	}
+}
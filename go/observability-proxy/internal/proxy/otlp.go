@@ -0,0 +1,392 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pluralsh/console/go/observability-proxy/internal/logging"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/klog/v2"
+)
+
+// otlpPrefix is the OTLP/HTTP base endpoint, exporters append /v1/{signal} to it.
+const otlpPrefix = "/ext/v1/ingest/otlp"
+
+const (
+	contentTypeProtobuf = "application/x-protobuf"
+	contentTypeJSON     = "application/json"
+)
+
+// otlpMetrics translates an OTLP metrics export into a remote-write request.
+func (h *Handler) otlpMetrics(w http.ResponseWriter, r *http.Request) {
+	klog.V(logging.LevelVerbose).Infof("handling otlp metrics request method=%s path=%s", r.Method, r.URL.Path)
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	cfg, err := h.configProvider.GetConfig(r.Context())
+	if err != nil {
+		http.Error(w, "observability config unavailable", http.StatusServiceUnavailable)
+		return
+	}
+
+	target, err := BuildPrometheusIngestTarget(cfg.PrometheusHost)
+	if err != nil {
+		klog.Errorf("invalid prometheus ingest target: %v", err)
+		http.Error(w, "prometheus ingest target unavailable", http.StatusServiceUnavailable)
+		return
+	}
+
+	// MetricsData is wire compatible with ExportMetricsServiceRequest
+	data := &metricspb.MetricsData{}
+	if !decodeOTLP(w, r, data) {
+		return
+	}
+
+	cluster, _ := ClusterFromContext(r.Context())
+	series := otlpSeries(data, cluster.ID)
+	if len(series) > 0 {
+		header := http.Header{}
+		header.Set("Content-Type", contentTypeProtobuf)
+		header.Set("Content-Encoding", "snappy")
+		header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+		if _, ok := h.push(w, r, target, header, encodeRemoteWrite(series), cfg.PrometheusUsername, cfg.PrometheusPassword); !ok {
+			return
+		}
+	}
+
+	writeOTLPResponse(w, r, nil)
+}
+
+// otlpLogs translates an OTLP logs export into Elastic documents.
+func (h *Handler) otlpLogs(w http.ResponseWriter, r *http.Request) {
+	klog.V(logging.LevelVerbose).Infof("handling otlp logs request method=%s path=%s", r.Method, r.URL.Path)
+	data := &logspb.LogsData{}
+	h.otlpDocuments(w, r, data, "rejectedLogRecords", func(cluster string) []map[string]any {
+		return otlpLogDocuments(data, cluster)
+	})
+}
+
+// otlpTraces translates an OTLP traces export into Elastic documents, one per span.
+func (h *Handler) otlpTraces(w http.ResponseWriter, r *http.Request) {
+	klog.V(logging.LevelVerbose).Infof("handling otlp traces request method=%s path=%s", r.Method, r.URL.Path)
+	data := &tracepb.TracesData{}
+	h.otlpDocuments(w, r, data, "rejectedSpans", func(cluster string) []map[string]any {
+		return otlpSpanDocuments(data, cluster)
+	})
+}
+
+// otlpDocuments writes the documents of an OTLP export to Elastic. Documents Elastic
+// rejects are reported as a partial success under rejectedField.
+func (h *Handler) otlpDocuments(w http.ResponseWriter, r *http.Request, data proto.Message, rejectedField string, documents func(cluster string) []map[string]any) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	target, index, username, password, ok := h.elasticBulkTarget(w, r)
+	if !ok {
+		return
+	}
+
+	if !decodeOTLP(w, r, data) {
+		return
+	}
+
+	cluster, _ := ClusterFromContext(r.Context())
+	rejections, ok := h.pushDocuments(w, r, target, index, documents(cluster.ID), username, password)
+	if !ok {
+		return
+	}
+
+	var partial *otlpPartialSuccess
+	if rejections.rejected > 0 {
+		partial = &otlpPartialSuccess{field: rejectedField, rejected: int64(rejections.rejected), message: rejections.reason}
+	}
+	writeOTLPResponse(w, r, partial)
+}
+
+// elasticBulkTarget resolves the _bulk endpoint and write index of translated documents
+func (h *Handler) elasticBulkTarget(w http.ResponseWriter, r *http.Request) (target *url.URL, index, username, password string, ok bool) {
+	cfg, err := h.configProvider.GetConfig(r.Context())
+	if err != nil {
+		http.Error(w, "observability config unavailable", http.StatusServiceUnavailable)
+		return nil, "", "", "", false
+	}
+
+	target, err = BuildElasticTarget(cfg.ElasticHost, "/_bulk")
+	if err != nil {
+		klog.Errorf("invalid elastic target: %v", err)
+		http.Error(w, "elastic target unavailable", http.StatusServiceUnavailable)
+		return nil, "", "", "", false
+	}
+
+	index, err = ElasticWriteIndex(cfg.ElasticIndex)
+	if err != nil {
+		klog.Errorf("invalid elastic index: %v", err)
+		http.Error(w, "elastic index unavailable", http.StatusServiceUnavailable)
+		return nil, "", "", "", false
+	}
+
+	return target, index, cfg.ElasticUsername, cfg.ElasticPassword, true
+}
+
+// pushDocuments writes documents to Elastic with a single _bulk request and returns the
+// documents Elastic rejected. When none was written it replies with 400, or 429 if
+// Elastic only throttled them, and returns false.
+func (h *Handler) pushDocuments(w http.ResponseWriter, r *http.Request, target *url.URL, index string, docs []map[string]any, username, password string) (bulkRejections, bool) {
+	if len(docs) == 0 {
+		return bulkRejections{}, true
+	}
+
+	body, err := encodeBulk(index, docs)
+	if err != nil {
+		klog.Errorf("failed to encode bulk request: %v", err)
+		http.Error(w, "failed to encode documents", http.StatusInternalServerError)
+		return bulkRejections{}, false
+	}
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/x-ndjson")
+	resp, ok := h.push(w, r, target, header, body, username, password)
+	if !ok {
+		return bulkRejections{}, false
+	}
+
+	rejections, err := parseBulkResponse(resp)
+	if err != nil {
+		klog.Errorf("failed to parse bulk response, assuming all documents were written: %v", err)
+		return bulkRejections{}, true
+	}
+	if rejections.rejected == 0 {
+		return rejections, true
+	}
+
+	klog.Errorf("upstream rejected documents rejected=%d total=%d reason=%q", rejections.rejected, len(docs), rejections.reason)
+	if rejections.rejected < len(docs) {
+		return rejections, true
+	}
+
+	if rejections.throttled == rejections.rejected {
+		http.Error(w, "upstream is throttling requests", http.StatusTooManyRequests)
+	} else {
+		http.Error(w, fmt.Sprintf("upstream rejected all documents: %s", rejections.reason), http.StatusBadRequest)
+	}
+	return rejections, false
+}
+
+// push sends a translated request upstream and returns the upstream response body.
+// Upstream rejections are reported as 400 so exporters drop the data, anything else
+// keeps a retryable status.
+func (h *Handler) push(w http.ResponseWriter, r *http.Request, target *url.URL, header http.Header, body []byte, username, password string) ([]byte, bool) {
+	klog.V(logging.LevelDebug).Infof("pushing translated request upstream=%s://%s%s bytes=%d", target.Scheme, target.Host, target.Path, len(body))
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodPost, target.String(), &countingReadCloser{
+		readCloser: io.NopCloser(bytes.NewReader(body)),
+		onRead:     h.recordBytes,
+	})
+	if err != nil {
+		klog.Errorf("failed to build upstream request: %v", err)
+		http.Error(w, "upstream request failed", http.StatusInternalServerError)
+		return nil, false
+	}
+	req.ContentLength = int64(len(body))
+	req.Header = header
+	setUpstreamAuth(req.Header, username, password)
+
+	resp, err := h.transport.RoundTrip(req)
+	if err != nil {
+		klog.Errorf("proxy upstream error: %v", err)
+		status := http.StatusBadGateway
+		if errors.Is(err, context.DeadlineExceeded) {
+			status = http.StatusGatewayTimeout
+		}
+		http.Error(w, "upstream request failed", status)
+		return nil, false
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		message, err := io.ReadAll(resp.Body)
+		if err != nil {
+			klog.Errorf("failed to read upstream response: %v", err)
+		}
+		return message, true
+	}
+
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	klog.Errorf("upstream rejected translated request status=%d body=%q", resp.StatusCode, message)
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		http.Error(w, "upstream is throttling requests", http.StatusTooManyRequests)
+	case resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusUnauthorized && resp.StatusCode != http.StatusForbidden:
+		http.Error(w, "upstream rejected the request", http.StatusBadRequest)
+	default:
+		http.Error(w, "upstream request failed", http.StatusBadGateway)
+	}
+	return nil, false
+}
+
+// decodeOTLP reads an OTLP/HTTP request body in its protobuf or JSON encoding
+func decodeOTLP(w http.ResponseWriter, r *http.Request, msg proto.Message) bool {
+	body, ok := readEncodedBody(w, r)
+	if !ok {
+		return false
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	var err error
+	switch mediaType {
+	case contentTypeProtobuf:
+		err = proto.Unmarshal(body, msg)
+	case contentTypeJSON:
+		err = unmarshalOTLPJSON(body, msg)
+	default:
+		http.Error(w, "unsupported content type", http.StatusUnsupportedMediaType)
+		return false
+	}
+	if err != nil {
+		klog.V(logging.LevelVerbose).Infof("rejecting invalid otlp request path=%s: %v", r.URL.Path, err)
+		http.Error(w, fmt.Sprintf("invalid otlp request: %v", err), http.StatusBadRequest)
+		return false
+	}
+
+	return true
+}
+
+// readEncodedBody reads a request body that may be gzip encoded
+func readEncodedBody(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	encoding := r.Header.Get("Content-Encoding")
+	if encoding != "" && !strings.EqualFold(encoding, "gzip") {
+		http.Error(w, fmt.Sprintf("unsupported content encoding %q", encoding), http.StatusUnsupportedMediaType)
+		return nil, false
+	}
+
+	body, ok := readBody(w, r)
+	if !ok || encoding == "" {
+		return body, ok
+	}
+
+	decoded, err := gunzip(body)
+	if err != nil {
+		http.Error(w, "invalid gzip body", http.StatusBadRequest)
+		return nil, false
+	}
+
+	return decoded, true
+}
+
+// unmarshalOTLPJSON decodes the OTLP JSON encoding, which differs from protojson in
+// encoding trace and span IDs as hex rather than base64
+func unmarshalOTLPJSON(body []byte, msg proto.Message) error {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var doc any
+	if err := decoder.Decode(&doc); err != nil {
+		return err
+	}
+	if err := hexIDsToBase64(doc); err != nil {
+		return err
+	}
+
+	normalized, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	return protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(normalized, msg)
+}
+
+var otlpIDFields = map[string]struct{}{
+	"traceId": {}, "spanId": {}, "parentSpanId": {},
+}
+
+func hexIDsToBase64(value any) error {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if id, ok := field.(string); ok {
+				if _, isID := otlpIDFields[key]; isID {
+					decoded, err := hex.DecodeString(id)
+					if err != nil {
+						return fmt.Errorf("invalid %s %q", key, id)
+					}
+					v[key] = base64.StdEncoding.EncodeToString(decoded)
+				}
+				continue
+			}
+			if err := hexIDsToBase64(field); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for _, item := range v {
+			if err := hexIDsToBase64(item); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// otlpPartialSuccess reports the records of an export the upstream rejected
+type otlpPartialSuccess struct {
+	// field is the JSON name of the rejected count, e.g. rejectedLogRecords
+	field    string
+	rejected int64
+	message  string
+}
+
+// writeOTLPResponse replies with an Export*ServiceResponse in the encoding of the
+// request. It is empty, i.e. a full success, unless partial is set.
+func writeOTLPResponse(w http.ResponseWriter, r *http.Request, partial *otlpPartialSuccess) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == contentTypeJSON {
+		body := []byte("{}")
+		if partial != nil {
+			body, _ = json.Marshal(map[string]map[string]string{"partialSuccess": {
+				partial.field:  strconv.FormatInt(partial.rejected, 10),
+				"errorMessage": partial.message,
+			}})
+		}
+		w.Header().Set("Content-Type", contentTypeJSON)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(body)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentTypeProtobuf)
+	w.WriteHeader(http.StatusOK)
+	if partial != nil {
+		// partial_success is field 1 of every response, its rejected count field 1
+		// and error_message field 2
+		var msg []byte
+		msg = protowire.AppendTag(msg, 1, protowire.VarintType)
+		msg = protowire.AppendVarint(msg, uint64(partial.rejected))
+		msg = protowire.AppendTag(msg, 2, protowire.BytesType)
+		msg = protowire.AppendString(msg, partial.message)
+
+		var body []byte
+		body = protowire.AppendTag(body, 1, protowire.BytesType)
+		body = protowire.AppendBytes(body, msg)
+		_, _ = w.Write(body)
+	}
+}
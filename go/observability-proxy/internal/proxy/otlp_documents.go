@@ -0,0 +1,232 @@
+package proxy
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+// Documents translated from OTLP and Loki carry the signal they came from, so logs
+// and spans sharing the logs indices can be told apart.
+const (
+	signalField  = "signal"
+	signalLogs   = "logs"
+	signalTraces = "traces"
+)
+
+// otlpLogDocuments translates OTLP log records into Elastic documents
+func otlpLogDocuments(data *logspb.LogsData, cluster string) []map[string]any {
+	var docs []map[string]any
+	for _, rl := range data.GetResourceLogs() {
+		resource := attributeMap(rl.GetResource().GetAttributes())
+		for _, sl := range rl.GetScopeLogs() {
+			scope := scopeMap(sl.GetScope())
+			for _, record := range sl.GetLogRecords() {
+				timestamp := record.GetTimeUnixNano()
+				if timestamp == 0 {
+					timestamp = record.GetObservedTimeUnixNano()
+				}
+
+				doc := map[string]any{
+					"@timestamp": formatTimestamp(timestamp),
+					signalField:  signalLogs,
+					ClusterLabel: cluster,
+				}
+				if body := record.GetBody(); body != nil {
+					if _, ok := body.GetValue().(*commonpb.AnyValue_StringValue); ok {
+						doc["message"] = body.GetStringValue()
+					} else {
+						doc["body"] = anyValue(body)
+					}
+				}
+				setNonEmpty(doc, "severity_text", record.GetSeverityText())
+				if severity := record.GetSeverityNumber(); severity != logspb.SeverityNumber_SEVERITY_NUMBER_UNSPECIFIED {
+					doc["severity_number"] = int32(severity)
+				}
+				setNonEmpty(doc, "event_name", record.GetEventName())
+				setNonEmpty(doc, "trace_id", hex.EncodeToString(record.GetTraceId()))
+				setNonEmpty(doc, "span_id", hex.EncodeToString(record.GetSpanId()))
+				setNonEmptyMap(doc, "attributes", attributeMap(record.GetAttributes()))
+				setNonEmptyMap(doc, "resource", resource)
+				setNonEmptyMap(doc, "scope", scope)
+
+				docs = append(docs, doc)
+			}
+		}
+	}
+
+	return docs
+}
+
+// otlpSpanDocuments translates OTLP spans into Elastic documents
+func otlpSpanDocuments(data *tracepb.TracesData, cluster string) []map[string]any {
+	var docs []map[string]any
+	for _, rs := range data.GetResourceSpans() {
+		resource := attributeMap(rs.GetResource().GetAttributes())
+		for _, ss := range rs.GetScopeSpans() {
+			scope := scopeMap(ss.GetScope())
+			for _, span := range ss.GetSpans() {
+				doc := map[string]any{
+					"@timestamp":  formatTimestamp(span.GetStartTimeUnixNano()),
+					signalField:   signalTraces,
+					ClusterLabel:  cluster,
+					"name":        span.GetName(),
+					"trace_id":    hex.EncodeToString(span.GetTraceId()),
+					"span_id":     hex.EncodeToString(span.GetSpanId()),
+					"kind":        spanKind(span.GetKind()),
+					"end_time":    formatTimestamp(span.GetEndTimeUnixNano()),
+					"duration_ns": spanDuration(span),
+				}
+				setNonEmpty(doc, "parent_span_id", hex.EncodeToString(span.GetParentSpanId()))
+				setNonEmpty(doc, "trace_state", span.GetTraceState())
+				if status := span.GetStatus(); status != nil {
+					statusDoc := map[string]any{"code": strings.TrimPrefix(status.GetCode().String(), "STATUS_CODE_")}
+					setNonEmpty(statusDoc, "message", status.GetMessage())
+					doc["status"] = statusDoc
+				}
+				setNonEmptyMap(doc, "attributes", attributeMap(span.GetAttributes()))
+				setNonEmptyMap(doc, "resource", resource)
+				setNonEmptyMap(doc, "scope", scope)
+
+				if events := span.GetEvents(); len(events) > 0 {
+					encoded := make([]map[string]any, 0, len(events))
+					for _, event := range events {
+						eventDoc := map[string]any{
+							"name": event.GetName(),
+							"time": formatTimestamp(event.GetTimeUnixNano()),
+						}
+						setNonEmptyMap(eventDoc, "attributes", attributeMap(event.GetAttributes()))
+						encoded = append(encoded, eventDoc)
+					}
+					doc["events"] = encoded
+				}
+				if links := span.GetLinks(); len(links) > 0 {
+					encoded := make([]map[string]any, 0, len(links))
+					for _, link := range links {
+						linkDoc := map[string]any{
+							"trace_id": hex.EncodeToString(link.GetTraceId()),
+							"span_id":  hex.EncodeToString(link.GetSpanId()),
+						}
+						setNonEmptyMap(linkDoc, "attributes", attributeMap(link.GetAttributes()))
+						encoded = append(encoded, linkDoc)
+					}
+					doc["links"] = encoded
+				}
+
+				docs = append(docs, doc)
+			}
+		}
+	}
+
+	return docs
+}
+
+func spanKind(kind tracepb.Span_SpanKind) string {
+	return strings.ToLower(strings.TrimPrefix(kind.String(), "SPAN_KIND_"))
+}
+
+func spanDuration(span *tracepb.Span) uint64 {
+	if span.GetEndTimeUnixNano() < span.GetStartTimeUnixNano() {
+		return 0
+	}
+
+	return span.GetEndTimeUnixNano() - span.GetStartTimeUnixNano()
+}
+
+func scopeMap(scope *commonpb.InstrumentationScope) map[string]any {
+	doc := map[string]any{}
+	setNonEmpty(doc, "name", scope.GetName())
+	setNonEmpty(doc, "version", scope.GetVersion())
+	setNonEmptyMap(doc, "attributes", attributeMap(scope.GetAttributes()))
+
+	return doc
+}
+
+func attributeMap(attrs []*commonpb.KeyValue) map[string]any {
+	values := make(map[string]any, len(attrs))
+	for _, attr := range attrs {
+		values[attr.GetKey()] = anyValue(attr.GetValue())
+	}
+
+	return values
+}
+
+// anyValue converts an OTLP value to its JSON representation
+func anyValue(value *commonpb.AnyValue) any {
+	switch v := value.GetValue().(type) {
+	case *commonpb.AnyValue_StringValue:
+		return v.StringValue
+	case *commonpb.AnyValue_BoolValue:
+		return v.BoolValue
+	case *commonpb.AnyValue_IntValue:
+		return v.IntValue
+	case *commonpb.AnyValue_DoubleValue:
+		// JSON has no representation of NaN and infinities
+		if math.IsNaN(v.DoubleValue) || math.IsInf(v.DoubleValue, 0) {
+			return formatFloat(v.DoubleValue)
+		}
+		return v.DoubleValue
+	case *commonpb.AnyValue_BytesValue:
+		return base64.StdEncoding.EncodeToString(v.BytesValue)
+	case *commonpb.AnyValue_ArrayValue:
+		values := make([]any, 0, len(v.ArrayValue.GetValues()))
+		for _, item := range v.ArrayValue.GetValues() {
+			values = append(values, anyValue(item))
+		}
+		return values
+	case *commonpb.AnyValue_KvlistValue:
+		return attributeMap(v.KvlistValue.GetValues())
+	}
+
+	return nil
+}
+
+// anyValueString converts an OTLP value to a label value, composite values are JSON encoded
+func anyValueString(value *commonpb.AnyValue) string {
+	switch v := value.GetValue().(type) {
+	case nil:
+		return ""
+	case *commonpb.AnyValue_StringValue:
+		return v.StringValue
+	case *commonpb.AnyValue_BoolValue:
+		return strconv.FormatBool(v.BoolValue)
+	case *commonpb.AnyValue_IntValue:
+		return strconv.FormatInt(v.IntValue, 10)
+	case *commonpb.AnyValue_DoubleValue:
+		return formatFloat(v.DoubleValue)
+	}
+
+	encoded, err := json.Marshal(anyValue(value))
+	if err != nil {
+		return ""
+	}
+	return string(encoded)
+}
+
+func formatTimestamp(unixNano uint64) string {
+	if unixNano == 0 {
+		return time.Now().UTC().Format(time.RFC3339Nano)
+	}
+
+	return time.Unix(0, int64(unixNano)).UTC().Format(time.RFC3339Nano)
+}
+
+func setNonEmpty(doc map[string]any, key, value string) {
+	if value != "" {
+		doc[key] = value
+	}
+}
+
+func setNonEmptyMap(doc map[string]any, key string, value map[string]any) {
+	if len(value) > 0 {
+		doc[key] = value
+	}
+}
@@ -0,0 +1,244 @@
+package proxy
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/pluralsh/console/go/observability-proxy/internal/logging"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	"k8s.io/klog/v2"
+)
+
+// otlpSeries translates OTLP metrics into Prometheus series the way the Prometheus
+// OTLP receiver does: job and instance come from the service resource attributes,
+// data point attributes become labels, and every series carries the cluster label.
+// Delta temporality has no Prometheus equivalent and is dropped.
+func otlpSeries(data *metricspb.MetricsData, cluster string) []promSeries {
+	var series []promSeries
+	dropped := 0
+	for _, rm := range data.GetResourceMetrics() {
+		base := resourceLabels(rm.GetResource().GetAttributes())
+		for _, sm := range rm.GetScopeMetrics() {
+			for _, metric := range sm.GetMetrics() {
+				name := sanitizeMetricName(metric.GetName())
+				if name == "" {
+					dropped++
+					continue
+				}
+
+				translated, ok := metricSeries(metric, name, base, cluster)
+				if !ok {
+					dropped++
+					continue
+				}
+				series = append(series, translated...)
+			}
+		}
+	}
+
+	if dropped > 0 {
+		klog.V(logging.LevelVerbose).Infof("dropped untranslatable otlp metrics cluster=%s count=%d", cluster, dropped)
+	}
+
+	return series
+}
+
+func metricSeries(metric *metricspb.Metric, name string, base map[string]string, cluster string) ([]promSeries, bool) {
+	var series []promSeries
+	add := func(name string, attrs []*commonpb.KeyValue, extra map[string]string, value float64, timeUnixNano uint64) {
+		series = append(series, promSeries{
+			labels:  seriesLabels(name, base, attrs, extra, cluster),
+			samples: []promSample{{value: value, timestamp: timestampMillis(timeUnixNano)}},
+		})
+	}
+
+	switch data := metric.GetData().(type) {
+	case *metricspb.Metric_Gauge:
+		for _, point := range data.Gauge.GetDataPoints() {
+			if noRecordedValue(point.GetFlags()) {
+				continue
+			}
+			add(name, point.GetAttributes(), nil, numberValue(point), point.GetTimeUnixNano())
+		}
+	case *metricspb.Metric_Sum:
+		if data.Sum.GetAggregationTemporality() == metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA {
+			return nil, false
+		}
+		if data.Sum.GetIsMonotonic() && !strings.HasSuffix(name, "_total") {
+			name += "_total"
+		}
+		for _, point := range data.Sum.GetDataPoints() {
+			if noRecordedValue(point.GetFlags()) {
+				continue
+			}
+			add(name, point.GetAttributes(), nil, numberValue(point), point.GetTimeUnixNano())
+		}
+	case *metricspb.Metric_Histogram:
+		if data.Histogram.GetAggregationTemporality() == metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA {
+			return nil, false
+		}
+		for _, point := range data.Histogram.GetDataPoints() {
+			if noRecordedValue(point.GetFlags()) {
+				continue
+			}
+
+			var cumulative uint64
+			for i, bound := range point.GetExplicitBounds() {
+				if i < len(point.GetBucketCounts()) {
+					cumulative += point.GetBucketCounts()[i]
+				}
+				add(name+"_bucket", point.GetAttributes(), map[string]string{"le": formatFloat(bound)}, float64(cumulative), point.GetTimeUnixNano())
+			}
+			add(name+"_bucket", point.GetAttributes(), map[string]string{"le": "+Inf"}, float64(point.GetCount()), point.GetTimeUnixNano())
+			if point.Sum != nil {
+				add(name+"_sum", point.GetAttributes(), nil, point.GetSum(), point.GetTimeUnixNano())
+			}
+			add(name+"_count", point.GetAttributes(), nil, float64(point.GetCount()), point.GetTimeUnixNano())
+		}
+	case *metricspb.Metric_ExponentialHistogram:
+		// native histograms need remote-write 2.0, only the aggregates are kept
+		if data.ExponentialHistogram.GetAggregationTemporality() == metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA {
+			return nil, false
+		}
+		for _, point := range data.ExponentialHistogram.GetDataPoints() {
+			if noRecordedValue(point.GetFlags()) {
+				continue
+			}
+			if point.Sum != nil {
+				add(name+"_sum", point.GetAttributes(), nil, point.GetSum(), point.GetTimeUnixNano())
+			}
+			add(name+"_count", point.GetAttributes(), nil, float64(point.GetCount()), point.GetTimeUnixNano())
+		}
+	case *metricspb.Metric_Summary:
+		for _, point := range data.Summary.GetDataPoints() {
+			if noRecordedValue(point.GetFlags()) {
+				continue
+			}
+			for _, quantile := range point.GetQuantileValues() {
+				add(name, point.GetAttributes(), map[string]string{"quantile": formatFloat(quantile.GetQuantile())}, quantile.GetValue(), point.GetTimeUnixNano())
+			}
+			add(name+"_sum", point.GetAttributes(), nil, point.GetSum(), point.GetTimeUnixNano())
+			add(name+"_count", point.GetAttributes(), nil, float64(point.GetCount()), point.GetTimeUnixNano())
+		}
+	default:
+		return nil, false
+	}
+
+	return series, true
+}
+
+// resourceLabels derives the job and instance labels from the service attributes
+func resourceLabels(attrs []*commonpb.KeyValue) map[string]string {
+	values := make(map[string]string, len(attrs))
+	for _, attr := range attrs {
+		values[attr.GetKey()] = anyValueString(attr.GetValue())
+	}
+
+	labels := make(map[string]string, 2)
+	if job := values["service.name"]; job != "" {
+		if namespace := values["service.namespace"]; namespace != "" {
+			job = namespace + "/" + job
+		}
+		labels["job"] = job
+	}
+	if instance := values["service.instance.id"]; instance != "" {
+		labels["instance"] = instance
+	}
+
+	return labels
+}
+
+// seriesLabels merges the labels of a series, sorted by name as remote-write expects.
+// The metric name and cluster label always win over attributes.
+func seriesLabels(name string, base map[string]string, attrs []*commonpb.KeyValue, extra map[string]string, cluster string) []promLabel {
+	merged := make(map[string]string, len(base)+len(attrs)+len(extra)+2)
+	for key, value := range base {
+		merged[key] = value
+	}
+	for _, attr := range attrs {
+		if key := sanitizeLabelName(attr.GetKey()); key != "" {
+			merged[key] = anyValueString(attr.GetValue())
+		}
+	}
+	for key, value := range extra {
+		merged[key] = value
+	}
+	merged["__name__"] = name
+	merged[ClusterLabel] = cluster
+
+	labels := make([]promLabel, 0, len(merged))
+	for key, value := range merged {
+		if value != "" {
+			labels = append(labels, promLabel{name: key, value: value})
+		}
+	}
+	sort.Slice(labels, func(i, j int) bool { return labels[i].name < labels[j].name })
+
+	return labels
+}
+
+func sanitizeMetricName(name string) string {
+	return sanitizeName(name, true)
+}
+
+func sanitizeLabelName(name string) string {
+	return sanitizeName(name, false)
+}
+
+// sanitizeName replaces the characters Prometheus does not allow in names with
+// underscores, e.g. http.server.duration becomes http_server_duration
+func sanitizeName(name string, colons bool) string {
+	if name == "" {
+		return ""
+	}
+
+	var b strings.Builder
+	for i, r := range name {
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || r == '_' || (colons && r == ':')):
+			b.WriteRune(r)
+		case r < unicode.MaxASCII && unicode.IsDigit(r):
+			if i == 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+
+	return b.String()
+}
+
+func numberValue(point *metricspb.NumberDataPoint) float64 {
+	if value, ok := point.GetValue().(*metricspb.NumberDataPoint_AsInt); ok {
+		return float64(value.AsInt)
+	}
+
+	return point.GetAsDouble()
+}
+
+func noRecordedValue(flags uint32) bool {
+	return flags&uint32(metricspb.DataPointFlags_DATA_POINT_FLAGS_NO_RECORDED_VALUE_MASK) != 0
+}
+
+func timestampMillis(unixNano uint64) int64 {
+	if unixNano == 0 {
+		return time.Now().UnixMilli()
+	}
+
+	return int64(unixNano / uint64(time.Millisecond))
+}
+
+func formatFloat(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,398 @@
+package proxy
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pluralsh/console/go/observability-proxy/internal/console"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+func stringAttr(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{Key: key, Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}}}
+}
+
+func metricsData(metrics ...*metricspb.Metric) *metricspb.MetricsData {
+	return &metricspb.MetricsData{ResourceMetrics: []*metricspb.ResourceMetrics{{
+		Resource: &resourcepb.Resource{Attributes: []*commonpb.KeyValue{
+			stringAttr("service.name", "api"),
+			stringAttr("service.namespace", "shop"),
+			stringAttr("service.instance.id", "pod-1"),
+		}},
+		ScopeMetrics: []*metricspb.ScopeMetrics{{Metrics: metrics}},
+	}}}
+}
+
+// captureUpstream records the last request body and path received upstream
+func captureUpstream(t *testing.T, status int) (*httptest.Server, *[]byte, *string) {
+	t.Helper()
+
+	var body []byte
+	var path string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		path = r.URL.Path
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(upstream.Close)
+
+	return upstream, &body, &path
+}
+
+// bulkUpstream replies to every _bulk request with response
+func bulkUpstream(t *testing.T, response string) *httptest.Server {
+	t.Helper()
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(upstream.Close)
+
+	return upstream
+}
+
+func newOTLPMux(upstreamURL string, recordBytes func(int64)) *http.ServeMux {
+	provider := staticProvider{cfg: console.ObservabilityConfig{
+		PrometheusHost: upstreamURL + "/select/t/prometheus",
+		ElasticHost:    upstreamURL,
+		ElasticIndex:   "plrl-x-logs-*",
+	}}
+	mux := http.NewServeMux()
+	NewHandler(provider, staticVerifier{}, 5*time.Second, recordBytes).Register(mux)
+	return mux
+}
+
+func TestOTLPSeries(t *testing.T) {
+	sum := 12.5
+	data := metricsData(
+		&metricspb.Metric{Name: "http.server.requests", Data: &metricspb.Metric_Sum{Sum: &metricspb.Sum{
+			IsMonotonic:            true,
+			AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+			DataPoints: []*metricspb.NumberDataPoint{{
+				Attributes:   []*commonpb.KeyValue{stringAttr("http.method", "GET"), stringAttr("cluster", "spoofed")},
+				TimeUnixNano: 1700000000000000000,
+				Value:        &metricspb.NumberDataPoint_AsInt{AsInt: 3},
+			}},
+		}}},
+		&metricspb.Metric{Name: "latency", Data: &metricspb.Metric_Histogram{Histogram: &metricspb.Histogram{
+			AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+			DataPoints: []*metricspb.HistogramDataPoint{{
+				Count:          5,
+				Sum:            &sum,
+				BucketCounts:   []uint64{2, 3},
+				ExplicitBounds: []float64{0.5},
+			}},
+		}}},
+		&metricspb.Metric{Name: "deltas", Data: &metricspb.Metric_Sum{Sum: &metricspb.Sum{
+			AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA,
+			DataPoints:             []*metricspb.NumberDataPoint{{Value: &metricspb.NumberDataPoint_AsInt{AsInt: 1}}},
+		}}},
+	)
+
+	series := otlpSeries(data, "c1")
+
+	var got []string
+	for _, s := range series {
+		var labels []string
+		for _, l := range s.labels {
+			labels = append(labels, l.name+"="+l.value)
+		}
+		got = append(got, strings.Join(labels, " "))
+	}
+	want := []string{
+		"__name__=http_server_requests_total cluster=c1 http_method=GET instance=pod-1 job=shop/api",
+		"__name__=latency_bucket cluster=c1 instance=pod-1 job=shop/api le=0.5",
+		"__name__=latency_bucket cluster=c1 instance=pod-1 job=shop/api le=+Inf",
+		"__name__=latency_sum cluster=c1 instance=pod-1 job=shop/api",
+		"__name__=latency_count cluster=c1 instance=pod-1 job=shop/api",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected series:\ngot  %q\nwant %q", got, want)
+	}
+
+	values := []float64{3, 2, 5, 12.5, 5}
+	for i, s := range series {
+		if s.samples[0].value != values[i] {
+			t.Fatalf("unexpected sample %d: got %v want %v", i, s.samples[0].value, values[i])
+		}
+	}
+	if series[0].samples[0].timestamp != 1700000000000 {
+		t.Fatalf("unexpected timestamp: got %d", series[0].samples[0].timestamp)
+	}
+}
+
+func TestSanitizeName(t *testing.T) {
+	cases := map[string]string{
+		"http.server.duration": "http_server_duration",
+		"1xx":                  "_1xx",
+		"ns:rule:rate":         "ns:rule:rate",
+		"naïve":                "na_ve",
+	}
+
+	for in, want := range cases {
+		if got := sanitizeMetricName(in); got != want {
+			t.Fatalf("unexpected name for %q: got %q want %q", in, got, want)
+		}
+	}
+	if got := sanitizeLabelName("a:b"); got != "a_b" {
+		t.Fatalf("unexpected label name: got %q", got)
+	}
+}
+
+func TestOTLPMetricsForwardsRemoteWrite(t *testing.T) {
+	upstream, body, path := captureUpstream(t, http.StatusNoContent)
+	var counted atomic.Int64
+	mux := newOTLPMux(upstream.URL, func(n int64) { counted.Add(n) })
+
+	encoded, err := proto.Marshal(metricsData(&metricspb.Metric{Name: "up", Data: &metricspb.Metric_Gauge{Gauge: &metricspb.Gauge{
+		DataPoints: []*metricspb.NumberDataPoint{{Value: &metricspb.NumberDataPoint_AsDouble{AsDouble: 1}}},
+	}}}))
+	if err != nil {
+		t.Fatalf("marshal metrics: %v", err)
+	}
+
+	req := newClusterRequest(http.MethodPost, "/ext/v1/ingest/otlp/v1/metrics", bytes.NewReader(encoded))
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status: got %d want %d (%s)", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	if got := rec.Header().Get("Content-Type"); got != "application/x-protobuf" {
+		t.Fatalf("unexpected content type: got %q", got)
+	}
+	if *path != "/insert/t/prometheus/api/v1/write" {
+		t.Fatalf("unexpected upstream path: got %q", *path)
+	}
+	if got, want := remoteWriteLabels(t, *body), "[__name__=up cluster=test-id instance=pod-1 job=shop/api]"; got != want {
+		t.Fatalf("unexpected labels: got %s want %s", got, want)
+	}
+	if counted.Load() != int64(len(*body)) {
+		t.Fatalf("unexpected metered bytes: got %d want %d", counted.Load(), len(*body))
+	}
+}
+
+func TestOTLPLogsJSONWritesBulkDocuments(t *testing.T) {
+	upstream, body, path := captureUpstream(t, http.StatusOK)
+	mux := newOTLPMux(upstream.URL, nil)
+
+	payload := `{"resourceLogs":[{"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"api"}}]},
+		"scopeLogs":[{"logRecords":[{"timeUnixNano":"1700000000000000000","severityText":"INFO",
+		"body":{"stringValue":"hello"},"traceId":"5b8efff798038103d269b633813fc60c","spanId":"eee19b7ec3c1b174",
+		"attributes":[{"key":"cluster","value":{"stringValue":"spoofed"}}]}]}]}]}`
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	_, _ = gz.Write([]byte(payload))
+	_ = gz.Close()
+
+	req := newClusterRequest(http.MethodPost, "/ext/v1/ingest/otlp/v1/logs", &compressed)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK || rec.Body.String() != "{}" {
+		t.Fatalf("unexpected response: got %d %q", rec.Code, rec.Body.String())
+	}
+	if *path != "/_bulk" {
+		t.Fatalf("unexpected upstream path: got %q", *path)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(*body)), "\n")
+	if len(lines) != 2 || lines[0] != `{"create":{"_index":"plrl-x-logs-write"}}` {
+		t.Fatalf("unexpected bulk body: %q", *body)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal([]byte(lines[1]), &doc); err != nil {
+		t.Fatalf("invalid document: %v", err)
+	}
+	want := map[string]any{
+		"@timestamp":    "2023-11-14T22:13:20Z",
+		"cluster":       "test-id",
+		"signal":        "logs",
+		"message":       "hello",
+		"severity_text": "INFO",
+		"trace_id":      "5b8efff798038103d269b633813fc60c",
+		"span_id":       "eee19b7ec3c1b174",
+	}
+	for key, value := range want {
+		if doc[key] != value {
+			t.Fatalf("unexpected %s: got %v want %v", key, doc[key], value)
+		}
+	}
+	if attrs := doc["attributes"].(map[string]any); attrs["cluster"] != "spoofed" {
+		t.Fatalf("unexpected attributes: got %v", attrs)
+	}
+}
+
+func TestOTLPTracesWritesSpanDocuments(t *testing.T) {
+	upstream, body, _ := captureUpstream(t, http.StatusOK)
+	mux := newOTLPMux(upstream.URL, nil)
+
+	payload := `{"resourceSpans":[{"scopeSpans":[{"spans":[{"traceId":"5b8efff798038103d269b633813fc60c",
+		"spanId":"eee19b7ec3c1b174","name":"GET /","kind":2,"startTimeUnixNano":"1700000000000000000",
+		"endTimeUnixNano":"1700000000250000000","status":{"code":2,"message":"boom"}}]}]}]}`
+	req := newClusterRequest(http.MethodPost, "/ext/v1/ingest/otlp/v1/traces", strings.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status: got %d want %d (%s)", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(*body)), "\n")
+	var doc map[string]any
+	if err := json.Unmarshal([]byte(lines[len(lines)-1]), &doc); err != nil {
+		t.Fatalf("invalid document: %v", err)
+	}
+	if doc["signal"] != "traces" || doc["kind"] != "server" || doc["duration_ns"] != float64(250000000) || doc["cluster"] != "test-id" {
+		t.Fatalf("unexpected span document: %v", doc)
+	}
+	if status := doc["status"].(map[string]any); status["code"] != "ERROR" || status["message"] != "boom" {
+		t.Fatalf("unexpected status: %v", status)
+	}
+}
+
+func TestOTLPRejectsInvalidRequests(t *testing.T) {
+	upstream, _, _ := captureUpstream(t, http.StatusOK)
+	mux := newOTLPMux(upstream.URL, nil)
+
+	cases := []struct {
+		name        string
+		method      string
+		contentType string
+		body        string
+		want        int
+	}{
+		{name: "method", method: http.MethodGet, contentType: "application/json", want: http.StatusMethodNotAllowed},
+		{name: "content type", method: http.MethodPost, contentType: "text/plain", body: "{}", want: http.StatusUnsupportedMediaType},
+		{name: "invalid json", method: http.MethodPost, contentType: "application/json", body: "{", want: http.StatusBadRequest},
+		{name: "invalid trace id", method: http.MethodPost, contentType: "application/json", body: `{"resourceLogs":[{"scopeLogs":[{"logRecords":[{"traceId":"zz"}]}]}]}`, want: http.StatusBadRequest},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := newClusterRequest(tc.method, "/ext/v1/ingest/otlp/v1/logs", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", tc.contentType)
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			if rec.Code != tc.want {
+				t.Fatalf("unexpected status: got %d want %d", rec.Code, tc.want)
+			}
+		})
+	}
+}
+
+func TestOTLPMapsUpstreamStatus(t *testing.T) {
+	cases := map[int]int{
+		http.StatusBadRequest:          http.StatusBadRequest,
+		http.StatusTooManyRequests:     http.StatusTooManyRequests,
+		http.StatusUnauthorized:        http.StatusBadGateway,
+		http.StatusServiceUnavailable:  http.StatusBadGateway,
+		http.StatusInternalServerError: http.StatusBadGateway,
+	}
+
+	for upstreamStatus, want := range cases {
+		upstream, _, _ := captureUpstream(t, upstreamStatus)
+		mux := newOTLPMux(upstream.URL, nil)
+
+		req := newClusterRequest(http.MethodPost, "/ext/v1/ingest/otlp/v1/logs", strings.NewReader(`{"resourceLogs":[{"scopeLogs":[{"logRecords":[{}]}]}]}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+
+		if rec.Code != want {
+			t.Fatalf("unexpected status for upstream %d: got %d want %d", upstreamStatus, rec.Code, want)
+		}
+	}
+}
+
+func TestOTLPReportsRejectedDocuments(t *testing.T) {
+	const (
+		created  = `{"create":{"status":201}}`
+		rejected = `{"create":{"status":400,"error":{"type":"mapper_parsing_exception","reason":"bad field"}}}`
+		throttle = `{"create":{"status":429,"error":{"type":"es_rejected_execution_exception","reason":"queue full"}}}`
+	)
+	logs := `{"resourceLogs":[{"scopeLogs":[{"logRecords":[{"body":{"stringValue":"a"}},{"body":{"stringValue":"b"}}]}]}]}`
+
+	t.Run("partial success json", func(t *testing.T) {
+		mux := newOTLPMux(bulkUpstream(t, `{"errors":true,"items":[`+created+`,`+rejected+`]}`).URL, nil)
+		req := newClusterRequest(http.MethodPost, "/ext/v1/ingest/otlp/v1/logs", strings.NewReader(logs))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+
+		want := `{"partialSuccess":{"errorMessage":"mapper_parsing_exception: bad field","rejectedLogRecords":"1"}}`
+		if rec.Code != http.StatusOK || rec.Body.String() != want {
+			t.Fatalf("unexpected response: got %d %s want %s", rec.Code, rec.Body.String(), want)
+		}
+	})
+
+	t.Run("partial success protobuf", func(t *testing.T) {
+		mux := newOTLPMux(bulkUpstream(t, `{"errors":true,"items":[`+rejected+`,`+created+`]}`).URL, nil)
+		encoded, err := proto.Marshal(&tracepb.TracesData{ResourceSpans: []*tracepb.ResourceSpans{{ScopeSpans: []*tracepb.ScopeSpans{{
+			Spans: []*tracepb.Span{{Name: "a"}, {Name: "b"}},
+		}}}}})
+		if err != nil {
+			t.Fatalf("marshal traces: %v", err)
+		}
+
+		req := newClusterRequest(http.MethodPost, "/ext/v1/ingest/otlp/v1/traces", bytes.NewReader(encoded))
+		req.Header.Set("Content-Type", "application/x-protobuf")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("unexpected status: got %d want %d (%s)", rec.Code, http.StatusOK, rec.Body.String())
+		}
+		// ExportTraceServiceResponse{partial_success: {rejected_spans: 1, error_message: ...}}
+		var msg []byte
+		msg = protowire.AppendTag(msg, 1, protowire.VarintType)
+		msg = protowire.AppendVarint(msg, 1)
+		msg = protowire.AppendTag(msg, 2, protowire.BytesType)
+		msg = protowire.AppendString(msg, "mapper_parsing_exception: bad field")
+		want := protowire.AppendBytes(protowire.AppendTag(nil, 1, protowire.BytesType), msg)
+		if !bytes.Equal(rec.Body.Bytes(), want) {
+			t.Fatalf("unexpected response: got %x want %x", rec.Body.Bytes(), want)
+		}
+	})
+
+	cases := map[string]struct {
+		response string
+		want     int
+	}{
+		"all rejected":  {response: `{"errors":true,"items":[` + rejected + `,` + throttle + `]}`, want: http.StatusBadRequest},
+		"all throttled": {response: `{"errors":true,"items":[` + throttle + `,` + throttle + `]}`, want: http.StatusTooManyRequests},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mux := newOTLPMux(bulkUpstream(t, tc.response).URL, nil)
+			req := newClusterRequest(http.MethodPost, "/ext/v1/ingest/otlp/v1/logs", strings.NewReader(logs))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			if rec.Code != tc.want {
+				t.Fatalf("unexpected status: got %d want %d (%s)", rec.Code, tc.want, rec.Body.String())
+			}
+		})
+	}
+}
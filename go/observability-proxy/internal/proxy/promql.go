@@ -0,0 +1,256 @@
+package proxy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type promqlTokenKind int
+
+const (
+	promqlIdent promqlTokenKind = iota
+	promqlNumber
+	promqlString
+	promqlPunct
+)
+
+type promqlToken struct {
+	kind promqlTokenKind
+	text string
+	pos  int
+}
+
+// keywords that are never metric names
+var promqlKeywords = map[string]struct{}{
+	"and": {}, "or": {}, "unless": {}, "atan2": {}, "bool": {}, "offset": {},
+	"by": {}, "without": {}, "on": {}, "ignoring": {}, "group_left": {}, "group_right": {},
+	"inf": {}, "nan": {},
+}
+
+// MetricsQL keywords are only keywords after an operand, e.g. `sum(x) limit 5`,
+// anywhere else they are metric names
+var metricsqlKeywords = map[string]struct{}{
+	"default": {}, "if": {}, "ifnot": {}, "keep_metric_names": {}, "limit": {},
+}
+
+// keywords followed by a list of label names rather than an expression
+var promqlGroupingKeywords = map[string]struct{}{
+	"by": {}, "without": {}, "on": {}, "ignoring": {}, "group_left": {}, "group_right": {},
+}
+
+// SetPromQLMatcher rewrites a PromQL (or MetricsQL) expression so every series
+// selector carries the name="value" matcher. Matchers are ANDed, so any matcher on
+// the same label sent by the client can only narrow the result further.
+func SetPromQLMatcher(query, name, value string) (string, error) {
+	tokens, err := tokenizePromQL(query)
+	if err != nil {
+		return "", err
+	}
+
+	matcher := name + "=" + strconv.Quote(value)
+	type insertion struct {
+		pos  int
+		text string
+	}
+	var insertions []insertion
+
+	// selector injects the matcher into the braces starting at tokens[start] and
+	// returns the index of the closing brace
+	selector := func(start int) (int, error) {
+		empty := true
+		for i := start + 1; i < len(tokens); i++ {
+			tok := tokens[i]
+			// the matcher is appended right after the previous token
+			prev := tokens[i-1].pos + len(tokens[i-1].text)
+			switch {
+			case tok.kind == promqlPunct && tok.text == "}":
+				insertions = append(insertions, insertion{prev, separator(empty) + matcher})
+				return i, nil
+			case tok.kind == promqlPunct && tok.text == "{":
+				return 0, fmt.Errorf("unexpected '{' at position %d", tok.pos)
+			case tok.kind == promqlIdent && strings.EqualFold(tok.text, "or"):
+				// MetricsQL `{a="b" or c="d"}` selects the union of both filter groups
+				insertions = append(insertions, insertion{prev, separator(empty) + matcher})
+				empty = true
+			default:
+				empty = false
+			}
+		}
+		return 0, fmt.Errorf("unclosed '{' at position %d", tokens[start].pos)
+	}
+
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		next := func(text string) bool {
+			return i+1 < len(tokens) && tokens[i+1].kind == promqlPunct && tokens[i+1].text == text
+		}
+
+		switch {
+		case tok.kind == promqlPunct && tok.text == "{":
+			end, err := selector(i)
+			if err != nil {
+				return "", err
+			}
+			i = end
+		case tok.kind == promqlIdent:
+			keyword := strings.ToLower(tok.text)
+			if _, ok := promqlGroupingKeywords[keyword]; ok && next("(") {
+				end, err := closing(tokens, i+1, "(", ")")
+				if err != nil {
+					return "", err
+				}
+				i = end
+				continue
+			}
+			if _, ok := promqlKeywords[keyword]; ok || next("(") || nextGrouping(tokens, i) {
+				continue
+			}
+			if _, ok := metricsqlKeywords[keyword]; ok && i > 0 && endsOperand(tokens[i-1]) {
+				continue
+			}
+
+			if next("{") {
+				end, err := selector(i + 1)
+				if err != nil {
+					return "", err
+				}
+				i = end
+				continue
+			}
+			insertions = append(insertions, insertion{tok.pos + len(tok.text), "{" + matcher + "}"})
+		}
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, ins := range insertions {
+		b.WriteString(query[last:ins.pos])
+		b.WriteString(ins.text)
+		last = ins.pos
+	}
+	b.WriteString(query[last:])
+
+	return b.String(), nil
+}
+
+// nextGrouping reports whether tokens[i] is an aggregation followed by its grouping, e.g. `sum by (job) (...)`
+func nextGrouping(tokens []promqlToken, i int) bool {
+	if i+1 >= len(tokens) || tokens[i+1].kind != promqlIdent {
+		return false
+	}
+
+	keyword := strings.ToLower(tokens[i+1].text)
+	return keyword == "by" || keyword == "without"
+}
+
+func endsOperand(tok promqlToken) bool {
+	switch tok.kind {
+	case promqlIdent:
+		keyword := strings.ToLower(tok.text)
+		_, operator := promqlKeywords[keyword]
+		_, metricsqlOperator := metricsqlKeywords[keyword]
+		return keyword == "inf" || keyword == "nan" || (!operator && !metricsqlOperator)
+	case promqlPunct:
+		return tok.text == ")" || tok.text == "}" || tok.text == "]"
+	}
+	return true
+}
+
+func separator(empty bool) string {
+	if empty {
+		return ""
+	}
+	return ","
+}
+
+// closing returns the index of the token closing the bracket opened at tokens[start]
+func closing(tokens []promqlToken, start int, left, right string) (int, error) {
+	depth := 0
+	for i := start; i < len(tokens); i++ {
+		if tokens[i].kind != promqlPunct {
+			continue
+		}
+		switch tokens[i].text {
+		case left:
+			depth++
+		case right:
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		}
+	}
+
+	return 0, fmt.Errorf("unclosed %q at position %d", left, tokens[start].pos)
+}
+
+func tokenizePromQL(query string) ([]promqlToken, error) {
+	var tokens []promqlToken
+	for pos := 0; pos < len(query); {
+		r, size := utf8.DecodeRuneInString(query[pos:])
+		start := pos
+
+		switch {
+		case unicode.IsSpace(r):
+			pos += size
+		case r == '#':
+			if end := strings.IndexByte(query[pos:], '\n'); end >= 0 {
+				pos += end
+			} else {
+				pos = len(query)
+			}
+		case r == '"' || r == '\'' || r == '`':
+			end, err := stringEnd(query, pos, byte(r))
+			if err != nil {
+				return nil, err
+			}
+			pos = end
+			tokens = append(tokens, promqlToken{kind: promqlString, text: query[start:pos], pos: start})
+		case unicode.IsDigit(r) || (r == '.' && pos+1 < len(query) && isDigit(query[pos+1])):
+			pos = identEnd(query, pos+size)
+			tokens = append(tokens, promqlToken{kind: promqlNumber, text: query[start:pos], pos: start})
+		case unicode.IsLetter(r) || r == '_' || r == ':':
+			pos = identEnd(query, pos+size)
+			tokens = append(tokens, promqlToken{kind: promqlIdent, text: query[start:pos], pos: start})
+		default:
+			pos += size
+			tokens = append(tokens, promqlToken{kind: promqlPunct, text: query[start:pos], pos: start})
+		}
+	}
+
+	return tokens, nil
+}
+
+func identEnd(query string, pos int) int {
+	for pos < len(query) {
+		r, size := utf8.DecodeRuneInString(query[pos:])
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != ':' && r != '.' {
+			break
+		}
+		pos += size
+	}
+
+	return pos
+}
+
+func stringEnd(query string, start int, quote byte) (int, error) {
+	for pos := start + 1; pos < len(query); pos++ {
+		switch query[pos] {
+		case '\\':
+			if quote != '`' {
+				pos++
+			}
+		case quote:
+			return pos + 1, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unterminated string at position %d", start)
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
@@ -0,0 +1,60 @@
+package proxy
+
+import "testing"
+
+func TestSetPromQLMatcher(t *testing.T) {
+	cases := []struct {
+		query string
+		want  string
+	}{
+		{query: `up`, want: `up{cluster="c1"}`},
+		{query: `up{}`, want: `up{cluster="c1"}`},
+		{query: `up{job="node"}`, want: `up{job="node",cluster="c1"}`},
+		{query: `{__name__=~".+"}`, want: `{__name__=~".+",cluster="c1"}`},
+		{query: `up{cluster="c2"}`, want: `up{cluster="c2",cluster="c1"}`},
+		{query: `node:cpu:rate5m`, want: `node:cpu:rate5m{cluster="c1"}`},
+		{
+			query: `sum by (job) (rate(http_requests_total{code=~"5.."}[5m] offset 1h))`,
+			want:  `sum by (job) (rate(http_requests_total{code=~"5..",cluster="c1"}[5m] offset 1h))`,
+		},
+		{
+			query: `sum(rate(a[5m])) without (instance) / on(job) group_left(team) b > bool 0`,
+			want:  `sum(rate(a{cluster="c1"}[5m])) without (instance) / on(job) group_left(team) b{cluster="c1"} > bool 0`,
+		},
+		{
+			query: `max_over_time(deriv(rate(x[1m])[5m:1m])[10m:]) and y unless z or w`,
+			want:  `max_over_time(deriv(rate(x{cluster="c1"}[1m])[5m:1m])[10m:]) and y{cluster="c1"} unless z{cluster="c1"} or w{cluster="c1"}`,
+		},
+		{
+			query: `label_replace(up, "dst", "$1", "src", "(.*)") @ start()`,
+			want:  `label_replace(up{cluster="c1"}, "dst", "$1", "src", "(.*)") @ start()`,
+		},
+		{query: `topk(5, x) # comment with y{}`, want: `topk(5, x{cluster="c1"}) # comment with y{}`},
+		{query: `1 + 2 * Inf`, want: `1 + 2 * Inf`},
+		// MetricsQL
+		{query: `{a="b" or c="d"}`, want: `{a="b",cluster="c1" or c="d",cluster="c1"}`},
+		{query: `sum(x) by (y) limit 5`, want: `sum(x{cluster="c1"}) by (y) limit 5`},
+		{query: `x default limit`, want: `x{cluster="c1"} default limit{cluster="c1"}`},
+		{query: `tcp.bytes{job="a"}`, want: `tcp.bytes{job="a",cluster="c1"}`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.query, func(t *testing.T) {
+			got, err := SetPromQLMatcher(tc.query, "cluster", "c1")
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("unexpected query: got %s want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSetPromQLMatcherInvalid(t *testing.T) {
+	for _, query := range []string{`up{job="node"`, `up{job="node}`, `up{a={b}}`} {
+		if _, err := SetPromQLMatcher(query, "cluster", "c1"); err == nil {
+			t.Fatalf("expected error for %s", query)
+		}
+	}
+}
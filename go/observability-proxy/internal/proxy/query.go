@@ -0,0 +1,65 @@
+package proxy
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// queryAPI describes the parameters of a Prometheus query API endpoint that select series.
+type queryAPI struct {
+	params []string
+	// requireMatch adds a match[] selector when the client sends none, the endpoint
+	// reads every series otherwise
+	requireMatch bool
+}
+
+var (
+	expressionAPI = queryAPI{params: []string{"query"}}
+	seriesAPI     = queryAPI{params: []string{"match[]"}, requireMatch: true}
+	infoAPI       = queryAPI{}
+)
+
+// lookupQueryAPI returns the query API served at the given path suffix. Endpoints
+// that cannot be restricted to a single cluster are not proxied.
+func lookupQueryAPI(suffix string) (queryAPI, bool) {
+	switch suffix {
+	case "/api/v1/query", "/api/v1/query_range", "/api/v1/query_exemplars":
+		return expressionAPI, true
+	case "/api/v1/series", "/api/v1/labels":
+		return seriesAPI, true
+	case "/api/v1/status/buildinfo":
+		return infoAPI, true
+	}
+
+	segments := splitPath(suffix)
+	if len(segments) == 5 && segments[0] == "api" && segments[1] == "v1" && segments[2] == "label" && segments[4] == "values" {
+		return seriesAPI, true
+	}
+
+	return queryAPI{}, false
+}
+
+// Restrict rewrites every selecting parameter in values to the name="value" series.
+func (a queryAPI) Restrict(values url.Values, name, value string) error {
+	for _, param := range a.params {
+		for i, query := range values[param] {
+			restricted, err := SetPromQLMatcher(query, name, value)
+			if err != nil {
+				return err
+			}
+			values[param][i] = restricted
+		}
+	}
+
+	return nil
+}
+
+// RequireMatch adds a name="value" match[] selector to query if neither query nor
+// form select any series.
+func (a queryAPI) RequireMatch(query, form url.Values, name, value string) {
+	if !a.requireMatch || len(query["match[]"]) > 0 || len(form["match[]"]) > 0 {
+		return
+	}
+
+	query.Set("match[]", "{"+name+"="+strconv.Quote(value)+"}")
+}
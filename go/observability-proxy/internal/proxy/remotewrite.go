@@ -0,0 +1,217 @@
+package proxy
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/golang/snappy"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// maxRemoteWriteSize bounds the decompressed size of a remote-write request.
+const maxRemoteWriteSize = 256 << 20
+
+// Field numbers of the Prometheus remote-write 1.0 protobuf messages.
+const (
+	writeRequestTimeseries = 1
+	timeSeriesLabels       = 1
+	timeSeriesSamples      = 2
+	labelName              = 1
+	labelValue             = 2
+	sampleValue            = 1
+	sampleTimestamp        = 2
+)
+
+var errRemoteWriteV2 = errors.New("remote-write 2.0 is not supported")
+
+// checkRemoteWriteFormat accepts snappy-compressed remote-write 1.0 requests only, the
+// 2.0 format references labels through a symbol table that is not rewritten.
+func checkRemoteWriteFormat(contentType, contentEncoding string) error {
+	if strings.Contains(contentType, "io.prometheus.write.v2.Request") {
+		return errRemoteWriteV2
+	}
+	if contentEncoding != "" && !strings.EqualFold(contentEncoding, "snappy") {
+		return fmt.Errorf("unsupported content encoding %q", contentEncoding)
+	}
+
+	return nil
+}
+
+// SetRemoteWriteLabel rewrites a snappy-compressed remote-write request so every
+// series carries name=value, replacing any label of the same name sent by the client.
+func SetRemoteWriteLabel(body []byte, name, value string) ([]byte, error) {
+	size, err := snappy.DecodedLen(body)
+	if err != nil {
+		return nil, fmt.Errorf("decode snappy: %w", err)
+	}
+	if size > maxRemoteWriteSize {
+		return nil, fmt.Errorf("decoded remote-write request exceeds %d bytes", maxRemoteWriteSize)
+	}
+
+	decoded, err := snappy.Decode(nil, body)
+	if err != nil {
+		return nil, fmt.Errorf("decode snappy: %w", err)
+	}
+
+	out := make([]byte, 0, len(decoded)+len(decoded)/8)
+	err = eachField(decoded, func(num protowire.Number, typ protowire.Type, field []byte) error {
+		if num != writeRequestTimeseries || typ != protowire.BytesType {
+			out = append(out, field...)
+			return nil
+		}
+
+		series, n := protowire.ConsumeBytes(field[protowire.SizeTag(num):])
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+
+		rewritten, err := setSeriesLabel(series, name, value)
+		if err != nil {
+			return err
+		}
+
+		out = protowire.AppendTag(out, num, protowire.BytesType)
+		out = protowire.AppendBytes(out, rewritten)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("parse remote-write request: %w", err)
+	}
+
+	return snappy.Encode(nil, out), nil
+}
+
+// setSeriesLabel rewrites a TimeSeries message, keeping its labels sorted by name
+func setSeriesLabel(series []byte, name, value string) ([]byte, error) {
+	out := make([]byte, 0, len(series)+len(name)+len(value)+8)
+	rest := make([]byte, 0, len(series))
+	inserted := false
+	insert := func() {
+		label := protowire.AppendTag(nil, labelName, protowire.BytesType)
+		label = protowire.AppendString(label, name)
+		label = protowire.AppendTag(label, labelValue, protowire.BytesType)
+		label = protowire.AppendString(label, value)
+
+		out = protowire.AppendTag(out, timeSeriesLabels, protowire.BytesType)
+		out = protowire.AppendBytes(out, label)
+		inserted = true
+	}
+
+	err := eachField(series, func(num protowire.Number, typ protowire.Type, field []byte) error {
+		if num != timeSeriesLabels || typ != protowire.BytesType {
+			rest = append(rest, field...)
+			return nil
+		}
+
+		label, n := protowire.ConsumeBytes(field[protowire.SizeTag(num):])
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+
+		current, err := readLabelName(label)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case current == name:
+			return nil
+		case !inserted && current > name:
+			insert()
+		}
+
+		out = append(out, field...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !inserted {
+		insert()
+	}
+
+	return append(out, rest...), nil
+}
+
+func readLabelName(label []byte) (string, error) {
+	var name string
+	err := eachField(label, func(num protowire.Number, typ protowire.Type, field []byte) error {
+		if num == labelName && typ == protowire.BytesType {
+			value, n := protowire.ConsumeString(field[protowire.SizeTag(num):])
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			name = value
+		}
+		return nil
+	})
+
+	return name, err
+}
+
+// eachField calls fn with the number, type and raw bytes (tag included) of each
+// top-level field of a protobuf message
+func eachField(msg []byte, fn func(num protowire.Number, typ protowire.Type, field []byte) error) error {
+	for len(msg) > 0 {
+		num, typ, n := protowire.ConsumeField(msg)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+
+		if err := fn(num, typ, msg[:n]); err != nil {
+			return err
+		}
+		msg = msg[n:]
+	}
+
+	return nil
+}
+
+type promLabel struct {
+	name  string
+	value string
+}
+
+type promSample struct {
+	value     float64
+	timestamp int64
+}
+
+type promSeries struct {
+	labels  []promLabel
+	samples []promSample
+}
+
+// encodeRemoteWrite encodes series as a snappy-compressed remote-write 1.0 request
+func encodeRemoteWrite(series []promSeries) []byte {
+	var out []byte
+	for _, s := range series {
+		var encoded []byte
+		for _, l := range s.labels {
+			label := protowire.AppendTag(nil, labelName, protowire.BytesType)
+			label = protowire.AppendString(label, l.name)
+			label = protowire.AppendTag(label, labelValue, protowire.BytesType)
+			label = protowire.AppendString(label, l.value)
+
+			encoded = protowire.AppendTag(encoded, timeSeriesLabels, protowire.BytesType)
+			encoded = protowire.AppendBytes(encoded, label)
+		}
+		for _, s := range s.samples {
+			sample := protowire.AppendTag(nil, sampleValue, protowire.Fixed64Type)
+			sample = protowire.AppendFixed64(sample, math.Float64bits(s.value))
+			sample = protowire.AppendTag(sample, sampleTimestamp, protowire.VarintType)
+			sample = protowire.AppendVarint(sample, uint64(s.timestamp))
+
+			encoded = protowire.AppendTag(encoded, timeSeriesSamples, protowire.BytesType)
+			encoded = protowire.AppendBytes(encoded, sample)
+		}
+
+		out = protowire.AppendTag(out, writeRequestTimeseries, protowire.BytesType)
+		out = protowire.AppendBytes(out, encoded)
+	}
+
+	return snappy.Encode(nil, out)
+}
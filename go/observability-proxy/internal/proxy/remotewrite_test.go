@@ -0,0 +1,132 @@
+package proxy
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/golang/snappy"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// remoteWriteBody encodes a remote-write request with a single `up` series and a sample
+func remoteWriteBody(t *testing.T, labels ...string) []byte {
+	t.Helper()
+
+	labels = append([]string{"__name__", "up"}, labels...)
+	var series []byte
+	for i := 0; i+1 < len(labels); i += 2 {
+		label := protowire.AppendTag(nil, labelName, protowire.BytesType)
+		label = protowire.AppendString(label, labels[i])
+		label = protowire.AppendTag(label, labelValue, protowire.BytesType)
+		label = protowire.AppendString(label, labels[i+1])
+		series = protowire.AppendTag(series, timeSeriesLabels, protowire.BytesType)
+		series = protowire.AppendBytes(series, label)
+	}
+
+	sample := protowire.AppendTag(nil, sampleValue, protowire.Fixed64Type)
+	sample = protowire.AppendFixed64(sample, 0x3ff0000000000000) // 1.0
+	sample = protowire.AppendTag(sample, sampleTimestamp, protowire.VarintType)
+	sample = protowire.AppendVarint(sample, 1700000000000)
+	series = protowire.AppendTag(series, timeSeriesSamples, protowire.BytesType)
+	series = protowire.AppendBytes(series, sample)
+
+	req := protowire.AppendTag(nil, writeRequestTimeseries, protowire.BytesType)
+	req = protowire.AppendBytes(req, series)
+	return snappy.Encode(nil, req)
+}
+
+// remoteWriteLabels decodes the labels of every series of a remote-write request
+func remoteWriteLabels(t *testing.T, body []byte) string {
+	t.Helper()
+
+	decoded, err := snappy.Decode(nil, body)
+	if err != nil {
+		t.Fatalf("decode snappy: %v", err)
+	}
+
+	var out []string
+	err = eachField(decoded, func(num protowire.Number, _ protowire.Type, field []byte) error {
+		series, _ := protowire.ConsumeBytes(field[protowire.SizeTag(num):])
+		var labels []string
+		err := eachField(series, func(num protowire.Number, _ protowire.Type, field []byte) error {
+			if num != timeSeriesLabels {
+				return nil
+			}
+			label, _ := protowire.ConsumeBytes(field[protowire.SizeTag(num):])
+			var pair [2]string
+			err := eachField(label, func(num protowire.Number, _ protowire.Type, field []byte) error {
+				value, _ := protowire.ConsumeString(field[protowire.SizeTag(num):])
+				pair[num-1] = value
+				return nil
+			})
+			labels = append(labels, pair[0]+"="+pair[1])
+			return err
+		})
+		out = append(out, fmt.Sprintf("[%s]", strings.Join(labels, " ")))
+		return err
+	})
+	if err != nil {
+		t.Fatalf("parse remote-write request: %v", err)
+	}
+
+	return strings.Join(out, "")
+}
+
+func TestSetRemoteWriteLabel(t *testing.T) {
+	cases := []struct {
+		name   string
+		labels []string
+		want   string
+	}{
+		{name: "appended", labels: []string{"instance", "a"}, want: "[__name__=up cluster=c1 instance=a]"},
+		{name: "last", labels: nil, want: "[__name__=up cluster=c1]"},
+		{name: "replaced", labels: []string{"cluster", "spoofed", "job", "j"}, want: "[__name__=up cluster=c1 job=j]"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			body, err := SetRemoteWriteLabel(remoteWriteBody(t, tc.labels...), "cluster", "c1")
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if got := remoteWriteLabels(t, body); got != tc.want {
+				t.Fatalf("unexpected labels: got %s want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSetRemoteWriteLabelKeepsSamples(t *testing.T) {
+	body, err := SetRemoteWriteLabel(remoteWriteBody(t), "cluster", "c1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	decoded, _ := snappy.Decode(nil, body)
+	original, _ := snappy.Decode(nil, remoteWriteBody(t))
+	if got, want := len(decoded)-len(original), len("cluster")+len("c1")+6; got != want {
+		t.Fatalf("unexpected size difference: got %d want %d", got, want)
+	}
+}
+
+func TestSetRemoteWriteLabelInvalid(t *testing.T) {
+	if _, err := SetRemoteWriteLabel([]byte("not snappy"), "cluster", "c1"); err == nil {
+		t.Fatalf("expected error")
+	}
+	if _, err := SetRemoteWriteLabel(snappy.Encode(nil, []byte{0x0a, 0x05, 0x01}), "cluster", "c1"); err == nil {
+		t.Fatalf("expected error")
+	}
+}
+
+func TestCheckRemoteWriteFormat(t *testing.T) {
+	if err := checkRemoteWriteFormat("application/x-protobuf", "snappy"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := checkRemoteWriteFormat("application/x-protobuf;proto=io.prometheus.write.v2.Request", "snappy"); err == nil {
+		t.Fatalf("expected error for remote-write 2.0")
+	}
+	if err := checkRemoteWriteFormat("application/x-protobuf", "zstd"); err == nil {
+		t.Fatalf("expected error for zstd")
+	}
+}
@@ -78,3 +78,22 @@ func splitPath(raw string) []string {
 
 	return strings.Split(trimmed, "/")
 }
+
+// ElasticWriteIndex returns the index translated documents are written to. Console
+// configures the query pattern of the logs indices (e.g. plrl-x-logs-*), which are
+// written through their rollover alias (plrl-x-logs-write).
+func ElasticWriteIndex(index string) (string, error) {
+	if index == "" {
+		return "", fmt.Errorf("elastic index is not configured")
+	}
+
+	if prefix, ok := strings.CutSuffix(index, "*"); ok {
+		prefix = strings.TrimSuffix(prefix, "-")
+		if prefix == "" {
+			return "", fmt.Errorf("elastic index %q has no write alias", index)
+		}
+		return prefix + "-write", nil
+	}
+
+	return index, nil
+}
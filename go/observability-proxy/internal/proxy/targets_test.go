@@ -30,3 +30,27 @@ func TestBuildPrometheusQueryTarget(t *testing.T) {
 		t.Fatalf("unexpected target: got %s want %s", got, want)
 	}
 }
+
+func TestElasticWriteIndex(t *testing.T) {
+	cases := map[string]string{
+		"plrl-x-logs-*": "plrl-x-logs-write",
+		"logs*":         "logs-write",
+		"logs":          "logs",
+	}
+
+	for index, want := range cases {
+		got, err := ElasticWriteIndex(index)
+		if err != nil {
+			t.Fatalf("expected no error for %q, got %v", index, err)
+		}
+		if got != want {
+			t.Fatalf("unexpected write index for %q: got %s want %s", index, got, want)
+		}
+	}
+
+	for _, index := range []string{"", "*"} {
+		if _, err := ElasticWriteIndex(index); err == nil {
+			t.Fatalf("expected error for %q", index)
+		}
+	}
+}
@@ -172,6 +172,53 @@ defmodule Toolquery.AzureConnection do
   field :client_secret, 4, type: :string, json_name: "clientSecret"
 end
 
+defmodule Toolquery.ClickhouseConnection do
+  @moduledoc false
+
+  use Protobuf,
+    full_name: "toolquery.ClickhouseConnection",
+    protoc_gen_elixir_version: "0.16.0",
+    syntax: :proto3
+
+  field :url, 1, type: :string
+  field :username, 2, proto3_optional: true, type: :string
+  field :password, 3, proto3_optional: true, type: :string
+  field :database, 4, proto3_optional: true, type: :string
+  field :logs_table, 5, proto3_optional: true, type: :string, json_name: "logsTable"
+  field :traces_table, 6, proto3_optional: true, type: :string, json_name: "tracesTable"
+end
+
+defmodule Toolquery.VictoriaLogsConnection do
+  @moduledoc false
+
+  use Protobuf,
+    full_name: "toolquery.VictoriaLogsConnection",
+    protoc_gen_elixir_version: "0.16.0",
+    syntax: :proto3
+
+  field :url, 1, type: :string
+  field :token, 2, proto3_optional: true, type: :string
+  field :username, 3, proto3_optional: true, type: :string
+  field :password, 4, proto3_optional: true, type: :string
+  field :account_id, 5, proto3_optional: true, type: :string, json_name: "accountId"
+  field :project_id, 6, proto3_optional: true, type: :string, json_name: "projectId"
+end
+
+defmodule Toolquery.MimirConnection do
+  @moduledoc false
+
+  use Protobuf,
+    full_name: "toolquery.MimirConnection",
+    protoc_gen_elixir_version: "0.16.0",
+    syntax: :proto3
+
+  field :url, 1, type: :string
+  field :token, 2, proto3_optional: true, type: :string
+  field :tenant_id, 3, proto3_optional: true, type: :string, json_name: "tenantId"
+  field :username, 4, proto3_optional: true, type: :string
+  field :password, 5, proto3_optional: true, type: :string
+end
+
 defmodule Toolquery.ToolConnection do
   @moduledoc false
 
@@ -193,6 +240,14 @@ defmodule Toolquery.ToolConnection do
   field :azure, 9, type: Toolquery.AzureConnection, oneof: 0
   field :jaeger, 10, type: Toolquery.JaegerConnection, oneof: 0
   field :opensearch, 11, type: Toolquery.OpensearchConnection, oneof: 0
+  field :clickhouse, 12, type: Toolquery.ClickhouseConnection, oneof: 0
+
+  field :victoria_logs, 13,
+    type: Toolquery.VictoriaLogsConnection,
+    json_name: "victoriaLogs",
+    oneof: 0
+
+  field :mimir, 14, type: Toolquery.MimirConnection, oneof: 0
 end
 
 defmodule Toolquery.TimeRange do
@@ -277,6 +332,7 @@ defmodule Toolquery.LogsQueryInput do
   field :limit, 4, proto3_optional: true, type: :int32
   field :facets, 5, repeated: true, type: Toolquery.LogsQueryFacet
   field :options, 6, proto3_optional: true, type: Toolquery.LogsOptions
+  field :page_token, 7, proto3_optional: true, type: :string, json_name: "pageToken"
 end
 
 defmodule Toolquery.LogsOptions do
@@ -314,6 +370,7 @@ defmodule Toolquery.TracesQueryInput do
   field :range, 3, type: Toolquery.TimeRange
   field :limit, 4, proto3_optional: true, type: :int32
   field :options, 5, proto3_optional: true, type: Toolquery.TracesOptions
+  field :page_token, 6, proto3_optional: true, type: :string, json_name: "pageToken"
 end
 
 defmodule Toolquery.TracesOptions do
@@ -548,6 +605,7 @@ defmodule Toolquery.LogsQueryOutput do
     syntax: :proto3
 
   field :logs, 1, repeated: true, type: Toolquery.LogEntry
+  field :next_page_token, 2, proto3_optional: true, type: :string, json_name: "nextPageToken"
 end
 
 defmodule Toolquery.TraceSpan.TagsEntry do
@@ -590,6 +648,7 @@ defmodule Toolquery.TracesQueryOutput do
     syntax: :proto3
 
   field :spans, 1, repeated: true, type: Toolquery.TraceSpan
+  field :next_page_token, 2, proto3_optional: true, type: :string, json_name: "nextPageToken"
 end
 
 defmodule Toolquery.InvokeLambdaInput do
@@ -654,6 +713,10 @@ defmodule Toolquery.ToolQuery.Service do
 
   rpc :Traces, Toolquery.TracesQueryInput, Toolquery.TracesQueryOutput
 
+  rpc :StreamLogs, Toolquery.LogsQueryInput, stream(Toolquery.LogsQueryOutput)
+
+  rpc :StreamTraces, Toolquery.TracesQueryInput, stream(Toolquery.TracesQueryOutput)
+
   rpc :InvokeLambda, Toolquery.InvokeLambdaInput, Toolquery.InvokeLambdaOutput
 
   rpc :RunLua, Toolquery.RunLuaInput, Toolquery.RunLuaOutput
@@ -48,7 +48,8 @@ defimpl Console.AI.Evidence, for: Console.Schema.StackRun do
   end
   defp error_messages(_), do: []
 
-  defp plan(%StackRun{type: :terraform, state: %StackState{plan: p}}) when is_binary(p) and byte_size(p) > 0 do
+  defp plan(%StackRun{type: t, state: %StackState{plan: p}})
+    when t in ~w(terraform opentofu cdktf)a and is_binary(p) and byte_size(p) > 0 do
     [{:user, "the terraform run also has an associate terraform plan that could be useful below:\n#{p}"}]
   end
   defp plan(_), do: []
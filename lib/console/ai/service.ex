@@ -1,7 +1,7 @@
 defmodule Console.AI.Service do
   use Console.Services.Base
   import Console.AI.Policy
-  alias Console.Schema.{User, AiInsight}
+  alias Console.Schema.{User, AiInsight, AiUsageRollup}
 
   @type ai_resp :: {:ok, AiInsight.t} | Console.error
 
@@ -13,4 +13,18 @@ defmodule Console.AI.Service do
     Repo.get!(AiInsight, id)
     |> allow(user, :read)
   end
+
+  @doc """
+  Adds AI usage reported by the AI proxy to the daily rollup of its caller and model
+  """
+  @spec record_usage(map) :: {:ok, AiUsageRollup.t} | Console.error
+  def record_usage(%{provider: _, model: _} = attrs) do
+    attrs = Map.put_new(attrs, :day, Date.utc_today())
+    %AiUsageRollup{}
+    |> AiUsageRollup.changeset(attrs)
+    |> Repo.insert(
+      conflict_target: AiUsageRollup.conflict_target(),
+      on_conflict: [inc: Map.take(attrs, ~w(prompt_tokens completion_tokens cached_tokens cost requests)a) |> Map.to_list()]
+    )
+  end
 end
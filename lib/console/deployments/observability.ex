@@ -258,17 +258,17 @@ defmodule Console.Deployments.Observability do
   """
   @spec heat_map(Cluster.t | Service.t, :pod | :namespace | :node) :: {:ok, map} | error
   def heat_map(resource, flavor \\ :pod)
-  def heat_map(%Cluster{handle: cluster}, flavor) do
+  def heat_map(%Cluster{} = cluster, flavor) do
     queries(:heat, flavor)
-    |> bulk_query(%{cluster: cluster, filter: "", rate: "2h"})
+    |> bulk_query(%{cluster: cluster_selector(cluster), filter: "", rate: "2h"})
   end
 
   def heat_map(%Service{namespace: ns} = service, flavor) when flavor in [:pod, :node] do
-    %Service{cluster: %Cluster{handle: cluster}} =
+    %Service{cluster: %Cluster{} = cluster} =
       Repo.preload(service, [:cluster])
 
     queries(:heat, flavor)
-    |> bulk_query(%{cluster: cluster, filter: ",namespace=\"#{ns}\"", rate: "2h"})
+    |> bulk_query(%{cluster: cluster_selector(cluster), filter: ",namespace=\"#{ns}\"", rate: "2h"})
   end
 
   def heat_map(_, flavor), do: {:error, "cannot aggregate utilization by #{flavor} for that resource"}
@@ -277,9 +277,9 @@ defmodule Console.Deployments.Observability do
   Queries memory usage by pod for a given cluster and filters those that don't exceed a significant threshold
   """
   @spec noisy_neighbors(Cluster.t) :: {:ok, map} | error
-  def noisy_neighbors(%Cluster{handle: cluster}) do
+  def noisy_neighbors(%Cluster{} = cluster) do
     queries(:noisy)
-    |> bulk_query(%{cluster: cluster, rate: "2h"})
+    |> bulk_query(%{cluster: cluster_selector(cluster), rate: "2h"})
     |> case do
       {:ok, %{cpu: %Prometheus.Data{result: cpu} = cpu_res, memory: %Prometheus.Data{result: memory} = mem_res}} ->
         cpu    = Enum.filter(cpu, & &1.value > @noisy_threshold)
@@ -293,21 +293,21 @@ defmodule Console.Deployments.Observability do
   Queries opinionated metrics for a set of different, relevant scopes
   """
   @spec query(Cluster.t | {Cluster.t, binary} | ServiceComponent.t, binary, binary, binary) :: {:ok, map} | error
-  def query(%Cluster{handle: cluster}, start, stop, step) do
+  def query(%Cluster{} = cluster, start, stop, step) do
     queries(:cluster)
-    |> bulk_range_query(%{cluster: cluster, rate: rate_window(step)}, start, stop, step)
+    |> bulk_range_query(%{cluster: cluster_selector(cluster), rate: rate_window(step)}, start, stop, step)
   end
 
-  def query({%Cluster{handle: cluster}, node}, start, stop, step) do
+  def query({%Cluster{} = cluster, node}, start, stop, step) do
     queries(:node)
-    |> bulk_range_query(%{cluster: cluster, instance: node, rate: rate_window(step)}, start, stop, step)
+    |> bulk_range_query(%{cluster: cluster_selector(cluster), instance: node, rate: rate_window(step)}, start, stop, step)
   end
 
   def query(%Service{namespace: ns} = service, start, stop, step) do
     service = Repo.preload(service, [:cluster])
     bulk_range_query(
       queries(:service),
-      [cluster: service.cluster.handle, namespace: ns, rate: rate_window(step)],
+      [cluster: cluster_selector(service.cluster), namespace: ns, rate: rate_window(step)],
       start,
       stop,
       step
@@ -324,15 +324,19 @@ defmodule Console.Deployments.Observability do
 
   defp component_args(%ServiceComponent{group: "apps", version: "v1", kind: kind, name: name, namespace: ns} = comp)
     when kind in ~w(DaemonSet daemonsets),
-      do: {:ok, [namespace: ns, name: name, cluster: comp.service.cluster.handle, regex: "-[a-z0-9]+"]}
+      do: {:ok, [namespace: ns, name: name, cluster: cluster_selector(comp.service.cluster), regex: "-[a-z0-9]+"]}
   defp component_args(%ServiceComponent{group: "apps", version: "v1", kind: kind, name: name, namespace: ns} = comp)
     when kind in ~w(Deployment deployments),
-      do: {:ok, [namespace: ns, name: name, cluster: comp.service.cluster.handle, regex: "-[a-z0-9]+-?[a-z0-9]+"]}
+      do: {:ok, [namespace: ns, name: name, cluster: cluster_selector(comp.service.cluster), regex: "-[a-z0-9]+-?[a-z0-9]+"]}
   defp component_args(%ServiceComponent{group: "apps", version: "v1", kind: kind, name: name, namespace: ns} = comp)
     when kind in ~w(StatefulSet statefulsets),
-      do: {:ok, [namespace: ns, name: name, cluster: comp.service.cluster.handle, regex: "-[0-9]+"]}
+      do: {:ok, [namespace: ns, name: name, cluster: cluster_selector(comp.service.cluster), regex: "-[0-9]+"]}
   defp component_args(%ServiceComponent{group: g, kind: k}), do: {:error, "unsupported component kind #{g}/#{k}"}
 
+  # series ingested through the observability proxy are labeled with the immutable cluster id,
+  # series shipped directly to prometheus with the handle
+  defp cluster_selector(%Cluster{id: id, handle: handle}), do: "#{handle}|#{id}"
+
   # Keep CPU rates stable even when range-query resolution is shortened for a
   # higher-density chart.  A rate window below five minutes is unreliable for
   # the common 30-60 second Prometheus scrape intervals.
@@ -3,7 +3,7 @@ defmodule Console.Deployments.Stacks.Commands do
 
   def commands(stack, dry \\ false)
 
-  def commands(%Stack{type: :terraform} = stack, dry) do
+  def commands(%Stack{type: t} = stack, dry) when t in ~w(terraform opentofu cdktf)a do
     terraform_commands(stack, dry)
     |> stitch_hooks(stack, dry)
   end
@@ -124,6 +124,7 @@ defmodule Console.Deployments.Stacks.Commands do
     ])
   end
 
+  defp tf_command(%Stack{type: :opentofu}), do: "tofu"
   defp tf_command(%Stack{configuration: %{terraform: %Stack.Configuration.Terraform{tofu: true}}}),
     do: "tofu"
   defp tf_command(_), do: "terraform"
@@ -176,6 +176,7 @@ defmodule Console.GraphQl.Deployments.Service do
   input_object :component_content_attributes do
     field :desired, :string, description: "the desired state of a service component as determined from the configured manifests"
     field :live,    :string
+    field :diff,    :string, description: "a json encoded structured diff between the live and desired states of a service component"
   end
 
   input_object :service_error_attributes do
@@ -478,6 +479,7 @@ defmodule Console.GraphQl.Deployments.Service do
     field :id,      non_null(:id)
     field :live,    :string
     field :desired, :string, description: "the inferred desired state of this component"
+    field :diff,    :string, description: "a json encoded structured diff between the live and desired states of this component"
 
     timestamps()
   end
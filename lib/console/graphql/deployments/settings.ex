@@ -151,6 +151,8 @@ defmodule Console.GraphQl.Deployments.Settings do
     field :graph, :graph_store_attributes
     field :price_sheets, list_of(:ai_price_sheet_attributes),
       description: "per-model token prices used to calculate AI usage costs"
+    field :guardrails, :ai_guardrails_attributes,
+      description: "prompt guardrails enforced by the AI proxy"
   end
 
   input_object :ai_price_sheet_attributes do
@@ -160,6 +162,26 @@ defmodule Console.GraphQl.Deployments.Settings do
     field :output_price, :float
   end
 
+  input_object :ai_guardrails_attributes do
+    field :enabled,         :boolean, description: "whether guardrails are enforced on AI proxy requests"
+    field :redact_pii,      :boolean, description: "whether to replace PII in prompts with reversible placeholders"
+    field :detect_secrets,  :boolean, description: "whether to redact credentials like cloud keys, private keys and kubernetes tokens"
+    field :max_prompt_size, :integer, description: "the maximum number of characters allowed in a prompt"
+    field :pii_terms,       list_of(:string), description: "a dictionary of terms that are always redacted"
+    field :pii_patterns,    list_of(:ai_pii_pattern_attributes), description: "custom regexes for PII that should be redacted"
+    field :blocked_topics,  list_of(:ai_blocked_topic_attributes), description: "topics that cause a prompt to be rejected"
+  end
+
+  input_object :ai_pii_pattern_attributes do
+    field :name,    non_null(:string), description: "the name of the placeholder used for redacted matches"
+    field :pattern, non_null(:string), description: "the regex matching this kind of PII"
+  end
+
+  input_object :ai_blocked_topic_attributes do
+    field :name,     non_null(:string), description: "the name of the topic, reported when a prompt is rejected"
+    field :patterns, list_of(:string), description: "case-insensitive regexes matching prompts about this topic"
+  end
+
   input_object :analysis_rates_attributes do
     field :fast, :integer,
       description:
@@ -557,6 +579,8 @@ defmodule Console.GraphQl.Deployments.Settings do
     field :vertex, :vertex_ai_settings
     field :price_sheets, list_of(:ai_price_sheet),
       description: "per-model token prices used to calculate AI usage costs"
+    field :guardrails, :ai_guardrails_settings,
+      description: "prompt guardrails enforced by the AI proxy"
   end
 
   object :ai_price_sheet do
@@ -566,6 +590,27 @@ defmodule Console.GraphQl.Deployments.Settings do
     field :output_price, :float
   end
 
+  @desc "Prompt guardrails enforced by the AI proxy before and after provider calls"
+  object :ai_guardrails_settings do
+    field :enabled,         :boolean, description: "whether guardrails are enforced on AI proxy requests"
+    field :redact_pii,      :boolean, description: "whether to replace PII in prompts with reversible placeholders"
+    field :detect_secrets,  :boolean, description: "whether to redact credentials like cloud keys, private keys and kubernetes tokens"
+    field :max_prompt_size, :integer, description: "the maximum number of characters allowed in a prompt"
+    field :pii_terms,       list_of(:string), description: "a dictionary of terms that are always redacted"
+    field :pii_patterns,    list_of(:ai_pii_pattern), description: "custom regexes for PII that should be redacted"
+    field :blocked_topics,  list_of(:ai_blocked_topic), description: "topics that cause a prompt to be rejected"
+  end
+
+  object :ai_pii_pattern do
+    field :name,    non_null(:string), description: "the name of the placeholder used for redacted matches"
+    field :pattern, non_null(:string), description: "the regex matching this kind of PII"
+  end
+
+  object :ai_blocked_topic do
+    field :name,     non_null(:string), description: "the name of the topic, reported when a prompt is rejected"
+    field :patterns, list_of(:string), description: "case-insensitive regexes matching prompts about this topic"
+  end
+
   object :vector_store_settings do
     field :enabled, :boolean
     field :store, :vector_store
@@ -52,6 +52,7 @@ defmodule Console.GraphQl.Deployments.Stack do
     field :pulumi,      :pulumi_configuration_attributes, description: "the pulumi configuration for this stack"
     field :ansible,     :ansible_configuration_attributes, description: "the ansible configuration for this stack"
     field :ai_approval, :ai_approval_attributes, description: "the ai approval configuration for this stack"
+    field :cost_estimation, :cost_estimation_attributes, description: "the cost estimation configuration for this stack"
   end
 
   input_object :stack_overrides_attributes do
@@ -98,6 +99,11 @@ defmodule Console.GraphQl.Deployments.Stack do
     field :delete_playbook,  :string, description: "the playbook to run when deleting the stack"
   end
 
+  input_object :cost_estimation_attributes do
+    field :enabled,            non_null(:boolean), description: "whether to price planned changes after the plan stage"
+    field :approval_threshold, :float, description: "the monthly cost increase above which a run requires manual approval"
+  end
+
   input_object :ai_approval_attributes do
     field :enabled,       non_null(:boolean)
     field :ignore_cancel, non_null(:boolean)
@@ -389,6 +395,7 @@ defmodule Console.GraphQl.Deployments.Stack do
     field :pulumi,      :pulumi_configuration, description: "the pulumi configuration for this stack"
     field :ansible,     :ansible_configuration, description: "the ansible configuration for this stack"
     field :ai_approval, :ai_approval_configuration, description: "the ai approval configuration for this stack"
+    field :cost_estimation, :cost_estimation_configuration, description: "the cost estimation configuration for this stack"
   end
 
   @desc "Configuration for ai approval of a stack run"
@@ -399,6 +406,12 @@ defmodule Console.GraphQl.Deployments.Stack do
     field :file,          non_null(:string), description: "the rules file to use alongside the git reference"
   end
 
+  @desc "Configuration for estimating the cost of planned changes in a stack run"
+  object :cost_estimation_configuration do
+    field :enabled,            non_null(:boolean), description: "whether to price planned changes after the plan stage"
+    field :approval_threshold, :float, description: "the monthly cost increase above which a run requires manual approval"
+  end
+
   @desc "Configuration for applying policy enforcement to a stack"
   object :policy_engine do
     field :type,            non_null(:policy_engine_type), description: "the policy engine to use with this stack"
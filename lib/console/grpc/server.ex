@@ -11,6 +11,36 @@ defmodule Console.GRPC.Server do
     %Plrl.MeterMetricsResponse{success: true}
   end
 
+  def meter_ai_usage(%Plrl.MeterAiUsageRequest{usage: usage}, _) do
+    Enum.each(usage, fn %Plrl.AiUsage{} = u ->
+      Console.Prom.Meter.incr_ai_tokens(max(u.promptTokens, 0) + max(u.completionTokens, 0))
+      :telemetry.execute(
+        Console.Prom.Plugin.metric_scope(:ai_usage),
+        %{
+          prompt_tokens: u.promptTokens,
+          completion_tokens: u.completionTokens,
+          cached_tokens: u.cachedTokens,
+          cost: u.cost,
+          requests: u.requests
+        },
+        %{provider: u.provider, model: u.model}
+      )
+
+      Console.AI.Service.record_usage(%{
+        user_id: u.userId,
+        cluster_id: u.clusterId,
+        provider: u.provider,
+        model: u.model,
+        prompt_tokens: max(u.promptTokens, 0),
+        completion_tokens: max(u.completionTokens, 0),
+        cached_tokens: max(u.cachedTokens, 0),
+        cost: max(u.cost, 0.0),
+        requests: max(u.requests, 0)
+      })
+    end)
+    %Plrl.MeterAiUsageResponse{success: true}
+  end
+
   def get_ai_config(_req, _) do
     Settings.fetch()
     |> to_pb()
@@ -25,11 +55,11 @@ defmodule Console.GRPC.Server do
 
   def proxy_authentication(%Plrl.ProxyAuthenticationRequest{token: token}, _) do
     case Console.authed_user(token) do
-      %User{} ->
-        %Plrl.ProxyAuthenticationResponse{authenticated: true}
+      %User{id: id} ->
+        %Plrl.ProxyAuthenticationResponse{authenticated: true, userId: id}
 
-      %Cluster{} = cluster ->
-        %Plrl.ProxyAuthenticationResponse{authenticated: Agents.has_runtime?(cluster)}
+      %Cluster{id: id} = cluster ->
+        %Plrl.ProxyAuthenticationResponse{authenticated: Agents.has_runtime?(cluster), clusterId: id}
 
       _ ->
         %Plrl.ProxyAuthenticationResponse{authenticated: false}
@@ -78,11 +108,25 @@ defmodule Console.GRPC.Server do
       anthropic: to_anthropic_pb(Map.get(ai, :anthropic)),
       vertexAi: to_vertex_pb(Map.get(ai, :vertex)),
       bedrock: to_bedrock_pb(Map.get(ai, :bedrock)),
-      azure: to_azure_pb(Map.get(ai, :azure))
+      azure: to_azure_pb(Map.get(ai, :azure)),
+      guardrails: to_guardrails_pb(Map.get(ai, :guardrails))
     }
   end
   defp to_pb(_), do: %Plrl.AiConfig{enabled: false}
 
+  defp to_guardrails_pb(%{enabled: true} = guardrails) do
+    %Plrl.GuardrailsConfig{
+      enabled: true,
+      redactPii: Map.get(guardrails, :redact_pii, true) != false,
+      detectSecrets: Map.get(guardrails, :detect_secrets, true) != false,
+      maxPromptSize: Map.get(guardrails, :max_prompt_size),
+      piiTerms: Map.get(guardrails, :pii_terms) || [],
+      piiPatterns: Enum.map(Map.get(guardrails, :pii_patterns) || [], &%Plrl.PiiPattern{name: &1.name, pattern: &1.pattern}),
+      blockedTopics: Enum.map(Map.get(guardrails, :blocked_topics) || [], &%Plrl.BlockedTopic{name: &1.name, patterns: Map.get(&1, :patterns) || []})
+    }
+  end
+  defp to_guardrails_pb(_), do: nil
+
   defp to_openai_pb(openai, defaults_provider \\ :openai)
   defp to_openai_pb(%{} = openai, defaults_provider) do
     defaults = Provider.defaults(defaults_provider)
@@ -199,7 +243,8 @@ defmodule Console.GRPC.Server do
   defp my_cluster_pb(%Cluster{} = cluster) do
     %Plrl.VerifyClusterResponse{
       id: cluster.id,
-      name: cluster.name
+      name: cluster.name,
+      handle: cluster.handle
     }
   end
 end
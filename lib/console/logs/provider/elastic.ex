@@ -100,6 +100,7 @@ defmodule Console.Logs.Provider.Elastic do
   defp build_query(%Query{} = q) do
     %{
       query: maybe_query(q)
+             |> exclude_traces()
              |> add_terms(q)
              |> add_pod(q)
              |> add_range(q)
@@ -111,15 +112,29 @@ defmodule Console.Logs.Provider.Elastic do
   end
 
   defp add_terms(query, %Query{resource: %Cluster{} = cluster}),
-    do: put_in(query[:bool][:filter], [%{term: %{"cluster.handle.keyword" => cluster.handle}}])
+    do: put_in(query[:bool][:filter], [cluster_filter(cluster)])
   defp add_terms(query, %Query{resource: %Service{cluster: %Cluster{} = cluster} = svc}) do
     put_in(query[:bool][:filter], [
       %{term: %{"kubernetes.namespace.keyword" => svc.namespace}},
-      %{term: %{"cluster.handle.keyword" => cluster.handle}}
+      cluster_filter(cluster)
     ])
   end
   defp add_terms(query, _), do: query
 
+  # documents ingested through the observability proxy carry the immutable cluster id
+  defp cluster_filter(%Cluster{id: id, handle: handle}) do
+    %{bool: %{
+      should: [
+        %{term: %{"cluster.handle.keyword" => handle}},
+        %{term: %{"cluster.keyword" => id}}
+      ],
+      minimum_should_match: 1
+    }}
+  end
+
+  # spans ingested through the observability proxy share the logs indices
+  defp exclude_traces(query), do: put_in(query[:bool][:must_not], [%{term: %{"signal.keyword" => "traces"}}])
+
   defp add_pod(query, %Query{pod: pod}) when is_binary(pod) and byte_size(pod) > 0,
     do: add_filter(query, %{term: %{"kubernetes.pod.name.keyword" => pod}})
   defp add_pod(query, _), do: query
@@ -182,6 +197,7 @@ defmodule Console.Logs.Provider.Elastic do
   defp build_aggregation_query(%Query{} = q) do
     %{
       query: maybe_query(q)
+             |> exclude_traces()
              |> add_terms(q)
              |> add_range(q)
              |> add_namespaces(q)
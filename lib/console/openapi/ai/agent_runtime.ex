@@ -22,7 +22,7 @@ defmodule Console.OpenAPI.AI.AgentRuntime do
     properties: timestamps(%{
       id: string(description: "Unique identifier for the agent runtime"),
       name: string(description: "Human-readable name of this runtime"),
-      type: ecto_enum(Console.Schema.AgentRuntime.Type, description: "Type of agent runtime (claude, opencode, gemini, custom)"),
+      type: ecto_enum(Console.Schema.AgentRuntime.Type, description: "Type of agent runtime (claude, opencode, gemini, codex, pi, aider, goose, custom)"),
       ai_proxy: boolean(description: "Whether this runtime uses the built-in Plural AI proxy for LLM requests"),
       default: boolean(description: "Whether this is the default runtime for coding agents"),
       cluster_id: string(description: "ID of the cluster this runtime is deployed on"),
@@ -29,6 +29,9 @@ defmodule Console.Prom.Meter do
 
   def incr_tokens(ref \\ __MODULE__, %ReqLLM.Response{} = result), do: GenServer.cast(ref, {:tokens, ReqLLM.Response.usage(result)})
 
+  def incr_ai_tokens(ref \\ __MODULE__, tokens) when is_integer(tokens),
+    do: GenServer.cast(ref, {:tokens, %{total_tokens: tokens}})
+
   def fetch(ref \\ __MODULE__), do: GenServer.call(ref, :fetch)
 
   def handle_cast({:incr, inc}, %State{ingest: ingest} = state), do: {:noreply, %{state | ingest: ingest + inc}}
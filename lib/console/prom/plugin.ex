@@ -2,6 +2,8 @@ defmodule Console.Prom.Plugin do
   use PromEx.Plugin
   alias Console.Deployments.Statistics
 
+  @ai_usage_tags [:provider, :model]
+
   def metric_scope(:git_agent), do: ~w(console git agent)a
   def metric_scope(:cluster_count), do: ~w(console cluster count)a
   def metric_scope(:unhealthy_cluster_count), do: ~w(console unhealthy cluster count)a
@@ -14,6 +16,7 @@ defmodule Console.Prom.Plugin do
   def metric_scope(:erlang_nodes), do: ~w(console erlang nodes count)a
   def metric_scope(:persisted_query_hit), do: ~w(console persisted query hit)a
   def metric_scope(:persisted_query_miss), do: ~w(console persisted query miss)a
+  def metric_scope(:ai_usage), do: ~w(console ai usage)a
 
   def plural_metric(name), do: [:plrl | name]
 
@@ -40,6 +43,41 @@ defmodule Console.Prom.Plugin do
           event_name: metric_scope(:persisted_query_miss),
           measurement: :count,
           description: "The number of persisted query misses."
+        ),
+        sum(
+          plural_metric(~w(ai prompt tokens)a),
+          event_name: metric_scope(:ai_usage),
+          measurement: :prompt_tokens,
+          description: "The number of prompt tokens consumed through the AI proxy.",
+          tags: @ai_usage_tags
+        ),
+        sum(
+          plural_metric(~w(ai completion tokens)a),
+          event_name: metric_scope(:ai_usage),
+          measurement: :completion_tokens,
+          description: "The number of completion tokens generated through the AI proxy.",
+          tags: @ai_usage_tags
+        ),
+        sum(
+          plural_metric(~w(ai cached tokens)a),
+          event_name: metric_scope(:ai_usage),
+          measurement: :cached_tokens,
+          description: "The number of prompt tokens served from provider caches through the AI proxy.",
+          tags: @ai_usage_tags
+        ),
+        sum(
+          plural_metric(~w(ai cost)a),
+          event_name: metric_scope(:ai_usage),
+          measurement: :cost,
+          description: "The estimated cost in USD of AI proxy requests.",
+          tags: @ai_usage_tags
+        ),
+        sum(
+          plural_metric(~w(ai requests)a),
+          event_name: metric_scope(:ai_usage),
+          measurement: :requests,
+          description: "The number of requests served by the AI proxy.",
+          tags: @ai_usage_tags
         )
       ]
     )
@@ -0,0 +1,52 @@
+defmodule Console.Schema.AiUsageRollup do
+  use Piazza.Ecto.Schema
+  alias Console.Schema.{User, Cluster}
+
+  @nil_id "00000000-0000-0000-0000-000000000000"
+
+  schema "ai_usage_rollups" do
+    field :provider,          :string
+    field :model,             :string
+    field :day,               :date
+    field :prompt_tokens,     :integer, default: 0
+    field :completion_tokens, :integer, default: 0
+    field :cached_tokens,     :integer, default: 0
+    field :cost,              :float, default: 0.0
+    field :requests,          :integer, default: 0
+
+    belongs_to :user,    User
+    belongs_to :cluster, Cluster
+
+    timestamps()
+  end
+
+  @doc """
+  The conflict target of the unique index rollups are upserted on, it must match
+  the index expressions exactly
+  """
+  def conflict_target() do
+    {:unsafe_fragment, "(coalesce(user_id, '#{@nil_id}'), coalesce(cluster_id, '#{@nil_id}'), provider, model, day)"}
+  end
+
+  def for_user(query \\ __MODULE__, user_id) do
+    from(r in query, where: r.user_id == ^user_id)
+  end
+
+  def for_cluster(query \\ __MODULE__, cluster_id) do
+    from(r in query, where: r.cluster_id == ^cluster_id)
+  end
+
+  def ordered(query \\ __MODULE__, order \\ [desc: :day]) do
+    from(r in query, order_by: ^order)
+  end
+
+  @valid ~w(user_id cluster_id provider model day prompt_tokens completion_tokens cached_tokens cost requests)a
+
+  def changeset(model, attrs \\ %{}) do
+    model
+    |> cast(attrs, @valid)
+    |> foreign_key_constraint(:user_id)
+    |> foreign_key_constraint(:cluster_id)
+    |> validate_required(~w(provider model day)a)
+  end
+end
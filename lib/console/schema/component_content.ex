@@ -5,13 +5,14 @@ defmodule Console.Schema.ComponentContent do
   schema "component_contents" do
     field :desired, :binary
     field :live, :binary
+    field :diff, :binary
 
     belongs_to :component, ServiceComponent
 
     timestamps()
   end
 
-  @valid ~w(desired live component_id)a
+  @valid ~w(desired live diff component_id)a
 
   def changeset(model, attrs \\ %{}) do
     model
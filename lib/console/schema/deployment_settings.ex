@@ -348,6 +348,24 @@ defmodule Console.Schema.DeploymentSettings do
         field :input_price,     :float
         field :output_price,    :float
       end
+
+      embeds_one :guardrails, Guardrails, on_replace: :update do
+        field :enabled,         :boolean, default: false
+        field :redact_pii,      :boolean, default: true
+        field :detect_secrets,  :boolean, default: true
+        field :max_prompt_size, :integer
+        field :pii_terms,       {:array, :string}
+
+        embeds_many :pii_patterns, PiiPattern, on_replace: :delete do
+          field :name,    :string
+          field :pattern, :string
+        end
+
+        embeds_many :blocked_topics, BlockedTopic, on_replace: :delete do
+          field :name,     :string
+          field :patterns, {:array, :string}
+        end
+      end
     end
 
     belongs_to :artifact_repository, GitRepository
@@ -435,6 +453,7 @@ defmodule Console.Schema.DeploymentSettings do
     |> cast_embed(:vertex, with: &vertex_changeset/2)
     |> cast_embed(:nexus, with: &nexus_changeset/2)
     |> cast_embed(:price_sheets, with: &price_sheet_changeset/2)
+    |> cast_embed(:guardrails, with: &guardrails_changeset/2)
   end
 
   defp analysis_rates_changeset(model, attrs), do: model |> cast(attrs, ~w(fast slow)a)
@@ -566,6 +585,65 @@ defmodule Console.Schema.DeploymentSettings do
     |> validate_number(:output_price, greater_than_or_equal_to: 0)
   end
 
+  defp guardrails_changeset(model, attrs) do
+    model
+    |> cast(attrs, ~w(enabled redact_pii detect_secrets max_prompt_size pii_terms)a)
+    |> cast_embed(:pii_patterns, with: &pii_pattern_changeset/2)
+    |> cast_embed(:blocked_topics, with: &blocked_topic_changeset/2)
+    |> validate_number(:max_prompt_size, greater_than: 0)
+  end
+
+  defp pii_pattern_changeset(model, attrs) do
+    model
+    |> cast(attrs, ~w(name pattern)a)
+    |> validate_required(~w(name pattern)a)
+    |> validate_format(:name, ~r/^[a-zA-Z0-9_]+$/, message: "must only contain letters, numbers and underscores")
+    |> validate_change(:pattern, &validate_regex/2)
+  end
+
+  defp blocked_topic_changeset(model, attrs) do
+    model
+    |> cast(attrs, ~w(name patterns)a)
+    |> validate_required(~w(name patterns)a)
+    |> validate_change(:patterns, fn :patterns, patterns ->
+      Enum.flat_map(patterns, &validate_regex(:patterns, &1))
+    end)
+  end
+
+  defp validate_regex(field, pattern) do
+    with {:ok, _} <- Regex.compile(pattern),
+         nil <- re2_unsupported(pattern) do
+      []
+    else
+      {:error, _} -> [{field, "#{pattern} is not a valid regex"}]
+      feature -> [{field, "#{pattern} uses #{feature}, which nexus does not support (patterns must be RE2 compatible)"}]
+    end
+  end
+
+  # nexus compiles guardrail patterns with go's RE2 engine, which lacks the PCRE
+  # features below, so they are rejected here rather than failing every AI request
+  @regex_token ~r/\\.|\[\^?\]?(?:\\.|[^\]])*\]|./su
+
+  defp re2_unsupported(pattern) do
+    Regex.scan(@regex_token, pattern)
+    |> Enum.map(&hd/1)
+    |> re2_feature()
+  end
+
+  defp re2_feature(["(", "?", c | _]) when c in ~w(= !), do: "lookarounds"
+  defp re2_feature(["(", "?", "<", c | _]) when c in ~w(= !), do: "lookarounds"
+  defp re2_feature(["(", "?", ">" | _]), do: "atomic groups"
+  defp re2_feature(["(", "?", "#" | _]), do: "inline comments"
+  defp re2_feature(["(", "?", "P", c | _]) when c in ~w(> =), do: "backreferences"
+  defp re2_feature(["(", "?", c | _]) when c in ~w(| \( R & + ') or c in ~w(0 1 2 3 4 5 6 7 8 9),
+    do: "recursion or conditionals"
+  defp re2_feature(["(", "?", "-", c | _]) when c in ~w(0 1 2 3 4 5 6 7 8 9), do: "recursion or conditionals"
+  defp re2_feature(["\\" <> c | _]) when c in ~w(1 2 3 4 5 6 7 8 9 g k), do: "backreferences"
+  defp re2_feature(["\\" <> c | _]) when c in ~w(G K R X Z h H V N), do: "the \\#{c} escape"
+  defp re2_feature([q, "+" | _]) when q in ~w(* + ? }), do: "possessive quantifiers"
+  defp re2_feature([_ | rest]), do: re2_feature(rest)
+  defp re2_feature([]), do: nil
+
   defp set_initialized(changeset) do
     case {get_change(changeset, :store), get_change(changeset, :elastic), get_change(changeset, :opensearch)} do
       {:elastic, e, _} when not is_nil(e) ->
@@ -29,7 +29,7 @@ defmodule Console.Schema.Service do
 
   defenum Promotion, ignore: 0, proceed: 1, rollback: 2
   defenum Status, stale: 0, synced: 1, healthy: 2, failed: 3, paused: 4
-  defenum RendererType, auto: 0, raw: 1, helm: 2, kustomize: 3
+  defenum RendererType, auto: 0, raw: 1, helm: 2, kustomize: 3, jsonnet: 4, cue: 5, kcl: 6
 
   defmodule Git do
     use Piazza.Ecto.Schema
@@ -25,7 +25,7 @@ defmodule Console.Schema.Stack do
     StackPolicy
   }
 
-  defenum Type, terraform: 0, ansible: 1, custom: 2, terragrunt: 3, pulumi: 4
+  defenum Type, terraform: 0, ansible: 1, custom: 2, terragrunt: 3, pulumi: 4, opentofu: 5, cdktf: 6
   defenum Status,
     queued: 0,
     pending: 1,
@@ -91,6 +91,11 @@ defmodule Console.Schema.Stack do
         embeds_one :git, Service.Git, on_replace: :update
         field :file, :string
       end
+
+      embeds_one :cost_estimation, CostEstimation, on_replace: :update do
+        field :enabled,            :boolean, default: false
+        field :approval_threshold, :float
+      end
     end
 
     def changeset(model, attrs \\ %{}) do
@@ -102,6 +107,7 @@ defmodule Console.Schema.Stack do
       |> cast_embed(:pulumi, with: &pulumi_changeset/2)
       |> cast_embed(:ansible, with: &ansible_changeset/2)
       |> cast_embed(:ai_approval, with: &ai_approval_changeset/2)
+      |> cast_embed(:cost_estimation, with: &cost_estimation_changeset/2)
     end
 
     defp hook_changeset(model, attrs) do
@@ -137,13 +143,19 @@ defmodule Console.Schema.Stack do
       |> cast_embed(:git)
       |> validate_required(~w(enabled file)a)
     end
+
+    defp cost_estimation_changeset(model, attrs) do
+      model
+      |> cast(attrs, ~w(enabled approval_threshold)a)
+      |> validate_number(:approval_threshold, greater_than_or_equal_to: 0)
+    end
   end
 
   defmodule PolicyEngine do
     use Piazza.Ecto.Schema
     alias Console.Schema.Service.Git
 
-    defenum Type, trivy: 0
+    defenum Type, trivy: 0, checkov: 1, conftest: 2
 
     embedded_schema do
       field :type,            Type
@@ -35,6 +35,45 @@ defmodule Plrl.AiConfig do
   field :azure, 6, type: Plrl.AzureOpenAiConfig
   field :openaiCompatible, 7, type: Plrl.OpenAiConfig
   field :xai, 8, type: Plrl.OpenAiConfig
+  field :guardrails, 9, type: Plrl.GuardrailsConfig
+end
+
+defmodule Plrl.GuardrailsConfig do
+  @moduledoc false
+
+  use Protobuf,
+    full_name: "plrl.GuardrailsConfig",
+    protoc_gen_elixir_version: "0.16.0",
+    syntax: :proto3
+
+  field :enabled, 1, type: :bool
+  field :redactPii, 2, type: :bool
+  field :detectSecrets, 3, type: :bool
+  field :maxPromptSize, 4, proto3_optional: true, type: :int64
+  field :piiTerms, 5, repeated: true, type: :string
+  field :piiPatterns, 6, repeated: true, type: Plrl.PiiPattern
+  field :blockedTopics, 7, repeated: true, type: Plrl.BlockedTopic
+end
+
+defmodule Plrl.PiiPattern do
+  @moduledoc false
+
+  use Protobuf, full_name: "plrl.PiiPattern", protoc_gen_elixir_version: "0.16.0", syntax: :proto3
+
+  field :name, 1, type: :string
+  field :pattern, 2, type: :string
+end
+
+defmodule Plrl.BlockedTopic do
+  @moduledoc false
+
+  use Protobuf,
+    full_name: "plrl.BlockedTopic",
+    protoc_gen_elixir_version: "0.16.0",
+    syntax: :proto3
+
+  field :name, 1, type: :string
+  field :patterns, 2, repeated: true, type: :string
 end
 
 defmodule Plrl.OpenAiTokenExchange do
@@ -187,6 +226,8 @@ defmodule Plrl.ProxyAuthenticationResponse do
     syntax: :proto3
 
   field :authenticated, 1, type: :bool
+  field :userId, 2, proto3_optional: true, type: :string
+  field :clusterId, 3, proto3_optional: true, type: :string
 end
 
 defmodule Plrl.VerifyClusterRequest do
@@ -210,6 +251,7 @@ defmodule Plrl.VerifyClusterResponse do
 
   field :id, 1, type: :string
   field :name, 2, type: :string
+  field :handle, 3, type: :string
 end
 
 defmodule Plrl.ObservabilityConfig do
@@ -251,6 +293,44 @@ defmodule Plrl.MeterMetricsResponse do
   field :success, 1, type: :bool
 end
 
+defmodule Plrl.AiUsage do
+  @moduledoc false
+
+  use Protobuf, full_name: "plrl.AiUsage", protoc_gen_elixir_version: "0.16.0", syntax: :proto3
+
+  field :userId, 1, proto3_optional: true, type: :string
+  field :clusterId, 2, proto3_optional: true, type: :string
+  field :provider, 3, type: :string
+  field :model, 4, type: :string
+  field :promptTokens, 5, type: :int64
+  field :completionTokens, 6, type: :int64
+  field :cachedTokens, 7, type: :int64
+  field :cost, 8, type: :double
+  field :requests, 9, type: :int64
+end
+
+defmodule Plrl.MeterAiUsageRequest do
+  @moduledoc false
+
+  use Protobuf,
+    full_name: "plrl.MeterAiUsageRequest",
+    protoc_gen_elixir_version: "0.16.0",
+    syntax: :proto3
+
+  field :usage, 1, repeated: true, type: Plrl.AiUsage
+end
+
+defmodule Plrl.MeterAiUsageResponse do
+  @moduledoc false
+
+  use Protobuf,
+    full_name: "plrl.MeterAiUsageResponse",
+    protoc_gen_elixir_version: "0.16.0",
+    syntax: :proto3
+
+  field :success, 1, type: :bool
+end
+
 defmodule Plrl.ObservabilityConfigRequest do
   @moduledoc false
 
@@ -267,6 +347,8 @@ defmodule Plrl.PluralServer.Service do
 
   rpc :MeterMetrics, Plrl.MeterMetricsRequest, Plrl.MeterMetricsResponse
 
+  rpc :MeterAiUsage, Plrl.MeterAiUsageRequest, Plrl.MeterAiUsageResponse
+
   rpc :GetAiConfig, Plrl.AiConfigRequest, Plrl.AiConfig
 
   rpc :GetObservabilityConfig, Plrl.ObservabilityConfigRequest, Plrl.ObservabilityConfig
@@ -0,0 +1,9 @@
+defmodule Console.Repo.Migrations.AddComponentContentDiff do
+  use Ecto.Migration
+
+  def change do
+    alter table(:component_contents) do
+      add :diff, :binary
+    end
+  end
+end
@@ -0,0 +1,34 @@
+defmodule Console.Repo.Migrations.AddAiUsageRollups do
+  use Ecto.Migration
+
+  def change do
+    create table(:ai_usage_rollups, primary_key: false) do
+      add :id,                :uuid, primary_key: true
+      add :user_id,           references(:watchman_users, type: :uuid, on_delete: :delete_all)
+      add :cluster_id,        references(:clusters, type: :uuid, on_delete: :delete_all)
+      add :provider,          :string
+      add :model,             :string
+      add :day,               :date
+      add :prompt_tokens,     :bigint, default: 0
+      add :completion_tokens, :bigint, default: 0
+      add :cached_tokens,     :bigint, default: 0
+      add :cost,              :float, default: 0.0
+      add :requests,          :bigint, default: 0
+
+      timestamps()
+    end
+
+    # callers can be a user or a cluster, coalesce the missing one so usage without it
+    # still conflicts on upsert
+    create unique_index(:ai_usage_rollups, [
+      "coalesce(user_id, '00000000-0000-0000-0000-000000000000')",
+      "coalesce(cluster_id, '00000000-0000-0000-0000-000000000000')",
+      :provider,
+      :model,
+      :day
+    ], name: :ai_usage_rollups_caller_model_day_index)
+    create index(:ai_usage_rollups, [:user_id])
+    create index(:ai_usage_rollups, [:cluster_id])
+    create index(:ai_usage_rollups, [:day])
+  end
+end
@@ -1036,6 +1036,33 @@ defmodule Console.Deployments.ServicesTest do
       assert component.content.desired == "new yaml"
     end
 
+    test "it can persist structured dry run diffs" do
+      service = insert(:service)
+      diff = Jason.encode!(%{
+        manifestChanged: true,
+        drifted: false,
+        changes: [%{path: "/spec/rules/0/host", op: "replace", live: "old.example.com", desired: "new.example.com"}]
+      })
+
+      {:ok, service} = Services.update_components(%{
+        components: [%{
+          state: :running,
+          synced: false,
+          group: "networking.k8s.io",
+          version: "v1",
+          kind: "Ingress",
+          namespace: "my-app",
+          name: "api",
+          content: %{live: "some yaml", desired: "other yaml", diff: diff}
+        }]
+      }, service)
+
+      %{components: [component]} = Console.Repo.preload(service, [components: :content])
+      assert component.content.live == "some yaml"
+      assert component.content.desired == "other yaml"
+      assert Jason.decode!(component.content.diff) == Jason.decode!(diff)
+    end
+
     test "if a component is not synced it will remain stale" do
       service = insert(:service)
 
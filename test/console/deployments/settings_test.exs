@@ -188,6 +188,25 @@ defmodule Console.Deployments.SettingsTest do
 
       {:error, _} = Settings.update(%{write_bindings: [%{user_id: user.id}]}, insert(:user))
     end
+
+    test "guardrail patterns must be RE2 compatible" do
+      admin = admin_user()
+      insert(:deployment_settings)
+      guardrails = fn attrs -> %{ai: %{guardrails: Map.put(attrs, :enabled, true)}} end
+
+      {:ok, updated} = Settings.update(guardrails.(%{
+        pii_patterns: [%{name: "employee_id", pattern: "(?i)EMP-[0-9]{6}"}],
+        blocked_topics: [%{name: "payroll", patterns: ["salar(y|ies)", "\\++"]}]
+      }), admin)
+
+      assert [%{pattern: "(?i)EMP-[0-9]{6}"}] = updated.ai.guardrails.pii_patterns
+
+      for pattern <- ["(?<=EMP-)[0-9]+", "(a)\\1", "a++", "(?>a)"] do
+        {:error, _} = Settings.update(guardrails.(%{pii_patterns: [%{name: "broken", pattern: pattern}]}), admin)
+      end
+
+      {:error, _} = Settings.update(guardrails.(%{blocked_topics: [%{name: "payroll", patterns: ["salary(?!cap)"]}]}), admin)
+    end
   end
 
   describe "#migrate_agents/0" do
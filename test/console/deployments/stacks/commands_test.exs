@@ -46,5 +46,29 @@ defmodule Console.Deployments.Stacks.CommandsTest do
         %{name: "destroy", cmd: "pulumi", args: ["destroy", "--yes"], stage: :destroy}
       ]
     end
+
+    test "uses tofu for opentofu stacks" do
+      commands =
+        %Stack{type: :opentofu}
+        |> Commands.commands()
+
+      assert Enum.map(commands, &Map.take(&1, [:name, :cmd, :args, :stage])) == [
+        %{name: "init", cmd: "tofu", args: ["init", "-upgrade"], stage: :init},
+        %{name: "plan", cmd: "tofu", args: ["plan"], stage: :plan},
+        %{name: "apply", cmd: "tofu", args: ["apply", "terraform.tfplan"], stage: :apply}
+      ]
+    end
+
+    test "uses terraform for synthesized cdktf stacks" do
+      commands =
+        %Stack{type: :cdktf}
+        |> Commands.commands()
+
+      assert Enum.map(commands, &Map.take(&1, [:name, :cmd, :args, :stage])) == [
+        %{name: "init", cmd: "terraform", args: ["init", "-upgrade"], stage: :init},
+        %{name: "plan", cmd: "terraform", args: ["plan"], stage: :plan},
+        %{name: "apply", cmd: "terraform", args: ["apply", "terraform.tfplan"], stage: :apply}
+      ]
+    end
   end
 end
@@ -73,6 +73,42 @@ defmodule Console.GRPC.ServerTest do
       assert config.xai.model == "grok-4.5"
       assert config.xai.toolModel == "grok-4.5"
     end
+
+    test "returns guardrails configuration" do
+      deployment_settings(
+        ai: %{
+          enabled: true,
+          openai: %{access_token: "openai-token"},
+          guardrails: %{
+            enabled: true,
+            redact_pii: true,
+            detect_secrets: false,
+            max_prompt_size: 10_000,
+            pii_terms: ["acme corp"],
+            pii_patterns: [%{name: "employee_id", pattern: "EMP-[0-9]{6}"}],
+            blocked_topics: [%{name: "weapons", patterns: ["explosives?"]}]
+          }
+        }
+      )
+
+      %{guardrails: guardrails} = Server.get_ai_config(%Plrl.AiConfigRequest{}, nil)
+
+      assert guardrails.enabled
+      assert guardrails.redactPii
+      refute guardrails.detectSecrets
+      assert guardrails.maxPromptSize == 10_000
+      assert guardrails.piiTerms == ["acme corp"]
+      assert [%Plrl.PiiPattern{name: "employee_id", pattern: "EMP-[0-9]{6}"}] = guardrails.piiPatterns
+      assert [%Plrl.BlockedTopic{name: "weapons", patterns: ["explosives?"]}] = guardrails.blockedTopics
+    end
+
+    test "omits disabled guardrails" do
+      deployment_settings(ai: %{enabled: true, guardrails: %{enabled: false}})
+
+      config = Server.get_ai_config(%Plrl.AiConfigRequest{}, nil)
+
+      refute config.guardrails
+    end
   end
 
   describe "verify_cluster/2" do
@@ -83,6 +119,7 @@ defmodule Console.GRPC.ServerTest do
 
       assert result.id == cluster.id
       assert result.name == cluster.name
+      assert result.handle == cluster.handle
     end
 
     test "requires a valid cluster access token" do
@@ -94,4 +131,54 @@ defmodule Console.GRPC.ServerTest do
       assert error.status == GRPC.Status.unauthenticated()
     end
   end
+
+  describe "proxy_authentication/2" do
+    test "returns the user identity for a user access token" do
+      user = insert(:user)
+      token = insert(:access_token, user: user)
+
+      result = Server.proxy_authentication(%Plrl.ProxyAuthenticationRequest{token: token.token}, nil)
+
+      assert result.authenticated
+      assert result.userId == user.id
+      refute result.clusterId
+    end
+
+    test "rejects invalid tokens" do
+      result = Server.proxy_authentication(%Plrl.ProxyAuthenticationRequest{token: "console-not-a-token"}, nil)
+
+      refute result.authenticated
+      refute result.userId
+    end
+  end
+
+  describe "meter_ai_usage/2" do
+    test "accumulates usage per caller, model and day" do
+      user = insert(:user)
+      cluster = insert(:cluster)
+      usage = [
+        %Plrl.AiUsage{userId: user.id, provider: "openai", model: "gpt-5", promptTokens: 100, completionTokens: 20, cost: 0.001, requests: 1},
+        %Plrl.AiUsage{userId: user.id, provider: "openai", model: "gpt-5", promptTokens: 50, completionTokens: 10, cost: 0.002, requests: 1},
+        %Plrl.AiUsage{clusterId: cluster.id, provider: "anthropic", model: "claude-sonnet-4-5", promptTokens: 10, completionTokens: 5, requests: 2}
+      ]
+
+      assert %Plrl.MeterAiUsageResponse{success: true} =
+        Server.meter_ai_usage(%Plrl.MeterAiUsageRequest{usage: usage}, nil)
+
+      [user_usage] = Console.Schema.AiUsageRollup.for_user(user.id) |> Console.Repo.all()
+      assert user_usage.model == "gpt-5"
+      assert user_usage.day == Date.utc_today()
+      assert user_usage.prompt_tokens == 150
+      assert user_usage.completion_tokens == 30
+      assert user_usage.requests == 2
+      assert_in_delta user_usage.cost, 0.003, 1.0e-9
+      refute user_usage.cluster_id
+
+      [cluster_usage] = Console.Schema.AiUsageRollup.for_cluster(cluster.id) |> Console.Repo.all()
+      assert cluster_usage.model == "claude-sonnet-4-5"
+      assert cluster_usage.prompt_tokens == 10
+      assert cluster_usage.requests == 2
+      refute cluster_usage.user_id
+    end
+  end
 end